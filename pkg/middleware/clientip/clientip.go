@@ -0,0 +1,177 @@
+// Package clientip는 신뢰하는 프록시를 거친 요청에서 실제 클라이언트 IP를 찾는 Echo 미들웨어를 제공합니다.
+//
+// 요청을 보낸 주소(RemoteAddr)가 신뢰하는 프록시일 때만 Forwarded, X-Forwarded-For, X-Real-IP 헤더를
+// 이 순서로 사용합니다. 프록시 목록 헤더는 오른쪽(가장 가까운 프록시)부터 읽으며, 신뢰하는 프록시가 아닌
+// 첫 번째 주소를 클라이언트로 봅니다. 신뢰하지 않는 클라이언트가 보낸 헤더는 무시하므로 IP를 위조할 수 없습니다.
+package clientip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type contextKey struct{}
+
+// Resolver는 신뢰하는 프록시 목록으로 요청의 클라이언트 IP를 찾습니다
+type Resolver struct {
+	trusted []*net.IPNet
+}
+
+// NewResolver는 신뢰하는 프록시의 CIDR(예: 10.0.0.0/8) 또는 IP 목록으로 Resolver를 생성합니다.
+// 목록이 비어 있으면 헤더를 사용하지 않고 항상 요청을 보낸 주소를 클라이언트 IP로 봅니다.
+func NewResolver(trustedProxies []string) (*Resolver, error) {
+	r := &Resolver{}
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("신뢰하는 프록시 주소가 올바르지 않습니다: %s", proxy)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			r.trusted = append(r.trusted, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("신뢰하는 프록시 CIDR이 올바르지 않습니다: %s: %w", proxy, err)
+		}
+		r.trusted = append(r.trusted, network)
+	}
+	return r, nil
+}
+
+// ClientIP는 요청의 클라이언트 IP를 반환합니다
+func (r *Resolver) ClientIP(req *http.Request) string {
+	remote := parseHost(req.RemoteAddr)
+	if remote == nil {
+		return req.RemoteAddr
+	}
+	if !r.isTrusted(remote) {
+		return remote.String()
+	}
+
+	if hops := forwardedFor(req.Header.Values("Forwarded")); len(hops) > 0 {
+		return r.fromHops(hops, remote).String()
+	}
+	if hops := xForwardedFor(req.Header.Values("X-Forwarded-For")); len(hops) > 0 {
+		return r.fromHops(hops, remote).String()
+	}
+	if ip := parseHost(req.Header.Get("X-Real-IP")); ip != nil {
+		return ip.String()
+	}
+	return remote.String()
+}
+
+// IPExtractor는 Echo의 IPExtractor로 사용할 함수를 반환합니다.
+// echo.Echo.IPExtractor에 설정하면 c.RealIP()와 요청 로그가 이 Resolver의 결과를 사용합니다.
+func (r *Resolver) IPExtractor() echo.IPExtractor {
+	return r.ClientIP
+}
+
+// Middleware는 클라이언트 IP를 요청 context에 담는 미들웨어를 반환합니다. 핸들러 아래 계층에서는 FromContext로 읽습니다.
+func (r *Resolver) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(NewContext(req.Context(), r.ClientIP(req))))
+			return next(c)
+		}
+	}
+}
+
+// NewContext는 클라이언트 IP를 담은 context를 반환합니다
+func NewContext(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, contextKey{}, ip)
+}
+
+// FromContext는 Middleware가 context에 담은 클라이언트 IP를 반환합니다
+func FromContext(ctx context.Context) (string, bool) {
+	ip, ok := ctx.Value(contextKey{}).(string)
+	return ip, ok && ip != ""
+}
+
+func (r *Resolver) isTrusted(ip net.IP) bool {
+	for _, network := range r.trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// fromHops는 프록시 목록을 오른쪽부터 읽어 신뢰하지 않는 첫 번째 주소를 반환합니다.
+// 읽을 수 없는 주소(unknown 등)를 만나면 그 주소를 전달한 프록시를, 모두 신뢰하는 주소이면 가장 왼쪽 주소를 반환합니다.
+func (r *Resolver) fromHops(hops []net.IP, remote net.IP) net.IP {
+	last := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		if hops[i] == nil {
+			return last
+		}
+		if !r.isTrusted(hops[i]) {
+			return hops[i]
+		}
+		last = hops[i]
+	}
+	return last
+}
+
+// forwardedFor는 RFC 7239 Forwarded 헤더의 for 값을 순서대로 반환합니다. for가 없거나 읽을 수 없는 항목은 nil입니다.
+func forwardedFor(values []string) []net.IP {
+	var hops []net.IP
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			if strings.TrimSpace(element) == "" {
+				continue
+			}
+			var ip net.IP
+			for _, pair := range strings.Split(element, ";") {
+				key, v, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(strings.TrimSpace(key), "for") {
+					ip = parseHost(strings.Trim(strings.TrimSpace(v), `"`))
+					break
+				}
+			}
+			hops = append(hops, ip)
+		}
+	}
+	return hops
+}
+
+// xForwardedFor는 X-Forwarded-For 헤더의 주소를 순서대로 반환합니다. 읽을 수 없는 항목은 nil입니다.
+func xForwardedFor(values []string) []net.IP {
+	var hops []net.IP
+	for _, value := range values {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, parseHost(hop))
+			}
+		}
+	}
+	return hops
+}
+
+// parseHost는 IP, IP:포트, [IPv6]:포트, [IPv6] 형식의 주소에서 IP를 읽습니다
+func parseHost(host string) net.IP {
+	host = strings.TrimSpace(host)
+	if ip := net.ParseIP(host); ip != nil {
+		return ip
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"))
+}
@@ -0,0 +1,116 @@
+package clientip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestResolverClientIP(t *testing.T) {
+	resolver, err := NewResolver([]string{"10.0.0.0/8", "192.168.1.1", "fd00::/8"})
+	if err != nil {
+		t.Fatalf("NewResolver() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		remote  string
+		headers map[string]string
+		want    string
+	}{
+		{
+			name:   "직접 연결",
+			remote: "203.0.113.7:51234",
+			want:   "203.0.113.7",
+		},
+		{
+			name:    "신뢰하지 않는 클라이언트의 헤더는 무시",
+			remote:  "203.0.113.7:51234",
+			headers: map[string]string{"X-Forwarded-For": "1.1.1.1", "X-Real-IP": "1.1.1.1"},
+			want:    "203.0.113.7",
+		},
+		{
+			name:    "X-Forwarded-For는 신뢰하지 않는 오른쪽 첫 주소",
+			remote:  "10.0.0.2:443",
+			headers: map[string]string{"X-Forwarded-For": "1.1.1.1, 198.51.100.4, 10.1.2.3"},
+			want:    "198.51.100.4",
+		},
+		{
+			name:    "모두 신뢰하는 프록시이면 가장 왼쪽 주소",
+			remote:  "10.0.0.2:443",
+			headers: map[string]string{"X-Forwarded-For": "192.168.1.1, 10.1.2.3"},
+			want:    "192.168.1.1",
+		},
+		{
+			name:    "읽을 수 없는 주소는 전달한 프록시에서 멈춤",
+			remote:  "10.0.0.2:443",
+			headers: map[string]string{"X-Forwarded-For": "1.1.1.1, garbage, 10.1.2.3"},
+			want:    "10.1.2.3",
+		},
+		{
+			name:   "Forwarded가 X-Forwarded-For보다 우선",
+			remote: "[fd00::1]:443",
+			headers: map[string]string{
+				"Forwarded":       `for="[2001:db8:cafe::17]:4711";proto=https, for=10.0.0.9`,
+				"X-Forwarded-For": "1.1.1.1",
+			},
+			want: "2001:db8:cafe::17",
+		},
+		{
+			name:    "Forwarded의 unknown은 전달한 프록시에서 멈춤",
+			remote:  "10.0.0.2:443",
+			headers: map[string]string{"Forwarded": "for=unknown, for=10.0.0.9"},
+			want:    "10.0.0.9",
+		},
+		{
+			name:    "X-Real-IP",
+			remote:  "10.0.0.2:443",
+			headers: map[string]string{"X-Real-IP": "198.51.100.4"},
+			want:    "198.51.100.4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remote
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			if got := resolver.ClientIP(req); got != tt.want {
+				t.Errorf("ClientIP() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewResolverRejectsInvalidProxy(t *testing.T) {
+	for _, proxy := range []string{"10.0.0.0/33", "not-an-ip"} {
+		if _, err := NewResolver([]string{proxy}); err == nil {
+			t.Errorf("NewResolver(%q) error = nil", proxy)
+		}
+	}
+}
+
+func TestMiddlewareStoresClientIP(t *testing.T) {
+	resolver, _ := NewResolver([]string{"10.0.0.0/8"})
+	e := echo.New()
+	e.IPExtractor = resolver.IPExtractor()
+	e.Use(resolver.Middleware())
+	e.GET("/", func(c echo.Context) error {
+		ip, _ := FromContext(c.Request().Context())
+		return c.String(http.StatusOK, ip+" "+c.RealIP())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.2:443"
+	req.Header.Set("X-Forwarded-For", "198.51.100.4")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if got := rec.Body.String(); got != "198.51.100.4 198.51.100.4" {
+		t.Errorf("body = %q", got)
+	}
+}
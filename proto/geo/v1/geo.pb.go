@@ -21,10 +21,69 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// LookupField는 일괄 조회에서 선택할 수 있는 조회 항목입니다
+type LookupField int32
+
+const (
+	LookupField_LOOKUP_FIELD_UNSPECIFIED LookupField = 0
+	LookupField_LOOKUP_FIELD_CITY        LookupField = 1
+	LookupField_LOOKUP_FIELD_COUNTRY     LookupField = 2
+	LookupField_LOOKUP_FIELD_ASN         LookupField = 3
+	LookupField_LOOKUP_FIELD_ANONYMOUS   LookupField = 4
+)
+
+// Enum value maps for LookupField.
+var (
+	LookupField_name = map[int32]string{
+		0: "LOOKUP_FIELD_UNSPECIFIED",
+		1: "LOOKUP_FIELD_CITY",
+		2: "LOOKUP_FIELD_COUNTRY",
+		3: "LOOKUP_FIELD_ASN",
+		4: "LOOKUP_FIELD_ANONYMOUS",
+	}
+	LookupField_value = map[string]int32{
+		"LOOKUP_FIELD_UNSPECIFIED": 0,
+		"LOOKUP_FIELD_CITY":        1,
+		"LOOKUP_FIELD_COUNTRY":     2,
+		"LOOKUP_FIELD_ASN":         3,
+		"LOOKUP_FIELD_ANONYMOUS":   4,
+	}
+)
+
+func (x LookupField) Enum() *LookupField {
+	p := new(LookupField)
+	*p = x
+	return p
+}
+
+func (x LookupField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LookupField) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_geo_v1_geo_proto_enumTypes[0].Descriptor()
+}
+
+func (LookupField) Type() protoreflect.EnumType {
+	return &file_proto_geo_v1_geo_proto_enumTypes[0]
+}
+
+func (x LookupField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LookupField.Descriptor instead.
+func (LookupField) EnumDescriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{0}
+}
+
 // IpRequest는 IP 주소를 포함하는 요청 메시지입니다
 type IpRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ip            string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Ip    string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	// 이름에 사용할 언어입니다(예: ko, 또는 Accept-Language 형식의 "ko-KR,ko;q=0.9,en;q=0.8").
+	// 비어 있으면 accept-language 메타데이터, 서버 기본 언어 순으로 사용합니다.
+	Language      string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -66,6 +125,13 @@ func (x *IpRequest) GetIp() string {
 	return ""
 }
 
+func (x *IpRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
 // GeoDataResponse는 종합적인 지리 정보를 포함하는 응답 메시지입니다
 type GeoDataResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -83,6 +149,12 @@ type GeoDataResponse struct {
 	IsAnonymous    bool                   `protobuf:"varint,12,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"`
 	IsAnonymousVpn bool                   `protobuf:"varint,13,opt,name=is_anonymous_vpn,json=isAnonymousVpn,proto3" json:"is_anonymous_vpn,omitempty"`
 	IsTorExitNode  bool                   `protobuf:"varint,14,opt,name=is_tor_exit_node,json=isTorExitNode,proto3" json:"is_tor_exit_node,omitempty"`
+	Organization   string                 `protobuf:"bytes,15,opt,name=organization,proto3" json:"organization,omitempty"`
+	Domain         string                 `protobuf:"bytes,16,opt,name=domain,proto3" json:"domain,omitempty"`
+	ConnectionType string                 `protobuf:"bytes,17,opt,name=connection_type,json=connectionType,proto3" json:"connection_type,omitempty"`
+	UserType       string                 `protobuf:"bytes,18,opt,name=user_type,json=userType,proto3" json:"user_type,omitempty"`
+	PostalCode     string                 `protobuf:"bytes,19,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Subdivision    string                 `protobuf:"bytes,20,opt,name=subdivision,proto3" json:"subdivision,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -215,6 +287,48 @@ func (x *GeoDataResponse) GetIsTorExitNode() bool {
 	return false
 }
 
+func (x *GeoDataResponse) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *GeoDataResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *GeoDataResponse) GetConnectionType() string {
+	if x != nil {
+		return x.ConnectionType
+	}
+	return ""
+}
+
+func (x *GeoDataResponse) GetUserType() string {
+	if x != nil {
+		return x.UserType
+	}
+	return ""
+}
+
+func (x *GeoDataResponse) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *GeoDataResponse) GetSubdivision() string {
+	if x != nil {
+		return x.Subdivision
+	}
+	return ""
+}
+
 // CityResponse는 도시 정보를 포함하는 응답 메시지입니다
 type CityResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -451,29 +565,36 @@ func (x *AnonymousResponse) GetFeatureSupport() bool {
 	return false
 }
 
-// CityInfo는 도시 정보를 표현하는 메시지입니다
-type CityInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	GeonameId     uint32                 `protobuf:"varint,1,opt,name=geoname_id,json=geonameId,proto3" json:"geoname_id,omitempty"`
-	Names         map[string]string      `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CityInfo) Reset() {
-	*x = CityInfo{}
+// EnterpriseResponse는 GeoIP2 Enterprise 정보를 포함하는 응답 메시지입니다
+type EnterpriseResponse struct {
+	state              protoimpl.MessageState  `protogen:"open.v1"`
+	City               *CityInfo               `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Country            *CountryInfo            `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	Continent          *ContinentInfo          `protobuf:"bytes,3,opt,name=continent,proto3" json:"continent,omitempty"`
+	Location           *LocationInfo           `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	Traits             *EnterpriseTraits       `protobuf:"bytes,5,opt,name=traits,proto3" json:"traits,omitempty"`
+	Postal             *PostalInfo             `protobuf:"bytes,6,opt,name=postal,proto3" json:"postal,omitempty"`
+	Subdivisions       []*SubdivisionInfo      `protobuf:"bytes,7,rep,name=subdivisions,proto3" json:"subdivisions,omitempty"`
+	RegisteredCountry  *CountryInfo            `protobuf:"bytes,8,opt,name=registered_country,json=registeredCountry,proto3" json:"registered_country,omitempty"`
+	RepresentedCountry *RepresentedCountryInfo `protobuf:"bytes,9,opt,name=represented_country,json=representedCountry,proto3" json:"represented_country,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *EnterpriseResponse) Reset() {
+	*x = EnterpriseResponse{}
 	mi := &file_proto_geo_v1_geo_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CityInfo) String() string {
+func (x *EnterpriseResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CityInfo) ProtoMessage() {}
+func (*EnterpriseResponse) ProtoMessage() {}
 
-func (x *CityInfo) ProtoReflect() protoreflect.Message {
+func (x *EnterpriseResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_geo_v1_geo_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -485,50 +606,101 @@ func (x *CityInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CityInfo.ProtoReflect.Descriptor instead.
-func (*CityInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use EnterpriseResponse.ProtoReflect.Descriptor instead.
+func (*EnterpriseResponse) Descriptor() ([]byte, []int) {
 	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CityInfo) GetGeonameId() uint32 {
+func (x *EnterpriseResponse) GetCity() *CityInfo {
 	if x != nil {
-		return x.GeonameId
+		return x.City
 	}
-	return 0
+	return nil
 }
 
-func (x *CityInfo) GetNames() map[string]string {
+func (x *EnterpriseResponse) GetCountry() *CountryInfo {
 	if x != nil {
-		return x.Names
+		return x.Country
 	}
 	return nil
 }
 
-// CountryInfo는 국가 정보를 표현하는 메시지입니다
-type CountryInfo struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	GeonameId         uint32                 `protobuf:"varint,1,opt,name=geoname_id,json=geonameId,proto3" json:"geoname_id,omitempty"`
-	IsInEuropeanUnion bool                   `protobuf:"varint,2,opt,name=is_in_european_union,json=isInEuropeanUnion,proto3" json:"is_in_european_union,omitempty"`
-	IsoCode           string                 `protobuf:"bytes,3,opt,name=iso_code,json=isoCode,proto3" json:"iso_code,omitempty"`
-	Names             map[string]string      `protobuf:"bytes,4,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *EnterpriseResponse) GetContinent() *ContinentInfo {
+	if x != nil {
+		return x.Continent
+	}
+	return nil
 }
 
-func (x *CountryInfo) Reset() {
-	*x = CountryInfo{}
+func (x *EnterpriseResponse) GetLocation() *LocationInfo {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *EnterpriseResponse) GetTraits() *EnterpriseTraits {
+	if x != nil {
+		return x.Traits
+	}
+	return nil
+}
+
+func (x *EnterpriseResponse) GetPostal() *PostalInfo {
+	if x != nil {
+		return x.Postal
+	}
+	return nil
+}
+
+func (x *EnterpriseResponse) GetSubdivisions() []*SubdivisionInfo {
+	if x != nil {
+		return x.Subdivisions
+	}
+	return nil
+}
+
+func (x *EnterpriseResponse) GetRegisteredCountry() *CountryInfo {
+	if x != nil {
+		return x.RegisteredCountry
+	}
+	return nil
+}
+
+func (x *EnterpriseResponse) GetRepresentedCountry() *RepresentedCountryInfo {
+	if x != nil {
+		return x.RepresentedCountry
+	}
+	return nil
+}
+
+// ISPResponse는 GeoIP2 ISP 정보를 포함하는 응답 메시지입니다
+type ISPResponse struct {
+	state                        protoimpl.MessageState `protogen:"open.v1"`
+	AutonomousSystemNumber       uint32                 `protobuf:"varint,1,opt,name=autonomous_system_number,json=autonomousSystemNumber,proto3" json:"autonomous_system_number,omitempty"`
+	AutonomousSystemOrganization string                 `protobuf:"bytes,2,opt,name=autonomous_system_organization,json=autonomousSystemOrganization,proto3" json:"autonomous_system_organization,omitempty"`
+	Isp                          string                 `protobuf:"bytes,3,opt,name=isp,proto3" json:"isp,omitempty"`
+	MobileCountryCode            string                 `protobuf:"bytes,4,opt,name=mobile_country_code,json=mobileCountryCode,proto3" json:"mobile_country_code,omitempty"`
+	MobileNetworkCode            string                 `protobuf:"bytes,5,opt,name=mobile_network_code,json=mobileNetworkCode,proto3" json:"mobile_network_code,omitempty"`
+	Organization                 string                 `protobuf:"bytes,6,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *ISPResponse) Reset() {
+	*x = ISPResponse{}
 	mi := &file_proto_geo_v1_geo_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CountryInfo) String() string {
+func (x *ISPResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CountryInfo) ProtoMessage() {}
+func (*ISPResponse) ProtoMessage() {}
 
-func (x *CountryInfo) ProtoReflect() protoreflect.Message {
+func (x *ISPResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_geo_v1_geo_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -540,63 +712,75 @@ func (x *CountryInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CountryInfo.ProtoReflect.Descriptor instead.
-func (*CountryInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use ISPResponse.ProtoReflect.Descriptor instead.
+func (*ISPResponse) Descriptor() ([]byte, []int) {
 	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *CountryInfo) GetGeonameId() uint32 {
+func (x *ISPResponse) GetAutonomousSystemNumber() uint32 {
 	if x != nil {
-		return x.GeonameId
+		return x.AutonomousSystemNumber
 	}
 	return 0
 }
 
-func (x *CountryInfo) GetIsInEuropeanUnion() bool {
+func (x *ISPResponse) GetAutonomousSystemOrganization() string {
 	if x != nil {
-		return x.IsInEuropeanUnion
+		return x.AutonomousSystemOrganization
 	}
-	return false
+	return ""
 }
 
-func (x *CountryInfo) GetIsoCode() string {
+func (x *ISPResponse) GetIsp() string {
 	if x != nil {
-		return x.IsoCode
+		return x.Isp
 	}
 	return ""
 }
 
-func (x *CountryInfo) GetNames() map[string]string {
+func (x *ISPResponse) GetMobileCountryCode() string {
 	if x != nil {
-		return x.Names
+		return x.MobileCountryCode
 	}
-	return nil
+	return ""
 }
 
-// ContinentInfo는 대륙 정보를 표현하는 메시지입니다
-type ContinentInfo struct {
+func (x *ISPResponse) GetMobileNetworkCode() string {
+	if x != nil {
+		return x.MobileNetworkCode
+	}
+	return ""
+}
+
+func (x *ISPResponse) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+// DomainResponse는 GeoIP2 Domain 정보를 포함하는 응답 메시지입니다
+type DomainResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	GeonameId     uint32                 `protobuf:"varint,2,opt,name=geoname_id,json=geonameId,proto3" json:"geoname_id,omitempty"`
-	Names         map[string]string      `protobuf:"bytes,3,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ContinentInfo) Reset() {
-	*x = ContinentInfo{}
+func (x *DomainResponse) Reset() {
+	*x = DomainResponse{}
 	mi := &file_proto_geo_v1_geo_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ContinentInfo) String() string {
+func (x *DomainResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ContinentInfo) ProtoMessage() {}
+func (*DomainResponse) ProtoMessage() {}
 
-func (x *ContinentInfo) ProtoReflect() protoreflect.Message {
+func (x *DomainResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_geo_v1_geo_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -608,57 +792,87 @@ func (x *ContinentInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ContinentInfo.ProtoReflect.Descriptor instead.
-func (*ContinentInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use DomainResponse.ProtoReflect.Descriptor instead.
+func (*DomainResponse) Descriptor() ([]byte, []int) {
 	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ContinentInfo) GetCode() string {
+func (x *DomainResponse) GetDomain() string {
 	if x != nil {
-		return x.Code
+		return x.Domain
 	}
 	return ""
 }
 
-func (x *ContinentInfo) GetGeonameId() uint32 {
+// ConnectionTypeResponse는 GeoIP2 Connection Type 정보를 포함하는 응답 메시지입니다
+type ConnectionTypeResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionType string                 `protobuf:"bytes,1,opt,name=connection_type,json=connectionType,proto3" json:"connection_type,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ConnectionTypeResponse) Reset() {
+	*x = ConnectionTypeResponse{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionTypeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionTypeResponse) ProtoMessage() {}
+
+func (x *ConnectionTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[9]
 	if x != nil {
-		return x.GeonameId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ContinentInfo) GetNames() map[string]string {
+// Deprecated: Use ConnectionTypeResponse.ProtoReflect.Descriptor instead.
+func (*ConnectionTypeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConnectionTypeResponse) GetConnectionType() string {
 	if x != nil {
-		return x.Names
+		return x.ConnectionType
 	}
-	return nil
+	return ""
 }
 
-// LocationInfo는 위치 정보를 표현하는 메시지입니다
-type LocationInfo struct {
+// BatchLookupRequest는 일괄 조회 요청 메시지입니다. fields가 비어 있으면 모든 항목을 조회합니다
+type BatchLookupRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Ips           []string               `protobuf:"bytes,1,rep,name=ips,proto3" json:"ips,omitempty"`
+	Fields        []LookupField          `protobuf:"varint,2,rep,packed,name=fields,proto3,enum=geo.LookupField" json:"fields,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LocationInfo) Reset() {
-	*x = LocationInfo{}
-	mi := &file_proto_geo_v1_geo_proto_msgTypes[9]
+func (x *BatchLookupRequest) Reset() {
+	*x = BatchLookupRequest{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LocationInfo) String() string {
+func (x *BatchLookupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LocationInfo) ProtoMessage() {}
+func (*BatchLookupRequest) ProtoMessage() {}
 
-func (x *LocationInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_geo_v1_geo_proto_msgTypes[9]
+func (x *BatchLookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -669,39 +883,989 @@ func (x *LocationInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LocationInfo.ProtoReflect.Descriptor instead.
-func (*LocationInfo) Descriptor() ([]byte, []int) {
-	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use BatchLookupRequest.ProtoReflect.Descriptor instead.
+func (*BatchLookupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *LocationInfo) GetLatitude() float64 {
+func (x *BatchLookupRequest) GetIps() []string {
 	if x != nil {
-		return x.Latitude
+		return x.Ips
 	}
-	return 0
+	return nil
 }
 
-func (x *LocationInfo) GetLongitude() float64 {
+func (x *BatchLookupRequest) GetFields() []LookupField {
 	if x != nil {
-		return x.Longitude
+		return x.Fields
 	}
-	return 0
+	return nil
 }
 
-func (x *LocationInfo) GetTimeZone() string {
+// BatchLookupResponse는 요청 순서대로 IP별 결과를 담는 응답 메시지입니다
+type BatchLookupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*LookupResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchLookupResponse) Reset() {
+	*x = BatchLookupResponse{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchLookupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchLookupResponse) ProtoMessage() {}
+
+func (x *BatchLookupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[11]
 	if x != nil {
-		return x.TimeZone
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchLookupResponse.ProtoReflect.Descriptor instead.
+func (*BatchLookupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchLookupResponse) GetResults() []*LookupResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// StreamLookupRequest는 스트림 조회의 요청 메시지입니다. fields가 비어 있으면 모든 항목을 조회합니다
+type StreamLookupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Fields        []LookupField          `protobuf:"varint,2,rep,packed,name=fields,proto3,enum=geo.LookupField" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamLookupRequest) Reset() {
+	*x = StreamLookupRequest{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamLookupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamLookupRequest) ProtoMessage() {}
+
+func (x *StreamLookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamLookupRequest.ProtoReflect.Descriptor instead.
+func (*StreamLookupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StreamLookupRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *StreamLookupRequest) GetFields() []LookupField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// LookupResult는 IP 하나에 대한 조회 결과입니다. 요청하지 않았거나 실패한 항목은 비어 있으며,
+// error는 IP 자체의 오류, errors는 항목별 오류입니다
+type LookupResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// index는 일괄 조회에서는 ips의 위치, 스트림 조회에서는 요청 메시지의 순번(0부터)입니다
+	Index         uint64             `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Ip            string             `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	City          *CityResponse      `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	Country       *CountryResponse   `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
+	Asn           *ASNResponse       `protobuf:"bytes,5,opt,name=asn,proto3" json:"asn,omitempty"`
+	Anonymous     *AnonymousResponse `protobuf:"bytes,6,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
+	Error         string             `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Errors        map[string]string  `protobuf:"bytes,8,rep,name=errors,proto3" json:"errors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupResult) Reset() {
+	*x = LookupResult{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupResult) ProtoMessage() {}
+
+func (x *LookupResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupResult.ProtoReflect.Descriptor instead.
+func (*LookupResult) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LookupResult) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *LookupResult) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *LookupResult) GetCity() *CityResponse {
+	if x != nil {
+		return x.City
+	}
+	return nil
+}
+
+func (x *LookupResult) GetCountry() *CountryResponse {
+	if x != nil {
+		return x.Country
+	}
+	return nil
+}
+
+func (x *LookupResult) GetAsn() *ASNResponse {
+	if x != nil {
+		return x.Asn
+	}
+	return nil
+}
+
+func (x *LookupResult) GetAnonymous() *AnonymousResponse {
+	if x != nil {
+		return x.Anonymous
+	}
+	return nil
+}
+
+func (x *LookupResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *LookupResult) GetErrors() map[string]string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// GeoFenceRequest는 지역 제한 규칙 평가 요청 메시지입니다
+type GeoFenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleSet       string                 `protobuf:"bytes,1,opt,name=rule_set,json=ruleSet,proto3" json:"rule_set,omitempty"`
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoFenceRequest) Reset() {
+	*x = GeoFenceRequest{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoFenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoFenceRequest) ProtoMessage() {}
+
+func (x *GeoFenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoFenceRequest.ProtoReflect.Descriptor instead.
+func (*GeoFenceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GeoFenceRequest) GetRuleSet() string {
+	if x != nil {
+		return x.RuleSet
+	}
+	return ""
+}
+
+func (x *GeoFenceRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+// GeoFenceResponse는 지역 제한 규칙 평가 결과입니다. matched_rule이 비어 있으면 규칙 집합의 기본 동작을 따른 것입니다
+type GeoFenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleSet       string                 `protobuf:"bytes,1,opt,name=rule_set,json=ruleSet,proto3" json:"rule_set,omitempty"`
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Allowed       bool                   `protobuf:"varint,3,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	MatchedRule   string                 `protobuf:"bytes,5,opt,name=matched_rule,json=matchedRule,proto3" json:"matched_rule,omitempty"`
+	CountryCode   string                 `protobuf:"bytes,6,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	ContinentCode string                 `protobuf:"bytes,7,opt,name=continent_code,json=continentCode,proto3" json:"continent_code,omitempty"`
+	Asn           uint32                 `protobuf:"varint,8,opt,name=asn,proto3" json:"asn,omitempty"`
+	// 규칙에 필요한 지리 정보를 조회하지 못했거나 값이 비어 있어 규칙을 평가할 수 없었으면 true입니다. 이때 규칙 집합의 unknown_action을 따릅니다
+	Unknown       bool `protobuf:"varint,9,opt,name=unknown,proto3" json:"unknown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoFenceResponse) Reset() {
+	*x = GeoFenceResponse{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoFenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoFenceResponse) ProtoMessage() {}
+
+func (x *GeoFenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoFenceResponse.ProtoReflect.Descriptor instead.
+func (*GeoFenceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GeoFenceResponse) GetRuleSet() string {
+	if x != nil {
+		return x.RuleSet
+	}
+	return ""
+}
+
+func (x *GeoFenceResponse) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *GeoFenceResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *GeoFenceResponse) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *GeoFenceResponse) GetMatchedRule() string {
+	if x != nil {
+		return x.MatchedRule
+	}
+	return ""
+}
+
+func (x *GeoFenceResponse) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *GeoFenceResponse) GetContinentCode() string {
+	if x != nil {
+		return x.ContinentCode
+	}
+	return ""
+}
+
+func (x *GeoFenceResponse) GetAsn() uint32 {
+	if x != nil {
+		return x.Asn
+	}
+	return 0
+}
+
+func (x *GeoFenceResponse) GetUnknown() bool {
+	if x != nil {
+		return x.Unknown
+	}
+	return false
+}
+
+// CityInfo는 도시 정보를 표현하는 메시지입니다
+type CityInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GeonameId     uint32                 `protobuf:"varint,1,opt,name=geoname_id,json=geonameId,proto3" json:"geoname_id,omitempty"`
+	Names         map[string]string      `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CityInfo) Reset() {
+	*x = CityInfo{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CityInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityInfo) ProtoMessage() {}
+
+func (x *CityInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityInfo.ProtoReflect.Descriptor instead.
+func (*CityInfo) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CityInfo) GetGeonameId() uint32 {
+	if x != nil {
+		return x.GeonameId
+	}
+	return 0
+}
+
+func (x *CityInfo) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+// CountryInfo는 국가 정보를 표현하는 메시지입니다
+type CountryInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	GeonameId         uint32                 `protobuf:"varint,1,opt,name=geoname_id,json=geonameId,proto3" json:"geoname_id,omitempty"`
+	IsInEuropeanUnion bool                   `protobuf:"varint,2,opt,name=is_in_european_union,json=isInEuropeanUnion,proto3" json:"is_in_european_union,omitempty"`
+	IsoCode           string                 `protobuf:"bytes,3,opt,name=iso_code,json=isoCode,proto3" json:"iso_code,omitempty"`
+	Names             map[string]string      `protobuf:"bytes,4,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CountryInfo) Reset() {
+	*x = CountryInfo{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountryInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryInfo) ProtoMessage() {}
+
+func (x *CountryInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryInfo.ProtoReflect.Descriptor instead.
+func (*CountryInfo) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CountryInfo) GetGeonameId() uint32 {
+	if x != nil {
+		return x.GeonameId
+	}
+	return 0
+}
+
+func (x *CountryInfo) GetIsInEuropeanUnion() bool {
+	if x != nil {
+		return x.IsInEuropeanUnion
+	}
+	return false
+}
+
+func (x *CountryInfo) GetIsoCode() string {
+	if x != nil {
+		return x.IsoCode
+	}
+	return ""
+}
+
+func (x *CountryInfo) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+// ContinentInfo는 대륙 정보를 표현하는 메시지입니다
+type ContinentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	GeonameId     uint32                 `protobuf:"varint,2,opt,name=geoname_id,json=geonameId,proto3" json:"geoname_id,omitempty"`
+	Names         map[string]string      `protobuf:"bytes,3,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContinentInfo) Reset() {
+	*x = ContinentInfo{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContinentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContinentInfo) ProtoMessage() {}
+
+func (x *ContinentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContinentInfo.ProtoReflect.Descriptor instead.
+func (*ContinentInfo) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ContinentInfo) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ContinentInfo) GetGeonameId() uint32 {
+	if x != nil {
+		return x.GeonameId
+	}
+	return 0
+}
+
+func (x *ContinentInfo) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+// LocationInfo는 위치 정보를 표현하는 메시지입니다
+type LocationInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocationInfo) Reset() {
+	*x = LocationInfo{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocationInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocationInfo) ProtoMessage() {}
+
+func (x *LocationInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocationInfo.ProtoReflect.Descriptor instead.
+func (*LocationInfo) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LocationInfo) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *LocationInfo) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *LocationInfo) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// EnterpriseTraits는 Enterprise 수준의 특성 정보를 표현하는 메시지입니다
+type EnterpriseTraits struct {
+	state                        protoimpl.MessageState `protogen:"open.v1"`
+	AutonomousSystemNumber       uint32                 `protobuf:"varint,1,opt,name=autonomous_system_number,json=autonomousSystemNumber,proto3" json:"autonomous_system_number,omitempty"`
+	AutonomousSystemOrganization string                 `protobuf:"bytes,2,opt,name=autonomous_system_organization,json=autonomousSystemOrganization,proto3" json:"autonomous_system_organization,omitempty"`
+	ConnectionType               string                 `protobuf:"bytes,3,opt,name=connection_type,json=connectionType,proto3" json:"connection_type,omitempty"`
+	Domain                       string                 `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`
+	Isp                          string                 `protobuf:"bytes,5,opt,name=isp,proto3" json:"isp,omitempty"`
+	MobileCountryCode            string                 `protobuf:"bytes,6,opt,name=mobile_country_code,json=mobileCountryCode,proto3" json:"mobile_country_code,omitempty"`
+	MobileNetworkCode            string                 `protobuf:"bytes,7,opt,name=mobile_network_code,json=mobileNetworkCode,proto3" json:"mobile_network_code,omitempty"`
+	Organization                 string                 `protobuf:"bytes,8,opt,name=organization,proto3" json:"organization,omitempty"`
+	UserType                     string                 `protobuf:"bytes,9,opt,name=user_type,json=userType,proto3" json:"user_type,omitempty"`
+	StaticIpScore                float64                `protobuf:"fixed64,10,opt,name=static_ip_score,json=staticIpScore,proto3" json:"static_ip_score,omitempty"`
+	IsAnonymousProxy             bool                   `protobuf:"varint,11,opt,name=is_anonymous_proxy,json=isAnonymousProxy,proto3" json:"is_anonymous_proxy,omitempty"`
+	IsAnycast                    bool                   `protobuf:"varint,12,opt,name=is_anycast,json=isAnycast,proto3" json:"is_anycast,omitempty"`
+	IsLegitimateProxy            bool                   `protobuf:"varint,13,opt,name=is_legitimate_proxy,json=isLegitimateProxy,proto3" json:"is_legitimate_proxy,omitempty"`
+	IsSatelliteProvider          bool                   `protobuf:"varint,14,opt,name=is_satellite_provider,json=isSatelliteProvider,proto3" json:"is_satellite_provider,omitempty"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *EnterpriseTraits) Reset() {
+	*x = EnterpriseTraits{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnterpriseTraits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnterpriseTraits) ProtoMessage() {}
+
+func (x *EnterpriseTraits) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnterpriseTraits.ProtoReflect.Descriptor instead.
+func (*EnterpriseTraits) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *EnterpriseTraits) GetAutonomousSystemNumber() uint32 {
+	if x != nil {
+		return x.AutonomousSystemNumber
+	}
+	return 0
+}
+
+func (x *EnterpriseTraits) GetAutonomousSystemOrganization() string {
+	if x != nil {
+		return x.AutonomousSystemOrganization
+	}
+	return ""
+}
+
+func (x *EnterpriseTraits) GetConnectionType() string {
+	if x != nil {
+		return x.ConnectionType
+	}
+	return ""
+}
+
+func (x *EnterpriseTraits) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *EnterpriseTraits) GetIsp() string {
+	if x != nil {
+		return x.Isp
+	}
+	return ""
+}
+
+func (x *EnterpriseTraits) GetMobileCountryCode() string {
+	if x != nil {
+		return x.MobileCountryCode
+	}
+	return ""
+}
+
+func (x *EnterpriseTraits) GetMobileNetworkCode() string {
+	if x != nil {
+		return x.MobileNetworkCode
+	}
+	return ""
+}
+
+func (x *EnterpriseTraits) GetOrganization() string {
+	if x != nil {
+		return x.Organization
+	}
+	return ""
+}
+
+func (x *EnterpriseTraits) GetUserType() string {
+	if x != nil {
+		return x.UserType
+	}
+	return ""
+}
+
+func (x *EnterpriseTraits) GetStaticIpScore() float64 {
+	if x != nil {
+		return x.StaticIpScore
+	}
+	return 0
+}
+
+func (x *EnterpriseTraits) GetIsAnonymousProxy() bool {
+	if x != nil {
+		return x.IsAnonymousProxy
+	}
+	return false
+}
+
+func (x *EnterpriseTraits) GetIsAnycast() bool {
+	if x != nil {
+		return x.IsAnycast
+	}
+	return false
+}
+
+func (x *EnterpriseTraits) GetIsLegitimateProxy() bool {
+	if x != nil {
+		return x.IsLegitimateProxy
+	}
+	return false
+}
+
+func (x *EnterpriseTraits) GetIsSatelliteProvider() bool {
+	if x != nil {
+		return x.IsSatelliteProvider
+	}
+	return false
+}
+
+// PostalInfo는 우편 정보를 표현하는 메시지입니다
+type PostalInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Confidence    uint32                 `protobuf:"varint,2,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostalInfo) Reset() {
+	*x = PostalInfo{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostalInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostalInfo) ProtoMessage() {}
+
+func (x *PostalInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostalInfo.ProtoReflect.Descriptor instead.
+func (*PostalInfo) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PostalInfo) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *PostalInfo) GetConfidence() uint32 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+// SubdivisionInfo는 행정구역 정보를 표현하는 메시지입니다
+type SubdivisionInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GeonameId     uint32                 `protobuf:"varint,1,opt,name=geoname_id,json=geonameId,proto3" json:"geoname_id,omitempty"`
+	IsoCode       string                 `protobuf:"bytes,2,opt,name=iso_code,json=isoCode,proto3" json:"iso_code,omitempty"`
+	Names         map[string]string      `protobuf:"bytes,3,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Confidence    uint32                 `protobuf:"varint,4,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubdivisionInfo) Reset() {
+	*x = SubdivisionInfo{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubdivisionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubdivisionInfo) ProtoMessage() {}
+
+func (x *SubdivisionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubdivisionInfo.ProtoReflect.Descriptor instead.
+func (*SubdivisionInfo) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SubdivisionInfo) GetGeonameId() uint32 {
+	if x != nil {
+		return x.GeonameId
+	}
+	return 0
+}
+
+func (x *SubdivisionInfo) GetIsoCode() string {
+	if x != nil {
+		return x.IsoCode
+	}
+	return ""
+}
+
+func (x *SubdivisionInfo) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *SubdivisionInfo) GetConfidence() uint32 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+// RepresentedCountryInfo는 대표 국가 정보를 표현하는 메시지입니다
+type RepresentedCountryInfo struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	GeonameId         uint32                 `protobuf:"varint,1,opt,name=geoname_id,json=geonameId,proto3" json:"geoname_id,omitempty"`
+	IsInEuropeanUnion bool                   `protobuf:"varint,2,opt,name=is_in_european_union,json=isInEuropeanUnion,proto3" json:"is_in_european_union,omitempty"`
+	IsoCode           string                 `protobuf:"bytes,3,opt,name=iso_code,json=isoCode,proto3" json:"iso_code,omitempty"`
+	Names             map[string]string      `protobuf:"bytes,4,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Type              string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RepresentedCountryInfo) Reset() {
+	*x = RepresentedCountryInfo{}
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepresentedCountryInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepresentedCountryInfo) ProtoMessage() {}
+
+func (x *RepresentedCountryInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_geo_v1_geo_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepresentedCountryInfo.ProtoReflect.Descriptor instead.
+func (*RepresentedCountryInfo) Descriptor() ([]byte, []int) {
+	return file_proto_geo_v1_geo_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RepresentedCountryInfo) GetGeonameId() uint32 {
+	if x != nil {
+		return x.GeonameId
+	}
+	return 0
+}
+
+func (x *RepresentedCountryInfo) GetIsInEuropeanUnion() bool {
+	if x != nil {
+		return x.IsInEuropeanUnion
+	}
+	return false
+}
+
+func (x *RepresentedCountryInfo) GetIsoCode() string {
+	if x != nil {
+		return x.IsoCode
+	}
+	return ""
+}
+
+func (x *RepresentedCountryInfo) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *RepresentedCountryInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
 
 var File_proto_geo_v1_geo_proto protoreflect.FileDescriptor
 
 const file_proto_geo_v1_geo_proto_rawDesc = "" +
 	"\n" +
-	"\x16proto/geo/v1/geo.proto\x12\x03geo\"\x1b\n" +
+	"\x16proto/geo/v1/geo.proto\x12\x03geo\"7\n" +
 	"\tIpRequest\x12\x0e\n" +
-	"\x02ip\x18\x01 \x01(\tR\x02ip\"\xbd\x03\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\"\x82\x05\n" +
 	"\x0fGeoDataResponse\x12\x1d\n" +
 	"\n" +
 	"ip_address\x18\x01 \x01(\tR\tipAddress\x12\x12\n" +
@@ -718,7 +1882,14 @@ const file_proto_geo_v1_geo_proto_rawDesc = "" +
 	"\bis_valid\x18\v \x01(\bR\aisValid\x12!\n" +
 	"\fis_anonymous\x18\f \x01(\bR\visAnonymous\x12(\n" +
 	"\x10is_anonymous_vpn\x18\r \x01(\bR\x0eisAnonymousVpn\x12'\n" +
-	"\x10is_tor_exit_node\x18\x0e \x01(\bR\risTorExitNode\"\xbe\x01\n" +
+	"\x10is_tor_exit_node\x18\x0e \x01(\bR\risTorExitNode\x12\"\n" +
+	"\forganization\x18\x0f \x01(\tR\forganization\x12\x16\n" +
+	"\x06domain\x18\x10 \x01(\tR\x06domain\x12'\n" +
+	"\x0fconnection_type\x18\x11 \x01(\tR\x0econnectionType\x12\x1b\n" +
+	"\tuser_type\x18\x12 \x01(\tR\buserType\x12\x1f\n" +
+	"\vpostal_code\x18\x13 \x01(\tR\n" +
+	"postalCode\x12 \n" +
+	"\vsubdivision\x18\x14 \x01(\tR\vsubdivision\"\xbe\x01\n" +
 	"\fCityResponse\x12!\n" +
 	"\x04city\x18\x01 \x01(\v2\r.geo.CityInfoR\x04city\x12*\n" +
 	"\acountry\x18\x02 \x01(\v2\x10.geo.CountryInfoR\acountry\x120\n" +
@@ -733,7 +1904,61 @@ const file_proto_geo_v1_geo_proto_rawDesc = "" +
 	"\x11AnonymousResponse\x12!\n" +
 	"\fis_anonymous\x18\x01 \x01(\bR\visAnonymous\x12'\n" +
 	"\x10is_tor_exit_node\x18\x02 \x01(\bR\risTorExitNode\x12'\n" +
-	"\x0ffeature_support\x18\x03 \x01(\bR\x0efeatureSupport\"\x93\x01\n" +
+	"\x0ffeature_support\x18\x03 \x01(\bR\x0efeatureSupport\"\xe5\x03\n" +
+	"\x12EnterpriseResponse\x12!\n" +
+	"\x04city\x18\x01 \x01(\v2\r.geo.CityInfoR\x04city\x12*\n" +
+	"\acountry\x18\x02 \x01(\v2\x10.geo.CountryInfoR\acountry\x120\n" +
+	"\tcontinent\x18\x03 \x01(\v2\x12.geo.ContinentInfoR\tcontinent\x12-\n" +
+	"\blocation\x18\x04 \x01(\v2\x11.geo.LocationInfoR\blocation\x12-\n" +
+	"\x06traits\x18\x05 \x01(\v2\x15.geo.EnterpriseTraitsR\x06traits\x12'\n" +
+	"\x06postal\x18\x06 \x01(\v2\x0f.geo.PostalInfoR\x06postal\x128\n" +
+	"\fsubdivisions\x18\a \x03(\v2\x14.geo.SubdivisionInfoR\fsubdivisions\x12?\n" +
+	"\x12registered_country\x18\b \x01(\v2\x10.geo.CountryInfoR\x11registeredCountry\x12L\n" +
+	"\x13represented_country\x18\t \x01(\v2\x1b.geo.RepresentedCountryInfoR\x12representedCountry\"\xa3\x02\n" +
+	"\vISPResponse\x128\n" +
+	"\x18autonomous_system_number\x18\x01 \x01(\rR\x16autonomousSystemNumber\x12D\n" +
+	"\x1eautonomous_system_organization\x18\x02 \x01(\tR\x1cautonomousSystemOrganization\x12\x10\n" +
+	"\x03isp\x18\x03 \x01(\tR\x03isp\x12.\n" +
+	"\x13mobile_country_code\x18\x04 \x01(\tR\x11mobileCountryCode\x12.\n" +
+	"\x13mobile_network_code\x18\x05 \x01(\tR\x11mobileNetworkCode\x12\"\n" +
+	"\forganization\x18\x06 \x01(\tR\forganization\"(\n" +
+	"\x0eDomainResponse\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\"A\n" +
+	"\x16ConnectionTypeResponse\x12'\n" +
+	"\x0fconnection_type\x18\x01 \x01(\tR\x0econnectionType\"P\n" +
+	"\x12BatchLookupRequest\x12\x10\n" +
+	"\x03ips\x18\x01 \x03(\tR\x03ips\x12(\n" +
+	"\x06fields\x18\x02 \x03(\x0e2\x10.geo.LookupFieldR\x06fields\"B\n" +
+	"\x13BatchLookupResponse\x12+\n" +
+	"\aresults\x18\x01 \x03(\v2\x11.geo.LookupResultR\aresults\"O\n" +
+	"\x13StreamLookupRequest\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12(\n" +
+	"\x06fields\x18\x02 \x03(\x0e2\x10.geo.LookupFieldR\x06fields\"\xed\x02\n" +
+	"\fLookupResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x04R\x05index\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12%\n" +
+	"\x04city\x18\x03 \x01(\v2\x11.geo.CityResponseR\x04city\x12.\n" +
+	"\acountry\x18\x04 \x01(\v2\x14.geo.CountryResponseR\acountry\x12\"\n" +
+	"\x03asn\x18\x05 \x01(\v2\x10.geo.ASNResponseR\x03asn\x124\n" +
+	"\tanonymous\x18\x06 \x01(\v2\x16.geo.AnonymousResponseR\tanonymous\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x125\n" +
+	"\x06errors\x18\b \x03(\v2\x1d.geo.LookupResult.ErrorsEntryR\x06errors\x1a9\n" +
+	"\vErrorsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"<\n" +
+	"\x0fGeoFenceRequest\x12\x19\n" +
+	"\brule_set\x18\x01 \x01(\tR\aruleSet\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\"\x88\x02\n" +
+	"\x10GeoFenceResponse\x12\x19\n" +
+	"\brule_set\x18\x01 \x01(\tR\aruleSet\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x18\n" +
+	"\aallowed\x18\x03 \x01(\bR\aallowed\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12!\n" +
+	"\fmatched_rule\x18\x05 \x01(\tR\vmatchedRule\x12!\n" +
+	"\fcountry_code\x18\x06 \x01(\tR\vcountryCode\x12%\n" +
+	"\x0econtinent_code\x18\a \x01(\tR\rcontinentCode\x12\x10\n" +
+	"\x03asn\x18\b \x01(\rR\x03asn\x12\x18\n" +
+	"\aunknown\x18\t \x01(\bR\aunknown\"\x93\x01\n" +
 	"\bCityInfo\x12\x1d\n" +
 	"\n" +
 	"geoname_id\x18\x01 \x01(\rR\tgeonameId\x12.\n" +
@@ -764,7 +1989,59 @@ const file_proto_geo_v1_geo_proto_rawDesc = "" +
 	"\fLocationInfo\x12\x1a\n" +
 	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
 	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1b\n" +
-	"\ttime_zone\x18\x03 \x01(\tR\btimeZone2\xa0\x02\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\"\xdf\x04\n" +
+	"\x10EnterpriseTraits\x128\n" +
+	"\x18autonomous_system_number\x18\x01 \x01(\rR\x16autonomousSystemNumber\x12D\n" +
+	"\x1eautonomous_system_organization\x18\x02 \x01(\tR\x1cautonomousSystemOrganization\x12'\n" +
+	"\x0fconnection_type\x18\x03 \x01(\tR\x0econnectionType\x12\x16\n" +
+	"\x06domain\x18\x04 \x01(\tR\x06domain\x12\x10\n" +
+	"\x03isp\x18\x05 \x01(\tR\x03isp\x12.\n" +
+	"\x13mobile_country_code\x18\x06 \x01(\tR\x11mobileCountryCode\x12.\n" +
+	"\x13mobile_network_code\x18\a \x01(\tR\x11mobileNetworkCode\x12\"\n" +
+	"\forganization\x18\b \x01(\tR\forganization\x12\x1b\n" +
+	"\tuser_type\x18\t \x01(\tR\buserType\x12&\n" +
+	"\x0fstatic_ip_score\x18\n" +
+	" \x01(\x01R\rstaticIpScore\x12,\n" +
+	"\x12is_anonymous_proxy\x18\v \x01(\bR\x10isAnonymousProxy\x12\x1d\n" +
+	"\n" +
+	"is_anycast\x18\f \x01(\bR\tisAnycast\x12.\n" +
+	"\x13is_legitimate_proxy\x18\r \x01(\bR\x11isLegitimateProxy\x122\n" +
+	"\x15is_satellite_provider\x18\x0e \x01(\bR\x13isSatelliteProvider\"@\n" +
+	"\n" +
+	"PostalInfo\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x02 \x01(\rR\n" +
+	"confidence\"\xdc\x01\n" +
+	"\x0fSubdivisionInfo\x12\x1d\n" +
+	"\n" +
+	"geoname_id\x18\x01 \x01(\rR\tgeonameId\x12\x19\n" +
+	"\biso_code\x18\x02 \x01(\tR\aisoCode\x125\n" +
+	"\x05names\x18\x03 \x03(\v2\x1f.geo.SubdivisionInfo.NamesEntryR\x05names\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x04 \x01(\rR\n" +
+	"confidence\x1a8\n" +
+	"\n" +
+	"NamesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8f\x02\n" +
+	"\x16RepresentedCountryInfo\x12\x1d\n" +
+	"\n" +
+	"geoname_id\x18\x01 \x01(\rR\tgeonameId\x12/\n" +
+	"\x14is_in_european_union\x18\x02 \x01(\bR\x11isInEuropeanUnion\x12\x19\n" +
+	"\biso_code\x18\x03 \x01(\tR\aisoCode\x12<\n" +
+	"\x05names\x18\x04 \x03(\v2&.geo.RepresentedCountryInfo.NamesEntryR\x05names\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x1a8\n" +
+	"\n" +
+	"NamesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*\x8e\x01\n" +
+	"\vLookupField\x12\x1c\n" +
+	"\x18LOOKUP_FIELD_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11LOOKUP_FIELD_CITY\x10\x01\x12\x18\n" +
+	"\x14LOOKUP_FIELD_COUNTRY\x10\x02\x12\x14\n" +
+	"\x10LOOKUP_FIELD_ASN\x10\x03\x12\x1a\n" +
+	"\x16LOOKUP_FIELD_ANONYMOUS\x10\x042\xdc\x05\n" +
 	"\n" +
 	"GeoService\x124\n" +
 	"\n" +
@@ -773,7 +2050,15 @@ const file_proto_geo_v1_geo_proto_rawDesc = "" +
 	"\x0eGetCountryInfo\x12\x0e.geo.IpRequest\x1a\x14.geo.CountryResponse\"\x00\x120\n" +
 	"\n" +
 	"GetASNInfo\x12\x0e.geo.IpRequest\x1a\x10.geo.ASNResponse\"\x00\x12<\n" +
-	"\x10CheckAnonymousIP\x12\x0e.geo.IpRequest\x1a\x16.geo.AnonymousResponse\"\x00BaZ_github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/adapter/handler/grpc/protob\x06proto3"
+	"\x10CheckAnonymousIP\x12\x0e.geo.IpRequest\x1a\x16.geo.AnonymousResponse\"\x00\x12>\n" +
+	"\x11GetEnterpriseInfo\x12\x0e.geo.IpRequest\x1a\x17.geo.EnterpriseResponse\"\x00\x120\n" +
+	"\n" +
+	"GetISPInfo\x12\x0e.geo.IpRequest\x1a\x10.geo.ISPResponse\"\x00\x126\n" +
+	"\rGetDomainInfo\x12\x0e.geo.IpRequest\x1a\x13.geo.DomainResponse\"\x00\x12F\n" +
+	"\x15GetConnectionTypeInfo\x12\x0e.geo.IpRequest\x1a\x1b.geo.ConnectionTypeResponse\"\x00\x12B\n" +
+	"\vBatchLookup\x12\x17.geo.BatchLookupRequest\x1a\x18.geo.BatchLookupResponse\"\x00\x12A\n" +
+	"\fStreamLookup\x12\x18.geo.StreamLookupRequest\x1a\x11.geo.LookupResult\"\x00(\x010\x01\x12A\n" +
+	"\x10EvaluateGeoFence\x12\x14.geo.GeoFenceRequest\x1a\x15.geo.GeoFenceResponse\"\x00BaZ_github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/adapter/handler/grpc/protob\x06proto3"
 
 var (
 	file_proto_geo_v1_geo_proto_rawDescOnce sync.Once
@@ -787,47 +2072,99 @@ func file_proto_geo_v1_geo_proto_rawDescGZIP() []byte {
 	return file_proto_geo_v1_geo_proto_rawDescData
 }
 
-var file_proto_geo_v1_geo_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_geo_v1_geo_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_geo_v1_geo_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_proto_geo_v1_geo_proto_goTypes = []any{
-	(*IpRequest)(nil),         // 0: geo.IpRequest
-	(*GeoDataResponse)(nil),   // 1: geo.GeoDataResponse
-	(*CityResponse)(nil),      // 2: geo.CityResponse
-	(*CountryResponse)(nil),   // 3: geo.CountryResponse
-	(*ASNResponse)(nil),       // 4: geo.ASNResponse
-	(*AnonymousResponse)(nil), // 5: geo.AnonymousResponse
-	(*CityInfo)(nil),          // 6: geo.CityInfo
-	(*CountryInfo)(nil),       // 7: geo.CountryInfo
-	(*ContinentInfo)(nil),     // 8: geo.ContinentInfo
-	(*LocationInfo)(nil),      // 9: geo.LocationInfo
-	nil,                       // 10: geo.CityInfo.NamesEntry
-	nil,                       // 11: geo.CountryInfo.NamesEntry
-	nil,                       // 12: geo.ContinentInfo.NamesEntry
+	(LookupField)(0),               // 0: geo.LookupField
+	(*IpRequest)(nil),              // 1: geo.IpRequest
+	(*GeoDataResponse)(nil),        // 2: geo.GeoDataResponse
+	(*CityResponse)(nil),           // 3: geo.CityResponse
+	(*CountryResponse)(nil),        // 4: geo.CountryResponse
+	(*ASNResponse)(nil),            // 5: geo.ASNResponse
+	(*AnonymousResponse)(nil),      // 6: geo.AnonymousResponse
+	(*EnterpriseResponse)(nil),     // 7: geo.EnterpriseResponse
+	(*ISPResponse)(nil),            // 8: geo.ISPResponse
+	(*DomainResponse)(nil),         // 9: geo.DomainResponse
+	(*ConnectionTypeResponse)(nil), // 10: geo.ConnectionTypeResponse
+	(*BatchLookupRequest)(nil),     // 11: geo.BatchLookupRequest
+	(*BatchLookupResponse)(nil),    // 12: geo.BatchLookupResponse
+	(*StreamLookupRequest)(nil),    // 13: geo.StreamLookupRequest
+	(*LookupResult)(nil),           // 14: geo.LookupResult
+	(*GeoFenceRequest)(nil),        // 15: geo.GeoFenceRequest
+	(*GeoFenceResponse)(nil),       // 16: geo.GeoFenceResponse
+	(*CityInfo)(nil),               // 17: geo.CityInfo
+	(*CountryInfo)(nil),            // 18: geo.CountryInfo
+	(*ContinentInfo)(nil),          // 19: geo.ContinentInfo
+	(*LocationInfo)(nil),           // 20: geo.LocationInfo
+	(*EnterpriseTraits)(nil),       // 21: geo.EnterpriseTraits
+	(*PostalInfo)(nil),             // 22: geo.PostalInfo
+	(*SubdivisionInfo)(nil),        // 23: geo.SubdivisionInfo
+	(*RepresentedCountryInfo)(nil), // 24: geo.RepresentedCountryInfo
+	nil,                            // 25: geo.LookupResult.ErrorsEntry
+	nil,                            // 26: geo.CityInfo.NamesEntry
+	nil,                            // 27: geo.CountryInfo.NamesEntry
+	nil,                            // 28: geo.ContinentInfo.NamesEntry
+	nil,                            // 29: geo.SubdivisionInfo.NamesEntry
+	nil,                            // 30: geo.RepresentedCountryInfo.NamesEntry
 }
 var file_proto_geo_v1_geo_proto_depIdxs = []int32{
-	6,  // 0: geo.CityResponse.city:type_name -> geo.CityInfo
-	7,  // 1: geo.CityResponse.country:type_name -> geo.CountryInfo
-	8,  // 2: geo.CityResponse.continent:type_name -> geo.ContinentInfo
-	9,  // 3: geo.CityResponse.location:type_name -> geo.LocationInfo
-	7,  // 4: geo.CountryResponse.country:type_name -> geo.CountryInfo
-	8,  // 5: geo.CountryResponse.continent:type_name -> geo.ContinentInfo
-	10, // 6: geo.CityInfo.names:type_name -> geo.CityInfo.NamesEntry
-	11, // 7: geo.CountryInfo.names:type_name -> geo.CountryInfo.NamesEntry
-	12, // 8: geo.ContinentInfo.names:type_name -> geo.ContinentInfo.NamesEntry
-	0,  // 9: geo.GeoService.GetGeoData:input_type -> geo.IpRequest
-	0,  // 10: geo.GeoService.GetCityInfo:input_type -> geo.IpRequest
-	0,  // 11: geo.GeoService.GetCountryInfo:input_type -> geo.IpRequest
-	0,  // 12: geo.GeoService.GetASNInfo:input_type -> geo.IpRequest
-	0,  // 13: geo.GeoService.CheckAnonymousIP:input_type -> geo.IpRequest
-	1,  // 14: geo.GeoService.GetGeoData:output_type -> geo.GeoDataResponse
-	2,  // 15: geo.GeoService.GetCityInfo:output_type -> geo.CityResponse
-	3,  // 16: geo.GeoService.GetCountryInfo:output_type -> geo.CountryResponse
-	4,  // 17: geo.GeoService.GetASNInfo:output_type -> geo.ASNResponse
-	5,  // 18: geo.GeoService.CheckAnonymousIP:output_type -> geo.AnonymousResponse
-	14, // [14:19] is the sub-list for method output_type
-	9,  // [9:14] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	17, // 0: geo.CityResponse.city:type_name -> geo.CityInfo
+	18, // 1: geo.CityResponse.country:type_name -> geo.CountryInfo
+	19, // 2: geo.CityResponse.continent:type_name -> geo.ContinentInfo
+	20, // 3: geo.CityResponse.location:type_name -> geo.LocationInfo
+	18, // 4: geo.CountryResponse.country:type_name -> geo.CountryInfo
+	19, // 5: geo.CountryResponse.continent:type_name -> geo.ContinentInfo
+	17, // 6: geo.EnterpriseResponse.city:type_name -> geo.CityInfo
+	18, // 7: geo.EnterpriseResponse.country:type_name -> geo.CountryInfo
+	19, // 8: geo.EnterpriseResponse.continent:type_name -> geo.ContinentInfo
+	20, // 9: geo.EnterpriseResponse.location:type_name -> geo.LocationInfo
+	21, // 10: geo.EnterpriseResponse.traits:type_name -> geo.EnterpriseTraits
+	22, // 11: geo.EnterpriseResponse.postal:type_name -> geo.PostalInfo
+	23, // 12: geo.EnterpriseResponse.subdivisions:type_name -> geo.SubdivisionInfo
+	18, // 13: geo.EnterpriseResponse.registered_country:type_name -> geo.CountryInfo
+	24, // 14: geo.EnterpriseResponse.represented_country:type_name -> geo.RepresentedCountryInfo
+	0,  // 15: geo.BatchLookupRequest.fields:type_name -> geo.LookupField
+	14, // 16: geo.BatchLookupResponse.results:type_name -> geo.LookupResult
+	0,  // 17: geo.StreamLookupRequest.fields:type_name -> geo.LookupField
+	3,  // 18: geo.LookupResult.city:type_name -> geo.CityResponse
+	4,  // 19: geo.LookupResult.country:type_name -> geo.CountryResponse
+	5,  // 20: geo.LookupResult.asn:type_name -> geo.ASNResponse
+	6,  // 21: geo.LookupResult.anonymous:type_name -> geo.AnonymousResponse
+	25, // 22: geo.LookupResult.errors:type_name -> geo.LookupResult.ErrorsEntry
+	26, // 23: geo.CityInfo.names:type_name -> geo.CityInfo.NamesEntry
+	27, // 24: geo.CountryInfo.names:type_name -> geo.CountryInfo.NamesEntry
+	28, // 25: geo.ContinentInfo.names:type_name -> geo.ContinentInfo.NamesEntry
+	29, // 26: geo.SubdivisionInfo.names:type_name -> geo.SubdivisionInfo.NamesEntry
+	30, // 27: geo.RepresentedCountryInfo.names:type_name -> geo.RepresentedCountryInfo.NamesEntry
+	1,  // 28: geo.GeoService.GetGeoData:input_type -> geo.IpRequest
+	1,  // 29: geo.GeoService.GetCityInfo:input_type -> geo.IpRequest
+	1,  // 30: geo.GeoService.GetCountryInfo:input_type -> geo.IpRequest
+	1,  // 31: geo.GeoService.GetASNInfo:input_type -> geo.IpRequest
+	1,  // 32: geo.GeoService.CheckAnonymousIP:input_type -> geo.IpRequest
+	1,  // 33: geo.GeoService.GetEnterpriseInfo:input_type -> geo.IpRequest
+	1,  // 34: geo.GeoService.GetISPInfo:input_type -> geo.IpRequest
+	1,  // 35: geo.GeoService.GetDomainInfo:input_type -> geo.IpRequest
+	1,  // 36: geo.GeoService.GetConnectionTypeInfo:input_type -> geo.IpRequest
+	11, // 37: geo.GeoService.BatchLookup:input_type -> geo.BatchLookupRequest
+	13, // 38: geo.GeoService.StreamLookup:input_type -> geo.StreamLookupRequest
+	15, // 39: geo.GeoService.EvaluateGeoFence:input_type -> geo.GeoFenceRequest
+	2,  // 40: geo.GeoService.GetGeoData:output_type -> geo.GeoDataResponse
+	3,  // 41: geo.GeoService.GetCityInfo:output_type -> geo.CityResponse
+	4,  // 42: geo.GeoService.GetCountryInfo:output_type -> geo.CountryResponse
+	5,  // 43: geo.GeoService.GetASNInfo:output_type -> geo.ASNResponse
+	6,  // 44: geo.GeoService.CheckAnonymousIP:output_type -> geo.AnonymousResponse
+	7,  // 45: geo.GeoService.GetEnterpriseInfo:output_type -> geo.EnterpriseResponse
+	8,  // 46: geo.GeoService.GetISPInfo:output_type -> geo.ISPResponse
+	9,  // 47: geo.GeoService.GetDomainInfo:output_type -> geo.DomainResponse
+	10, // 48: geo.GeoService.GetConnectionTypeInfo:output_type -> geo.ConnectionTypeResponse
+	12, // 49: geo.GeoService.BatchLookup:output_type -> geo.BatchLookupResponse
+	14, // 50: geo.GeoService.StreamLookup:output_type -> geo.LookupResult
+	16, // 51: geo.GeoService.EvaluateGeoFence:output_type -> geo.GeoFenceResponse
+	40, // [40:52] is the sub-list for method output_type
+	28, // [28:40] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_proto_geo_v1_geo_proto_init() }
@@ -840,13 +2177,14 @@ func file_proto_geo_v1_geo_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_geo_v1_geo_proto_rawDesc), len(file_proto_geo_v1_geo_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   13,
+			NumEnums:      1,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_geo_v1_geo_proto_goTypes,
 		DependencyIndexes: file_proto_geo_v1_geo_proto_depIdxs,
+		EnumInfos:         file_proto_geo_v1_geo_proto_enumTypes,
 		MessageInfos:      file_proto_geo_v1_geo_proto_msgTypes,
 	}.Build()
 	File_proto_geo_v1_geo_proto = out.File
@@ -19,11 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GeoService_GetGeoData_FullMethodName       = "/geo.GeoService/GetGeoData"
-	GeoService_GetCityInfo_FullMethodName      = "/geo.GeoService/GetCityInfo"
-	GeoService_GetCountryInfo_FullMethodName   = "/geo.GeoService/GetCountryInfo"
-	GeoService_GetASNInfo_FullMethodName       = "/geo.GeoService/GetASNInfo"
-	GeoService_CheckAnonymousIP_FullMethodName = "/geo.GeoService/CheckAnonymousIP"
+	GeoService_GetGeoData_FullMethodName            = "/geo.GeoService/GetGeoData"
+	GeoService_GetCityInfo_FullMethodName           = "/geo.GeoService/GetCityInfo"
+	GeoService_GetCountryInfo_FullMethodName        = "/geo.GeoService/GetCountryInfo"
+	GeoService_GetASNInfo_FullMethodName            = "/geo.GeoService/GetASNInfo"
+	GeoService_CheckAnonymousIP_FullMethodName      = "/geo.GeoService/CheckAnonymousIP"
+	GeoService_GetEnterpriseInfo_FullMethodName     = "/geo.GeoService/GetEnterpriseInfo"
+	GeoService_GetISPInfo_FullMethodName            = "/geo.GeoService/GetISPInfo"
+	GeoService_GetDomainInfo_FullMethodName         = "/geo.GeoService/GetDomainInfo"
+	GeoService_GetConnectionTypeInfo_FullMethodName = "/geo.GeoService/GetConnectionTypeInfo"
+	GeoService_BatchLookup_FullMethodName           = "/geo.GeoService/BatchLookup"
+	GeoService_StreamLookup_FullMethodName          = "/geo.GeoService/StreamLookup"
+	GeoService_EvaluateGeoFence_FullMethodName      = "/geo.GeoService/EvaluateGeoFence"
 )
 
 // GeoServiceClient is the client API for GeoService service.
@@ -42,6 +49,21 @@ type GeoServiceClient interface {
 	GetASNInfo(ctx context.Context, in *IpRequest, opts ...grpc.CallOption) (*ASNResponse, error)
 	// CheckAnonymousIP는 IP 주소가 익명 프록시인지 확인합니다
 	CheckAnonymousIP(ctx context.Context, in *IpRequest, opts ...grpc.CallOption) (*AnonymousResponse, error)
+	// GetEnterpriseInfo는 IP 주소에 대한 GeoIP2 Enterprise 정보를 반환합니다
+	GetEnterpriseInfo(ctx context.Context, in *IpRequest, opts ...grpc.CallOption) (*EnterpriseResponse, error)
+	// GetISPInfo는 IP 주소에 대한 GeoIP2 ISP 정보를 반환합니다
+	GetISPInfo(ctx context.Context, in *IpRequest, opts ...grpc.CallOption) (*ISPResponse, error)
+	// GetDomainInfo는 IP 주소에 대한 GeoIP2 Domain 정보를 반환합니다
+	GetDomainInfo(ctx context.Context, in *IpRequest, opts ...grpc.CallOption) (*DomainResponse, error)
+	// GetConnectionTypeInfo는 IP 주소에 대한 GeoIP2 Connection Type 정보를 반환합니다
+	GetConnectionTypeInfo(ctx context.Context, in *IpRequest, opts ...grpc.CallOption) (*ConnectionTypeResponse, error)
+	// BatchLookup은 여러 IP 주소를 한 번에 조회하여 요청 순서대로 결과를 반환합니다
+	BatchLookup(ctx context.Context, in *BatchLookupRequest, opts ...grpc.CallOption) (*BatchLookupResponse, error)
+	// StreamLookup은 스트림으로 받은 IP 주소를 조회하는 대로 결과를 보냅니다.
+	// 결과는 조회가 끝난 순서로 전송되므로 index로 요청과 대응시킵니다.
+	StreamLookup(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamLookupRequest, LookupResult], error)
+	// EvaluateGeoFence는 IP 주소에 지역 제한 규칙 집합을 평가하여 허용 여부와 일치한 규칙을 반환합니다
+	EvaluateGeoFence(ctx context.Context, in *GeoFenceRequest, opts ...grpc.CallOption) (*GeoFenceResponse, error)
 }
 
 type geoServiceClient struct {
@@ -102,6 +124,79 @@ func (c *geoServiceClient) CheckAnonymousIP(ctx context.Context, in *IpRequest,
 	return out, nil
 }
 
+func (c *geoServiceClient) GetEnterpriseInfo(ctx context.Context, in *IpRequest, opts ...grpc.CallOption) (*EnterpriseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnterpriseResponse)
+	err := c.cc.Invoke(ctx, GeoService_GetEnterpriseInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) GetISPInfo(ctx context.Context, in *IpRequest, opts ...grpc.CallOption) (*ISPResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ISPResponse)
+	err := c.cc.Invoke(ctx, GeoService_GetISPInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) GetDomainInfo(ctx context.Context, in *IpRequest, opts ...grpc.CallOption) (*DomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DomainResponse)
+	err := c.cc.Invoke(ctx, GeoService_GetDomainInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) GetConnectionTypeInfo(ctx context.Context, in *IpRequest, opts ...grpc.CallOption) (*ConnectionTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConnectionTypeResponse)
+	err := c.cc.Invoke(ctx, GeoService_GetConnectionTypeInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) BatchLookup(ctx context.Context, in *BatchLookupRequest, opts ...grpc.CallOption) (*BatchLookupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchLookupResponse)
+	err := c.cc.Invoke(ctx, GeoService_BatchLookup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geoServiceClient) StreamLookup(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamLookupRequest, LookupResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GeoService_ServiceDesc.Streams[0], GeoService_StreamLookup_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamLookupRequest, LookupResult]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GeoService_StreamLookupClient = grpc.BidiStreamingClient[StreamLookupRequest, LookupResult]
+
+func (c *geoServiceClient) EvaluateGeoFence(ctx context.Context, in *GeoFenceRequest, opts ...grpc.CallOption) (*GeoFenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeoFenceResponse)
+	err := c.cc.Invoke(ctx, GeoService_EvaluateGeoFence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GeoServiceServer is the server API for GeoService service.
 // All implementations must embed UnimplementedGeoServiceServer
 // for forward compatibility.
@@ -118,6 +213,21 @@ type GeoServiceServer interface {
 	GetASNInfo(context.Context, *IpRequest) (*ASNResponse, error)
 	// CheckAnonymousIP는 IP 주소가 익명 프록시인지 확인합니다
 	CheckAnonymousIP(context.Context, *IpRequest) (*AnonymousResponse, error)
+	// GetEnterpriseInfo는 IP 주소에 대한 GeoIP2 Enterprise 정보를 반환합니다
+	GetEnterpriseInfo(context.Context, *IpRequest) (*EnterpriseResponse, error)
+	// GetISPInfo는 IP 주소에 대한 GeoIP2 ISP 정보를 반환합니다
+	GetISPInfo(context.Context, *IpRequest) (*ISPResponse, error)
+	// GetDomainInfo는 IP 주소에 대한 GeoIP2 Domain 정보를 반환합니다
+	GetDomainInfo(context.Context, *IpRequest) (*DomainResponse, error)
+	// GetConnectionTypeInfo는 IP 주소에 대한 GeoIP2 Connection Type 정보를 반환합니다
+	GetConnectionTypeInfo(context.Context, *IpRequest) (*ConnectionTypeResponse, error)
+	// BatchLookup은 여러 IP 주소를 한 번에 조회하여 요청 순서대로 결과를 반환합니다
+	BatchLookup(context.Context, *BatchLookupRequest) (*BatchLookupResponse, error)
+	// StreamLookup은 스트림으로 받은 IP 주소를 조회하는 대로 결과를 보냅니다.
+	// 결과는 조회가 끝난 순서로 전송되므로 index로 요청과 대응시킵니다.
+	StreamLookup(grpc.BidiStreamingServer[StreamLookupRequest, LookupResult]) error
+	// EvaluateGeoFence는 IP 주소에 지역 제한 규칙 집합을 평가하여 허용 여부와 일치한 규칙을 반환합니다
+	EvaluateGeoFence(context.Context, *GeoFenceRequest) (*GeoFenceResponse, error)
 	mustEmbedUnimplementedGeoServiceServer()
 }
 
@@ -143,6 +253,27 @@ func (UnimplementedGeoServiceServer) GetASNInfo(context.Context, *IpRequest) (*A
 func (UnimplementedGeoServiceServer) CheckAnonymousIP(context.Context, *IpRequest) (*AnonymousResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckAnonymousIP not implemented")
 }
+func (UnimplementedGeoServiceServer) GetEnterpriseInfo(context.Context, *IpRequest) (*EnterpriseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEnterpriseInfo not implemented")
+}
+func (UnimplementedGeoServiceServer) GetISPInfo(context.Context, *IpRequest) (*ISPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetISPInfo not implemented")
+}
+func (UnimplementedGeoServiceServer) GetDomainInfo(context.Context, *IpRequest) (*DomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDomainInfo not implemented")
+}
+func (UnimplementedGeoServiceServer) GetConnectionTypeInfo(context.Context, *IpRequest) (*ConnectionTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnectionTypeInfo not implemented")
+}
+func (UnimplementedGeoServiceServer) BatchLookup(context.Context, *BatchLookupRequest) (*BatchLookupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchLookup not implemented")
+}
+func (UnimplementedGeoServiceServer) StreamLookup(grpc.BidiStreamingServer[StreamLookupRequest, LookupResult]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLookup not implemented")
+}
+func (UnimplementedGeoServiceServer) EvaluateGeoFence(context.Context, *GeoFenceRequest) (*GeoFenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateGeoFence not implemented")
+}
 func (UnimplementedGeoServiceServer) mustEmbedUnimplementedGeoServiceServer() {}
 func (UnimplementedGeoServiceServer) testEmbeddedByValue()                    {}
 
@@ -254,6 +385,121 @@ func _GeoService_CheckAnonymousIP_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GeoService_GetEnterpriseInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetEnterpriseInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_GetEnterpriseInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetEnterpriseInfo(ctx, req.(*IpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_GetISPInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetISPInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_GetISPInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetISPInfo(ctx, req.(*IpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_GetDomainInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetDomainInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_GetDomainInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetDomainInfo(ctx, req.(*IpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_GetConnectionTypeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).GetConnectionTypeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_GetConnectionTypeInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).GetConnectionTypeInfo(ctx, req.(*IpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_BatchLookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchLookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).BatchLookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_BatchLookup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).BatchLookup(ctx, req.(*BatchLookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeoService_StreamLookup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GeoServiceServer).StreamLookup(&grpc.GenericServerStream[StreamLookupRequest, LookupResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GeoService_StreamLookupServer = grpc.BidiStreamingServer[StreamLookupRequest, LookupResult]
+
+func _GeoService_EvaluateGeoFence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeoFenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeoServiceServer).EvaluateGeoFence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeoService_EvaluateGeoFence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeoServiceServer).EvaluateGeoFence(ctx, req.(*GeoFenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GeoService_ServiceDesc is the grpc.ServiceDesc for GeoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,7 +527,38 @@ var GeoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CheckAnonymousIP",
 			Handler:    _GeoService_CheckAnonymousIP_Handler,
 		},
+		{
+			MethodName: "GetEnterpriseInfo",
+			Handler:    _GeoService_GetEnterpriseInfo_Handler,
+		},
+		{
+			MethodName: "GetISPInfo",
+			Handler:    _GeoService_GetISPInfo_Handler,
+		},
+		{
+			MethodName: "GetDomainInfo",
+			Handler:    _GeoService_GetDomainInfo_Handler,
+		},
+		{
+			MethodName: "GetConnectionTypeInfo",
+			Handler:    _GeoService_GetConnectionTypeInfo_Handler,
+		},
+		{
+			MethodName: "BatchLookup",
+			Handler:    _GeoService_BatchLookup_Handler,
+		},
+		{
+			MethodName: "EvaluateGeoFence",
+			Handler:    _GeoService_EvaluateGeoFence_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamLookup",
+			Handler:       _GeoService_StreamLookup_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/geo/v1/geo.proto",
 }
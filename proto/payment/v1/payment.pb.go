@@ -0,0 +1,595 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: proto/payment/v1/payment.proto
+
+package paymentv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CheckEntitlementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UniversalId   string                 `protobuf:"bytes,1,opt,name=universal_id,json=universalId,proto3" json:"universal_id,omitempty"`
+	Feature       string                 `protobuf:"bytes,2,opt,name=feature,proto3" json:"feature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckEntitlementRequest) Reset() {
+	*x = CheckEntitlementRequest{}
+	mi := &file_proto_payment_v1_payment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckEntitlementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckEntitlementRequest) ProtoMessage() {}
+
+func (x *CheckEntitlementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_v1_payment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckEntitlementRequest.ProtoReflect.Descriptor instead.
+func (*CheckEntitlementRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_v1_payment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckEntitlementRequest) GetUniversalId() string {
+	if x != nil {
+		return x.UniversalId
+	}
+	return ""
+}
+
+func (x *CheckEntitlementRequest) GetFeature() string {
+	if x != nil {
+		return x.Feature
+	}
+	return ""
+}
+
+type CheckEntitlementResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Feature string                 `protobuf:"bytes,1,opt,name=feature,proto3" json:"feature,omitempty"`
+	Allowed bool                   `protobuf:"varint,2,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	// unlimited is set when the feature is allowed without a limit; limit is then zero
+	Unlimited bool  `protobuf:"varint,3,opt,name=unlimited,proto3" json:"unlimited,omitempty"`
+	Limit     int64 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// sources lists the plans granting the feature
+	Sources       []string `protobuf:"bytes,5,rep,name=sources,proto3" json:"sources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckEntitlementResponse) Reset() {
+	*x = CheckEntitlementResponse{}
+	mi := &file_proto_payment_v1_payment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckEntitlementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckEntitlementResponse) ProtoMessage() {}
+
+func (x *CheckEntitlementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_v1_payment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckEntitlementResponse.ProtoReflect.Descriptor instead.
+func (*CheckEntitlementResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_v1_payment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CheckEntitlementResponse) GetFeature() string {
+	if x != nil {
+		return x.Feature
+	}
+	return ""
+}
+
+func (x *CheckEntitlementResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *CheckEntitlementResponse) GetUnlimited() bool {
+	if x != nil {
+		return x.Unlimited
+	}
+	return false
+}
+
+func (x *CheckEntitlementResponse) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *CheckEntitlementResponse) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+type CreditUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// amount is a decimal string; leave empty to price the usage from the feature catalog
+	Amount        string           `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	FeatureName   string           `protobuf:"bytes,2,opt,name=feature_name,json=featureName,proto3" json:"feature_name,omitempty"`
+	Description   string           `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	UsageMetadata *structpb.Struct `protobuf:"bytes,4,opt,name=usage_metadata,json=usageMetadata,proto3" json:"usage_metadata,omitempty"`
+	// idempotency_key is a UUID; a usage with an already processed key is reported as a duplicate
+	IdempotencyKey string `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreditUsage) Reset() {
+	*x = CreditUsage{}
+	mi := &file_proto_payment_v1_payment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreditUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreditUsage) ProtoMessage() {}
+
+func (x *CreditUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_v1_payment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreditUsage.ProtoReflect.Descriptor instead.
+func (*CreditUsage) Descriptor() ([]byte, []int) {
+	return file_proto_payment_v1_payment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreditUsage) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CreditUsage) GetFeatureName() string {
+	if x != nil {
+		return x.FeatureName
+	}
+	return ""
+}
+
+func (x *CreditUsage) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreditUsage) GetUsageMetadata() *structpb.Struct {
+	if x != nil {
+		return x.UsageMetadata
+	}
+	return nil
+}
+
+func (x *CreditUsage) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type UseCreditsBatchRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UniversalId string                 `protobuf:"bytes,1,opt,name=universal_id,json=universalId,proto3" json:"universal_id,omitempty"`
+	// actor_id is the workspace member spending the credits, if any
+	ActorId         string `protobuf:"bytes,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	ServiceProvider string `protobuf:"bytes,3,opt,name=service_provider,json=serviceProvider,proto3" json:"service_provider,omitempty"`
+	// atomic batches are applied entirely or not at all
+	Atomic        bool           `protobuf:"varint,4,opt,name=atomic,proto3" json:"atomic,omitempty"`
+	Usages        []*CreditUsage `protobuf:"bytes,5,rep,name=usages,proto3" json:"usages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UseCreditsBatchRequest) Reset() {
+	*x = UseCreditsBatchRequest{}
+	mi := &file_proto_payment_v1_payment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UseCreditsBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UseCreditsBatchRequest) ProtoMessage() {}
+
+func (x *UseCreditsBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_v1_payment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UseCreditsBatchRequest.ProtoReflect.Descriptor instead.
+func (*UseCreditsBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_v1_payment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UseCreditsBatchRequest) GetUniversalId() string {
+	if x != nil {
+		return x.UniversalId
+	}
+	return ""
+}
+
+func (x *UseCreditsBatchRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *UseCreditsBatchRequest) GetServiceProvider() string {
+	if x != nil {
+		return x.ServiceProvider
+	}
+	return ""
+}
+
+func (x *UseCreditsBatchRequest) GetAtomic() bool {
+	if x != nil {
+		return x.Atomic
+	}
+	return false
+}
+
+func (x *UseCreditsBatchRequest) GetUsages() []*CreditUsage {
+	if x != nil {
+		return x.Usages
+	}
+	return nil
+}
+
+type CreditUsageResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Index int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// status is one of applied, duplicate, failed or rejected
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TransactionId int64  `protobuf:"varint,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Amount        string `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	PriceVersion  int32  `protobuf:"varint,5,opt,name=price_version,json=priceVersion,proto3" json:"price_version,omitempty"`
+	Code          string `protobuf:"bytes,6,opt,name=code,proto3" json:"code,omitempty"`
+	Error         string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreditUsageResult) Reset() {
+	*x = CreditUsageResult{}
+	mi := &file_proto_payment_v1_payment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreditUsageResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreditUsageResult) ProtoMessage() {}
+
+func (x *CreditUsageResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_v1_payment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreditUsageResult.ProtoReflect.Descriptor instead.
+func (*CreditUsageResult) Descriptor() ([]byte, []int) {
+	return file_proto_payment_v1_payment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreditUsageResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *CreditUsageResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreditUsageResult) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *CreditUsageResult) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CreditUsageResult) GetPriceVersion() int32 {
+	if x != nil {
+		return x.PriceVersion
+	}
+	return 0
+}
+
+func (x *CreditUsageResult) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreditUsageResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type UseCreditsBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// rejected is set when an atomic batch was not applied
+	Rejected      bool                 `protobuf:"varint,1,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Applied       int32                `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"`
+	Duplicates    int32                `protobuf:"varint,3,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Failed        int32                `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	BalanceAfter  string               `protobuf:"bytes,5,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"`
+	Results       []*CreditUsageResult `protobuf:"bytes,6,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UseCreditsBatchResponse) Reset() {
+	*x = UseCreditsBatchResponse{}
+	mi := &file_proto_payment_v1_payment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UseCreditsBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UseCreditsBatchResponse) ProtoMessage() {}
+
+func (x *UseCreditsBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_v1_payment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UseCreditsBatchResponse.ProtoReflect.Descriptor instead.
+func (*UseCreditsBatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_v1_payment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UseCreditsBatchResponse) GetRejected() bool {
+	if x != nil {
+		return x.Rejected
+	}
+	return false
+}
+
+func (x *UseCreditsBatchResponse) GetApplied() int32 {
+	if x != nil {
+		return x.Applied
+	}
+	return 0
+}
+
+func (x *UseCreditsBatchResponse) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *UseCreditsBatchResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *UseCreditsBatchResponse) GetBalanceAfter() string {
+	if x != nil {
+		return x.BalanceAfter
+	}
+	return ""
+}
+
+func (x *UseCreditsBatchResponse) GetResults() []*CreditUsageResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_proto_payment_v1_payment_proto protoreflect.FileDescriptor
+
+const file_proto_payment_v1_payment_proto_rawDesc = "" +
+	"\n" +
+	"\x1eproto/payment/v1/payment.proto\x12\x0fsemo.payment.v1\x1a\x1cgoogle/protobuf/struct.proto\"V\n" +
+	"\x17CheckEntitlementRequest\x12!\n" +
+	"\funiversal_id\x18\x01 \x01(\tR\vuniversalId\x12\x18\n" +
+	"\afeature\x18\x02 \x01(\tR\afeature\"\x9c\x01\n" +
+	"\x18CheckEntitlementResponse\x12\x18\n" +
+	"\afeature\x18\x01 \x01(\tR\afeature\x12\x18\n" +
+	"\aallowed\x18\x02 \x01(\bR\aallowed\x12\x1c\n" +
+	"\tunlimited\x18\x03 \x01(\bR\tunlimited\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x03R\x05limit\x12\x18\n" +
+	"\asources\x18\x05 \x03(\tR\asources\"\xd3\x01\n" +
+	"\vCreditUsage\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\tR\x06amount\x12!\n" +
+	"\ffeature_name\x18\x02 \x01(\tR\vfeatureName\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12>\n" +
+	"\x0eusage_metadata\x18\x04 \x01(\v2\x17.google.protobuf.StructR\rusageMetadata\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"\xcf\x01\n" +
+	"\x16UseCreditsBatchRequest\x12!\n" +
+	"\funiversal_id\x18\x01 \x01(\tR\vuniversalId\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\tR\aactorId\x12)\n" +
+	"\x10service_provider\x18\x03 \x01(\tR\x0fserviceProvider\x12\x16\n" +
+	"\x06atomic\x18\x04 \x01(\bR\x06atomic\x124\n" +
+	"\x06usages\x18\x05 \x03(\v2\x1c.semo.payment.v1.CreditUsageR\x06usages\"\xcf\x01\n" +
+	"\x11CreditUsageResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\x03R\rtransactionId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\tR\x06amount\x12#\n" +
+	"\rprice_version\x18\x05 \x01(\x05R\fpriceVersion\x12\x12\n" +
+	"\x04code\x18\x06 \x01(\tR\x04code\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"\xea\x01\n" +
+	"\x17UseCreditsBatchResponse\x12\x1a\n" +
+	"\brejected\x18\x01 \x01(\bR\brejected\x12\x18\n" +
+	"\aapplied\x18\x02 \x01(\x05R\aapplied\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x03 \x01(\x05R\n" +
+	"duplicates\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x05R\x06failed\x12#\n" +
+	"\rbalance_after\x18\x05 \x01(\tR\fbalanceAfter\x12<\n" +
+	"\aresults\x18\x06 \x03(\v2\".semo.payment.v1.CreditUsageResultR\aresults2\xe3\x01\n" +
+	"\x0ePaymentService\x12i\n" +
+	"\x10CheckEntitlement\x12(.semo.payment.v1.CheckEntitlementRequest\x1a).semo.payment.v1.CheckEntitlementResponse\"\x00\x12f\n" +
+	"\x0fUseCreditsBatch\x12'.semo.payment.v1.UseCreditsBatchRequest\x1a(.semo.payment.v1.UseCreditsBatchResponse\"\x00BKZIgithub.com/wekeepgrowing/semo-backend-monorepo/proto/payment/v1;paymentv1b\x06proto3"
+
+var (
+	file_proto_payment_v1_payment_proto_rawDescOnce sync.Once
+	file_proto_payment_v1_payment_proto_rawDescData []byte
+)
+
+func file_proto_payment_v1_payment_proto_rawDescGZIP() []byte {
+	file_proto_payment_v1_payment_proto_rawDescOnce.Do(func() {
+		file_proto_payment_v1_payment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_payment_v1_payment_proto_rawDesc), len(file_proto_payment_v1_payment_proto_rawDesc)))
+	})
+	return file_proto_payment_v1_payment_proto_rawDescData
+}
+
+var file_proto_payment_v1_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_payment_v1_payment_proto_goTypes = []any{
+	(*CheckEntitlementRequest)(nil),  // 0: semo.payment.v1.CheckEntitlementRequest
+	(*CheckEntitlementResponse)(nil), // 1: semo.payment.v1.CheckEntitlementResponse
+	(*CreditUsage)(nil),              // 2: semo.payment.v1.CreditUsage
+	(*UseCreditsBatchRequest)(nil),   // 3: semo.payment.v1.UseCreditsBatchRequest
+	(*CreditUsageResult)(nil),        // 4: semo.payment.v1.CreditUsageResult
+	(*UseCreditsBatchResponse)(nil),  // 5: semo.payment.v1.UseCreditsBatchResponse
+	(*structpb.Struct)(nil),          // 6: google.protobuf.Struct
+}
+var file_proto_payment_v1_payment_proto_depIdxs = []int32{
+	6, // 0: semo.payment.v1.CreditUsage.usage_metadata:type_name -> google.protobuf.Struct
+	2, // 1: semo.payment.v1.UseCreditsBatchRequest.usages:type_name -> semo.payment.v1.CreditUsage
+	4, // 2: semo.payment.v1.UseCreditsBatchResponse.results:type_name -> semo.payment.v1.CreditUsageResult
+	0, // 3: semo.payment.v1.PaymentService.CheckEntitlement:input_type -> semo.payment.v1.CheckEntitlementRequest
+	3, // 4: semo.payment.v1.PaymentService.UseCreditsBatch:input_type -> semo.payment.v1.UseCreditsBatchRequest
+	1, // 5: semo.payment.v1.PaymentService.CheckEntitlement:output_type -> semo.payment.v1.CheckEntitlementResponse
+	5, // 6: semo.payment.v1.PaymentService.UseCreditsBatch:output_type -> semo.payment.v1.UseCreditsBatchResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_payment_v1_payment_proto_init() }
+func file_proto_payment_v1_payment_proto_init() {
+	if File_proto_payment_v1_payment_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_payment_v1_payment_proto_rawDesc), len(file_proto_payment_v1_payment_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_payment_v1_payment_proto_goTypes,
+		DependencyIndexes: file_proto_payment_v1_payment_proto_depIdxs,
+		MessageInfos:      file_proto_payment_v1_payment_proto_msgTypes,
+	}.Build()
+	File_proto_payment_v1_payment_proto = out.File
+	file_proto_payment_v1_payment_proto_goTypes = nil
+	file_proto_payment_v1_payment_proto_depIdxs = nil
+}
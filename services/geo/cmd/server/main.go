@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -9,13 +10,17 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+	"github.com/wekeepgrowing/semo-backend-monorepo/pkg/middleware/clientip"
 	pb "github.com/wekeepgrowing/semo-backend-monorepo/proto/geo/v1"
 	grpcHandler "github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/adapter/handler/grpc"
 	httpHandler "github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/adapter/handler/http"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/adapter/repository"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/config"
+	domainRepository "github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/repository"
 	grpcServer "github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/infrastructure/grpc"
 	httpServer "github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/infrastructure/http"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/infrastructure/updater"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/usecase"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -42,24 +47,131 @@ func main() {
 	countryDbPath := filepath.Join(dataDir, "GeoLite2-Country.mmdb")
 	asnDbPath := filepath.Join(dataDir, "GeoLite2-ASN.mmdb")
 
+	// 데이터베이스 자동 업데이트를 사용하면, 파일이 없을 때 서버 시작 전에 먼저 내려받습니다
+	var dbUpdater *updater.Updater
+	if cfg.GeoLite.Update.Enabled {
+		editions := cfg.GeoLite.Update.Editions
+		if len(editions) == 0 {
+			editions = []string{"GeoLite2-City", "GeoLite2-Country", "GeoLite2-ASN"}
+		}
+		dbUpdater = updater.New(updater.Config{
+			DownloadURL:  cfg.GeoLite.Update.DownloadURL,
+			AccountID:    cfg.GeoLite.Update.AccountID,
+			LicenseKey:   cfg.GeoLite.Update.LicenseKey,
+			Editions:     editions,
+			DataDir:      dataDir,
+			Interval:     time.Duration(cfg.GeoLite.Update.Interval) * time.Hour,
+			KeepVersions: cfg.GeoLite.Update.KeepVersions,
+		}, log)
+
+		for _, path := range []string{cityDbPath, countryDbPath, asnDbPath} {
+			if _, err := os.Stat(path); err != nil {
+				log.Info("GeoLite2 데이터베이스 파일이 없어 먼저 내려받습니다", zap.String("path", path))
+				if _, err := dbUpdater.Update(context.Background()); err != nil {
+					log.Error("GeoLite2 데이터베이스 내려받기 실패", zap.Error(err))
+				}
+				break
+			}
+		}
+	}
+
 	// 4. GeoLite2 리포지토리 초기화
 	log.Info("GeoLite2 데이터베이스 초기화 중...")
-	geoRepo, err := repository.NewGeoLite2Repository(cityDbPath, countryDbPath, asnDbPath)
+	geoRepo, err := repository.NewReloadableGeoLite2Repository(cityDbPath, countryDbPath, asnDbPath, log,
+		repository.WithOptionalDatabases(optionalDatabasePaths(dataDir, cfg.GeoLite.Databases)))
 	if err != nil {
 		log.Fatal("GeoLite2 리포지토리 초기화 실패", zap.Error(err))
 	}
 	defer geoRepo.Close()
 	log.Info("GeoLite2 데이터베이스 초기화 완료")
 
+	// 데이터베이스 파일이 교체되면 재시작 없이 다시 엽니다
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	reloadInterval := 60 * time.Second
+	if cfg.GeoLite.ReloadInterval != 0 {
+		reloadInterval = time.Duration(cfg.GeoLite.ReloadInterval) * time.Second
+	}
+	if reloadInterval > 0 {
+		go geoRepo.Watch(watchCtx, reloadInterval)
+	}
+
+	// SIGHUP을 받으면 즉시 다시 엽니다
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			log.Info("SIGHUP 수신, GeoLite2 데이터베이스 재적재")
+			geoRepo.Reload()
+		}
+	}()
+
+	if dbUpdater != nil {
+		go dbUpdater.Run(watchCtx, geoRepo)
+	}
+
 	// 5. 유스케이스 초기화
-	geoUseCase := usecase.NewGeoUseCaseWithGeoLite2(geoRepo)
+	geoUseCase := usecase.NewGeoUseCaseWithGeoIP2(geoRepo)
 	defer geoUseCase.Close()
+	geoUseCase.SetDefaultLanguages(cfg.GeoLite.Languages)
+
+	// 종합 지리 정보 조회 결과를 캐시합니다. 키에 데이터베이스 버전이 들어가므로 재적재되면 새로 조회합니다.
+	var geoData usecase.GeoDataProvider = geoUseCase
+	var cachedGeoData *usecase.CachedGeoData
+	if cacheRepo := newCacheRepository(cfg.Cache, log); cacheRepo != nil {
+		cachedGeoData = usecase.NewCachedGeoData(geoUseCase, cacheRepo, geoRepo, usecase.CacheOptions{
+			TTL:        time.Duration(cfg.Cache.TTL) * time.Second,
+			IPv4Prefix: cfg.Cache.IPv4Prefix,
+			IPv6Prefix: cfg.Cache.IPv6Prefix,
+		})
+		geoData = cachedGeoData
+	}
+
+	// 지역 제한 규칙은 저장소에서 주기적으로 다시 읽어 재시작 없이 반영합니다
+	var geoFence *usecase.GeoFenceUseCase
+	if cfg.GeoFence.Enabled {
+		geoFenceRepo, err := newGeoFenceRepository(cfg.GeoFence, cfg.Cache, log)
+		if err != nil {
+			log.Fatal("지역 제한 규칙 저장소 설정 오류", zap.Error(err))
+		}
+		geoFence, err = usecase.NewGeoFenceUseCase(context.Background(), geoFenceRepo, geoData, log)
+		if err != nil {
+			log.Fatal("지역 제한 규칙 적재 실패", zap.Error(err))
+		}
+
+		geoFenceInterval := 30 * time.Second
+		if cfg.GeoFence.ReloadInterval != 0 {
+			geoFenceInterval = time.Duration(cfg.GeoFence.ReloadInterval) * time.Second
+		}
+		if geoFenceInterval > 0 {
+			go geoFence.Watch(watchCtx, geoFenceInterval)
+		}
+
+		// SIGHUP을 받으면 규칙도 즉시 다시 읽습니다
+		geoFenceHup := make(chan os.Signal, 1)
+		signal.Notify(geoFenceHup, syscall.SIGHUP)
+		go func() {
+			for range geoFenceHup {
+				if err := geoFence.Reload(watchCtx); err != nil {
+					log.Error("지역 제한 규칙 재적재 실패, 기존 규칙을 계속 사용합니다", zap.Error(err))
+				}
+			}
+		}()
+	}
 
 	// 6. HTTP 핸들러 초기화
-	geoHttpHandler := httpHandler.NewGeoHandler(geoUseCase)
+	geoHttpHandler := httpHandler.NewGeoHandler(geoUseCase, geoData)
+	var updateStatus usecase.UpdateStatusProvider
+	if dbUpdater != nil {
+		updateStatus = dbUpdater
+	}
+	databaseHttpHandler := httpHandler.NewDatabaseHandler(usecase.NewDatabaseUseCase(geoRepo, updateStatus))
 
 	// 7. gRPC 핸들러 초기화
-	geoGrpcHandler := grpcHandler.NewGeoHandler(geoUseCase)
+	geoGrpcHandler := grpcHandler.NewGeoHandler(geoUseCase, geoData)
+	if geoFence != nil {
+		geoGrpcHandler.SetGeoFence(geoFence)
+	}
 
 	// 8. HTTP 서버 포트 설정
 	httpPort := 8080
@@ -74,13 +186,25 @@ func main() {
 	}
 
 	// 10. HTTP 서버 초기화 및 시작
+	clientIPResolver, err := clientip.NewResolver(cfg.Server.HTTP.TrustedProxies)
+	if err != nil {
+		log.Fatal("신뢰하는 프록시 설정 오류", zap.Error(err))
+	}
 	httpSrv := httpServer.NewServer(
 		httpServer.WithPort(httpPort),
 		httpServer.WithLogger(log),
+		httpServer.WithClientIPResolver(clientIPResolver),
 	)
 
 	// 라우트 등록
 	httpSrv.RegisterRoutes(geoHttpHandler.RegisterRoutes)
+	httpSrv.RegisterRoutes(databaseHttpHandler.RegisterRoutes)
+	if cachedGeoData != nil {
+		httpSrv.RegisterRoutes(httpHandler.NewCacheHandler(cachedGeoData).RegisterRoutes)
+	}
+	if geoFence != nil {
+		httpSrv.RegisterRoutes(httpHandler.NewGeoFenceHandler(geoFence).RegisterRoutes)
+	}
 
 	// HTTP 서버 시작
 	go func() {
@@ -136,6 +260,72 @@ func main() {
 	log.Info("서버 정상 종료")
 }
 
+// newCacheRepository는 설정된 종류의 캐시 저장소를 생성합니다. 캐시를 사용하지 않으면 nil을 반환합니다.
+func newCacheRepository(cfg config.Cache, log *zap.Logger) domainRepository.CacheRepository {
+	switch cfg.Type {
+	case "none":
+		log.Info("조회 결과 캐시를 사용하지 않습니다")
+		return nil
+	case "redis":
+		log.Info("Redis 조회 결과 캐시 사용", zap.String("addr", cfg.Redis.Addr))
+		return repository.NewRedisCacheRepository(redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		}))
+	case "", "memory":
+		return repository.NewMemoryCacheRepository(cfg.Capacity, cfg.Shards)
+	default:
+		log.Warn("알 수 없는 캐시 종류, 인메모리 캐시를 사용합니다", zap.String("type", cfg.Type))
+		return repository.NewMemoryCacheRepository(cfg.Capacity, cfg.Shards)
+	}
+}
+
+// newGeoFenceRepository는 설정된 종류의 지역 제한 규칙 저장소를 생성합니다.
+// Redis 주소를 설정하지 않으면 캐시의 Redis 설정을 사용합니다.
+func newGeoFenceRepository(cfg config.GeoFence, cache config.Cache, log *zap.Logger) (domainRepository.GeoFenceRepository, error) {
+	switch cfg.Source {
+	case "redis":
+		addr, password, db := cfg.Redis.Addr, cfg.Redis.Password, cfg.Redis.DB
+		if addr == "" {
+			addr, password, db = cache.Redis.Addr, cache.Redis.Password, cache.Redis.DB
+		}
+		log.Info("Redis 지역 제한 규칙 사용", zap.String("addr", addr))
+		return repository.NewRedisGeoFenceRepository(redis.NewClient(&redis.Options{
+			Addr:     addr,
+			Password: password,
+			DB:       db,
+		}), cfg.Redis.Key), nil
+	case "", "file":
+		if cfg.File == "" {
+			return nil, errors.New("지역 제한 규칙 파일 경로가 필요합니다")
+		}
+		log.Info("파일 지역 제한 규칙 사용", zap.String("file", cfg.File))
+		return repository.NewFileGeoFenceRepository(cfg.File), nil
+	default:
+		return nil, fmt.Errorf("알 수 없는 지역 제한 규칙 저장소: %s", cfg.Source)
+	}
+}
+
+// optionalDatabasePaths는 설정된 GeoIP2 데이터베이스 파일 이름을 데이터 디렉터리 기준 경로로 바꿉니다.
+// 설정하지 않은 데이터베이스는 MaxMind 기본 파일 이름을 사용합니다.
+func optionalDatabasePaths(dataDir string, databases config.GeoIP2Databases) repository.OptionalDatabasePaths {
+	path := func(name, defaultName string) string {
+		if name == "" {
+			name = defaultName
+		}
+		return filepath.Join(dataDir, name)
+	}
+
+	return repository.OptionalDatabasePaths{
+		Enterprise:     path(databases.Enterprise, "GeoIP2-Enterprise.mmdb"),
+		AnonymousIP:    path(databases.AnonymousIP, "GeoIP2-Anonymous-IP.mmdb"),
+		ISP:            path(databases.ISP, "GeoIP2-ISP.mmdb"),
+		Domain:         path(databases.Domain, "GeoIP2-Domain.mmdb"),
+		ConnectionType: path(databases.ConnectionType, "GeoIP2-Connection-Type.mmdb"),
+	}
+}
+
 // parseInt는 문자열을 정수로 변환하고, 변환 실패 시 기본값을 반환합니다.
 func parseInt(s string, defaultVal int) int {
 	var val int
@@ -0,0 +1,194 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/wekeepgrowing/semo-backend-monorepo/proto/geo/v1"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/usecase"
+)
+
+// BatchLookup은 여러 IP 주소를 한 번에 조회하여 요청 순서대로 결과를 반환합니다
+func (h *GeoHandler) BatchLookup(ctx context.Context, req *proto.BatchLookupRequest) (*proto.BatchLookupResponse, error) {
+	fields, err := lookupFields(req.Fields)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	results, err := h.geoUseCase.BatchLookup(ctx, req.Ips, fields)
+	if err != nil {
+		if err == usecase.ErrBatchTooLarge {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := &proto.BatchLookupResponse{
+		Results: make([]*proto.LookupResult, len(results)),
+	}
+	for i, result := range results {
+		response.Results[i] = toProtoLookupResult(uint64(i), result)
+	}
+
+	return response, nil
+}
+
+// StreamLookup은 스트림으로 받은 IP 주소를 동시에 조회하여 끝나는 대로 결과를 보냅니다.
+// 동시에 조회하는 수는 usecase.BatchConcurrency로 제한되며, 한도에 이르면 다음 요청을 받지 않고 기다립니다.
+// 클라이언트가 요청 스트림을 닫으면 남은 결과를 모두 보낸 뒤 종료하고, 결과를 보내지 못하면 그 오류로 종료합니다.
+func (h *GeoHandler) StreamLookup(stream proto.GeoService_StreamLookupServer) error {
+	ctx := stream.Context()
+	sem := make(chan struct{}, usecase.BatchConcurrency())
+
+	var wg sync.WaitGroup
+	var sendMu sync.Mutex
+	var sendErr error
+	send := func(result *proto.LookupResult) {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		if sendErr == nil {
+			sendErr = stream.Send(result)
+		}
+	}
+	failed := func() error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return sendErr
+	}
+
+	for index := uint64(0); ; index++ {
+		// 결과를 보내지 못했으면 더 받지 않고 진행 중인 조회만 기다려 종료합니다
+		if err := failed(); err != nil {
+			wg.Wait()
+			return err
+		}
+
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			wg.Wait()
+			return err
+		}
+
+		fields, err := lookupFields(req.Fields)
+		if err != nil {
+			send(&proto.LookupResult{Index: index, Ip: req.Ip, Error: err.Error()})
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return status.FromContextError(ctx.Err()).Err()
+		}
+
+		wg.Add(1)
+		go func(index uint64, ip string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			send(toProtoLookupResult(index, h.geoUseCase.Lookup(ip, fields)))
+		}(index, req.Ip)
+	}
+
+	wg.Wait()
+	return sendErr
+}
+
+// lookupFields는 요청의 조회 항목을 유스케이스의 LookupFields로 변환합니다. 비어 있으면 모든 항목을 조회합니다.
+func lookupFields(fields []proto.LookupField) (usecase.LookupFields, error) {
+	if len(fields) == 0 {
+		return usecase.AllLookupFields, nil
+	}
+
+	var result usecase.LookupFields
+	for _, field := range fields {
+		switch field {
+		case proto.LookupField_LOOKUP_FIELD_CITY:
+			result.City = true
+		case proto.LookupField_LOOKUP_FIELD_COUNTRY:
+			result.Country = true
+		case proto.LookupField_LOOKUP_FIELD_ASN:
+			result.ASN = true
+		case proto.LookupField_LOOKUP_FIELD_ANONYMOUS:
+			result.Anonymous = true
+		default:
+			return usecase.LookupFields{}, usecase.ErrInvalidLookupField
+		}
+	}
+	return result, nil
+}
+
+// toProtoLookupResult는 유스케이스의 조회 결과를 응답 메시지로 변환합니다
+func toProtoLookupResult(index uint64, result usecase.LookupResult) *proto.LookupResult {
+	response := &proto.LookupResult{
+		Index:  index,
+		Ip:     result.IP,
+		Error:  result.Error,
+		Errors: result.Errors,
+	}
+
+	if result.City != nil {
+		response.City = &proto.CityResponse{
+			City: &proto.CityInfo{
+				GeonameId: uint32(result.City.City.GeoNameID),
+				Names:     result.City.City.Names,
+			},
+			Country:   toProtoCountryInfo(result.City.Country),
+			Continent: toProtoContinentInfo(result.City.Continent),
+			Location: &proto.LocationInfo{
+				Latitude:  result.City.Location.Latitude,
+				Longitude: result.City.Location.Longitude,
+				TimeZone:  result.City.Location.TimeZone,
+			},
+		}
+	}
+
+	if result.Country != nil {
+		response.Country = &proto.CountryResponse{
+			Country:   toProtoCountryInfo(result.Country.Country),
+			Continent: toProtoContinentInfo(result.Country.Continent),
+		}
+	}
+
+	if result.ASN != nil {
+		response.Asn = &proto.ASNResponse{
+			AutonomousSystemNumber:       uint32(result.ASN.AutonomousSystemNumber),
+			AutonomousSystemOrganization: result.ASN.AutonomousSystemOrganization,
+		}
+	}
+
+	if result.Anonymous != nil {
+		response.Anonymous = &proto.AnonymousResponse{
+			IsAnonymous:    result.Anonymous.IsAnonymous,
+			IsTorExitNode:  result.Anonymous.IsTorExitNode,
+			FeatureSupport: result.Anonymous.FeatureSupport,
+		}
+	}
+
+	return response
+}
+
+func toProtoCountryInfo(country entity.CountryInfo) *proto.CountryInfo {
+	return &proto.CountryInfo{
+		GeonameId:         uint32(country.GeoNameID),
+		IsInEuropeanUnion: country.IsInEuropeanUnion,
+		IsoCode:           country.IsoCode,
+		Names:             country.Names,
+	}
+}
+
+func toProtoContinentInfo(continent entity.ContinentInfo) *proto.ContinentInfo {
+	return &proto.ContinentInfo{
+		Code:      continent.Code,
+		GeonameId: uint32(continent.GeoNameID),
+		Names:     continent.Names,
+	}
+}
@@ -0,0 +1,199 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/wekeepgrowing/semo-backend-monorepo/proto/geo/v1"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/usecase"
+)
+
+// stubGeoLite2는 IP 주소를 도시 이름으로 돌려주는 GeoLite2Repository입니다
+type stubGeoLite2 struct{}
+
+func (stubGeoLite2) GetCity(ip net.IP) (entity.City, error) {
+	return entity.City{City: entity.CityInfo{Names: map[string]string{"en": ip.String()}}}, nil
+}
+
+func (stubGeoLite2) GetCountry(net.IP) (entity.Country, error) { return entity.Country{}, nil }
+func (stubGeoLite2) GetASN(net.IP) (entity.ASN, error)         { return entity.ASN{}, nil }
+func (stubGeoLite2) Close() error                              { return nil }
+
+func newTestHandler() *GeoHandler {
+	return NewGeoHandler(usecase.NewGeoUseCaseWithGeoLite2(stubGeoLite2{}), nil)
+}
+
+// fakeLookupStream은 requests를 차례로 받은 뒤 EOF를 반환하는 StreamLookup 스트림입니다.
+// requests가 nil이면 끝없이 요청을 받습니다. sendErr가 설정되면 모든 Send가 실패합니다.
+type fakeLookupStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*proto.StreamLookupRequest
+	sendErr  error
+
+	mu       sync.Mutex
+	received int
+	results  []*proto.LookupResult
+}
+
+func (s *fakeLookupStream) Context() context.Context { return s.ctx }
+
+func (s *fakeLookupStream) Recv() (*proto.StreamLookupRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.requests == nil {
+		s.received++
+		return &proto.StreamLookupRequest{Ip: "1.2.3.4"}, nil
+	}
+	if s.received == len(s.requests) {
+		return nil, io.EOF
+	}
+	s.received++
+	return s.requests[s.received-1], nil
+}
+
+func (s *fakeLookupStream) Send(result *proto.LookupResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.results = append(s.results, result)
+	return nil
+}
+
+func TestBatchLookupReturnsResultsInRequestOrder(t *testing.T) {
+	ips := make([]string, 30)
+	for i := range ips {
+		ips[i] = fmt.Sprintf("10.0.0.%d", i)
+	}
+
+	resp, err := newTestHandler().BatchLookup(context.Background(), &proto.BatchLookupRequest{
+		Ips:    ips,
+		Fields: []proto.LookupField{proto.LookupField_LOOKUP_FIELD_CITY},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Results) != len(ips) {
+		t.Fatalf("len(Results) = %d, want %d", len(resp.Results), len(ips))
+	}
+	for i, result := range resp.Results {
+		if result.Index != uint64(i) || result.Ip != ips[i] || result.City.GetCity().GetNames()["en"] != ips[i] {
+			t.Errorf("Results[%d] = %v, want the lookup of %s", i, result, ips[i])
+		}
+		if result.Asn != nil {
+			t.Errorf("Results[%d].Asn = %v, want only the requested city", i, result.Asn)
+		}
+	}
+}
+
+func TestBatchLookupRejectsInvalidRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *proto.BatchLookupRequest
+	}{
+		{"too many ips", &proto.BatchLookupRequest{Ips: make([]string, usecase.MaxBatchSize+1)}},
+		{"unknown field", &proto.BatchLookupRequest{Ips: []string{"1.2.3.4"}, Fields: []proto.LookupField{99}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestHandler().BatchLookup(context.Background(), tt.req)
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestStreamLookupSendsEveryResultBeforeEOF(t *testing.T) {
+	var requests []*proto.StreamLookupRequest
+	for i := 0; i < 20; i++ {
+		requests = append(requests, &proto.StreamLookupRequest{
+			Ip:     fmt.Sprintf("10.0.0.%d", i),
+			Fields: []proto.LookupField{proto.LookupField_LOOKUP_FIELD_CITY},
+		})
+	}
+	requests = append(requests, &proto.StreamLookupRequest{Ip: "10.0.0.99", Fields: []proto.LookupField{99}})
+	stream := &fakeLookupStream{ctx: context.Background(), requests: requests}
+
+	if err := newTestHandler().StreamLookup(stream); err != nil {
+		t.Fatalf("StreamLookup() error = %v", err)
+	}
+
+	if len(stream.results) != len(requests) {
+		t.Fatalf("결과 %d개, want %d", len(stream.results), len(requests))
+	}
+	sort.Slice(stream.results, func(i, j int) bool { return stream.results[i].Index < stream.results[j].Index })
+	for i, result := range stream.results[:20] {
+		if result.Index != uint64(i) || result.City.GetCity().GetNames()["en"] != requests[i].Ip {
+			t.Errorf("결과 %d = %v, want the lookup of %s", i, result, requests[i].Ip)
+		}
+	}
+	// 잘못된 조회 항목은 스트림을 끝내지 않고 그 요청의 결과에 오류로 담깁니다
+	if invalid := stream.results[20]; invalid.Index != 20 || invalid.Error != usecase.ErrInvalidLookupField.Error() {
+		t.Errorf("잘못된 요청의 결과 = %v, want ErrInvalidLookupField", invalid)
+	}
+}
+
+func TestStreamLookupStopsReceivingWhenSendFails(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(2))
+
+	sendErr := errors.New("client gone")
+	stream := &fakeLookupStream{ctx: context.Background(), sendErr: sendErr}
+
+	if err := newTestHandler().StreamLookup(stream); !errors.Is(err, sendErr) {
+		t.Fatalf("StreamLookup() error = %v, want %v", err, sendErr)
+	}
+	// 끝없이 요청을 보내는 클라이언트라도, 보내기 실패 뒤에는 동시 조회 한도 안에서 받기를 멈춥니다
+	if limit := 2*usecase.BatchConcurrency() + 2; stream.received > limit {
+		t.Errorf("요청 %d개를 받았습니다, want at most %d", stream.received, limit)
+	}
+}
+
+func TestStreamLookupCanceledContext(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	// 동시 조회 한도(1)를 채운 조회가 끝나지 않은 채로 다음 요청을 기다리는 중에 ctx가 취소됩니다
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeLookupStream{ctx: ctx}
+	blocking := &blockingGeoLite2{started: make(chan struct{}), release: make(chan struct{})}
+	handler := NewGeoHandler(usecase.NewGeoUseCaseWithGeoLite2(blocking), nil)
+
+	done := make(chan error, 1)
+	go func() { done <- handler.StreamLookup(stream) }()
+	<-blocking.started
+	cancel()
+	close(blocking.release)
+
+	if err := <-done; status.Code(err) != codes.Canceled {
+		t.Errorf("StreamLookup() error = %v, want Canceled", err)
+	}
+}
+
+// blockingGeoLite2는 release가 닫힐 때까지 GetCity를 멈추는 GeoLite2Repository입니다
+type blockingGeoLite2 struct {
+	stubGeoLite2
+	once    sync.Once
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingGeoLite2) GetCity(ip net.IP) (entity.City, error) {
+	b.once.Do(func() { close(b.started) })
+	<-b.release
+	return b.stubGeoLite2.GetCity(ip)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	proto "github.com/wekeepgrowing/semo-backend-monorepo/proto/geo/v1"
@@ -14,12 +15,20 @@ import (
 type GeoHandler struct {
 	proto.UnimplementedGeoServiceServer
 	geoUseCase *usecase.GeoUseCase
+	geoData    usecase.GeoDataProvider
+	// geoFence는 지역 제한 규칙을 사용할 때만 설정합니다
+	geoFence *usecase.GeoFenceUseCase
 }
 
-// NewGeoHandler는 새로운 GeoHandler 인스턴스를 생성합니다
-func NewGeoHandler(geoUseCase *usecase.GeoUseCase) *GeoHandler {
+// NewGeoHandler는 새로운 GeoHandler 인스턴스를 생성합니다.
+// geoData는 종합 지리 정보 조회에 사용하며, nil이면 geoUseCase를 그대로 사용합니다.
+func NewGeoHandler(geoUseCase *usecase.GeoUseCase, geoData usecase.GeoDataProvider) *GeoHandler {
+	if geoData == nil {
+		geoData = geoUseCase
+	}
 	return &GeoHandler{
 		geoUseCase: geoUseCase,
+		geoData:    geoData,
 	}
 }
 
@@ -29,7 +38,7 @@ func (h *GeoHandler) GetGeoData(ctx context.Context, req *proto.IpRequest) (*pro
 		return nil, status.Error(codes.InvalidArgument, "IP 주소가 필요합니다")
 	}
 
-	geoData, err := h.geoUseCase.GetGeoData(req.Ip)
+	geoData, err := h.geoData.GetGeoData(req.Ip, requestLanguages(ctx, req.Language)...)
 	if err != nil {
 		if err == usecase.ErrInvalidIPAddress {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -52,11 +61,28 @@ func (h *GeoHandler) GetGeoData(ctx context.Context, req *proto.IpRequest) (*pro
 		IsAnonymous:    geoData.IsAnonymous,
 		IsAnonymousVpn: geoData.IsAnonymousVPN,
 		IsTorExitNode:  geoData.IsTorExitNode,
+		Organization:   geoData.Organization,
+		Domain:         geoData.Domain,
+		ConnectionType: geoData.ConnectionType,
+		UserType:       geoData.UserType,
+		PostalCode:     geoData.PostalCode,
+		Subdivision:    geoData.Subdivision,
 	}
 
 	return response, nil
 }
 
+// requestLanguages는 요청의 language 필드, 없으면 accept-language 메타데이터에서 이름에 사용할 언어 목록을 읽습니다
+func requestLanguages(ctx context.Context, language string) []string {
+	if language != "" {
+		return usecase.ParseLanguages(language)
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		return usecase.ParseLanguages(md.Get("accept-language")...)
+	}
+	return nil
+}
+
 // GetCityInfo는 IP 주소에 대한 도시 정보를 반환합니다
 func (h *GeoHandler) GetCityInfo(ctx context.Context, req *proto.IpRequest) (*proto.CityResponse, error) {
 	if req.Ip == "" {
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/wekeepgrowing/semo-backend-monorepo/proto/geo/v1"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/usecase"
+)
+
+// SetGeoFence는 EvaluateGeoFence에 사용할 지역 제한 규칙을 설정합니다. 설정하지 않으면 Unimplemented를 반환합니다.
+func (h *GeoHandler) SetGeoFence(geoFence *usecase.GeoFenceUseCase) {
+	h.geoFence = geoFence
+}
+
+// EvaluateGeoFence는 IP 주소에 지역 제한 규칙 집합을 평가하여 허용 여부와 일치한 규칙을 반환합니다
+func (h *GeoHandler) EvaluateGeoFence(ctx context.Context, req *proto.GeoFenceRequest) (*proto.GeoFenceResponse, error) {
+	if h.geoFence == nil {
+		return nil, status.Error(codes.Unimplemented, "지역 제한 규칙을 사용하지 않습니다")
+	}
+	if req.RuleSet == "" {
+		return nil, status.Error(codes.InvalidArgument, "규칙 집합 이름이 필요합니다")
+	}
+	if req.Ip == "" {
+		return nil, status.Error(codes.InvalidArgument, "IP 주소가 필요합니다")
+	}
+
+	decision, err := h.geoFence.Evaluate(req.RuleSet, req.Ip)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrGeoFenceRuleSetNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, usecase.ErrInvalidIPAddress):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &proto.GeoFenceResponse{
+		RuleSet:       decision.RuleSet,
+		Ip:            decision.IPAddress,
+		Allowed:       decision.Allowed,
+		Action:        decision.Action,
+		MatchedRule:   decision.MatchedRule,
+		CountryCode:   decision.CountryCode,
+		ContinentCode: decision.ContinentCode,
+		Asn:           uint32(decision.ASN),
+		Unknown:       decision.Unknown,
+	}, nil
+}
@@ -0,0 +1,141 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/wekeepgrowing/semo-backend-monorepo/proto/geo/v1"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/usecase"
+)
+
+// GetEnterpriseInfo는 IP 주소에 대한 GeoIP2 Enterprise 정보를 반환합니다
+func (h *GeoHandler) GetEnterpriseInfo(ctx context.Context, req *proto.IpRequest) (*proto.EnterpriseResponse, error) {
+	if req.Ip == "" {
+		return nil, status.Error(codes.InvalidArgument, "IP 주소가 필요합니다")
+	}
+
+	enterprise, err := h.geoUseCase.GetEnterpriseInfo(req.Ip)
+	if err != nil {
+		return nil, geoIP2Error(err)
+	}
+
+	subdivisions := make([]*proto.SubdivisionInfo, len(enterprise.Subdivisions))
+	for i, subdivision := range enterprise.Subdivisions {
+		subdivisions[i] = &proto.SubdivisionInfo{
+			GeonameId:  uint32(subdivision.GeoNameID),
+			IsoCode:    subdivision.IsoCode,
+			Names:      subdivision.Names,
+			Confidence: uint32(subdivision.Confidence),
+		}
+	}
+
+	response := &proto.EnterpriseResponse{
+		City: &proto.CityInfo{
+			GeonameId: uint32(enterprise.City.GeoNameID),
+			Names:     enterprise.City.Names,
+		},
+		Country:   toProtoCountryInfo(enterprise.Country),
+		Continent: toProtoContinentInfo(enterprise.Continent),
+		Location: &proto.LocationInfo{
+			Latitude:  enterprise.Location.Latitude,
+			Longitude: enterprise.Location.Longitude,
+			TimeZone:  enterprise.Location.TimeZone,
+		},
+		Traits: &proto.EnterpriseTraits{
+			AutonomousSystemNumber:       uint32(enterprise.Traits.AutonomousSystemNumber),
+			AutonomousSystemOrganization: enterprise.Traits.AutonomousSystemOrganization,
+			ConnectionType:               enterprise.Traits.ConnectionType,
+			Domain:                       enterprise.Traits.Domain,
+			Isp:                          enterprise.Traits.ISP,
+			MobileCountryCode:            enterprise.Traits.MobileCountryCode,
+			MobileNetworkCode:            enterprise.Traits.MobileNetworkCode,
+			Organization:                 enterprise.Traits.Organization,
+			UserType:                     enterprise.Traits.UserType,
+			StaticIpScore:                enterprise.Traits.StaticIPScore,
+			IsAnonymousProxy:             enterprise.Traits.IsAnonymousProxy,
+			IsAnycast:                    enterprise.Traits.IsAnycast,
+			IsLegitimateProxy:            enterprise.Traits.IsLegitimateProxy,
+			IsSatelliteProvider:          enterprise.Traits.IsSatelliteProvider,
+		},
+		Postal: &proto.PostalInfo{
+			Code:       enterprise.Postal.Code,
+			Confidence: uint32(enterprise.Postal.Confidence),
+		},
+		Subdivisions:      subdivisions,
+		RegisteredCountry: toProtoCountryInfo(enterprise.RegisteredCountry),
+		RepresentedCountry: &proto.RepresentedCountryInfo{
+			GeonameId:         uint32(enterprise.RepresentedCountry.GeoNameID),
+			IsInEuropeanUnion: enterprise.RepresentedCountry.IsInEuropeanUnion,
+			IsoCode:           enterprise.RepresentedCountry.IsoCode,
+			Names:             enterprise.RepresentedCountry.Names,
+			Type:              enterprise.RepresentedCountry.Type,
+		},
+	}
+
+	return response, nil
+}
+
+// GetISPInfo는 IP 주소에 대한 GeoIP2 ISP 정보를 반환합니다
+func (h *GeoHandler) GetISPInfo(ctx context.Context, req *proto.IpRequest) (*proto.ISPResponse, error) {
+	if req.Ip == "" {
+		return nil, status.Error(codes.InvalidArgument, "IP 주소가 필요합니다")
+	}
+
+	isp, err := h.geoUseCase.GetISPInfo(req.Ip)
+	if err != nil {
+		return nil, geoIP2Error(err)
+	}
+
+	response := &proto.ISPResponse{
+		AutonomousSystemNumber:       uint32(isp.AutonomousSystemNumber),
+		AutonomousSystemOrganization: isp.AutonomousSystemOrganization,
+		Isp:                          isp.ISP,
+		MobileCountryCode:            isp.MobileCountryCode,
+		MobileNetworkCode:            isp.MobileNetworkCode,
+		Organization:                 isp.Organization,
+	}
+
+	return response, nil
+}
+
+// GetDomainInfo는 IP 주소에 대한 GeoIP2 Domain 정보를 반환합니다
+func (h *GeoHandler) GetDomainInfo(ctx context.Context, req *proto.IpRequest) (*proto.DomainResponse, error) {
+	if req.Ip == "" {
+		return nil, status.Error(codes.InvalidArgument, "IP 주소가 필요합니다")
+	}
+
+	domain, err := h.geoUseCase.GetDomainInfo(req.Ip)
+	if err != nil {
+		return nil, geoIP2Error(err)
+	}
+
+	return &proto.DomainResponse{Domain: domain.Domain}, nil
+}
+
+// GetConnectionTypeInfo는 IP 주소에 대한 GeoIP2 Connection Type 정보를 반환합니다
+func (h *GeoHandler) GetConnectionTypeInfo(ctx context.Context, req *proto.IpRequest) (*proto.ConnectionTypeResponse, error) {
+	if req.Ip == "" {
+		return nil, status.Error(codes.InvalidArgument, "IP 주소가 필요합니다")
+	}
+
+	connectionType, err := h.geoUseCase.GetConnectionTypeInfo(req.Ip)
+	if err != nil {
+		return nil, geoIP2Error(err)
+	}
+
+	return &proto.ConnectionTypeResponse{ConnectionType: connectionType.ConnectionType}, nil
+}
+
+// geoIP2Error는 GeoIP2 조회 오류를 gRPC 상태로 변환합니다. 데이터베이스가 설치되지 않았으면 Unimplemented입니다.
+func geoIP2Error(err error) error {
+	switch err {
+	case usecase.ErrInvalidIPAddress:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case usecase.ErrFeatureNotSupported:
+		return status.Error(codes.Unimplemented, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
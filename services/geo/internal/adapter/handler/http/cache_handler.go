@@ -0,0 +1,36 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/usecase"
+)
+
+// CacheHandler는 조회 결과 캐시 통계 HTTP 핸들러입니다
+type CacheHandler struct {
+	cachedGeoData *usecase.CachedGeoData
+}
+
+// NewCacheHandler는 새로운 CacheHandler 인스턴스를 생성합니다
+func NewCacheHandler(cachedGeoData *usecase.CachedGeoData) *CacheHandler {
+	return &CacheHandler{
+		cachedGeoData: cachedGeoData,
+	}
+}
+
+// RegisterRoutes는 Echo 라우터에 핸들러 경로를 등록합니다
+func (h *CacheHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/geo/cache/stats", h.GetStats)
+}
+
+// GetStats는 종합 지리 정보 캐시의 적중/실패 통계를 반환합니다
+// @Summary 조회 결과 캐시 통계
+// @Description 서버 시작 후 종합 지리 정보 조회의 캐시 적중 수, 실패 수, 캐시 저장소 오류 수, 적중률을 반환합니다
+// @Tags geo
+// @Produce json
+// @Success 200 {object} usecase.CacheStats
+// @Router /geo/cache/stats [get]
+func (h *CacheHandler) GetStats(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.cachedGeoData.Stats())
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/usecase"
+)
+
+// DatabaseHandler는 GeoLite2 데이터베이스 상태 HTTP 핸들러입니다
+type DatabaseHandler struct {
+	databaseUseCase *usecase.DatabaseUseCase
+}
+
+// NewDatabaseHandler는 새로운 DatabaseHandler 인스턴스를 생성합니다
+func NewDatabaseHandler(databaseUseCase *usecase.DatabaseUseCase) *DatabaseHandler {
+	return &DatabaseHandler{
+		databaseUseCase: databaseUseCase,
+	}
+}
+
+// RegisterRoutes는 Echo 라우터에 핸들러 경로를 등록합니다
+func (h *DatabaseHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/geo/databases", h.GetDatabases)
+}
+
+// GetDatabases는 조회에 사용 중인 데이터베이스의 빌드 시각과 경과 시간을 반환합니다
+// @Summary GeoLite2 데이터베이스 상태 조회
+// @Description 데이터베이스별 빌드 epoch, 빌드 후 경과 시간(초), 적재 시각, 자동 업데이트 결과를 반환합니다
+// @Tags geo
+// @Produce json
+// @Success 200 {object} map[string][]usecase.DatabaseStatus
+// @Router /geo/databases [get]
+func (h *DatabaseHandler) GetDatabases(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string][]usecase.DatabaseStatus{
+		"databases": h.databaseUseCase.GetDatabaseStatus(),
+	})
+}
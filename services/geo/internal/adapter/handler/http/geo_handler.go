@@ -10,22 +10,34 @@ import (
 // GeoHandler는 지오로케이션 관련 HTTP 핸들러입니다
 type GeoHandler struct {
 	geoUseCase *usecase.GeoUseCase
+	geoData    usecase.GeoDataProvider
 }
 
-// NewGeoHandler는 새로운 GeoHandler 인스턴스를 생성합니다
-func NewGeoHandler(geoUseCase *usecase.GeoUseCase) *GeoHandler {
+// NewGeoHandler는 새로운 GeoHandler 인스턴스를 생성합니다.
+// geoData는 종합 지리 정보 조회에 사용하며, nil이면 geoUseCase를 그대로 사용합니다.
+func NewGeoHandler(geoUseCase *usecase.GeoUseCase, geoData usecase.GeoDataProvider) *GeoHandler {
+	if geoData == nil {
+		geoData = geoUseCase
+	}
 	return &GeoHandler{
 		geoUseCase: geoUseCase,
+		geoData:    geoData,
 	}
 }
 
 // RegisterRoutes는 Echo 라우터에 핸들러 경로를 등록합니다
 func (h *GeoHandler) RegisterRoutes(e *echo.Echo) {
 	e.GET("/geo/ip/:ip", h.GetGeoData)
+	e.GET("/geo/me", h.GetMyGeoData)
 	e.GET("/geo/city/:ip", h.GetCityInfo)
 	e.GET("/geo/country/:ip", h.GetCountryInfo)
 	e.GET("/geo/asn/:ip", h.GetASNInfo)
 	e.GET("/geo/anonymous/:ip", h.CheckAnonymousIP)
+	e.GET("/geo/enterprise/:ip", h.GetEnterpriseInfo)
+	e.GET("/geo/isp/:ip", h.GetISPInfo)
+	e.GET("/geo/domain/:ip", h.GetDomainInfo)
+	e.GET("/geo/connection-type/:ip", h.GetConnectionTypeInfo)
+	e.POST("/geo/batch", h.BatchLookup)
 }
 
 // GetGeoData는 IP 주소에 대한 종합적인 지리 정보를 반환합니다
@@ -35,6 +47,8 @@ func (h *GeoHandler) RegisterRoutes(e *echo.Echo) {
 // @Accept json
 // @Produce json
 // @Param ip path string true "IP 주소"
+// @Param lang query string false "이름에 사용할 언어(예: ko 또는 ko,en). 없으면 Accept-Language 헤더를 사용합니다"
+// @Param Accept-Language header string false "이름에 사용할 언어"
 // @Success 200 {object} usecase.GeoData
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -47,7 +61,33 @@ func (h *GeoHandler) GetGeoData(c echo.Context) error {
 		})
 	}
 
-	geoData, err := h.geoUseCase.GetGeoData(ipStr)
+	geoData, err := h.geoData.GetGeoData(ipStr, requestLanguages(c)...)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == usecase.ErrInvalidIPAddress {
+			status = http.StatusBadRequest
+		}
+		return c.JSON(status, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, geoData)
+}
+
+// GetMyGeoData는 요청한 클라이언트 IP에 대한 종합적인 지리 정보를 반환합니다
+// @Summary 요청한 클라이언트의 지리 정보 조회
+// @Description 신뢰하는 프록시의 X-Forwarded-For, X-Real-IP, Forwarded 헤더를 반영한 클라이언트 IP의 지리 정보를 반환합니다
+// @Tags geo
+// @Accept json
+// @Produce json
+// @Param lang query string false "이름에 사용할 언어(예: ko 또는 ko,en). 없으면 Accept-Language 헤더를 사용합니다"
+// @Success 200 {object} usecase.GeoData
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /geo/me [get]
+func (h *GeoHandler) GetMyGeoData(c echo.Context) error {
+	geoData, err := h.geoData.GetGeoData(c.RealIP(), requestLanguages(c)...)
 	if err != nil {
 		status := http.StatusInternalServerError
 		if err == usecase.ErrInvalidIPAddress {
@@ -61,6 +101,14 @@ func (h *GeoHandler) GetGeoData(c echo.Context) error {
 	return c.JSON(http.StatusOK, geoData)
 }
 
+// requestLanguages는 lang 쿼리, 없으면 Accept-Language 헤더에서 이름에 사용할 언어 목록을 읽습니다
+func requestLanguages(c echo.Context) []string {
+	if lang := c.QueryParam("lang"); lang != "" {
+		return usecase.ParseLanguages(lang)
+	}
+	return usecase.ParseLanguages(c.Request().Header.Values("Accept-Language")...)
+}
+
 // GetCityInfo는 IP 주소에 대한 도시 정보를 반환합니다
 // @Summary IP 주소의 도시 정보 조회
 // @Description IP 주소에 대한 도시 정보를 반환합니다
@@ -204,3 +252,50 @@ func (h *GeoHandler) CheckAnonymousIP(c echo.Context) error {
 		"feature_support":  true,
 	})
 }
+
+// BatchLookupRequest는 일괄 조회 요청 본문입니다
+type BatchLookupRequest struct {
+	IPs    []string `json:"ips"`
+	Fields []string `json:"fields"`
+}
+
+// BatchLookup은 여러 IP 주소를 한 번에 조회하여 요청 순서대로 결과를 반환합니다
+// @Summary 여러 IP 주소의 지리 정보 일괄 조회
+// @Description 최대 1000개의 IP 주소를 조회하여 IP별 결과와 오류를 반환합니다. fields(city, country, asn, anonymous)로 조회 항목을 선택하며, 비어 있으면 모두 조회합니다
+// @Tags geo
+// @Accept json
+// @Produce json
+// @Param request body BatchLookupRequest true "조회할 IP 주소와 항목"
+// @Success 200 {object} map[string][]usecase.LookupResult
+// @Failure 400 {object} map[string]string
+// @Router /geo/batch [post]
+func (h *GeoHandler) BatchLookup(c echo.Context) error {
+	var req BatchLookupRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "잘못된 요청 형식입니다",
+		})
+	}
+
+	fields, err := usecase.ParseLookupFields(req.Fields)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	results, err := h.geoUseCase.BatchLookup(c.Request().Context(), req.IPs, fields)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == usecase.ErrBatchTooLarge {
+			status = http.StatusBadRequest
+		}
+		return c.JSON(status, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string][]usecase.LookupResult{
+		"results": results,
+	})
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/usecase"
+)
+
+// GeoFenceHandler는 지역 제한 규칙 HTTP 핸들러입니다
+type GeoFenceHandler struct {
+	geoFence *usecase.GeoFenceUseCase
+}
+
+// NewGeoFenceHandler는 새로운 GeoFenceHandler 인스턴스를 생성합니다
+func NewGeoFenceHandler(geoFence *usecase.GeoFenceUseCase) *GeoFenceHandler {
+	return &GeoFenceHandler{
+		geoFence: geoFence,
+	}
+}
+
+// RegisterRoutes는 Echo 라우터에 핸들러 경로를 등록합니다
+func (h *GeoFenceHandler) RegisterRoutes(e *echo.Echo) {
+	e.POST("/geo/evaluate", h.Evaluate)
+	e.GET("/geo/rule-sets", h.GetRuleSets)
+}
+
+// EvaluateRequest는 지역 제한 규칙 평가 요청 본문입니다
+type EvaluateRequest struct {
+	RuleSet string `json:"rule_set"`
+	// IP가 비어 있으면 요청한 클라이언트의 IP를 평가합니다
+	IP string `json:"ip"`
+}
+
+// Evaluate는 IP 주소에 지역 제한 규칙 집합을 평가하여 허용 여부와 일치한 규칙을 반환합니다
+// @Summary 지역 제한 규칙 평가
+// @Description 규칙 집합의 규칙을 순서대로 평가하여 처음 일치한 규칙의 동작을 반환합니다. 일치하는 규칙이 없으면 기본 동작을 따르고 matched_rule은 비어 있습니다. ip가 없으면 요청한 클라이언트의 IP를 평가합니다
+// @Tags geo
+// @Accept json
+// @Produce json
+// @Param request body EvaluateRequest true "규칙 집합 이름과 IP 주소"
+// @Success 200 {object} usecase.GeoFenceDecision
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /geo/evaluate [post]
+func (h *GeoFenceHandler) Evaluate(c echo.Context) error {
+	var req EvaluateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "잘못된 요청 형식입니다",
+		})
+	}
+	if req.RuleSet == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "규칙 집합 이름이 필요합니다",
+		})
+	}
+	if req.IP == "" {
+		req.IP = c.RealIP()
+	}
+
+	decision, err := h.geoFence.Evaluate(req.RuleSet, req.IP)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, usecase.ErrGeoFenceRuleSetNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, usecase.ErrInvalidIPAddress):
+			status = http.StatusBadRequest
+		}
+		return c.JSON(status, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, decision)
+}
+
+// GetRuleSets는 적재된 지역 제한 규칙 집합과 그 버전을 반환합니다
+// @Summary 지역 제한 규칙 집합 조회
+// @Description 평가에 사용 중인 규칙 집합, 내용으로 만든 버전, 적재 시각을 반환합니다
+// @Tags geo
+// @Produce json
+// @Success 200 {object} usecase.GeoFenceStatus
+// @Router /geo/rule-sets [get]
+func (h *GeoFenceHandler) GetRuleSets(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.geoFence.Status())
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/usecase"
+)
+
+// GetEnterpriseInfo는 IP 주소에 대한 GeoIP2 Enterprise 정보를 반환합니다
+// @Summary IP 주소의 Enterprise 정보 조회
+// @Description GeoIP2 Enterprise 데이터베이스가 설치된 경우 도시, 국가, 행정구역, 우편번호, 네트워크 특성 정보를 반환합니다
+// @Tags geo
+// @Accept json
+// @Produce json
+// @Param ip path string true "IP 주소"
+// @Success 200 {object} entity.Enterprise
+// @Failure 400 {object} map[string]string
+// @Failure 501 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /geo/enterprise/{ip} [get]
+func (h *GeoHandler) GetEnterpriseInfo(c echo.Context) error {
+	ipStr := c.Param("ip")
+	if ipStr == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "IP 주소가 필요합니다",
+		})
+	}
+
+	enterprise, err := h.geoUseCase.GetEnterpriseInfo(ipStr)
+	if err != nil {
+		return c.JSON(geoIP2ErrorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, enterprise)
+}
+
+// GetISPInfo는 IP 주소에 대한 GeoIP2 ISP 정보를 반환합니다
+// @Summary IP 주소의 ISP 정보 조회
+// @Description GeoIP2 ISP 데이터베이스가 설치된 경우 ISP, 조직, ASN, 이동통신 코드 정보를 반환합니다
+// @Tags geo
+// @Accept json
+// @Produce json
+// @Param ip path string true "IP 주소"
+// @Success 200 {object} entity.ISP
+// @Failure 400 {object} map[string]string
+// @Failure 501 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /geo/isp/{ip} [get]
+func (h *GeoHandler) GetISPInfo(c echo.Context) error {
+	ipStr := c.Param("ip")
+	if ipStr == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "IP 주소가 필요합니다",
+		})
+	}
+
+	isp, err := h.geoUseCase.GetISPInfo(ipStr)
+	if err != nil {
+		return c.JSON(geoIP2ErrorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, isp)
+}
+
+// GetDomainInfo는 IP 주소에 대한 GeoIP2 Domain 정보를 반환합니다
+// @Summary IP 주소의 도메인 정보 조회
+// @Description GeoIP2 Domain 데이터베이스가 설치된 경우 IP 주소에 연결된 2차 도메인을 반환합니다
+// @Tags geo
+// @Accept json
+// @Produce json
+// @Param ip path string true "IP 주소"
+// @Success 200 {object} entity.Domain
+// @Failure 400 {object} map[string]string
+// @Failure 501 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /geo/domain/{ip} [get]
+func (h *GeoHandler) GetDomainInfo(c echo.Context) error {
+	ipStr := c.Param("ip")
+	if ipStr == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "IP 주소가 필요합니다",
+		})
+	}
+
+	domain, err := h.geoUseCase.GetDomainInfo(ipStr)
+	if err != nil {
+		return c.JSON(geoIP2ErrorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, domain)
+}
+
+// GetConnectionTypeInfo는 IP 주소에 대한 GeoIP2 Connection Type 정보를 반환합니다
+// @Summary IP 주소의 연결 유형 조회
+// @Description GeoIP2 Connection Type 데이터베이스가 설치된 경우 연결 유형(Cable/DSL, Cellular 등)을 반환합니다
+// @Tags geo
+// @Accept json
+// @Produce json
+// @Param ip path string true "IP 주소"
+// @Success 200 {object} entity.ConnectionType
+// @Failure 400 {object} map[string]string
+// @Failure 501 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /geo/connection-type/{ip} [get]
+func (h *GeoHandler) GetConnectionTypeInfo(c echo.Context) error {
+	ipStr := c.Param("ip")
+	if ipStr == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "IP 주소가 필요합니다",
+		})
+	}
+
+	connectionType, err := h.geoUseCase.GetConnectionTypeInfo(ipStr)
+	if err != nil {
+		return c.JSON(geoIP2ErrorStatus(err), map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, connectionType)
+}
+
+// geoIP2ErrorStatus는 GeoIP2 조회 오류에 맞는 HTTP 상태 코드를 반환합니다. 데이터베이스가 설치되지 않았으면 501입니다.
+func geoIP2ErrorStatus(err error) int {
+	switch err {
+	case usecase.ErrInvalidIPAddress:
+		return http.StatusBadRequest
+	case usecase.ErrFeatureNotSupported:
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/usecase"
+)
+
+// stubGeoLite2는 빈 결과를 돌려주는 GeoLite2Repository입니다
+type stubGeoLite2 struct{}
+
+func (stubGeoLite2) GetCity(net.IP) (entity.City, error)       { return entity.City{}, nil }
+func (stubGeoLite2) GetCountry(net.IP) (entity.Country, error) { return entity.Country{}, nil }
+func (stubGeoLite2) GetASN(net.IP) (entity.ASN, error)         { return entity.ASN{}, nil }
+func (stubGeoLite2) Close() error                              { return nil }
+
+func TestGeoIP2EndpointsWithoutDatabase(t *testing.T) {
+	e := echo.New()
+	NewGeoHandler(usecase.NewGeoUseCaseWithGeoLite2(stubGeoLite2{}), nil).RegisterRoutes(e)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/geo/enterprise/1.2.3.4", http.StatusNotImplemented},
+		{"/geo/isp/1.2.3.4", http.StatusNotImplemented},
+		{"/geo/domain/1.2.3.4", http.StatusNotImplemented},
+		{"/geo/connection-type/1.2.3.4", http.StatusNotImplemented},
+		{"/geo/city/1.2.3.4", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.want {
+				t.Errorf("GET %s = %d, want %d", tt.path, rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -438,18 +438,24 @@ type GeoLite2All struct {
 
 // NewGeoLite2Repository는 GeoLite2 통합 리포지토리를 생성합니다
 func NewGeoLite2Repository(cityDbPath, countryDbPath, asnDbPath string) (repository.GeoLite2Repository, error) {
-	cityReader, err := geolite.Open(cityDbPath)
+	return openGeoLite2All(cityDbPath, countryDbPath, asnDbPath)
+}
+
+// openGeoLite2All은 세 데이터베이스 파일을 열고 각 파일이 기대하는 조회를 지원하는지 검증합니다.
+// 하나라도 실패하면 이미 연 리더를 닫고 오류를 반환합니다.
+func openGeoLite2All(cityDbPath, countryDbPath, asnDbPath string) (*GeoLite2All, error) {
+	cityReader, err := geolite.OpenFor(cityDbPath, "City")
 	if err != nil {
 		return nil, err
 	}
 
-	countryReader, err := geolite.Open(countryDbPath)
+	countryReader, err := geolite.OpenFor(countryDbPath, "Country")
 	if err != nil {
 		cityReader.Close()
 		return nil, err
 	}
 
-	asnReader, err := geolite.Open(asnDbPath)
+	asnReader, err := geolite.OpenFor(asnDbPath, "ASN")
 	if err != nil {
 		cityReader.Close()
 		countryReader.Close()
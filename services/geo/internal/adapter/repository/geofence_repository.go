@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/repository"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultGeoFenceRedisKey는 규칙 집합을 저장하는 Redis 해시의 기본 키입니다
+const DefaultGeoFenceRedisKey = "geo:fence:rule_sets"
+
+// geoFenceFile은 규칙 집합 파일의 형식입니다
+type geoFenceFile struct {
+	RuleSets []entity.GeoFenceRuleSet `yaml:"rule_sets"`
+}
+
+// FileGeoFence는 YAML 또는 JSON 파일에서 규칙 집합을 읽는 GeoFenceRepository 구현체입니다.
+// 조회할 때마다 파일을 다시 읽으므로 파일을 고치면 다음 조회부터 반영됩니다.
+type FileGeoFence struct {
+	path string
+}
+
+// NewFileGeoFenceRepository는 파일 규칙 집합 리포지토리를 생성합니다
+func NewFileGeoFenceRepository(path string) *FileGeoFence {
+	return &FileGeoFence{path: path}
+}
+
+var _ repository.GeoFenceRepository = (*FileGeoFence)(nil)
+
+// ListRuleSets는 파일에 정의된 규칙 집합을 파일의 순서대로 반환합니다
+func (r *FileGeoFence) ListRuleSets(ctx context.Context) ([]entity.GeoFenceRuleSet, error) {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("지역 제한 규칙 파일 읽기 실패: %w", err)
+	}
+
+	// JSON도 YAML 문법에 포함되므로 같은 방법으로 읽습니다
+	var file geoFenceFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("지역 제한 규칙 파일 해석 실패(%s): %w", r.path, err)
+	}
+	return file.RuleSets, nil
+}
+
+// RedisGeoFence는 Redis 해시에 규칙 집합을 저장하는 GeoFenceRepository 구현체입니다.
+// 해시의 필드는 규칙 집합 이름, 값은 규칙 집합 JSON이며 여러 인스턴스가 같은 규칙을 공유할 때 사용합니다.
+type RedisGeoFence struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisGeoFenceRepository는 Redis 규칙 집합 리포지토리를 생성합니다. key가 비어 있으면 DefaultGeoFenceRedisKey를 사용합니다.
+func NewRedisGeoFenceRepository(client *redis.Client, key string) *RedisGeoFence {
+	if key == "" {
+		key = DefaultGeoFenceRedisKey
+	}
+	return &RedisGeoFence{client: client, key: key}
+}
+
+var _ repository.GeoFenceRepository = (*RedisGeoFence)(nil)
+
+// ListRuleSets는 해시에 저장된 규칙 집합을 이름 순으로 반환합니다. 값에 이름이 없으면 필드 이름을 사용합니다.
+func (r *RedisGeoFence) ListRuleSets(ctx context.Context) ([]entity.GeoFenceRuleSet, error) {
+	values, err := r.client.HGetAll(ctx, r.key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("지역 제한 규칙 조회 실패: %w", err)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ruleSets := make([]entity.GeoFenceRuleSet, 0, len(names))
+	for _, name := range names {
+		var ruleSet entity.GeoFenceRuleSet
+		if err := json.Unmarshal([]byte(values[name]), &ruleSet); err != nil {
+			return nil, fmt.Errorf("지역 제한 규칙 집합 %s 해석 실패: %w", name, err)
+		}
+		if ruleSet.Name == "" {
+			ruleSet.Name = name
+		}
+		ruleSets = append(ruleSets, ruleSet)
+	}
+	return ruleSets, nil
+}
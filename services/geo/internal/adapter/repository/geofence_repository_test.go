@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+)
+
+func TestFileGeoFenceReadsYAMLAndJSON(t *testing.T) {
+	want := []entity.GeoFenceRuleSet{{
+		Name:          "payments",
+		DefaultAction: entity.GeoFenceAllow,
+		UnknownAction: entity.GeoFenceDeny,
+		Rules: []entity.GeoFenceRule{
+			{Name: "office", Action: entity.GeoFenceAllow, CIDRs: []string{"10.0.0.0/8"}},
+			{Name: "sanctioned", Action: entity.GeoFenceDeny, Countries: []string{"KP"}, ASNs: []uint{4766}, Anonymous: []string{"tor"}},
+		},
+	}}
+
+	files := map[string]string{
+		"rules.yaml": `
+rule_sets:
+  - name: payments
+    default_action: allow
+    unknown_action: deny
+    rules:
+      - name: office
+        action: allow
+        cidrs: [10.0.0.0/8]
+      - name: sanctioned
+        action: deny
+        countries: [KP]
+        asns: [4766]
+        anonymous: [tor]
+`,
+		"rules.json": `{"rule_sets": [{"name": "payments", "default_action": "allow", "unknown_action": "deny", "rules": [
+			{"name": "office", "action": "allow", "cidrs": ["10.0.0.0/8"]},
+			{"name": "sanctioned", "action": "deny", "countries": ["KP"], "asns": [4766], "anonymous": ["tor"]}]}]}`,
+	}
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		ruleSets, err := NewFileGeoFenceRepository(path).ListRuleSets(context.Background())
+		if err != nil {
+			t.Fatalf("%s: ListRuleSets() error = %v", name, err)
+		}
+		if !reflect.DeepEqual(ruleSets, want) {
+			t.Errorf("%s: ListRuleSets() = %+v, want %+v", name, ruleSets, want)
+		}
+	}
+}
+
+func TestFileGeoFenceReadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	repo := NewFileGeoFenceRepository(path)
+
+	if _, err := repo.ListRuleSets(context.Background()); err == nil {
+		t.Error("ListRuleSets() of a missing file should fail")
+	}
+
+	for _, name := range []string{"first", "second"} {
+		if err := os.WriteFile(path, []byte("rule_sets:\n  - name: "+name+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		ruleSets, err := repo.ListRuleSets(context.Background())
+		if err != nil || len(ruleSets) != 1 || ruleSets[0].Name != name {
+			t.Errorf("ListRuleSets() = %+v, %v, want rule set %s", ruleSets, err, name)
+		}
+	}
+}
+
+func TestFileGeoFenceReadsExample(t *testing.T) {
+	repo := NewFileGeoFenceRepository(filepath.Join("..", "..", "..", "..", "..", "configs", "example", "geofence.yaml"))
+
+	ruleSets, err := repo.ListRuleSets(context.Background())
+	if err != nil {
+		t.Fatalf("ListRuleSets() error = %v", err)
+	}
+	if len(ruleSets) == 0 {
+		t.Error("example defines no rule sets")
+	}
+}
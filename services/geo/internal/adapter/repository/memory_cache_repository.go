@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"container/list"
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/repository"
+)
+
+const (
+	defaultMemoryCacheCapacity = 100000
+	defaultMemoryCacheShards   = 16
+)
+
+// memoryCacheEntry는 LRU 목록의 항목입니다. expiresAt이 0이면 만료되지 않습니다.
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// memoryCacheShard는 자체 잠금과 LRU 목록을 가진 캐시 조각입니다
+type memoryCacheShard struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List // 앞쪽이 최근에 사용한 항목입니다
+}
+
+// MemoryCache는 키 해시로 나눈 조각마다 LRU를 유지하는 인메모리 CacheRepository 구현체입니다.
+// 조각마다 잠금이 따로 있어 동시 조회가 한 잠금에 몰리지 않으며, 조각이 가득 차면 가장 오래 사용하지 않은 항목을 버립니다.
+// 만료된 항목은 조회할 때 지웁니다.
+type MemoryCache struct {
+	shards []*memoryCacheShard
+	now    func() time.Time
+}
+
+// NewMemoryCacheRepository는 전체 capacity개 항목을 shards개 조각에 나누어 담는 인메모리 캐시를 생성합니다.
+// 0 이하의 값은 기본값(100000개, 16조각)을 사용합니다.
+func NewMemoryCacheRepository(capacity, shards int) *MemoryCache {
+	if capacity <= 0 {
+		capacity = defaultMemoryCacheCapacity
+	}
+	if shards <= 0 {
+		shards = defaultMemoryCacheShards
+	}
+	if shards > capacity {
+		shards = capacity
+	}
+
+	c := &MemoryCache{
+		shards: make([]*memoryCacheShard, shards),
+		now:    time.Now,
+	}
+	perShard := (capacity + shards - 1) / shards
+	for i := range c.shards {
+		c.shards[i] = &memoryCacheShard{
+			capacity: perShard,
+			items:    make(map[string]*list.Element),
+			order:    list.New(),
+		}
+	}
+	return c
+}
+
+var _ repository.CacheRepository = (*MemoryCache)(nil)
+
+// Get 키로 값 조회. 없거나 만료되었으면 repository.ErrCacheMiss를 반환합니다.
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	shard := c.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	elem, ok := shard.lookup(key, c.now())
+	if !ok {
+		return nil, repository.ErrCacheMiss
+	}
+	shard.order.MoveToFront(elem)
+	return elem.Value.(*memoryCacheEntry).value, nil
+}
+
+// Set 키-값 저장. expiration이 0이면 만료되지 않습니다.
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	shard := c.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	var expiresAt time.Time
+	if expiration > 0 {
+		expiresAt = c.now().Add(expiration)
+	}
+
+	if elem, ok := shard.items[key]; ok {
+		entry := elem.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		shard.order.MoveToFront(elem)
+		return nil
+	}
+
+	shard.items[key] = shard.order.PushFront(&memoryCacheEntry{key: key, value: value, expiresAt: expiresAt})
+	if shard.order.Len() > shard.capacity {
+		shard.remove(shard.order.Back())
+	}
+	return nil
+}
+
+// Delete 키 삭제
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	shard := c.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if elem, ok := shard.items[key]; ok {
+		shard.remove(elem)
+	}
+	return nil
+}
+
+// Exists 키 존재 여부 확인
+func (c *MemoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	shard := c.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	_, ok := shard.lookup(key, c.now())
+	return ok, nil
+}
+
+// Expire 키 만료 시간 설정. 없는 키는 repository.ErrCacheMiss를 반환합니다.
+func (c *MemoryCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	shard := c.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	elem, ok := shard.lookup(key, c.now())
+	if !ok {
+		return repository.ErrCacheMiss
+	}
+	entry := elem.Value.(*memoryCacheEntry)
+	if expiration > 0 {
+		entry.expiresAt = c.now().Add(expiration)
+	} else {
+		entry.expiresAt = time.Time{}
+	}
+	return nil
+}
+
+// Len은 캐시에 담긴 항목 수를 반환합니다. 아직 지워지지 않은 만료 항목도 포함합니다.
+func (c *MemoryCache) Len() int {
+	total := 0
+	for _, shard := range c.shards {
+		shard.mu.Lock()
+		total += shard.order.Len()
+		shard.mu.Unlock()
+	}
+	return total
+}
+
+func (c *MemoryCache) shard(key string) *memoryCacheShard {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return c.shards[h.Sum32()%uint32(len(c.shards))]
+}
+
+// lookup은 만료되지 않은 항목을 찾고, 만료된 항목은 지웁니다. 잠금을 잡은 상태에서 호출해야 합니다.
+func (s *memoryCacheShard) lookup(key string, now time.Time) (*list.Element, bool) {
+	elem, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryCacheEntry)
+	if !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt) {
+		s.remove(elem)
+		return nil, false
+	}
+	return elem, true
+}
+
+func (s *memoryCacheShard) remove(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.items, elem.Value.(*memoryCacheEntry).key)
+}
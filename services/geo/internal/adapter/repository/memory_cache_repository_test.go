@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/repository"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCacheRepository(3, 1)
+
+	for _, key := range []string{"a", "b", "c"} {
+		cache.Set(ctx, key, []byte(key), 0)
+	}
+	// a를 사용하면 가장 오래 사용하지 않은 항목은 b가 됩니다
+	if _, err := cache.Get(ctx, "a"); err != nil {
+		t.Fatalf("Get(a) error = %v", err)
+	}
+	cache.Set(ctx, "d", []byte("d"), 0)
+
+	if _, err := cache.Get(ctx, "b"); !errors.Is(err, repository.ErrCacheMiss) {
+		t.Errorf("Get(b) error = %v, want ErrCacheMiss", err)
+	}
+	for _, key := range []string{"a", "c", "d"} {
+		if value, err := cache.Get(ctx, key); err != nil || string(value) != key {
+			t.Errorf("Get(%s) = %q, %v", key, value, err)
+		}
+	}
+	if cache.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", cache.Len())
+	}
+}
+
+func TestMemoryCacheExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCacheRepository(10, 2)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	cache.Set(ctx, "short", []byte("1"), time.Minute)
+	cache.Set(ctx, "forever", []byte("2"), 0)
+	cache.Set(ctx, "extended", []byte("3"), time.Minute)
+	if err := cache.Expire(ctx, "extended", time.Hour); err != nil {
+		t.Fatalf("Expire() error = %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if ok, _ := cache.Exists(ctx, "short"); ok {
+		t.Error("expired entry still exists")
+	}
+	for _, key := range []string{"forever", "extended"} {
+		if ok, _ := cache.Exists(ctx, key); !ok {
+			t.Errorf("%s missing", key)
+		}
+	}
+	if err := cache.Expire(ctx, "short", time.Hour); !errors.Is(err, repository.ErrCacheMiss) {
+		t.Errorf("Expire(short) error = %v, want ErrCacheMiss", err)
+	}
+
+	cache.Delete(ctx, "forever")
+	if ok, _ := cache.Exists(ctx, "forever"); ok {
+		t.Error("deleted entry still exists")
+	}
+}
+
+func TestMemoryCacheSpreadsKeysAcrossShards(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCacheRepository(1000, 8)
+
+	for i := 0; i < 500; i++ {
+		cache.Set(ctx, fmt.Sprintf("geo:v1:10.0.%d.0/24", i), []byte("x"), 0)
+	}
+	for i, shard := range cache.shards {
+		if shard.order.Len() == 0 {
+			t.Errorf("shard %d is empty", i)
+		}
+	}
+	if cache.Len() != 500 {
+		t.Errorf("Len() = %d, want 500", cache.Len())
+	}
+}
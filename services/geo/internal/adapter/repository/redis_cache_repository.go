@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/repository"
+)
+
+// RedisCache는 Redis를 사용하는 CacheRepository 구현체입니다. 여러 인스턴스가 캐시를 공유할 때 사용합니다.
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCacheRepository는 Redis 캐시 리포지토리를 생성합니다
+func NewRedisCacheRepository(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+var _ repository.CacheRepository = (*RedisCache)(nil)
+
+// Get 키로 값 조회. 없으면 repository.ErrCacheMiss를 반환합니다.
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, repository.ErrCacheMiss
+	}
+	return value, err
+}
+
+// Set 키-값 저장. expiration이 0이면 만료되지 않습니다.
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	return c.client.Set(ctx, key, value, expiration).Err()
+}
+
+// Delete 키 삭제
+func (c *RedisCache) Delete(ctx context.Context, key string) error {
+	return c.client.Del(ctx, key).Err()
+}
+
+// Exists 키 존재 여부 확인
+func (c *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := c.client.Exists(ctx, key).Result()
+	return n > 0, err
+}
+
+// Expire 키 만료 시간 설정. expiration이 0이면 만료를 없애고, 없는 키는 repository.ErrCacheMiss를 반환합니다.
+func (c *RedisCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	var ok bool
+	var err error
+	if expiration > 0 {
+		ok, err = c.client.Expire(ctx, key, expiration).Result()
+	} else if ok, err = c.client.Persist(ctx, key).Result(); err == nil && !ok {
+		// 만료 시간이 없던 키도 Persist는 false를 반환합니다
+		ok, err = c.Exists(ctx, key)
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return repository.ErrCacheMiss
+	}
+	return nil
+}
+
+// Close는 Redis 연결을 닫습니다
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
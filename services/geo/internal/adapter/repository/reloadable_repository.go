@@ -0,0 +1,481 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/repository"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/infrastructure/geolite"
+	"go.uber.org/zap"
+)
+
+// ErrRepositoryClosed는 닫힌 리포지토리에서 조회하거나 다시 열려고 할 때 반환됩니다
+var ErrRepositoryClosed = errors.New("GeoLite2 리포지토리가 닫혔습니다")
+
+// fileState는 변경 감지에 사용하는 데이터베이스 파일의 크기와 수정 시각입니다.
+// 선택 데이터베이스 파일이 없으면 0 값입니다.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// databaseCount는 감시하는 데이터베이스 파일 수입니다(GeoLite2 3개와 선택 GeoIP2 5개)
+const databaseCount = 8
+
+// OptionalDatabasePaths는 파일이 있을 때만 적재하는 GeoIP2 데이터베이스 경로입니다. 빈 경로는 사용하지 않습니다.
+type OptionalDatabasePaths struct {
+	Enterprise     string
+	AnonymousIP    string
+	ISP            string
+	Domain         string
+	ConnectionType string
+}
+
+// ReloadableOption은 ReloadableGeoLite2 생성을 위한 옵션 함수 타입입니다
+type ReloadableOption func(*ReloadableGeoLite2)
+
+// WithOptionalDatabases는 GeoIP2 Enterprise, Anonymous IP, ISP, Domain, Connection Type 데이터베이스가
+// 설치되어 있으면 함께 적재하도록 설정합니다
+func WithOptionalDatabases(paths OptionalDatabasePaths) ReloadableOption {
+	return func(r *ReloadableGeoLite2) {
+		r.optional = paths
+	}
+}
+
+// geoIP2Set은 한 번에 적재된 리더 묶음입니다. 선택 데이터베이스는 파일이 없으면 nil입니다.
+type geoIP2Set struct {
+	*GeoLite2All
+	enterprise     *GeoIP2Enterprise
+	anonymousIP    *GeoIP2AnonymousIP
+	isp            *GeoIP2ISP
+	domain         *GeoIP2Domain
+	connectionType *GeoIP2ConnectionType
+}
+
+// loadedReader는 적재된 리더와 그 파일 경로입니다
+type loadedReader struct {
+	path   string
+	reader *geolite.Reader
+}
+
+// readers는 적재된 모든 리더를 경로와 함께 반환합니다
+func (s *geoIP2Set) readers(r *ReloadableGeoLite2) []loadedReader {
+	readers := []loadedReader{
+		{r.cityDbPath, s.GeoIP2City.reader},
+		{r.countryDbPath, s.GeoIP2Country.reader},
+		{r.asnDbPath, s.GeoLite2ASN.reader},
+	}
+	if s.enterprise != nil {
+		readers = append(readers, loadedReader{r.optional.Enterprise, s.enterprise.reader})
+	}
+	if s.anonymousIP != nil {
+		readers = append(readers, loadedReader{r.optional.AnonymousIP, s.anonymousIP.reader})
+	}
+	if s.isp != nil {
+		readers = append(readers, loadedReader{r.optional.ISP, s.isp.reader})
+	}
+	if s.domain != nil {
+		readers = append(readers, loadedReader{r.optional.Domain, s.domain.reader})
+	}
+	if s.connectionType != nil {
+		readers = append(readers, loadedReader{r.optional.ConnectionType, s.connectionType.reader})
+	}
+	return readers
+}
+
+// version은 적재된 데이터베이스 유형과 빌드 epoch로 만든 짧은 해시입니다
+func (s *geoIP2Set) version(r *ReloadableGeoLite2) string {
+	h := fnv.New64a()
+	for _, db := range s.readers(r) {
+		metadata := db.reader.Metadata()
+		fmt.Fprintf(h, "%s:%d;", metadata.DatabaseType, metadata.BuildEpoch)
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// Close는 적재된 모든 리더의 리소스를 해제합니다
+func (s *geoIP2Set) Close() error {
+	if s.enterprise != nil {
+		s.enterprise.Close()
+	}
+	if s.anonymousIP != nil {
+		s.anonymousIP.Close()
+	}
+	if s.isp != nil {
+		s.isp.Close()
+	}
+	if s.domain != nil {
+		s.domain.Close()
+	}
+	if s.connectionType != nil {
+		s.connectionType.Close()
+	}
+	return s.GeoLite2All.Close()
+}
+
+// ReloadableGeoLite2는 GeoLite2 데이터베이스 파일이 교체되면 다시 여는 통합 리포지토리입니다.
+// 조회는 읽기 잠금을 잡은 채로 현재 리더를 사용하고, 교체는 새 리더를 모두 열고 검증한 뒤에만
+// 쓰기 잠금 아래에서 포인터를 바꿉니다. 쓰기 잠금은 진행 중인 조회가 모두 끝나야 잡히므로,
+// 교체 후 이전 리더를 닫아도 이를 사용하는 조회는 남아 있지 않습니다.
+// 선택 GeoIP2 데이터베이스는 파일이 생기거나 없어지는 것도 변경으로 보고 다시 엽니다.
+type ReloadableGeoLite2 struct {
+	cityDbPath    string
+	countryDbPath string
+	asnDbPath     string
+	optional      OptionalDatabasePaths
+	logger        *zap.Logger
+
+	mu       sync.RWMutex
+	current  *geoIP2Set
+	loadedAt time.Time
+	version  string
+
+	// reloadMu는 감시 루프와 SIGHUP 등에서 동시에 들어온 재적재를 직렬화합니다
+	reloadMu sync.Mutex
+	loaded   [databaseCount]fileState
+}
+
+// NewReloadableGeoLite2Repository는 다시 열 수 있는 GeoLite2 통합 리포지토리를 생성합니다
+func NewReloadableGeoLite2Repository(cityDbPath, countryDbPath, asnDbPath string, logger *zap.Logger, opts ...ReloadableOption) (*ReloadableGeoLite2, error) {
+	r := &ReloadableGeoLite2{
+		cityDbPath:    cityDbPath,
+		countryDbPath: countryDbPath,
+		asnDbPath:     asnDbPath,
+		logger:        logger,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	states, err := r.fileStates()
+	if err != nil {
+		return nil, err
+	}
+	current, err := r.open()
+	if err != nil {
+		return nil, err
+	}
+	r.current = current
+	r.loadedAt = time.Now()
+	r.version = current.version(r)
+	r.loaded = states
+
+	return r, nil
+}
+
+var _ repository.ReloadableGeoLite2Repository = (*ReloadableGeoLite2)(nil)
+
+// GetCity는 IP 주소에 해당하는 도시 정보를 반환합니다
+func (r *ReloadableGeoLite2) GetCity(ipAddress net.IP) (entity.City, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.current == nil {
+		return entity.City{}, ErrRepositoryClosed
+	}
+	return r.current.GetCity(ipAddress)
+}
+
+// GetCountry는 IP 주소에 해당하는 국가 정보를 반환합니다
+func (r *ReloadableGeoLite2) GetCountry(ipAddress net.IP) (entity.Country, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.current == nil {
+		return entity.Country{}, ErrRepositoryClosed
+	}
+	return r.current.GetCountry(ipAddress)
+}
+
+// GetASN은 IP 주소에 해당하는 ASN 정보를 반환합니다
+func (r *ReloadableGeoLite2) GetASN(ipAddress net.IP) (entity.ASN, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.current == nil {
+		return entity.ASN{}, ErrRepositoryClosed
+	}
+	return r.current.GetASN(ipAddress)
+}
+
+// GetEnterprise는 IP 주소에 해당하는 Enterprise 정보를 반환합니다
+func (r *ReloadableGeoLite2) GetEnterprise(ipAddress net.IP) (entity.Enterprise, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.current == nil {
+		return entity.Enterprise{}, ErrRepositoryClosed
+	}
+	if r.current.enterprise == nil {
+		return entity.Enterprise{}, repository.ErrDatabaseNotAvailable
+	}
+	return r.current.enterprise.GetEnterprise(ipAddress)
+}
+
+// GetAnonymousIP는 IP 주소에 해당하는 익명 IP 정보를 반환합니다
+func (r *ReloadableGeoLite2) GetAnonymousIP(ipAddress net.IP) (entity.AnonymousIP, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.current == nil {
+		return entity.AnonymousIP{}, ErrRepositoryClosed
+	}
+	if r.current.anonymousIP == nil {
+		return entity.AnonymousIP{}, repository.ErrDatabaseNotAvailable
+	}
+	return r.current.anonymousIP.GetAnonymousIP(ipAddress)
+}
+
+// GetISP는 IP 주소에 해당하는 ISP 정보를 반환합니다
+func (r *ReloadableGeoLite2) GetISP(ipAddress net.IP) (entity.ISP, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.current == nil {
+		return entity.ISP{}, ErrRepositoryClosed
+	}
+	if r.current.isp == nil {
+		return entity.ISP{}, repository.ErrDatabaseNotAvailable
+	}
+	return r.current.isp.GetISP(ipAddress)
+}
+
+// GetDomain은 IP 주소에 해당하는 도메인 정보를 반환합니다
+func (r *ReloadableGeoLite2) GetDomain(ipAddress net.IP) (entity.Domain, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.current == nil {
+		return entity.Domain{}, ErrRepositoryClosed
+	}
+	if r.current.domain == nil {
+		return entity.Domain{}, repository.ErrDatabaseNotAvailable
+	}
+	return r.current.domain.GetDomain(ipAddress)
+}
+
+// GetConnectionType은 IP 주소에 해당하는 연결 유형 정보를 반환합니다
+func (r *ReloadableGeoLite2) GetConnectionType(ipAddress net.IP) (entity.ConnectionType, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.current == nil {
+		return entity.ConnectionType{}, ErrRepositoryClosed
+	}
+	if r.current.connectionType == nil {
+		return entity.ConnectionType{}, repository.ErrDatabaseNotAvailable
+	}
+	return r.current.connectionType.GetConnectionType(ipAddress)
+}
+
+// Reload는 데이터베이스 파일을 다시 열어 검증한 뒤 현재 리더와 교체합니다.
+// 새 파일 중 하나라도 열리지 않거나 유형이 맞지 않으면 기존 리더를 그대로 사용합니다.
+func (r *ReloadableGeoLite2) Reload() error {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	states, err := r.fileStates()
+	if err != nil {
+		return err
+	}
+	return r.reload(states)
+}
+
+// reload는 reloadMu를 잡은 상태에서 호출되어야 합니다
+func (r *ReloadableGeoLite2) reload(states [databaseCount]fileState) error {
+	next, err := r.open()
+	if err != nil {
+		r.logger.Error("GeoLite2 데이터베이스 재적재 실패, 기존 데이터베이스를 계속 사용합니다", zap.Error(err))
+		return err
+	}
+
+	r.mu.Lock()
+	previous := r.current
+	if previous == nil {
+		r.mu.Unlock()
+		next.Close()
+		return ErrRepositoryClosed
+	}
+	r.current = next
+	r.loadedAt = time.Now()
+	r.version = next.version(r)
+	r.mu.Unlock()
+
+	// 쓰기 잠금을 잡았다 놓았으므로 이전 리더를 사용하는 조회는 모두 끝났습니다
+	previous.Close()
+	r.loaded = states
+
+	fields := make([]zap.Field, 0, databaseCount)
+	for _, db := range next.readers(r) {
+		metadata := db.reader.Metadata()
+		fields = append(fields, zap.Uint(metadata.DatabaseType+"_build_epoch", metadata.BuildEpoch))
+	}
+	r.logger.Info("GeoLite2 데이터베이스 재적재 완료", fields...)
+	return nil
+}
+
+// open은 GeoLite2 데이터베이스와, 파일이 있는 선택 데이터베이스를 모두 엽니다.
+// 하나라도 실패하면 이미 연 리더를 닫고 오류를 반환합니다.
+func (r *ReloadableGeoLite2) open() (*geoIP2Set, error) {
+	all, err := openGeoLite2All(r.cityDbPath, r.countryDbPath, r.asnDbPath)
+	if err != nil {
+		return nil, err
+	}
+	set := &geoIP2Set{GeoLite2All: all}
+
+	for _, optional := range []struct {
+		path   string
+		method string
+		assign func(base baseGeoRepository)
+	}{
+		{r.optional.Enterprise, "Enterprise", func(base baseGeoRepository) { set.enterprise = &GeoIP2Enterprise{base} }},
+		{r.optional.AnonymousIP, "AnonymousIP", func(base baseGeoRepository) { set.anonymousIP = &GeoIP2AnonymousIP{base} }},
+		{r.optional.ISP, "ISP", func(base baseGeoRepository) { set.isp = &GeoIP2ISP{base} }},
+		{r.optional.Domain, "Domain", func(base baseGeoRepository) { set.domain = &GeoIP2Domain{base} }},
+		{r.optional.ConnectionType, "ConnectionType", func(base baseGeoRepository) { set.connectionType = &GeoIP2ConnectionType{base} }},
+	} {
+		if !optionalExists(optional.path) {
+			continue
+		}
+		reader, err := geolite.OpenFor(optional.path, optional.method)
+		if err != nil {
+			set.Close()
+			return nil, err
+		}
+		optional.assign(baseGeoRepository{reader})
+	}
+
+	return set, nil
+}
+
+// Databases는 현재 조회에 사용 중인 데이터베이스의 에디션, 빌드 시각, 적재 시각을 반환합니다.
+// 에디션은 파일 이름에서 확장자를 뺀 값입니다(예: GeoLite2-City).
+func (r *ReloadableGeoLite2) Databases() []entity.DatabaseInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.current == nil {
+		return nil
+	}
+
+	readers := r.current.readers(r)
+	databases := make([]entity.DatabaseInfo, 0, len(readers))
+	for _, db := range readers {
+		metadata := db.reader.Metadata()
+		databases = append(databases, entity.DatabaseInfo{
+			Edition:      strings.TrimSuffix(filepath.Base(db.path), ".mmdb"),
+			DatabaseType: metadata.DatabaseType,
+			BuildEpoch:   metadata.BuildEpoch,
+			BuiltAt:      time.Unix(int64(metadata.BuildEpoch), 0).UTC(),
+			LoadedAt:     r.loadedAt,
+		})
+	}
+	return databases
+}
+
+// DatabaseVersion은 적재된 데이터베이스의 빌드 epoch로 만든 버전 문자열입니다.
+// 재적재로 데이터베이스 빌드가 바뀌면 값이 바뀌므로 조회 결과 캐시의 키에 사용합니다.
+func (r *ReloadableGeoLite2) DatabaseVersion() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.version
+}
+
+// Watch는 interval마다 데이터베이스 파일의 크기와 수정 시각을 확인하여 바뀌었으면 다시 엽니다.
+// 파일을 쓰는 도중에 열지 않도록, 변경이 감지된 뒤 한 번 더 같은 상태가 확인되었을 때 교체합니다.
+// ctx가 취소되면 반환합니다.
+func (r *ReloadableGeoLite2) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var pending *[databaseCount]fileState
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		pending = r.poll(pending)
+	}
+}
+
+// poll은 Watch의 한 번의 확인입니다. 적재된 상태와 다른 파일 상태를 처음 보면 이를 반환하여
+// 다음 확인에 넘기고, 다음 확인에서도 같은 상태이면 다시 엽니다.
+func (r *ReloadableGeoLite2) poll(pending *[databaseCount]fileState) *[databaseCount]fileState {
+	states, err := r.fileStates()
+	if err != nil {
+		// 파일 교체 중에는 잠시 파일이 없을 수 있습니다
+		return nil
+	}
+
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	if states == r.loaded {
+		return nil
+	}
+	if pending == nil || *pending != states {
+		return &states
+	}
+	if err := r.reload(states); err != nil {
+		// 같은 파일로 재시도하지 않도록 실패한 상태도 적재된 것으로 기록합니다
+		r.loaded = states
+	}
+	return nil
+}
+
+// Close는 현재 리더의 리소스를 해제합니다. 여러 번 호출해도 안전합니다.
+func (r *ReloadableGeoLite2) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
+
+// fileStates는 데이터베이스 파일의 현재 상태를 반환합니다. GeoLite2 파일이 없으면 오류를 반환하고,
+// 선택 데이터베이스 파일이 없으면 0 값으로 둡니다.
+func (r *ReloadableGeoLite2) fileStates() ([databaseCount]fileState, error) {
+	var states [databaseCount]fileState
+	paths := [databaseCount]string{
+		r.cityDbPath, r.countryDbPath, r.asnDbPath,
+		r.optional.Enterprise, r.optional.AnonymousIP, r.optional.ISP, r.optional.Domain, r.optional.ConnectionType,
+	}
+	for i, path := range paths {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			if i >= 3 && errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return states, err
+		}
+		states[i] = fileState{size: info.Size(), modTime: info.ModTime()}
+	}
+	return states, nil
+}
+
+// optionalExists는 선택 데이터베이스 경로가 설정되어 있고 파일이 있는지 확인합니다
+func optionalExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/infrastructure/geolite"
+	"go.uber.org/zap"
+)
+
+var testIP = net.ParseIP("1.2.3.4")
+
+// writeMMDB는 모든 IPv4 주소가 record 하나에 대응하는 MaxMind DB 파일을 씁니다.
+// 파일은 임시 파일에 쓴 뒤 이름을 바꾸므로, 열려 있는 리더가 사용하는 파일은 바뀌지 않습니다.
+func writeMMDB(t *testing.T, path, databaseType string, buildEpoch uint64, record map[string]interface{}) {
+	t.Helper()
+
+	var db bytes.Buffer
+	// 노드 하나의 두 레코드(24비트)가 모두 데이터 섹션의 첫 레코드를 가리킵니다: node_count + 16 + 0
+	db.Write([]byte{0, 0, 17, 0, 0, 17})
+	db.Write(make([]byte, 16))
+	encodeMMDB(&db, record)
+	db.WriteString("\xAB\xCD\xEFMaxMind.com")
+	encodeMMDB(&db, map[string]interface{}{
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"build_epoch":                 buildEpoch,
+		"database_type":               databaseType,
+		"description":                 map[string]interface{}{"en": databaseType + " test fixture"},
+		"ip_version":                  uint16(4),
+		"languages":                   []interface{}{"en"},
+		"node_count":                  uint32(1),
+		"record_size":                 uint16(24),
+	})
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, db.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	// 같은 크기의 파일로 빠르게 교체되어도 변경으로 감지되도록 수정 시각을 빌드 epoch로 둡니다
+	modTime := time.Unix(int64(buildEpoch), 0)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// encodeMMDB는 MaxMind DB 데이터 섹션 형식으로 값을 씁니다
+func encodeMMDB(buf *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		writeMMDBControl(buf, 2, len(v))
+		buf.WriteString(v)
+	case uint16:
+		writeMMDBUint(buf, 5, uint64(v))
+	case uint32:
+		writeMMDBUint(buf, 6, uint64(v))
+	case uint64:
+		writeMMDBUint(buf, 9, v)
+	case []interface{}:
+		writeMMDBControl(buf, 11, len(v))
+		for _, item := range v {
+			encodeMMDB(buf, item)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		writeMMDBControl(buf, 7, len(v))
+		for _, key := range keys {
+			encodeMMDB(buf, key)
+			encodeMMDB(buf, v[key])
+		}
+	default:
+		panic("unsupported MaxMind DB value")
+	}
+}
+
+func writeMMDBUint(buf *bytes.Buffer, typeNum int, v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	value := bytes.TrimLeft(b[:], "\x00")
+	writeMMDBControl(buf, typeNum, len(value))
+	buf.Write(value)
+}
+
+// writeMMDBControl은 유형과 크기(284 이하)를 나타내는 제어 바이트를 씁니다
+func writeMMDBControl(buf *bytes.Buffer, typeNum int, size int) {
+	if size > 284 {
+		panic("MaxMind DB value too large for the test encoder")
+	}
+	sizeBits := size
+	if size > 28 {
+		sizeBits = 29
+	}
+	if typeNum <= 7 {
+		buf.WriteByte(byte(typeNum<<5 | sizeBits))
+	} else {
+		buf.WriteByte(byte(sizeBits))
+		buf.WriteByte(byte(typeNum - 7))
+	}
+	if size > 28 {
+		buf.WriteByte(byte(size - 29))
+	}
+}
+
+func countryRecord(isoCode string) map[string]interface{} {
+	return map[string]interface{}{
+		"country": map[string]interface{}{"iso_code": isoCode},
+	}
+}
+
+func asnRecord(number uint32) map[string]interface{} {
+	return map[string]interface{}{
+		"autonomous_system_number":       number,
+		"autonomous_system_organization": "Test AS",
+	}
+}
+
+type testDatabases struct {
+	city, country, asn string
+}
+
+// newTestReloadable는 임시 디렉터리의 City, Country, ASN 데이터베이스로 리포지토리를 엽니다
+func newTestReloadable(t *testing.T) (*ReloadableGeoLite2, testDatabases) {
+	t.Helper()
+
+	dir := t.TempDir()
+	dbs := testDatabases{
+		city:    filepath.Join(dir, "GeoLite2-City.mmdb"),
+		country: filepath.Join(dir, "GeoLite2-Country.mmdb"),
+		asn:     filepath.Join(dir, "GeoLite2-ASN.mmdb"),
+	}
+	writeMMDB(t, dbs.city, "GeoLite2-City", 1700000000, countryRecord("KR"))
+	writeMMDB(t, dbs.country, "GeoLite2-Country", 1700000000, countryRecord("KR"))
+	writeMMDB(t, dbs.asn, "GeoLite2-ASN", 1700000000, asnRecord(4766))
+
+	repo, err := NewReloadableGeoLite2Repository(dbs.city, dbs.country, dbs.asn, zap.NewNop())
+	if err != nil {
+		t.Fatalf("리포지토리 생성 실패: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo, dbs
+}
+
+func TestReloadableGeoLite2LookupsDuringReload(t *testing.T) {
+	repo, dbs := newTestReloadable(t)
+
+	stop := make(chan struct{})
+	errs := make(chan error, 8)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				city, err := repo.GetCity(testIP)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if code := city.Country.IsoCode; code != "KR" && code != "JP" {
+					errs <- errors.New("예상하지 못한 국가 코드: " + code)
+					return
+				}
+				if _, err := repo.GetASN(testIP); err != nil {
+					errs <- err
+					return
+				}
+				repo.DatabaseVersion()
+			}
+		}()
+	}
+
+	codes := []string{"JP", "KR"}
+	for i := 0; i < 20; i++ {
+		writeMMDB(t, dbs.city, "GeoLite2-City", uint64(1700000001+i), countryRecord(codes[i%2]))
+		if err := repo.Reload(); err != nil {
+			t.Fatalf("재적재 실패: %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("재적재 중 조회 실패: %v", err)
+	}
+
+	// 마지막으로 교체한 파일(i=19)이 사용됩니다
+	city, err := repo.GetCity(testIP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if city.Country.IsoCode != "KR" {
+		t.Errorf("IsoCode = %q, want KR", city.Country.IsoCode)
+	}
+}
+
+func TestReloadableGeoLite2ReloadChangesVersion(t *testing.T) {
+	repo, dbs := newTestReloadable(t)
+	before := repo.DatabaseVersion()
+
+	writeMMDB(t, dbs.asn, "GeoLite2-ASN", 1800000000, asnRecord(9318))
+	if err := repo.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if repo.DatabaseVersion() == before {
+		t.Error("빌드가 바뀌었는데 DatabaseVersion이 그대로입니다")
+	}
+	asn, err := repo.GetASN(testIP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asn.AutonomousSystemNumber != 9318 {
+		t.Errorf("AutonomousSystemNumber = %d, want 9318", asn.AutonomousSystemNumber)
+	}
+}
+
+func TestReloadableGeoLite2RejectsWrongDatabaseType(t *testing.T) {
+	repo, dbs := newTestReloadable(t)
+	before := repo.DatabaseVersion()
+
+	// City 경로에 ASN 데이터베이스가 놓이면 교체하지 않습니다
+	writeMMDB(t, dbs.city, "GeoLite2-ASN", 1800000000, asnRecord(9318))
+	err := repo.Reload()
+
+	var invalid geolite.InvalidMethodError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Reload() error = %v, want InvalidMethodError", err)
+	}
+	if repo.DatabaseVersion() != before {
+		t.Error("거부된 데이터베이스로 DatabaseVersion이 바뀌었습니다")
+	}
+	city, err := repo.GetCity(testIP)
+	if err != nil {
+		t.Fatalf("기존 리더로 조회 실패: %v", err)
+	}
+	if city.Country.IsoCode != "KR" {
+		t.Errorf("IsoCode = %q, want KR", city.Country.IsoCode)
+	}
+}
+
+func TestReloadableGeoLite2WatchWaitsForStableFiles(t *testing.T) {
+	repo, dbs := newTestReloadable(t)
+	before := repo.DatabaseVersion()
+
+	// 변경이 처음 보인 확인에서는 다시 열지 않습니다
+	writeMMDB(t, dbs.city, "GeoLite2-City", 1800000000, countryRecord("JP"))
+	pending := repo.poll(nil)
+	if pending == nil {
+		t.Fatal("변경된 파일 상태가 다음 확인으로 넘어가지 않았습니다")
+	}
+	if repo.DatabaseVersion() != before {
+		t.Fatal("첫 확인에서 다시 열었습니다")
+	}
+
+	// 확인 사이에 파일이 또 바뀌면(아직 쓰는 중) 기다립니다
+	writeMMDB(t, dbs.city, "GeoLite2-City", 1800000001, countryRecord("US"))
+	pending = repo.poll(pending)
+	if pending == nil || repo.DatabaseVersion() != before {
+		t.Fatal("확인 사이에 바뀐 파일을 다시 열었습니다")
+	}
+
+	// 두 확인에서 같은 상태이면 다시 엽니다
+	if pending = repo.poll(pending); pending != nil {
+		t.Error("다시 연 뒤에도 대기 중인 상태가 남았습니다")
+	}
+	city, err := repo.GetCity(testIP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if city.Country.IsoCode != "US" {
+		t.Errorf("IsoCode = %q, want US", city.Country.IsoCode)
+	}
+
+	// 적재된 상태와 같으면 아무 일도 하지 않습니다
+	if pending = repo.poll(nil); pending != nil {
+		t.Error("바뀌지 않은 파일이 변경으로 감지되었습니다")
+	}
+}
+
+func TestReloadableGeoLite2WatchSkipsRejectedFiles(t *testing.T) {
+	repo, dbs := newTestReloadable(t)
+	before := repo.DatabaseVersion()
+
+	writeMMDB(t, dbs.country, "GeoLite2-ASN", 1800000000, asnRecord(9318))
+	pending := repo.poll(repo.poll(nil))
+	if pending != nil {
+		t.Fatal("거부된 파일이 대기 상태로 남았습니다")
+	}
+	if repo.DatabaseVersion() != before {
+		t.Fatal("거부된 데이터베이스로 교체되었습니다")
+	}
+	// 실패한 상태는 적재된 것으로 기록되어 같은 파일로 재시도하지 않습니다
+	if pending = repo.poll(nil); pending != nil {
+		t.Error("거부된 파일을 다시 변경으로 감지했습니다")
+	}
+	if _, err := repo.GetCountry(testIP); err != nil {
+		t.Errorf("기존 리더로 조회 실패: %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package config
+
+// Cache는 종합 지리 정보 조회 결과 캐시 설정입니다
+type Cache struct {
+	// Type은 캐시 저장소 종류입니다(memory, redis, none). 비어 있으면 memory입니다.
+	Type string `yaml:"type"`
+	// TTL은 캐시 항목의 유효 시간(초)입니다. 0이면 1시간입니다.
+	TTL int `yaml:"ttl"`
+	// Capacity와 Shards는 인메모리 LRU의 전체 항목 수와 조각 수입니다
+	Capacity int `yaml:"capacity"`
+	Shards   int `yaml:"shards"`
+	// IPv4Prefix와 IPv6Prefix는 캐시 키로 사용할 네트워크 접두사 길이입니다. 0이면 IP 주소 하나입니다.
+	IPv4Prefix int `yaml:"ipv4_prefix"`
+	IPv6Prefix int `yaml:"ipv6_prefix"`
+
+	// Redis 캐시 설정
+	Redis struct {
+		Addr     string `yaml:"addr"`
+		Password string `yaml:"password"`
+		DB       int    `yaml:"db"`
+	} `yaml:"redis"`
+}
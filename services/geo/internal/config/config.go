@@ -8,13 +8,15 @@ import (
 
 // Config 인증 서비스 설정 구조체
 type Config struct {
-	Service Service `yaml:"service"`
-	Server  Server  `yaml:"server"`
-	GeoLite GeoLite `yaml:"geolite"`
-	JWT     JWT     `yaml:"jwt"`
-	Log     Log     `yaml:"log"`
-	Email   Email   `yaml:"email"`
-	Logger  *zap.Logger
+	Service  Service  `yaml:"service"`
+	Server   Server   `yaml:"server"`
+	GeoLite  GeoLite  `yaml:"geolite"`
+	Cache    Cache    `yaml:"cache"`
+	GeoFence GeoFence `yaml:"geofence"`
+	JWT      JWT      `yaml:"jwt"`
+	Log      Log      `yaml:"log"`
+	Email    Email    `yaml:"email"`
+	Logger   *zap.Logger
 }
 
 var (
@@ -42,6 +44,7 @@ func Load() (*Config, error) {
 	appConfig.Server.HTTP.Port = cfg.GetString("server.port")
 	appConfig.Server.HTTP.Timeout = cfg.GetInt("server.timeout")
 	appConfig.Server.HTTP.Debug = cfg.GetBool("server.debug")
+	appConfig.Server.HTTP.TrustedProxies = cfg.GetStringSlice("server.trusted_proxies")
 
 	// gRPC 서버 설정
 	appConfig.Server.GRPC.Port = cfg.GetString("server.grpc.port")
@@ -49,6 +52,41 @@ func Load() (*Config, error) {
 
 	// GeoLite 설정
 	appConfig.GeoLite.DbPath = cfg.GetString("geolite.db_path")
+	appConfig.GeoLite.ReloadInterval = cfg.GetInt("geolite.reload_interval")
+	appConfig.GeoLite.Databases.Enterprise = cfg.GetString("geolite.databases.enterprise")
+	appConfig.GeoLite.Databases.AnonymousIP = cfg.GetString("geolite.databases.anonymous_ip")
+	appConfig.GeoLite.Databases.ISP = cfg.GetString("geolite.databases.isp")
+	appConfig.GeoLite.Databases.Domain = cfg.GetString("geolite.databases.domain")
+	appConfig.GeoLite.Databases.ConnectionType = cfg.GetString("geolite.databases.connection_type")
+	appConfig.GeoLite.Update.Enabled = cfg.GetBool("geolite.update.enabled")
+	appConfig.GeoLite.Update.DownloadURL = cfg.GetString("geolite.update.download_url")
+	appConfig.GeoLite.Update.AccountID = cfg.GetString("geolite.update.account_id")
+	appConfig.GeoLite.Update.LicenseKey = cfg.GetString("geolite.update.license_key")
+	appConfig.GeoLite.Update.Editions = cfg.GetStringSlice("geolite.update.editions")
+	appConfig.GeoLite.Update.Interval = cfg.GetInt("geolite.update.interval")
+	appConfig.GeoLite.Update.KeepVersions = cfg.GetInt("geolite.update.keep_versions")
+	appConfig.GeoLite.Languages = cfg.GetStringSlice("geolite.languages")
+
+	// 캐시 설정
+	appConfig.Cache.Type = cfg.GetString("cache.type")
+	appConfig.Cache.TTL = cfg.GetInt("cache.ttl")
+	appConfig.Cache.Capacity = cfg.GetInt("cache.capacity")
+	appConfig.Cache.Shards = cfg.GetInt("cache.shards")
+	appConfig.Cache.IPv4Prefix = cfg.GetInt("cache.ipv4_prefix")
+	appConfig.Cache.IPv6Prefix = cfg.GetInt("cache.ipv6_prefix")
+	appConfig.Cache.Redis.Addr = cfg.GetString("cache.redis.addr")
+	appConfig.Cache.Redis.Password = cfg.GetString("cache.redis.password")
+	appConfig.Cache.Redis.DB = cfg.GetInt("cache.redis.db")
+
+	// 지역 제한 규칙 설정
+	appConfig.GeoFence.Enabled = cfg.GetBool("geofence.enabled")
+	appConfig.GeoFence.Source = cfg.GetString("geofence.source")
+	appConfig.GeoFence.File = cfg.GetString("geofence.file")
+	appConfig.GeoFence.ReloadInterval = cfg.GetInt("geofence.reload_interval")
+	appConfig.GeoFence.Redis.Addr = cfg.GetString("geofence.redis.addr")
+	appConfig.GeoFence.Redis.Password = cfg.GetString("geofence.redis.password")
+	appConfig.GeoFence.Redis.DB = cfg.GetInt("geofence.redis.db")
+	appConfig.GeoFence.Redis.Key = cfg.GetString("geofence.redis.key")
 
 	// JWT 설정
 	appConfig.JWT.Secret = cfg.GetString("jwt.secret")
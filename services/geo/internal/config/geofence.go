@@ -0,0 +1,21 @@
+package config
+
+// GeoFence는 지역 제한 규칙 설정입니다
+type GeoFence struct {
+	Enabled bool `yaml:"enabled"`
+	// Source는 규칙 집합 저장소입니다(file, redis). 비어 있으면 file입니다.
+	Source string `yaml:"source"`
+	// File은 규칙 집합을 정의한 YAML 또는 JSON 파일 경로입니다
+	File string `yaml:"file"`
+	// ReloadInterval은 규칙 변경을 확인하는 주기(초)입니다. 0이면 30초, 음수이면 확인하지 않습니다.
+	ReloadInterval int `yaml:"reload_interval"`
+
+	// Redis 저장소 설정. Addr이 비어 있으면 캐시의 Redis 설정을 사용합니다.
+	Redis struct {
+		Addr     string `yaml:"addr"`
+		Password string `yaml:"password"`
+		DB       int    `yaml:"db"`
+		// Key는 규칙 집합을 저장한 해시 키입니다. 비어 있으면 geo:fence:rule_sets입니다.
+		Key string `yaml:"key"`
+	} `yaml:"redis"`
+}
@@ -2,4 +2,35 @@ package config
 
 type GeoLite struct {
 	DbPath string `yaml:"db_path"`
+	// ReloadInterval은 데이터베이스 파일 변경을 확인하는 주기(초)입니다. 0이면 기본값(60초), 음수이면 감시하지 않습니다.
+	ReloadInterval int `yaml:"reload_interval"`
+	// Databases는 파일이 있을 때만 적재하는 GeoIP2 데이터베이스 파일 이름(db_path 기준)입니다
+	Databases GeoIP2Databases `yaml:"databases"`
+	// Update는 데이터베이스 자동 업데이트 설정입니다
+	Update GeoLiteUpdate `yaml:"update"`
+	// Languages는 요청에 언어가 없거나 요청한 언어의 이름이 없을 때 차례로 사용하는 언어입니다. 모두 없으면 en을 사용합니다.
+	Languages []string `yaml:"languages"`
+}
+
+// GeoIP2Databases는 GeoIP2 유료 데이터베이스 파일 이름입니다. 비어 있으면 MaxMind 기본 파일 이름을 사용합니다.
+type GeoIP2Databases struct {
+	Enterprise     string `yaml:"enterprise"`
+	AnonymousIP    string `yaml:"anonymous_ip"`
+	ISP            string `yaml:"isp"`
+	Domain         string `yaml:"domain"`
+	ConnectionType string `yaml:"connection_type"`
+}
+
+// GeoLiteUpdate는 MaxMind 또는 내부 미러에서 데이터베이스를 내려받는 설정입니다
+type GeoLiteUpdate struct {
+	Enabled bool `yaml:"enabled"`
+	// DownloadURL은 {edition}, {suffix} 자리표시자를 포함한 다운로드 주소입니다. 비어 있으면 MaxMind 다운로드 API를 사용합니다.
+	DownloadURL string   `yaml:"download_url"`
+	AccountID   string   `yaml:"account_id"`
+	LicenseKey  string   `yaml:"license_key"`
+	Editions    []string `yaml:"editions"`
+	// Interval은 업데이트 확인 주기(시간)입니다. 0이면 24시간입니다.
+	Interval int `yaml:"interval"`
+	// KeepVersions는 되돌리기용으로 보관할 이전 버전 수입니다. 0이면 3개, 음수이면 보관하지 않습니다.
+	KeepVersions int `yaml:"keep_versions"`
 }
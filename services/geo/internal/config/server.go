@@ -6,6 +6,8 @@ type Server struct {
 		Port    string `yaml:"port"`
 		Timeout int    `yaml:"timeout"`
 		Debug   bool   `yaml:"debug"`
+		// TrustedProxies는 X-Forwarded-For 등의 헤더를 믿을 수 있는 프록시의 CIDR 또는 IP 목록입니다
+		TrustedProxies []string `yaml:"trusted_proxies"`
 	} `yaml:"http"`
 
 	// gRPC 서버 설정
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// DatabaseInfo는 현재 조회에 사용 중인 데이터베이스의 메타데이터입니다
+type DatabaseInfo struct {
+	Edition      string    `json:"edition"`
+	DatabaseType string    `json:"database_type"`
+	BuildEpoch   uint      `json:"build_epoch"`
+	BuiltAt      time.Time `json:"built_at"`
+	LoadedAt     time.Time `json:"loaded_at"`
+}
+
+// DatabaseUpdateStatus는 에디션별 자동 업데이트 결과입니다
+type DatabaseUpdateStatus struct {
+	Edition          string     `json:"edition"`
+	SHA256           string     `json:"sha256,omitempty"`
+	LastCheckedAt    *time.Time `json:"last_checked_at,omitempty"`
+	LastUpdatedAt    *time.Time `json:"last_updated_at,omitempty"`
+	LastError        string     `json:"last_error,omitempty"`
+	PreviousVersions []string   `json:"previous_versions"`
+}
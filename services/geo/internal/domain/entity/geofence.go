@@ -0,0 +1,42 @@
+package entity
+
+// 지역 제한 규칙의 동작
+const (
+	GeoFenceAllow = "allow"
+	GeoFenceDeny  = "deny"
+)
+
+// 지역 제한 규칙에 사용할 수 있는 익명 IP 유형
+const (
+	GeoFenceAnonymous        = "anonymous"
+	GeoFenceAnonymousVPN     = "vpn"
+	GeoFenceTorExitNode      = "tor"
+	GeoFenceHostingProvider  = "hosting"
+	GeoFencePublicProxy      = "public_proxy"
+	GeoFenceResidentialProxy = "residential_proxy"
+)
+
+// GeoFenceRuleSet은 기능별로 이름을 붙인 지역 제한 규칙 집합입니다.
+// 규칙을 순서대로 평가하여 처음 일치한 규칙의 동작을 따르고, 일치하는 규칙이 없으면 DefaultAction을 따릅니다.
+type GeoFenceRuleSet struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description"`
+	// DefaultAction은 일치하는 규칙이 없을 때의 동작입니다. 비어 있으면 allow입니다.
+	DefaultAction string `json:"default_action,omitempty" yaml:"default_action"`
+	// UnknownAction은 규칙에 필요한 지리 정보를 조회하지 못했거나 값이 비어 있어 규칙을 평가할 수 없을 때의 동작입니다.
+	// 비어 있으면 deny입니다.
+	UnknownAction string         `json:"unknown_action,omitempty" yaml:"unknown_action"`
+	Rules         []GeoFenceRule `json:"rules" yaml:"rules"`
+}
+
+// GeoFenceRule은 조건이 모두 맞으면 Action을 적용하는 규칙입니다.
+// 각 조건은 목록의 값 중 하나만 맞으면 되고, 비어 있는 조건은 검사하지 않습니다. 조건이 없으면 모든 IP에 일치합니다.
+type GeoFenceRule struct {
+	Name       string   `json:"name" yaml:"name"`
+	Action     string   `json:"action" yaml:"action"`
+	Countries  []string `json:"countries,omitempty" yaml:"countries"`   // ISO 3166-1 국가 코드(예: KR)
+	Continents []string `json:"continents,omitempty" yaml:"continents"` // 대륙 코드(예: AS, EU)
+	ASNs       []uint   `json:"asns,omitempty" yaml:"asns"`
+	CIDRs      []string `json:"cidrs,omitempty" yaml:"cidrs"`         // 네트워크 또는 IP 주소
+	Anonymous  []string `json:"anonymous,omitempty" yaml:"anonymous"` // 익명 IP 유형(anonymous, vpn, tor 등)
+}
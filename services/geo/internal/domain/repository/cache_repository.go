@@ -2,9 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrCacheMiss는 캐시에 없거나 만료된 키를 조회할 때 반환됩니다
+var ErrCacheMiss = errors.New("캐시에 없는 키입니다")
+
 // CacheRepository 캐시 관련 저장소 인터페이스
 type CacheRepository interface {
 	// Get 키로 값 조회
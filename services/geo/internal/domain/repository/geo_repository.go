@@ -1,11 +1,15 @@
 package repository
 
 import (
+	"errors"
 	"net"
 
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
 )
 
+// ErrDatabaseNotAvailable은 설치되지 않은 데이터베이스를 조회할 때 반환됩니다
+var ErrDatabaseNotAvailable = errors.New("설치되지 않은 데이터베이스입니다")
+
 // BaseGeoRepository는 모든 GeoIP 저장소가 공통으로 가지는 메서드를 정의합니다
 type BaseGeoRepository interface {
 	// Close는 사용한 리소스를 해제합니다
@@ -89,3 +93,21 @@ type GeoIP2FullRepository interface {
 	GeoIP2DomainRepository
 	GeoIP2ConnectionTypeRepository
 }
+
+// GeoIP2Repository는 GeoLite2 데이터베이스와 함께, 설치된 GeoIP2 데이터베이스까지 조회하는 인터페이스입니다.
+// 설치되지 않은 데이터베이스를 조회하면 ErrDatabaseNotAvailable을 반환합니다.
+type GeoIP2Repository interface {
+	GeoLite2Repository
+	GeoIP2FullRepository
+}
+
+// ReloadableGeoLite2Repository는 서비스 재시작 없이 데이터베이스 파일을 다시 열 수 있는 GeoLite2 인터페이스입니다
+type ReloadableGeoLite2Repository interface {
+	GeoIP2Repository
+	// Reload는 데이터베이스 파일을 다시 열어 검증한 뒤, 진행 중인 조회에 영향 없이 교체합니다
+	Reload() error
+	// Databases는 현재 조회에 사용 중인 데이터베이스의 메타데이터를 반환합니다
+	Databases() []entity.DatabaseInfo
+	// DatabaseVersion은 적재된 데이터베이스의 빌드 epoch로 만든 버전 문자열이며, 재적재로 빌드가 바뀌면 달라집니다
+	DatabaseVersion() string
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+)
+
+// GeoFenceRepository는 지역 제한 규칙 집합 저장소 인터페이스입니다
+type GeoFenceRepository interface {
+	// ListRuleSets는 저장된 모든 규칙 집합을 반환합니다
+	ListRuleSets(ctx context.Context) ([]entity.GeoFenceRuleSet, error)
+}
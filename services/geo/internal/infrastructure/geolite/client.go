@@ -72,6 +72,37 @@ func FromBytes(bytes []byte) (*Reader, error) {
 	return &Reader{reader, dbType}, err
 }
 
+// methodDatabaseTypes는 조회 메서드별로 이를 지원하는 데이터베이스 유형을 나타냅니다.
+var methodDatabaseTypes = map[string]databaseType{
+	"AnonymousIP":    isAnonymousIP,
+	"ASN":            isASN,
+	"City":           isCity,
+	"ConnectionType": isConnectionType,
+	"Country":        isCountry,
+	"Domain":         isDomain,
+	"Enterprise":     isEnterprise,
+	"ISP":            isISP,
+}
+
+// OpenFor는 Open과 같이 데이터베이스 파일을 열고, 데이터베이스 유형이 주어진 조회 메서드
+// (예: "City", "ASN")를 지원하는지 검증합니다. 지원하지 않으면 Reader를 닫고
+// InvalidMethodError를 반환하므로, 잘못된 파일로 교체된 데이터베이스를 사용 전에 걸러낼 수 있습니다.
+func OpenFor(file string, method string) (*Reader, error) {
+	reader, err := Open(file)
+	if err != nil {
+		if reader != nil {
+			reader.Close()
+		}
+		return nil, err
+	}
+	if methodDatabaseTypes[method]&reader.databaseType == 0 {
+		dbType := reader.Metadata().DatabaseType
+		reader.Close()
+		return nil, InvalidMethodError{method, dbType}
+	}
+	return reader, nil
+}
+
 func getDBType(reader *maxminddb.Reader) (databaseType, error) {
 	switch reader.Metadata.DatabaseType {
 	case "GeoIP2-Anonymous-IP":
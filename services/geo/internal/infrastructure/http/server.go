@@ -8,14 +8,16 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/wekeepgrowing/semo-backend-monorepo/pkg/logger"
+	"github.com/wekeepgrowing/semo-backend-monorepo/pkg/middleware/clientip"
 	"go.uber.org/zap"
 )
 
 // Server HTTP 서버 구조체입니다.
 type Server struct {
-	echo   *echo.Echo
-	logger *zap.Logger
-	port   int
+	echo     *echo.Echo
+	logger   *zap.Logger
+	port     int
+	clientIP *clientip.Resolver
 }
 
 // ServerOption Server 생성을 위한 옵션 함수 타입입니다.
@@ -35,6 +37,14 @@ func WithLogger(logger *zap.Logger) ServerOption {
 	}
 }
 
+// WithClientIPResolver 신뢰하는 프록시를 거친 요청의 클라이언트 IP를 찾는 Resolver를 설정하는 옵션입니다.
+// c.RealIP()와 요청 로그가 이 Resolver의 결과를 사용하며, 설정하지 않으면 X-Forwarded-For 등의 헤더를 믿지 않습니다.
+func WithClientIPResolver(resolver *clientip.Resolver) ServerOption {
+	return func(s *Server) {
+		s.clientIP = resolver
+	}
+}
+
 // NewServer HTTP 서버를 생성합니다.
 func NewServer(opts ...ServerOption) *Server {
 	// 기본 서버 설정
@@ -55,6 +65,13 @@ func NewServer(opts ...ServerOption) *Server {
 	// 로거 설정
 	logger.WithEchoLogger(e, s.logger)
 
+	// 클라이언트 IP 설정. Resolver가 없으면 프록시 헤더를 무시하고 요청을 보낸 주소를 사용합니다
+	if s.clientIP == nil {
+		s.clientIP, _ = clientip.NewResolver(nil)
+	}
+	e.IPExtractor = s.clientIP.IPExtractor()
+	e.Use(s.clientIP.Middleware())
+
 	// 미들웨어 설정
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORS())
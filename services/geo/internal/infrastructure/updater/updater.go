@@ -0,0 +1,642 @@
+package updater
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/infrastructure/geolite"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultDownloadURL은 MaxMind 다운로드 API 주소입니다. {edition}과 {suffix}는 요청마다 치환됩니다.
+	DefaultDownloadURL = "https://download.maxmind.com/geoip/databases/{edition}/download?suffix={suffix}"
+
+	defaultInterval     = 24 * time.Hour
+	defaultKeepVersions = 3
+
+	archiveSuffix  = "tar.gz"
+	checksumSuffix = "tar.gz.sha256"
+
+	versionsDir      = "versions"
+	installedSumFile = "installed.sha256"
+	versionSumSuffix = ".sha256"
+)
+
+var (
+	// ErrChecksumMismatch는 내려받은 아카이브의 SHA256이 체크섬 파일과 다를 때 반환됩니다
+	ErrChecksumMismatch = errors.New("데이터베이스 아카이브의 체크섬이 일치하지 않습니다")
+	// ErrDatabaseNotInArchive는 아카이브에 에디션의 mmdb 파일이 없을 때 반환됩니다
+	ErrDatabaseNotInArchive = errors.New("아카이브에 데이터베이스 파일이 없습니다")
+	// ErrNoPreviousVersion은 되돌릴 이전 버전이 없을 때 반환됩니다
+	ErrNoPreviousVersion = errors.New("되돌릴 이전 데이터베이스 버전이 없습니다")
+)
+
+// Reloader는 설치된 데이터베이스 파일을 다시 여는 리포지토리입니다
+type Reloader interface {
+	Reload() error
+}
+
+// Config는 업데이터 설정입니다
+type Config struct {
+	// DownloadURL은 {edition}, {suffix} 자리표시자를 포함한 다운로드 주소입니다. MaxMind 또는 내부 미러를 가리킵니다.
+	DownloadURL string
+	// AccountID와 LicenseKey가 있으면 Basic 인증으로 전달합니다
+	AccountID  string
+	LicenseKey string
+	// Editions는 내려받을 에디션 목록입니다(예: GeoLite2-City)
+	Editions []string
+	// DataDir은 <에디션>.mmdb 파일이 설치되는 디렉터리입니다
+	DataDir string
+	// Interval은 업데이트 확인 주기입니다. 0이면 24시간입니다.
+	Interval time.Duration
+	// KeepVersions는 에디션별로 보관할 이전 버전 수입니다. 0이면 3개, 음수이면 보관하지 않습니다.
+	KeepVersions int
+}
+
+// Updater는 설정된 주소에서 데이터베이스 에디션을 주기적으로 내려받아 체크섬을 검증하고,
+// 압축을 풀어 데이터 디렉터리에 원자적으로 설치합니다. 교체된 파일은 versions/<에디션>/<빌드 epoch>.mmdb로,
+// 그 체크섬은 <빌드 epoch>.sha256으로 보관하여 새 데이터베이스를 적재하지 못하면 되돌립니다.
+type Updater struct {
+	cfg    Config
+	client *http.Client
+	logger *zap.Logger
+	now    func() time.Time
+
+	// validate는 설치 전에 mmdb 파일을 열어 에디션에 맞는지 확인하고 빌드 epoch를 반환합니다
+	validate func(file, edition string) (uint, error)
+
+	// runMu는 주기 실행과 부트스트랩 업데이트를 직렬화합니다
+	runMu sync.Mutex
+
+	mu     sync.Mutex
+	status map[string]*entity.DatabaseUpdateStatus
+}
+
+// New는 새로운 Updater 인스턴스를 생성합니다
+func New(cfg Config, logger *zap.Logger) *Updater {
+	if cfg.DownloadURL == "" {
+		cfg.DownloadURL = DefaultDownloadURL
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+	if cfg.KeepVersions == 0 {
+		cfg.KeepVersions = defaultKeepVersions
+	}
+
+	status := make(map[string]*entity.DatabaseUpdateStatus, len(cfg.Editions))
+	for _, edition := range cfg.Editions {
+		status[edition] = &entity.DatabaseUpdateStatus{Edition: edition}
+	}
+
+	return &Updater{
+		cfg: cfg,
+		client: &http.Client{
+			Timeout: 10 * time.Minute,
+		},
+		logger:   logger,
+		now:      time.Now,
+		validate: validateDatabase,
+		status:   status,
+	}
+}
+
+// Run은 즉시 한 번, 이후 Interval마다 업데이트를 확인합니다. 새 버전이 설치되면 reloader로 다시 열고,
+// 실패하면 이번에 설치한 에디션을 이전 버전으로 되돌린 뒤 다시 엽니다. 되돌릴 버전이 없는 에디션은
+// 내려받은 파일을 버립니다. ctx가 취소되면 반환합니다.
+func (u *Updater) Run(ctx context.Context, reloader Reloader) {
+	ticker := time.NewTicker(u.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		u.runOnce(ctx, reloader)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (u *Updater) runOnce(ctx context.Context, reloader Reloader) {
+	installed, err := u.Update(ctx)
+	if err != nil {
+		u.logger.Error("GeoLite2 데이터베이스 업데이트 중 오류 발생", zap.Error(err))
+	}
+	if len(installed) == 0 {
+		return
+	}
+
+	reloadErr := reloader.Reload()
+	if reloadErr == nil {
+		return
+	}
+
+	u.logger.Error("새 GeoLite2 데이터베이스 적재 실패, 이전 버전으로 되돌립니다",
+		zap.Strings("editions", installed),
+		zap.Error(reloadErr))
+	for _, edition := range installed {
+		err := u.Rollback(edition)
+		if errors.Is(err, ErrNoPreviousVersion) {
+			// 적재하지 못한 파일을 현재 데이터베이스로 남기지 않습니다. 체크섬 표시도 지워 다음 주기에 다시 내려받습니다.
+			if err := u.discard(edition); err != nil {
+				u.logger.Error("적재하지 못한 GeoLite2 데이터베이스 삭제 실패", zap.String("edition", edition), zap.Error(err))
+			}
+			u.recordError(edition, fmt.Errorf("적재 실패로 내려받은 데이터베이스를 버림: %w", reloadErr))
+			continue
+		}
+		if err != nil {
+			u.logger.Error("GeoLite2 데이터베이스 되돌리기 실패", zap.String("edition", edition), zap.Error(err))
+		}
+		u.recordError(edition, fmt.Errorf("적재 실패로 이전 버전으로 되돌림: %w", reloadErr))
+	}
+	if err := reloader.Reload(); err != nil {
+		u.logger.Error("되돌린 GeoLite2 데이터베이스 적재 실패", zap.Error(err))
+	}
+}
+
+// Update는 모든 에디션의 체크섬을 확인하여 바뀐 에디션만 내려받아 설치하고, 설치한 에디션 목록을 반환합니다.
+// 리포지토리에 다시 열도록 알리지는 않으므로, 서버 시작 전 데이터베이스 파일이 없을 때도 사용할 수 있습니다.
+func (u *Updater) Update(ctx context.Context) ([]string, error) {
+	u.runMu.Lock()
+	defer u.runMu.Unlock()
+
+	var installed []string
+	var errs []error
+	for _, edition := range u.cfg.Editions {
+		changed, err := u.updateEdition(ctx, edition)
+		if err != nil {
+			u.recordError(edition, err)
+			errs = append(errs, fmt.Errorf("%s: %w", edition, err))
+			continue
+		}
+		if changed {
+			installed = append(installed, edition)
+		}
+	}
+	return installed, errors.Join(errs...)
+}
+
+// Status는 에디션별 마지막 확인/설치 결과와 보관 중인 이전 버전을 반환합니다
+func (u *Updater) Status() []entity.DatabaseUpdateStatus {
+	u.mu.Lock()
+	statuses := make([]entity.DatabaseUpdateStatus, 0, len(u.cfg.Editions))
+	for _, edition := range u.cfg.Editions {
+		statuses = append(statuses, *u.status[edition])
+	}
+	u.mu.Unlock()
+
+	for i := range statuses {
+		versions, _ := u.previousVersions(statuses[i].Edition)
+		statuses[i].PreviousVersions = make([]string, 0, len(versions))
+		for _, version := range versions {
+			statuses[i].PreviousVersions = append(statuses[i].PreviousVersions, strings.TrimSuffix(version, ".mmdb"))
+		}
+	}
+	return statuses
+}
+
+// Rollback은 에디션의 가장 최근 이전 버전을 현재 데이터베이스로 되돌립니다.
+// 되돌린 버전은 보관 목록에서 빠지고, 교체된 데이터베이스는 버려집니다. 설치 체크섬도 되돌린 버전의
+// 것으로 바꾸고, 알 수 없으면 지워서 다음 확인 때 원격 버전을 다시 내려받게 합니다.
+func (u *Updater) Rollback(edition string) error {
+	versions, err := u.previousVersions(edition)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return ErrNoPreviousVersion
+	}
+
+	latest := filepath.Join(u.versionDir(edition), versions[0])
+	if err := os.Rename(latest, u.databasePath(edition)); err != nil {
+		return fmt.Errorf("이전 버전 복원 실패: %w", err)
+	}
+
+	checksum := ""
+	versionSum := strings.TrimSuffix(latest, ".mmdb") + versionSumSuffix
+	if content, err := os.ReadFile(versionSum); err == nil {
+		checksum = strings.TrimSpace(string(content))
+	}
+	if err := u.writeInstalledSum(edition, checksum); err != nil {
+		return fmt.Errorf("설치 체크섬 복원 실패: %w", err)
+	}
+	os.Remove(versionSum)
+
+	u.mu.Lock()
+	if st, ok := u.status[edition]; ok {
+		st.SHA256 = checksum
+	}
+	u.mu.Unlock()
+
+	u.logger.Info("GeoLite2 데이터베이스를 이전 버전으로 되돌렸습니다",
+		zap.String("edition", edition),
+		zap.String("version", strings.TrimSuffix(versions[0], ".mmdb")))
+	return nil
+}
+
+// discard는 되돌릴 이전 버전이 없는 에디션에서 적재하지 못한 데이터베이스와 설치 체크섬을 지웁니다
+func (u *Updater) discard(edition string) error {
+	if err := os.Remove(u.databasePath(edition)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := u.writeInstalledSum(edition, ""); err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	if st, ok := u.status[edition]; ok {
+		st.SHA256 = ""
+	}
+	u.mu.Unlock()
+	return nil
+}
+
+// writeInstalledSum은 설치된 데이터베이스의 체크섬을 기록합니다. 빈 값이면 기록을 지웁니다.
+func (u *Updater) writeInstalledSum(edition, checksum string) error {
+	file := filepath.Join(u.versionDir(edition), installedSumFile)
+	if checksum == "" {
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(file, []byte(checksum+"\n"), 0o644)
+}
+
+// updateEdition은 원격 체크섬이 설치된 것과 다를 때만 아카이브를 내려받아 설치합니다
+func (u *Updater) updateEdition(ctx context.Context, edition string) (bool, error) {
+	checksum, err := u.fetchChecksum(ctx, edition)
+	if err != nil {
+		return false, err
+	}
+
+	checkedAt := u.now()
+	installedSum, _ := os.ReadFile(filepath.Join(u.versionDir(edition), installedSumFile))
+	if _, statErr := os.Stat(u.databasePath(edition)); statErr == nil && strings.TrimSpace(string(installedSum)) == checksum {
+		u.mu.Lock()
+		st := u.status[edition]
+		st.SHA256 = checksum
+		st.LastCheckedAt = &checkedAt
+		st.LastError = ""
+		u.mu.Unlock()
+		return false, nil
+	}
+
+	if err := os.MkdirAll(u.versionDir(edition), 0o755); err != nil {
+		return false, err
+	}
+
+	archive, err := u.download(ctx, edition, checksum)
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(archive)
+
+	database, err := u.extract(archive, edition)
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(database)
+
+	buildEpoch, err := u.validate(database, edition)
+	if err != nil {
+		return false, fmt.Errorf("내려받은 데이터베이스 검증 실패: %w", err)
+	}
+
+	if err := u.install(database, edition); err != nil {
+		return false, err
+	}
+	if err := u.writeInstalledSum(edition, checksum); err != nil {
+		return false, err
+	}
+
+	u.mu.Lock()
+	st := u.status[edition]
+	st.SHA256 = checksum
+	st.LastCheckedAt = &checkedAt
+	st.LastUpdatedAt = &checkedAt
+	st.LastError = ""
+	u.mu.Unlock()
+
+	u.logger.Info("GeoLite2 데이터베이스 설치 완료",
+		zap.String("edition", edition),
+		zap.Uint("build_epoch", buildEpoch),
+		zap.String("sha256", checksum))
+	return true, nil
+}
+
+// fetchChecksum은 <에디션>.tar.gz.sha256 파일을 받아 SHA256 값을 반환합니다.
+// 파일 형식은 sha256sum 출력과 같은 "<16진수>  <파일 이름>"입니다.
+func (u *Updater) fetchChecksum(ctx context.Context, edition string) (string, error) {
+	resp, err := u.get(ctx, edition, checksumSuffix)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return "", fmt.Errorf("체크섬 파일 읽기 실패: %w", err)
+	}
+
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("체크섬 파일이 비어 있습니다")
+	}
+	checksum := strings.ToLower(fields[0])
+	if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("잘못된 체크섬 형식입니다: %q", fields[0])
+	}
+	return checksum, nil
+}
+
+// download는 아카이브를 데이터 디렉터리의 임시 파일로 내려받으면서 SHA256을 계산하여 검증합니다
+func (u *Updater) download(ctx context.Context, edition, checksum string) (string, error) {
+	resp, err := u.get(ctx, edition, archiveSuffix)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	file, err := os.CreateTemp(u.versionDir(edition), "download-*.tar.gz")
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, hash), resp.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("아카이브 다운로드 실패: %w", err)
+	}
+
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != checksum {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("%w: 기대값 %s, 실제값 %s", ErrChecksumMismatch, checksum, actual)
+	}
+	return file.Name(), nil
+}
+
+// extract는 아카이브에서 <에디션>.mmdb를 찾아 데이터 디렉터리의 임시 파일로 풀어냅니다.
+// MaxMind 아카이브는 <에디션>_<날짜>/<에디션>.mmdb 구조이므로 디렉터리 이름은 무시합니다.
+func (u *Updater) extract(archive, edition string) (string, error) {
+	file, err := os.Open(archive)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return "", fmt.Errorf("아카이브 압축 해제 실패: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return "", ErrDatabaseNotInArchive
+		}
+		if err != nil {
+			return "", fmt.Errorf("아카이브 읽기 실패: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg || path.Base(header.Name) != edition+".mmdb" {
+			continue
+		}
+
+		// 설치 시 rename이 원자적이도록 데이터 디렉터리와 같은 파일 시스템에 풉니다
+		out, err := os.CreateTemp(u.cfg.DataDir, "."+edition+"-*.mmdb.tmp")
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(out, tr)
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(out.Name())
+			return "", fmt.Errorf("데이터베이스 파일 추출 실패: %w", err)
+		}
+		return out.Name(), nil
+	}
+}
+
+// install은 현재 데이터베이스를 이전 버전으로 보관한 뒤 새 파일로 원자적으로 교체하고,
+// KeepVersions를 넘는 오래된 버전을 지웁니다
+func (u *Updater) install(database, edition string) error {
+	current := u.databasePath(edition)
+	if _, err := os.Stat(current); err == nil && u.cfg.KeepVersions > 0 {
+		if err := u.archiveCurrent(current, edition); err != nil {
+			return fmt.Errorf("현재 데이터베이스 보관 실패: %w", err)
+		}
+	}
+
+	if err := os.Chmod(database, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(database, current); err != nil {
+		return fmt.Errorf("데이터베이스 교체 실패: %w", err)
+	}
+
+	return u.prune(edition)
+}
+
+// archiveCurrent는 현재 파일을 하드 링크로 versions/<에디션>/<빌드 epoch>.mmdb에, 설치 체크섬을
+// <빌드 epoch>.sha256에 보관합니다. 현재 파일은 그대로 남아 있으므로 교체 전까지 조회가 계속 가능합니다.
+func (u *Updater) archiveCurrent(current, edition string) error {
+	version := ""
+	if buildEpoch, err := u.validate(current, edition); err == nil {
+		version = strconv.FormatUint(uint64(buildEpoch), 10)
+	} else {
+		info, err := os.Stat(current)
+		if err != nil {
+			return err
+		}
+		version = strconv.FormatInt(info.ModTime().Unix(), 10)
+	}
+
+	versionSum := filepath.Join(u.versionDir(edition), version+versionSumSuffix)
+	os.Remove(versionSum)
+	if installedSum, err := os.ReadFile(filepath.Join(u.versionDir(edition), installedSumFile)); err == nil {
+		if err := os.WriteFile(versionSum, installedSum, 0o644); err != nil {
+			return err
+		}
+	}
+
+	target := filepath.Join(u.versionDir(edition), version+".mmdb")
+	os.Remove(target)
+	if err := os.Link(current, target); err == nil {
+		return nil
+	}
+	return copyFile(current, target)
+}
+
+// prune은 가장 최근 KeepVersions개를 남기고 이전 버전을 지웁니다
+func (u *Updater) prune(edition string) error {
+	versions, err := u.previousVersions(edition)
+	if err != nil {
+		return err
+	}
+
+	keep := u.cfg.KeepVersions
+	if keep < 0 {
+		keep = 0
+	}
+	for i := keep; i < len(versions); i++ {
+		if err := os.Remove(filepath.Join(u.versionDir(edition), versions[i])); err != nil {
+			return err
+		}
+		os.Remove(filepath.Join(u.versionDir(edition), strings.TrimSuffix(versions[i], ".mmdb")+versionSumSuffix))
+	}
+	return nil
+}
+
+// previousVersions는 보관 중인 버전 파일 이름을 최신순으로 반환합니다
+func (u *Updater) previousVersions(edition string) ([]string, error) {
+	entries, err := os.ReadDir(u.versionDir(edition))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), ".mmdb") {
+			versions = append(versions, entry.Name())
+		}
+	}
+	// 파일 이름은 Unix 초 단위 epoch이므로 길이가 같으면 문자열 순서가 시간 순서입니다
+	sort.Slice(versions, func(i, j int) bool {
+		if len(versions[i]) != len(versions[j]) {
+			return len(versions[i]) > len(versions[j])
+		}
+		return versions[i] > versions[j]
+	})
+	return versions, nil
+}
+
+// get은 에디션과 접미사로 다운로드 주소를 만들어 요청합니다
+func (u *Updater) get(ctx context.Context, edition, suffix string) (*http.Response, error) {
+	url := strings.NewReplacer("{edition}", edition, "{suffix}", suffix).Replace(u.cfg.DownloadURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if u.cfg.AccountID != "" || u.cfg.LicenseKey != "" {
+		req.SetBasicAuth(u.cfg.AccountID, u.cfg.LicenseKey)
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("다운로드 요청 실패: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("다운로드 요청 실패: %s %s", suffix, resp.Status)
+	}
+	return resp, nil
+}
+
+func (u *Updater) recordError(edition string, err error) {
+	checkedAt := u.now()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if st, ok := u.status[edition]; ok {
+		st.LastCheckedAt = &checkedAt
+		st.LastError = err.Error()
+	}
+}
+
+func (u *Updater) databasePath(edition string) string {
+	return filepath.Join(u.cfg.DataDir, edition+".mmdb")
+}
+
+func (u *Updater) versionDir(edition string) string {
+	return filepath.Join(u.cfg.DataDir, versionsDir, edition)
+}
+
+// editionMethods는 에디션 이름의 접미사별로 해당 데이터베이스가 지원해야 하는 조회 메서드입니다
+var editionMethods = []struct {
+	suffix string
+	method string
+}{
+	{"-City", "City"},
+	{"-Country", "Country"},
+	{"-ASN", "ASN"},
+	{"-Enterprise", "Enterprise"},
+	{"-ISP", "ISP"},
+	{"-Domain", "Domain"},
+	{"-Connection-Type", "ConnectionType"},
+	{"-Anonymous-IP", "AnonymousIP"},
+}
+
+// validateDatabase는 mmdb 파일을 열어 에디션에 맞는 조회를 지원하는지 확인하고 빌드 epoch를 반환합니다
+func validateDatabase(file, edition string) (uint, error) {
+	var reader *geolite.Reader
+	var err error
+
+	opened := false
+	for _, em := range editionMethods {
+		if strings.HasSuffix(edition, em.suffix) {
+			reader, err = geolite.OpenFor(file, em.method)
+			opened = true
+			break
+		}
+	}
+	if !opened {
+		reader, err = geolite.Open(file)
+	}
+	if err != nil {
+		if reader != nil {
+			reader.Close()
+		}
+		return 0, err
+	}
+	defer reader.Close()
+
+	return reader.Metadata().BuildEpoch, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,311 @@
+package updater
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+const testEdition = "GeoLite2-City"
+
+// fakeMirror는 MaxMind 다운로드 API처럼 아카이브와 체크섬 파일을 제공하는 HTTP 서버입니다
+type fakeMirror struct {
+	mu       sync.Mutex
+	archive  []byte
+	checksum string
+	requests int
+}
+
+func (m *fakeMirror) publish(t *testing.T, content string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	name := testEdition + "_20250101/" + testEdition + ".mmdb"
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	tw.Close()
+	gz.Close()
+
+	sum := sha256.Sum256(buf.Bytes())
+	m.mu.Lock()
+	m.archive = buf.Bytes()
+	m.checksum = hex.EncodeToString(sum[:])
+	m.mu.Unlock()
+}
+
+func (m *fakeMirror) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, pass, ok := r.BasicAuth(); !ok || user != "42" || pass != "license" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.URL.Path != "/geoip/databases/"+testEdition+"/download" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch r.URL.Query().Get("suffix") {
+	case checksumSuffix:
+		fmt.Fprintf(w, "%s  %s_20250101.tar.gz\n", m.checksum, testEdition)
+	case archiveSuffix:
+		m.requests++
+		w.Write(m.archive)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// newTestUpdater는 파일 내용의 "build-<epoch>" 값을 빌드 epoch로 취급하는 Updater를 생성합니다
+func newTestUpdater(t *testing.T, mirror *fakeMirror, keep int) (*Updater, string) {
+	t.Helper()
+
+	server := httptest.NewServer(mirror)
+	t.Cleanup(server.Close)
+
+	dataDir := t.TempDir()
+	u := New(Config{
+		DownloadURL:  server.URL + "/geoip/databases/{edition}/download?suffix={suffix}",
+		AccountID:    "42",
+		LicenseKey:   "license",
+		Editions:     []string{testEdition},
+		DataDir:      dataDir,
+		KeepVersions: keep,
+	}, zap.NewNop())
+	u.validate = func(file, edition string) (uint, error) {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return 0, err
+		}
+		epoch, err := strconv.ParseUint(strings.TrimPrefix(string(content), "build-"), 10, 64)
+		if err != nil {
+			return 0, errors.New("invalid database")
+		}
+		return uint(epoch), nil
+	}
+	return u, dataDir
+}
+
+func readDatabase(t *testing.T, dataDir string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(dataDir, testEdition+".mmdb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestUpdateInstallsAndKeepsPreviousVersions(t *testing.T) {
+	mirror := &fakeMirror{}
+	u, dataDir := newTestUpdater(t, mirror, 2)
+	ctx := context.Background()
+
+	mirror.publish(t, "build-1700000001")
+	installed, err := u.Update(ctx)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if len(installed) != 1 || readDatabase(t, dataDir) != "build-1700000001" {
+		t.Fatalf("first Update() installed %v, database %q", installed, readDatabase(t, dataDir))
+	}
+
+	// 체크섬이 그대로이면 아카이브를 다시 내려받지 않습니다
+	installed, err = u.Update(ctx)
+	if err != nil || len(installed) != 0 || mirror.requests != 1 {
+		t.Fatalf("unchanged Update() installed %v, err %v, archive requests %d", installed, err, mirror.requests)
+	}
+
+	for _, build := range []string{"build-1700000002", "build-1700000003", "build-1700000004"} {
+		mirror.publish(t, build)
+		if _, err := u.Update(ctx); err != nil {
+			t.Fatalf("Update(%s) error = %v", build, err)
+		}
+	}
+	if got := readDatabase(t, dataDir); got != "build-1700000004" {
+		t.Fatalf("database = %q, want build-1700000004", got)
+	}
+
+	status := u.Status()[0]
+	if want := []string{"1700000003", "1700000002"}; fmt.Sprint(status.PreviousVersions) != fmt.Sprint(want) {
+		t.Errorf("PreviousVersions = %v, want %v", status.PreviousVersions, want)
+	}
+	if status.SHA256 != mirror.checksum || status.LastUpdatedAt == nil || status.LastError != "" {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	if err := u.Rollback(testEdition); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if got := readDatabase(t, dataDir); got != "build-1700000003" {
+		t.Errorf("database after Rollback() = %q, want build-1700000003", got)
+	}
+}
+
+func TestUpdateRejectsChecksumMismatch(t *testing.T) {
+	mirror := &fakeMirror{}
+	u, dataDir := newTestUpdater(t, mirror, 3)
+
+	mirror.publish(t, "build-1700000001")
+	mirror.checksum = strings.Repeat("0", 64)
+
+	installed, err := u.Update(context.Background())
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Update() error = %v, want ErrChecksumMismatch", err)
+	}
+	if len(installed) != 0 {
+		t.Errorf("Update() installed %v", installed)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, testEdition+".mmdb")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("database installed despite checksum mismatch: %v", err)
+	}
+	if u.Status()[0].LastError == "" {
+		t.Error("LastError not recorded")
+	}
+}
+
+func TestUpdateRejectsInvalidDatabase(t *testing.T) {
+	mirror := &fakeMirror{}
+	u, dataDir := newTestUpdater(t, mirror, 3)
+
+	mirror.publish(t, "build-1700000001")
+	if _, err := u.Update(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mirror.publish(t, "not a database")
+	if _, err := u.Update(context.Background()); err == nil {
+		t.Fatal("Update() accepted an invalid database")
+	}
+	if got := readDatabase(t, dataDir); got != "build-1700000001" {
+		t.Errorf("database = %q, want build-1700000001", got)
+	}
+}
+
+type failingReloader struct {
+	calls int
+}
+
+func (r *failingReloader) Reload() error {
+	r.calls++
+	if r.calls == 1 {
+		return errors.New("reload failed")
+	}
+	return nil
+}
+
+func TestRunRollsBackWhenReloadFails(t *testing.T) {
+	mirror := &fakeMirror{}
+	u, dataDir := newTestUpdater(t, mirror, 3)
+
+	mirror.publish(t, "build-1700000001")
+	if _, err := u.Update(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mirror.publish(t, "build-1700000002")
+	reloader := &failingReloader{}
+	u.runOnce(context.Background(), reloader)
+
+	if reloader.calls != 2 {
+		t.Errorf("Reload() called %d times, want 2", reloader.calls)
+	}
+	if got := readDatabase(t, dataDir); got != "build-1700000001" {
+		t.Errorf("database = %q, want rolled back build-1700000001", got)
+	}
+	if u.Status()[0].LastError == "" {
+		t.Error("LastError not recorded after rollback")
+	}
+}
+
+func TestRollbackRestoresInstalledChecksum(t *testing.T) {
+	mirror := &fakeMirror{}
+	u, dataDir := newTestUpdater(t, mirror, 3)
+
+	mirror.publish(t, "build-1700000001")
+	if _, err := u.Update(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	previousSum := mirror.checksum
+
+	mirror.publish(t, "build-1700000002")
+	u.runOnce(context.Background(), &failingReloader{})
+
+	if got := u.Status()[0].SHA256; got != previousSum {
+		t.Errorf("Status SHA256 = %q, want the rolled back %q", got, previousSum)
+	}
+	installedSum, err := os.ReadFile(filepath.Join(dataDir, versionsDir, testEdition, installedSumFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(installedSum)); got != previousSum {
+		t.Errorf("installed checksum = %q, want %q", got, previousSum)
+	}
+
+	// 되돌린 뒤에도 원격 버전은 다시 내려받아야 합니다
+	installed, err := u.Update(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(installed) != 1 || mirror.requests != 3 {
+		t.Errorf("installed = %v after %d downloads, want the remote version downloaded again", installed, mirror.requests)
+	}
+}
+
+func TestRunDiscardsDatabaseWithoutPreviousVersion(t *testing.T) {
+	mirror := &fakeMirror{}
+	u, dataDir := newTestUpdater(t, mirror, 3)
+
+	mirror.publish(t, "build-1700000001")
+	u.runOnce(context.Background(), &failingReloader{})
+
+	if _, err := os.Stat(filepath.Join(dataDir, testEdition+".mmdb")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("database that failed to load left installed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, versionsDir, testEdition, installedSumFile)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("checksum of the discarded database left installed: %v", err)
+	}
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != versionsDir {
+			t.Errorf("staging file %s left in the data directory", entry.Name())
+		}
+	}
+	status := u.Status()[0]
+	if status.SHA256 != "" || status.LastError == "" {
+		t.Errorf("status = %+v, want no SHA256 and the reload error", status)
+	}
+
+	// 다음 확인 때 다시 내려받습니다
+	if _, err := u.Update(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if mirror.requests != 2 {
+		t.Errorf("archive downloaded %d times, want 2", mirror.requests)
+	}
+}
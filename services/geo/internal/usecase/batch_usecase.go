@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"sync"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+)
+
+// MaxBatchSize는 한 번의 일괄 조회 요청에 담을 수 있는 최대 IP 수입니다
+const MaxBatchSize = 1000
+
+// LookupField는 일괄 조회에서 선택할 수 있는 조회 항목입니다
+type LookupField string
+
+const (
+	LookupFieldCity      LookupField = "city"
+	LookupFieldCountry   LookupField = "country"
+	LookupFieldASN       LookupField = "asn"
+	LookupFieldAnonymous LookupField = "anonymous"
+)
+
+// LookupFields는 IP마다 수행할 조회 항목의 집합입니다
+type LookupFields struct {
+	City      bool
+	Country   bool
+	ASN       bool
+	Anonymous bool
+}
+
+// AllLookupFields는 모든 항목을 조회합니다
+var AllLookupFields = LookupFields{City: true, Country: true, ASN: true, Anonymous: true}
+
+// ParseLookupFields는 항목 이름 목록을 LookupFields로 변환합니다. 목록이 비어 있으면 모든 항목을 조회합니다.
+func ParseLookupFields(names []string) (LookupFields, error) {
+	if len(names) == 0 {
+		return AllLookupFields, nil
+	}
+
+	var fields LookupFields
+	for _, name := range names {
+		switch LookupField(name) {
+		case LookupFieldCity:
+			fields.City = true
+		case LookupFieldCountry:
+			fields.Country = true
+		case LookupFieldASN:
+			fields.ASN = true
+		case LookupFieldAnonymous:
+			fields.Anonymous = true
+		default:
+			return LookupFields{}, ErrInvalidLookupField
+		}
+	}
+	return fields, nil
+}
+
+// AnonymousResult는 익명 IP 확인 결과입니다
+type AnonymousResult struct {
+	IsAnonymous    bool `json:"is_anonymous"`
+	IsTorExitNode  bool `json:"is_tor_exit_node"`
+	FeatureSupport bool `json:"feature_support"`
+}
+
+// LookupResult는 IP 하나에 대한 일괄 조회 결과입니다. 요청하지 않았거나 실패한 항목은 비어 있고,
+// 실패한 항목이 있으면 Errors에 항목별 오류가 담깁니다.
+type LookupResult struct {
+	IP        string            `json:"ip"`
+	City      *entity.City      `json:"city,omitempty"`
+	Country   *entity.Country   `json:"country,omitempty"`
+	ASN       *entity.ASN       `json:"asn,omitempty"`
+	Anonymous *AnonymousResult  `json:"anonymous,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// Lookup은 IP 하나에 대해 선택한 항목만 조회합니다. IP가 유효하지 않으면 Error에 사유를 담습니다.
+// City와 Country를 함께 요청하면 City 데이터베이스의 국가 정보를 사용하여 Country 조회를 생략합니다.
+func (uc *GeoUseCase) Lookup(ipStr string, fields LookupFields) LookupResult {
+	result := LookupResult{IP: ipStr}
+
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		result.Error = ErrInvalidIPAddress.Error()
+		return result
+	}
+
+	fail := func(field LookupField, err error) {
+		if result.Errors == nil {
+			result.Errors = make(map[string]string)
+		}
+		result.Errors[string(field)] = err.Error()
+	}
+
+	if fields.City {
+		if city, err := uc.cityRepo.GetCity(ip); err != nil {
+			fail(LookupFieldCity, err)
+		} else {
+			result.City = &city
+			if fields.Country {
+				result.Country = &entity.Country{Country: city.Country, Continent: city.Continent}
+			}
+		}
+	}
+
+	if fields.Country && result.Country == nil {
+		if country, err := uc.countryRepo.GetCountry(ip); err != nil {
+			fail(LookupFieldCountry, err)
+		} else {
+			result.Country = &country
+		}
+	}
+
+	if fields.ASN {
+		if asn, err := uc.asnRepo.GetASN(ip); err != nil {
+			fail(LookupFieldASN, err)
+		} else {
+			result.ASN = &asn
+		}
+	}
+
+	if fields.Anonymous {
+		if uc.anonymousRepo == nil {
+			result.Anonymous = &AnonymousResult{FeatureSupport: false}
+		} else if anonIP, err := uc.anonymousRepo.GetAnonymousIP(ip); err != nil {
+			if notSupported(err) == ErrFeatureNotSupported {
+				result.Anonymous = &AnonymousResult{FeatureSupport: false}
+			} else {
+				fail(LookupFieldAnonymous, err)
+			}
+		} else {
+			result.Anonymous = &AnonymousResult{
+				IsAnonymous:    anonIP.IsAnonymous,
+				IsTorExitNode:  anonIP.IsTorExitNode,
+				FeatureSupport: true,
+			}
+		}
+	}
+
+	return result
+}
+
+// BatchLookup은 여러 IP를 동시에 조회하여 요청 순서대로 결과를 반환합니다.
+// 동시에 조회하는 수는 BatchConcurrency로 제한되며, ctx가 취소되면 남은 IP는 조회하지 않고 오류를 담습니다.
+func (uc *GeoUseCase) BatchLookup(ctx context.Context, ips []string, fields LookupFields) ([]LookupResult, error) {
+	if len(ips) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+
+	results := make([]LookupResult, len(ips))
+	sem := make(chan struct{}, BatchConcurrency())
+	var wg sync.WaitGroup
+	for i, ip := range ips {
+		// 취소된 뒤에는 빈 자리가 있어도 조회하지 않습니다(select는 준비된 경우 중 임의로 고릅니다)
+		if err := ctx.Err(); err != nil {
+			results[i] = LookupResult{IP: ip, Error: err.Error()}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			results[i] = LookupResult{IP: ip, Error: ctx.Err().Error()}
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int, ip string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = uc.Lookup(ip, fields)
+		}(i, ip)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// BatchConcurrency는 일괄 조회에서 동시에 수행하는 조회 수입니다.
+// 조회는 메모리 맵 파일을 읽는 CPU 작업이므로 사용 가능한 CPU 수로 제한합니다.
+func BatchConcurrency() int {
+	return runtime.GOMAXPROCS(0)
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/repository"
+)
+
+// stubGeoRepository는 모든 IP에 같은 결과를 돌려주는 GeoIP2Repository입니다.
+// errs에 조회 메서드 이름(예: "GetASN")으로 오류를 설정하면 그 조회는 오류를 반환합니다.
+type stubGeoRepository struct {
+	city           entity.City
+	country        entity.Country
+	asn            entity.ASN
+	anonymousIP    entity.AnonymousIP
+	enterprise     entity.Enterprise
+	isp            entity.ISP
+	domain         entity.Domain
+	connectionType entity.ConnectionType
+	errs           map[string]error
+
+	// onCity는 설정되면 GetCity가 결과 대신 호출하여 그 결과를 반환합니다
+	onCity func(ip net.IP) entity.City
+
+	cityCalls    atomic.Int32
+	countryCalls atomic.Int32
+}
+
+var _ repository.GeoIP2Repository = (*stubGeoRepository)(nil)
+
+func (s *stubGeoRepository) GetCity(ip net.IP) (entity.City, error) {
+	s.cityCalls.Add(1)
+	if err := s.errs["GetCity"]; err != nil {
+		return entity.City{}, err
+	}
+	if s.onCity != nil {
+		return s.onCity(ip), nil
+	}
+	return s.city, nil
+}
+
+func (s *stubGeoRepository) GetCountry(net.IP) (entity.Country, error) {
+	s.countryCalls.Add(1)
+	return s.country, s.errs["GetCountry"]
+}
+
+func (s *stubGeoRepository) GetASN(net.IP) (entity.ASN, error) {
+	return s.asn, s.errs["GetASN"]
+}
+
+func (s *stubGeoRepository) GetAnonymousIP(net.IP) (entity.AnonymousIP, error) {
+	return s.anonymousIP, s.errs["GetAnonymousIP"]
+}
+
+func (s *stubGeoRepository) GetEnterprise(net.IP) (entity.Enterprise, error) {
+	return s.enterprise, s.errs["GetEnterprise"]
+}
+
+func (s *stubGeoRepository) GetISP(net.IP) (entity.ISP, error) {
+	return s.isp, s.errs["GetISP"]
+}
+
+func (s *stubGeoRepository) GetDomain(net.IP) (entity.Domain, error) {
+	return s.domain, s.errs["GetDomain"]
+}
+
+func (s *stubGeoRepository) GetConnectionType(net.IP) (entity.ConnectionType, error) {
+	return s.connectionType, s.errs["GetConnectionType"]
+}
+
+func (s *stubGeoRepository) Close() error { return nil }
+
+// cityNamedAfterIP는 IP 주소를 도시 이름으로 하는 결과를 조금씩 다른 시간 뒤에 반환합니다
+func cityNamedAfterIP(ip net.IP) entity.City {
+	time.Sleep(time.Duration(ip.To4()[3]%7) * time.Millisecond)
+	return entity.City{City: entity.CityInfo{Names: map[string]string{"en": ip.String()}}}
+}
+
+func testIPs(n int) []string {
+	ips := make([]string, n)
+	for i := range ips {
+		ips[i] = fmt.Sprintf("10.0.%d.%d", i/256, i%256)
+	}
+	return ips
+}
+
+func TestBatchLookupKeepsRequestOrder(t *testing.T) {
+	uc := NewGeoUseCaseWithGeoIP2(&stubGeoRepository{onCity: cityNamedAfterIP})
+	ips := testIPs(50)
+
+	results, err := uc.BatchLookup(context.Background(), ips, LookupFields{City: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(ips) {
+		t.Fatalf("len(results) = %d, want %d", len(results), len(ips))
+	}
+	for i, result := range results {
+		if result.IP != ips[i] || result.City == nil || result.City.City.Names["en"] != ips[i] {
+			t.Fatalf("results[%d] = %+v, want the lookup of %s", i, result, ips[i])
+		}
+	}
+}
+
+func TestBatchLookupRejectsTooManyIPs(t *testing.T) {
+	uc := NewGeoUseCaseWithGeoIP2(&stubGeoRepository{})
+
+	if _, err := uc.BatchLookup(context.Background(), testIPs(MaxBatchSize+1), AllLookupFields); !errors.Is(err, ErrBatchTooLarge) {
+		t.Errorf("%d개 조회 error = %v, want ErrBatchTooLarge", MaxBatchSize+1, err)
+	}
+	results, err := uc.BatchLookup(context.Background(), testIPs(MaxBatchSize), LookupFields{ASN: true})
+	if err != nil || len(results) != MaxBatchSize {
+		t.Errorf("%d개 조회 = %d개, %v", MaxBatchSize, len(results), err)
+	}
+}
+
+func TestLookupReportsErrorsPerField(t *testing.T) {
+	repo := &stubGeoRepository{
+		city: entity.City{Country: entity.CountryInfo{IsoCode: "KR"}},
+		errs: map[string]error{
+			"GetASN":         errors.New("ASN 조회 실패"),
+			"GetAnonymousIP": repository.ErrDatabaseNotAvailable,
+		},
+	}
+	uc := NewGeoUseCaseWithGeoIP2(repo)
+
+	result := uc.Lookup("1.2.3.4", AllLookupFields)
+
+	if result.Error != "" {
+		t.Errorf("Error = %q, want none", result.Error)
+	}
+	if result.City == nil || result.Country == nil || result.Country.Country.IsoCode != "KR" {
+		t.Errorf("City, Country = %+v, %+v, want KR", result.City, result.Country)
+	}
+	if result.ASN != nil || result.Errors["asn"] != "ASN 조회 실패" {
+		t.Errorf("ASN = %+v, Errors = %v, want only the asn error", result.ASN, result.Errors)
+	}
+	// 설치되지 않은 Anonymous IP 데이터베이스는 오류가 아니라 미지원으로 보고합니다
+	if result.Anonymous == nil || result.Anonymous.FeatureSupport {
+		t.Errorf("Anonymous = %+v, want FeatureSupport false", result.Anonymous)
+	}
+	if _, ok := result.Errors["anonymous"]; ok || len(result.Errors) != 1 {
+		t.Errorf("Errors = %v, want only asn", result.Errors)
+	}
+	// City와 Country를 함께 요청하면 Country 데이터베이스는 조회하지 않습니다
+	if calls := repo.countryCalls.Load(); calls != 0 {
+		t.Errorf("GetCountry 호출 %d회, want 0", calls)
+	}
+}
+
+func TestLookupInvalidIP(t *testing.T) {
+	repo := &stubGeoRepository{}
+	uc := NewGeoUseCaseWithGeoIP2(repo)
+
+	result := uc.Lookup("not-an-ip", AllLookupFields)
+
+	if result.Error != ErrInvalidIPAddress.Error() || result.City != nil || result.Errors != nil {
+		t.Errorf("Lookup = %+v, want only the invalid IP error", result)
+	}
+	if calls := repo.cityCalls.Load(); calls != 0 {
+		t.Errorf("GetCity 호출 %d회, want 0", calls)
+	}
+}
+
+func TestBatchLookupCanceledContext(t *testing.T) {
+	repo := &stubGeoRepository{}
+	uc := NewGeoUseCaseWithGeoIP2(repo)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := uc.BatchLookup(ctx, testIPs(20), LookupFields{City: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, result := range results {
+		if result.Error != context.Canceled.Error() {
+			t.Errorf("results[%d].Error = %q, want %q", i, result.Error, context.Canceled.Error())
+		}
+	}
+	if calls := repo.cityCalls.Load(); calls != 0 {
+		t.Errorf("취소된 뒤 GetCity 호출 %d회, want 0", calls)
+	}
+}
+
+func TestBatchLookupStopsWhenCanceled(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo := &stubGeoRepository{onCity: func(ip net.IP) entity.City {
+		cancel()
+		return entity.City{}
+	}}
+	uc := NewGeoUseCaseWithGeoIP2(repo)
+	ips := testIPs(100)
+
+	results, err := uc.BatchLookup(ctx, ips, LookupFields{City: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	canceled := 0
+	for i, result := range results {
+		switch {
+		case result.Error == context.Canceled.Error():
+			canceled++
+		case result.City == nil:
+			t.Errorf("results[%d] = %+v, want a city or the cancellation", i, result)
+		}
+	}
+	if looked := int(repo.cityCalls.Load()); looked+canceled != len(ips) || looked > BatchConcurrency()+1 {
+		t.Errorf("조회 %d개, 취소 %d개, want at most %d lookups", looked, canceled, BatchConcurrency()+1)
+	}
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/repository"
+)
+
+const (
+	defaultCacheTTL = time.Hour
+	// cacheTimeout은 캐시 저장소 요청 한 번의 제한 시간입니다. 캐시가 느리면 조회를 기다리게 하지 않고 건너뜁니다.
+	cacheTimeout = 100 * time.Millisecond
+)
+
+// GeoDataProvider는 IP 주소의 종합 지리 정보를 제공합니다. GeoUseCase와 CachedGeoData가 구현합니다.
+// languages는 도시와 국가 이름에 사용할 언어의 선호 순서입니다.
+type GeoDataProvider interface {
+	GetGeoData(ipStr string, languages ...string) (*GeoData, error)
+}
+
+// DatabaseVersionProvider는 조회에 사용 중인 데이터베이스의 버전을 제공합니다
+type DatabaseVersionProvider interface {
+	DatabaseVersion() string
+}
+
+// CacheOptions는 조회 결과 캐시 설정입니다
+type CacheOptions struct {
+	// TTL은 캐시 항목의 유효 시간입니다. 0이면 1시간입니다.
+	TTL time.Duration
+	// IPv4Prefix와 IPv6Prefix는 캐시 키로 사용할 네트워크 접두사 길이입니다.
+	// 0이면 IP 주소 하나(/32, /128)를 키로 사용하고, 줄이면 같은 네트워크의 IP가 결과를 공유합니다.
+	IPv4Prefix int
+	IPv6Prefix int
+}
+
+// CacheStats는 조회 결과 캐시의 적중/실패 통계입니다
+type CacheStats struct {
+	Hits     uint64  `json:"hits"`
+	Misses   uint64  `json:"misses"`
+	Errors   uint64  `json:"errors"`
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+// CachedGeoData는 GetGeoData 결과를 CacheRepository에 저장하는 데코레이터입니다.
+// 캐시 키에 데이터베이스 버전을 넣으므로, 재적재로 빌드가 바뀌면 이전 결과는 더 이상 조회되지 않고 TTL이나 LRU로 사라집니다.
+// 캐시 저장소 오류는 조회를 실패시키지 않고 통계에만 남깁니다.
+type CachedGeoData struct {
+	next    GeoDataProvider
+	cache   repository.CacheRepository
+	version DatabaseVersionProvider
+	opts    CacheOptions
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	errors atomic.Uint64
+}
+
+// NewCachedGeoData는 next의 GetGeoData 결과를 cache에 저장하는 CachedGeoData 인스턴스를 생성합니다
+func NewCachedGeoData(next GeoDataProvider, cache repository.CacheRepository, version DatabaseVersionProvider, opts CacheOptions) *CachedGeoData {
+	if opts.TTL <= 0 {
+		opts.TTL = defaultCacheTTL
+	}
+	if opts.IPv4Prefix <= 0 || opts.IPv4Prefix > 32 {
+		opts.IPv4Prefix = 32
+	}
+	if opts.IPv6Prefix <= 0 || opts.IPv6Prefix > 128 {
+		opts.IPv6Prefix = 128
+	}
+
+	return &CachedGeoData{
+		next:    next,
+		cache:   cache,
+		version: version,
+		opts:    opts,
+	}
+}
+
+// GetGeoData는 캐시에 있으면 저장된 결과를, 없으면 next에서 조회하여 저장한 결과를 반환합니다.
+// 이름의 언어가 다르면 결과도 다르므로 언어 목록별로 따로 저장합니다. 오류 결과는 저장하지 않습니다.
+func (c *CachedGeoData) GetGeoData(ipStr string, languages ...string) (*GeoData, error) {
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return c.next.GetGeoData(ipStr, languages...)
+	}
+	key := c.key(ip, languages)
+
+	getCtx, cancel := context.WithTimeout(context.Background(), cacheTimeout)
+	cached, err := c.cache.Get(getCtx, key)
+	cancel()
+	if err == nil {
+		var geoData GeoData
+		if err := json.Unmarshal(cached, &geoData); err == nil {
+			c.hits.Add(1)
+			// 접두사 단위로 공유된 결과일 수 있으므로 조회한 IP로 바꿉니다
+			geoData.IPAddress = ipStr
+			return &geoData, nil
+		}
+		c.errors.Add(1)
+	} else if !errors.Is(err, repository.ErrCacheMiss) {
+		c.errors.Add(1)
+	}
+	c.misses.Add(1)
+
+	geoData, err := c.next.GetGeoData(ipStr, languages...)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(geoData); err == nil {
+		setCtx, cancel := context.WithTimeout(context.Background(), cacheTimeout)
+		if err := c.cache.Set(setCtx, key, data, c.opts.TTL); err != nil {
+			c.errors.Add(1)
+		}
+		cancel()
+	}
+
+	return geoData, nil
+}
+
+// Stats는 지금까지의 캐시 적중/실패 통계를 반환합니다
+func (c *CachedGeoData) Stats() CacheStats {
+	stats := CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Errors: c.errors.Load(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// key는 데이터베이스 버전, 언어 목록, IP 네트워크 접두사로 캐시 키를 만듭니다(예: geo:<버전>:ko,en:1.2.3.0/24).
+// 언어 목록이 비어 있으면 "-"를 사용합니다.
+func (c *CachedGeoData) key(ip net.IP, languages []string) string {
+	var network net.IPNet
+	if ip4 := ip.To4(); ip4 != nil {
+		mask := net.CIDRMask(c.opts.IPv4Prefix, 32)
+		network = net.IPNet{IP: ip4.Mask(mask), Mask: mask}
+	} else {
+		mask := net.CIDRMask(c.opts.IPv6Prefix, 128)
+		network = net.IPNet{IP: ip.Mask(mask), Mask: mask}
+	}
+	lang := strings.Join(languages, ",")
+	if lang == "" {
+		lang = "-"
+	}
+	return fmt.Sprintf("geo:%s:%s:%s", c.version.DatabaseVersion(), lang, network.String())
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/repository"
+)
+
+// UpdateStatusProvider는 데이터베이스 자동 업데이트 결과를 제공합니다
+type UpdateStatusProvider interface {
+	Status() []entity.DatabaseUpdateStatus
+}
+
+// DatabaseStatus는 데이터베이스 하나의 빌드 시각, 경과 시간, 자동 업데이트 결과입니다
+type DatabaseStatus struct {
+	entity.DatabaseInfo
+	AgeSeconds int64                        `json:"age_seconds"`
+	Update     *entity.DatabaseUpdateStatus `json:"update,omitempty"`
+}
+
+// DatabaseUseCase는 조회에 사용 중인 데이터베이스의 상태를 보고합니다
+type DatabaseUseCase struct {
+	repo    repository.ReloadableGeoLite2Repository
+	updater UpdateStatusProvider
+	now     func() time.Time
+}
+
+// NewDatabaseUseCase는 새로운 DatabaseUseCase 인스턴스를 생성합니다.
+// 자동 업데이트를 사용하지 않으면 updater는 nil입니다.
+func NewDatabaseUseCase(repo repository.ReloadableGeoLite2Repository, updater UpdateStatusProvider) *DatabaseUseCase {
+	return &DatabaseUseCase{
+		repo:    repo,
+		updater: updater,
+		now:     time.Now,
+	}
+}
+
+// GetDatabaseStatus는 데이터베이스별 빌드 epoch와 빌드 후 경과 시간을 자동 업데이트 결과와 함께 반환합니다
+func (uc *DatabaseUseCase) GetDatabaseStatus() []DatabaseStatus {
+	updates := make(map[string]entity.DatabaseUpdateStatus)
+	if uc.updater != nil {
+		for _, status := range uc.updater.Status() {
+			updates[status.Edition] = status
+		}
+	}
+
+	now := uc.now()
+	databases := uc.repo.Databases()
+	statuses := make([]DatabaseStatus, 0, len(databases))
+	for _, db := range databases {
+		status := DatabaseStatus{
+			DatabaseInfo: db,
+			AgeSeconds:   int64(now.Sub(db.BuiltAt) / time.Second),
+		}
+		if update, ok := updates[db.Edition]; ok {
+			status.Update = &update
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
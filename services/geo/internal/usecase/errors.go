@@ -7,4 +7,9 @@ var (
 	ErrInvalidIPAddress    = errors.New("유효하지 않은 IP 주소입니다")
 	ErrFeatureNotSupported = errors.New("지원하지 않는 기능입니다")
 	ErrGeoLookupFailed     = errors.New("지리 정보 조회에 실패했습니다")
+	ErrBatchTooLarge       = errors.New("한 번에 조회할 수 있는 IP 수를 초과했습니다")
+	ErrInvalidLookupField  = errors.New("지원하지 않는 조회 항목입니다")
+
+	ErrGeoFenceRuleSetNotFound = errors.New("지역 제한 규칙 집합이 없습니다")
+	ErrInvalidGeoFenceRule     = errors.New("잘못된 지역 제한 규칙입니다")
 )
@@ -1,7 +1,9 @@
 package usecase
 
 import (
+	"errors"
 	"net"
+	"strings"
 
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/repository"
@@ -13,6 +15,15 @@ type GeoUseCase struct {
 	countryRepo   repository.GeoIP2CountryRepository
 	asnRepo       repository.GeoLite2ASNRepository
 	anonymousRepo repository.GeoIP2AnonymousIPRepository
+
+	// 아래 리포지토리는 GeoIP2 데이터베이스가 있을 때만 사용합니다
+	enterpriseRepo     repository.GeoIP2EnterpriseRepository
+	ispRepo            repository.GeoIP2ISPRepository
+	domainRepo         repository.GeoIP2DomainRepository
+	connectionTypeRepo repository.GeoIP2ConnectionTypeRepository
+
+	// languages는 요청에 언어가 없거나 요청한 언어의 이름이 없을 때 차례로 사용하는 언어 목록입니다
+	languages []string
 }
 
 // NewGeoUseCase는 새로운 GeoUseCase 인스턴스를 생성합니다
@@ -40,6 +51,21 @@ func NewGeoUseCaseWithGeoLite2(repo repository.GeoLite2Repository) *GeoUseCase {
 	}
 }
 
+// NewGeoUseCaseWithGeoIP2는 GeoLite2와 설치된 GeoIP2 데이터베이스를 함께 조회하는 리포지토리를 사용하는
+// GeoUseCase 인스턴스를 생성합니다. 설치되지 않은 데이터베이스의 조회는 ErrFeatureNotSupported를 반환합니다.
+func NewGeoUseCaseWithGeoIP2(repo repository.GeoIP2Repository) *GeoUseCase {
+	return &GeoUseCase{
+		cityRepo:           repo,
+		countryRepo:        repo,
+		asnRepo:            repo,
+		anonymousRepo:      repo,
+		enterpriseRepo:     repo,
+		ispRepo:            repo,
+		domainRepo:         repo,
+		connectionTypeRepo: repo,
+	}
+}
+
 // GetCityInfo는 IP 주소에 대한 도시 정보를 조회합니다
 func (uc *GeoUseCase) GetCityInfo(ipStr string) (entity.City, error) {
 	ip := net.ParseIP(ipStr)
@@ -83,7 +109,7 @@ func (uc *GeoUseCase) IsAnonymousIP(ipStr string) (bool, error) {
 
 	anonIP, err := uc.anonymousRepo.GetAnonymousIP(ip)
 	if err != nil {
-		return false, err
+		return false, notSupported(err)
 	}
 
 	return anonIP.IsAnonymous, nil
@@ -102,18 +128,94 @@ func (uc *GeoUseCase) IsTorExitNode(ipStr string) (bool, error) {
 
 	anonIP, err := uc.anonymousRepo.GetAnonymousIP(ip)
 	if err != nil {
-		return false, err
+		return false, notSupported(err)
 	}
 
 	return anonIP.IsTorExitNode, nil
 }
 
-// GetGeoData는 IP 주소에 대한 종합적인 지리 정보를 조회합니다
-func (uc *GeoUseCase) GetGeoData(ipStr string) (*GeoData, error) {
+// GetEnterpriseInfo는 IP 주소에 대한 Enterprise 정보를 조회합니다
+func (uc *GeoUseCase) GetEnterpriseInfo(ipStr string) (entity.Enterprise, error) {
+	if uc.enterpriseRepo == nil {
+		return entity.Enterprise{}, ErrFeatureNotSupported
+	}
+
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return entity.Enterprise{}, ErrInvalidIPAddress
+	}
+
+	enterprise, err := uc.enterpriseRepo.GetEnterprise(ip)
+	return enterprise, notSupported(err)
+}
+
+// GetISPInfo는 IP 주소에 대한 ISP 정보를 조회합니다
+func (uc *GeoUseCase) GetISPInfo(ipStr string) (entity.ISP, error) {
+	if uc.ispRepo == nil {
+		return entity.ISP{}, ErrFeatureNotSupported
+	}
+
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return entity.ISP{}, ErrInvalidIPAddress
+	}
+
+	isp, err := uc.ispRepo.GetISP(ip)
+	return isp, notSupported(err)
+}
+
+// GetDomainInfo는 IP 주소에 대한 도메인 정보를 조회합니다
+func (uc *GeoUseCase) GetDomainInfo(ipStr string) (entity.Domain, error) {
+	if uc.domainRepo == nil {
+		return entity.Domain{}, ErrFeatureNotSupported
+	}
+
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return entity.Domain{}, ErrInvalidIPAddress
+	}
+
+	domain, err := uc.domainRepo.GetDomain(ip)
+	return domain, notSupported(err)
+}
+
+// GetConnectionTypeInfo는 IP 주소에 대한 연결 유형 정보를 조회합니다
+func (uc *GeoUseCase) GetConnectionTypeInfo(ipStr string) (entity.ConnectionType, error) {
+	if uc.connectionTypeRepo == nil {
+		return entity.ConnectionType{}, ErrFeatureNotSupported
+	}
+
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return entity.ConnectionType{}, ErrInvalidIPAddress
+	}
+
+	connectionType, err := uc.connectionTypeRepo.GetConnectionType(ip)
+	return connectionType, notSupported(err)
+}
+
+// SetDefaultLanguages는 이름을 고를 때 요청한 언어 다음으로 사용할 언어 목록을 설정합니다(예: ko, en).
+// 목록의 언어에도 이름이 없으면 DefaultLanguage의 이름을 사용합니다.
+func (uc *GeoUseCase) SetDefaultLanguages(languages []string) {
+	uc.languages = ParseLanguages(strings.Join(languages, ","))
+}
+
+// notSupported는 데이터베이스가 설치되지 않아 실패한 조회를 ErrFeatureNotSupported로 바꿉니다
+func notSupported(err error) error {
+	if errors.Is(err, repository.ErrDatabaseNotAvailable) {
+		return ErrFeatureNotSupported
+	}
+	return err
+}
+
+// GetGeoData는 IP 주소에 대한 종합적인 지리 정보를 조회합니다.
+// 도시와 국가 이름은 languages, 기본 언어 목록, DefaultLanguage 순으로 처음 찾은 언어의 이름을 사용합니다.
+func (uc *GeoUseCase) GetGeoData(ipStr string, languages ...string) (*GeoData, error) {
 	ip := net.ParseIP(ipStr)
 	if ip == nil {
 		return nil, ErrInvalidIPAddress
 	}
+	languages = append(languages[:len(languages):len(languages)], uc.languages...)
 
 	city, cityErr := uc.cityRepo.GetCity(ip)
 	country, countryErr := uc.countryRepo.GetCountry(ip)
@@ -131,7 +233,7 @@ func (uc *GeoUseCase) GetGeoData(ipStr string) (*GeoData, error) {
 
 	// 도시 정보가 있으면 설정합니다
 	if cityErr == nil {
-		geoData.City = city.City.Names["en"]
+		geoData.City = LocalizedName(city.City.Names, languages)
 		geoData.Latitude = city.Location.Latitude
 		geoData.Longitude = city.Location.Longitude
 		geoData.TimeZone = city.Location.TimeZone
@@ -140,12 +242,12 @@ func (uc *GeoUseCase) GetGeoData(ipStr string) (*GeoData, error) {
 	// 국가 정보가 있으면 설정합니다
 	if countryErr == nil {
 		geoData.CountryCode = country.Country.IsoCode
-		geoData.CountryName = country.Country.Names["en"]
+		geoData.CountryName = LocalizedName(country.Country.Names, languages)
 		geoData.ContinentCode = country.Continent.Code
 	} else if cityErr == nil {
 		// 도시 정보에서 국가 정보를 가져올 수 있습니다
 		geoData.CountryCode = city.Country.IsoCode
-		geoData.CountryName = city.Country.Names["en"]
+		geoData.CountryName = LocalizedName(city.Country.Names, languages)
 		geoData.ContinentCode = city.Continent.Code
 	}
 
@@ -159,9 +261,53 @@ func (uc *GeoUseCase) GetGeoData(ipStr string) (*GeoData, error) {
 	if uc.anonymousRepo != nil {
 		anonIP, err := uc.anonymousRepo.GetAnonymousIP(ip)
 		if err == nil {
+			geoData.AnonymousIPChecked = true
 			geoData.IsAnonymous = anonIP.IsAnonymous
 			geoData.IsAnonymousVPN = anonIP.IsAnonymousVPN
 			geoData.IsTorExitNode = anonIP.IsTorExitNode
+			geoData.IsHostingProvider = anonIP.IsHostingProvider
+			geoData.IsPublicProxy = anonIP.IsPublicProxy
+			geoData.IsResidentialProxy = anonIP.IsResidentialProxy
+		}
+	}
+
+	// GeoIP2 데이터베이스가 있으면 우편번호, 행정구역, ISP, 도메인, 연결 유형 정보를 더합니다
+	if uc.enterpriseRepo != nil {
+		if enterprise, err := uc.enterpriseRepo.GetEnterprise(ip); err == nil {
+			geoData.PostalCode = enterprise.Postal.Code
+			if len(enterprise.Subdivisions) > 0 {
+				geoData.Subdivision = enterprise.Subdivisions[0].IsoCode
+			}
+			geoData.UserType = enterprise.Traits.UserType
+			geoData.Organization = enterprise.Traits.Organization
+			geoData.Domain = enterprise.Traits.Domain
+			geoData.ConnectionType = enterprise.Traits.ConnectionType
+			if enterprise.Traits.ISP != "" {
+				geoData.ISP = enterprise.Traits.ISP
+			}
+		}
+	}
+
+	if uc.ispRepo != nil {
+		if isp, err := uc.ispRepo.GetISP(ip); err == nil {
+			if isp.ISP != "" {
+				geoData.ISP = isp.ISP
+			}
+			if isp.Organization != "" {
+				geoData.Organization = isp.Organization
+			}
+		}
+	}
+
+	if uc.domainRepo != nil {
+		if domain, err := uc.domainRepo.GetDomain(ip); err == nil && domain.Domain != "" {
+			geoData.Domain = domain.Domain
+		}
+	}
+
+	if uc.connectionTypeRepo != nil {
+		if connectionType, err := uc.connectionTypeRepo.GetConnectionType(ip); err == nil && connectionType.ConnectionType != "" {
+			geoData.ConnectionType = connectionType.ConnectionType
 		}
 	}
 
@@ -188,6 +334,12 @@ func (uc *GeoUseCase) Close() error {
 		anonErr = uc.anonymousRepo.Close()
 	}
 
+	for _, repo := range []repository.BaseGeoRepository{uc.enterpriseRepo, uc.ispRepo, uc.domainRepo, uc.connectionTypeRepo} {
+		if repo != nil {
+			repo.Close()
+		}
+	}
+
 	// 여러 오류가 발생할 경우 첫 번째 발생한 오류를 반환합니다
 	if cityErr != nil {
 		return cityErr
@@ -207,18 +359,29 @@ func (uc *GeoUseCase) Close() error {
 
 // GeoData는 IP 주소에 대한 종합적인 지리 정보를 담는 구조체입니다
 type GeoData struct {
-	IPAddress      string  `json:"ip_address"`
-	City           string  `json:"city,omitempty"`
-	CountryCode    string  `json:"country_code,omitempty"`
-	CountryName    string  `json:"country_name,omitempty"`
-	ContinentCode  string  `json:"continent_code,omitempty"`
-	Latitude       float64 `json:"latitude,omitempty"`
-	Longitude      float64 `json:"longitude,omitempty"`
-	TimeZone       string  `json:"time_zone,omitempty"`
-	ASN            uint    `json:"asn,omitempty"`
-	ISP            string  `json:"isp,omitempty"`
-	IsValid        bool    `json:"is_valid"`
-	IsAnonymous    bool    `json:"is_anonymous,omitempty"`
-	IsAnonymousVPN bool    `json:"is_anonymous_vpn,omitempty"`
-	IsTorExitNode  bool    `json:"is_tor_exit_node,omitempty"`
+	IPAddress          string  `json:"ip_address"`
+	City               string  `json:"city,omitempty"`
+	CountryCode        string  `json:"country_code,omitempty"`
+	CountryName        string  `json:"country_name,omitempty"`
+	ContinentCode      string  `json:"continent_code,omitempty"`
+	Latitude           float64 `json:"latitude,omitempty"`
+	Longitude          float64 `json:"longitude,omitempty"`
+	TimeZone           string  `json:"time_zone,omitempty"`
+	ASN                uint    `json:"asn,omitempty"`
+	ISP                string  `json:"isp,omitempty"`
+	IsValid            bool    `json:"is_valid"`
+	IsAnonymous        bool    `json:"is_anonymous,omitempty"`
+	IsAnonymousVPN     bool    `json:"is_anonymous_vpn,omitempty"`
+	IsTorExitNode      bool    `json:"is_tor_exit_node,omitempty"`
+	IsHostingProvider  bool    `json:"is_hosting_provider,omitempty"`
+	IsPublicProxy      bool    `json:"is_public_proxy,omitempty"`
+	IsResidentialProxy bool    `json:"is_residential_proxy,omitempty"`
+	Organization       string  `json:"organization,omitempty"`
+	Domain             string  `json:"domain,omitempty"`
+	ConnectionType     string  `json:"connection_type,omitempty"`
+	UserType           string  `json:"user_type,omitempty"`
+	PostalCode         string  `json:"postal_code,omitempty"`
+	Subdivision        string  `json:"subdivision,omitempty"`
+	// AnonymousIPChecked는 익명 IP 데이터베이스로 확인했는지 여부입니다. false이면 익명 여부 플래그는 알 수 없습니다.
+	AnonymousIPChecked bool `json:"anonymous_ip_checked,omitempty"`
 }
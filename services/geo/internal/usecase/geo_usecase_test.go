@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/repository"
+)
+
+func TestGeoIP2LookupsNotSupported(t *testing.T) {
+	lookups := []struct {
+		method string
+		lookup func(uc *GeoUseCase, ip string) error
+	}{
+		{"GetEnterprise", func(uc *GeoUseCase, ip string) error { _, err := uc.GetEnterpriseInfo(ip); return err }},
+		{"GetISP", func(uc *GeoUseCase, ip string) error { _, err := uc.GetISPInfo(ip); return err }},
+		{"GetDomain", func(uc *GeoUseCase, ip string) error { _, err := uc.GetDomainInfo(ip); return err }},
+		{"GetConnectionType", func(uc *GeoUseCase, ip string) error { _, err := uc.GetConnectionTypeInfo(ip); return err }},
+	}
+	lookupErr := errors.New("데이터베이스 읽기 실패")
+
+	for _, lookup := range lookups {
+		t.Run(lookup.method, func(t *testing.T) {
+			tests := []struct {
+				name    string
+				uc      *GeoUseCase
+				ip      string
+				wantErr error
+			}{
+				{
+					name:    "GeoLite2만 사용",
+					uc:      NewGeoUseCaseWithGeoLite2(&stubGeoRepository{}),
+					ip:      "1.2.3.4",
+					wantErr: ErrFeatureNotSupported,
+				},
+				{
+					name:    "설치되지 않은 데이터베이스",
+					uc:      NewGeoUseCaseWithGeoIP2(&stubGeoRepository{errs: map[string]error{lookup.method: repository.ErrDatabaseNotAvailable}}),
+					ip:      "1.2.3.4",
+					wantErr: ErrFeatureNotSupported,
+				},
+				{
+					name:    "조회 실패는 그대로 반환",
+					uc:      NewGeoUseCaseWithGeoIP2(&stubGeoRepository{errs: map[string]error{lookup.method: lookupErr}}),
+					ip:      "1.2.3.4",
+					wantErr: lookupErr,
+				},
+				{
+					name:    "유효하지 않은 IP",
+					uc:      NewGeoUseCaseWithGeoIP2(&stubGeoRepository{}),
+					ip:      "not-an-ip",
+					wantErr: ErrInvalidIPAddress,
+				},
+				{
+					name: "설치된 데이터베이스",
+					uc:   NewGeoUseCaseWithGeoIP2(&stubGeoRepository{}),
+					ip:   "1.2.3.4",
+				},
+			}
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					// 핸들러는 오류를 == 로 비교하여 501, 400, 500을 고르므로 감싸지 않은 오류여야 합니다
+					if err := lookup.lookup(tt.uc, tt.ip); err != tt.wantErr {
+						t.Errorf("error = %v, want %v", err, tt.wantErr)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestGetGeoDataEnrichmentPrecedence(t *testing.T) {
+	enterprise := entity.Enterprise{
+		Postal:       entity.PostalInfo{Code: "06236"},
+		Subdivisions: []entity.SubdivisionInfo{{IsoCode: "11"}},
+		Traits: entity.EnterpriseTraits{
+			ISP:            "Enterprise ISP",
+			Organization:   "Enterprise Org",
+			Domain:         "enterprise.example",
+			ConnectionType: "Corporate",
+			UserType:       "business",
+		},
+	}
+	unavailable := repository.ErrDatabaseNotAvailable
+
+	tests := []struct {
+		name string
+		repo *stubGeoRepository
+		want GeoData
+	}{
+		{
+			name: "GeoIP2 전용 데이터베이스가 Enterprise보다 우선",
+			repo: &stubGeoRepository{
+				enterprise:     enterprise,
+				isp:            entity.ISP{ISP: "ISP ISP", Organization: "ISP Org"},
+				domain:         entity.Domain{Domain: "domain.example"},
+				connectionType: entity.ConnectionType{ConnectionType: "Cellular"},
+			},
+			want: GeoData{
+				ISP: "ISP ISP", Organization: "ISP Org", Domain: "domain.example", ConnectionType: "Cellular",
+				PostalCode: "06236", Subdivision: "11", UserType: "business",
+			},
+		},
+		{
+			name: "빈 값은 Enterprise 값을 덮어쓰지 않음",
+			repo: &stubGeoRepository{enterprise: enterprise},
+			want: GeoData{
+				ISP: "Enterprise ISP", Organization: "Enterprise Org", Domain: "enterprise.example", ConnectionType: "Corporate",
+				PostalCode: "06236", Subdivision: "11", UserType: "business",
+			},
+		},
+		{
+			name: "설치되지 않은 데이터베이스는 건너뜀",
+			repo: &stubGeoRepository{
+				enterprise: enterprise,
+				errs:       map[string]error{"GetISP": unavailable, "GetDomain": unavailable, "GetConnectionType": unavailable},
+			},
+			want: GeoData{
+				ISP: "Enterprise ISP", Organization: "Enterprise Org", Domain: "enterprise.example", ConnectionType: "Corporate",
+				PostalCode: "06236", Subdivision: "11", UserType: "business",
+			},
+		},
+		{
+			name: "Enterprise 없이 ISP 데이터베이스만",
+			repo: &stubGeoRepository{
+				isp:  entity.ISP{ISP: "ISP ISP"},
+				errs: map[string]error{"GetEnterprise": unavailable},
+			},
+			want: GeoData{ISP: "ISP ISP"},
+		},
+		{
+			name: "GeoIP2 데이터베이스가 없으면 ASN 조직을 ISP로 사용",
+			repo: &stubGeoRepository{
+				errs: map[string]error{"GetEnterprise": unavailable, "GetISP": unavailable, "GetDomain": unavailable, "GetConnectionType": unavailable},
+			},
+			want: GeoData{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.repo.asn = entity.ASN{AutonomousSystemNumber: 4766, AutonomousSystemOrganization: "Korea Telecom"}
+			if tt.want.ISP == "" {
+				tt.want.ISP = "Korea Telecom"
+			}
+
+			geoData, err := NewGeoUseCaseWithGeoIP2(tt.repo).GetGeoData("1.2.3.4")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := GeoData{
+				ISP: geoData.ISP, Organization: geoData.Organization, Domain: geoData.Domain, ConnectionType: geoData.ConnectionType,
+				PostalCode: geoData.PostalCode, Subdivision: geoData.Subdivision, UserType: geoData.UserType,
+			}
+			if got != tt.want {
+				t.Errorf("GetGeoData() = %+v, want %+v", got, tt.want)
+			}
+			if geoData.ASN != 4766 {
+				t.Errorf("ASN = %d, want 4766", geoData.ASN)
+			}
+		})
+	}
+}
+
+func TestGetGeoDataCountry(t *testing.T) {
+	city := entity.City{
+		Country:   entity.CountryInfo{IsoCode: "KR", Names: map[string]string{"en": "South Korea"}},
+		Continent: entity.ContinentInfo{Code: "AS"},
+	}
+	lookupErr := errors.New("조회 실패")
+
+	t.Run("Country 데이터베이스 우선", func(t *testing.T) {
+		repo := &stubGeoRepository{city: city, country: entity.Country{Country: entity.CountryInfo{IsoCode: "JP"}}}
+
+		geoData, err := NewGeoUseCaseWithGeoIP2(repo).GetGeoData("1.2.3.4")
+		if err != nil || geoData.CountryCode != "JP" {
+			t.Errorf("CountryCode = %v, %v, want JP", geoData, err)
+		}
+	})
+
+	t.Run("Country 조회가 실패하면 City의 국가", func(t *testing.T) {
+		repo := &stubGeoRepository{city: city, errs: map[string]error{"GetCountry": lookupErr}}
+
+		geoData, err := NewGeoUseCaseWithGeoIP2(repo).GetGeoData("1.2.3.4")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if geoData.CountryCode != "KR" || geoData.CountryName != "South Korea" || geoData.ContinentCode != "AS" {
+			t.Errorf("GetGeoData() = %+v, want the country of the city", geoData)
+		}
+	})
+
+	t.Run("기본 조회가 모두 실패", func(t *testing.T) {
+		repo := &stubGeoRepository{errs: map[string]error{"GetCity": lookupErr, "GetCountry": lookupErr, "GetASN": lookupErr}}
+
+		if _, err := NewGeoUseCaseWithGeoIP2(repo).GetGeoData("1.2.3.4"); err != ErrGeoLookupFailed {
+			t.Errorf("error = %v, want ErrGeoLookupFailed", err)
+		}
+	})
+}
@@ -0,0 +1,422 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// GeoFenceDecision은 IP 주소에 규칙 집합을 평가한 결과입니다
+type GeoFenceDecision struct {
+	RuleSet   string `json:"rule_set"`
+	IPAddress string `json:"ip_address"`
+	Allowed   bool   `json:"allowed"`
+	Action    string `json:"action"`
+	// MatchedRule은 일치한 규칙의 이름입니다. 비어 있으면 규칙 집합의 기본 동작을 따른 것입니다.
+	MatchedRule string `json:"matched_rule,omitempty"`
+	// Unknown은 규칙에 필요한 지리 정보를 조회하지 못했거나 값이 비어 있어 규칙을 평가할 수 없었음을 나타냅니다.
+	// 이때 규칙 집합의 unknown_action을 따릅니다.
+	Unknown       bool   `json:"unknown,omitempty"`
+	CountryCode   string `json:"country_code,omitempty"`
+	ContinentCode string `json:"continent_code,omitempty"`
+	ASN           uint   `json:"asn,omitempty"`
+}
+
+// GeoFenceStatus는 적재된 규칙 집합과 그 버전입니다
+type GeoFenceStatus struct {
+	Version  string                   `json:"version"`
+	LoadedAt time.Time                `json:"loaded_at"`
+	RuleSets []entity.GeoFenceRuleSet `json:"rule_sets"`
+}
+
+// geoFenceRule은 평가할 수 있도록 정리한 규칙입니다
+type geoFenceRule struct {
+	name       string
+	allow      bool
+	countries  map[string]bool
+	continents map[string]bool
+	asns       map[uint]bool
+	networks   []*net.IPNet
+	anonymous  []string
+}
+
+// geoFenceRuleSet은 평가할 수 있도록 정리한 규칙 집합입니다
+type geoFenceRuleSet struct {
+	name         string
+	defaultAllow bool
+	unknownAllow bool
+	rules        []geoFenceRule
+}
+
+// geoFenceState는 한 번에 적재된 규칙 집합 묶음입니다. 교체할 때 통째로 바꿉니다.
+type geoFenceState struct {
+	version  string
+	loadedAt time.Time
+	source   []entity.GeoFenceRuleSet
+	ruleSets map[string]*geoFenceRuleSet
+}
+
+// GeoFenceUseCase는 이름 붙인 지역 제한 규칙 집합으로 IP 주소의 허용 여부를 판단합니다.
+// 규칙은 저장소에서 주기적으로 다시 읽어, 바뀌었고 모두 올바를 때만 재시작 없이 교체합니다.
+type GeoFenceUseCase struct {
+	repo    repository.GeoFenceRepository
+	geoData GeoDataProvider
+	logger  *zap.Logger
+
+	mu    sync.RWMutex
+	state *geoFenceState
+
+	// reloadMu는 감시 루프와 SIGHUP 등에서 동시에 들어온 재적재를 직렬화합니다
+	reloadMu sync.Mutex
+}
+
+// NewGeoFenceUseCase는 규칙 집합을 처음 적재한 GeoFenceUseCase 인스턴스를 생성합니다.
+// 규칙 집합을 읽지 못하거나 올바르지 않은 규칙이 있으면 오류를 반환합니다.
+func NewGeoFenceUseCase(ctx context.Context, repo repository.GeoFenceRepository, geoData GeoDataProvider, logger *zap.Logger) (*GeoFenceUseCase, error) {
+	uc := &GeoFenceUseCase{
+		repo:    repo,
+		geoData: geoData,
+		logger:  logger,
+	}
+	if err := uc.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return uc, nil
+}
+
+// Reload는 저장소에서 규칙 집합을 다시 읽어 바뀌었으면 교체합니다.
+// 읽지 못하거나 올바르지 않은 규칙이 있으면 기존 규칙을 그대로 사용하고 오류를 반환합니다.
+func (uc *GeoFenceUseCase) Reload(ctx context.Context) error {
+	uc.reloadMu.Lock()
+	defer uc.reloadMu.Unlock()
+
+	source, err := uc.repo.ListRuleSets(ctx)
+	if err != nil {
+		return err
+	}
+
+	version, err := geoFenceVersion(source)
+	if err != nil {
+		return err
+	}
+	if current := uc.current(); current != nil && current.version == version {
+		return nil
+	}
+
+	ruleSets := make(map[string]*geoFenceRuleSet, len(source))
+	for _, ruleSet := range source {
+		compiled, err := compileGeoFenceRuleSet(ruleSet)
+		if err != nil {
+			return err
+		}
+		if _, ok := ruleSets[compiled.name]; ok {
+			return fmt.Errorf("%w: 규칙 집합 %s 이름이 중복됩니다", ErrInvalidGeoFenceRule, compiled.name)
+		}
+		ruleSets[compiled.name] = compiled
+	}
+
+	uc.mu.Lock()
+	uc.state = &geoFenceState{
+		version:  version,
+		loadedAt: time.Now(),
+		source:   source,
+		ruleSets: ruleSets,
+	}
+	uc.mu.Unlock()
+
+	uc.logger.Info("지역 제한 규칙 적재 완료",
+		zap.String("version", version),
+		zap.Int("rule_sets", len(ruleSets)))
+	return nil
+}
+
+// Watch는 interval마다 규칙 집합을 다시 읽어 바뀌었으면 교체합니다. ctx가 취소되면 반환합니다.
+func (uc *GeoFenceUseCase) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := uc.Reload(ctx); err != nil && ctx.Err() == nil {
+			uc.logger.Error("지역 제한 규칙 재적재 실패, 기존 규칙을 계속 사용합니다", zap.Error(err))
+		}
+	}
+}
+
+// Status는 적재된 규칙 집합과 그 버전을 반환합니다
+func (uc *GeoFenceUseCase) Status() GeoFenceStatus {
+	state := uc.current()
+	return GeoFenceStatus{
+		Version:  state.version,
+		LoadedAt: state.loadedAt,
+		RuleSets: state.source,
+	}
+}
+
+// Evaluate는 IP 주소에 규칙 집합을 평가합니다. 지리 정보가 없는 IP(사설 IP 등)에도
+// CIDR 규칙과 조건이 없는 규칙은 적용됩니다. 규칙이 쓰는 값(국가, 대륙, ASN)이 비어 있거나 익명 IP
+// 데이터베이스로 확인하지 못해 일치 여부를 알 수 없는 규칙에 이르면 Unknown으로 표시한 unknown_action을
+// 반환합니다. 지리 정보 조회 자체가 실패하면 모든 값이 비어 있는 것으로 평가합니다.
+func (uc *GeoFenceUseCase) Evaluate(ruleSetName, ipStr string) (*GeoFenceDecision, error) {
+	ruleSet, ok := uc.current().ruleSets[ruleSetName]
+	if !ok {
+		return nil, ErrGeoFenceRuleSetNotFound
+	}
+
+	ip := net.ParseIP(ipStr)
+	if ip == nil {
+		return nil, ErrInvalidIPAddress
+	}
+
+	geoData, err := uc.geoData.GetGeoData(ipStr)
+	lookupFailed := errors.Is(err, ErrGeoLookupFailed)
+	if lookupFailed {
+		geoData = &GeoData{IPAddress: ipStr}
+	} else if err != nil {
+		return nil, err
+	}
+
+	decision := &GeoFenceDecision{
+		RuleSet:       ruleSet.name,
+		IPAddress:     ipStr,
+		Allowed:       ruleSet.defaultAllow,
+		CountryCode:   geoData.CountryCode,
+		ContinentCode: geoData.ContinentCode,
+		ASN:           geoData.ASN,
+	}
+	for _, rule := range ruleSet.rules {
+		matched, unknown := rule.match(ip, geoData)
+		if unknown {
+			decision.Allowed = ruleSet.unknownAllow
+			decision.Unknown = true
+			uc.logger.Warn("지리 정보가 없어 지역 제한 규칙을 평가할 수 없습니다",
+				zap.String("rule_set", ruleSet.name),
+				zap.String("rule", rule.name),
+				zap.String("ip", ipStr),
+				zap.Bool("lookup_failed", lookupFailed),
+				zap.Bool("allowed", decision.Allowed))
+			break
+		}
+		if matched {
+			decision.Allowed = rule.allow
+			decision.MatchedRule = rule.name
+			break
+		}
+	}
+
+	decision.Action = entity.GeoFenceDeny
+	if decision.Allowed {
+		decision.Action = entity.GeoFenceAllow
+	}
+	return decision, nil
+}
+
+// current는 적재된 규칙 집합 묶음을 반환합니다
+func (uc *GeoFenceUseCase) current() *geoFenceState {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	return uc.state
+}
+
+// match는 규칙의 조건이 모두 맞으면 matched를 반환합니다. 값을 아는 조건은 모두 맞지만 값이 비어 있거나
+// 데이터를 확인하지 못한 조건이 있으면 일치 여부를 알 수 없으므로 unknown을 반환합니다.
+func (r geoFenceRule) match(ip net.IP, geoData *GeoData) (matched, unknown bool) {
+	if r.networks != nil && !containsIP(r.networks, ip) {
+		return false, false
+	}
+	if r.countries != nil {
+		if geoData.CountryCode == "" {
+			unknown = true
+		} else if !r.countries[geoData.CountryCode] {
+			return false, false
+		}
+	}
+	if r.continents != nil {
+		if geoData.ContinentCode == "" {
+			unknown = true
+		} else if !r.continents[geoData.ContinentCode] {
+			return false, false
+		}
+	}
+	if r.asns != nil {
+		if geoData.ASN == 0 {
+			unknown = true
+		} else if !r.asns[geoData.ASN] {
+			return false, false
+		}
+	}
+	if r.anonymous != nil {
+		if !geoData.AnonymousIPChecked {
+			unknown = true
+		} else if !hasAnonymousFlag(r.anonymous, geoData) {
+			return false, false
+		}
+	}
+	return !unknown, unknown
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnonymousFlag(flags []string, geoData *GeoData) bool {
+	for _, flag := range flags {
+		switch {
+		case flag == entity.GeoFenceAnonymous && geoData.IsAnonymous,
+			flag == entity.GeoFenceAnonymousVPN && geoData.IsAnonymousVPN,
+			flag == entity.GeoFenceTorExitNode && geoData.IsTorExitNode,
+			flag == entity.GeoFenceHostingProvider && geoData.IsHostingProvider,
+			flag == entity.GeoFencePublicProxy && geoData.IsPublicProxy,
+			flag == entity.GeoFenceResidentialProxy && geoData.IsResidentialProxy:
+			return true
+		}
+	}
+	return false
+}
+
+// compileGeoFenceRuleSet은 규칙 집합을 검사하여 평가할 수 있는 형태로 바꿉니다
+func compileGeoFenceRuleSet(ruleSet entity.GeoFenceRuleSet) (*geoFenceRuleSet, error) {
+	name := strings.TrimSpace(ruleSet.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: 규칙 집합 이름이 필요합니다", ErrInvalidGeoFenceRule)
+	}
+
+	defaultAllow, err := geoFenceAllows(ruleSet.DefaultAction, true)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 규칙 집합 %s의 기본 동작 %q", ErrInvalidGeoFenceRule, name, ruleSet.DefaultAction)
+	}
+
+	// 지리 정보 없이 판단할 수 없는 IP는 따로 정하지 않으면 거부합니다
+	unknownAllow := false
+	if strings.TrimSpace(ruleSet.UnknownAction) != "" {
+		if unknownAllow, err = geoFenceAllows(ruleSet.UnknownAction, false); err != nil {
+			return nil, fmt.Errorf("%w: 규칙 집합 %s의 조회 실패 동작 %q", ErrInvalidGeoFenceRule, name, ruleSet.UnknownAction)
+		}
+	}
+
+	compiled := &geoFenceRuleSet{
+		name:         name,
+		defaultAllow: defaultAllow,
+		unknownAllow: unknownAllow,
+		rules:        make([]geoFenceRule, 0, len(ruleSet.Rules)),
+	}
+	for i, rule := range ruleSet.Rules {
+		ruleName := strings.TrimSpace(rule.Name)
+		if ruleName == "" {
+			ruleName = fmt.Sprintf("rule-%d", i+1)
+		}
+		invalid := func(format string, args ...interface{}) error {
+			return fmt.Errorf("%w: 규칙 집합 %s의 규칙 %s: %s", ErrInvalidGeoFenceRule, name, ruleName, fmt.Sprintf(format, args...))
+		}
+
+		allow, err := geoFenceAllows(rule.Action, false)
+		if err != nil {
+			return nil, invalid("알 수 없는 동작 %q", rule.Action)
+		}
+		compiledRule := geoFenceRule{name: ruleName, allow: allow}
+
+		if len(rule.Countries) > 0 {
+			compiledRule.countries = make(map[string]bool, len(rule.Countries))
+			for _, country := range rule.Countries {
+				compiledRule.countries[strings.ToUpper(strings.TrimSpace(country))] = true
+			}
+		}
+		if len(rule.Continents) > 0 {
+			compiledRule.continents = make(map[string]bool, len(rule.Continents))
+			for _, continent := range rule.Continents {
+				compiledRule.continents[strings.ToUpper(strings.TrimSpace(continent))] = true
+			}
+		}
+		if len(rule.ASNs) > 0 {
+			compiledRule.asns = make(map[uint]bool, len(rule.ASNs))
+			for _, asn := range rule.ASNs {
+				compiledRule.asns[asn] = true
+			}
+		}
+		for _, cidr := range rule.CIDRs {
+			network, err := parseNetwork(strings.TrimSpace(cidr))
+			if err != nil {
+				return nil, invalid("잘못된 네트워크 %q", cidr)
+			}
+			compiledRule.networks = append(compiledRule.networks, network)
+		}
+		for _, flag := range rule.Anonymous {
+			flag = strings.ToLower(strings.TrimSpace(flag))
+			switch flag {
+			case entity.GeoFenceAnonymous, entity.GeoFenceAnonymousVPN, entity.GeoFenceTorExitNode,
+				entity.GeoFenceHostingProvider, entity.GeoFencePublicProxy, entity.GeoFenceResidentialProxy:
+				compiledRule.anonymous = append(compiledRule.anonymous, flag)
+			default:
+				return nil, invalid("알 수 없는 익명 IP 유형 %q", flag)
+			}
+		}
+
+		compiled.rules = append(compiled.rules, compiledRule)
+	}
+
+	return compiled, nil
+}
+
+// geoFenceAllows는 동작이 allow인지 반환합니다. 빈 동작은 empty를 따릅니다.
+func geoFenceAllows(action string, empty bool) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "":
+		if empty {
+			return true, nil
+		}
+	case entity.GeoFenceAllow:
+		return true, nil
+	case entity.GeoFenceDeny:
+		return false, nil
+	}
+	return false, fmt.Errorf("알 수 없는 동작: %q", action)
+}
+
+// parseNetwork는 CIDR 또는 IP 주소 하나를 네트워크로 바꿉니다
+func parseNetwork(value string) (*net.IPNet, error) {
+	if strings.Contains(value, "/") {
+		_, network, err := net.ParseCIDR(value)
+		return network, err
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("잘못된 IP 주소: %q", value)
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return &net.IPNet{IP: ipv4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+// geoFenceVersion은 규칙 집합 내용으로 만든 짧은 해시입니다. 내용이 같으면 다시 적재하지 않습니다.
+func geoFenceVersion(ruleSets []entity.GeoFenceRuleSet) (string, error) {
+	data, err := json.Marshal(ruleSets)
+	if err != nil {
+		return "", err
+	}
+	h := fnv.New64a()
+	h.Write(data)
+	return fmt.Sprintf("%016x", h.Sum64()), nil
+}
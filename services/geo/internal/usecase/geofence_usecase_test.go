@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/geo/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+// staticGeoFenceRepository는 고정된 규칙 집합을 돌려주는 GeoFenceRepository입니다
+type staticGeoFenceRepository []entity.GeoFenceRuleSet
+
+func (r staticGeoFenceRepository) ListRuleSets(context.Context) ([]entity.GeoFenceRuleSet, error) {
+	return r, nil
+}
+
+// geoDataFunc는 함수로 구현한 GeoDataProvider입니다
+type geoDataFunc func(ipStr string) (*GeoData, error)
+
+func (f geoDataFunc) GetGeoData(ipStr string, _ ...string) (*GeoData, error) {
+	return f(ipStr)
+}
+
+func TestGeoFenceEvaluateWhenGeoLookupFails(t *testing.T) {
+	ruleSet := entity.GeoFenceRuleSet{
+		Name:          "payments",
+		DefaultAction: entity.GeoFenceAllow,
+		Rules: []entity.GeoFenceRule{
+			{Name: "office", Action: entity.GeoFenceAllow, CIDRs: []string{"10.0.0.0/8"}},
+			{Name: "blocked-network", Action: entity.GeoFenceDeny, CIDRs: []string{"203.0.113.0/24"}, Countries: []string{"KP"}},
+			{Name: "sanctioned", Action: entity.GeoFenceDeny, Countries: []string{"KP"}},
+		},
+	}
+	failing := geoDataFunc(func(string) (*GeoData, error) { return nil, ErrGeoLookupFailed })
+
+	tests := []struct {
+		name          string
+		unknownAction string
+		ip            string
+		wantAllowed   bool
+		wantUnknown   bool
+		wantRule      string
+	}{
+		{name: "기본은 거부", ip: "1.2.3.4", wantUnknown: true},
+		{name: "unknown_action allow", unknownAction: entity.GeoFenceAllow, ip: "1.2.3.4", wantAllowed: true, wantUnknown: true},
+		{name: "지리 정보가 필요 없는 규칙은 평가", ip: "10.1.2.3", wantAllowed: true, wantRule: "office"},
+		{name: "네트워크가 맞으면 국가를 알 수 없음", unknownAction: entity.GeoFenceAllow, ip: "203.0.113.7", wantAllowed: true, wantUnknown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleSet := ruleSet
+			ruleSet.UnknownAction = tt.unknownAction
+			uc, err := NewGeoFenceUseCase(context.Background(), staticGeoFenceRepository{ruleSet}, failing, zap.NewNop())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decision, err := uc.Evaluate("payments", tt.ip)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decision.Allowed != tt.wantAllowed || decision.Unknown != tt.wantUnknown || decision.MatchedRule != tt.wantRule {
+				t.Errorf("Evaluate(%s) = %+v, want allowed %v, unknown %v, rule %q", tt.ip, decision, tt.wantAllowed, tt.wantUnknown, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestGeoFenceEvaluateWithGeoData(t *testing.T) {
+	ruleSet := entity.GeoFenceRuleSet{
+		Name:          "kr-only",
+		DefaultAction: entity.GeoFenceDeny,
+		Rules:         []entity.GeoFenceRule{{Name: "korea", Action: entity.GeoFenceAllow, Countries: []string{"KR"}}},
+	}
+	geoData := geoDataFunc(func(ipStr string) (*GeoData, error) {
+		return &GeoData{IPAddress: ipStr, CountryCode: "KR"}, nil
+	})
+
+	uc, err := NewGeoFenceUseCase(context.Background(), staticGeoFenceRepository{ruleSet}, geoData, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decision, err := uc.Evaluate("kr-only", "1.2.3.4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decision.Allowed || decision.Unknown || decision.MatchedRule != "korea" {
+		t.Errorf("Evaluate() = %+v, want allowed by korea", decision)
+	}
+}
+
+func TestGeoFenceEvaluateWithMissingGeoData(t *testing.T) {
+	ruleSets := []entity.GeoFenceRuleSet{
+		{
+			Name:          "payments",
+			DefaultAction: entity.GeoFenceAllow,
+			Rules: []entity.GeoFenceRule{
+				{Name: "block-anonymous", Action: entity.GeoFenceDeny, Anonymous: []string{entity.GeoFenceAnonymousVPN, entity.GeoFenceTorExitNode}},
+				{Name: "sanctioned", Action: entity.GeoFenceDeny, Countries: []string{"KP"}},
+			},
+		},
+		{
+			Name:          "carrier",
+			DefaultAction: entity.GeoFenceAllow,
+			Rules: []entity.GeoFenceRule{
+				{Name: "kp-carrier", Action: entity.GeoFenceDeny, Countries: []string{"KP"}, ASNs: []uint{64500}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		ruleSet       string
+		unknownAction string
+		geoData       GeoData
+		wantAllowed   bool
+		wantUnknown   bool
+		wantRule      string
+	}{
+		{name: "익명 IP 데이터베이스가 없으면 알 수 없음", ruleSet: "payments", geoData: GeoData{CountryCode: "KR"}, wantUnknown: true},
+		{name: "unknown_action allow", ruleSet: "payments", unknownAction: entity.GeoFenceAllow, geoData: GeoData{CountryCode: "KR"}, wantAllowed: true, wantUnknown: true},
+		{name: "국가가 비어 있으면 알 수 없음", ruleSet: "payments", geoData: GeoData{ASN: 1234, AnonymousIPChecked: true}, wantUnknown: true},
+		{name: "모두 확인되면 기본 동작", ruleSet: "payments", geoData: GeoData{CountryCode: "KR", AnonymousIPChecked: true}, wantAllowed: true},
+		{name: "익명 플래그 일치", ruleSet: "payments", geoData: GeoData{CountryCode: "KR", AnonymousIPChecked: true, IsAnonymousVPN: true}, wantRule: "block-anonymous"},
+		{name: "아는 조건이 맞지 않으면 건너뜀", ruleSet: "carrier", geoData: GeoData{ASN: 1234}, wantAllowed: true},
+		{name: "아는 조건이 맞고 국가가 비어 있으면 알 수 없음", ruleSet: "carrier", geoData: GeoData{ASN: 64500}, wantUnknown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleSets := append([]entity.GeoFenceRuleSet(nil), ruleSets...)
+			for i := range ruleSets {
+				ruleSets[i].UnknownAction = tt.unknownAction
+			}
+			geoData := geoDataFunc(func(ipStr string) (*GeoData, error) {
+				data := tt.geoData
+				data.IPAddress = ipStr
+				return &data, nil
+			})
+			uc, err := NewGeoFenceUseCase(context.Background(), staticGeoFenceRepository(ruleSets), geoData, zap.NewNop())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decision, err := uc.Evaluate(tt.ruleSet, "1.2.3.4")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decision.Allowed != tt.wantAllowed || decision.Unknown != tt.wantUnknown || decision.MatchedRule != tt.wantRule {
+				t.Errorf("Evaluate() = %+v, want allowed %v, unknown %v, rule %q", decision, tt.wantAllowed, tt.wantUnknown, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestGeoFenceRejectsUnknownAction(t *testing.T) {
+	ruleSet := entity.GeoFenceRuleSet{Name: "payments", UnknownAction: "maybe"}
+
+	_, err := NewGeoFenceUseCase(context.Background(), staticGeoFenceRepository{ruleSet}, nil, zap.NewNop())
+	if !errors.Is(err, ErrInvalidGeoFenceRule) {
+		t.Errorf("error = %v, want ErrInvalidGeoFenceRule", err)
+	}
+}
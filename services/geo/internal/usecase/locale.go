@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage는 모든 MaxMind 데이터베이스가 이름을 제공하는 언어로, 언어 목록의 마지막 대체 언어입니다
+const DefaultLanguage = "en"
+
+// maxLanguages는 요청 하나에서 받아들이는 언어 수입니다
+const maxLanguages = 8
+
+// ParseLanguages는 lang 쿼리나 Accept-Language 헤더 형식(예: "ko-KR,ko;q=0.9,en;q=0.8")의 값을
+// 선호 순서대로 정규화된 언어 태그 목록으로 변환합니다. q=0인 언어와 "*"는 제외합니다.
+func ParseLanguages(values ...string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []weighted
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+			tag = normalizeLanguage(tag)
+			if tag == "" || tag == "*" || seen[tag] {
+				continue
+			}
+
+			q := 1.0
+			for _, param := range strings.Split(params, ";") {
+				name, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if ok && strings.TrimSpace(name) == "q" {
+					if parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+						q = parsed
+					}
+				}
+			}
+			if q <= 0 {
+				continue
+			}
+
+			seen[tag] = true
+			candidates = append(candidates, weighted{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	if len(candidates) > maxLanguages {
+		candidates = candidates[:maxLanguages]
+	}
+
+	languages := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		languages[i] = candidate.tag
+	}
+	return languages
+}
+
+// normalizeLanguage는 언어 태그를 MaxMind names 키 형식(예: ko, pt-BR, zh-CN)으로 바꿉니다
+func normalizeLanguage(tag string) string {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	base, region, found := strings.Cut(tag, "-")
+	if !found {
+		return strings.ToLower(base)
+	}
+	return strings.ToLower(base) + "-" + strings.ToUpper(region)
+}
+
+// LocalizedName은 languages 순서대로 names에서 이름을 찾습니다. 언어마다 정확히 같은 태그, 지역을 뺀 언어(ko-KR → ko),
+// 같은 언어의 다른 지역(zh-TW → zh-CN) 순으로 찾고, 모두 없으면 DefaultLanguage의 이름을 반환합니다.
+func LocalizedName(names map[string]string, languages []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	for _, language := range languages {
+		if name := names[language]; name != "" {
+			return name
+		}
+
+		base, _, found := strings.Cut(language, "-")
+		if !found {
+			base = language
+		} else if name := names[base]; name != "" {
+			return name
+		}
+
+		// 같은 언어의 지역 변형이 여러 개면 결과가 매번 같도록 정렬된 첫 번째 키를 사용합니다
+		var variant string
+		for key, name := range names {
+			if name != "" && strings.HasPrefix(key, base+"-") && (variant == "" || key < variant) {
+				variant = key
+			}
+		}
+		if variant != "" {
+			return names[variant]
+		}
+	}
+
+	return names[DefaultLanguage]
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLanguages(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   []string
+	}{
+		{
+			name:   "q 값 순서",
+			values: []string{"en;q=0.5, ko-KR, ja;q=0.8"},
+			want:   []string{"ko-KR", "ja", "en"},
+		},
+		{
+			name:   "같은 q 값은 요청 순서 유지",
+			values: []string{"fr;q=0.7, de;q=0.7, es;q=0.7"},
+			want:   []string{"fr", "de", "es"},
+		},
+		{
+			name:   "q=0과 *는 제외",
+			values: []string{"*, ko;q=0, en;q=0.1"},
+			want:   []string{"en"},
+		},
+		{
+			name:   "잘못된 q 값은 1로 취급",
+			values: []string{"en;q=0.9, ko;q=abc"},
+			want:   []string{"ko", "en"},
+		},
+		{
+			name:   "태그 정규화",
+			values: []string{" ZH_cn , pt-br;q=0.9, EN"},
+			want:   []string{"zh-CN", "en", "pt-BR"},
+		},
+		{
+			name:   "중복은 처음 것만 사용",
+			values: []string{"ko;q=0.5, en, KO"},
+			want:   []string{"en", "ko"},
+		},
+		{
+			name:   "여러 값의 중복",
+			values: []string{"ko", "ko-KR,ko;q=0.9,en;q=0.8"},
+			want:   []string{"ko", "ko-KR", "en"},
+		},
+		{
+			name:   "최대 8개",
+			values: []string{"a1,a2,a3,a4,a5,a6,a7,a8,a9;q=0.9,ko"},
+			want:   []string{"a1", "a2", "a3", "a4", "a5", "a6", "a7", "a8"},
+		},
+		{
+			name:   "q 값으로 정렬한 뒤 자름",
+			values: []string{"a1;q=0.1,a2,a3,a4,a5,a6,a7,a8,a9"},
+			want:   []string{"a2", "a3", "a4", "a5", "a6", "a7", "a8", "a9"},
+		},
+		{
+			name:   "빈 값",
+			values: []string{"", " , "},
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseLanguages(tt.values...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLanguages(%q) = %q, want %q", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalizedName(t *testing.T) {
+	names := map[string]string{
+		"en":    "Seoul",
+		"ko":    "서울",
+		"zh-CN": "首尔",
+		"pt-BR": "Seul",
+	}
+
+	tests := []struct {
+		name      string
+		names     map[string]string
+		languages []string
+		want      string
+	}{
+		{"정확히 같은 태그", names, []string{"zh-CN"}, "首尔"},
+		{"지역을 뺀 언어", names, []string{"ko-KR"}, "서울"},
+		{"같은 언어의 다른 지역", names, []string{"zh-TW"}, "首尔"},
+		{"지역 없는 요청의 지역 변형", names, []string{"pt"}, "Seul"},
+		{"목록 순서대로 찾음", names, []string{"ja", "fr", "ko"}, "서울"},
+		{"없으면 기본 언어", names, []string{"ja"}, "Seoul"},
+		{"언어가 없으면 기본 언어", names, nil, "Seoul"},
+		{
+			name:      "여러 지역 변형은 정렬된 첫 번째",
+			names:     map[string]string{"en": "Taipei", "zh-TW": "臺北", "zh-CN": "台北"},
+			languages: []string{"zh-HK"},
+			want:      "台北",
+		},
+		{
+			name:      "빈 이름은 건너뜀",
+			names:     map[string]string{"en": "Tokyo", "ja": "", "ja-JP": "東京"},
+			languages: []string{"ja"},
+			want:      "東京",
+		},
+		{"이름이 없음", nil, []string{"ko"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LocalizedName(tt.names, tt.languages); got != tt.want {
+				t.Errorf("LocalizedName(%v) = %q, want %q", tt.languages, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/database"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// export-transactions writes payments, refunds or credit transactions of a date range to a CSV or
+// JSONL file (or stdout), e.g. for the monthly finance spreadsheets:
+//
+//	export-transactions -dataset payments -from 2025-03-01 -to 2025-03-31 -out payments.csv
+func main() {
+	dataset := flag.String("dataset", string(usecase.ExportDatasetPayments), "payments, refunds or credit_transactions")
+	format := flag.String("format", string(usecase.ExportFormatCSV), "csv or jsonl")
+	from := flag.String("from", "", "first day exported (YYYY-MM-DD, KST)")
+	to := flag.String("to", "", "last day exported (YYYY-MM-DD, KST, inclusive)")
+	serviceProvider := flag.String("service-provider", "", "export only this service provider")
+	out := flag.String("out", "", "output file (default stdout)")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger; logs go to stderr so stdout carries only the export
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	req := usecase.ExportRequest{
+		Dataset:         usecase.ExportDataset(*dataset),
+		Format:          usecase.ExportFormat(*format),
+		ServiceProvider: *serviceProvider,
+	}
+	if req.From, err = time.ParseInLocation("2006-01-02", *from, usecase.ExportLocation); err != nil {
+		logger.Fatal("Invalid -from date", zap.String("from", *from), zap.Error(err))
+	}
+	if req.To, err = time.ParseInLocation("2006-01-02", *to, usecase.ExportLocation); err != nil {
+		logger.Fatal("Invalid -to date", zap.String("to", *to), zap.Error(err))
+	}
+	if err := req.Validate(); err != nil {
+		logger.Fatal("Invalid export", zap.Error(err))
+	}
+
+	// Initialize database connection
+	db, err := database.NewConnection(&cfg.Database, logger)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer func() {
+		if err := database.Close(db, logger); err != nil {
+			logger.Error("Failed to close database connection", zap.Error(err))
+		}
+	}()
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			logger.Fatal("Failed to create output file", zap.String("out", *out), zap.Error(err))
+		}
+		defer file.Close()
+		w = file
+	}
+	buffered := bufio.NewWriter(w)
+
+	// Initialize repositories
+	repos := database.NewRepositories(db, &cfg.Service.Supabase, logger)
+	exportService := usecase.NewExportService(repos.Export, logger)
+
+	rows, err := exportService.Export(context.Background(), buffered, req)
+	if err == nil {
+		err = buffered.Flush()
+	}
+	if err != nil {
+		logger.Fatal("Export failed", zap.Int64("rows_written", rows), zap.Error(err))
+	}
+
+	logger.Info("Export completed",
+		zap.String("dataset", *dataset),
+		zap.String("out", *out),
+		zap.Int64("rows", rows))
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/crypto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/database"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/notification"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+const (
+	// trialWillEndLead is how long before the end of a trial the user is notified
+	trialWillEndLead = 3 * 24 * time.Hour
+	// conversionBatchSize limits the number of trials charged per run
+	conversionBatchSize = 100
+)
+
+// process-trials sends trial_will_end notifications and charges ended Toss trials.
+// Stripe trials are converted by Stripe itself and are tracked through webhooks.
+func main() {
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	if cfg.Service.Toss.EncryptionKey == "" {
+		logger.Fatal("Toss billing is not configured; encryption_key is required")
+	}
+
+	// Initialize database connection
+	db, err := database.NewConnection(&cfg.Database, logger)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer func() {
+		if err := database.Close(db, logger); err != nil {
+			logger.Error("Failed to close database connection", zap.Error(err))
+		}
+	}()
+
+	// Initialize repositories
+	repos := database.NewRepositories(db, &cfg.Service.Supabase, logger)
+
+	// Initialize services
+	encryptService, err := crypto.NewAESEncryptionService(cfg.Service.Toss.EncryptionKey)
+	if err != nil {
+		logger.Fatal("Failed to initialize encryption service", zap.Error(err))
+	}
+	// Trials are charged through the Toss account of the service provider that issued their billing key
+	serviceProviders := usecase.NewServiceProviderRegistry(
+		repos.ServiceProvider,
+		encryptService,
+		usecase.DefaultServiceProvider(&cfg.Service),
+		usecase.DefaultServiceProviderCacheTTL,
+		logger,
+	)
+	creditService := usecase.NewCreditService(repos.Credit, repos.Subscription, repos.Plan, logger, usecase.DefaultServiceProvider(&cfg.Service).Code, nil)
+	couponService := usecase.NewCouponService(repos.Coupon, repos.Plan, creditService, logger)
+	referralService := usecase.NewReferralService(repos.Referral, repos.Payment, creditService, cfg.Credits.Referral, logger)
+	billingService := usecase.NewBillingService(
+		repos.BillingKey,
+		repos.Payment,
+		repos.Plan,
+		serviceProviders,
+		encryptService,
+		creditService,
+		couponService,
+		referralService,
+		logger,
+	)
+	trialService := usecase.NewTrialService(
+		repos.Trial,
+		repos.Plan,
+		repos.BillingKey,
+		billingService,
+		creditService,
+		notification.NewTrialNotifier(cfg.Email, logger),
+		nil,
+		logger,
+	)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	notified, err := trialService.NotifyEndingTrials(ctx, now, trialWillEndLead)
+	if err != nil {
+		logger.Error("Failed to notify ending trials", zap.Error(err))
+	}
+
+	converted, failed, err := trialService.ConvertDueTrials(ctx, now, conversionBatchSize)
+	if err != nil {
+		logger.Fatal("Failed to convert due trials", zap.Error(err))
+	}
+
+	logger.Info("Trial processing completed",
+		zap.Int("notified", notified),
+		zap.Int("converted", converted),
+		zap.Int("failed", failed))
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/crypto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/database"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// reconcile-payments compares the payments of the last -since with the TossPayments and Stripe
+// accounts of every active service provider to catch missed webhooks. Payments a PG completed are
+// marked paid and their credits allocated; every other mismatch is reported. The report is written
+// to stdout as JSON. Run it hourly; -toss-url and -stripe-url point it at local fakes of the PGs.
+func main() {
+	since := flag.Duration("since", 72*time.Hour, "reconcile payments created within this duration")
+	dryRun := flag.Bool("dry-run", false, "report mismatches without fixing them")
+	tossURL := flag.String("toss-url", "", "TossPayments API base URL (default the live API)")
+	stripeURL := flag.String("stripe-url", "", "Stripe API base URL (default the live API)")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	// Initialize database connection
+	db, err := database.NewConnection(&cfg.Database, logger)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer func() {
+		if err := database.Close(db, logger); err != nil {
+			logger.Error("Failed to close database connection", zap.Error(err))
+		}
+	}()
+
+	// Initialize repositories
+	repos := database.NewRepositories(db, &cfg.Service.Supabase, logger)
+
+	// Each service provider is reconciled with its own Toss and Stripe accounts
+	encryptService, err := crypto.NewAESEncryptionService(cfg.Service.Toss.EncryptionKey)
+	if err != nil {
+		logger.Fatal("Failed to initialize encryption service", zap.Error(err))
+	}
+	serviceProviders := usecase.NewServiceProviderRegistry(
+		repos.ServiceProvider,
+		encryptService,
+		usecase.DefaultServiceProvider(&cfg.Service),
+		usecase.DefaultServiceProviderCacheTTL,
+		logger,
+	)
+	clients := usecase.ServiceProviderReconciliationClients(*tossURL, *stripeURL, logger)
+
+	creditService := usecase.NewCreditService(repos.Credit, repos.Subscription, repos.Plan, logger, usecase.DefaultServiceProvider(&cfg.Service).Code, nil)
+	reconciliationService := usecase.NewReconciliationService(repos.Reconciliation, repos.Credit, creditService, serviceProviders, clients, logger)
+
+	to := time.Now()
+	report, err := reconciliationService.Reconcile(context.Background(), usecase.ReconciliationRequest{
+		From:   to.Add(-*since),
+		To:     to,
+		DryRun: *dryRun,
+	})
+	if err != nil {
+		logger.Fatal("Failed to reconcile payments", zap.Error(err))
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		logger.Fatal("Failed to write reconciliation report", zap.Error(err))
+	}
+}
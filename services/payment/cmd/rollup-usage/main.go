@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/database"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// rollup-usage recomputes credit usage rollups for every day, week and month touched since
+// -lookback ago (or -from, for backfills). It is safe to run repeatedly, e.g. every few minutes.
+func main() {
+	lookback := flag.Duration("lookback", 48*time.Hour, "recompute periods touched within this duration")
+	from := flag.String("from", "", "recompute periods from this date (YYYY-MM-DD, KST) instead of -lookback")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	since := time.Now().Add(-*lookback)
+	if *from != "" {
+		since, err = time.ParseInLocation("2006-01-02", *from, model.UsageLocation)
+		if err != nil {
+			logger.Fatal("Invalid -from date", zap.String("from", *from), zap.Error(err))
+		}
+	}
+
+	// Initialize database connection
+	db, err := database.NewConnection(&cfg.Database, logger)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer func() {
+		if err := database.Close(db, logger); err != nil {
+			logger.Error("Failed to close database connection", zap.Error(err))
+		}
+	}()
+
+	// Initialize repositories
+	repos := database.NewRepositories(db, &cfg.Service.Supabase, logger)
+	usageService := usecase.NewUsageService(repos.Usage, logger)
+
+	rows, err := usageService.RefreshRollups(context.Background(), since)
+	if err != nil {
+		logger.Fatal("Failed to refresh usage rollups", zap.Error(err))
+	}
+
+	logger.Info("Usage rollup completed",
+		zap.Time("since", since),
+		zap.Int64("rows", rows))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/database"
 	grpcServer "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/grpc"
 	httpServer "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/http"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
 	"go.uber.org/zap"
 )
 
@@ -51,9 +52,12 @@ cfg, err := config.LoadConfig()
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Entitlements are cached once for both servers so invalidations reach gRPC and HTTP alike
+	entitlementService := usecase.NewEntitlementService(repos.Subscription, repos.Payment, repos.Trial, repos.Plan, usecase.DefaultEntitlementCacheTTL, logger)
+
 	// Initialize servers
-	grpcSrv := grpcServer.NewServer(cfg, logger)
-	httpSrv := httpServer.NewServer(cfg, logger, repos)
+	grpcSrv := grpcServer.NewServer(cfg, logger, repos, entitlementService)
+	httpSrv := httpServer.NewServer(cfg, logger, repos, entitlementService)
 
 	// Start servers
 	go func() {
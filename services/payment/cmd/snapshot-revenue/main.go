@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/database"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// snapshot-revenue records the MRR of every active subscription at the end of yesterday (KST) for
+// the revenue analytics reports. Run it nightly after midnight KST. Days that already have a
+// snapshot are skipped unless -replace is set; -from backfills every day since a date, reconstructing
+// past days from subscription creation and cancellation times.
+func main() {
+	from := flag.String("from", "", "snapshot every day from this date (YYYY-MM-DD, KST) through yesterday")
+	replace := flag.Bool("replace", false, "overwrite existing snapshots")
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	yesterday := model.UsageGranularityDay.PeriodStart(time.Now()).AddDate(0, 0, -1)
+	start := yesterday
+	if *from != "" {
+		start, err = time.ParseInLocation("2006-01-02", *from, model.UsageLocation)
+		if err != nil {
+			logger.Fatal("Invalid -from date", zap.String("from", *from), zap.Error(err))
+		}
+	}
+
+	// Initialize database connection
+	db, err := database.NewConnection(&cfg.Database, logger)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer func() {
+		if err := database.Close(db, logger); err != nil {
+			logger.Error("Failed to close database connection", zap.Error(err))
+		}
+	}()
+
+	// Initialize repositories
+	repos := database.NewRepositories(db, &cfg.Service.Supabase, logger)
+	analyticsService := usecase.NewAnalyticsService(repos.Analytics, logger)
+
+	taken := 0
+	for day := start; !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+		_, ok, err := analyticsService.TakeSnapshot(context.Background(), day, *replace)
+		if err != nil {
+			logger.Fatal("Failed to take revenue snapshot",
+				zap.String("date", day.Format("2006-01-02")),
+				zap.Error(err))
+		}
+		if ok {
+			taken++
+		}
+	}
+
+	logger.Info("Revenue snapshots completed",
+		zap.String("from", start.Format("2006-01-02")),
+		zap.String("to", yesterday.Format("2006-01-02")),
+		zap.Int("snapshots", taken))
+}
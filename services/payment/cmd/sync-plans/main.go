@@ -86,6 +86,15 @@ func main() {
 					zap.Error(err))
 				continue
 			}
+			for i := range plan.Prices {
+				price := &plan.Prices[i]
+				price.PlanID = plan.ID
+				if err := repos.Plan.UpsertPrice(ctx, price); err != nil {
+					logger.Error("Failed to upsert Toss plan price",
+						zap.String("provider_price_id", price.ProviderPriceID),
+						zap.Error(err))
+				}
+			}
 			tossPlansSynced++
 		}
 	}
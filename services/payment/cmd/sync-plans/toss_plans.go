@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
 	"gopkg.in/yaml.v3"
 )
 
@@ -24,7 +25,20 @@ type tossPlanEntry struct {
 	CreditsPerCycle   int                    `yaml:"credits_per_cycle"`
 	Features          map[string]interface{} `yaml:"features"`
 	SortOrder         int                    `yaml:"sort_order"`
+	TrialPeriodDays   int                    `yaml:"trial_period_days"`
+	TrialCredits      int                    `yaml:"trial_credits"`
+	SeatBased         bool                   `yaml:"seat_based"`
+	ServiceProvider   string                 `yaml:"service_provider"`
 	IsActive          *bool                  `yaml:"is_active"`
+	Prices            []tossPlanPriceEntry   `yaml:"prices"` // Price points in other currencies
+}
+
+type tossPlanPriceEntry struct {
+	ProviderPriceID string `yaml:"provider_price_id"`
+	PgProvider      string `yaml:"pg_provider"`
+	Currency        string `yaml:"currency"`
+	Amount          int64  `yaml:"amount"`
+	IsActive        *bool  `yaml:"is_active"`
 }
 
 func loadTossPlansFromYAML(path string) ([]*model.PaymentPlan, error) {
@@ -86,6 +100,32 @@ func loadTossPlansFromYAML(path string) ([]*model.PaymentPlan, error) {
 			currency = "KRW"
 		}
 
+		prices := make([]model.PlanPrice, 0, len(entry.Prices))
+		for j, priceEntry := range entry.Prices {
+			priceCurrency := strings.ToUpper(strings.TrimSpace(priceEntry.Currency))
+			if priceEntry.ProviderPriceID == "" || priceCurrency == "" {
+				return nil, fmt.Errorf("plans[%d].prices[%d]: provider_price_id and currency are required", i, j)
+			}
+
+			pricePgProvider := priceEntry.PgProvider
+			if pricePgProvider == "" {
+				pricePgProvider = usecase.PGProviderForCurrency(priceCurrency)
+			}
+
+			priceActive := true
+			if priceEntry.IsActive != nil {
+				priceActive = *priceEntry.IsActive
+			}
+
+			prices = append(prices, model.PlanPrice{
+				ProviderPriceID: priceEntry.ProviderPriceID,
+				PgProvider:      pricePgProvider,
+				Currency:        priceCurrency,
+				Amount:          priceEntry.Amount,
+				IsActive:        priceActive,
+			})
+		}
+
 		plans = append(plans, &model.PaymentPlan{
 			ProviderPriceID:   entry.ProviderPriceID,
 			ProviderProductID: entry.ProviderProductID,
@@ -96,7 +136,12 @@ func loadTossPlansFromYAML(path string) ([]*model.PaymentPlan, error) {
 			CreditsPerCycle:   entry.CreditsPerCycle,
 			Features:          features,
 			SortOrder:         entry.SortOrder,
+			TrialPeriodDays:   entry.TrialPeriodDays,
+			TrialCredits:      entry.TrialCredits,
+			SeatBased:         entry.SeatBased,
+			ServiceProvider:   entry.ServiceProvider,
 			IsActive:          isActive,
+			Prices:            prices,
 		})
 	}
 
@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paymentv1 "github.com/wekeepgrowing/semo-backend-monorepo/proto/payment/v1"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+)
+
+// UseCreditsBatch deducts many credit usages from one balance in a single transaction.
+// A rejected atomic batch is not an RPC error; it is reported in the response with its results.
+func (h *PaymentHandler) UseCreditsBatch(ctx context.Context, req *paymentv1.UseCreditsBatchRequest) (*paymentv1.UseCreditsBatchResponse, error) {
+	universalID, err := uuid.Parse(req.UniversalId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "universal_id must be a valid UUID")
+	}
+
+	batch := &usecase.BatchUsageRequest{
+		UniversalID:     universalID,
+		ServiceProvider: req.ServiceProvider,
+		Atomic:          req.Atomic,
+		Items:           make([]usecase.BatchUsageItem, len(req.Usages)),
+	}
+	if req.ActorId != "" {
+		actorID, err := uuid.Parse(req.ActorId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "actor_id must be a valid UUID")
+		}
+		batch.ActorID = &actorID
+	}
+
+	for i, usage := range req.Usages {
+		item := usecase.BatchUsageItem{
+			FeatureName: usage.FeatureName,
+			Description: usage.Description,
+		}
+		if usage.UsageMetadata != nil {
+			item.UsageMetadata = usage.UsageMetadata.AsMap()
+		}
+		if usage.Amount != "" {
+			amount, err := decimal.NewFromString(usage.Amount)
+			if err != nil || !amount.IsPositive() {
+				return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("usages[%d]: amount must be a number greater than zero", i))
+			}
+			item.Amount = amount
+		}
+		if usage.IdempotencyKey != "" {
+			key, err := uuid.Parse(usage.IdempotencyKey)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("usages[%d]: idempotency_key must be a valid UUID", i))
+			}
+			item.IdempotencyKey = &key
+		}
+		batch.Items[i] = item
+	}
+
+	result, err := h.batchService.UseCreditsBatch(ctx, batch)
+	rejected := errors.Is(err, domainErrors.ErrCreditBatchRejected)
+	if err != nil && !rejected {
+		if errors.Is(err, domainErrors.ErrCreditBatchEmpty) || errors.Is(err, domainErrors.ErrCreditBatchTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		h.logger.Error("Failed to use credits in batch",
+			zap.String("universal_id", req.UniversalId),
+			zap.Int("usages", len(req.Usages)),
+			zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to process credit usage")
+	}
+
+	response := &paymentv1.UseCreditsBatchResponse{
+		Rejected:     rejected,
+		Applied:      int32(result.Applied),
+		Duplicates:   int32(result.Duplicates),
+		Failed:       int32(result.Failed),
+		BalanceAfter: result.BalanceAfter.String(),
+		Results:      make([]*paymentv1.CreditUsageResult, len(result.Results)),
+	}
+	for i, item := range result.Results {
+		response.Results[i] = &paymentv1.CreditUsageResult{
+			Index:         int32(item.Index),
+			Status:        item.Status,
+			TransactionId: item.TransactionID,
+			Amount:        item.Amount,
+			Code:          item.Code,
+			Error:         item.Error,
+		}
+		if item.PriceVersion != nil {
+			response.Results[i].PriceVersion = int32(*item.PriceVersion)
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paymentv1 "github.com/wekeepgrowing/semo-backend-monorepo/proto/payment/v1"
+)
+
+// CheckEntitlement reports whether a user or workspace may use a feature
+func (h *PaymentHandler) CheckEntitlement(ctx context.Context, req *paymentv1.CheckEntitlementRequest) (*paymentv1.CheckEntitlementResponse, error) {
+	if _, err := uuid.Parse(req.UniversalId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "universal_id must be a valid UUID")
+	}
+	if req.Feature == "" {
+		return nil, status.Error(codes.InvalidArgument, "feature is required")
+	}
+
+	entitlement, err := h.entitlementService.CheckEntitlement(ctx, req.UniversalId, req.Feature)
+	if err != nil {
+		h.logger.Error("Failed to check entitlement",
+			zap.String("universal_id", req.UniversalId),
+			zap.String("feature", req.Feature),
+			zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to check entitlement")
+	}
+
+	response := &paymentv1.CheckEntitlementResponse{
+		Feature:   entitlement.Feature,
+		Allowed:   entitlement.Allowed(),
+		Unlimited: entitlement.Unlimited(),
+		Sources:   entitlement.Sources,
+	}
+	if entitlement.Limit != nil {
+		response.Limit = *entitlement.Limit
+	}
+
+	return response, nil
+}
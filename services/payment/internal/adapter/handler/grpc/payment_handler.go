@@ -0,0 +1,25 @@
+package grpc
+
+import (
+	"go.uber.org/zap"
+
+	paymentv1 "github.com/wekeepgrowing/semo-backend-monorepo/proto/payment/v1"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+)
+
+// PaymentHandler serves the payment service to other services
+type PaymentHandler struct {
+	paymentv1.UnimplementedPaymentServiceServer
+	entitlementService *usecase.EntitlementService
+	batchService       *usecase.CreditBatchService
+	logger             *zap.Logger
+}
+
+// NewPaymentHandler creates a new PaymentHandler instance
+func NewPaymentHandler(entitlementService *usecase.EntitlementService, batchService *usecase.CreditBatchService, logger *zap.Logger) *PaymentHandler {
+	return &PaymentHandler{
+		entitlementService: entitlementService,
+		batchService:       batchService,
+		logger:             logger,
+	}
+}
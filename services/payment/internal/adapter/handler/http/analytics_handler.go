@@ -0,0 +1,156 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// AnalyticsHandler handles revenue analytics endpoints
+type AnalyticsHandler struct {
+	analyticsService *usecase.AnalyticsService
+	logger           *zap.Logger
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler instance
+func NewAnalyticsHandler(analyticsService *usecase.AnalyticsService, logger *zap.Logger) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+		logger:           logger,
+	}
+}
+
+// GetRevenue handles GET /admin/analytics/revenue endpoint.
+// Reports MRR, ARR, MRR movements and subscriptions per plan for a month.
+// Query params: month (YYYY-MM, KST, default the current month).
+func (h *AnalyticsHandler) GetRevenue(c echo.Context) error {
+	month := time.Now()
+	if value := c.QueryParam("month"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01", value, model.UsageLocation)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": "month must be in YYYY-MM format",
+				"code":  "INVALID_ANALYTICS_QUERY",
+			})
+		}
+		month = parsed
+	}
+
+	report, err := h.analyticsService.GetRevenueReport(c.Request().Context(), month)
+	if err != nil {
+		if status, body, ok := analyticsErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to get revenue report", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to get revenue report",
+			"code":  "ANALYTICS_FETCH_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GetCohorts handles GET /admin/analytics/cohorts endpoint.
+// Reports the monthly retention of paying customers by the month of their first payment.
+// Query params: from and to (YYYY-MM, KST, both inclusive; default the last 12 months).
+func (h *AnalyticsHandler) GetCohorts(c echo.Context) error {
+	to := time.Now()
+	from := to.AddDate(0, -11, 0)
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{
+		{"from", &from},
+		{"to", &to},
+	} {
+		value := c.QueryParam(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseInLocation("2006-01", value, model.UsageLocation)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": param.name + " must be in YYYY-MM format",
+				"code":  "INVALID_ANALYTICS_QUERY",
+			})
+		}
+		*param.target = parsed
+	}
+
+	report, err := h.analyticsService.GetCohortReport(c.Request().Context(), from, to)
+	if err != nil {
+		if status, body, ok := analyticsErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to get cohort report", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to get cohort report",
+			"code":  "ANALYTICS_FETCH_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// TakeSnapshot handles POST /admin/analytics/snapshots endpoint.
+// Takes the revenue snapshot of a past day, normally taken by cmd/snapshot-revenue.
+// Query params: date (YYYY-MM-DD, KST) and replace=true to overwrite an existing snapshot.
+func (h *AnalyticsHandler) TakeSnapshot(c echo.Context) error {
+	date, err := time.ParseInLocation("2006-01-02", c.QueryParam("date"), model.UsageLocation)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "date must be in YYYY-MM-DD format",
+			"code":  "INVALID_ANALYTICS_QUERY",
+		})
+	}
+	replace, _ := strconv.ParseBool(c.QueryParam("replace"))
+
+	rows, taken, err := h.analyticsService.TakeSnapshot(c.Request().Context(), date, replace)
+	if err != nil {
+		if status, body, ok := analyticsErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to take revenue snapshot", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to take revenue snapshot",
+			"code":  "SNAPSHOT_FAILED",
+		})
+	}
+
+	if !taken {
+		return c.JSON(http.StatusConflict, echo.Map{
+			"error": "A snapshot of this date already exists; pass replace=true to overwrite it",
+			"code":  "SNAPSHOT_EXISTS",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"date":          date.Format("2006-01-02"),
+		"subscriptions": rows,
+	})
+}
+
+// analyticsErrorResponse maps analytics errors to HTTP responses
+func analyticsErrorResponse(err error) (int, echo.Map, bool) {
+	switch {
+	case errors.Is(err, domainErrors.ErrRevenueSnapshotNotFound):
+		return http.StatusNotFound, echo.Map{
+			"error": err.Error(),
+			"code":  "SNAPSHOT_NOT_FOUND",
+		}, true
+	case errors.Is(err, domainErrors.ErrInvalidAnalyticsRange):
+		return http.StatusBadRequest, echo.Map{
+			"error": err.Error(),
+			"code":  "INVALID_ANALYTICS_QUERY",
+		}, true
+	}
+	return 0, nil, false
+}
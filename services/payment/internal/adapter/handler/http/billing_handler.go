@@ -3,22 +3,27 @@ package http
 import (
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/tenant"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
 	"go.uber.org/zap"
 )
 
 type BillingHandler struct {
 	billingService *usecase.BillingService
+	riskService    *usecase.RiskService
 	logger         *zap.Logger
 }
 
-func NewBillingHandler(billingService *usecase.BillingService, logger *zap.Logger) *BillingHandler {
+func NewBillingHandler(billingService *usecase.BillingService, riskService *usecase.RiskService, logger *zap.Logger) *BillingHandler {
 	return &BillingHandler{
 		billingService: billingService,
+		riskService:    riskService,
 		logger:         logger,
 	}
 }
@@ -57,8 +62,10 @@ func (h *BillingHandler) IssueBillingKey(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "auth_key and customer_key are required"})
 	}
 
+	// The card is registered with the Toss account of the request's service provider
 	billingKey, err := h.billingService.IssueBillingKey(
 		c.Request().Context(),
+		tenant.GetServiceProvider(c),
 		universalID,
 		req.AuthKey,
 		req.CustomerKey,
@@ -66,12 +73,33 @@ func (h *BillingHandler) IssueBillingKey(c echo.Context) error {
 		c.Request().UserAgent(),
 	)
 	if err != nil {
+		if status, body, ok := serviceProviderErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
 		h.logger.Error("failed to issue billing key",
 			zap.String("universal_id", universalIDStr),
 			zap.Error(err))
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
 	}
 
+	// The card is assessed once Toss has issued the key, as only then its issuer is known. The
+	// cardholder was authenticated in the Toss billing auth window, so only a blocked card is
+	// deactivated again.
+	assessment := assessRisk(c, h.riskService, usecase.RiskRequest{
+		Action:       model.RiskActionBillingKey,
+		UniversalID:  universalID,
+		BillingKeyID: billingKey.ID,
+	})
+	if status, body, ok := riskErrorResponse(assessment, true); ok {
+		if err := h.billingService.DeactivateCard(c.Request().Context(), billingKey.ID, universalID, c.RealIP(), c.Request().UserAgent()); err != nil {
+			h.logger.Error("failed to deactivate card declined by risk checks",
+				zap.String("universal_id", universalIDStr),
+				zap.Int64("billing_key_id", billingKey.ID),
+				zap.Error(err))
+		}
+		return c.JSON(status, body)
+	}
+
 	return c.JSON(http.StatusOK, billingKeyResponse{
 		ID:           billingKey.ID,
 		CardLastFour: billingKey.CardLastFour,
@@ -155,6 +183,7 @@ type chargeBillingKeyRequest struct {
 	OrderName       string `json:"order_name" validate:"required"`
 	PlanID          string `json:"plan_id" validate:"required"`
 	ServiceProvider string `json:"service_provider"`
+	CouponCode      string `json:"coupon_code,omitempty"`
 }
 
 type chargeBillingKeyResponse struct {
@@ -163,6 +192,7 @@ type chargeBillingKeyResponse struct {
 	TransactionKey   string `json:"transaction_key"`
 	Status           string `json:"status"`
 	Amount           int64  `json:"amount"`
+	DiscountAmount   int64  `json:"discount_amount,omitempty"`
 	ApprovedAt       string `json:"approved_at,omitempty"`
 	CreditsAllocated int    `json:"credits_allocated"`
 }
@@ -191,18 +221,42 @@ func (h *BillingHandler) ChargeBillingKey(c echo.Context) error {
 		})
 	}
 
+	serviceProvider, status, body := requestServiceProvider(c, req.ServiceProvider)
+	if serviceProvider == nil {
+		return c.JSON(status, body)
+	}
+
+	assessment := assessRisk(c, h.riskService, usecase.RiskRequest{
+		Action:       model.RiskActionBillingCharge,
+		UniversalID:  universalID,
+		BillingKeyID: req.BillingKeyID,
+		Amount:       req.Amount,
+		Currency:     "KRW",
+	})
+	if status, body, ok := riskErrorResponse(assessment, false); ok {
+		return c.JSON(status, body)
+	}
+
 	result, err := h.billingService.ChargeBillingKey(
 		c.Request().Context(),
 		universalID,
 		req.BillingKeyID,
+		"",
 		req.Amount,
 		req.OrderName,
 		req.PlanID,
-		req.ServiceProvider,
+		serviceProvider.Code,
+		strings.TrimSpace(req.CouponCode),
 		c.RealIP(),
 		c.Request().UserAgent(),
 	)
 	if err != nil {
+		if status, body, ok := couponErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		if status, body, ok := serviceProviderErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
 		h.logger.Error("failed to charge billing key",
 			zap.Int64("billing_key_id", req.BillingKeyID),
 			zap.Error(err))
@@ -215,6 +269,7 @@ func (h *BillingHandler) ChargeBillingKey(c echo.Context) error {
 		TransactionKey:   result.TransactionKey,
 		Status:           result.Status,
 		Amount:           result.Amount,
+		DiscountAmount:   result.DiscountAmount,
 		CreditsAllocated: result.CreditsAllocated,
 	}
 	if result.ApprovedAt != nil {
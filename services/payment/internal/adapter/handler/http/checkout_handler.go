@@ -5,10 +5,9 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/stripe/stripe-go/v79"
-	portalsession "github.com/stripe/stripe-go/v79/billingportal/session"
-	checkoutsession "github.com/stripe/stripe-go/v79/checkout/session"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/auth"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/tenant"
 	"go.uber.org/zap"
 )
 
@@ -39,6 +38,9 @@ type CreateCheckoutRequest struct {
 	PriceID string `json:"priceId"`
 	Email   string `json:"email"`
 	Mode    string `json:"mode"` // "embedded" or "" (기본값)
+
+	CouponCode string `json:"couponCode,omitempty"`
+	Seats      int    `json:"seats,omitempty"` // seat-based plans only; defaults to the workspace member count
 }
 
 type CreateCheckoutResponse struct {
@@ -60,8 +62,13 @@ func (h *CheckoutHandler) HandleReturn(c echo.Context) error {
 		})
 	}
 
+	stripeClient, status, body := requestStripeClient(c)
+	if stripeClient == nil {
+		return c.JSON(status, body)
+	}
+
 	// Checkout session 조회
-	s, err := checkoutsession.Get(sessionID, nil)
+	s, err := stripeClient.CheckoutSessions.Get(sessionID, nil)
 	if err != nil {
 		h.logger.Error("Error retrieving checkout session",
 			zap.String("session_id", sessionID),
@@ -97,12 +104,18 @@ func (h *CheckoutHandler) CreatePortalSession(c echo.Context) error {
 		zap.String("customer_id", req.CustomerID),
 	)
 
+	// The customer belongs to the Stripe account of the request's service provider
+	stripeClient, status, body := requestStripeClient(c)
+	if stripeClient == nil {
+		return c.JSON(status, body)
+	}
+
 	params := &stripe.BillingPortalSessionParams{
 		Customer:  stripe.String(req.CustomerID),
 		ReturnURL: stripe.String(h.resolveClientURL(c)),
 	}
 
-	ps, err := portalsession.New(params)
+	ps, err := stripeClient.BillingPortalSessions.New(params)
 	if err != nil {
 		h.logger.Error("Error creating portal session", zap.Error(err))
 		return c.JSON(http.StatusInternalServerError, echo.Map{
@@ -123,6 +136,18 @@ func (h *CheckoutHandler) CreatePortalSession(c echo.Context) error {
 
 func (h *CheckoutHandler) resolveClientURL(c echo.Context) string {
 	origin := c.Request().Header.Get("Origin")
+
+	// Redirects go back to a client of the service provider the request is for
+	if serviceProvider := tenant.GetServiceProvider(c); serviceProvider != nil {
+		if origin != "" && serviceProvider.AllowsOrigin(origin) {
+			return origin
+		}
+		if url := serviceProvider.PrimaryClientURL(); url != "" {
+			return url
+		}
+		return h.clientURL
+	}
+
 	if origin != "" {
 		if _, ok := h.allowedOrigins[origin]; ok {
 			return origin
@@ -146,7 +171,12 @@ func (h *CheckoutHandler) CheckSessionStatus(c echo.Context) error {
 		zap.String("universal_id", user.UniversalID),
 	)
 
-	s, err := checkoutsession.Get(sessionID, nil)
+	stripeClient, status, body := requestStripeClient(c)
+	if stripeClient == nil {
+		return c.JSON(status, body)
+	}
+
+	s, err := stripeClient.CheckoutSessions.Get(sessionID, nil)
 	if err != nil {
 		h.logger.Error("Failed to retrieve session",
 			zap.String("session_id", sessionID),
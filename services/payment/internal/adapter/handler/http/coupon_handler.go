@@ -0,0 +1,204 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/auth"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// CouponHandler handles coupon endpoints
+type CouponHandler struct {
+	couponService *usecase.CouponService
+	logger        *zap.Logger
+}
+
+// NewCouponHandler creates a new CouponHandler instance
+func NewCouponHandler(couponService *usecase.CouponService, logger *zap.Logger) *CouponHandler {
+	return &CouponHandler{
+		couponService: couponService,
+		logger:        logger,
+	}
+}
+
+// ValidateCouponRequest represents the HTTP request for previewing a coupon
+type ValidateCouponRequest struct {
+	Code     string `json:"code" validate:"required"`
+	PlanID   string `json:"plan_id,omitempty"`
+	Amount   int64  `json:"amount" validate:"min=0"`
+	Currency string `json:"currency,omitempty"`
+}
+
+// ValidateCoupon handles POST /coupons/validate endpoint
+func (h *CouponHandler) ValidateCoupon(c echo.Context) error {
+	var req ValidateCouponRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+		})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Validation failed",
+			"code":    "VALIDATION_FAILED",
+			"details": err.Error(),
+		})
+	}
+
+	universalIDStr, err := auth.GetUniversalID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Failed to get user information",
+			"code":  "AUTH_ERROR",
+		})
+	}
+
+	universalID, err := uuid.Parse(universalIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid user ID",
+			"code":  "INVALID_USER_ID",
+		})
+	}
+
+	quote, err := h.couponService.Quote(c.Request().Context(), req.Code, universalID, req.PlanID, req.Amount, strings.ToUpper(req.Currency))
+	if err != nil {
+		if status, body, ok := couponErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to validate coupon",
+			zap.String("code", req.Code),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to validate coupon",
+			"code":  "COUPON_VALIDATION_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusOK, quote)
+}
+
+// CreateCouponRequest represents the HTTP request for creating a coupon
+type CreateCouponRequest struct {
+	Code                  string          `json:"code" validate:"required,max=100"`
+	Name                  string          `json:"name" validate:"required,max=200"`
+	DiscountType          string          `json:"discount_type" validate:"required"`
+	PercentOff            decimal.Decimal `json:"percent_off"`
+	AmountOff             int64           `json:"amount_off"`
+	Currency              string          `json:"currency,omitempty"`
+	BonusCredits          int             `json:"bonus_credits"`
+	MaxRedemptions        *int            `json:"max_redemptions,omitempty"`
+	MaxRedemptionsPerUser *int            `json:"max_redemptions_per_user,omitempty"`
+	ValidFrom             *time.Time      `json:"valid_from,omitempty"`
+	ValidUntil            *time.Time      `json:"valid_until,omitempty"`
+	PlanIDs               []string        `json:"plan_ids,omitempty"` // Plan or price IDs; empty means all plans
+	ServiceProvider       string          `json:"service_provider,omitempty"`
+}
+
+// CreateCoupon handles POST /admin/coupons endpoint
+func (h *CouponHandler) CreateCoupon(c echo.Context) error {
+	var req CreateCouponRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+		})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Validation failed",
+			"code":    "VALIDATION_FAILED",
+			"details": err.Error(),
+		})
+	}
+
+	coupon := &model.Coupon{
+		Code:                  req.Code,
+		Name:                  req.Name,
+		DiscountType:          req.DiscountType,
+		PercentOff:            req.PercentOff,
+		AmountOff:             req.AmountOff,
+		Currency:              req.Currency,
+		BonusCredits:          req.BonusCredits,
+		MaxRedemptions:        req.MaxRedemptions,
+		MaxRedemptionsPerUser: req.MaxRedemptionsPerUser,
+		ValidFrom:             req.ValidFrom,
+		ValidUntil:            req.ValidUntil,
+		PlanIDs:               model.StringList(req.PlanIDs),
+		ServiceProvider:       req.ServiceProvider,
+		IsActive:              true,
+	}
+	if coupon.PlanIDs == nil {
+		coupon.PlanIDs = model.StringList{}
+	}
+
+	if err := h.couponService.Create(c.Request().Context(), coupon); err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrInvalidCoupon):
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": err.Error(),
+				"code":  "INVALID_COUPON",
+			})
+		case errors.Is(err, domainErrors.ErrCouponCodeTaken):
+			return c.JSON(http.StatusConflict, echo.Map{
+				"error": err.Error(),
+				"code":  "COUPON_CODE_TAKEN",
+			})
+		}
+		h.logger.Error("Failed to create coupon",
+			zap.String("code", req.Code),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to create coupon",
+			"code":  "COUPON_CREATE_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, coupon)
+}
+
+// couponErrorResponse maps coupon validation errors to client-facing responses
+func couponErrorResponse(err error) (int, echo.Map, bool) {
+	codes := []struct {
+		err  error
+		code string
+	}{
+		{domainErrors.ErrCouponNotFound, "COUPON_NOT_FOUND"},
+		{domainErrors.ErrCouponInactive, "COUPON_INACTIVE"},
+		{domainErrors.ErrCouponNotYetValid, "COUPON_NOT_YET_VALID"},
+		{domainErrors.ErrCouponExpired, "COUPON_EXPIRED"},
+		{domainErrors.ErrCouponUsageLimitReached, "COUPON_USAGE_LIMIT_REACHED"},
+		{domainErrors.ErrCouponUserLimitReached, "COUPON_USER_LIMIT_REACHED"},
+		{domainErrors.ErrCouponPlanNotApplicable, "COUPON_PLAN_NOT_APPLICABLE"},
+		{domainErrors.ErrCouponCurrencyMismatch, "COUPON_CURRENCY_MISMATCH"},
+		{domainErrors.ErrCouponAmountTooLow, "COUPON_AMOUNT_TOO_LOW"},
+		{domainErrors.ErrCouponProviderNotSupported, "COUPON_PROVIDER_NOT_SUPPORTED"},
+	}
+
+	for _, candidate := range codes {
+		if errors.Is(err, candidate.err) {
+			status := http.StatusBadRequest
+			if candidate.err == domainErrors.ErrCouponNotFound {
+				status = http.StatusNotFound
+			}
+			return status, echo.Map{
+				"error": candidate.err.Error(),
+				"code":  candidate.code,
+			}, true
+		}
+	}
+
+	return 0, nil, false
+}
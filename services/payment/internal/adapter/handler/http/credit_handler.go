@@ -14,7 +14,10 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/shopspring/decimal"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
 	customErr "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/auth"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/tenant"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
 	"go.uber.org/zap"
 )
@@ -24,6 +27,8 @@ type CreditHandler struct {
 	logger                   *zap.Logger
 	creditService            *usecase.CreditService
 	creditTransactionService *usecase.CreditTransactionService
+	pricingService           *usecase.FeaturePricingService
+	batchService             *usecase.CreditBatchService
 }
 
 // NewCreditHandler creates a new credit handler instance
@@ -31,11 +36,15 @@ func NewCreditHandler(
 	logger *zap.Logger,
 	creditService *usecase.CreditService,
 	creditTransactionService *usecase.CreditTransactionService,
+	pricingService *usecase.FeaturePricingService,
+	batchService *usecase.CreditBatchService,
 ) *CreditHandler {
 	return &CreditHandler{
 		logger:                   logger,
 		creditService:            creditService,
 		creditTransactionService: creditTransactionService,
+		pricingService:           pricingService,
+		batchService:             batchService,
 	}
 }
 
@@ -58,11 +67,14 @@ func (h *CreditHandler) GetUserCredits(c echo.Context) error {
 		})
 	}
 
-	// Determine service provider, falling back to default if query param is empty
-	serviceProvider := c.QueryParam("provider")
+	// Determine service provider, falling back to the request's provider if query param is empty
+	serviceProvider, status, body := requestServiceProvider(c, c.QueryParam("provider"))
+	if serviceProvider == nil {
+		return c.JSON(status, body)
+	}
 
 	// Get user's credit balance
-	balance, err := h.creditService.GetBalanceForProvider(c.Request().Context(), universalID, serviceProvider)
+	balance, err := h.creditService.GetBalanceForProvider(c.Request().Context(), universalID, serviceProvider.Code)
 	if err != nil {
 		h.logger.Error("Failed to get user credit balance",
 			zap.String("universal_id", universalID.String()),
@@ -104,6 +116,11 @@ func (h *CreditHandler) GetTransactionHistory(c echo.Context) error {
 		UserID: universalID,
 	}
 
+	// Only the transactions of the request's service provider are listed
+	if serviceProvider := tenant.GetServiceProvider(c); serviceProvider != nil {
+		filters.ServiceProvider = serviceProvider.Code
+	}
+
 	// Parse limit
 	if limitStr := c.QueryParam("limit"); limitStr != "" {
 		limit, err := strconv.Atoi(limitStr)
@@ -115,15 +132,36 @@ func (h *CreditHandler) GetTransactionHistory(c echo.Context) error {
 		filters.Limit = limit
 	}
 
-	// Parse offset
-	if offsetStr := c.QueryParam("offset"); offsetStr != "" {
-		offset, err := strconv.Atoi(offsetStr)
-		if err != nil || offset < 0 {
+	// Parse sort order
+	filters.Sort = dto.TransactionSortCreatedAt
+	if sort := c.QueryParam("sort"); sort != "" {
+		if sort != dto.TransactionSortCreatedAt && sort != dto.TransactionSortAmount {
 			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "invalid offset parameter",
+				"error": "invalid sort, must be one of: created_at, amount",
 			})
 		}
-		filters.Offset = offset
+		filters.Sort = sort
+	}
+	switch order := c.QueryParam("order"); order {
+	case "", "desc":
+	case "asc":
+		filters.Ascending = true
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid order, must be one of: asc, desc",
+		})
+	}
+
+	// Parse cursor
+	if cursorStr := c.QueryParam("cursor"); cursorStr != "" {
+		cursor, err := entity.DecodeCursor(cursorStr, filters.Sort, !filters.Ascending)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "invalid cursor",
+				"code":  "INVALID_CURSOR",
+			})
+		}
+		filters.Cursor = cursor
 	}
 
 	// Parse start date
@@ -156,10 +194,12 @@ func (h *CreditHandler) GetTransactionHistory(c echo.Context) error {
 			"credit_usage":      true,
 			"refund":            true,
 			"adjustment":        true,
+			"transfer_out":      true,
+			"transfer_in":       true,
 		}
 		if !validTypes[transactionType] {
 			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "invalid transaction_type, must be one of: credit_allocation, credit_usage, refund, adjustment",
+				"error": "invalid transaction_type, must be one of: credit_allocation, credit_usage, refund, adjustment, transfer_out, transfer_in",
 			})
 		}
 		filters.TransactionType = &transactionType
@@ -168,6 +208,12 @@ func (h *CreditHandler) GetTransactionHistory(c echo.Context) error {
 	// Get transaction history
 	response, err := h.creditTransactionService.GetUserTransactionHistory(c.Request().Context(), universalID, filters)
 	if err != nil {
+		if errors.Is(err, customErr.ErrInvalidCursor) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "invalid cursor",
+				"code":  "INVALID_CURSOR",
+			})
+		}
 		h.logger.Error("Failed to get transaction history",
 			zap.String("universal_id", universalID.String()),
 			zap.Error(err))
@@ -239,13 +285,37 @@ func (h *CreditHandler) UseCredits(c echo.Context) error {
 		})
 	}
 
-	// Parse amount to decimal
-	amount, err := decimal.NewFromString(req.Amount)
-	if err != nil {
-		h.logger.Error("Invalid amount format", zap.String("amount", req.Amount), zap.Error(err))
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "invalid amount format",
-		})
+	serviceProvider, status, body := requestServiceProvider(c, req.ServiceProvider)
+	if serviceProvider == nil {
+		return c.JSON(status, body)
+	}
+	req.ServiceProvider = serviceProvider.Code
+
+	// Without an amount the cost is computed from the feature price catalog
+	var quote *usecase.PriceQuote
+	var amount decimal.Decimal
+	if req.Amount == "" {
+		quote, err = h.pricingService.Quote(c.Request().Context(), universalID.String(), req.ServiceProvider, req.FeatureName, req.UsageMetadata)
+		if err != nil {
+			return h.priceErrorResponse(c, req.FeatureName, err)
+		}
+		amount = quote.Credits
+
+		// Keep the applied price with the transaction for auditing
+		if req.UsageMetadata == nil {
+			req.UsageMetadata = make(map[string]interface{})
+		}
+		req.UsageMetadata["price_id"] = quote.PriceID
+		req.UsageMetadata["price_version"] = quote.PriceVersion
+	} else {
+		// Parse amount to decimal
+		amount, err = decimal.NewFromString(req.Amount)
+		if err != nil {
+			h.logger.Error("Invalid amount format", zap.String("amount", req.Amount), zap.Error(err))
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "invalid amount format",
+			})
+		}
 	}
 
 	// Validate amount is positive
@@ -281,10 +351,19 @@ func (h *CreditHandler) UseCredits(c echo.Context) error {
 		usageMetadata = metadataBytes
 	}
 
+	// Record which member spent the credits when using a workspace balance
+	var actorID *uuid.UUID
+	if userIDStr, err := auth.GetUserID(c); err == nil {
+		if userID, err := uuid.Parse(userIDStr); err == nil {
+			actorID = &userID
+		}
+	}
+
 	// Call service to use credits
 	transaction, err := h.creditService.UseCredits(
 		c.Request().Context(),
 		universalID,
+		actorID,
 		req.ServiceProvider,
 		amount,
 		req.FeatureName,
@@ -348,9 +427,13 @@ func (h *CreditHandler) UseCredits(c echo.Context) error {
 	response := dto.UseCreditResponse{
 		Success:       true,
 		TransactionID: transaction.ID,
+		Amount:        amount.String(),
 		BalanceAfter:  transaction.BalanceAfter.String(),
 		Message:       "Credits successfully deducted",
 	}
+	if quote != nil {
+		response.PriceVersion = &quote.PriceVersion
+	}
 
 	h.logger.Info("Credits used successfully",
 		zap.String("universal_id", universalID.String()),
@@ -361,3 +444,167 @@ func (h *CreditHandler) UseCredits(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, response)
 }
+
+// QuoteCredits handles POST /api/v1/credits/quote
+// Returns the catalog price of a feature use without deducting credits.
+func (h *CreditHandler) QuoteCredits(c echo.Context) error {
+	universalID, err := auth.GetUniversalID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "unauthorized",
+		})
+	}
+
+	var req dto.QuoteCreditRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+	if err := c.Validate(req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "validation failed: " + err.Error(),
+		})
+	}
+
+	serviceProvider, status, body := requestServiceProvider(c, req.ServiceProvider)
+	if serviceProvider == nil {
+		return c.JSON(status, body)
+	}
+
+	quote, err := h.pricingService.Quote(c.Request().Context(), universalID, serviceProvider.Code, req.FeatureName, req.UsageMetadata)
+	if err != nil {
+		return h.priceErrorResponse(c, req.FeatureName, err)
+	}
+
+	return c.JSON(http.StatusOK, quote)
+}
+
+// priceErrorResponse maps catalog pricing errors to client-facing responses
+func (h *CreditHandler) priceErrorResponse(c echo.Context, featureName string, err error) error {
+	switch {
+	case errors.Is(err, customErr.ErrFeaturePriceNotFound):
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+			"error":   "price_not_found",
+			"message": fmt.Sprintf("No price is configured for feature %s; pass an explicit amount", featureName),
+		})
+	case errors.Is(err, customErr.ErrFeaturePriceUnitsMissing):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error":   "invalid_usage_metadata",
+			"message": err.Error(),
+		})
+	}
+
+	h.logger.Error("Failed to price feature usage",
+		zap.String("feature", featureName),
+		zap.Error(err))
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": "failed to price credit usage",
+	})
+}
+
+// UseCreditsBatch handles POST /api/v1/credits/batch
+// Applies many usages in one database transaction and reports the outcome of each item.
+func (h *CreditHandler) UseCreditsBatch(c echo.Context) error {
+	universalIDStr, err := auth.GetUniversalID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"error": "unauthorized",
+		})
+	}
+	universalID, err := uuid.Parse(universalIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid user ID format",
+		})
+	}
+
+	var req dto.UseCreditBatchRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "invalid request body",
+		})
+	}
+	if err := c.Validate(req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "validation failed: " + err.Error(),
+		})
+	}
+
+	serviceProvider, status, body := requestServiceProvider(c, req.ServiceProvider)
+	if serviceProvider == nil {
+		return c.JSON(status, body)
+	}
+
+	batch := &usecase.BatchUsageRequest{
+		UniversalID:     universalID,
+		ServiceProvider: serviceProvider.Code,
+		Atomic:          req.Atomic,
+		Items:           make([]usecase.BatchUsageItem, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		usage := usecase.BatchUsageItem{
+			FeatureName:   item.FeatureName,
+			Description:   item.Description,
+			UsageMetadata: item.UsageMetadata,
+		}
+		if item.Amount != "" {
+			amount, err := decimal.NewFromString(item.Amount)
+			if err != nil || !amount.IsPositive() {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": fmt.Sprintf("items[%d]: amount must be a number greater than zero", i),
+				})
+			}
+			usage.Amount = amount
+		}
+		if item.IdempotencyKey != nil && *item.IdempotencyKey != "" {
+			key, err := uuid.Parse(*item.IdempotencyKey)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": fmt.Sprintf("items[%d]: invalid idempotency key format", i),
+				})
+			}
+			usage.IdempotencyKey = &key
+		}
+		batch.Items[i] = usage
+	}
+
+	// Record which member spent the credits when using a workspace balance
+	if userIDStr, err := auth.GetUserID(c); err == nil {
+		if userID, err := uuid.Parse(userIDStr); err == nil {
+			batch.ActorID = &userID
+		}
+	}
+
+	result, err := h.batchService.UseCreditsBatch(c.Request().Context(), batch)
+	if err != nil {
+		switch {
+		case errors.Is(err, customErr.ErrCreditBatchRejected):
+			status := http.StatusUnprocessableEntity
+			for _, item := range result.Results {
+				if item.Code == "insufficient_credits" {
+					status = http.StatusPaymentRequired
+					break
+				}
+			}
+			return c.JSON(status, echo.Map{
+				"error":  "batch_rejected",
+				"result": result,
+			})
+		case errors.Is(err, customErr.ErrCreditBatchEmpty), errors.Is(err, customErr.ErrCreditBatchTooLarge):
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+
+		h.logger.Error("Failed to use credits in batch",
+			zap.String("universal_id", universalID.String()),
+			zap.Int("items", len(batch.Items)),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "failed to process credit usage",
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
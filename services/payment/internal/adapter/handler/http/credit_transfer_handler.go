@@ -0,0 +1,187 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/auth"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// CreditTransferHandler handles credit transfers between users
+type CreditTransferHandler struct {
+	transferService *usecase.CreditTransferService
+	roleService     auth.WorkspaceRoleService
+	logger          *zap.Logger
+}
+
+// NewCreditTransferHandler creates a new CreditTransferHandler instance
+func NewCreditTransferHandler(transferService *usecase.CreditTransferService, roleService auth.WorkspaceRoleService, logger *zap.Logger) *CreditTransferHandler {
+	return &CreditTransferHandler{
+		transferService: transferService,
+		roleService:     roleService,
+		logger:          logger,
+	}
+}
+
+// TransferCreditsRequest represents the HTTP request for sending credits to another user
+type TransferCreditsRequest struct {
+	RecipientID     string `json:"recipient_id" validate:"required,uuid"`
+	Amount          string `json:"amount" validate:"required"`
+	Note            string `json:"note,omitempty" validate:"max=200"`
+	ServiceProvider string `json:"service_provider,omitempty"`
+	IdempotencyKey  string `json:"idempotency_key,omitempty" validate:"omitempty,uuid4"`
+}
+
+// TransferCreditsResponse represents the result of a credit transfer
+type TransferCreditsResponse struct {
+	TransferID   string `json:"transfer_id"`
+	RecipientID  string `json:"recipient_id"`
+	Amount       string `json:"amount"`
+	BalanceAfter string `json:"balance_after"`
+	Duplicate    bool   `json:"duplicate"` // The idempotency key was already used; no credits were moved again
+}
+
+// TransferCredits handles POST /credits/transfers endpoint.
+// Sending from a workspace balance (X-Workspace-Id) requires the workspace owner or an admin.
+func (h *CreditTransferHandler) TransferCredits(c echo.Context) error {
+	var req TransferCreditsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+		})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Validation failed",
+			"code":    "VALIDATION_FAILED",
+			"details": err.Error(),
+		})
+	}
+
+	universalIDStr, err := auth.GetUniversalID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Failed to get user information",
+			"code":  "AUTH_ERROR",
+		})
+	}
+
+	universalID, err := uuid.Parse(universalIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid user ID",
+			"code":  "INVALID_USER_ID",
+		})
+	}
+
+	if workspaceID, _ := auth.GetWorkspaceID(c); workspaceID != "" {
+		allowed, err := auth.HasWorkspaceRole(c, h.roleService, auth.WorkspaceRoleOwner, auth.WorkspaceRoleAdmin)
+		if err != nil {
+			h.logger.Warn("Failed to resolve workspace role for credit transfer",
+				zap.String("workspace_id", workspaceID),
+				zap.Error(err))
+		}
+		if !allowed {
+			return auth.WorkspaceRoleRequired(c)
+		}
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid amount format",
+			"code":  "INVALID_AMOUNT",
+		})
+	}
+
+	serviceProvider, status, body := requestServiceProvider(c, req.ServiceProvider)
+	if serviceProvider == nil {
+		return c.JSON(status, body)
+	}
+
+	transfer := &usecase.TransferRequest{
+		FromID:          universalID,
+		ToID:            uuid.MustParse(req.RecipientID),
+		ServiceProvider: serviceProvider.Code,
+		Amount:          amount,
+		Note:            req.Note,
+	}
+	if req.IdempotencyKey != "" {
+		key := uuid.MustParse(req.IdempotencyKey)
+		transfer.IdempotencyKey = &key
+	}
+
+	// Record which member sent the credits when sending from a workspace balance
+	if userIDStr, err := auth.GetUserID(c); err == nil {
+		if userID, err := uuid.Parse(userIDStr); err == nil {
+			transfer.ActorID = &userID
+		}
+	}
+
+	result, err := h.transferService.Transfer(c.Request().Context(), transfer)
+	if err != nil {
+		if status, body, ok := transferErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to transfer credits",
+			zap.String("from", universalID.String()),
+			zap.String("to", req.RecipientID),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to transfer credits",
+			"code":  "TRANSFER_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusOK, TransferCreditsResponse{
+		TransferID:   result.TransferID.String(),
+		RecipientID:  result.Credit.UniversalID.String(),
+		Amount:       result.Credit.Amount.String(),
+		BalanceAfter: result.Debit.BalanceAfter.String(),
+		Duplicate:    result.Duplicate,
+	})
+}
+
+// transferErrorResponse maps transfer rule violations to client-facing responses
+func transferErrorResponse(err error) (int, echo.Map, bool) {
+	var insufficientErr *domainErrors.InsufficientBalanceError
+	if errors.As(err, &insufficientErr) {
+		return http.StatusPaymentRequired, echo.Map{
+			"error":             err.Error(),
+			"code":              "INSUFFICIENT_CREDITS",
+			"available_balance": insufficientErr.Available.String(),
+		}, true
+	}
+
+	codes := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{domainErrors.ErrSelfTransfer, http.StatusBadRequest, "SELF_TRANSFER"},
+		{domainErrors.ErrInvalidTransferAmount, http.StatusBadRequest, "INVALID_AMOUNT"},
+		{domainErrors.ErrTransferLimitExceeded, http.StatusTooManyRequests, "TRANSFER_LIMIT_EXCEEDED"},
+		{domainErrors.ErrTransferNotAllowed, http.StatusForbidden, "TRANSFER_NOT_ALLOWED"},
+		{domainErrors.ErrIdempotencyKeyConflict, http.StatusConflict, "IDEMPOTENCY_KEY_CONFLICT"},
+		{domainErrors.ErrTransferRecipientNotFound, http.StatusNotFound, "RECIPIENT_NOT_FOUND"},
+	}
+
+	for _, candidate := range codes {
+		if errors.Is(err, candidate.err) {
+			return candidate.status, echo.Map{
+				"error": err.Error(),
+				"code":  candidate.code,
+			}, true
+		}
+	}
+
+	return 0, nil, false
+}
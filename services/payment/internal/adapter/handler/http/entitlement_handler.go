@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/auth"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// EntitlementHandler handles entitlement endpoints
+type EntitlementHandler struct {
+	entitlementService *usecase.EntitlementService
+	logger             *zap.Logger
+}
+
+// NewEntitlementHandler creates a new EntitlementHandler instance
+func NewEntitlementHandler(entitlementService *usecase.EntitlementService, logger *zap.Logger) *EntitlementHandler {
+	return &EntitlementHandler{
+		entitlementService: entitlementService,
+		logger:             logger,
+	}
+}
+
+// GetEntitlements handles GET /entitlements endpoint.
+// With ?feature=<key> only that feature is returned, disabled when no plan grants it.
+func (h *EntitlementHandler) GetEntitlements(c echo.Context) error {
+	universalID, err := auth.GetUniversalID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Failed to get user information",
+			"code":  "AUTH_ERROR",
+		})
+	}
+
+	ctx := c.Request().Context()
+
+	if feature := c.QueryParam("feature"); feature != "" {
+		entitlement, err := h.entitlementService.CheckEntitlement(ctx, universalID, feature)
+		if err != nil {
+			return h.failed(c, universalID, err)
+		}
+		return c.JSON(http.StatusOK, echo.Map{
+			"feature":   entitlement.Feature,
+			"allowed":   entitlement.Allowed(),
+			"unlimited": entitlement.Unlimited(),
+			"limit":     entitlement.Limit,
+			"sources":   entitlement.Sources,
+		})
+	}
+
+	entitlements, err := h.entitlementService.GetEntitlements(ctx, universalID)
+	if err != nil {
+		return h.failed(c, universalID, err)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"universal_id": universalID,
+		"entitlements": entitlements.List(),
+	})
+}
+
+func (h *EntitlementHandler) failed(c echo.Context, universalID string, err error) error {
+	h.logger.Error("Failed to resolve entitlements",
+		zap.String("universal_id", universalID),
+		zap.Error(err))
+	return c.JSON(http.StatusInternalServerError, echo.Map{
+		"error": "Failed to get entitlements",
+		"code":  "ENTITLEMENTS_FETCH_FAILED",
+	})
+}
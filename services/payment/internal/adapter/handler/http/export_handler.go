@@ -0,0 +1,79 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// ExportHandler handles finance export endpoints
+type ExportHandler struct {
+	exportService *usecase.ExportService
+	logger        *zap.Logger
+}
+
+// NewExportHandler creates a new ExportHandler instance
+func NewExportHandler(exportService *usecase.ExportService, logger *zap.Logger) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// Export handles GET /admin/exports/:dataset endpoint.
+// Streams payments, refunds or credit_transactions as a file download.
+// Query params: from and to (YYYY-MM-DD, KST, both inclusive), format (csv|jsonl, default csv)
+// and service_provider.
+func (h *ExportHandler) Export(c echo.Context) error {
+	req := usecase.ExportRequest{
+		Dataset:         usecase.ExportDataset(c.Param("dataset")),
+		Format:          usecase.ExportFormat(c.QueryParam("format")),
+		ServiceProvider: c.QueryParam("service_provider"),
+	}
+	if req.Format == "" {
+		req.Format = usecase.ExportFormatCSV
+	}
+
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{
+		{"from", &req.From},
+		{"to", &req.To},
+	} {
+		date, err := time.ParseInLocation("2006-01-02", c.QueryParam(param.name), usecase.ExportLocation)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": fmt.Sprintf("%s must be a date in YYYY-MM-DD format", param.name),
+				"code":  "INVALID_EXPORT_QUERY",
+			})
+		}
+		*param.target = date
+	}
+
+	// Errors cannot be reported once streaming starts, so validate first
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": err.Error(),
+			"code":  "INVALID_EXPORT_QUERY",
+		})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, req.ContentType())
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", req.Filename()))
+	c.Response().WriteHeader(http.StatusOK)
+
+	rows, err := h.exportService.Export(c.Request().Context(), c.Response(), req)
+	if err != nil {
+		// The status is already sent; the truncated download is the only signal left to the client
+		h.logger.Error("Export stream aborted",
+			zap.String("dataset", string(req.Dataset)),
+			zap.Int64("rows_written", rows),
+			zap.Error(err))
+	}
+	return nil
+}
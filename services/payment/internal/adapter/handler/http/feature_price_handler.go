@@ -0,0 +1,120 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// FeaturePriceHandler handles feature price catalog administration
+type FeaturePriceHandler struct {
+	pricingService *usecase.FeaturePricingService
+	logger         *zap.Logger
+}
+
+// NewFeaturePriceHandler creates a new FeaturePriceHandler instance
+func NewFeaturePriceHandler(pricingService *usecase.FeaturePricingService, logger *zap.Logger) *FeaturePriceHandler {
+	return &FeaturePriceHandler{
+		pricingService: pricingService,
+		logger:         logger,
+	}
+}
+
+// CreateFeaturePriceRequest represents the HTTP request for adding a feature price version
+type CreateFeaturePriceRequest struct {
+	ServiceProvider string          `json:"service_provider"`
+	FeatureName     string          `json:"feature_name" validate:"required,max=100"`
+	PlanTier        string          `json:"plan_tier"`
+	Unit            string          `json:"unit"`
+	UnitSize        int64           `json:"unit_size"`
+	BaseCredits     decimal.Decimal `json:"base_credits"`
+	UnitCredits     decimal.Decimal `json:"unit_credits"`
+	MinCredits      decimal.Decimal `json:"min_credits"`
+	EffectiveFrom   *time.Time      `json:"effective_from,omitempty"`
+	EffectiveTo     *time.Time      `json:"effective_to,omitempty"`
+}
+
+// ListFeaturePrices handles GET /admin/feature-prices endpoint.
+// Query params: service_provider, feature, and effective=true to list only the versions in effect now.
+func (h *FeaturePriceHandler) ListFeaturePrices(c echo.Context) error {
+	var at *time.Time
+	if c.QueryParam("effective") == "true" {
+		now := time.Now()
+		at = &now
+	}
+
+	prices, err := h.pricingService.ListPrices(c.Request().Context(), c.QueryParam("service_provider"), c.QueryParam("feature"), at)
+	if err != nil {
+		h.logger.Error("Failed to list feature prices", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to list feature prices",
+			"code":  "FEATURE_PRICES_FETCH_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"prices": prices,
+		"count":  len(prices),
+	})
+}
+
+// CreateFeaturePrice handles POST /admin/feature-prices endpoint.
+// Adds a new version of the price for its service provider, feature and tier; the version it
+// replaces ends when the new one takes effect.
+func (h *FeaturePriceHandler) CreateFeaturePrice(c echo.Context) error {
+	var req CreateFeaturePriceRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+		})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Validation failed",
+			"code":    "VALIDATION_FAILED",
+			"details": err.Error(),
+		})
+	}
+
+	price := &model.FeaturePrice{
+		ServiceProvider: req.ServiceProvider,
+		FeatureName:     req.FeatureName,
+		PlanTier:        req.PlanTier,
+		Unit:            req.Unit,
+		UnitSize:        req.UnitSize,
+		BaseCredits:     req.BaseCredits,
+		UnitCredits:     req.UnitCredits,
+		MinCredits:      req.MinCredits,
+		EffectiveTo:     req.EffectiveTo,
+	}
+	if req.EffectiveFrom != nil {
+		price.EffectiveFrom = *req.EffectiveFrom
+	}
+
+	if err := h.pricingService.CreatePrice(c.Request().Context(), price); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidFeaturePrice) {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": err.Error(),
+				"code":  "INVALID_FEATURE_PRICE",
+			})
+		}
+		h.logger.Error("Failed to create feature price",
+			zap.String("feature_name", req.FeatureName),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to create feature price",
+			"code":  "FEATURE_PRICE_CREATE_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, price)
+}
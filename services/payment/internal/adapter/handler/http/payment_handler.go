@@ -1,10 +1,15 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/auth"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
 	"go.uber.org/zap"
@@ -29,61 +34,34 @@ func (h *PaymentHandler) GetPayments(c echo.Context) error {
 		return err // RequireAuth already returns the JSON error response
 	}
 
-	// Parse pagination parameters
-	page := 1
-	limit := 20
-	
-	// Parse page parameter
-	if pageStr := c.QueryParam("page"); pageStr != "" {
-		parsedPage, err := strconv.Atoi(pageStr)
-		if err != nil {
-			h.logger.Warn("Invalid page parameter",
-				zap.String("page", pageStr),
-				zap.Error(err))
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid page parameter",
-			})
-		}
-		if parsedPage < 1 {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Page must be greater than 0",
-			})
-		}
-		page = parsedPage
-	}
-	
-	// Parse limit parameter
-	if limitStr := c.QueryParam("limit"); limitStr != "" {
-		parsedLimit, err := strconv.Atoi(limitStr)
-		if err != nil {
-			h.logger.Warn("Invalid limit parameter",
-				zap.String("limit", limitStr),
-				zap.Error(err))
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid limit parameter",
-			})
-		}
-		if parsedLimit < 1 || parsedLimit > 100 {
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Limit must be between 1 and 100",
-			})
-		}
-		limit = parsedLimit
+	params, errResp := parsePaymentListParams(c)
+	if errResp != nil {
+		h.logger.Warn("Invalid payment list parameters",
+			zap.String("universal_id", user.UniversalID),
+			zap.String("error", errResp["error"]))
+		return c.JSON(http.StatusBadRequest, errResp)
 	}
+	params.UniversalID = user.UniversalID
 
 	h.logger.Info("Getting user payments",
 		zap.String("universal_id", user.UniversalID),
 		zap.String("email", user.Email),
-		zap.Int("page", page),
-		zap.Int("limit", limit),
+		zap.String("sort", params.Sort),
+		zap.Bool("descending", params.Descending),
+		zap.Int("limit", params.Limit),
 	)
 
-	response, err := h.usecase.GetPayments(c.Request().Context(), user.UniversalID, page, limit)
+	response, err := h.usecase.ListPayments(c.Request().Context(), params)
 	if err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidCursor) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid cursor",
+				"code":  "INVALID_CURSOR",
+			})
+		}
 		h.logger.Error("Failed to get user payments",
 			zap.String("universal_id", user.UniversalID),
-			zap.Int("page", page),
-			zap.Int("limit", limit),
+			zap.Int("limit", params.Limit),
 			zap.Error(err))
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to get payments",
@@ -93,14 +71,91 @@ func (h *PaymentHandler) GetPayments(c echo.Context) error {
 	h.logger.Debug("Retrieved user payments",
 		zap.String("universal_id", user.UniversalID),
 		zap.Int("payment_count", len(response.Data)),
-		zap.Int64("total_count", response.Pagination.Total),
-		zap.Int("current_page", response.Pagination.CurrentPage),
-		zap.Int("total_pages", response.Pagination.TotalPages),
+		zap.Bool("has_more", response.Pagination.HasMore),
 	)
 
 	return c.JSON(http.StatusOK, response)
 }
 
+// parsePaymentListParams reads the filters, sort order and page position of GET /payments
+func parsePaymentListParams(c echo.Context) (*entity.PaymentListParams, map[string]string) {
+	params := &entity.PaymentListParams{
+		Sort:       entity.PaymentSortCreatedAt,
+		Descending: true,
+		Limit:      entity.DefaultPageSize,
+		PlanID:     c.QueryParam("plan_id"),
+		Provider:   c.QueryParam("provider"),
+	}
+
+	if statusStr := c.QueryParam("status"); statusStr != "" {
+		for _, part := range strings.Split(statusStr, ",") {
+			status := entity.PaymentStatus(strings.ToLower(strings.TrimSpace(part)))
+			if !status.IsValid() {
+				return nil, map[string]string{"error": "Invalid status: " + part, "code": "INVALID_STATUS"}
+			}
+			params.Statuses = append(params.Statuses, status)
+		}
+	}
+
+	for name, target := range map[string]**time.Time{"start_date": &params.StartDate, "end_date": &params.EndDate} {
+		if value := c.QueryParam(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, map[string]string{"error": name + " must be an RFC 3339 timestamp", "code": "INVALID_DATE"}
+			}
+			*target = &parsed
+		}
+	}
+	if params.StartDate != nil && params.EndDate != nil && params.EndDate.Before(*params.StartDate) {
+		return nil, map[string]string{"error": "end_date must not be before start_date", "code": "INVALID_DATE"}
+	}
+
+	for name, target := range map[string]**int64{"min_amount": &params.MinAmount, "max_amount": &params.MaxAmount} {
+		if value := c.QueryParam(name); value != "" {
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || parsed < 0 {
+				return nil, map[string]string{"error": name + " must be a non-negative integer", "code": "INVALID_AMOUNT"}
+			}
+			*target = &parsed
+		}
+	}
+	if params.MinAmount != nil && params.MaxAmount != nil && *params.MaxAmount < *params.MinAmount {
+		return nil, map[string]string{"error": "max_amount must not be less than min_amount", "code": "INVALID_AMOUNT"}
+	}
+
+	if sort := c.QueryParam("sort"); sort != "" {
+		if sort != entity.PaymentSortCreatedAt && sort != entity.PaymentSortAmount {
+			return nil, map[string]string{"error": "sort must be created_at or amount", "code": "INVALID_SORT"}
+		}
+		params.Sort = sort
+	}
+	switch strings.ToLower(c.QueryParam("order")) {
+	case "", "desc":
+	case "asc":
+		params.Descending = false
+	default:
+		return nil, map[string]string{"error": "order must be asc or desc", "code": "INVALID_SORT"}
+	}
+
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit < entity.MinPageSize || parsedLimit > entity.MaxPageSize {
+			return nil, map[string]string{"error": "Limit must be between 1 and 100", "code": "INVALID_LIMIT"}
+		}
+		params.Limit = parsedLimit
+	}
+
+	if cursorStr := c.QueryParam("cursor"); cursorStr != "" {
+		cursor, err := entity.DecodeCursor(cursorStr, params.Sort, params.Descending)
+		if err != nil {
+			return nil, map[string]string{"error": "Invalid cursor", "code": "INVALID_CURSOR"}
+		}
+		params.Cursor = cursor
+	}
+
+	return params, nil
+}
+
 func (h *PaymentHandler) GetPaymentByTxID(c echo.Context) error {
 	id := c.Param("id")
 
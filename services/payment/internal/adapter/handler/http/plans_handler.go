@@ -2,6 +2,7 @@ package http
 
 import (
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -9,18 +10,24 @@ import (
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/adapter/repository"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/tenant"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
 	"go.uber.org/zap"
 )
 
+var currencyCodePattern = regexp.MustCompile(`^[A-Za-z]{3}$`)
+
 type PlansHandler struct {
 	logger   *zap.Logger
 	planRepo repository.PlanRepository
+	pricing  *usecase.PricingService
 }
 
-func NewPlansHandler(logger *zap.Logger, planRepo repository.PlanRepository) *PlansHandler {
+func NewPlansHandler(logger *zap.Logger, planRepo repository.PlanRepository, pricing *usecase.PricingService) *PlansHandler {
 	return &PlansHandler{
 		logger:   logger,
 		planRepo: planRepo,
+		pricing:  pricing,
 	}
 }
 
@@ -28,119 +35,83 @@ func NewPlansHandler(logger *zap.Logger, planRepo repository.PlanRepository) *Pl
 func (h *PlansHandler) GetSubscriptionPlans(c echo.Context) error {
 	h.logger.Info("Fetching subscription-type payment plans from database...")
 
+	return h.getPlansByType(c, model.PlanTypeSubscription,
+		"No active plans found. Waiting for Stripe webhook sync.")
+}
+
+// GetOneTimePlans returns all one-time payment plans
+func (h *PlansHandler) GetOneTimePlans(c echo.Context) error {
+	h.logger.Info("Fetching one-time payment plans from database...")
+
+	return h.getPlansByType(c, model.PlanTypeOneTime,
+		"No active one-time payment plans found. Waiting for Stripe webhook sync.")
+}
+
+// GetPlans returns all plans (backward compatibility)
+func (h *PlansHandler) GetPlans(c echo.Context) error {
+	h.logger.Info("Fetching all plans from database...")
+
 	ctx := c.Request().Context()
 
-	provider := c.QueryParam("provider")
-	if provider == "" {
+	selection, ok := h.selectCurrency(c)
+	if !ok {
 		return c.JSON(http.StatusBadRequest, echo.Map{
-			"error": "provider query parameter is required",
+			"error": "currency must be a 3-letter ISO currency code",
+			"code":  "INVALID_CURRENCY",
 		})
 	}
 
-	currency := strings.ToUpper(strings.TrimSpace(c.QueryParam("currency")))
-
-	dbPlans, err := h.planRepo.GetByTypeAndProvider(ctx, model.PlanTypeSubscription, provider, currency)
+	dbPlans, err := h.planRepo.GetAll(ctx)
 	if err != nil {
-		h.logger.Error("Error fetching subscription-type payment plans from database", zap.Error(err))
+		h.logger.Error("Error fetching plans from database", zap.Error(err))
 		return c.JSON(http.StatusInternalServerError, echo.Map{
-			"error": "Failed to fetch subscription-type payment plans",
-		})
-	}
-
-	plans := make([]entity.Plan, 0, len(dbPlans))
-	for _, dbPlan := range dbPlans {
-		plans = append(plans, mapPaymentPlanToEntity(dbPlan))
-	}
-
-	h.logger.Info("Plans fetched successfully from database",
-		zap.Int("plan_count", len(plans)),
-	)
-
-	if len(plans) == 0 {
-		return c.JSON(http.StatusOK, echo.Map{
-			"plans":   []entity.Plan{},
-			"message": "No active plans found. Waiting for Stripe webhook sync.",
+			"error": "Failed to fetch plans",
 		})
 	}
 
-	return c.JSON(http.StatusOK, echo.Map{
-		"plans": plans,
-	})
+	return h.respondWithPlans(c, dbPlans, selection,
+		"No active plans found. Waiting for Stripe webhook sync.")
 }
 
-// GetOneTimePlans returns all one-time payment plans
-func (h *PlansHandler) GetOneTimePlans(c echo.Context) error {
-	h.logger.Info("Fetching one-time payment plans from database...")
-
+// getPlansByType returns the plans of one type priced for the caller. The optional provider query
+// parameter restricts the plans to one payment gateway; otherwise the gateway of the currency is used.
+func (h *PlansHandler) getPlansByType(c echo.Context, planType string, emptyMessage string) error {
 	ctx := c.Request().Context()
 
-	provider := c.QueryParam("provider")
-	if provider == "" {
+	selection, ok := h.selectCurrency(c)
+	if !ok {
 		return c.JSON(http.StatusBadRequest, echo.Map{
-			"error": "provider query parameter is required",
+			"error": "currency must be a 3-letter ISO currency code",
+			"code":  "INVALID_CURRENCY",
 		})
 	}
 
-	currency := strings.ToUpper(strings.TrimSpace(c.QueryParam("currency")))
-
-	dbPlans, err := h.planRepo.GetByTypeAndProvider(ctx, model.PlanTypeOneTime, provider, currency)
+	dbPlans, err := h.planRepo.GetByTypeAndProvider(ctx, planType, c.QueryParam("provider"), "")
 	if err != nil {
-		h.logger.Error("Error fetching one-time payment plans from database", zap.Error(err))
+		h.logger.Error("Error fetching payment plans from database",
+			zap.String("type", planType),
+			zap.Error(err))
 		return c.JSON(http.StatusInternalServerError, echo.Map{
-			"error": "Failed to fetch one-time payment plans",
+			"error": "Failed to fetch payment plans",
 		})
 	}
 
-	plans := make([]entity.Plan, 0, len(dbPlans))
-	for _, dbPlan := range dbPlans {
-		plans = append(plans, mapPaymentPlanToEntity(dbPlan))
-	}
-
-	h.logger.Info("One-time payment plans fetched successfully from database",
-		zap.Int("plan_count", len(plans)),
-	)
+	return h.respondWithPlans(c, dbPlans, selection, emptyMessage)
+}
 
-	if len(plans) == 0 {
-		return c.JSON(http.StatusOK, echo.Map{
-			"plans":   []entity.Plan{},
-			"message": "No active one-time payment plans found. Waiting for Stripe webhook sync.",
-		})
+// selectCurrency selects the currency for the caller from the currency query parameter or the
+// country of the caller's IP address
+func (h *PlansHandler) selectCurrency(c echo.Context) (usecase.CurrencySelection, bool) {
+	override := strings.TrimSpace(c.QueryParam("currency"))
+	if override != "" && !currencyCodePattern.MatchString(override) {
+		return usecase.CurrencySelection{}, false
 	}
-
-	return c.JSON(http.StatusOK, echo.Map{
-		"plans": plans,
-	})
+	return h.pricing.SelectCurrency(c.Request().Context(), c.RealIP(), override), true
 }
 
-// GetPlans returns all plans (backward compatibility)
-func (h *PlansHandler) GetPlans(c echo.Context) error {
-	h.logger.Info("Fetching all plans from database...")
-
-	ctx := c.Request().Context()
-
-	provider := c.QueryParam("provider")
-	currency := strings.ToUpper(strings.TrimSpace(c.QueryParam("currency")))
-
-	dbPlans, err := h.planRepo.GetAll(ctx)
-	if provider != "" || currency != "" {
-		filtered := make([]*model.PaymentPlan, 0, len(dbPlans))
-		for _, plan := range dbPlans {
-			if provider != "" && plan.PgProvider != provider {
-				continue
-			}
-			if currency != "" && strings.ToUpper(plan.Currency) != currency {
-				continue
-			}
-			filtered = append(filtered, plan)
-		}
-		dbPlans = filtered
-	}
-	if err != nil {
-		h.logger.Error("Error fetching plans from database", zap.Error(err))
-		return c.JSON(http.StatusInternalServerError, echo.Map{
-			"error": "Failed to fetch plans",
-		})
-	}
+func (h *PlansHandler) respondWithPlans(c echo.Context, dbPlans []*model.PaymentPlan, selection usecase.CurrencySelection, emptyMessage string) error {
+	dbPlans = offeredPlans(c, dbPlans)
+	dbPlans, selection = h.pricing.PricePlans(dbPlans, selection, c.QueryParam("provider"))
 
 	plans := make([]entity.Plan, 0, len(dbPlans))
 	for _, dbPlan := range dbPlans {
@@ -149,18 +120,39 @@ func (h *PlansHandler) GetPlans(c echo.Context) error {
 
 	h.logger.Info("Plans fetched successfully from database",
 		zap.Int("plan_count", len(plans)),
+		zap.String("currency", selection.Currency),
+		zap.String("currency_source", selection.Source),
 	)
 
+	// Without a requested or located currency plans keep their own prices, which may differ in currency
+	response := echo.Map{"plans": plans}
+	if selection.Currency != "" {
+		response["currency"] = selection.Currency
+	}
+	if selection.Country != "" {
+		response["country"] = selection.Country
+	}
 	if len(plans) == 0 {
-		return c.JSON(http.StatusOK, echo.Map{
-			"plans":   []entity.Plan{},
-			"message": "No active plans found. Waiting for Stripe webhook sync.",
-		})
+		response["message"] = emptyMessage
 	}
 
-	return c.JSON(http.StatusOK, echo.Map{
-		"plans": plans,
-	})
+	return c.JSON(http.StatusOK, response)
+}
+
+// offeredPlans keeps the plans sold by the request's service provider
+func offeredPlans(c echo.Context, dbPlans []*model.PaymentPlan) []*model.PaymentPlan {
+	serviceProvider := tenant.GetServiceProvider(c)
+	if serviceProvider == nil {
+		return dbPlans
+	}
+
+	offered := make([]*model.PaymentPlan, 0, len(dbPlans))
+	for _, plan := range dbPlans {
+		if plan.OfferedBy(serviceProvider.Code) {
+			offered = append(offered, plan)
+		}
+	}
+	return offered
 }
 
 func mapPaymentPlanToEntity(dbPlan *model.PaymentPlan) entity.Plan {
@@ -175,6 +167,9 @@ func mapPaymentPlanToEntity(dbPlan *model.PaymentPlan) entity.Plan {
 	if plan.Type == "" {
 		plan.Type = model.PlanTypeSubscription
 	}
+	if currencies := dbPlan.Currencies(); len(currencies) > 1 {
+		plan.Currencies = currencies
+	}
 
 	features := dbPlan.Features
 	if features == nil {
@@ -203,7 +198,9 @@ func mapPaymentPlanToEntity(dbPlan *model.PaymentPlan) entity.Plan {
 			plan.IntervalCount = intervalCount
 		}
 		if price.Amount != 0 || price.Currency != "" {
+			price.Display = model.FormatAmount(price.Amount, price.Currency)
 			plan.Price = &price
+			plan.DisplayPrice = price.Display
 		}
 	}
 
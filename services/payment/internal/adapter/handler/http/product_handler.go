@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	dbRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/adapter/repository"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
@@ -24,6 +25,7 @@ type ProductHandler struct {
 	providerFactory     *providerFactory.Factory
 	customerMappingRepo domainRepo.CustomerMappingRepository
 	planRepo            dbRepo.PlanRepository
+	riskService         *usecase.RiskService
 	logger              *zap.Logger
 }
 
@@ -37,6 +39,7 @@ func NewProductHandler(
 	providerFactory *providerFactory.Factory,
 	customerMappingRepo domainRepo.CustomerMappingRepository,
 	planRepo dbRepo.PlanRepository,
+	riskService *usecase.RiskService,
 	logger *zap.Logger,
 ) *ProductHandler {
 	return &ProductHandler{
@@ -44,6 +47,7 @@ func NewProductHandler(
 		providerFactory:     providerFactory,
 		customerMappingRepo: customerMappingRepo,
 		planRepo:            planRepo,
+		riskService:         riskService,
 		logger:              logger,
 	}
 }
@@ -144,6 +148,14 @@ type CreateProductRequest struct {
 	CustomerKey string                 `json:"customer_key"`
 	PlanID      string                 `json:"plan_id,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	CashReceipt *CashReceiptRequest    `json:"cash_receipt,omitempty"`
+	CouponCode  string                 `json:"coupon_code,omitempty"`
+}
+
+// CashReceiptRequest represents the cash receipt (현금영수증) details collected at checkout
+type CashReceiptRequest struct {
+	Type                   string `json:"type" validate:"required,oneof=소득공제 지출증빙"`
+	CustomerIdentityNumber string `json:"customer_identity_number" validate:"required,min=8,max=20"`
 }
 
 // CreateProduct handles POST /products endpoint
@@ -156,26 +168,6 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 		providerStr = string(provider.ProviderTypeToss)
 	}
 
-	// Get provider instance
-	paymentProvider, err := h.providerFactory.GetProviderFromString(providerStr)
-	if err != nil {
-		h.logger.Error("Failed to get payment provider",
-			zap.String("provider", providerStr),
-			zap.Error(err))
-
-		if providerStr == string(provider.ProviderTypeStripe) {
-			return c.JSON(http.StatusNotImplemented, echo.Map{
-				"error": "Stripe one-time payment is not yet implemented",
-				"code":  "PROVIDER_NOT_IMPLEMENTED",
-			})
-		}
-
-		return c.JSON(http.StatusBadRequest, echo.Map{
-			"error": "Invalid payment provider",
-			"code":  "INVALID_PROVIDER",
-		})
-	}
-
 	// Parse request body
 	var req CreateProductRequest
 	if err := c.Bind(&req); err != nil {
@@ -198,6 +190,36 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 		})
 	}
 
+	// Payments are made with the credentials of the service provider the order is for
+	var requestedServiceProvider string
+	if req.Metadata != nil {
+		requestedServiceProvider, _ = req.Metadata["service_provider"].(string)
+	}
+	serviceProvider, status, body := requestServiceProvider(c, requestedServiceProvider)
+	if serviceProvider == nil {
+		return c.JSON(status, body)
+	}
+
+	// Get provider instance
+	paymentProvider, err := h.providerFactory.GetProviderForServiceProvider(serviceProvider, providerStr)
+	if err != nil {
+		h.logger.Error("Failed to get payment provider",
+			zap.String("provider", providerStr),
+			zap.Error(err))
+
+		if providerStr == string(provider.ProviderTypeStripe) {
+			return c.JSON(http.StatusNotImplemented, echo.Map{
+				"error": "Stripe one-time payment is not yet implemented",
+				"code":  "PROVIDER_NOT_IMPLEMENTED",
+			})
+		}
+
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid payment provider",
+			"code":  "INVALID_PROVIDER",
+		})
+	}
+
 	// Get universal ID from JWT context
 	universalID, err := auth.GetUniversalID(c)
 	if err != nil {
@@ -209,6 +231,13 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 		})
 	}
 
+	if req.CashReceipt != nil && providerStr != string(provider.ProviderTypeToss) {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Cash receipts are only supported for Toss payments",
+			"code":  "CASH_RECEIPT_NOT_SUPPORTED",
+		})
+	}
+
 	var metadataEmail string
 	if req.Metadata != nil {
 		if emailValue, ok := req.Metadata["email"].(string); ok {
@@ -222,7 +251,7 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 
 	currency := strings.ToUpper(req.Currency)
 	if req.PlanID != "" && h.planRepo != nil {
-		planCurrency, err := h.resolvePlanCurrency(ctx, req.PlanID)
+		planCurrency, err := h.resolvePlanCurrency(ctx, req.PlanID, serviceProvider.Code)
 		if err != nil {
 			if errors.Is(err, errPlanNotFound) {
 				h.logger.Warn("Plan ID not found",
@@ -265,6 +294,36 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 		currency = planCurrency
 	}
 
+	// Risky checkouts are stopped before the provider is asked for a payment. The card is not
+	// known until the customer pays, so card rules do not apply here. The cardholder is present
+	// and authenticated by the card company in the payment widget, so only blocks stop it.
+	if h.riskService != nil {
+		parsedUniversalID, err := uuid.Parse(universalID)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": "Invalid user ID",
+				"code":  "INVALID_USER_ID",
+			})
+		}
+
+		assessment := assessRisk(c, h.riskService, usecase.RiskRequest{
+			Action:      model.RiskActionCheckout,
+			UniversalID: parsedUniversalID,
+			Amount:      req.Amount,
+			Currency:    currency,
+		})
+		if status, body, ok := riskErrorResponse(assessment, true); ok {
+			return c.JSON(status, body)
+		}
+	}
+
+	// Credits and rewards of the payment go to the service provider recorded in its metadata
+	metadata := make(map[string]interface{}, len(req.Metadata)+1)
+	for k, v := range req.Metadata {
+		metadata[k] = v
+	}
+	metadata["service_provider"] = serviceProvider.Code
+
 	// Create payment request
 	usecaseReq := &usecase.CreateProductRequest{
 		UniversalID: universalID,
@@ -273,12 +332,26 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 		OrderName:   req.OrderName,
 		CustomerKey: req.CustomerKey,
 		PlanID:      req.PlanID,
-		Metadata:    req.Metadata,
+		Metadata:    metadata,
+		CouponCode:  strings.TrimSpace(req.CouponCode),
+	}
+
+	if req.CashReceipt != nil {
+		usecaseReq.CashReceiptType = req.CashReceipt.Type
+		usecaseReq.CashReceiptIdentifier = strings.ReplaceAll(req.CashReceipt.CustomerIdentityNumber, "-", "")
 	}
 
 	// Create payment with provider
 	resp, err := h.productUseCase.CreateProductWithProvider(ctx, usecaseReq, paymentProvider)
 	if err != nil {
+		if status, body, ok := couponErrorResponse(err); ok {
+			h.logger.Warn("Coupon rejected for payment",
+				zap.String("universal_id", universalID),
+				zap.String("coupon_code", req.CouponCode),
+				zap.Error(err))
+			return c.JSON(status, body)
+		}
+
 		h.logger.Error("Failed to create payment",
 			zap.String("universal_id", universalID),
 			zap.Error(err))
@@ -296,7 +369,7 @@ func (h *ProductHandler) CreateProduct(c echo.Context) error {
 	return c.JSON(http.StatusCreated, resp)
 }
 
-func (h *ProductHandler) resolvePlanCurrency(ctx context.Context, planID string) (string, error) {
+func (h *ProductHandler) resolvePlanCurrency(ctx context.Context, planID string, serviceProvider string) (string, error) {
 	if planID == "" || h.planRepo == nil {
 		return "", nil
 	}
@@ -305,7 +378,7 @@ func (h *ProductHandler) resolvePlanCurrency(ctx context.Context, planID string)
 	if err != nil {
 		return "", err
 	}
-	if plan == nil {
+	if plan == nil || !plan.OfferedBy(serviceProvider) {
 		return "", errPlanNotFound
 	}
 
@@ -349,8 +422,13 @@ func (h *ProductHandler) ConfirmProduct(c echo.Context) error {
 		providerStr = string(provider.ProviderTypeToss)
 	}
 
-	// Get provider instance
-	paymentProvider, err := h.providerFactory.GetProviderFromString(providerStr)
+	serviceProvider, status, body := requestServiceProvider(c, "")
+	if serviceProvider == nil {
+		return c.JSON(status, body)
+	}
+
+	// Get provider instance with the credentials of the request's service provider
+	paymentProvider, err := h.providerFactory.GetProviderForServiceProvider(serviceProvider, providerStr)
 	if err != nil {
 		h.logger.Error("Failed to get payment provider",
 			zap.String("provider", providerStr),
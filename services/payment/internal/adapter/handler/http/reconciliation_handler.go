@@ -0,0 +1,79 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// DefaultReconciliationWindow is how far back reconciliation looks when no period is given
+const DefaultReconciliationWindow = 72 * time.Hour
+
+// ReconciliationHandler handles payment reconciliation endpoints
+type ReconciliationHandler struct {
+	reconciliationService *usecase.ReconciliationService
+	logger                *zap.Logger
+}
+
+// NewReconciliationHandler creates a new ReconciliationHandler instance
+func NewReconciliationHandler(reconciliationService *usecase.ReconciliationService, logger *zap.Logger) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+		logger:                logger,
+	}
+}
+
+// Reconcile handles POST /admin/reconciliations endpoint.
+// Compares payments with TossPayments and Stripe, fixes payments stuck unpaid or without credits
+// and reports the other mismatches. Normally run by cmd/reconcile-payments.
+// Query params: from and to (RFC3339, default the last 72 hours) and dry_run=true to only report.
+func (h *ReconciliationHandler) Reconcile(c echo.Context) error {
+	req := usecase.ReconciliationRequest{To: time.Now()}
+	req.From = req.To.Add(-DefaultReconciliationWindow)
+	req.DryRun, _ = strconv.ParseBool(c.QueryParam("dry_run"))
+
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{
+		{"from", &req.From},
+		{"to", &req.To},
+	} {
+		value := c.QueryParam(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": fmt.Sprintf("%s must be an RFC3339 timestamp", param.name),
+				"code":  "INVALID_RECONCILIATION_QUERY",
+			})
+		}
+		*param.target = parsed
+	}
+
+	report, err := h.reconciliationService.Reconcile(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidReconciliationRange) {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": err.Error(),
+				"code":  "INVALID_RECONCILIATION_QUERY",
+			})
+		}
+		h.logger.Error("Failed to reconcile payments", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to reconcile payments",
+			"code":  "RECONCILIATION_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/auth"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// ReferralHandler handles referral program endpoints
+type ReferralHandler struct {
+	referralService *usecase.ReferralService
+	logger          *zap.Logger
+}
+
+// NewReferralHandler creates a new ReferralHandler instance
+func NewReferralHandler(referralService *usecase.ReferralService, logger *zap.Logger) *ReferralHandler {
+	return &ReferralHandler{
+		referralService: referralService,
+		logger:          logger,
+	}
+}
+
+// RedeemReferralRequest represents the HTTP request for redeeming a referral code
+type RedeemReferralRequest struct {
+	Code string `json:"code" validate:"required,max=20"`
+}
+
+// GetReferrals handles GET /referrals endpoint
+// Returns the user's referral code, creating it on first use, with their referral counts.
+func (h *ReferralHandler) GetReferrals(c echo.Context) error {
+	universalID, ok := h.universalID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Failed to get user information",
+			"code":  "AUTH_ERROR",
+		})
+	}
+
+	summary, err := h.referralService.GetSummary(c.Request().Context(), universalID)
+	if err != nil {
+		if status, body, ok := referralErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to get referral summary",
+			zap.String("universal_id", universalID.String()),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to get referrals",
+			"code":  "REFERRAL_FETCH_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+// RedeemReferral handles POST /referrals/redeem endpoint
+// Both users receive credits once the redeeming user's first payment succeeds.
+func (h *ReferralHandler) RedeemReferral(c echo.Context) error {
+	var req RedeemReferralRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+		})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Validation failed",
+			"code":    "VALIDATION_FAILED",
+			"details": err.Error(),
+		})
+	}
+
+	universalID, ok := h.universalID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Failed to get user information",
+			"code":  "AUTH_ERROR",
+		})
+	}
+
+	referral, err := h.referralService.Redeem(c.Request().Context(), universalID, req.Code)
+	if err != nil {
+		if status, body, ok := referralErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to redeem referral code",
+			zap.String("universal_id", universalID.String()),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to redeem referral code",
+			"code":  "REFERRAL_REDEEM_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, echo.Map{
+		"code":   referral.Code,
+		"status": referral.Status,
+	})
+}
+
+func (h *ReferralHandler) universalID(c echo.Context) (uuid.UUID, bool) {
+	universalIDStr, err := auth.GetUniversalID(c)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	universalID, err := uuid.Parse(universalIDStr)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return universalID, true
+}
+
+// referralErrorResponse maps referral errors to client-facing responses
+func referralErrorResponse(err error) (int, echo.Map, bool) {
+	codes := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{domainErrors.ErrReferralProgramDisabled, http.StatusNotFound, "REFERRAL_PROGRAM_DISABLED"},
+		{domainErrors.ErrReferralCodeNotFound, http.StatusNotFound, "REFERRAL_CODE_NOT_FOUND"},
+		{domainErrors.ErrSelfReferral, http.StatusBadRequest, "SELF_REFERRAL"},
+		{domainErrors.ErrAlreadyReferred, http.StatusConflict, "ALREADY_REFERRED"},
+		{domainErrors.ErrReferralNotEligible, http.StatusBadRequest, "REFERRAL_NOT_ELIGIBLE"},
+	}
+
+	for _, candidate := range codes {
+		if errors.Is(err, candidate.err) {
+			return candidate.status, echo.Map{
+				"error": err.Error(),
+				"code":  candidate.code,
+			}, true
+		}
+	}
+
+	return 0, nil, false
+}
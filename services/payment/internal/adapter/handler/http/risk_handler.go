@@ -0,0 +1,143 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	geoMiddleware "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/geo"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// RiskHandler handles risk decision endpoints
+type RiskHandler struct {
+	riskService *usecase.RiskService
+	logger      *zap.Logger
+}
+
+// NewRiskHandler creates a new RiskHandler instance
+func NewRiskHandler(riskService *usecase.RiskService, logger *zap.Logger) *RiskHandler {
+	return &RiskHandler{
+		riskService: riskService,
+		logger:      logger,
+	}
+}
+
+// ListAssessments handles GET /admin/risk-assessments endpoint.
+// Accepts optional universal_id, decision, since (RFC 3339) and limit parameters.
+func (h *RiskHandler) ListAssessments(c echo.Context) error {
+	if h.riskService == nil {
+		return c.JSON(http.StatusNotFound, echo.Map{
+			"error": "Risk checks are not enabled",
+			"code":  "RISK_NOT_ENABLED",
+		})
+	}
+
+	var filters dto.RiskAssessmentFilters
+	if value := c.QueryParam("universal_id"); value != "" {
+		universalID, err := uuid.Parse(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": "universal_id must be a valid UUID",
+				"code":  "INVALID_RISK_QUERY",
+			})
+		}
+		filters.UniversalID = &universalID
+	}
+
+	switch decision := c.QueryParam("decision"); decision {
+	case "", model.RiskDecisionAllow, model.RiskDecisionVerify, model.RiskDecisionBlock:
+		filters.Decision = decision
+	default:
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "decision must be one of allow, verify or block",
+			"code":  "INVALID_RISK_QUERY",
+		})
+	}
+
+	if value := c.QueryParam("since"); value != "" {
+		since, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": "since must be an RFC 3339 timestamp",
+				"code":  "INVALID_RISK_QUERY",
+			})
+		}
+		filters.Since = since
+	}
+
+	if value := c.QueryParam("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": "limit must be a positive number",
+				"code":  "INVALID_RISK_QUERY",
+			})
+		}
+		filters.Limit = limit
+	}
+
+	assessments, err := h.riskService.ListAssessments(c.Request().Context(), filters)
+	if err != nil {
+		h.logger.Error("Failed to list risk assessments", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to list risk assessments",
+			"code":  "RISK_ASSESSMENTS_FETCH_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{"assessments": assessments})
+}
+
+// assessRisk scores a request from the caller's IP and location. Returns nil when risk checks are disabled.
+func assessRisk(c echo.Context, riskService *usecase.RiskService, req usecase.RiskRequest) *model.RiskAssessment {
+	if riskService == nil {
+		return nil
+	}
+
+	req.IP = c.RealIP()
+	req.Location = geoMiddleware.GetLocation(c)
+	return riskService.Assess(c.Request().Context(), req)
+}
+
+// riskVerificationChallenge tells the client how to complete a verify decision: pay through the Toss
+// payment widget, where the card company authenticates the cardholder
+const riskVerificationChallenge = "payment_widget"
+
+// riskErrorResponse maps a risk decision to a client-facing response. Only a block decision refuses
+// the request outright. A verify decision passes when the cardholder is authenticated on the way,
+// e.g. 3-D Secure on a Stripe payment or the card company's authentication in the Toss payment
+// widget. Elsewhere, such as billing key charges made without the cardholder present, it answers
+// 428 RISK_VERIFICATION_REQUIRED with the challenge the client can complete instead.
+func riskErrorResponse(assessment *model.RiskAssessment, canVerify bool) (int, echo.Map, bool) {
+	if assessment == nil {
+		return 0, nil, false
+	}
+
+	switch assessment.Decision {
+	case model.RiskDecisionBlock:
+		return http.StatusForbidden, echo.Map{
+			"error":         "Request was declined by risk checks",
+			"code":          "RISK_BLOCKED",
+			"assessment_id": assessment.ID,
+		}, true
+	case model.RiskDecisionVerify:
+		if canVerify {
+			return 0, nil, false
+		}
+		return http.StatusPreconditionRequired, echo.Map{
+			"error":         "Additional verification is required for this request",
+			"code":          "RISK_VERIFICATION_REQUIRED",
+			"action":        string(model.RiskDecisionVerify),
+			"challenge":     riskVerificationChallenge,
+			"assessment_id": assessment.ID,
+		}, true
+	}
+
+	return 0, nil, false
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/auth"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/tenant"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// SeatHandler handles workspace seat endpoints
+type SeatHandler struct {
+	seatService *usecase.SeatService
+	roleService auth.WorkspaceRoleService
+	logger      *zap.Logger
+}
+
+// NewSeatHandler creates a new SeatHandler instance
+func NewSeatHandler(seatService *usecase.SeatService, roleService auth.WorkspaceRoleService, logger *zap.Logger) *SeatHandler {
+	return &SeatHandler{
+		seatService: seatService,
+		roleService: roleService,
+		logger:      logger,
+	}
+}
+
+// GetSeats handles GET /seats endpoint
+func (h *SeatHandler) GetSeats(c echo.Context) error {
+	return h.handle(c, "get", h.seatService.GetSeatUsage)
+}
+
+// ReserveSeat handles POST /seats/reserve endpoint.
+// Returns 409 when all paid seats are in use; with ?purchase=true a seat is bought instead,
+// returning 402 if its payment fails. The member must not be added on either error.
+// Purchasing a seat requires the workspace owner or an admin.
+func (h *SeatHandler) ReserveSeat(c echo.Context) error {
+	purchase := c.QueryParam("purchase") == "true"
+	if purchase {
+		allowed, err := auth.HasWorkspaceRole(c, h.roleService, auth.WorkspaceRoleOwner, auth.WorkspaceRoleAdmin)
+		if err != nil {
+			h.logger.Warn("Failed to resolve workspace role for seat purchase", zap.Error(err))
+		}
+		if !allowed {
+			return auth.WorkspaceRoleRequired(c)
+		}
+	}
+	return h.handle(c, "reserve", func(ctx context.Context, workspaceID string) (*usecase.SeatUsage, error) {
+		return h.seatService.ReserveSeat(ctx, tenant.GetServiceProvider(c), workspaceID, purchase)
+	})
+}
+
+// SyncSeats handles POST /seats/sync endpoint (owner or admin only, enforced by the route)
+func (h *SeatHandler) SyncSeats(c echo.Context) error {
+	return h.handle(c, "sync", func(ctx context.Context, workspaceID string) (*usecase.SeatUsage, error) {
+		return h.seatService.SyncSeats(ctx, tenant.GetServiceProvider(c), workspaceID)
+	})
+}
+
+func (h *SeatHandler) handle(c echo.Context, action string, fn func(ctx context.Context, workspaceID string) (*usecase.SeatUsage, error)) error {
+	workspaceID, _ := auth.GetWorkspaceID(c)
+	if workspaceID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "X-Workspace-Id header is required",
+			"code":  "WORKSPACE_REQUIRED",
+		})
+	}
+
+	usage, err := fn(c.Request().Context(), workspaceID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrSeatSubscriptionNotFound):
+			return c.JSON(http.StatusNotFound, echo.Map{
+				"error": err.Error(),
+				"code":  "SEAT_SUBSCRIPTION_NOT_FOUND",
+			})
+		case errors.Is(err, domainErrors.ErrSeatLimitReached):
+			return c.JSON(http.StatusConflict, echo.Map{
+				"error": err.Error(),
+				"code":  "SEAT_LIMIT_REACHED",
+				"seats": usage,
+			})
+		case errors.Is(err, domainErrors.ErrSeatPaymentFailed):
+			return c.JSON(http.StatusPaymentRequired, echo.Map{
+				"error": err.Error(),
+				"code":  "SEAT_PAYMENT_FAILED",
+			})
+		}
+		if status, body, ok := serviceProviderErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to handle seat request",
+			zap.String("action", action),
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to process seats",
+			"code":  "SEAT_REQUEST_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusOK, usage)
+}
@@ -0,0 +1,276 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stripe/stripe-go/v79/client"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/tenant"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// ServiceProviderHandler handles service provider registry administration
+type ServiceProviderHandler struct {
+	registry *usecase.ServiceProviderRegistry
+	logger   *zap.Logger
+}
+
+// NewServiceProviderHandler creates a new ServiceProviderHandler instance
+func NewServiceProviderHandler(registry *usecase.ServiceProviderRegistry, logger *zap.Logger) *ServiceProviderHandler {
+	return &ServiceProviderHandler{
+		registry: registry,
+		logger:   logger,
+	}
+}
+
+// ServiceProviderCredentialsRequest carries gateway secrets; blank values are not changed
+type ServiceProviderCredentialsRequest struct {
+	StripeSecretKey      string `json:"stripe_secret_key"`
+	StripeWebhookSecret  string `json:"stripe_webhook_secret"`
+	TossSecretKey        string `json:"toss_secret_key"`
+	TossClientKey        string `json:"toss_client_key"`
+	TossBillingSecretKey string `json:"toss_billing_secret_key"`
+}
+
+// CreateServiceProviderRequest represents the HTTP request for registering a service provider
+type CreateServiceProviderRequest struct {
+	Code        string                             `json:"code" validate:"required,max=50"`
+	DisplayName string                             `json:"display_name" validate:"max=200"`
+	ClientURLs  []string                           `json:"client_urls" validate:"dive,url"`
+	Credentials *ServiceProviderCredentialsRequest `json:"credentials,omitempty"`
+}
+
+// UpdateServiceProviderRequest represents the HTTP request for changing a service provider
+type UpdateServiceProviderRequest struct {
+	DisplayName *string                            `json:"display_name,omitempty" validate:"omitempty,max=200"`
+	ClientURLs  []string                           `json:"client_urls,omitempty" validate:"dive,url"`
+	IsActive    *bool                              `json:"is_active,omitempty"`
+	Credentials *ServiceProviderCredentialsRequest `json:"credentials,omitempty"`
+}
+
+// ServiceProviderResponse describes a service provider without revealing its secrets
+type ServiceProviderResponse struct {
+	Code                  string    `json:"code"`
+	DisplayName           string    `json:"display_name"`
+	ClientURLs            []string  `json:"client_urls"`
+	IsActive              bool      `json:"is_active"`
+	Default               bool      `json:"default"`
+	ConfiguredCredentials []string  `json:"configured_credentials"` // Names of the secrets stored for the provider
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// ListServiceProviders handles GET /admin/service-providers endpoint
+func (h *ServiceProviderHandler) ListServiceProviders(c echo.Context) error {
+	providers, err := h.registry.List(c.Request().Context())
+	if err != nil {
+		h.logger.Error("Failed to list service providers", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to list service providers",
+			"code":  "SERVICE_PROVIDERS_FETCH_FAILED",
+		})
+	}
+
+	responses := make([]ServiceProviderResponse, len(providers))
+	for i, provider := range providers {
+		responses[i] = h.toResponse(provider)
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"service_providers": responses,
+		"count":             len(responses),
+	})
+}
+
+// CreateServiceProvider handles POST /admin/service-providers endpoint
+func (h *ServiceProviderHandler) CreateServiceProvider(c echo.Context) error {
+	var req CreateServiceProviderRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+		})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Validation failed",
+			"code":    "VALIDATION_FAILED",
+			"details": err.Error(),
+		})
+	}
+
+	createReq := &usecase.CreateServiceProviderRequest{
+		Code:        req.Code,
+		DisplayName: req.DisplayName,
+		ClientURLs:  req.ClientURLs,
+	}
+	if req.Credentials != nil {
+		createReq.Credentials = req.Credentials.toModel()
+	}
+
+	provider, err := h.registry.Create(c.Request().Context(), createReq)
+	if err != nil {
+		if status, body, ok := serviceProviderErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to create service provider",
+			zap.String("code", req.Code),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to create service provider",
+			"code":  "SERVICE_PROVIDER_CREATE_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, h.toResponse(provider))
+}
+
+// UpdateServiceProvider handles PUT /admin/service-providers/:code endpoint
+func (h *ServiceProviderHandler) UpdateServiceProvider(c echo.Context) error {
+	var req UpdateServiceProviderRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+		})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Validation failed",
+			"code":    "VALIDATION_FAILED",
+			"details": err.Error(),
+		})
+	}
+
+	updateReq := &usecase.UpdateServiceProviderRequest{
+		DisplayName: req.DisplayName,
+		ClientURLs:  req.ClientURLs,
+		IsActive:    req.IsActive,
+	}
+	if req.Credentials != nil {
+		credentials := req.Credentials.toModel()
+		updateReq.Credentials = &credentials
+	}
+
+	code := c.Param("code")
+	provider, err := h.registry.Update(c.Request().Context(), code, updateReq)
+	if err != nil {
+		if status, body, ok := serviceProviderErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to update service provider",
+			zap.String("code", code),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to update service provider",
+			"code":  "SERVICE_PROVIDER_UPDATE_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusOK, h.toResponse(provider))
+}
+
+func (h *ServiceProviderHandler) toResponse(provider *model.ServiceProvider) ServiceProviderResponse {
+	configured := make([]string, 0, 5)
+	for name, value := range map[string]string{
+		"stripe_secret_key":       provider.Credentials.StripeSecretKey,
+		"stripe_webhook_secret":   provider.Credentials.StripeWebhookSecret,
+		"toss_secret_key":         provider.Credentials.TossSecretKey,
+		"toss_client_key":         provider.Credentials.TossClientKey,
+		"toss_billing_secret_key": provider.Credentials.TossBillingSecretKey,
+	} {
+		if value != "" {
+			configured = append(configured, name)
+		}
+	}
+	sort.Strings(configured)
+
+	clientURLs := []string(provider.ClientURLs)
+	if clientURLs == nil {
+		clientURLs = []string{}
+	}
+
+	return ServiceProviderResponse{
+		Code:                  provider.Code,
+		DisplayName:           provider.DisplayName,
+		ClientURLs:            clientURLs,
+		IsActive:              provider.IsActive,
+		Default:               provider.Code == h.registry.DefaultCode(),
+		ConfiguredCredentials: configured,
+		CreatedAt:             provider.CreatedAt,
+		UpdatedAt:             provider.UpdatedAt,
+	}
+}
+
+func (r *ServiceProviderCredentialsRequest) toModel() model.ServiceProviderCredentials {
+	return model.ServiceProviderCredentials{
+		StripeSecretKey:      r.StripeSecretKey,
+		StripeWebhookSecret:  r.StripeWebhookSecret,
+		TossSecretKey:        r.TossSecretKey,
+		TossClientKey:        r.TossClientKey,
+		TossBillingSecretKey: r.TossBillingSecretKey,
+	}
+}
+
+// serviceProviderErrorResponse maps service provider errors to client-facing responses
+func serviceProviderErrorResponse(err error) (int, echo.Map, bool) {
+	codes := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{domainErrors.ErrServiceProviderNotFound, http.StatusNotFound, "SERVICE_PROVIDER_NOT_FOUND"},
+		{domainErrors.ErrServiceProviderMismatch, http.StatusBadRequest, "SERVICE_PROVIDER_MISMATCH"},
+		{domainErrors.ErrServiceProviderExists, http.StatusConflict, "SERVICE_PROVIDER_EXISTS"},
+		{domainErrors.ErrInvalidServiceProviderCode, http.StatusBadRequest, "INVALID_SERVICE_PROVIDER_CODE"},
+		{domainErrors.ErrCredentialEncryptionUnavailable, http.StatusServiceUnavailable, "CREDENTIAL_ENCRYPTION_UNAVAILABLE"},
+		{domainErrors.ErrServiceProviderCredentialsMissing, http.StatusServiceUnavailable, "SERVICE_PROVIDER_CREDENTIALS_MISSING"},
+		{domainErrors.ErrBillingKeyServiceProviderMismatch, http.StatusBadRequest, "BILLING_KEY_SERVICE_PROVIDER_MISMATCH"},
+	}
+
+	for _, candidate := range codes {
+		if errors.Is(err, candidate.err) {
+			return candidate.status, echo.Map{
+				"error": err.Error(),
+				"code":  candidate.code,
+			}, true
+		}
+	}
+
+	return 0, nil, false
+}
+
+// requestServiceProvider resolves the service provider named in a request body, falling back to the
+// provider resolved from the request headers. On failure it returns the response to send instead.
+func requestServiceProvider(c echo.Context, requested string) (*model.ServiceProvider, int, echo.Map) {
+	provider, err := tenant.ResolveRequested(c, requested)
+	if err == nil {
+		return provider, 0, nil
+	}
+	if status, body, ok := serviceProviderErrorResponse(err); ok {
+		return nil, status, body
+	}
+	return nil, http.StatusInternalServerError, echo.Map{
+		"error": "Failed to resolve service provider",
+		"code":  "SERVICE_PROVIDER_RESOLUTION_FAILED",
+	}
+}
+
+// requestStripeClient returns the Stripe client of the request's service provider. On failure it
+// returns the response to send instead.
+func requestStripeClient(c echo.Context) (*client.API, int, echo.Map) {
+	stripeClient, err := usecase.StripeClient(tenant.GetServiceProvider(c))
+	if err != nil {
+		status, body, _ := serviceProviderErrorResponse(err)
+		return nil, status, body
+	}
+	return stripeClient, 0, nil
+}
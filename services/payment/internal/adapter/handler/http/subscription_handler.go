@@ -9,13 +9,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stripe/stripe-go/v79"
-	"github.com/stripe/stripe-go/v79/customer"
-	"github.com/stripe/stripe-go/v79/subscription"
+	"github.com/stripe/stripe-go/v79/client"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
 	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 	domainProvider "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
 	domainRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/auth"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/tenant"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
 	"go.uber.org/zap"
 )
@@ -25,6 +26,10 @@ type SubscriptionHandler struct {
 	subscriptionService *usecase.SubscriptionService
 	customerMappingRepo domainRepo.CustomerMappingRepository // 추가
 	clientURL           string                               // 추가
+	couponService       *usecase.CouponService
+	trialService        *usecase.TrialService
+	seatService         *usecase.SeatService
+	riskService         *usecase.RiskService
 }
 
 const stripeProvider = string(domainProvider.ProviderTypeStripe)
@@ -34,12 +39,20 @@ func NewSubscriptionHandler(
 	subscriptionService *usecase.SubscriptionService,
 	customerMappingRepo domainRepo.CustomerMappingRepository, // 추가
 	clientURL string, // 추가
+	couponService *usecase.CouponService,
+	trialService *usecase.TrialService,
+	seatService *usecase.SeatService,
+	riskService *usecase.RiskService,
 ) *SubscriptionHandler {
 	return &SubscriptionHandler{
 		logger:              logger,
 		subscriptionService: subscriptionService,
 		customerMappingRepo: customerMappingRepo, // 추가
 		clientURL:           clientURL,           // 추가
+		couponService:       couponService,
+		trialService:        trialService,
+		seatService:         seatService,
+		riskService:         riskService,
 	}
 }
 
@@ -54,8 +67,14 @@ func (h *SubscriptionHandler) GetCurrentSubscription(c echo.Context) error {
 		zap.String("universal_id", user.UniversalID),
 	)
 
+	// Subscriptions live in the Stripe account of the request's service provider
+	stripeClient, status, body := requestStripeClient(c)
+	if stripeClient == nil {
+		return c.JSON(status, body)
+	}
+
 	// Get active subscription for the user
-	activeSub, err := h.subscriptionService.GetActiveSubscriptionForUniversalID(c.Request().Context(), user.UniversalID)
+	activeSub, err := h.subscriptionService.GetActiveSubscriptionForUniversalID(c.Request().Context(), tenant.GetServiceProvider(c), user.UniversalID)
 	if err != nil {
 		if errors.Is(err, domainErrors.ErrNoCustomerMapping) {
 			// Customer mapping doesn't exist - create it lazily
@@ -63,7 +82,7 @@ func (h *SubscriptionHandler) GetCurrentSubscription(c echo.Context) error {
 				zap.String("universal_id", user.UniversalID))
 
 			// Create customer mapping with user's email
-			if err := h.getOrCreateCustomerMapping(c, user); err != nil {
+			if err := h.getOrCreateCustomerMapping(c, stripeClient, user); err != nil {
 				h.logger.Error("Failed to create customer mapping",
 					zap.String("universal_id", user.UniversalID),
 					zap.Error(err))
@@ -157,7 +176,7 @@ func (h *SubscriptionHandler) GetCurrentSubscription(c echo.Context) error {
 }
 
 // getOrCreateCustomerMapping ensures a Stripe customer exists for the authenticated user
-func (h *SubscriptionHandler) getOrCreateCustomerMapping(c echo.Context, user *auth.AuthUser) error {
+func (h *SubscriptionHandler) getOrCreateCustomerMapping(c echo.Context, stripeClient *client.API, user *auth.AuthUser) error {
 	// Check if we already have a Stripe customer for this user
 	existingMapping, err := h.customerMappingRepo.GetByProviderAndUniversalID(c.Request().Context(), stripeProvider, user.UniversalID)
 	if err != nil {
@@ -175,7 +194,7 @@ func (h *SubscriptionHandler) getOrCreateCustomerMapping(c echo.Context, user *a
 			"universal_id": user.UniversalID,
 		},
 	}
-	stripeCustomer, err := customer.New(customerParams)
+	stripeCustomer, err := stripeClient.Customers.New(customerParams)
 	if err != nil {
 		return fmt.Errorf("failed to create Stripe customer: %w", err)
 	}
@@ -199,6 +218,23 @@ func (h *SubscriptionHandler) getOrCreateCustomerMapping(c echo.Context, user *a
 	return nil
 }
 
+// defaultStripeCard returns the card of a Stripe customer's default payment method, or nil when they have none
+func (h *SubscriptionHandler) defaultStripeCard(stripeClient *client.API, customerID string) *stripe.PaymentMethodCard {
+	params := &stripe.CustomerParams{}
+	params.AddExpand("invoice_settings.default_payment_method")
+	customer, err := stripeClient.Customers.Get(customerID, params)
+	if err != nil {
+		h.logger.Warn("Failed to retrieve default card of Stripe customer",
+			zap.String("customer_id", customerID),
+			zap.Error(err))
+		return nil
+	}
+	if customer.InvoiceSettings == nil || customer.InvoiceSettings.DefaultPaymentMethod == nil {
+		return nil
+	}
+	return customer.InvoiceSettings.DefaultPaymentMethod.Card
+}
+
 func (h *SubscriptionHandler) CreateSubscription(c echo.Context) error {
 	// Get authenticated user from JWT
 	user, err := auth.RequireAuth(c)
@@ -224,6 +260,12 @@ func (h *SubscriptionHandler) CreateSubscription(c echo.Context) error {
 		})
 	}
 
+	// The customer and subscription are created in the request's service provider's Stripe account
+	stripeClient, status, body := requestStripeClient(c)
+	if stripeClient == nil {
+		return c.JSON(status, body)
+	}
+
 	h.logger.Info("Creating subscription with Payment Element...",
 		zap.String("price_id", req.PriceID),
 		zap.String("email", req.Email),
@@ -247,6 +289,74 @@ func (h *SubscriptionHandler) CreateSubscription(c echo.Context) error {
 		}
 	}
 
+	// Risky subscriptions are blocked; ones that need verification go through 3-D Secure. A returning
+	// customer is assessed with their default card, whose issuer country Stripe reports.
+	riskReq := usecase.RiskRequest{
+		Action:      model.RiskActionSubscription,
+		UniversalID: uuid.MustParse(user.UniversalID),
+	}
+	if h.riskService != nil && customerID != "" {
+		if card := h.defaultStripeCard(stripeClient, customerID); card != nil {
+			riskReq.CardFingerprint = card.Fingerprint
+			riskReq.CardCountry = card.Country
+		}
+	}
+	assessment := assessRisk(c, h.riskService, riskReq)
+	if status, body, ok := riskErrorResponse(assessment, true); ok {
+		return c.JSON(status, body)
+	}
+
+	// Validate the coupon before touching Stripe so invalid codes fail fast
+	var couponQuote *usecase.CouponQuote
+	var discount *stripe.SubscriptionDiscountParams
+	if req.CouponCode != "" {
+		if h.couponService == nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": "Coupons are not available",
+				"code":  "COUPON_NOT_SUPPORTED",
+			})
+		}
+
+		// The price ID is normalized to its plan ID, the identifier coupon plan restrictions use
+		couponQuote, err = h.couponService.Quote(c.Request().Context(), req.CouponCode, uuid.MustParse(user.UniversalID), req.PriceID, 0, "")
+		if err == nil {
+			discount, err = h.couponService.StripeDiscount(c.Request().Context(), tenant.GetServiceProvider(c), couponQuote.Coupon)
+		}
+		if err != nil {
+			if status, body, ok := couponErrorResponse(err); ok {
+				return c.JSON(status, body)
+			}
+			h.logger.Error("Failed to apply coupon to subscription",
+				zap.String("coupon_code", req.CouponCode),
+				zap.Error(err))
+			return c.JSON(http.StatusInternalServerError, echo.Map{
+				"error": "Failed to apply coupon",
+			})
+		}
+	}
+
+	// Seat-based plans are billed per workspace member
+	var seats int
+	workspaceID, _ := auth.GetWorkspaceID(c)
+	if h.seatService != nil {
+		seats, err = h.seatService.CheckoutQuantity(c.Request().Context(), workspaceID, req.PriceID, req.Seats)
+		if err != nil {
+			if errors.Is(err, domainErrors.ErrSeatWorkspaceRequired) {
+				return c.JSON(http.StatusBadRequest, echo.Map{
+					"error": err.Error(),
+					"code":  "WORKSPACE_REQUIRED",
+				})
+			}
+			h.logger.Error("Failed to determine seat quantity",
+				zap.String("price_id", req.PriceID),
+				zap.String("workspace_id", workspaceID),
+				zap.Error(err))
+			return c.JSON(http.StatusInternalServerError, echo.Map{
+				"error": "Failed to determine seat quantity",
+			})
+		}
+	}
+
 	// Create or retrieve customer
 	if customerID == "" {
 		// Create new customer
@@ -256,7 +366,7 @@ func (h *SubscriptionHandler) CreateSubscription(c echo.Context) error {
 				"universal_id": user.UniversalID,
 			},
 		}
-		customer, err := customer.New(customerParams)
+		customer, err := stripeClient.Customers.New(customerParams)
 		if err != nil {
 			h.logger.Error("Error creating customer", zap.Error(err))
 			return c.JSON(http.StatusInternalServerError, echo.Map{
@@ -319,8 +429,46 @@ func (h *SubscriptionHandler) CreateSubscription(c echo.Context) error {
 		},
 	}
 
+	if assessment != nil && assessment.Decision == model.RiskDecisionVerify {
+		subscriptionParams.PaymentSettings.PaymentMethodOptions = &stripe.SubscriptionPaymentSettingsPaymentMethodOptionsParams{
+			Card: &stripe.SubscriptionPaymentSettingsPaymentMethodOptionsCardParams{
+				RequestThreeDSecure: stripe.String(string(stripe.SubscriptionPaymentSettingsPaymentMethodOptionsCardRequestThreeDSecureAny)),
+			},
+		}
+		subscriptionParams.Metadata["risk_assessment_id"] = fmt.Sprint(assessment.ID)
+	}
+
+	if seats > 0 {
+		subscriptionParams.Items[0].Quantity = stripe.Int64(int64(seats))
+		subscriptionParams.Metadata["workspace_id"] = workspaceID
+	}
+
+	// Start with a free trial when the plan offers one and the user has not used a trial yet
+	var trialPlan *model.PaymentPlan
+	if h.trialService != nil {
+		trialPlan, err = h.trialService.CheckEligibility(c.Request().Context(), uuid.MustParse(user.UniversalID), req.PriceID)
+		if err != nil && !errors.Is(err, domainErrors.ErrTrialNotAvailable) && !errors.Is(err, domainErrors.ErrTrialAlreadyUsed) {
+			h.logger.Warn("Failed to check trial eligibility",
+				zap.String("price_id", req.PriceID),
+				zap.Error(err))
+		}
+	}
+	if trialPlan != nil {
+		subscriptionParams.TrialPeriodDays = stripe.Int64(int64(trialPlan.TrialPeriodDays))
+		subscriptionParams.TrialSettings = &stripe.SubscriptionTrialSettingsParams{
+			EndBehavior: &stripe.SubscriptionTrialSettingsEndBehaviorParams{
+				MissingPaymentMethod: stripe.String(string(stripe.SubscriptionTrialSettingsEndBehaviorMissingPaymentMethodCancel)),
+			},
+		}
+	}
+
+	if discount != nil {
+		subscriptionParams.Discounts = []*stripe.SubscriptionDiscountParams{discount}
+		subscriptionParams.Metadata["coupon_code"] = couponQuote.Code
+	}
+
 	// Create the subscription
-	sub, err := subscription.New(subscriptionParams)
+	sub, err := stripeClient.Subscriptions.New(subscriptionParams)
 	if err != nil {
 		h.logger.Error("Error creating subscription", zap.Error(err))
 		return c.JSON(http.StatusInternalServerError, echo.Map{
@@ -328,6 +476,29 @@ func (h *SubscriptionHandler) CreateSubscription(c echo.Context) error {
 		})
 	}
 
+	if trialPlan != nil && sub.TrialEnd > 0 {
+		email := user.Email
+		if email == "" {
+			email = req.Email
+		}
+		if _, err := h.trialService.RecordStripeTrial(c.Request().Context(), uuid.MustParse(user.UniversalID), email, trialPlan, customerID, sub.ID, time.Unix(sub.TrialEnd, 0)); err != nil {
+			h.logger.Error("Failed to record Stripe trial",
+				zap.String("subscription_id", sub.ID),
+				zap.Error(err))
+		}
+	}
+
+	// Stripe applies the discount itself; hold the redemption against the subscription until
+	// invoice.paid completes it, or the subscription expires unpaid and releases it
+	if couponQuote != nil {
+		if err := h.couponService.Reserve(c.Request().Context(), couponQuote, uuid.MustParse(user.UniversalID), sub.ID); err != nil {
+			h.logger.Warn("Failed to record coupon redemption for subscription",
+				zap.String("subscription_id", sub.ID),
+				zap.String("coupon_code", couponQuote.Code),
+				zap.Error(err))
+		}
+	}
+
 	var clientSecret string
 	var intentType string
 
@@ -356,13 +527,18 @@ func (h *SubscriptionHandler) CreateSubscription(c echo.Context) error {
 		zap.String("status", string(sub.Status)))
 
 	// Return the response with client secret for Payment Element
-	return c.JSON(http.StatusCreated, map[string]interface{}{
+	response := map[string]interface{}{
 		"subscriptionId": sub.ID,
 		"clientSecret":   clientSecret,
 		"intentType":     intentType,
 		"status":         string(sub.Status),
 		"customerId":     customerID,
-	})
+	}
+	if sub.TrialEnd > 0 {
+		response["trialEnd"] = time.Unix(sub.TrialEnd, 0)
+	}
+
+	return c.JSON(http.StatusCreated, response)
 }
 
 // CancelCurrentSubscription cancels the authenticated user's active subscription
@@ -380,12 +556,16 @@ func (h *SubscriptionHandler) CancelCurrentSubscription(c echo.Context) error {
 	)
 
 	// Cancel the user's active subscription
-	updatedSub, err := h.subscriptionService.CancelSubscriptionForUniversalID(c.Request().Context(), user.UniversalID)
+	updatedSub, err := h.subscriptionService.CancelSubscriptionForUniversalID(c.Request().Context(), tenant.GetServiceProvider(c), user.UniversalID)
 	if err != nil {
 		h.logger.Error("Failed to cancel subscription",
 			zap.String("universal_id", user.UniversalID),
 			zap.Error(err))
 
+		if status, body, ok := serviceProviderErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+
 		// Handle specific error cases
 		if errors.Is(err, domainErrors.ErrNoCustomerMapping) {
 			return c.JSON(http.StatusNotFound, echo.Map{
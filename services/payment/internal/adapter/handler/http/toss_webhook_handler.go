@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,9 +13,9 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/shopspring/decimal"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
-	tossProvider "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/provider/toss"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -22,28 +23,37 @@ import (
 
 // TossWebhookHandler handles TossPayments webhook events
 type TossWebhookHandler struct {
-	logger         *zap.Logger
-	paymentRepo    repository.PaymentRepository
-	creditService  *usecase.CreditService
-	tossProvider   *tossProvider.TossProvider
-	supabaseSecret string
+	logger             *zap.Logger
+	paymentRepo        repository.PaymentRepository
+	creditService      *usecase.CreditService
+	cashReceiptService *usecase.CashReceiptService
+	couponService      *usecase.CouponService
+	referralService    *usecase.ReferralService
+	serviceProviders   usecase.ServiceProviderResolver
+	supabaseSecret     string
 }
 
-// NewTossWebhookHandler creates a new TossWebhookHandler instance
+// NewTossWebhookHandler creates a new TossWebhookHandler instance. Events are processed with the
+// Toss keys of the service provider named in the route, or of the default provider.
 func NewTossWebhookHandler(
 	logger *zap.Logger,
 	paymentRepo repository.PaymentRepository,
 	creditService *usecase.CreditService,
-	secretKey string,
-	clientKey string,
+	cashReceiptService *usecase.CashReceiptService,
+	couponService *usecase.CouponService,
+	referralService *usecase.ReferralService,
+	serviceProviders usecase.ServiceProviderResolver,
 	supabaseSecret string,
 ) *TossWebhookHandler {
 	return &TossWebhookHandler{
-		logger:         logger,
-		paymentRepo:    paymentRepo,
-		creditService:  creditService,
-		tossProvider:   tossProvider.NewTossProvider(secretKey, clientKey, logger),
-		supabaseSecret: supabaseSecret,
+		logger:             logger,
+		paymentRepo:        paymentRepo,
+		creditService:      creditService,
+		cashReceiptService: cashReceiptService,
+		couponService:      couponService,
+		referralService:    referralService,
+		serviceProviders:   serviceProviders,
+		supabaseSecret:     supabaseSecret,
 	}
 }
 
@@ -185,8 +195,33 @@ func (h *TossWebhookHandler) Handle(c echo.Context) error {
 		}
 	}
 
+	serviceProvider, err := h.serviceProviders.Resolve(ctx, c.Param("provider"))
+	if err != nil {
+		h.logger.Error("Failed to resolve service provider for Toss webhook",
+			zap.String("service_provider", c.Param("provider")),
+			zap.Error(err))
+		if errors.Is(err, domainErrors.ErrServiceProviderNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{
+				"error": "Unknown service provider",
+				"code":  "SERVICE_PROVIDER_NOT_FOUND",
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to resolve service provider",
+			"code":  "SERVICE_PROVIDER_RESOLUTION_FAILED",
+		})
+	}
+	tossClient, err := usecase.TossClient(serviceProvider, h.logger)
+	if err != nil {
+		h.logger.Error("Toss webhook received for service provider without Toss credentials",
+			zap.String("service_provider", serviceProvider.Code),
+			zap.Error(err))
+		status, response, _ := serviceProviderErrorResponse(err)
+		return c.JSON(status, response)
+	}
+
 	// Process webhook event with provider
-	event, err := h.tossProvider.HandleWebhook(ctx, body, signature)
+	event, err := tossClient.HandleWebhook(ctx, body, signature)
 	if err != nil {
 		h.logger.Error("Failed to process webhook",
 			zap.Error(err))
@@ -282,6 +317,33 @@ func (h *TossWebhookHandler) handlePaymentCompleted(ctx context.Context, event *
 			zap.String("payment_key", event.PaymentKey))
 	}
 
+	if h.cashReceiptService != nil {
+		method, _ := event.Data["method"].(string)
+		if err := h.cashReceiptService.IssueForOrder(ctx, event.OrderID, method, event.Data); err != nil {
+			h.logger.Warn("Cash receipt issuance failed from Toss webhook",
+				zap.String("order_id", event.OrderID),
+				zap.Error(err))
+		}
+	}
+
+	if h.couponService != nil {
+		serviceProvider, _ := payment.Metadata["service_provider"].(string)
+		if err := h.couponService.Complete(ctx, event.OrderID, serviceProvider); err != nil {
+			h.logger.Warn("Coupon redemption failed from Toss webhook",
+				zap.String("order_id", event.OrderID),
+				zap.Error(err))
+		}
+	}
+
+	if h.referralService != nil {
+		serviceProvider, _ := payment.Metadata["service_provider"].(string)
+		if err := h.referralService.RewardFirstPayment(ctx, payment.UniversalID, serviceProvider, event.OrderID); err != nil {
+			h.logger.Warn("Referral reward failed from Toss webhook",
+				zap.String("order_id", event.OrderID),
+				zap.Error(err))
+		}
+	}
+
 	if h.creditService == nil {
 		h.logger.Warn("Credit service not configured; skipping credit allocation",
 			zap.String("order_id", event.OrderID))
@@ -382,6 +444,7 @@ func (h *TossWebhookHandler) handlePaymentCompleted(ctx context.Context, event *
 		event.OrderID,
 		"",
 		planID,
+		1,
 		serviceProvider,
 	)
 	if err != nil {
@@ -462,6 +525,9 @@ func (h *TossWebhookHandler) handlePaymentCancelled(ctx context.Context, event *
 	h.logger.Info("Payment marked as cancelled via webhook",
 		zap.String("order_id", event.OrderID))
 
+	h.cancelCashReceipt(ctx, event.OrderID, 0)
+	h.releaseCoupon(ctx, event.OrderID)
+
 	return nil
 }
 
@@ -486,11 +552,58 @@ func (h *TossWebhookHandler) handlePaymentRefunded(ctx context.Context, event *p
 	h.logger.Info("Payment marked as refunded via webhook",
 		zap.String("order_id", event.OrderID))
 
+	h.cancelCashReceipt(ctx, event.OrderID, latestCancelAmount(event.Data))
+
 	// TODO: Handle credit deduction if applicable
 
 	return nil
 }
 
+// cancelCashReceipt cancels the cash receipt tied to a cancelled or refunded payment
+func (h *TossWebhookHandler) cancelCashReceipt(ctx context.Context, orderID string, amount int64) {
+	if h.cashReceiptService == nil {
+		return
+	}
+
+	if err := h.cashReceiptService.CancelForOrder(ctx, orderID, amount); err != nil {
+		h.logger.Error("Failed to cancel cash receipt from Toss webhook",
+			zap.String("order_id", orderID),
+			zap.Int64("amount", amount),
+			zap.Error(err))
+	}
+}
+
+// releaseCoupon frees a coupon still reserved for an order that will not be paid
+func (h *TossWebhookHandler) releaseCoupon(ctx context.Context, orderID string) {
+	if h.couponService == nil {
+		return
+	}
+
+	if err := h.couponService.Release(ctx, orderID); err != nil {
+		h.logger.Error("Failed to release coupon from Toss webhook",
+			zap.String("order_id", orderID),
+			zap.Error(err))
+	}
+}
+
+// latestCancelAmount returns the amount of the most recent cancel entry in a Toss payment
+func latestCancelAmount(data map[string]interface{}) int64 {
+	cancels, ok := data["cancels"].([]interface{})
+	if !ok || len(cancels) == 0 {
+		return 0
+	}
+
+	latest, ok := cancels[len(cancels)-1].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+
+	if amount, ok := latest["cancelAmount"].(float64); ok {
+		return int64(amount)
+	}
+	return 0
+}
+
 // handlePaymentFailed handles failed payment events
 func (h *TossWebhookHandler) handlePaymentFailed(ctx context.Context, event *provider.WebhookEvent) error {
 	if event.OrderID == "" {
@@ -537,6 +650,8 @@ func (h *TossWebhookHandler) handlePaymentFailed(ctx context.Context, event *pro
 		zap.String("status", event.Status),
 		zap.String("failure_message", failureMessage))
 
+	h.releaseCoupon(ctx, event.OrderID)
+
 	return nil
 }
 
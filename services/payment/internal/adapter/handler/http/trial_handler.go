@@ -0,0 +1,188 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/auth"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// TrialHandler handles free trial endpoints
+type TrialHandler struct {
+	trialService *usecase.TrialService
+	logger       *zap.Logger
+}
+
+// NewTrialHandler creates a new TrialHandler instance
+func NewTrialHandler(trialService *usecase.TrialService, logger *zap.Logger) *TrialHandler {
+	return &TrialHandler{
+		trialService: trialService,
+		logger:       logger,
+	}
+}
+
+// StartTrialRequest represents the HTTP request for starting a trial with a registered card
+type StartTrialRequest struct {
+	PlanID          string `json:"plan_id" validate:"required"`
+	BillingKeyID    int64  `json:"billing_key_id" validate:"required"`
+	ServiceProvider string `json:"service_provider,omitempty"`
+}
+
+// StartTrial handles POST /trials endpoint
+func (h *TrialHandler) StartTrial(c echo.Context) error {
+	var req StartTrialRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid request format",
+			"code":  "INVALID_REQUEST",
+		})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error":   "Validation failed",
+			"code":    "VALIDATION_FAILED",
+			"details": err.Error(),
+		})
+	}
+
+	user, err := auth.RequireAuth(c)
+	if err != nil {
+		return err
+	}
+
+	universalID, err := uuid.Parse(user.UniversalID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid user ID",
+			"code":  "INVALID_USER_ID",
+		})
+	}
+
+	serviceProvider, status, body := requestServiceProvider(c, req.ServiceProvider)
+	if serviceProvider == nil {
+		return c.JSON(status, body)
+	}
+
+	trial, err := h.trialService.StartTossTrial(c.Request().Context(), &usecase.StartTrialRequest{
+		UniversalID:     universalID,
+		Email:           user.Email,
+		PlanID:          req.PlanID,
+		BillingKeyID:    req.BillingKeyID,
+		ServiceProvider: serviceProvider.Code,
+	})
+	if err != nil {
+		if status, body, ok := trialErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to start trial",
+			zap.String("universal_id", user.UniversalID),
+			zap.String("plan_id", req.PlanID),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to start trial",
+			"code":  "TRIAL_START_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, trial)
+}
+
+// GetCurrentTrial handles GET /trials/current endpoint
+func (h *TrialHandler) GetCurrentTrial(c echo.Context) error {
+	universalID, err := h.universalID(c)
+	if err != nil {
+		return err
+	}
+
+	trial, err := h.trialService.GetCurrentTrial(c.Request().Context(), universalID)
+	if err != nil {
+		if status, body, ok := trialErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to get current trial",
+			zap.String("universal_id", universalID.String()),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to get trial",
+			"code":  "TRIAL_FETCH_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusOK, trial)
+}
+
+// CancelCurrentTrial handles DELETE /trials/current endpoint
+func (h *TrialHandler) CancelCurrentTrial(c echo.Context) error {
+	universalID, err := h.universalID(c)
+	if err != nil {
+		return err
+	}
+
+	trial, err := h.trialService.CancelTossTrial(c.Request().Context(), universalID)
+	if err != nil {
+		if status, body, ok := trialErrorResponse(err); ok {
+			return c.JSON(status, body)
+		}
+		h.logger.Error("Failed to cancel trial",
+			zap.String("universal_id", universalID.String()),
+			zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to cancel trial",
+			"code":  "TRIAL_CANCEL_FAILED",
+		})
+	}
+
+	return c.JSON(http.StatusOK, trial)
+}
+
+// universalID reads the authenticated user's universal ID, writing an error response on failure
+func (h *TrialHandler) universalID(c echo.Context) (uuid.UUID, error) {
+	universalIDStr, err := auth.GetUniversalID(c)
+	if err != nil {
+		return uuid.Nil, c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Failed to get user information",
+			"code":  "AUTH_ERROR",
+		})
+	}
+
+	universalID, err := uuid.Parse(universalIDStr)
+	if err != nil {
+		return uuid.Nil, c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid user ID",
+			"code":  "INVALID_USER_ID",
+		})
+	}
+
+	return universalID, nil
+}
+
+// trialErrorResponse maps trial errors to client-facing responses
+func trialErrorResponse(err error) (int, echo.Map, bool) {
+	codes := []struct {
+		err    error
+		code   string
+		status int
+	}{
+		{domainErrors.ErrTrialNotAvailable, "TRIAL_NOT_AVAILABLE", http.StatusBadRequest},
+		{domainErrors.ErrTrialAlreadyUsed, "TRIAL_ALREADY_USED", http.StatusConflict},
+		{domainErrors.ErrTrialCardAlreadyUsed, "TRIAL_CARD_ALREADY_USED", http.StatusConflict},
+		{domainErrors.ErrTrialNotFound, "TRIAL_NOT_FOUND", http.StatusNotFound},
+	}
+
+	for _, candidate := range codes {
+		if errors.Is(err, candidate.err) {
+			return candidate.status, echo.Map{
+				"error": candidate.err.Error(),
+				"code":  candidate.code,
+			}, true
+		}
+	}
+
+	return 0, nil, false
+}
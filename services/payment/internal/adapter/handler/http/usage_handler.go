@@ -0,0 +1,154 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/auth"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// UsageHandler handles credit usage report endpoints
+type UsageHandler struct {
+	usageService *usecase.UsageService
+	logger       *zap.Logger
+}
+
+// NewUsageHandler creates a new UsageHandler instance
+func NewUsageHandler(usageService *usecase.UsageService, logger *zap.Logger) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+		logger:       logger,
+	}
+}
+
+// GetUsage handles GET /usage endpoint.
+// Reports the credit usage of the authenticated user, or of the workspace when X-Workspace-Id is set.
+// Query params: granularity (day|week|month), from and to (YYYY-MM-DD, KST), feature, service_provider,
+// user_id (workspace member) and format=csv.
+func (h *UsageHandler) GetUsage(c echo.Context) error {
+	universalIDStr, err := auth.GetUniversalID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{
+			"error": "Failed to get user information",
+			"code":  "AUTH_ERROR",
+		})
+	}
+
+	universalID, err := uuid.Parse(universalIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": "Invalid user ID",
+			"code":  "INVALID_USER_ID",
+		})
+	}
+
+	query, err := parseUsageQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": err.Error(),
+			"code":  "INVALID_USAGE_QUERY",
+		})
+	}
+	query.UniversalID = &universalID
+
+	return h.respond(c, query)
+}
+
+// GetUsageReport handles GET /admin/usage endpoint.
+// Accepts the same parameters as GetUsage plus an optional universal_id; without it usage of
+// every user and workspace is reported.
+func (h *UsageHandler) GetUsageReport(c echo.Context) error {
+	query, err := parseUsageQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"error": err.Error(),
+			"code":  "INVALID_USAGE_QUERY",
+		})
+	}
+
+	if value := c.QueryParam("universal_id"); value != "" {
+		universalID, err := uuid.Parse(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": "universal_id must be a valid UUID",
+				"code":  "INVALID_USAGE_QUERY",
+			})
+		}
+		query.UniversalID = &universalID
+	}
+
+	return h.respond(c, query)
+}
+
+func (h *UsageHandler) respond(c echo.Context, query usecase.UsageQuery) error {
+	report, err := h.usageService.GetUsageReport(c.Request().Context(), query)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidUsageGranularity) || errors.Is(err, domainErrors.ErrInvalidUsageRange) {
+			return c.JSON(http.StatusBadRequest, echo.Map{
+				"error": err.Error(),
+				"code":  "INVALID_USAGE_QUERY",
+			})
+		}
+		h.logger.Error("Failed to get usage report", zap.Error(err))
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"error": "Failed to get usage",
+			"code":  "USAGE_FETCH_FAILED",
+		})
+	}
+
+	if c.QueryParam("format") == "csv" {
+		filename := fmt.Sprintf("usage-%s-%s-%s.csv", report.Granularity,
+			report.From.Format("20060102"), report.To.AddDate(0, 0, -1).Format("20060102"))
+		c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+		c.Response().WriteHeader(http.StatusOK)
+		return usecase.WriteUsageCSV(c.Response(), report)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// parseUsageQuery reads the filters shared by the usage endpoints
+func parseUsageQuery(c echo.Context) (usecase.UsageQuery, error) {
+	query := usecase.UsageQuery{
+		Granularity:     model.UsageGranularity(c.QueryParam("granularity")),
+		FeatureName:     c.QueryParam("feature"),
+		ServiceProvider: c.QueryParam("service_provider"),
+	}
+
+	for _, param := range []struct {
+		name   string
+		target *time.Time
+	}{
+		{"from", &query.From},
+		{"to", &query.To},
+	} {
+		value := c.QueryParam(param.name)
+		if value == "" {
+			continue
+		}
+		date, err := time.ParseInLocation("2006-01-02", value, model.UsageLocation)
+		if err != nil {
+			return query, fmt.Errorf("%s must be a date in YYYY-MM-DD format", param.name)
+		}
+		*param.target = date
+	}
+
+	if value := c.QueryParam("user_id"); value != "" {
+		actorID, err := uuid.Parse(value)
+		if err != nil {
+			return query, fmt.Errorf("user_id must be a valid UUID")
+		}
+		query.ActorID = &actorID
+	}
+
+	return query, nil
+}
@@ -1,7 +1,9 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,9 +13,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stripe/stripe-go/v79"
+	"github.com/stripe/stripe-go/v79/client"
 	"github.com/stripe/stripe-go/v79/webhook"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/adapter/repository"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
 	domainRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
 	"go.uber.org/zap"
@@ -21,14 +26,17 @@ import (
 
 type WebhookHandler struct {
 	logger              *zap.Logger
-	webhookSecret       string
+	registry            *usecase.ServiceProviderRegistry
 	webhookRepo         repository.WebhookRepository
 	subscriptionRepo    domainRepo.SubscriptionRepository
 	paymentRepo         domainRepo.PaymentRepository
 	customerMappingRepo domainRepo.CustomerMappingRepository
 	creditService       *usecase.CreditService
 	planSyncService     *usecase.PlanSyncService
-	serviceProvider     string
+	trialService        *usecase.TrialService
+	referralService     *usecase.ReferralService
+	couponService       *usecase.CouponService
+	entitlementService  *usecase.EntitlementService
 	subscriptions       map[string]*entity.Subscription
 	payments            []PaymentData
 	mu                  sync.RWMutex
@@ -43,20 +51,25 @@ type PaymentData struct {
 	CreatedAt      time.Time
 }
 
-func NewWebhookHandler(logger *zap.Logger, webhookSecret string, webhookRepo repository.WebhookRepository, subscriptionRepo domainRepo.SubscriptionRepository, paymentRepo domainRepo.PaymentRepository, customerMappingRepo domainRepo.CustomerMappingRepository, creditRepo domainRepo.CreditRepository, planRepo repository.PlanRepository, serviceProvider string) *WebhookHandler {
+// NewWebhookHandler creates a Stripe webhook handler. Events are verified with the webhook secret of
+// the service provider named in the route, or of the default provider.
+func NewWebhookHandler(logger *zap.Logger, registry *usecase.ServiceProviderRegistry, webhookRepo repository.WebhookRepository, subscriptionRepo domainRepo.SubscriptionRepository, paymentRepo domainRepo.PaymentRepository, customerMappingRepo domainRepo.CustomerMappingRepository, creditRepo domainRepo.CreditRepository, planRepo repository.PlanRepository, trialService *usecase.TrialService, referralService *usecase.ReferralService, couponService *usecase.CouponService, entitlementService *usecase.EntitlementService) *WebhookHandler {
 	planSyncService := usecase.NewPlanSyncService(planRepo, logger)
-	creditService := usecase.NewCreditService(creditRepo, subscriptionRepo, planRepo, logger, serviceProvider)
+	creditService := usecase.NewCreditService(creditRepo, subscriptionRepo, planRepo, logger, registry.DefaultCode(), entitlementService)
 
 	return &WebhookHandler{
 		logger:              logger,
-		webhookSecret:       webhookSecret,
+		registry:            registry,
 		webhookRepo:         webhookRepo,
 		subscriptionRepo:    subscriptionRepo,
 		paymentRepo:         paymentRepo,
 		customerMappingRepo: customerMappingRepo,
 		creditService:       creditService,
 		planSyncService:     planSyncService,
-		serviceProvider:     serviceProvider,
+		trialService:        trialService,
+		referralService:     referralService,
+		couponService:       couponService,
+		entitlementService:  entitlementService,
 		subscriptions:       make(map[string]*entity.Subscription),
 		payments:            make([]PaymentData, 0),
 	}
@@ -80,12 +93,23 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "Error reading request body"})
 	}
 
+	serviceProvider, err := h.registry.Resolve(c.Request().Context(), c.Param("provider"))
+	if err != nil {
+		h.logger.Error("Failed to resolve service provider for webhook",
+			zap.String("service_provider", c.Param("provider")),
+			zap.Error(err))
+		if errors.Is(err, domainErrors.ErrServiceProviderNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"error": "Unknown service provider"})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "Failed to resolve service provider"})
+	}
+
 	sig := c.Request().Header.Get("Stripe-Signature")
 
 	event, err := webhook.ConstructEventWithOptions(
 		body,
 		sig,
-		h.webhookSecret,
+		serviceProvider.Credentials.StripeWebhookSecret,
 		webhook.ConstructEventOptions{
 			IgnoreAPIVersionMismatch: true,
 		},
@@ -104,6 +128,7 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 	h.logger.Info("Webhook Event Received",
 		zap.String("type", string(event.Type)),
 		zap.String("id", event.ID),
+		zap.String("service_provider", serviceProvider.Code),
 		zap.Time("created", time.Unix(event.Created, 0)),
 	)
 
@@ -165,6 +190,17 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 			zap.String("mode", paymentMode),
 		)
 
+		// Card collected for a trial subscription; check it against previous trials
+		if h.trialService != nil && setupIntent.PaymentMethod != nil {
+			if stripeClient, err := usecase.StripeClient(serviceProvider); err != nil {
+				h.logger.Error("Cannot check trial card without Stripe credentials",
+					zap.String("service_provider", serviceProvider.Code),
+					zap.Error(err))
+			} else {
+				h.attachTrialCard(c.Request().Context(), stripeClient, customerID, setupIntent.PaymentMethod.ID)
+			}
+		}
+
 		if paymentMode == "payment" {
 			// 일회성 결제일 때만 여기서 CustomerMapping 저장
 			if universalID != "" && isValidUUID(universalID) && h.customerMappingRepo != nil {
@@ -256,13 +292,33 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 			zap.Time("period_end", time.Unix(currentPeriodEnd, 0)),
 		)
 
+		if h.trialService != nil && subscriptionID != "" {
+			if err := h.trialService.SyncStripeSubscriptionStatus(c.Request().Context(), subscriptionID, status); err != nil {
+				h.logger.Error("Failed to sync trial with subscription status",
+					zap.String("subscription_id", subscriptionID),
+					zap.Error(err))
+			}
+		}
+
+		// Subscriptions whose first payment never completed free their reserved coupon
+		if h.couponService != nil && subscriptionID != "" && status == string(stripe.SubscriptionStatusIncompleteExpired) {
+			if err := h.couponService.Release(c.Request().Context(), subscriptionID); err != nil {
+				h.logger.Warn("Failed to release coupon for expired subscription",
+					zap.String("subscription_id", subscriptionID),
+					zap.Error(err))
+			}
+		}
+
 		if customerID != "" {
 			// Extract user ID from metadata
 			var universalID string
 			var customerEmail string
 
+			var workspaceID string
+
 			if metadata, ok := rawData["metadata"].(map[string]interface{}); ok {
 				universalID, _ = metadata["user_id"].(string)
+				workspaceID, _ = metadata["workspace_id"].(string)
 				h.logger.Info("Extracted user ID from subscription metadata",
 					zap.String("universal_id", universalID),
 					zap.String("subscription_id", subscriptionID))
@@ -309,6 +365,7 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 				Status:           status,
 				CurrentPeriodEnd: time.Unix(currentPeriodEnd, 0),
 				PlanID:           &productID,
+				WorkspaceID:      workspaceID,
 				CreatedAt:        time.Now(),
 				UpdatedAt:        time.Now(),
 			}
@@ -360,7 +417,16 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 			if items, ok := rawData["items"].(map[string]interface{}); ok {
 				if data, ok := items["data"].([]interface{}); ok && len(data) > 0 {
 					if item, ok := data[0].(map[string]interface{}); ok {
+						// Extract seat quantity
+						if quantity, ok := item["quantity"].(float64); ok {
+							subscription.Quantity = int(quantity)
+						}
+
 						if price, ok := item["price"].(map[string]interface{}); ok {
+							if priceID, ok := price["id"].(string); ok {
+								subscription.PriceID = priceID
+							}
+
 							// Extract product name
 							if product, ok := price["product"].(string); ok {
 								subscription.ProductName = product
@@ -424,6 +490,7 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 						zap.String("subscription_id", subscriptionID),
 						zap.Time("period_end", time.Unix(currentPeriodEnd, 0)),
 					)
+					h.invalidateEntitlements(ctx, subscriptionID)
 				}
 			}
 
@@ -462,6 +529,24 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 				h.logger.Info("Subscription successfully canceled in database",
 					zap.String("subscription_id", subscriptionID),
 					zap.String("customer_id", customerID))
+				h.invalidateEntitlements(ctx, subscriptionID)
+			}
+		}
+
+		// Release is a no-op once the redemption has been completed by a paid invoice
+		if h.couponService != nil && subscriptionID != "" {
+			if err := h.couponService.Release(c.Request().Context(), subscriptionID); err != nil {
+				h.logger.Warn("Failed to release coupon for deleted subscription",
+					zap.String("subscription_id", subscriptionID),
+					zap.Error(err))
+			}
+		}
+
+		if h.trialService != nil && subscriptionID != "" {
+			if err := h.trialService.SyncStripeSubscriptionStatus(c.Request().Context(), subscriptionID, "canceled"); err != nil {
+				h.logger.Error("Failed to close trial for deleted subscription",
+					zap.String("subscription_id", subscriptionID),
+					zap.Error(err))
 			}
 		}
 
@@ -475,6 +560,27 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 			h.mu.Unlock()
 		}
 
+	case stripe.EventTypeCustomerSubscriptionTrialWillEnd:
+		var rawData map[string]interface{}
+		if err := json.Unmarshal(event.Data.Raw, &rawData); err != nil {
+			h.logger.Error("Error parsing subscription trial data", zap.Error(err))
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "Error parsing webhook"})
+		}
+
+		subscriptionID, _ := rawData["id"].(string)
+
+		h.logger.Info("SUBSCRIPTION TRIAL WILL END",
+			zap.String("subscription_id", subscriptionID),
+		)
+
+		if h.trialService != nil && subscriptionID != "" {
+			if err := h.trialService.HandleStripeTrialWillEnd(c.Request().Context(), subscriptionID); err != nil {
+				h.logger.Error("Failed to send trial_will_end notification",
+					zap.String("subscription_id", subscriptionID),
+					zap.Error(err))
+			}
+		}
+
 	case stripe.EventTypeInvoicePaid:
 		var invoice stripe.Invoice
 		if err := json.Unmarshal(event.Data.Raw, &invoice); err != nil {
@@ -709,9 +815,11 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 				Currency:      string(invoice.Currency),
 				Status:        entity.PaymentStatusCompleted,
 				Method:        entity.PaymentMethodCard,
+				Provider:      string(provider.ProviderTypeStripe),
 				Metadata: map[string]interface{}{
 					"provider_invoice_id":  invoice.ID,
 					"provider_customer_id": invoice.Customer.ID,
+					"service_provider":     serviceProvider.Code,
 				},
 			}
 
@@ -741,6 +849,26 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 				zap.String("universal_id", universalID),
 				zap.Float64("amount", paymentEntity.Amount))
 
+			// Zero-amount invoices (e.g. trial starts) are not a first payment
+			if h.referralService != nil && invoice.AmountPaid > 0 {
+				if err := h.referralService.RewardFirstPayment(c.Request().Context(), universalID, serviceProvider.Code, invoice.ID); err != nil {
+					h.logger.Warn("Referral reward failed from Stripe webhook",
+						zap.String("invoice_id", invoice.ID),
+						zap.Error(err))
+				}
+			}
+
+			// The coupon reserved at subscription creation counts as used once its invoice is paid;
+			// zero-amount trial invoices without a discount leave it reserved
+			if h.couponService != nil && extractedSubscriptionID != "" && (invoice.AmountPaid > 0 || len(invoice.Discounts) > 0) {
+				if err := h.couponService.Complete(c.Request().Context(), extractedSubscriptionID, serviceProvider.Code); err != nil {
+					h.logger.Warn("Failed to complete coupon redemption for subscription",
+						zap.String("invoice_id", invoice.ID),
+						zap.String("subscription_id", extractedSubscriptionID),
+						zap.Error(err))
+				}
+			}
+
 			// CREDIT ALLOCATION ANALYSIS - Check preconditions
 			h.logger.Info("Credit allocation precondition check",
 				zap.Bool("has_credit_service", h.creditService != nil),
@@ -753,8 +881,17 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 				}()),
 				zap.String("universal_id", universalID))
 
-			// Allocate credits for the payment
-			if h.creditService != nil && invoice.Lines != nil && len(invoice.Lines.Data) > 0 {
+			// Allocate credits for the payment. Seat-quantity prorations are skipped because SeatService
+			// grants credits for added seats itself; plan changes are credited for the new price's line.
+			creditLineIndex, allocateCredits := 0, true
+			if invoice.BillingReason == stripe.InvoiceBillingReasonSubscriptionUpdate {
+				creditLineIndex, allocateCredits = usecase.SubscriptionUpdateCreditLine(rawInvoiceLines(event.Data.Raw))
+			}
+			if !allocateCredits {
+				h.logger.Info("Skipping credit allocation for seat proration invoice",
+					zap.String("invoice_id", invoice.ID),
+					zap.String("universal_id", universalID))
+			} else if h.creditService != nil && invoice.Lines != nil && len(invoice.Lines.Data) > 0 {
 				h.logger.Info("Starting credit allocation process",
 					zap.String("invoice_id", invoice.ID),
 					zap.String("universal_id", universalID))
@@ -771,10 +908,15 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 					h.logger.Info("Analyzing line items for credit metadata")
 					if lines, ok := rawInvoice["lines"].(map[string]interface{}); ok {
 						h.logger.Info("Found lines object in raw invoice data")
-						if data, ok := lines["data"].([]interface{}); ok && len(data) > 0 {
+						if data, ok := lines["data"].([]interface{}); ok && len(data) > creditLineIndex {
 							h.logger.Info("Found line items data", zap.Int("count", len(data)))
-							if lineItem, ok := data[0].(map[string]interface{}); ok {
-								h.logger.Debug("Processing first line item", zap.Any("line_item", lineItem))
+							if lineItem, ok := data[creditLineIndex].(map[string]interface{}); ok {
+								h.logger.Debug("Processing line item", zap.Int("index", creditLineIndex), zap.Any("line_item", lineItem))
+								// Seat-based plans are billed with the seat count as quantity
+								seats := 1
+								if quantity, ok := lineItem["quantity"].(float64); ok && quantity > 0 {
+									seats = int(quantity)
+								}
 								// Get price information - navigate through pricing -> price_details -> price
 								var stripePriceID string
 								if pricing, ok := lineItem["pricing"].(map[string]interface{}); ok {
@@ -827,6 +969,10 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 													h.logger.Info("Successfully parsed credits from metadata", zap.Int("credits", credits))
 												}
 
+												if seatBased, ok := metadata["seat_based"].(string); ok && seatBased == "true" {
+													credits *= seats
+												}
+
 												productName := "Subscription"
 												if name, ok := product["name"].(string); ok {
 													productName = name
@@ -846,6 +992,7 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 													invoice.ID,
 													credits,
 													productName,
+													serviceProvider.Code,
 												)
 												if err != nil {
 													h.logger.Error("CREDIT ALLOCATION FROM METADATA FAILED",
@@ -907,7 +1054,8 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 												invoice.ID,
 												subscriptionID,
 												stripePriceID,
-												"",
+												seats,
+												serviceProvider.Code,
 											)
 											if err != nil {
 												h.logger.Error("CREDIT ALLOCATION FROM DATABASE FAILED",
@@ -936,7 +1084,7 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 									h.logger.Error("Cannot allocate credits: no price ID found")
 								}
 							} else {
-								h.logger.Warn("First line item is not a map object")
+								h.logger.Warn("Line item is not a map object")
 							}
 						} else {
 							h.logger.Warn("No line items data found or empty")
@@ -1004,7 +1152,7 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 
 	case stripe.EventTypeProductCreated, stripe.EventTypeProductUpdated, stripe.EventTypeProductDeleted:
 		if h.planSyncService != nil {
-			if err := h.planSyncService.SyncProductEvent(c.Request().Context(), string(event.Type), event.Data.Raw); err != nil {
+			if err := h.planSyncService.SyncProductEvent(c.Request().Context(), serviceProvider, string(event.Type), event.Data.Raw); err != nil {
 				h.logger.Error("Failed to sync product event",
 					zap.String("event_type", string(event.Type)),
 					zap.Error(err))
@@ -1016,7 +1164,7 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 
 	case stripe.EventTypePriceCreated, stripe.EventTypePriceUpdated, stripe.EventTypePriceDeleted:
 		if h.planSyncService != nil {
-			if err := h.planSyncService.SyncPriceEvent(c.Request().Context(), string(event.Type), event.Data.Raw); err != nil {
+			if err := h.planSyncService.SyncPriceEvent(c.Request().Context(), serviceProvider, string(event.Type), event.Data.Raw); err != nil {
 				h.logger.Error("Failed to sync price event",
 					zap.String("event_type", string(event.Type)),
 					zap.Error(err))
@@ -1043,6 +1191,42 @@ func (h *WebhookHandler) HandleWebhook(c echo.Context) error {
 	return c.JSON(http.StatusOK, echo.Map{"received": true})
 }
 
+// attachTrialCard records the fingerprint of a card collected for a Stripe trial.
+// Cards that already backed another trial end the trial immediately so the first invoice is charged.
+func (h *WebhookHandler) attachTrialCard(ctx context.Context, stripeClient *client.API, customerID string, paymentMethodID string) {
+	if paymentMethodID == "" {
+		return
+	}
+
+	pm, err := stripeClient.PaymentMethods.Get(paymentMethodID, nil)
+	if err != nil {
+		h.logger.Error("Failed to retrieve payment method for trial",
+			zap.String("payment_method_id", paymentMethodID),
+			zap.Error(err))
+		return
+	}
+	if pm.Card == nil || pm.Card.Fingerprint == "" {
+		return
+	}
+
+	trial, err := h.trialService.AttachStripeCard(ctx, customerID, pm.Card.Fingerprint)
+	if errors.Is(err, domainErrors.ErrTrialCardAlreadyUsed) && trial != nil && trial.ProviderSubscriptionID != nil {
+		if _, err := stripeClient.Subscriptions.Update(*trial.ProviderSubscriptionID, &stripe.SubscriptionParams{
+			TrialEndNow: stripe.Bool(true),
+		}); err != nil {
+			h.logger.Error("Failed to end trial for reused card",
+				zap.String("subscription_id", *trial.ProviderSubscriptionID),
+				zap.Error(err))
+		}
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to attach card to trial",
+			zap.String("customer_id", customerID),
+			zap.Error(err))
+	}
+}
+
 // isValidUUID checks if a string is a valid UUID
 func isValidUUID(s string) bool {
 	_, err := uuid.Parse(s)
@@ -1050,6 +1234,62 @@ func isValidUUID(s string) bool {
 }
 
 // getMapKeys returns the keys of a map as a slice for logging
+// invalidateEntitlements drops the cached entitlements of the owner of a stored subscription
+func (h *WebhookHandler) invalidateEntitlements(ctx context.Context, subscriptionID string) {
+	if h.entitlementService == nil {
+		return
+	}
+	subscription, err := h.subscriptionRepo.GetByID(ctx, subscriptionID)
+	if err != nil || subscription == nil {
+		h.logger.Warn("Failed to resolve subscription owner for entitlement invalidation",
+			zap.String("subscription_id", subscriptionID),
+			zap.Error(err))
+		return
+	}
+	h.entitlementService.Invalidate(subscription.UniversalID)
+}
+
+// rawInvoiceLines extracts the price, quantity and amount of each line of a raw invoice payload
+func rawInvoiceLines(raw []byte) []usecase.StripeInvoiceLine {
+	var invoice struct {
+		Lines struct {
+			Data []struct {
+				Amount   int64           `json:"amount"`
+				Quantity int64           `json:"quantity"`
+				Price    json.RawMessage `json:"price"`
+				Pricing  struct {
+					PriceDetails struct {
+						Price string `json:"price"`
+					} `json:"price_details"`
+				} `json:"pricing"`
+			} `json:"data"`
+		} `json:"lines"`
+	}
+	if err := json.Unmarshal(raw, &invoice); err != nil {
+		return nil
+	}
+
+	lines := make([]usecase.StripeInvoiceLine, 0, len(invoice.Lines.Data))
+	for _, item := range invoice.Lines.Data {
+		priceID := item.Pricing.PriceDetails.Price
+		if priceID == "" && len(item.Price) > 0 {
+			// Older API versions return the price object on the line itself
+			var price struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(item.Price, &price); err == nil {
+				priceID = price.ID
+			}
+		}
+		lines = append(lines, usecase.StripeInvoiceLine{
+			PriceID:  priceID,
+			Quantity: item.Quantity,
+			Amount:   item.Amount,
+		})
+	}
+	return lines
+}
+
 func getMapKeys(m map[string]interface{}) []string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+)
+
+// analyticsRepository implements the AnalyticsRepository interface
+type analyticsRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewAnalyticsRepository creates a new analytics repository
+func NewAnalyticsRepository(db *gorm.DB, logger *zap.Logger) repository.AnalyticsRepository {
+	return &analyticsRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// subscriptionEndedAtSQL is when a subscription stopped being active. canceled_at is also set when a
+// cancellation is only scheduled for the end of the period, so active subscriptions have not ended
+// whatever their canceled_at. Inactive ones ended when they were canceled, or at the end of the period
+// they were deactivated in when that came later; their last update bounds the deactivation.
+const subscriptionEndedAtSQL = "CASE WHEN status <> 'active' THEN GREATEST(canceled_at, LEAST(current_period_end, updated_at)) END"
+
+// cohortActivitySQL counts paying customers per cohort (month of first completed payment) and month, in KST
+const cohortActivitySQL = `
+WITH activity AS (
+    SELECT universal_id, date_trunc('month', created_at AT TIME ZONE 'Asia/Seoul')::date AS month
+    FROM payments
+    WHERE status = ? AND amount_cents > 0
+    GROUP BY 1, 2
+), cohorts AS (
+    SELECT universal_id, MIN(month) AS cohort
+    FROM activity
+    GROUP BY 1
+)
+SELECT cohorts.cohort AS cohort, activity.month AS month, COUNT(*) AS customers
+FROM activity
+JOIN cohorts ON cohorts.universal_id = activity.universal_id
+WHERE cohorts.cohort >= ?::date AND cohorts.cohort < ?::date
+GROUP BY 1, 2
+ORDER BY 1, 2`
+
+// ListSubscriptionsActiveAt retrieves the subscriptions that were active at the given time
+func (r *analyticsRepository) ListSubscriptionsActiveAt(ctx context.Context, at time.Time) ([]*model.Subscription, error) {
+	var subscriptions []*model.Subscription
+
+	err := r.db.WithContext(ctx).
+		Where("created_at < ?", at).
+		Where("("+subscriptionEndedAtSQL+" IS NULL OR "+subscriptionEndedAtSQL+" >= ?)", at).
+		Order("id ASC").
+		Find(&subscriptions).Error
+	if err != nil {
+		r.logger.Error("Failed to list active subscriptions",
+			zap.Time("at", at),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to list active subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// HasSnapshot reports whether a snapshot was taken for the date
+func (r *analyticsRepository) HasSnapshot(ctx context.Context, date time.Time) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).
+		Model(&model.MRRSnapshot{}).
+		Where("snapshot_date = ?::date", snapshotDate(date)).
+		Limit(1).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error("Failed to check revenue snapshot",
+			zap.String("date", snapshotDate(date)),
+			zap.Error(err))
+		return false, fmt.Errorf("failed to check revenue snapshot: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// ReplaceSnapshot stores the rows as the snapshot of the date, replacing any previous one
+func (r *analyticsRepository) ReplaceSnapshot(ctx context.Context, date time.Time, rows []*model.MRRSnapshot) error {
+	// DATE columns take the calendar date of the parameter in UTC, so pass the KST date as UTC midnight
+	day, _ := time.Parse("2006-01-02", snapshotDate(date))
+	for _, row := range rows {
+		row.SnapshotDate = day
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("snapshot_date = ?::date", snapshotDate(date)).Delete(&model.MRRSnapshot{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(rows, 500).Error
+	})
+	if err != nil {
+		r.logger.Error("Failed to store revenue snapshot",
+			zap.String("date", snapshotDate(date)),
+			zap.Int("rows", len(rows)),
+			zap.Error(err))
+		return fmt.Errorf("failed to store revenue snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// LatestSnapshotDate returns the date of the latest snapshot on or before the date
+func (r *analyticsRepository) LatestSnapshotDate(ctx context.Context, onOrBefore time.Time) (*time.Time, error) {
+	var dates []time.Time
+
+	err := r.db.WithContext(ctx).
+		Model(&model.MRRSnapshot{}).
+		Where("snapshot_date <= ?::date", snapshotDate(onOrBefore)).
+		Order("snapshot_date DESC").
+		Limit(1).
+		Pluck("snapshot_date", &dates).Error
+	if err != nil {
+		r.logger.Error("Failed to find latest revenue snapshot",
+			zap.String("on_or_before", snapshotDate(onOrBefore)),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to find latest revenue snapshot: %w", err)
+	}
+
+	if len(dates) == 0 {
+		return nil, nil
+	}
+	date := time.Date(dates[0].Year(), dates[0].Month(), dates[0].Day(), 0, 0, 0, 0, model.UsageLocation)
+	return &date, nil
+}
+
+// ListSnapshot retrieves the rows of the snapshot of the date
+func (r *analyticsRepository) ListSnapshot(ctx context.Context, date time.Time) ([]*model.MRRSnapshot, error) {
+	var rows []*model.MRRSnapshot
+
+	err := r.db.WithContext(ctx).
+		Where("snapshot_date = ?::date", snapshotDate(date)).
+		Order("subscription_id ASC").
+		Find(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to list revenue snapshot",
+			zap.String("date", snapshotDate(date)),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to list revenue snapshot: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ListCohortActivity counts paying customers per cohort and month for cohorts starting in [from, to)
+func (r *analyticsRepository) ListCohortActivity(ctx context.Context, from, to time.Time) ([]dto.CohortActivity, error) {
+	var rows []struct {
+		Cohort    time.Time
+		Month     time.Time
+		Customers int64
+	}
+
+	err := r.db.WithContext(ctx).
+		Raw(cohortActivitySQL, string(entity.PaymentStatusCompleted), snapshotDate(from), snapshotDate(to)).
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to list cohort activity",
+			zap.Time("from", from),
+			zap.Time("to", to),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to list cohort activity: %w", err)
+	}
+
+	activity := make([]dto.CohortActivity, len(rows))
+	for i, row := range rows {
+		activity[i] = dto.CohortActivity{
+			Cohort:    time.Date(row.Cohort.Year(), row.Cohort.Month(), 1, 0, 0, 0, 0, model.UsageLocation),
+			Month:     time.Date(row.Month.Year(), row.Month.Month(), 1, 0, 0, 0, 0, model.UsageLocation),
+			Customers: row.Customers,
+		}
+	}
+
+	return activity, nil
+}
+
+// snapshotDate formats a date for comparison with DATE columns, which hold calendar dates in KST
+func snapshotDate(t time.Time) string {
+	return t.In(model.UsageLocation).Format("2006-01-02")
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	domainRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type couponRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewCouponRepository creates a new coupon repository instance
+func NewCouponRepository(db *gorm.DB, logger *zap.Logger) domainRepo.CouponRepository {
+	return &couponRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *couponRepository) Create(ctx context.Context, coupon *model.Coupon) error {
+	coupon.Code = strings.ToUpper(strings.TrimSpace(coupon.Code))
+	if err := r.db.WithContext(ctx).Create(coupon).Error; err != nil {
+		r.logger.Error("Failed to create coupon",
+			zap.String("code", coupon.Code),
+			zap.Error(err))
+		return fmt.Errorf("failed to create coupon: %w", err)
+	}
+	return nil
+}
+
+func (r *couponRepository) GetByCode(ctx context.Context, code string) (*model.Coupon, error) {
+	var coupon model.Coupon
+	err := r.db.WithContext(ctx).
+		Where("code = ?", strings.ToUpper(strings.TrimSpace(code))).
+		First(&coupon).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get coupon by code",
+			zap.String("code", code),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get coupon: %w", err)
+	}
+	return &coupon, nil
+}
+
+func (r *couponRepository) GetByID(ctx context.Context, id int64) (*model.Coupon, error) {
+	var coupon model.Coupon
+	err := r.db.WithContext(ctx).First(&coupon, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get coupon by ID",
+			zap.Int64("id", id),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get coupon: %w", err)
+	}
+	return &coupon, nil
+}
+
+func (r *couponRepository) UpdateStripeMapping(ctx context.Context, couponID int64, stripeCouponID string, stripePromotionCodeID string) error {
+	err := r.db.WithContext(ctx).
+		Model(&model.Coupon{}).
+		Where("id = ?", couponID).
+		Updates(map[string]interface{}{
+			"stripe_coupon_id":         stripeCouponID,
+			"stripe_promotion_code_id": stripePromotionCodeID,
+			"updated_at":               gorm.Expr("NOW()"),
+		}).Error
+	if err != nil {
+		r.logger.Error("Failed to update coupon Stripe mapping",
+			zap.Int64("coupon_id", couponID),
+			zap.Error(err))
+		return fmt.Errorf("failed to update coupon: %w", err)
+	}
+	return nil
+}
+
+func (r *couponRepository) CountUserRedemptions(ctx context.Context, couponID int64, universalID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.CouponRedemption{}).
+		Where("coupon_id = ? AND universal_id = ? AND status <> ?", couponID, universalID, model.CouponRedemptionReleased).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error("Failed to count coupon redemptions",
+			zap.Int64("coupon_id", couponID),
+			zap.String("universal_id", universalID.String()),
+			zap.Error(err))
+		return 0, fmt.Errorf("failed to count coupon redemptions: %w", err)
+	}
+	return count, nil
+}
+
+func (r *couponRepository) Reserve(ctx context.Context, redemption *model.CouponRedemption) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the coupon row so concurrent checkouts cannot exceed the usage limits
+		var coupon model.Coupon
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&coupon, redemption.CouponID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domainErrors.ErrCouponNotFound
+			}
+			return fmt.Errorf("failed to lock coupon: %w", err)
+		}
+
+		if coupon.MaxRedemptions != nil && coupon.TimesRedeemed >= *coupon.MaxRedemptions {
+			return domainErrors.ErrCouponUsageLimitReached
+		}
+
+		if coupon.MaxRedemptionsPerUser != nil {
+			var used int64
+			if err := tx.Model(&model.CouponRedemption{}).
+				Where("coupon_id = ? AND universal_id = ? AND status <> ?", coupon.ID, redemption.UniversalID, model.CouponRedemptionReleased).
+				Count(&used).Error; err != nil {
+				return fmt.Errorf("failed to count user redemptions: %w", err)
+			}
+			if used >= int64(*coupon.MaxRedemptionsPerUser) {
+				return domainErrors.ErrCouponUserLimitReached
+			}
+		}
+
+		redemption.Status = model.CouponRedemptionReserved
+		if err := tx.Create(redemption).Error; err != nil {
+			return fmt.Errorf("failed to create coupon redemption: %w", err)
+		}
+
+		return tx.Model(&model.Coupon{}).
+			Where("id = ?", coupon.ID).
+			Updates(map[string]interface{}{
+				"times_redeemed": gorm.Expr("times_redeemed + 1"),
+				"updated_at":     gorm.Expr("NOW()"),
+			}).Error
+	})
+	if err != nil {
+		r.logger.Warn("Failed to reserve coupon redemption",
+			zap.Int64("coupon_id", redemption.CouponID),
+			zap.String("order_id", redemption.OrderID),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *couponRepository) GetRedemptionByOrderID(ctx context.Context, orderID string) (*model.CouponRedemption, error) {
+	var redemption model.CouponRedemption
+	err := r.db.WithContext(ctx).
+		Preload("Coupon").
+		Where("order_id = ?", orderID).
+		First(&redemption).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get coupon redemption",
+			zap.String("order_id", orderID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get coupon redemption: %w", err)
+	}
+	return &redemption, nil
+}
+
+func (r *couponRepository) MarkRedeemed(ctx context.Context, orderID string) (*model.CouponRedemption, error) {
+	now := time.Now()
+	err := r.db.WithContext(ctx).
+		Model(&model.CouponRedemption{}).
+		Where("order_id = ? AND status = ?", orderID, model.CouponRedemptionReserved).
+		Updates(map[string]interface{}{
+			"status":      model.CouponRedemptionRedeemed,
+			"redeemed_at": now,
+			"updated_at":  now,
+		}).Error
+	if err != nil {
+		r.logger.Error("Failed to mark coupon redemption as redeemed",
+			zap.String("order_id", orderID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to update coupon redemption: %w", err)
+	}
+
+	return r.GetRedemptionByOrderID(ctx, orderID)
+}
+
+func (r *couponRepository) Release(ctx context.Context, orderID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var redemption model.CouponRedemption
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("order_id = ? AND status = ?", orderID, model.CouponRedemptionReserved).
+			First(&redemption).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to lock coupon redemption: %w", err)
+		}
+
+		if err := tx.Model(&redemption).Updates(map[string]interface{}{
+			"status":     model.CouponRedemptionReleased,
+			"updated_at": gorm.Expr("NOW()"),
+		}).Error; err != nil {
+			return fmt.Errorf("failed to release coupon redemption: %w", err)
+		}
+
+		return tx.Model(&model.Coupon{}).
+			Where("id = ? AND times_redeemed > 0", redemption.CouponID).
+			Update("times_redeemed", gorm.Expr("times_redeemed - 1")).Error
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 	domainRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
 	"go.uber.org/zap"
@@ -198,7 +199,7 @@ func (r *creditRepository) AllocateCredits(ctx context.Context, universalID uuid
 }
 
 // UseCredits deducts credits from a universal ID's balance atomically
-func (r *creditRepository) UseCredits(ctx context.Context, universalID uuid.UUID, serviceProvider string, amount decimal.Decimal, description string, featureName string) (*model.UserCreditBalance, *model.CreditTransaction, error) {
+func (r *creditRepository) UseCredits(ctx context.Context, universalID uuid.UUID, actorID *uuid.UUID, serviceProvider string, amount decimal.Decimal, description string, featureName string, usageMetadata model.JSONB) (*model.UserCreditBalance, *model.CreditTransaction, error) {
 	var balance *model.UserCreditBalance
 	var transaction *model.CreditTransaction
 
@@ -233,6 +234,9 @@ func (r *creditRepository) UseCredits(ctx context.Context, universalID uuid.UUID
 			BalanceAfter:    newBalance,
 			Description:     description,
 			FeatureName:     &featureName,
+			ServiceProvider: &serviceProvider,
+			ActorID:         actorID,
+			UsageMetadata:   usageMetadata,
 		}
 
 		if err := tx.Create(transaction).Error; err != nil {
@@ -287,6 +291,353 @@ func (r *creditRepository) UseCredits(ctx context.Context, universalID uuid.UUID
 	return balance, transaction, nil
 }
 
+// UseCreditsBatch deducts many usages from a balance in a single database transaction
+func (r *creditRepository) UseCreditsBatch(ctx context.Context, universalID uuid.UUID, serviceProvider string, usages []domainRepo.CreditUsage, atomic bool) (*model.UserCreditBalance, []domainRepo.CreditUsageOutcome, error) {
+	var balance *model.UserCreditBalance
+	outcomes := make([]domainRepo.CreditUsageOutcome, len(usages))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the balance row so the whole batch sees a consistent balance
+		var currentBalance model.UserCreditBalance
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("universal_id = ? AND service_provider = ?", universalID, serviceProvider).
+			First(&currentBalance).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("no credit balance found for user")
+			}
+			return fmt.Errorf("failed to lock balance: %w", err)
+		}
+		balance = &currentBalance
+
+		// Load transactions already recorded under the batch's idempotency keys
+		var keys []uuid.UUID
+		for _, usage := range usages {
+			if usage.IdempotencyKey != nil {
+				keys = append(keys, *usage.IdempotencyKey)
+			}
+		}
+		recorded := make(map[uuid.UUID]*model.CreditTransaction)
+		if len(keys) > 0 {
+			var existing []*model.CreditTransaction
+			if err := tx.Where("idempotency_key IN ?", keys).Find(&existing).Error; err != nil {
+				return fmt.Errorf("failed to look up idempotency keys: %w", err)
+			}
+			for _, transaction := range existing {
+				recorded[*transaction.IdempotencyKey] = transaction
+			}
+		}
+
+		running := currentBalance.CurrentBalance
+		firstUse := make(map[uuid.UUID]int)
+		var created []*model.CreditTransaction
+		failed := false
+
+		for i, usage := range usages {
+			if key := usage.IdempotencyKey; key != nil {
+				if previous, ok := recorded[*key]; ok {
+					if previous.UniversalID != universalID {
+						outcomes[i].Err = domainErrors.ErrIdempotencyKeyConflict
+						failed = true
+					} else {
+						outcomes[i] = domainRepo.CreditUsageOutcome{Transaction: previous, Duplicate: true}
+					}
+					continue
+				}
+				// Repeated keys within the batch share the outcome of their first use
+				if j, ok := firstUse[*key]; ok {
+					outcomes[i] = outcomes[j]
+					outcomes[i].Duplicate = outcomes[j].Err == nil
+					continue
+				}
+				firstUse[*key] = i
+			}
+
+			if running.LessThan(usage.Amount) {
+				outcomes[i].Err = domainErrors.NewInsufficientBalanceError(usage.Amount, running)
+				failed = true
+				continue
+			}
+
+			featureName := usage.FeatureName
+			running = running.Sub(usage.Amount)
+			transaction := &model.CreditTransaction{
+				UniversalID:     universalID,
+				TransactionType: model.TransactionTypeCreditUsage,
+				Amount:          usage.Amount.Neg(), // Negative for usage
+				BalanceAfter:    running,
+				Description:     usage.Description,
+				FeatureName:     &featureName,
+				ServiceProvider: &serviceProvider,
+				ActorID:         usage.ActorID,
+				UsageMetadata:   usage.UsageMetadata,
+				IdempotencyKey:  usage.IdempotencyKey,
+			}
+			outcomes[i].Transaction = transaction
+			created = append(created, transaction)
+		}
+
+		if failed && atomic {
+			return domainErrors.ErrCreditBatchRejected
+		}
+		if len(created) == 0 {
+			return nil
+		}
+
+		if err := tx.Create(&created).Error; err != nil {
+			return fmt.Errorf("failed to create transactions: %w", err)
+		}
+
+		currentBalance.CurrentBalance = running
+		currentBalance.LastTransactionAt = created[len(created)-1].CreatedAt
+		currentBalance.ServiceProvider = serviceProvider
+		if err := tx.Save(&currentBalance).Error; err != nil {
+			return fmt.Errorf("failed to update balance: %w", err)
+		}
+
+		return nil
+	})
+
+	if errors.Is(err, domainErrors.ErrCreditBatchRejected) {
+		// Nothing was written; drop the transactions that would have been created
+		for i := range outcomes {
+			if transaction := outcomes[i].Transaction; transaction != nil && transaction.ID == 0 {
+				outcomes[i].Transaction = nil
+				outcomes[i].Duplicate = false
+			}
+		}
+		r.logger.Warn("Credit usage batch rejected",
+			zap.String("universal_id", universalID.String()),
+			zap.Int("usages", len(usages)))
+		return balance, outcomes, err
+	}
+	if err != nil {
+		r.logger.Error("Failed to use credits in batch",
+			zap.String("universal_id", universalID.String()),
+			zap.Int("usages", len(usages)),
+			zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to use credits: %w", err)
+	}
+
+	r.logger.Info("Credit usage batch applied",
+		zap.String("universal_id", universalID.String()),
+		zap.String("service_provider", serviceProvider),
+		zap.Int("usages", len(usages)),
+		zap.String("new_balance", balance.CurrentBalance.String()))
+
+	return balance, outcomes, nil
+}
+
+// TransferCredits moves credits from one balance to another in a single database transaction.
+// Both ledger entries share a transfer ID; the debit carries the idempotency key.
+func (r *creditRepository) TransferCredits(ctx context.Context, transfer *domainRepo.CreditTransfer) (*domainRepo.CreditTransferResult, error) {
+	result := &domainRepo.CreditTransferResult{}
+	serviceProvider := transfer.ServiceProvider
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if found, err := r.findTransferByKey(tx, transfer, result); err != nil || found {
+			return err
+		}
+
+		// The recipient may not have a balance yet
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "universal_id"}, {Name: "service_provider"}},
+			DoNothing: true,
+		}).Create(&model.UserCreditBalance{
+			UniversalID:     transfer.ToID,
+			ServiceProvider: serviceProvider,
+			CurrentBalance:  decimal.Zero,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to ensure balance row: %w", err)
+		}
+
+		// Lock both balances in a fixed order so opposite transfers cannot deadlock
+		var balances []model.UserCreditBalance
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("universal_id IN ? AND service_provider = ?", []uuid.UUID{transfer.FromID, transfer.ToID}, serviceProvider).
+			Order("universal_id").
+			Find(&balances).Error; err != nil {
+			return fmt.Errorf("failed to lock balances: %w", err)
+		}
+
+		var from, to *model.UserCreditBalance
+		for i := range balances {
+			switch balances[i].UniversalID {
+			case transfer.FromID:
+				from = &balances[i]
+			case transfer.ToID:
+				to = &balances[i]
+			}
+		}
+		if to == nil {
+			return fmt.Errorf("failed to lock recipient balance")
+		}
+
+		// A concurrent request with the same key may have committed while this one waited for the locks
+		if found, err := r.findTransferByKey(tx, transfer, result); err != nil || found {
+			return err
+		}
+		if from == nil || from.CurrentBalance.LessThan(transfer.Amount) {
+			available := decimal.Zero
+			if from != nil {
+				available = from.CurrentBalance
+			}
+			return domainErrors.NewInsufficientBalanceError(transfer.Amount, available)
+		}
+
+		if err := r.checkTransferLimits(tx, transfer); err != nil {
+			return err
+		}
+
+		transferID := uuid.New()
+		metadata := model.JSONB{}
+		if transfer.Note != "" {
+			metadata["note"] = transfer.Note
+		}
+
+		from.CurrentBalance = from.CurrentBalance.Sub(transfer.Amount)
+		to.CurrentBalance = to.CurrentBalance.Add(transfer.Amount)
+
+		debit := &model.CreditTransaction{
+			UniversalID:     transfer.FromID,
+			TransactionType: model.TransactionTypeTransferOut,
+			Amount:          transfer.Amount.Neg(),
+			BalanceAfter:    from.CurrentBalance,
+			Description:     fmt.Sprintf("Credit transfer to %s", transfer.ToID),
+			ServiceProvider: &serviceProvider,
+			ActorID:         transfer.ActorID,
+			UsageMetadata:   metadata,
+			IdempotencyKey:  transfer.IdempotencyKey,
+			TransferID:      &transferID,
+			CounterpartyID:  &transfer.ToID,
+		}
+		credit := &model.CreditTransaction{
+			UniversalID:     transfer.ToID,
+			TransactionType: model.TransactionTypeTransferIn,
+			Amount:          transfer.Amount,
+			BalanceAfter:    to.CurrentBalance,
+			Description:     fmt.Sprintf("Credit transfer from %s", transfer.FromID),
+			ServiceProvider: &serviceProvider,
+			UsageMetadata:   metadata,
+			TransferID:      &transferID,
+			CounterpartyID:  &transfer.FromID,
+		}
+		entries := []*model.CreditTransaction{debit, credit}
+		if err := tx.Create(&entries).Error; err != nil {
+			return fmt.Errorf("failed to create transfer transactions: %w", err)
+		}
+
+		for _, balance := range []*model.UserCreditBalance{from, to} {
+			balance.ServiceProvider = serviceProvider
+			balance.LastTransactionAt = debit.CreatedAt
+			if err := tx.Save(balance).Error; err != nil {
+				return fmt.Errorf("failed to update balance: %w", err)
+			}
+		}
+
+		result.TransferID = transferID
+		result.Debit = debit
+		result.Credit = credit
+		return nil
+	})
+
+	if err != nil {
+		var insufficientErr *domainErrors.InsufficientBalanceError
+		if errors.As(err, &insufficientErr) ||
+			errors.Is(err, domainErrors.ErrTransferLimitExceeded) ||
+			errors.Is(err, domainErrors.ErrIdempotencyKeyConflict) {
+			return nil, err
+		}
+		r.logger.Error("Failed to transfer credits",
+			zap.String("from", transfer.FromID.String()),
+			zap.String("to", transfer.ToID.String()),
+			zap.String("amount", transfer.Amount.String()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to transfer credits: %w", err)
+	}
+
+	if !result.Duplicate {
+		r.logger.Info("Credits transferred",
+			zap.String("transfer_id", result.TransferID.String()),
+			zap.String("from", transfer.FromID.String()),
+			zap.String("to", transfer.ToID.String()),
+			zap.String("amount", transfer.Amount.String()))
+	}
+	return result, nil
+}
+
+// findTransferByKey loads the transfer already made under the request's idempotency key into result
+func (r *creditRepository) findTransferByKey(tx *gorm.DB, transfer *domainRepo.CreditTransfer, result *domainRepo.CreditTransferResult) (bool, error) {
+	if transfer.IdempotencyKey == nil {
+		return false, nil
+	}
+
+	var existing model.CreditTransaction
+	err := tx.Where("idempotency_key = ?", *transfer.IdempotencyKey).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to look up idempotency key: %w", err)
+	}
+	if existing.UniversalID != transfer.FromID || existing.TransferID == nil {
+		return false, domainErrors.ErrIdempotencyKeyConflict
+	}
+
+	var credit model.CreditTransaction
+	if err := tx.Where("transfer_id = ? AND transaction_type = ?", *existing.TransferID, model.TransactionTypeTransferIn).
+		First(&credit).Error; err != nil {
+		return false, fmt.Errorf("failed to load transfer credit entry: %w", err)
+	}
+	result.TransferID = *existing.TransferID
+	result.Debit = &existing
+	result.Credit = &credit
+	result.Duplicate = true
+	return true, nil
+}
+
+// checkTransferLimits compares the transfers already sent and received since the limit window
+// started with the configured limits. It runs while both balances are locked.
+func (r *creditRepository) checkTransferLimits(tx *gorm.DB, transfer *domainRepo.CreditTransfer) error {
+	limits := transfer.Limits
+
+	if limits.MaxSent.IsPositive() || limits.MaxSentN > 0 {
+		var sent struct {
+			Total decimal.Decimal
+			Count int
+		}
+		if err := tx.Model(&model.CreditTransaction{}).
+			Select("COALESCE(SUM(-amount), 0) AS total, COUNT(*) AS count").
+			Where("universal_id = ? AND service_provider = ? AND transaction_type = ? AND created_at >= ?",
+				transfer.FromID, transfer.ServiceProvider, model.TransactionTypeTransferOut, limits.Since).
+			Scan(&sent).Error; err != nil {
+			return fmt.Errorf("failed to sum sent transfers: %w", err)
+		}
+		if limits.MaxSentN > 0 && sent.Count >= limits.MaxSentN {
+			return fmt.Errorf("%w: at most %d transfers per day", domainErrors.ErrTransferLimitExceeded, limits.MaxSentN)
+		}
+		if limits.MaxSent.IsPositive() && sent.Total.Add(transfer.Amount).GreaterThan(limits.MaxSent) {
+			return fmt.Errorf("%w: at most %s credits sent per day", domainErrors.ErrTransferLimitExceeded, limits.MaxSent)
+		}
+	}
+
+	if limits.MaxReceived.IsPositive() {
+		var received decimal.Decimal
+		if err := tx.Model(&model.CreditTransaction{}).
+			Select("COALESCE(SUM(amount), 0)").
+			Where("universal_id = ? AND service_provider = ? AND transaction_type = ? AND created_at >= ?",
+				transfer.ToID, transfer.ServiceProvider, model.TransactionTypeTransferIn, limits.Since).
+			Scan(&received).Error; err != nil {
+			return fmt.Errorf("failed to sum received transfers: %w", err)
+		}
+		if received.Add(transfer.Amount).GreaterThan(limits.MaxReceived) {
+			return fmt.Errorf("%w: recipient can receive at most %s credits per day", domainErrors.ErrTransferLimitExceeded, limits.MaxReceived)
+		}
+	}
+
+	return nil
+}
+
 // GetTransactionByReference retrieves a transaction by its reference ID
 func (r *creditRepository) GetTransactionByReference(ctx context.Context, referenceID string) (*model.CreditTransaction, error) {
 	var transaction model.CreditTransaction
@@ -3,12 +3,16 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
 )
@@ -27,13 +31,12 @@ func NewCreditTransactionRepository(db *gorm.DB, logger *zap.Logger) repository.
 	}
 }
 
-// GetTransactions retrieves credit transactions with filters
+// GetTransactions retrieves a page of credit transactions with filters
 func (r *creditTransactionRepository) GetTransactions(ctx context.Context, filters dto.TransactionFilters) ([]model.CreditTransaction, error) {
 	var transactions []model.CreditTransaction
 
 	query := r.db.WithContext(ctx).
-		Where("universal_id = ?", filters.UserID).
-		Order("created_at DESC")
+		Where("universal_id = ?", filters.UserID)
 
 	// Apply date filters
 	if filters.StartDate != nil {
@@ -47,9 +50,26 @@ func (r *creditTransactionRepository) GetTransactions(ctx context.Context, filte
 	if filters.TransactionType != nil && *filters.TransactionType != "" {
 		query = query.Where("transaction_type = ?", *filters.TransactionType)
 	}
+	if filters.ServiceProvider != "" {
+		query = query.Where("service_provider = ?", filters.ServiceProvider)
+	}
+
+	column := "created_at"
+	if filters.Sort == dto.TransactionSortAmount {
+		column = "amount"
+	}
 
 	// Apply pagination
-	query = query.Limit(filters.Limit).Offset(filters.Offset)
+	var afterValue interface{}
+	var afterID int64
+	if filters.Cursor != nil {
+		value, err := transactionCursorValue(filters.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		afterValue, afterID = value, filters.Cursor.ID
+	}
+	query = keysetPage(query, column, !filters.Ascending, afterValue, afterID, filters.Limit)
 
 	if err := query.Find(&transactions).Error; err != nil {
 		r.logger.Error("failed to get transactions",
@@ -61,35 +81,21 @@ func (r *creditTransactionRepository) GetTransactions(ctx context.Context, filte
 	return transactions, nil
 }
 
-// CountTransactions counts the total number of transactions matching the filters
-func (r *creditTransactionRepository) CountTransactions(ctx context.Context, filters dto.TransactionFilters) (int64, error) {
-	var count int64
-
-	query := r.db.WithContext(ctx).
-		Model(&model.CreditTransaction{}).
-		Where("universal_id = ?", filters.UserID)
-
-	// Apply date filters
-	if filters.StartDate != nil {
-		query = query.Where("created_at >= ?", *filters.StartDate)
-	}
-	if filters.EndDate != nil {
-		query = query.Where("created_at <= ?", *filters.EndDate)
-	}
-
-	// Apply transaction type filter
-	if filters.TransactionType != nil && *filters.TransactionType != "" {
-		query = query.Where("transaction_type = ?", *filters.TransactionType)
+// transactionCursorValue parses the sort key of a credit transaction cursor
+func transactionCursorValue(cursor *entity.Cursor) (interface{}, error) {
+	if cursor.Sort == dto.TransactionSortAmount {
+		amount, err := decimal.NewFromString(cursor.Value)
+		if err != nil {
+			return nil, domainErrors.ErrInvalidCursor
+		}
+		return amount, nil
 	}
 
-	if err := query.Count(&count).Error; err != nil {
-		r.logger.Error("failed to count transactions",
-			zap.String("universal_id", filters.UserID.String()),
-			zap.Error(err))
-		return 0, fmt.Errorf("failed to count transactions: %w", err)
+	createdAt, err := time.Parse(time.RFC3339Nano, cursor.Value)
+	if err != nil {
+		return nil, domainErrors.ErrInvalidCursor
 	}
-
-	return count, nil
+	return createdAt, nil
 }
 
 // GetCreditBalance retrieves the current credit balance for a universal ID
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+)
+
+// paymentServiceProviderExpr selects the service provider recorded in a payment's metadata. Toss
+// refund events replace the metadata with the Toss payment object, which nests it under "metadata".
+const paymentServiceProviderExpr = "COALESCE(provider_payment_data->>'service_provider', provider_payment_data->'metadata'->>'service_provider')"
+
+// exportRepository implements the ExportRepository interface
+type exportRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewExportRepository creates a new export repository
+func NewExportRepository(db *gorm.DB, logger *zap.Logger) repository.ExportRepository {
+	return &exportRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// StreamPayments streams the payments created within the filter range
+func (r *exportRepository) StreamPayments(ctx context.Context, filters dto.ExportFilters, fn func(*model.Payment) error) error {
+	query := r.db.WithContext(ctx).
+		Model(&model.Payment{}).
+		Where("created_at >= ? AND created_at < ?", filters.From, filters.To).
+		Order("created_at ASC, id ASC")
+	if filters.ServiceProvider != "" {
+		query = query.Where(paymentServiceProviderExpr+" = ?", filters.ServiceProvider)
+	}
+
+	return r.streamPayments(query, "payments", fn)
+}
+
+// StreamRefunds streams the refunded payments whose refund was recorded within the filter range
+func (r *exportRepository) StreamRefunds(ctx context.Context, filters dto.ExportFilters, fn func(*model.Payment) error) error {
+	query := r.db.WithContext(ctx).
+		Model(&model.Payment{}).
+		Where("status = ?", string(entity.PaymentStatusRefunded)).
+		Where("updated_at >= ? AND updated_at < ?", filters.From, filters.To).
+		Order("updated_at ASC, id ASC")
+	if filters.ServiceProvider != "" {
+		query = query.Where(paymentServiceProviderExpr+" = ?", filters.ServiceProvider)
+	}
+
+	return r.streamPayments(query, "refunds", fn)
+}
+
+// StreamCreditTransactions streams the credit transactions created within the filter range
+func (r *exportRepository) StreamCreditTransactions(ctx context.Context, filters dto.ExportFilters, fn func(*model.CreditTransaction) error) error {
+	query := r.db.WithContext(ctx).
+		Model(&model.CreditTransaction{}).
+		Where("created_at >= ? AND created_at < ?", filters.From, filters.To).
+		Order("created_at ASC, id ASC")
+	if filters.ServiceProvider != "" {
+		query = query.Where("service_provider = ?", filters.ServiceProvider)
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		r.logger.Error("Failed to query credit transactions for export", zap.Error(err))
+		return fmt.Errorf("failed to query credit transactions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tx model.CreditTransaction
+		if err := r.db.ScanRows(rows, &tx); err != nil {
+			return fmt.Errorf("failed to scan credit transaction: %w", err)
+		}
+		if err := fn(&tx); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Failed to stream credit transactions for export", zap.Error(err))
+		return fmt.Errorf("failed to stream credit transactions: %w", err)
+	}
+	return nil
+}
+
+func (r *exportRepository) streamPayments(query *gorm.DB, dataset string, fn func(*model.Payment) error) error {
+	rows, err := query.Rows()
+	if err != nil {
+		r.logger.Error("Failed to query payments for export",
+			zap.String("dataset", dataset),
+			zap.Error(err))
+		return fmt.Errorf("failed to query %s: %w", dataset, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var payment model.Payment
+		if err := r.db.ScanRows(rows, &payment); err != nil {
+			return fmt.Errorf("failed to scan payment: %w", err)
+		}
+		if err := fn(&payment); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Failed to stream payments for export",
+			zap.String("dataset", dataset),
+			zap.Error(err))
+		return fmt.Errorf("failed to stream %s: %w", dataset, err)
+	}
+	return nil
+}
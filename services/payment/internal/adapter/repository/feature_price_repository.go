@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+)
+
+// featurePriceRepository implements the FeaturePriceRepository interface
+type featurePriceRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewFeaturePriceRepository creates a new feature price repository
+func NewFeaturePriceRepository(db *gorm.DB, logger *zap.Logger) repository.FeaturePriceRepository {
+	return &featurePriceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListEffective lists the prices of a feature effective at the given time
+func (r *featurePriceRepository) ListEffective(ctx context.Context, serviceProvider string, featureName string, tiers []string, at time.Time) ([]*model.FeaturePrice, error) {
+	var prices []*model.FeaturePrice
+
+	err := r.db.WithContext(ctx).
+		Where("feature_name = ?", featureName).
+		Where("service_provider IN ?", []string{serviceProvider, ""}).
+		Where("plan_tier IN ?", append([]string{""}, tiers...)).
+		Where("effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", at, at).
+		Order("version DESC").
+		Find(&prices).Error
+
+	if err != nil {
+		r.logger.Error("Failed to list effective feature prices",
+			zap.String("service_provider", serviceProvider),
+			zap.String("feature_name", featureName),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to list feature prices: %w", err)
+	}
+
+	return prices, nil
+}
+
+// List lists catalog entries
+func (r *featurePriceRepository) List(ctx context.Context, serviceProvider string, featureName string, at *time.Time) ([]*model.FeaturePrice, error) {
+	var prices []*model.FeaturePrice
+
+	query := r.db.WithContext(ctx)
+	if serviceProvider != "" {
+		query = query.Where("service_provider = ?", serviceProvider)
+	}
+	if featureName != "" {
+		query = query.Where("feature_name = ?", featureName)
+	}
+	if at != nil {
+		query = query.Where("effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", *at, *at)
+	}
+
+	err := query.
+		Order("feature_name ASC, service_provider ASC, plan_tier ASC, version DESC").
+		Find(&prices).Error
+	if err != nil {
+		r.logger.Error("Failed to list feature prices", zap.Error(err))
+		return nil, fmt.Errorf("failed to list feature prices: %w", err)
+	}
+
+	return prices, nil
+}
+
+// CreateVersion adds a new version of a price, ending the version it replaces when it takes effect
+func (r *featurePriceRepository) CreateVersion(ctx context.Context, price *model.FeaturePrice) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var versions []model.FeaturePrice
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("service_provider = ? AND feature_name = ? AND plan_tier = ?",
+				price.ServiceProvider, price.FeatureName, price.PlanTier).
+			Order("version DESC").
+			Find(&versions).Error
+		if err != nil {
+			return fmt.Errorf("failed to lock feature price versions: %w", err)
+		}
+
+		price.Version = 1
+		if len(versions) > 0 {
+			price.Version = versions[0].Version + 1
+		}
+
+		// A version scheduled before an already planned one ends where that one starts
+		for _, version := range versions {
+			if version.EffectiveFrom.After(price.EffectiveFrom) &&
+				(price.EffectiveTo == nil || version.EffectiveFrom.Before(*price.EffectiveTo)) {
+				end := version.EffectiveFrom
+				price.EffectiveTo = &end
+			}
+		}
+
+		// Versions still open when the new one starts end at its start
+		err = tx.Model(&model.FeaturePrice{}).
+			Where("service_provider = ? AND feature_name = ? AND plan_tier = ?",
+				price.ServiceProvider, price.FeatureName, price.PlanTier).
+			Where("effective_from < ? AND (effective_to IS NULL OR effective_to > ?)",
+				price.EffectiveFrom, price.EffectiveFrom).
+			Update("effective_to", price.EffectiveFrom).Error
+		if err != nil {
+			return fmt.Errorf("failed to end previous feature price: %w", err)
+		}
+
+		if err := tx.Create(price).Error; err != nil {
+			return fmt.Errorf("failed to create feature price: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		r.logger.Error("Failed to create feature price version",
+			zap.String("service_provider", price.ServiceProvider),
+			zap.String("feature_name", price.FeatureName),
+			zap.String("plan_tier", price.PlanTier),
+			zap.Error(err))
+		return err
+	}
+
+	r.logger.Info("Feature price version created",
+		zap.String("service_provider", price.ServiceProvider),
+		zap.String("feature_name", price.FeatureName),
+		zap.String("plan_tier", price.PlanTier),
+		zap.Int("version", price.Version),
+		zap.Time("effective_from", price.EffectiveFrom))
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// keysetPage orders query by column with id breaking ties and, when the previous page ended at
+// (afterValue, afterID), starts after that position. Column and id must form a unique, indexed key.
+func keysetPage(query *gorm.DB, column string, desc bool, afterValue interface{}, afterID int64, limit int) *gorm.DB {
+	direction, comparison := "ASC", ">"
+	if desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if afterValue != nil {
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, comparison), afterValue, afterID)
+	}
+
+	return query.
+		Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Limit(limit)
+}
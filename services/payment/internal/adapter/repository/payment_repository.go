@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
 	"go.uber.org/zap"
@@ -35,10 +40,12 @@ func (r *paymentRepository) Create(ctx context.Context, payment *entity.Payment)
 	paymentModel := &model.Payment{
 		UniversalID:           universalID,
 		ProviderPaymentIntentID: &payment.TransactionID,
-		AmountCents:           int(payment.Amount * 100), // Convert to cents
+		AmountCents:           int(math.Round(payment.Amount * 100)), // Convert to cents
 		Currency:              payment.Currency,
+		PgProvider:            payment.Provider,
 		Status:                string(payment.Status),
 		PaymentMethodType:     (*string)(&payment.Method),
+		ProviderPaymentData:   payment.Metadata,
 	}
 
 	err = r.db.WithContext(ctx).Create(paymentModel).Error
@@ -71,59 +78,94 @@ func (r *paymentRepository) GetByID(ctx context.Context, id string) (*entity.Pay
 	return r.modelToEntity(&payment), nil
 }
 
-func (r *paymentRepository) GetByUniversalID(ctx context.Context, universalID string, page, limit int) ([]*entity.Payment, int64, error) {
+// ListByUniversalID retrieves a page of a user's payments matching the filters of params
+func (r *paymentRepository) ListByUniversalID(ctx context.Context, params *entity.PaymentListParams) ([]*entity.Payment, error) {
 	var payments []model.Payment
-	var total int64
 
-	uuid, err := uuid.Parse(universalID)
+	universalID, err := uuid.Parse(params.UniversalID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("invalid universal ID: %w", err)
+		return nil, fmt.Errorf("invalid universal ID: %w", err)
 	}
 
-	// Get total count
-	err = r.db.WithContext(ctx).
-		Model(&model.Payment{}).
-		Where("universal_id = ?", uuid).
-		Count(&total).Error
-	
-	if err != nil {
-		r.logger.Error("Failed to count payments by universal ID",
-			zap.String("universal_id", universalID),
-			zap.Error(err))
-		return nil, 0, fmt.Errorf("failed to count payments: %w", err)
+	query := r.db.WithContext(ctx).
+		Where("universal_id = ?", universalID)
+
+	if len(params.Statuses) > 0 {
+		statuses := make([]string, len(params.Statuses))
+		for i, status := range params.Statuses {
+			statuses[i] = string(status)
+		}
+		query = query.Where("status IN ?", statuses)
+	}
+	if params.StartDate != nil {
+		query = query.Where("created_at >= ?", *params.StartDate)
+	}
+	if params.EndDate != nil {
+		query = query.Where("created_at <= ?", *params.EndDate)
+	}
+	if params.MinAmount != nil {
+		query = query.Where("amount_cents >= ?", *params.MinAmount)
+	}
+	if params.MaxAmount != nil {
+		query = query.Where("amount_cents <= ?", *params.MaxAmount)
+	}
+	if params.PlanID != "" {
+		query = query.Where("provider_payment_data->>'plan_id' = ?", params.PlanID)
+	}
+	if params.Provider != "" {
+		query = query.Where("pg_provider = ?", params.Provider)
 	}
 
-	// If no records, return early
-	if total == 0 {
-		return []*entity.Payment{}, 0, nil
+	column := "created_at"
+	if params.Sort == entity.PaymentSortAmount {
+		column = "amount_cents"
 	}
 
-	// Calculate offset
-	offset := (page - 1) * limit
+	var afterValue interface{}
+	var afterID int64
+	if params.Cursor != nil {
+		afterValue, err = paymentCursorValue(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		afterID = params.Cursor.ID
+	}
 
-	// Get paginated records
-	err = r.db.WithContext(ctx).
-		Where("universal_id = ?", uuid).
-		Order("created_at DESC").
-		Limit(limit).
-		Offset(offset).
+	err = keysetPage(query, column, params.Descending, afterValue, afterID, params.Limit).
 		Find(&payments).Error
 
 	if err != nil {
-		r.logger.Error("Failed to get payments by universal ID",
-			zap.String("universal_id", universalID),
-			zap.Int("page", page),
-			zap.Int("limit", limit),
+		r.logger.Error("Failed to list payments by universal ID",
+			zap.String("universal_id", params.UniversalID),
+			zap.String("sort", params.Sort),
+			zap.Int("limit", params.Limit),
 			zap.Error(err))
-		return nil, 0, fmt.Errorf("failed to get payments: %w", err)
+		return nil, fmt.Errorf("failed to list payments: %w", err)
 	}
 
 	entities := make([]*entity.Payment, len(payments))
-	for i, p := range payments {
-		entities[i] = r.modelToEntity(&p)
+	for i := range payments {
+		entities[i] = r.modelToEntity(&payments[i])
 	}
 
-	return entities, total, nil
+	return entities, nil
+}
+
+// paymentCursorValue parses the sort key of a payment cursor
+func paymentCursorValue(cursor *entity.Cursor) (interface{}, error) {
+	if cursor.Sort == entity.PaymentSortAmount {
+		amount, err := strconv.ParseInt(cursor.Value, 10, 64)
+		if err != nil {
+			return nil, domainErrors.ErrInvalidCursor
+		}
+		return amount, nil
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, cursor.Value)
+	if err != nil {
+		return nil, domainErrors.ErrInvalidCursor
+	}
+	return createdAt, nil
 }
 
 func (r *paymentRepository) GetByTransactionID(ctx context.Context, transactionID string) (*entity.Payment, error) {
@@ -213,10 +255,20 @@ func (r *paymentRepository) CreateOneTimePayment(ctx context.Context, payment *e
 		ProviderInvoiceID:      &payment.TransactionID, // Use order ID
 		AmountCents:            int(payment.Amount),    // Already in smallest unit
 		Currency:               payment.Currency,
+		PgProvider:             payment.Provider,
 		Status:                 string(payment.Status),
 		ProviderPaymentData:    payment.Metadata,
 	}
 
+	if payment.CashReceipt != nil {
+		receiptType := payment.CashReceipt.Type
+		identifier := payment.CashReceipt.Identifier
+		status := string(entity.CashReceiptStatusRequested)
+		paymentModel.CashReceiptType = &receiptType
+		paymentModel.CashReceiptIdentifier = &identifier
+		paymentModel.CashReceiptStatus = &status
+	}
+
 	err = r.db.WithContext(ctx).Create(paymentModel).Error
 	if err != nil {
 		r.logger.Error("Failed to create one-time payment",
@@ -270,6 +322,50 @@ func (r *paymentRepository) UpdatePaymentAfterConfirm(ctx context.Context, order
 	return nil
 }
 
+// UpdateCashReceipt updates only the cash receipt columns of a payment
+func (r *paymentRepository) UpdateCashReceipt(ctx context.Context, orderID string, receipt *entity.CashReceipt) error {
+	updates := map[string]interface{}{
+		"cash_receipt_status": receipt.Status,
+		"updated_at":          gorm.Expr("NOW()"),
+	}
+	if receipt.ReceiptKey != "" {
+		updates["cash_receipt_key"] = receipt.ReceiptKey
+	}
+	if receipt.IssueNumber != "" {
+		updates["cash_receipt_issue_number"] = receipt.IssueNumber
+	}
+	if receipt.ReceiptURL != "" {
+		updates["cash_receipt_url"] = receipt.ReceiptURL
+	}
+	if receipt.IssuedAt != nil {
+		updates["cash_receipt_issued_at"] = *receipt.IssuedAt
+	}
+	if receipt.CanceledAt != nil {
+		updates["cash_receipt_canceled_at"] = *receipt.CanceledAt
+	}
+	if receipt.CanceledAmount > 0 {
+		updates["cash_receipt_canceled_amount"] = receipt.CanceledAmount
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&model.Payment{}).
+		Where("provider_invoice_id = ?", orderID).
+		Where("cash_receipt_status IS NOT NULL").
+		Updates(updates)
+	if result.Error != nil {
+		r.logger.Error("Failed to update cash receipt",
+			zap.String("order_id", orderID),
+			zap.String("status", receipt.Status),
+			zap.Error(result.Error))
+		return fmt.Errorf("failed to update cash receipt: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("no cash receipt for order %s", orderID)
+	}
+
+	return nil
+}
+
 func (r *paymentRepository) List(ctx context.Context, limit, offset int) ([]*entity.Payment, error) {
 	var payments []model.Payment
 
@@ -310,6 +406,7 @@ func (r *paymentRepository) modelToEntity(m *model.Payment) *entity.Payment {
 		UniversalID: m.UniversalID.String(),
 		Amount:      float64(m.AmountCents), // Convert from cents
 		Currency:  m.Currency,
+		Provider:  m.PgProvider,
 		Status:    entity.PaymentStatus(m.Status),
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
@@ -323,6 +420,10 @@ func (r *paymentRepository) modelToEntity(m *model.Payment) *entity.Payment {
 		e.Method = entity.PaymentMethod(*m.PaymentMethodType)
 	}
 
+	if m.CashReceiptType != nil {
+		e.CashReceipt = cashReceiptFromModel(m)
+	}
+
 	// Convert metadata if needed
 	e.Metadata = make(map[string]interface{})
 	if m.ProviderPaymentData != nil {
@@ -333,3 +434,86 @@ func (r *paymentRepository) modelToEntity(m *model.Payment) *entity.Payment {
 
 	return e
 }
+
+// cashReceiptFromModel builds the cash receipt view of a payment
+func cashReceiptFromModel(m *model.Payment) *entity.CashReceipt {
+	receipt := &entity.CashReceipt{
+		Type:           *m.CashReceiptType,
+		IssuedAt:       m.CashReceiptIssuedAt,
+		CanceledAt:     m.CashReceiptCanceledAt,
+		CanceledAmount: m.CashReceiptCanceledAmount,
+	}
+
+	if m.CashReceiptIdentifier != nil {
+		receipt.Identifier = *m.CashReceiptIdentifier
+		receipt.MaskedIdentifier = maskIdentifier(*m.CashReceiptIdentifier)
+	}
+	if m.CashReceiptStatus != nil {
+		receipt.Status = *m.CashReceiptStatus
+	}
+	if m.CashReceiptKey != nil {
+		receipt.ReceiptKey = *m.CashReceiptKey
+	}
+	if m.CashReceiptIssueNumber != nil {
+		receipt.IssueNumber = *m.CashReceiptIssueNumber
+	}
+	if m.CashReceiptURL != nil {
+		receipt.ReceiptURL = *m.CashReceiptURL
+	}
+
+	return receipt
+}
+
+// maskIdentifier keeps only the last four characters of an identity number
+func maskIdentifier(identifier string) string {
+	if len(identifier) <= 4 {
+		return "****"
+	}
+	return strings.Repeat("*", len(identifier)-4) + identifier[len(identifier)-4:]
+}
+
+// ListPlanPurchases lists the plans a user paid for through completed payments.
+// The plan is read from the plan_id recorded in the payment's provider data.
+func (r *paymentRepository) ListPlanPurchases(ctx context.Context, universalID string) ([]*repository.PlanPurchase, error) {
+	var rows []struct {
+		PlanID string
+		PaidAt time.Time
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&model.Payment{}).
+		Select("provider_payment_data->>'plan_id' AS plan_id, COALESCE(paid_at, updated_at) AS paid_at").
+		Where("universal_id = ? AND status = ?", universalID, string(entity.PaymentStatusCompleted)).
+		Where("COALESCE(provider_payment_data->>'plan_id', '') <> ''").
+		Order("paid_at DESC").
+		Scan(&rows).Error
+
+	if err != nil {
+		r.logger.Error("Failed to list plan purchases",
+			zap.String("universal_id", universalID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to list plan purchases: %w", err)
+	}
+
+	purchases := make([]*repository.PlanPurchase, len(rows))
+	for i, row := range rows {
+		purchases[i] = &repository.PlanPurchase{PlanID: row.PlanID, PaidAt: row.PaidAt}
+	}
+	return purchases, nil
+}
+
+// CountCompleted counts a user's completed payments. Zero-amount payments such as trial starts are not counted.
+func (r *paymentRepository) CountCompleted(ctx context.Context, universalID string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.Payment{}).
+		Where("universal_id = ? AND status = ? AND amount_cents > 0", universalID, string(entity.PaymentStatusCompleted)).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error("Failed to count completed payments",
+			zap.String("universal_id", universalID),
+			zap.Error(err))
+		return 0, fmt.Errorf("failed to count completed payments: %w", err)
+	}
+	return count, nil
+}
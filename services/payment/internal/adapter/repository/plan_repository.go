@@ -9,6 +9,7 @@ import (
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PlanRepository handles payment plan storage
@@ -16,12 +17,14 @@ type PlanRepository interface {
 	GetAll(ctx context.Context) ([]*model.PaymentPlan, error)
 	GetByType(ctx context.Context, planType string) ([]*model.PaymentPlan, error)
 	GetByTypeAndProvider(ctx context.Context, planType string, provider string, currency string) ([]*model.PaymentPlan, error)
+	// GetByPriceID also resolves the price IDs of additional price points, returning the plan sold at that price
 	GetByPriceID(ctx context.Context, priceID string) (*model.PaymentPlan, error)
 	GetByProductID(ctx context.Context, productID string) ([]*model.PaymentPlan, error)
 	Create(ctx context.Context, plan *model.PaymentPlan) error
 	Update(ctx context.Context, plan *model.PaymentPlan) error
 	Delete(ctx context.Context, priceID string) error
 	Upsert(ctx context.Context, plan *model.PaymentPlan) error
+	UpsertPrice(ctx context.Context, price *model.PlanPrice) error
 }
 
 type planRepository struct {
@@ -51,7 +54,7 @@ func (r *planRepository) GetAll(ctx context.Context) ([]*model.PaymentPlan, erro
 		return nil, fmt.Errorf("failed to get plans: %w", err)
 	}
 
-	return plans, nil
+	return plans, r.loadPrices(ctx, plans)
 }
 
 // GetByType retrieves all active plans of a specific type
@@ -68,11 +71,22 @@ func (r *planRepository) GetByTypeAndProvider(ctx context.Context, planType stri
 	query := r.db.WithContext(ctx).
 		Where("type = ? AND is_active = ?", planType, true)
 
-	if provider != "" {
-		query = query.Where("pg_provider = ?", provider)
-	}
-	if currency != "" {
-		query = query.Where("currency = ?", currency)
+	// A plan matches when its own price or one of its additional price points is sold by the
+	// provider in the currency
+	if provider != "" || currency != "" {
+		base := r.db
+		point := r.db.Table("plan_prices").
+			Select("1").
+			Where("plan_prices.plan_id = payment_plans.id AND plan_prices.is_active = ?", true)
+		if provider != "" {
+			base = base.Where("payment_plans.pg_provider = ?", provider)
+			point = point.Where("plan_prices.pg_provider = ?", provider)
+		}
+		if currency != "" {
+			base = base.Where("payment_plans.currency = ?", currency)
+			point = point.Where("plan_prices.currency = ?", currency)
+		}
+		query = query.Where(base.Or("EXISTS (?)", point))
 	}
 
 	err := query.
@@ -88,7 +102,7 @@ func (r *planRepository) GetByTypeAndProvider(ctx context.Context, planType stri
 		return nil, fmt.Errorf("failed to get plans by type and provider: %w", err)
 	}
 
-	return plans, nil
+	return plans, r.loadPrices(ctx, plans)
 }
 
 // GetByPriceID retrieves a plan by Stripe price ID
@@ -99,17 +113,94 @@ func (r *planRepository) GetByPriceID(ctx context.Context, priceID string) (*mod
 		Where("provider_price_id = ?", priceID).
 		First(&plan).Error
 
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return r.getByPricePointID(ctx, priceID)
+	}
+	if err != nil {
+		r.logger.Error("Failed to get plan by price ID",
+			zap.String("price_id", priceID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+
+	plans := []*model.PaymentPlan{&plan}
+	return &plan, r.loadPrices(ctx, plans)
+}
+
+// getByPricePointID retrieves the plan of an additional price point, sold at that price
+func (r *planRepository) getByPricePointID(ctx context.Context, priceID string) (*model.PaymentPlan, error) {
+	var price model.PlanPrice
+
+	err := r.db.WithContext(ctx).
+		Where("provider_price_id = ?", priceID).
+		First(&price).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get plan price by price ID",
+			zap.String("price_id", priceID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get plan price: %w", err)
+	}
+
+	var plan model.PaymentPlan
+	err = r.db.WithContext(ctx).
+		Where("id = ?", price.PlanID).
+		First(&plan).Error
+
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
-		r.logger.Error("Failed to get plan by price ID",
+		r.logger.Error("Failed to get plan of price point",
 			zap.String("price_id", priceID),
+			zap.Int64("plan_id", price.PlanID),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to get plan: %w", err)
 	}
 
-	return &plan, nil
+	if err := r.loadPrices(ctx, []*model.PaymentPlan{&plan}); err != nil {
+		return nil, err
+	}
+
+	priced := plan.WithPrice(price)
+	priced.IsActive = plan.IsActive && price.IsActive
+	return priced, nil
+}
+
+// loadPrices attaches the active additional price points to plans
+func (r *planRepository) loadPrices(ctx context.Context, plans []*model.PaymentPlan) error {
+	if len(plans) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*model.PaymentPlan, len(plans))
+	ids := make([]int64, 0, len(plans))
+	for _, plan := range plans {
+		byID[plan.ID] = plan
+		ids = append(ids, plan.ID)
+	}
+
+	var prices []model.PlanPrice
+	err := r.db.WithContext(ctx).
+		Where("plan_id IN ? AND is_active = ?", ids, true).
+		Order("currency ASC, id ASC").
+		Find(&prices).Error
+
+	if err != nil {
+		r.logger.Error("Failed to load plan prices", zap.Error(err))
+		return fmt.Errorf("failed to load plan prices: %w", err)
+	}
+
+	for _, price := range prices {
+		if plan, ok := byID[price.PlanID]; ok {
+			plan.Prices = append(plan.Prices, price)
+		}
+	}
+
+	return nil
 }
 
 // GetByProductID retrieves all plans for a Stripe product
@@ -160,13 +251,23 @@ func (r *planRepository) Update(ctx context.Context, plan *model.PaymentPlan) er
 	return nil
 }
 
-// Delete soft deletes a payment plan
+// Delete soft deletes a payment plan, or the price point with the given price ID
 func (r *planRepository) Delete(ctx context.Context, priceID string) error {
 	err := r.db.WithContext(ctx).
 		Model(&model.PaymentPlan{}).
 		Where("provider_price_id = ?", priceID).
 		Update("is_active", false).Error
 
+	if err == nil {
+		err = r.db.WithContext(ctx).
+			Model(&model.PlanPrice{}).
+			Where("provider_price_id = ?", priceID).
+			Updates(map[string]interface{}{
+				"is_active":  false,
+				"updated_at": gorm.Expr("NOW()"),
+			}).Error
+	}
+
 	if err != nil {
 		r.logger.Error("Failed to delete plan",
 			zap.String("price_id", priceID),
@@ -179,18 +280,54 @@ func (r *planRepository) Delete(ctx context.Context, priceID string) error {
 
 // Upsert creates or updates a payment plan
 func (r *planRepository) Upsert(ctx context.Context, plan *model.PaymentPlan) error {
-	// Check if plan exists
-	existing, err := r.GetByPriceID(ctx, plan.ProviderPriceID)
-	if err != nil {
-		return err
-	}
+	// Check if plan exists; price points sharing the price ID are not plans
+	var existing model.PaymentPlan
+	err := r.db.WithContext(ctx).
+		Select("id").
+		Where("provider_price_id = ?", plan.ProviderPriceID).
+		First(&existing).Error
 
-	if existing != nil {
+	if err == nil {
 		// Update existing plan
 		plan.ID = existing.ID
 		return r.Update(ctx, plan)
 	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.logger.Error("Failed to get plan by price ID",
+			zap.String("price_id", plan.ProviderPriceID),
+			zap.Error(err))
+		return fmt.Errorf("failed to get plan: %w", err)
+	}
 
 	// Create new plan
 	return r.Create(ctx, plan)
 }
+
+// UpsertPrice creates or updates an additional price point by its provider price ID
+func (r *planRepository) UpsertPrice(ctx context.Context, price *model.PlanPrice) error {
+	price.Currency = strings.ToUpper(strings.TrimSpace(price.Currency))
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "provider_price_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"plan_id":     price.PlanID,
+				"pg_provider": price.PgProvider,
+				"currency":    price.Currency,
+				"amount":      price.Amount,
+				"is_active":   price.IsActive,
+				"updated_at":  gorm.Expr("NOW()"),
+			}),
+		}).
+		Create(price).Error
+
+	if err != nil {
+		r.logger.Error("Failed to upsert plan price",
+			zap.String("price_id", price.ProviderPriceID),
+			zap.Int64("plan_id", price.PlanID),
+			zap.Error(err))
+		return fmt.Errorf("failed to upsert plan price: %w", err)
+	}
+
+	return nil
+}
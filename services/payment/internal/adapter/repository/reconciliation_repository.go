@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+)
+
+// reconciliationRepository implements the ReconciliationRepository interface
+type reconciliationRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewReconciliationRepository creates a new reconciliation repository
+func NewReconciliationRepository(db *gorm.DB, logger *zap.Logger) repository.ReconciliationRepository {
+	return &reconciliationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListPayments retrieves the payments of a PG provider and the given service providers created in
+// [from, to). Refund webhooks store the service provider in the metadata of the Toss payment object.
+func (r *reconciliationRepository) ListPayments(ctx context.Context, pgProvider string, serviceProviders []string, from, to time.Time) ([]*model.Payment, error) {
+	var payments []*model.Payment
+
+	err := r.db.WithContext(ctx).
+		Where("pg_provider = ?", pgProvider).
+		Where("COALESCE(NULLIF(provider_payment_data->>'service_provider', ''), provider_payment_data->'metadata'->>'service_provider', '') IN ?", serviceProviders).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("created_at ASC, id ASC").
+		Find(&payments).Error
+	if err != nil {
+		r.logger.Error("Failed to list payments for reconciliation",
+			zap.String("pg_provider", pgProvider),
+			zap.Strings("service_providers", serviceProviders),
+			zap.Time("from", from),
+			zap.Time("to", to),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to list payments for reconciliation: %w", err)
+	}
+
+	return payments, nil
+}
+
+// GetStripePayment retrieves the payment recorded for a Stripe invoice. Invoice payments are stored
+// under their payment intent, or under the invoice itself when it had none.
+func (r *reconciliationRepository) GetStripePayment(ctx context.Context, invoiceID, paymentIntentID string) (*model.Payment, error) {
+	var payment model.Payment
+
+	query := r.db.WithContext(ctx).
+		Where("provider_payment_intent_id = ? OR provider_payment_data->>'provider_invoice_id' = ?", invoiceID, invoiceID)
+	if paymentIntentID != "" {
+		query = query.Or("provider_payment_intent_id = ?", paymentIntentID)
+	}
+
+	err := query.Order("id ASC").First(&payment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get payment for Stripe invoice",
+			zap.String("invoice_id", invoiceID),
+			zap.String("payment_intent_id", paymentIntentID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	return &payment, nil
+}
+
+// UpdatePayment applies updates to the payment with the given ID
+func (r *reconciliationRepository) UpdatePayment(ctx context.Context, id int64, updates map[string]interface{}) error {
+	updates["updated_at"] = gorm.Expr("NOW()")
+
+	err := r.db.WithContext(ctx).
+		Model(&model.Payment{}).
+		Where("id = ?", id).
+		Updates(updates).Error
+	if err != nil {
+		r.logger.Error("Failed to update payment during reconciliation",
+			zap.Int64("payment_id", id),
+			zap.Error(err))
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	return nil
+}
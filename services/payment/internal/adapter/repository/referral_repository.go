@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	domainRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type referralRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewReferralRepository creates a new referral repository instance
+func NewReferralRepository(db *gorm.DB, logger *zap.Logger) domainRepo.ReferralRepository {
+	return &referralRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *referralRepository) GetCode(ctx context.Context, universalID uuid.UUID) (*model.ReferralCode, error) {
+	var code model.ReferralCode
+	err := r.db.WithContext(ctx).
+		Where("universal_id = ?", universalID).
+		First(&code).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get referral code",
+			zap.String("universal_id", universalID.String()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get referral code: %w", err)
+	}
+	return &code, nil
+}
+
+func (r *referralRepository) CreateCode(ctx context.Context, code *model.ReferralCode) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(code)
+	if result.Error != nil {
+		r.logger.Error("Failed to create referral code",
+			zap.String("universal_id", code.UniversalID.String()),
+			zap.Error(result.Error))
+		return false, fmt.Errorf("failed to create referral code: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *referralRepository) GetCodeByCode(ctx context.Context, code string) (*model.ReferralCode, error) {
+	var referralCode model.ReferralCode
+	err := r.db.WithContext(ctx).
+		Where("code = ?", strings.ToUpper(strings.TrimSpace(code))).
+		First(&referralCode).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get referral code by code",
+			zap.String("code", code),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get referral code: %w", err)
+	}
+	return &referralCode, nil
+}
+
+func (r *referralRepository) Create(ctx context.Context, referral *model.Referral) error {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "referee_id"}}, DoNothing: true}).
+		Create(referral)
+	if result.Error != nil {
+		r.logger.Error("Failed to create referral",
+			zap.String("referrer_id", referral.ReferrerID.String()),
+			zap.String("referee_id", referral.RefereeID.String()),
+			zap.Error(result.Error))
+		return fmt.Errorf("failed to create referral: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrAlreadyReferred
+	}
+	return nil
+}
+
+func (r *referralRepository) GetByReferee(ctx context.Context, refereeID uuid.UUID) (*model.Referral, error) {
+	var referral model.Referral
+	err := r.db.WithContext(ctx).
+		Where("referee_id = ?", refereeID).
+		First(&referral).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get referral by referee",
+			zap.String("referee_id", refereeID.String()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get referral: %w", err)
+	}
+	return &referral, nil
+}
+
+func (r *referralRepository) CountByReferrer(ctx context.Context, referrerID uuid.UUID) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&model.Referral{}).
+		Select("status, COUNT(*) AS count").
+		Where("referrer_id = ?", referrerID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error("Failed to count referrals",
+			zap.String("referrer_id", referrerID.String()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to count referrals: %w", err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *referralRepository) UpdateStatus(ctx context.Context, referral *model.Referral) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&model.Referral{}).
+		Where("id = ? AND status = ?", referral.ID, model.ReferralStatusPending).
+		Updates(map[string]interface{}{
+			"status":           referral.Status,
+			"referrer_credits": referral.ReferrerCredits,
+			"referee_credits":  referral.RefereeCredits,
+			"payment_ref":      referral.PaymentRef,
+			"rewarded_at":      referral.RewardedAt,
+			"updated_at":       gorm.Expr("NOW()"),
+		})
+	if result.Error != nil {
+		r.logger.Error("Failed to update referral status",
+			zap.Int64("referral_id", referral.ID),
+			zap.String("status", referral.Status),
+			zap.Error(result.Error))
+		return false, fmt.Errorf("failed to update referral: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
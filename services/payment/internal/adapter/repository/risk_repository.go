@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+)
+
+// riskRepository implements the RiskRepository interface
+type riskRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewRiskRepository creates a new risk repository
+func NewRiskRepository(db *gorm.DB, logger *zap.Logger) repository.RiskRepository {
+	return &riskRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateAssessment records a risk decision
+func (r *riskRepository) CreateAssessment(ctx context.Context, assessment *model.RiskAssessment) error {
+	if err := r.db.WithContext(ctx).Create(assessment).Error; err != nil {
+		r.logger.Error("Failed to record risk assessment",
+			zap.String("universal_id", assessment.UniversalID.String()),
+			zap.String("action", assessment.Action),
+			zap.String("decision", assessment.Decision),
+			zap.Error(err))
+		return fmt.Errorf("failed to record risk assessment: %w", err)
+	}
+	return nil
+}
+
+// CountAttempts counts the assessments made since a time for a user, card fingerprint or IP address
+func (r *riskRepository) CountAttempts(ctx context.Context, key repository.RiskAttemptKey, value string, since time.Time) (int64, error) {
+	switch key {
+	case repository.RiskAttemptsByUser, repository.RiskAttemptsByCard, repository.RiskAttemptsByIP:
+	default:
+		return 0, fmt.Errorf("unknown risk attempt key: %s", key)
+	}
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.RiskAssessment{}).
+		Where(fmt.Sprintf("%s = ?", key), value).
+		Where("created_at >= ?", since).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error("Failed to count risk assessments",
+			zap.String("key", string(key)),
+			zap.Time("since", since),
+			zap.Error(err))
+		return 0, fmt.Errorf("failed to count risk assessments: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountFailedPayments counts the failed payments of a user created since a time
+func (r *riskRepository) CountFailedPayments(ctx context.Context, universalID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.Payment{}).
+		Where("status = ? AND universal_id = ?", provider.PaymentStatusFailed, universalID).
+		Where("created_at >= ?", since).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error("Failed to count failed payments",
+			zap.String("universal_id", universalID.String()),
+			zap.Time("since", since),
+			zap.Error(err))
+		return 0, fmt.Errorf("failed to count failed payments: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListAssessments retrieves assessments matching the filters, newest first
+func (r *riskRepository) ListAssessments(ctx context.Context, filters dto.RiskAssessmentFilters) ([]*model.RiskAssessment, error) {
+	query := r.db.WithContext(ctx).Model(&model.RiskAssessment{})
+	if filters.UniversalID != nil {
+		query = query.Where("universal_id = ?", *filters.UniversalID)
+	}
+	if filters.Decision != "" {
+		query = query.Where("decision = ?", filters.Decision)
+	}
+	if !filters.Since.IsZero() {
+		query = query.Where("created_at >= ?", filters.Since)
+	}
+
+	var assessments []*model.RiskAssessment
+	err := query.Order("created_at DESC, id DESC").Limit(filters.Limit).Find(&assessments).Error
+	if err != nil {
+		r.logger.Error("Failed to list risk assessments",
+			zap.String("decision", filters.Decision),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to list risk assessments: %w", err)
+	}
+
+	return assessments, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	domainRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type serviceProviderRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewServiceProviderRepository creates a new service provider repository instance
+func NewServiceProviderRepository(db *gorm.DB, logger *zap.Logger) domainRepo.ServiceProviderRepository {
+	return &serviceProviderRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *serviceProviderRepository) GetByCode(ctx context.Context, code string) (*model.ServiceProvider, error) {
+	var provider model.ServiceProvider
+	err := r.db.WithContext(ctx).
+		Where("code = ?", code).
+		First(&provider).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get service provider",
+			zap.String("code", code),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get service provider: %w", err)
+	}
+	return &provider, nil
+}
+
+func (r *serviceProviderRepository) List(ctx context.Context) ([]*model.ServiceProvider, error) {
+	var providers []*model.ServiceProvider
+	err := r.db.WithContext(ctx).
+		Order("code ASC").
+		Find(&providers).Error
+	if err != nil {
+		r.logger.Error("Failed to list service providers", zap.Error(err))
+		return nil, fmt.Errorf("failed to list service providers: %w", err)
+	}
+	return providers, nil
+}
+
+func (r *serviceProviderRepository) Create(ctx context.Context, provider *model.ServiceProvider) error {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(provider)
+	if result.Error != nil {
+		r.logger.Error("Failed to create service provider",
+			zap.String("code", provider.Code),
+			zap.Error(result.Error))
+		return fmt.Errorf("failed to create service provider: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrServiceProviderExists
+	}
+	return nil
+}
+
+func (r *serviceProviderRepository) Update(ctx context.Context, provider *model.ServiceProvider) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.ServiceProvider{}).
+		Where("code = ?", provider.Code).
+		Updates(map[string]interface{}{
+			"display_name":          provider.DisplayName,
+			"client_urls":           provider.ClientURLs,
+			"is_active":             provider.IsActive,
+			"encrypted_credentials": provider.EncryptedCredentials,
+			"credentials_iv":        provider.CredentialsIV,
+			"updated_at":            gorm.Expr("NOW()"),
+		})
+	if result.Error != nil {
+		r.logger.Error("Failed to update service provider",
+			zap.String("code", provider.Code),
+			zap.Error(result.Error))
+		return fmt.Errorf("failed to update service provider: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrServiceProviderNotFound
+	}
+	return nil
+}
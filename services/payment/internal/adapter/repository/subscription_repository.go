@@ -122,6 +122,22 @@ func (r *subscriptionRepository) Update(ctx context.Context, subscription *entit
 		updates["canceled_at"] = &now
 	}
 
+	if subscription.Quantity > 0 {
+		updates["quantity"] = subscription.Quantity
+	}
+
+	// Plan changes move the subscription to another price and possibly another product
+	if subscription.PriceID != "" {
+		updates["price_id"] = subscription.PriceID
+	}
+	if subscription.PlanID != nil && *subscription.PlanID != "" {
+		updates["plan_id"] = *subscription.PlanID
+	}
+
+	if workspaceID, err := uuid.Parse(subscription.WorkspaceID); err == nil {
+		updates["workspace_id"] = workspaceID
+	}
+
 	err = r.db.WithContext(ctx).
 		Model(&model.Subscription{}).
 		Where("provider_subscription_id = ?", subscription.ID).
@@ -269,6 +285,73 @@ func (r *subscriptionRepository) ListByStatus(ctx context.Context, status string
 	return entities, nil
 }
 
+// GetActiveByWorkspaceID retrieves the active subscription whose seats belong to a workspace
+func (r *subscriptionRepository) GetActiveByWorkspaceID(ctx context.Context, workspaceID string) (*entity.Subscription, error) {
+	var sub model.Subscription
+
+	err := r.db.WithContext(ctx).
+		Preload("Plan").
+		Where("workspace_id = ? AND status = ?", workspaceID, model.SubscriptionStatusActive).
+		Order("created_at DESC").
+		First(&sub).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get subscription by workspace ID",
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	return r.modelToEntity(&sub), nil
+}
+
+// GetActiveByUniversalID retrieves the active subscription of a user or workspace
+func (r *subscriptionRepository) GetActiveByUniversalID(ctx context.Context, universalID string) (*entity.Subscription, error) {
+	var sub model.Subscription
+
+	err := r.db.WithContext(ctx).
+		Preload("Plan").
+		Where("universal_id = ? AND status = ?", universalID, model.SubscriptionStatusActive).
+		Order("created_at DESC").
+		First(&sub).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get subscription by universal ID",
+			zap.String("universal_id", universalID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	return r.modelToEntity(&sub), nil
+}
+
+// UpdateQuantity sets the paid seat quantity of a subscription
+func (r *subscriptionRepository) UpdateQuantity(ctx context.Context, subscriptionID string, quantity int) error {
+	err := r.db.WithContext(ctx).
+		Model(&model.Subscription{}).
+		Where("provider_subscription_id = ?", subscriptionID).
+		Updates(map[string]interface{}{
+			"quantity":   quantity,
+			"updated_at": time.Now(),
+		}).Error
+
+	if err != nil {
+		r.logger.Error("Failed to update subscription quantity",
+			zap.String("subscription_id", subscriptionID),
+			zap.Int("quantity", quantity),
+			zap.Error(err))
+		return fmt.Errorf("failed to update subscription quantity: %w", err)
+	}
+
+	return nil
+}
+
 // modelToEntity converts database model to domain entity
 func (r *subscriptionRepository) modelToEntity(m *model.Subscription) *entity.Subscription {
 	if m == nil {
@@ -288,6 +371,17 @@ func (r *subscriptionRepository) modelToEntity(m *model.Subscription) *entity.Su
 		Currency:          m.Currency,
 		Interval:          m.Interval,
 		IntervalCount:     m.IntervalCount,
+		PlanID:            m.PlanID,
+		UniversalID:       m.UniversalID.String(),
+		Quantity:          m.Quantity,
+	}
+
+	if m.PriceID != nil {
+		e.PriceID = *m.PriceID
+	}
+
+	if m.WorkspaceID != nil {
+		e.WorkspaceID = m.WorkspaceID.String()
 	}
 
 	// If subscription item fields are empty, try to populate from plan (for backward compatibility)
@@ -345,6 +439,19 @@ func (r *subscriptionRepository) entityToModel(ctx context.Context, e *entity.Su
 		Currency:               e.Currency,
 		Interval:               e.Interval,
 		IntervalCount:          e.IntervalCount,
+		Quantity:               e.Quantity,
+	}
+
+	if m.Quantity < 1 {
+		m.Quantity = 1
+	}
+
+	if e.PriceID != "" {
+		m.PriceID = &e.PriceID
+	}
+
+	if workspaceID, err := uuid.Parse(e.WorkspaceID); err == nil {
+		m.WorkspaceID = &workspaceID
 	}
 
 	if e.CancelAtPeriodEnd {
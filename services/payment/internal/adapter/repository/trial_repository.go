@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	domainProvider "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
+	domainRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type trialRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewTrialRepository creates a new subscription trial repository instance
+func NewTrialRepository(db *gorm.DB, logger *zap.Logger) domainRepo.TrialRepository {
+	return &trialRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *trialRepository) Create(ctx context.Context, trial *model.SubscriptionTrial) error {
+	// A user can hold one active or converted trial; concurrent starts lose to the unique index
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(trial)
+	if result.Error != nil {
+		r.logger.Error("Failed to create subscription trial",
+			zap.String("universal_id", trial.UniversalID.String()),
+			zap.String("plan_id", trial.PlanID),
+			zap.Error(result.Error))
+		return fmt.Errorf("failed to create subscription trial: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domainErrors.ErrTrialAlreadyUsed
+	}
+	return nil
+}
+
+func (r *trialRepository) GetByID(ctx context.Context, id int64) (*model.SubscriptionTrial, error) {
+	return r.first(ctx, r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+func (r *trialRepository) GetLatestByUniversalID(ctx context.Context, universalID uuid.UUID) (*model.SubscriptionTrial, error) {
+	return r.first(ctx, r.db.WithContext(ctx).
+		Where("universal_id = ?", universalID).
+		Order("created_at DESC"))
+}
+
+func (r *trialRepository) GetByProviderSubscriptionID(ctx context.Context, subscriptionID string) (*model.SubscriptionTrial, error) {
+	return r.first(ctx, r.db.WithContext(ctx).Where("provider_subscription_id = ?", subscriptionID))
+}
+
+func (r *trialRepository) GetActiveByProviderCustomerID(ctx context.Context, provider string, customerID string) (*model.SubscriptionTrial, error) {
+	return r.first(ctx, r.db.WithContext(ctx).
+		Where("provider = ? AND provider_customer_id = ? AND status = ?", provider, customerID, model.TrialStatusActive).
+		Order("created_at DESC"))
+}
+
+func (r *trialRepository) ExistsForUniversalID(ctx context.Context, universalID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.SubscriptionTrial{}).
+		Where("universal_id = ?", universalID).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error("Failed to check trial history for user",
+			zap.String("universal_id", universalID.String()),
+			zap.Error(err))
+		return false, fmt.Errorf("failed to check trial history: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *trialRepository) ExistsForCardFingerprint(ctx context.Context, fingerprint string, cardCompany string, cardLastFour string, excludeID int64) (bool, error) {
+	if fingerprint == "" {
+		return false, nil
+	}
+
+	// Toss trials are corroborated by the card of their billing key, whoever's account it is on
+	sameCard := r.db.
+		Table("billing_keys").
+		Select("1").
+		Where("billing_keys.id = subscription_trials.billing_key_id").
+		Where("UPPER(billing_keys.card_company) = UPPER(?) AND billing_keys.card_last_four = ?", cardCompany, cardLastFour)
+
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.SubscriptionTrial{}).
+		Where("card_fingerprint = ? AND id <> ?", fingerprint, excludeID).
+		Where("provider <> ? OR EXISTS (?)", string(domainProvider.ProviderTypeToss), sameCard).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error("Failed to check trial history for card", zap.Error(err))
+		return false, fmt.Errorf("failed to check trial history: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *trialRepository) ListEndingBefore(ctx context.Context, provider string, before time.Time) ([]*model.SubscriptionTrial, error) {
+	var trials []*model.SubscriptionTrial
+	err := r.db.WithContext(ctx).
+		Where("provider = ? AND status = ? AND will_end_notified_at IS NULL AND ends_at <= ?",
+			provider, model.TrialStatusActive, before).
+		Order("ends_at ASC").
+		Find(&trials).Error
+	if err != nil {
+		r.logger.Error("Failed to list trials ending soon", zap.Error(err))
+		return nil, fmt.Errorf("failed to list trials: %w", err)
+	}
+	return trials, nil
+}
+
+func (r *trialRepository) ListDue(ctx context.Context, provider string, now time.Time, staleBefore time.Time, limit int) ([]*model.SubscriptionTrial, error) {
+	var trials []*model.SubscriptionTrial
+	query := r.db.WithContext(ctx).
+		Where("provider = ? AND ends_at <= ?", provider, now).
+		Where("status = ? OR (status = ? AND updated_at < ?)", model.TrialStatusActive, model.TrialStatusConverting, staleBefore).
+		Order("ends_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&trials).Error; err != nil {
+		r.logger.Error("Failed to list due trials", zap.Error(err))
+		return nil, fmt.Errorf("failed to list trials: %w", err)
+	}
+	return trials, nil
+}
+
+func (r *trialRepository) ClaimForConversion(ctx context.Context, id int64, staleBefore time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&model.SubscriptionTrial{}).
+		Where("id = ?", id).
+		Where("status = ? OR (status = ? AND updated_at < ?)", model.TrialStatusActive, model.TrialStatusConverting, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     model.TrialStatusConverting,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		r.logger.Error("Failed to claim trial for conversion",
+			zap.Int64("trial_id", id),
+			zap.Error(result.Error))
+		return false, fmt.Errorf("failed to claim trial: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *trialRepository) Update(ctx context.Context, id int64, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now()
+	err := r.db.WithContext(ctx).
+		Model(&model.SubscriptionTrial{}).
+		Where("id = ?", id).
+		Updates(updates).Error
+	if err != nil {
+		r.logger.Error("Failed to update subscription trial",
+			zap.Int64("trial_id", id),
+			zap.Error(err))
+		return fmt.Errorf("failed to update subscription trial: %w", err)
+	}
+	return nil
+}
+
+func (r *trialRepository) first(ctx context.Context, query *gorm.DB) (*model.SubscriptionTrial, error) {
+	var trial model.SubscriptionTrial
+	if err := query.First(&trial).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get subscription trial", zap.Error(err))
+		return nil, fmt.Errorf("failed to get subscription trial: %w", err)
+	}
+	return &trial, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+)
+
+// usageRepository implements the UsageRepository interface
+type usageRepository struct {
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+// NewUsageRepository creates a new usage repository
+func NewUsageRepository(db *gorm.DB, logger *zap.Logger) repository.UsageRepository {
+	return &usageRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// refreshRollupsSQL aggregates credit usage per period in KST. Usage is stored as negative amounts.
+const refreshRollupsSQL = `
+INSERT INTO credit_usage_rollups
+    (granularity, period_start, universal_id, actor_id, service_provider, feature_name, credits, usage_count, updated_at)
+SELECT ?, date_trunc(?, created_at AT TIME ZONE 'Asia/Seoul')::date, universal_id,
+       COALESCE(actor_id, universal_id), COALESCE(service_provider, ''), COALESCE(feature_name, ''),
+       -SUM(amount), COUNT(*), NOW()
+FROM credit_transactions
+WHERE transaction_type = ? AND created_at >= ?
+GROUP BY 2, 3, 4, 5, 6
+ON CONFLICT (granularity, period_start, universal_id, actor_id, service_provider, feature_name)
+DO UPDATE SET credits = EXCLUDED.credits, usage_count = EXCLUDED.usage_count, updated_at = EXCLUDED.updated_at`
+
+// RefreshRollups recomputes rollups of every period starting at or after from
+func (r *usageRepository) RefreshRollups(ctx context.Context, granularity model.UsageGranularity, from time.Time) (int64, error) {
+	start := granularity.PeriodStart(from)
+
+	result := r.db.WithContext(ctx).Exec(refreshRollupsSQL,
+		string(granularity), string(granularity), string(model.TransactionTypeCreditUsage), start)
+	if result.Error != nil {
+		r.logger.Error("Failed to refresh usage rollups",
+			zap.String("granularity", string(granularity)),
+			zap.Time("from", start),
+			zap.Error(result.Error))
+		return 0, fmt.Errorf("failed to refresh usage rollups: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// ListRollups retrieves rollups matching the filters
+func (r *usageRepository) ListRollups(ctx context.Context, filters dto.UsageFilters) ([]*model.UsageRollup, error) {
+	var rollups []*model.UsageRollup
+
+	// period_start is a date, so compare against calendar dates in KST
+	query := r.db.WithContext(ctx).
+		Where("granularity = ?", string(filters.Granularity)).
+		Where("period_start >= ?::date", filters.From.In(model.UsageLocation).Format("2006-01-02")).
+		Where("period_start < ?::date", filters.To.In(model.UsageLocation).Format("2006-01-02"))
+
+	if filters.UniversalID != nil {
+		query = query.Where("universal_id = ?", *filters.UniversalID)
+	}
+	if filters.ActorID != nil {
+		query = query.Where("actor_id = ?", *filters.ActorID)
+	}
+	if filters.ServiceProvider != "" {
+		query = query.Where("service_provider = ?", filters.ServiceProvider)
+	}
+	if filters.FeatureName != "" {
+		query = query.Where("feature_name = ?", filters.FeatureName)
+	}
+
+	err := query.
+		Order("period_start ASC").
+		Order("universal_id ASC").
+		Order("feature_name ASC").
+		Order("actor_id ASC").
+		Find(&rollups).Error
+	if err != nil {
+		r.logger.Error("Failed to list usage rollups",
+			zap.String("granularity", string(filters.Granularity)),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to list usage rollups: %w", err)
+	}
+
+	return rollups, nil
+}
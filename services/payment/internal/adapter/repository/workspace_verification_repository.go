@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
@@ -316,4 +318,64 @@ func (r *SupabaseWorkspaceVerificationRepository) VerifyWorkspaceMembership(
 		zap.Duration("json_parse_duration", parseDuration))
 
 	return member, nil
-}
\ No newline at end of file
+}
+
+// CountWorkspaceMembers returns the number of members in a workspace using the exact count
+// reported by the Supabase REST API in the Content-Range header
+func (r *SupabaseWorkspaceVerificationRepository) CountWorkspaceMembers(
+	ctx context.Context,
+	workspaceID string,
+) (int, error) {
+	params := url.Values{}
+	params.Add("workspace_id", fmt.Sprintf("eq.%s", workspaceID))
+	params.Add("select", "id")
+	queryURL := fmt.Sprintf("%s/rest/v1/workspace_members?%s", r.baseURL, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, queryURL, nil)
+	if err != nil {
+		return 0, domainErrors.NewWorkspaceVerificationError("", workspaceID,
+			fmt.Errorf("failed to create request: %w", err))
+	}
+
+	req.Header.Set("apikey", r.apiKey)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.apiKey))
+	req.Header.Set("Prefer", "count=exact")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		r.logger.Error("SupabaseRepository: Member count request failed",
+			zap.String("workspace_id", workspaceID),
+			zap.Error(err))
+		return 0, domainErrors.NewSupabaseConnectionError("", workspaceID,
+			fmt.Errorf("http request failed: %w", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		r.logger.Warn("SupabaseRepository: Supabase API returned unexpected status for member count",
+			zap.String("workspace_id", workspaceID),
+			zap.Int("status_code", resp.StatusCode))
+		return 0, domainErrors.NewSupabaseConnectionError("", workspaceID,
+			fmt.Errorf("supabase API error: status %d", resp.StatusCode))
+	}
+
+	// Content-Range looks like "0-4/5" or "*/0"
+	contentRange := resp.Header.Get("Content-Range")
+	slash := strings.LastIndex(contentRange, "/")
+	if slash < 0 {
+		return 0, domainErrors.NewSupabaseConnectionError("", workspaceID,
+			fmt.Errorf("missing member count in content range %q", contentRange))
+	}
+
+	count, err := strconv.Atoi(contentRange[slash+1:])
+	if err != nil {
+		return 0, domainErrors.NewSupabaseConnectionError("", workspaceID,
+			fmt.Errorf("invalid member count in content range %q: %w", contentRange, err))
+	}
+
+	r.logger.Debug("SupabaseRepository: Workspace members counted",
+		zap.String("workspace_id", workspaceID),
+		zap.Int("members", count))
+
+	return count, nil
+}
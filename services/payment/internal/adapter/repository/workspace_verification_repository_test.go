@@ -239,4 +239,47 @@ func TestSupabaseWorkspaceVerificationRepository_ContextCancellation(t *testing.
 	if assert.ErrorAs(t, err, &workspaceErr) {
 		assert.Equal(t, domainErrors.ErrTypeSupabaseConnectionFailed, workspaceErr.Type)
 	}
-}
\ No newline at end of file
+}
+
+func TestSupabaseWorkspaceVerificationRepository_CountWorkspaceMembers(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		contentRange  string
+		expectedCount int
+		expectedError bool
+	}{
+		{name: "members counted", status: http.StatusOK, contentRange: "0-4/5", expectedCount: 5},
+		{name: "partial content", status: http.StatusPartialContent, contentRange: "0-0/12", expectedCount: 12},
+		{name: "empty workspace", status: http.StatusOK, contentRange: "*/0", expectedCount: 0},
+		{name: "missing content range", status: http.StatusOK, expectedError: true},
+		{name: "server error", status: http.StatusInternalServerError, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodHead, r.Method)
+				assert.Equal(t, "/rest/v1/workspace_members", r.URL.Path)
+				assert.Equal(t, "eq.workspace-456", r.URL.Query().Get("workspace_id"))
+				assert.Equal(t, "count=exact", r.Header.Get("Prefer"))
+
+				if tt.contentRange != "" {
+					w.Header().Set("Content-Range", tt.contentRange)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			repo := NewSupabaseWorkspaceVerificationRepository(server.URL, "test-api-key", "test-jwt-secret", zap.NewNop())
+
+			count, err := repo.CountWorkspaceMembers(context.Background(), "workspace-456")
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCount, count)
+		})
+	}
+}
@@ -16,6 +16,9 @@ type Config struct {
 	JWT      JWTConfig      `yaml:"jwt"`
 	Email    EmailConfig    `yaml:"email"`
 	Webhook  WebhookConfig  `yaml:"webhook_semolens"`
+	Credits  CreditsConfig  `yaml:"credits"`
+	Pricing  PricingConfig  `yaml:"pricing"`
+	Risk     RiskConfig     `yaml:"risk"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -0,0 +1,25 @@
+package config
+
+// CreditsConfig configures credit transfers between users and the referral program
+type CreditsConfig struct {
+	Transfer TransferConfig `yaml:"transfer"`
+	Referral ReferralConfig `yaml:"referral"`
+}
+
+// TransferConfig bounds credit transfers. Daily limits cover a rolling 24 hours; zero is unlimited.
+type TransferConfig struct {
+	MinAmount         int64 `yaml:"min_amount"`
+	MaxAmount         int64 `yaml:"max_amount"`
+	DailySendLimit    int64 `yaml:"daily_send_limit"`
+	DailySendCount    int   `yaml:"daily_send_count"`
+	DailyReceiveLimit int64 `yaml:"daily_receive_limit"`
+	RequirePaidSender bool  `yaml:"require_paid_sender"` // Only users with a completed payment may send credits
+}
+
+// ReferralConfig configures the credits granted when a referred user makes their first payment
+type ReferralConfig struct {
+	Enabled         bool `yaml:"enabled"`
+	ReferrerCredits int  `yaml:"referrer_credits"`
+	RefereeCredits  int  `yaml:"referee_credits"`
+	MaxRewards      int  `yaml:"max_rewards"` // Rewarded referrals per referrer; zero is unlimited
+}
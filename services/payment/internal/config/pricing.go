@@ -0,0 +1,16 @@
+package config
+
+// PricingConfig configures the currency plans are priced in for a caller
+type PricingConfig struct {
+	DefaultCurrency   string            `yaml:"default_currency"`   // Used when the caller's country has no priced currency (default "USD")
+	CountryCurrencies map[string]string `yaml:"country_currencies"` // ISO country code to currency, overriding the built-in table
+	GeoService        GeoServiceConfig  `yaml:"geo_service"`
+}
+
+// GeoServiceConfig locates the geo service used to resolve the caller's location
+type GeoServiceConfig struct {
+	Addr            string `yaml:"addr"`              // host:port of the geo gRPC server; blank disables location lookups
+	TimeoutMs       int    `yaml:"timeout_ms"`        // Per lookup (default 300)
+	CacheTTLSeconds int    `yaml:"cache_ttl_seconds"` // How long a caller's location is reused (default 600)
+	CooldownSeconds int    `yaml:"cooldown_seconds"`  // Lookups skipped after the geo service fails (default 30)
+}
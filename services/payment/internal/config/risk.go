@@ -0,0 +1,24 @@
+package config
+
+// RiskConfig configures the risk checks of checkouts, subscriptions and card registrations.
+// Requests scoring at least VerifyScore need extra verification and those scoring at least
+// BlockScore are rejected.
+type RiskConfig struct {
+	Enabled     bool             `yaml:"enabled"`
+	VerifyScore int              `yaml:"verify_score"` // Default 50
+	BlockScore  int              `yaml:"block_score"`  // Default 80
+	Rules       []RiskRuleConfig `yaml:"rules"`        // The built-in rules are used when empty
+}
+
+// RiskRuleConfig adds Score to a request when its signal fires. Signals are anonymous_ip, vpn, tor,
+// country, country_mismatch, user_velocity, card_velocity, ip_velocity and failed_payments.
+type RiskRuleConfig struct {
+	Name          string   `yaml:"name"` // Defaults to the signal
+	Signal        string   `yaml:"signal"`
+	Score         int      `yaml:"score"`
+	Decision      string   `yaml:"decision"`       // verify or block forces at least that decision when the rule fires
+	Limit         int      `yaml:"limit"`          // Velocity and failed_payments: fires once this many earlier attempts or failures are in the window
+	WindowMinutes int      `yaml:"window_minutes"` // Velocity and failed_payments
+	Countries     []string `yaml:"countries"`      // country: fires for requests from these ISO countries
+	Actions       []string `yaml:"actions"`        // Restricts the rule to checkout, subscription, billing_key_issue or billing_charge
+}
@@ -8,6 +8,9 @@ type ServerConfig struct {
 type HTTPConfig struct {
 	Host string `yaml:"host"`
 	Port int    `yaml:"port"`
+	// Proxies (CIDRs or IPs) whose X-Forwarded-For, X-Real-IP and Forwarded headers name the client.
+	// When blank, forwarding headers are ignored and the connecting address is the client.
+	TrustedProxies []string `yaml:"trusted_proxies"`
 }
 
 type GRPCConfig struct {
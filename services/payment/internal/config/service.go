@@ -9,6 +9,8 @@ type ServiceConfig struct {
 	StripeSecretKey     string         `yaml:"stripe_secret_key"`
 	StripeWebhookSecret string         `yaml:"stripe_webhook_secret"`
 	EnableTestEndpoints bool           `yaml:"enable_test_endpoints"`
+	AdminAPIKey         string         `yaml:"admin_api_key"`    // Enables /api/v1/admin routes when set
+	ServiceProvider     string         `yaml:"service_provider"` // Default service provider, served with the settings above (default "semo")
 	Supabase            SupabaseConfig `yaml:"supabase"`
 	Toss                TossConfig     `yaml:"toss"`
 }
@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// CohortActivity counts the customers of a cohort who paid in a month. A customer's cohort is the
+// month (KST) of their first completed payment.
+type CohortActivity struct {
+	Cohort    time.Time
+	Month     time.Time
+	Customers int64
+}
@@ -4,15 +4,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 )
 
 // CreditTransactionDTO represents a simplified credit transaction for API responses
 type CreditTransactionDTO struct {
-	TransactionType string    `json:"transaction_type"`
-	Amount          string    `json:"amount"`
-	BalanceAfter    string    `json:"balance_after"`
-	Description     string    `json:"description,omitempty"`
-	CreatedAt       time.Time `json:"created_at"`
+	TransactionType string     `json:"transaction_type"`
+	Amount          string     `json:"amount"`
+	BalanceAfter    string     `json:"balance_after"`
+	Description     string     `json:"description,omitempty"`
+	TransferID      *uuid.UUID `json:"transfer_id,omitempty"`     // Set on both entries of a transfer
+	CounterpartyID  *uuid.UUID `json:"counterparty_id,omitempty"` // Other side of a transfer
+	CreatedAt       time.Time  `json:"created_at"`
 }
 
 // TransactionListResponse represents the paginated transaction list response
@@ -22,50 +26,85 @@ type TransactionListResponse struct {
 }
 
 // PaginationInfo contains pagination metadata
-type PaginationInfo struct {
-	Total   int64 `json:"total"`
-	Limit   int   `json:"limit"`
-	Offset  int   `json:"offset"`
-	HasMore bool  `json:"has_more"`
-}
+type PaginationInfo = entity.CursorPaginationMeta
+
+// Transaction list sort keys
+const (
+	TransactionSortCreatedAt = "created_at"
+	TransactionSortAmount    = "amount"
+)
 
-// TransactionFilters contains query filters for transaction retrieval
+// TransactionFilters contains query filters for transaction retrieval.
+// Transactions are listed newest first unless Ascending is set.
 type TransactionFilters struct {
 	UserID          uuid.UUID // Note: This still represents the Universal ID value
 	Limit           int
-	Offset          int
+	Cursor          *entity.Cursor // Position after the last transaction of the previous page
+	Sort            string
+	Ascending       bool
 	StartDate       *time.Time
 	EndDate         *time.Time
 	TransactionType *string
+	ServiceProvider string // Blank lists the transactions of every service provider
 }
 
 // SetDefaults sets default values for pagination
 func (f *TransactionFilters) SetDefaults() {
-	if f.Limit == 0 {
-		f.Limit = 20
-	}
-	if f.Limit > 100 {
-		f.Limit = 100
+	f.Limit = entity.NormalizePageSize(f.Limit)
+	if f.Sort == "" {
+		f.Sort = TransactionSortCreatedAt
 	}
-	if f.Offset < 0 {
-		f.Offset = 0
+}
+
+// TransactionCursor returns the cursor positioned after tx in the sort order of the filters
+func (f *TransactionFilters) TransactionCursor(tx *model.CreditTransaction) *entity.Cursor {
+	value := tx.CreatedAt.UTC().Format(time.RFC3339Nano)
+	if f.Sort == TransactionSortAmount {
+		value = tx.Amount.String()
 	}
+	return &entity.Cursor{Sort: f.Sort, Desc: !f.Ascending, Value: value, ID: tx.ID}
 }
 
 // UseCreditRequest represents the request body for using credits
 type UseCreditRequest struct {
-	Amount          string                 `json:"amount" validate:"required"`
+	Amount          string                 `json:"amount,omitempty"` // Omit to price the usage from the feature catalog
 	FeatureName     string                 `json:"feature_name" validate:"required,min=1,max=100"`
 	Description     string                 `json:"description" validate:"required,min=1,max=500"`
 	UsageMetadata   map[string]interface{} `json:"usage_metadata,omitempty"`
 	IdempotencyKey  *string                `json:"idempotency_key,omitempty" validate:"omitempty,uuid4"`
-	ServiceProvider string                 `json:"service_provider" validate:"required"`
+	ServiceProvider string                 `json:"service_provider,omitempty" validate:"max=50"` // Defaults to the X-Service-Provider header
 }
 
 // UseCreditResponse represents the response for credit usage
 type UseCreditResponse struct {
 	Success       bool   `json:"success"`
 	TransactionID int64  `json:"transaction_id"`
+	Amount        string `json:"amount"`
+	PriceVersion  *int   `json:"price_version,omitempty"` // Set when the amount came from the feature catalog
 	BalanceAfter  string `json:"balance_after"`
 	Message       string `json:"message"`
 }
+
+// QuoteCreditRequest represents the request body for pricing a credit usage from the catalog
+type QuoteCreditRequest struct {
+	FeatureName     string                 `json:"feature_name" validate:"required,min=1,max=100"`
+	UsageMetadata   map[string]interface{} `json:"usage_metadata,omitempty"`
+	ServiceProvider string                 `json:"service_provider,omitempty" validate:"max=50"` // Defaults to the X-Service-Provider header
+}
+
+// UseCreditBatchItem represents one usage of a batch credit request
+type UseCreditBatchItem struct {
+	Amount         string                 `json:"amount,omitempty"` // Omit to price the usage from the feature catalog
+	FeatureName    string                 `json:"feature_name" validate:"required,min=1,max=100"`
+	Description    string                 `json:"description,omitempty" validate:"max=500"`
+	UsageMetadata  map[string]interface{} `json:"usage_metadata,omitempty"`
+	IdempotencyKey *string                `json:"idempotency_key,omitempty" validate:"omitempty,uuid4"`
+}
+
+// UseCreditBatchRequest represents the request body for using credits in a batch.
+// Atomic batches are applied entirely or not at all; otherwise each item succeeds or fails on its own.
+type UseCreditBatchRequest struct {
+	ServiceProvider string               `json:"service_provider,omitempty" validate:"max=50"` // Defaults to the X-Service-Provider header
+	Atomic          bool                 `json:"atomic"`
+	Items           []UseCreditBatchItem `json:"items" validate:"required,min=1,dive"`
+}
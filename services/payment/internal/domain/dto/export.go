@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+// ExportFilters contains filters for streaming records to an export
+type ExportFilters struct {
+	From            time.Time // Inclusive
+	To              time.Time // Exclusive
+	ServiceProvider string    // Blank exports the records of every service provider
+}
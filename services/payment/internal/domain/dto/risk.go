@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RiskAssessmentFilters contains filters for listing risk assessments, newest first
+type RiskAssessmentFilters struct {
+	UniversalID *uuid.UUID // Nil lists the assessments of every user
+	Decision    string     // Blank lists every decision
+	Since       time.Time  // Zero lists from the beginning
+	Limit       int
+}
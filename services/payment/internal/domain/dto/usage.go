@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+)
+
+// UsageFilters contains query filters for usage rollup retrieval
+type UsageFilters struct {
+	Granularity     model.UsageGranularity
+	From            time.Time  // Start of the first period, inclusive
+	To              time.Time  // Start of the period after the last one, exclusive
+	UniversalID     *uuid.UUID // nil selects every user and workspace
+	ActorID         *uuid.UUID // nil selects every acting user
+	ServiceProvider string
+	FeatureName     string
+}
@@ -1,62 +1,72 @@
 package entity
 
-// PaginationParams represents pagination request parameters
-type PaginationParams struct {
-	Page  int `json:"page" query:"page"`
-	Limit int `json:"limit" query:"limit"`
-}
-
-// PaginationMeta represents pagination metadata in responses
-type PaginationMeta struct {
-	CurrentPage int   `json:"current_page"`
-	PerPage     int   `json:"per_page"`
-	Total       int64 `json:"total"`
-	TotalPages  int   `json:"total_pages"`
-}
+import (
+	"encoding/base64"
+	"encoding/json"
 
-// PaginatedPaymentsResponse represents paginated payment response
-type PaginatedPaymentsResponse struct {
-	Data       []*Payment     `json:"data"`
-	Pagination PaginationMeta `json:"pagination"`
-}
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+)
 
 // Pagination constants
 const (
 	DefaultPageSize = 20
 	MaxPageSize     = 100
 	MinPageSize     = 1
-	DefaultPage     = 1
 )
 
-// Validate validates and normalizes pagination parameters
-func (p *PaginationParams) Validate() {
-	if p.Page < 1 {
-		p.Page = DefaultPage
+// Cursor is the position after the last item of a page in a keyset-paginated list. Clients receive
+// it encoded as an opaque string and send it back to fetch the next page.
+type Cursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d,omitempty"`
+	Value string `json:"v"` // Sort key of the last item
+	ID    int64  `json:"i"` // ID of the last item, breaking ties of the sort key
+}
+
+// Encode returns the opaque string form of the cursor
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor issued for the given sort order; others fail with ErrInvalidCursor
+func DecodeCursor(encoded string, sort string, desc bool) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, domainErrors.ErrInvalidCursor
 	}
-	
-	if p.Limit < MinPageSize {
-		p.Limit = DefaultPageSize
-	} else if p.Limit > MaxPageSize {
-		p.Limit = MaxPageSize
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, domainErrors.ErrInvalidCursor
+	}
+	if cursor.Sort != sort || cursor.Desc != desc || cursor.Value == "" {
+		return nil, domainErrors.ErrInvalidCursor
 	}
-}
 
-// CalculateOffset calculates the database offset from page and limit
-func (p *PaginationParams) CalculateOffset() int {
-	return (p.Page - 1) * p.Limit
+	return &cursor, nil
 }
 
-// NewPaginationMeta creates pagination metadata from parameters and total count
-func NewPaginationMeta(page, limit int, total int64) PaginationMeta {
-	totalPages := int(total) / limit
-	if int(total)%limit > 0 {
-		totalPages++
+// NormalizePageSize returns limit bounded to the allowed page sizes, defaulting to DefaultPageSize
+func NormalizePageSize(limit int) int {
+	if limit < MinPageSize {
+		return DefaultPageSize
 	}
-	
-	return PaginationMeta{
-		CurrentPage: page,
-		PerPage:     limit,
-		Total:       total,
-		TotalPages:  totalPages,
+	if limit > MaxPageSize {
+		return MaxPageSize
 	}
-}
\ No newline at end of file
+	return limit
+}
+
+// CursorPaginationMeta represents keyset pagination metadata in responses
+type CursorPaginationMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"` // Pass as cursor to fetch the next page
+	HasMore    bool   `json:"has_more"`
+}
+
+// PaginatedPaymentsResponse represents paginated payment response
+type PaginatedPaymentsResponse struct {
+	Data       []*Payment           `json:"data"`
+	Pagination CursorPaginationMeta `json:"pagination"`
+}
@@ -10,8 +10,10 @@ type Payment struct {
 	Status        PaymentStatus          `json:"status"`
 	Method        PaymentMethod          `json:"method"`
 	TransactionID string                 `json:"transaction_id"`
+	Provider      string                 `json:"provider,omitempty"` // Payment gateway, e.g. "stripe" or "toss"
 	Description   string                 `json:"description"`
 	Metadata      map[string]interface{} `json:"metadata"`
+	CashReceipt   *CashReceipt           `json:"cash_receipt,omitempty"`
 	CreatedAt     time.Time              `json:"created_at"`
 	UpdatedAt     time.Time              `json:"updated_at"`
 }
@@ -35,3 +37,29 @@ const (
 	PaymentMethodWallet PaymentMethod = "wallet"
 	PaymentMethodCrypto PaymentMethod = "crypto"
 )
+
+// CashReceipt represents the cash receipt (현금영수증) attached to a payment
+type CashReceipt struct {
+	Type             string     `json:"type"`
+	Identifier       string     `json:"-"`                    // Customer identity number used for issuance
+	MaskedIdentifier string     `json:"identifier,omitempty"` // Identity number safe for display
+	Status           string     `json:"status"`
+	ReceiptKey       string     `json:"receipt_key,omitempty"`
+	IssueNumber      string     `json:"issue_number,omitempty"`
+	ReceiptURL       string     `json:"receipt_url,omitempty"`
+	IssuedAt         *time.Time `json:"issued_at,omitempty"`
+	CanceledAt       *time.Time `json:"canceled_at,omitempty"`
+	CanceledAmount   int64      `json:"canceled_amount,omitempty"` // Total cancelled so far, including partial cancellations
+}
+
+type CashReceiptStatus string
+
+const (
+	CashReceiptStatusRequested CashReceiptStatus = "requested"
+	CashReceiptStatusIssued    CashReceiptStatus = "issued"
+	CashReceiptStatusFailed    CashReceiptStatus = "failed"
+	CashReceiptStatusCanceled  CashReceiptStatus = "canceled"
+
+	// CashReceiptStatusPartiallyCanceled is an issued receipt whose amount was reduced by partial cancellations
+	CashReceiptStatusPartiallyCanceled CashReceiptStatus = "partially_canceled"
+)
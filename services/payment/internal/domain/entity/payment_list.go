@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+)
+
+// Payment list sort keys
+const (
+	PaymentSortCreatedAt = "created_at"
+	PaymentSortAmount    = "amount"
+)
+
+// PaymentListParams filters and orders a user's payments. Pages are fetched with keyset pagination
+// from the position of Cursor, which was issued for the same sort order.
+type PaymentListParams struct {
+	UniversalID string
+	Statuses    []PaymentStatus
+	StartDate   *time.Time
+	EndDate     *time.Time
+	MinAmount   *int64 // In the smallest currency unit, like Payment.Amount
+	MaxAmount   *int64
+	PlanID      string
+	Provider    string
+	Sort        string
+	Descending  bool
+	Limit       int
+	Cursor      *Cursor
+}
+
+// PaymentCursor returns the cursor positioned after payment in the given sort order
+func PaymentCursor(payment *Payment, sort string, desc bool) *Cursor {
+	id, _ := strconv.ParseInt(payment.ID, 10, 64)
+
+	value := payment.CreatedAt.UTC().Format(time.RFC3339Nano)
+	if sort == PaymentSortAmount {
+		value = strconv.FormatInt(int64(payment.Amount), 10)
+	}
+
+	return &Cursor{Sort: sort, Desc: desc, Value: value, ID: id}
+}
+
+// IsValid reports whether s is a known payment status
+func (s PaymentStatus) IsValid() bool {
+	switch s {
+	case PaymentStatusPending, PaymentStatusProcessing, PaymentStatusCompleted,
+		PaymentStatusFailed, PaymentStatusCanceled, PaymentStatusRefunded:
+		return true
+	}
+	return false
+}
@@ -15,6 +15,10 @@ type Subscription struct {
 	Interval          string    `json:"interval"`
 	IntervalCount     int64     `json:"interval_count"`
 	PlanID            *string   `json:"plan_id,omitempty"` // 추가
+	PriceID           string    `json:"price_id,omitempty"`
+	UniversalID       string    `json:"universal_id,omitempty"`
+	WorkspaceID       string    `json:"workspace_id,omitempty"`
+	Quantity          int       `json:"quantity"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
 }
@@ -34,6 +38,8 @@ type Plan struct {
 	CTA           *PlanCTA     `json:"cta,omitempty"`
 	Benefits      []string     `json:"benefits,omitempty"`
 	Price         *PlanPrice   `json:"price,omitempty"`
+	DisplayPrice  string       `json:"display_price,omitempty"` // Amount formatted for display, e.g. "₩9,900"
+	Currencies    []string     `json:"currencies,omitempty"`    // Every currency the plan is sold in
 }
 
 type PlanSummary struct {
@@ -56,6 +62,7 @@ type PlanCTA struct {
 type PlanPrice struct {
 	Amount   int64  `json:"amount"`
 	Currency string `json:"currency"`
+	Display  string `json:"display,omitempty"`
 }
 
 type WebhookData struct {
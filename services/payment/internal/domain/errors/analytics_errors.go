@@ -0,0 +1,11 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrRevenueSnapshotNotFound indicates that no revenue snapshot was taken for the requested period
+	ErrRevenueSnapshotNotFound = errors.New("no revenue snapshot for the requested period")
+
+	// ErrInvalidAnalyticsRange indicates that the analytics period is empty, in the future or too long
+	ErrInvalidAnalyticsRange = errors.New("invalid analytics period")
+)
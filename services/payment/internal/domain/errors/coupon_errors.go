@@ -0,0 +1,41 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrCouponNotFound indicates that no coupon exists for the given code
+	ErrCouponNotFound = errors.New("coupon not found")
+
+	// ErrCouponInactive indicates that the coupon has been disabled
+	ErrCouponInactive = errors.New("coupon is not active")
+
+	// ErrCouponNotYetValid indicates that the coupon's validity window has not started
+	ErrCouponNotYetValid = errors.New("coupon is not yet valid")
+
+	// ErrCouponExpired indicates that the coupon's validity window has ended
+	ErrCouponExpired = errors.New("coupon has expired")
+
+	// ErrCouponUsageLimitReached indicates that the coupon has no redemptions left
+	ErrCouponUsageLimitReached = errors.New("coupon usage limit reached")
+
+	// ErrCouponUserLimitReached indicates that the user has used the coupon too many times
+	ErrCouponUserLimitReached = errors.New("coupon already used by this user")
+
+	// ErrCouponPlanNotApplicable indicates that the coupon cannot be used with the selected plan
+	ErrCouponPlanNotApplicable = errors.New("coupon does not apply to this plan")
+
+	// ErrCouponCurrencyMismatch indicates that a fixed-amount coupon is in a different currency
+	ErrCouponCurrencyMismatch = errors.New("coupon currency does not match payment currency")
+
+	// ErrCouponAmountTooLow indicates that the discount would leave nothing to charge
+	ErrCouponAmountTooLow = errors.New("discounted amount is below the minimum charge")
+
+	// ErrCouponProviderNotSupported indicates that the coupon type cannot be used with the payment provider
+	ErrCouponProviderNotSupported = errors.New("coupon type is not supported by this payment provider")
+
+	// ErrInvalidCoupon indicates that a coupon definition is incomplete or inconsistent
+	ErrInvalidCoupon = errors.New("invalid coupon")
+
+	// ErrCouponCodeTaken indicates that another coupon already uses the code
+	ErrCouponCodeTaken = errors.New("coupon code already exists")
+)
@@ -0,0 +1,17 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrCreditBatchRejected indicates that an atomic batch was not applied because one of its usages failed
+	ErrCreditBatchRejected = errors.New("credit usage batch rejected")
+
+	// ErrIdempotencyKeyConflict indicates that an idempotency key was already used for another balance
+	ErrIdempotencyKeyConflict = errors.New("idempotency key already used by another request")
+
+	// ErrCreditBatchEmpty indicates that a batch has no usages
+	ErrCreditBatchEmpty = errors.New("credit usage batch is empty")
+
+	// ErrCreditBatchTooLarge indicates that a batch exceeds the maximum number of usages
+	ErrCreditBatchTooLarge = errors.New("too many usages in batch")
+)
@@ -0,0 +1,14 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrInvalidExportDataset indicates an export of something other than payments, refunds or credit transactions
+	ErrInvalidExportDataset = errors.New("dataset must be payments, refunds or credit_transactions")
+
+	// ErrInvalidExportFormat indicates an export format other than csv or jsonl
+	ErrInvalidExportFormat = errors.New("format must be csv or jsonl")
+
+	// ErrInvalidExportRange indicates that the export date range is missing, empty or too long
+	ErrInvalidExportRange = errors.New("invalid export date range")
+)
@@ -0,0 +1,8 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrGeoServiceUnavailable indicates that geo lookups are suspended after the geo service failed
+	ErrGeoServiceUnavailable = errors.New("geo service is unavailable")
+)
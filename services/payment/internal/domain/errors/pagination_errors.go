@@ -0,0 +1,6 @@
+package errors
+
+import "errors"
+
+// ErrInvalidCursor indicates a pagination cursor that is malformed or was issued for another sort order
+var ErrInvalidCursor = errors.New("invalid cursor")
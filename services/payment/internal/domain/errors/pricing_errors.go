@@ -0,0 +1,14 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrFeaturePriceNotFound indicates that the catalog has no price for the feature
+	ErrFeaturePriceNotFound = errors.New("no price configured for feature")
+
+	// ErrFeaturePriceUnitsMissing indicates that the usage metadata lacks the units the feature is priced by
+	ErrFeaturePriceUnitsMissing = errors.New("usage metadata is missing the priced units")
+
+	// ErrInvalidFeaturePrice indicates that a catalog entry is incomplete or inconsistent
+	ErrInvalidFeaturePrice = errors.New("invalid feature price")
+)
@@ -0,0 +1,8 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrInvalidReconciliationRange indicates that the reconciliation period is empty or too long
+	ErrInvalidReconciliationRange = errors.New("invalid reconciliation period")
+)
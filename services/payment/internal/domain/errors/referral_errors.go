@@ -0,0 +1,20 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrReferralProgramDisabled indicates that the referral program is turned off
+	ErrReferralProgramDisabled = errors.New("referral program is disabled")
+
+	// ErrReferralCodeNotFound indicates that no user owns the given referral code
+	ErrReferralCodeNotFound = errors.New("referral code not found")
+
+	// ErrSelfReferral indicates that a user tried to redeem their own referral code
+	ErrSelfReferral = errors.New("cannot redeem your own referral code")
+
+	// ErrAlreadyReferred indicates that the user already redeemed a referral code
+	ErrAlreadyReferred = errors.New("referral code already redeemed")
+
+	// ErrReferralNotEligible indicates that the user cannot be referred, e.g. they already paid
+	ErrReferralNotEligible = errors.New("account is not eligible for referral")
+)
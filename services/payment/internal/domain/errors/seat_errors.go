@@ -0,0 +1,17 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrSeatSubscriptionNotFound indicates that the workspace has no active seat-based subscription
+	ErrSeatSubscriptionNotFound = errors.New("no active seat-based subscription for workspace")
+
+	// ErrSeatWorkspaceRequired indicates that a seat-based plan was requested outside of a workspace
+	ErrSeatWorkspaceRequired = errors.New("seat-based plans require a workspace")
+
+	// ErrSeatLimitReached indicates that all paid seats of the workspace are in use
+	ErrSeatLimitReached = errors.New("all paid seats are in use")
+
+	// ErrSeatPaymentFailed indicates that the prorated charge for additional seats could not be collected
+	ErrSeatPaymentFailed = errors.New("payment for additional seats failed")
+)
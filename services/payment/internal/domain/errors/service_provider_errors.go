@@ -0,0 +1,30 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrServiceProviderNotFound indicates that no active service provider has the given code
+	ErrServiceProviderNotFound = errors.New("service provider not found")
+
+	// ErrServiceProviderMismatch indicates that a request body names a different service provider
+	// than its X-Service-Provider header
+	ErrServiceProviderMismatch = errors.New("service provider does not match the request header")
+
+	// ErrServiceProviderExists indicates that a service provider with the given code already exists
+	ErrServiceProviderExists = errors.New("service provider already exists")
+
+	// ErrInvalidServiceProviderCode indicates a code that is not a lowercase slug
+	ErrInvalidServiceProviderCode = errors.New("invalid service provider code")
+
+	// ErrCredentialEncryptionUnavailable indicates that credentials cannot be stored because
+	// no encryption key is configured
+	ErrCredentialEncryptionUnavailable = errors.New("credential encryption is not configured")
+
+	// ErrServiceProviderCredentialsMissing indicates that the service provider has no secret for
+	// the payment gateway a request needs
+	ErrServiceProviderCredentialsMissing = errors.New("payment gateway credentials not configured for service provider")
+
+	// ErrBillingKeyServiceProviderMismatch indicates a charge for another service provider than the
+	// one whose Toss account issued the billing key
+	ErrBillingKeyServiceProviderMismatch = errors.New("billing key was registered for another service provider")
+)
@@ -0,0 +1,20 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrSelfTransfer indicates that a user tried to transfer credits to themselves
+	ErrSelfTransfer = errors.New("cannot transfer credits to the same account")
+
+	// ErrInvalidTransferAmount indicates that the amount is outside the allowed range or too precise
+	ErrInvalidTransferAmount = errors.New("invalid transfer amount")
+
+	// ErrTransferLimitExceeded indicates that the transfer would exceed a rolling 24-hour limit
+	ErrTransferLimitExceeded = errors.New("credit transfer limit exceeded")
+
+	// ErrTransferNotAllowed indicates that the sender may not transfer credits, e.g. before their first payment
+	ErrTransferNotAllowed = errors.New("credit transfers are not allowed for this account")
+
+	// ErrTransferRecipientNotFound indicates that the recipient is neither a known customer nor a workspace
+	ErrTransferRecipientNotFound = errors.New("credit transfer recipient not found")
+)
@@ -0,0 +1,17 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrTrialNotAvailable indicates that the plan does not offer a free trial
+	ErrTrialNotAvailable = errors.New("plan does not offer a free trial")
+
+	// ErrTrialAlreadyUsed indicates that the user has already started a free trial
+	ErrTrialAlreadyUsed = errors.New("free trial already used by this user")
+
+	// ErrTrialCardAlreadyUsed indicates that the payment card was already used for another free trial
+	ErrTrialCardAlreadyUsed = errors.New("free trial already used with this card")
+
+	// ErrTrialNotFound indicates that no trial exists for the lookup
+	ErrTrialNotFound = errors.New("trial not found")
+)
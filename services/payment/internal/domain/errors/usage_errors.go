@@ -0,0 +1,11 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrInvalidUsageGranularity indicates that the usage period granularity is not day, week or month
+	ErrInvalidUsageGranularity = errors.New("granularity must be day, week or month")
+
+	// ErrInvalidUsageRange indicates that the usage report range is empty or too long
+	ErrInvalidUsageRange = errors.New("invalid usage report range")
+)
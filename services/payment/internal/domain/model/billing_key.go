@@ -10,11 +10,14 @@ type BillingKey struct {
 	ID                  int64      `gorm:"primaryKey;autoIncrement"`
 	UniversalID         uuid.UUID  `gorm:"column:universal_id;type:uuid;not null"`
 	CustomerKey         string     `gorm:"column:customer_key;uniqueIndex;size:300;not null"`
+	ServiceProvider     string     `gorm:"column:service_provider;size:50;not null;default:''"` // blank for keys of the default provider
 	EncryptedBillingKey string     `gorm:"column:encrypted_billing_key;type:text;not null"`
 	EncryptionIV        string     `gorm:"column:encryption_iv;type:text;not null"`
 	CardLastFour        string     `gorm:"column:card_last_four;size:4"`
 	CardCompany         string     `gorm:"column:card_company;size:50"`
 	CardType            string     `gorm:"column:card_type;size:20"`
+	CardFingerprint     string     `gorm:"column:card_fingerprint;size:64"`
+	CardCountry         string     `gorm:"column:card_country;size:2"` // ISO country of the issuer as reported by Toss, blank when unknown
 	IsActive            bool       `gorm:"column:is_active;default:true"`
 	CreatedAt           time.Time  `gorm:"default:now()"`
 	UpdatedAt           time.Time  `gorm:"default:now()"`
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Coupon discount type constants
+const (
+	CouponTypePercentage   = "percentage"
+	CouponTypeFixedAmount  = "fixed_amount"
+	CouponTypeBonusCredits = "bonus_credits"
+)
+
+// Coupon redemption status constants
+const (
+	CouponRedemptionReserved = "reserved"
+	CouponRedemptionRedeemed = "redeemed"
+	CouponRedemptionReleased = "released"
+)
+
+// Coupon represents a discount or bonus-credit coupon redeemable by promotion code
+type Coupon struct {
+	ID                    int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code                  string          `gorm:"column:code;uniqueIndex;size:100;not null" json:"code"`
+	Name                  string          `gorm:"column:name;size:200;not null" json:"name"`
+	DiscountType          string          `gorm:"column:discount_type;size:20;not null" json:"discount_type"`
+	PercentOff            decimal.Decimal `gorm:"column:percent_off;type:decimal(5,2);default:0" json:"percent_off"`
+	AmountOff             int64           `gorm:"column:amount_off;default:0" json:"amount_off"`
+	Currency              string          `gorm:"column:currency;size:10" json:"currency,omitempty"`
+	BonusCredits          int             `gorm:"column:bonus_credits;default:0" json:"bonus_credits"`
+	MaxRedemptions        *int            `gorm:"column:max_redemptions" json:"max_redemptions,omitempty"`
+	MaxRedemptionsPerUser *int            `gorm:"column:max_redemptions_per_user" json:"max_redemptions_per_user,omitempty"`
+	TimesRedeemed         int             `gorm:"column:times_redeemed;default:0" json:"times_redeemed"`
+	ValidFrom             *time.Time      `gorm:"column:valid_from" json:"valid_from,omitempty"`
+	ValidUntil            *time.Time      `gorm:"column:valid_until" json:"valid_until,omitempty"`
+	PlanIDs               StringList      `gorm:"column:plan_ids;type:jsonb;default:'[]'" json:"plan_ids"` // Plan IDs (payment_plans.provider_price_id); empty means all plans
+	ServiceProvider       string          `gorm:"column:service_provider;size:50" json:"service_provider,omitempty"`
+	StripeCouponID        *string         `gorm:"column:stripe_coupon_id;size:100" json:"stripe_coupon_id,omitempty"`
+	StripePromotionCodeID *string         `gorm:"column:stripe_promotion_code_id;size:100" json:"stripe_promotion_code_id,omitempty"`
+	IsActive              bool            `gorm:"column:is_active;default:true" json:"is_active"`
+	CreatedAt             time.Time       `gorm:"default:now()" json:"created_at"`
+	UpdatedAt             time.Time       `gorm:"default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Coupon) TableName() string {
+	return "coupons"
+}
+
+// AppliesToPlan reports whether the coupon can be used with the given plan identifier
+func (c *Coupon) AppliesToPlan(planID string) bool {
+	if len(c.PlanIDs) == 0 {
+		return true
+	}
+	for _, id := range c.PlanIDs {
+		if id == planID {
+			return true
+		}
+	}
+	return false
+}
+
+// CouponRedemption records a coupon applied to an order
+type CouponRedemption struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	CouponID       int64      `gorm:"column:coupon_id;not null;index" json:"coupon_id"`
+	UniversalID    uuid.UUID  `gorm:"column:universal_id;type:uuid;not null;index" json:"universal_id"`
+	OrderID        string     `gorm:"column:order_id;uniqueIndex;size:100;not null" json:"order_id"`
+	PlanID         string     `gorm:"column:plan_id;size:100" json:"plan_id,omitempty"`
+	OriginalAmount int64      `gorm:"column:original_amount;not null" json:"original_amount"`
+	DiscountAmount int64      `gorm:"column:discount_amount;not null;default:0" json:"discount_amount"`
+	BonusCredits   int        `gorm:"column:bonus_credits;default:0" json:"bonus_credits"`
+	Status         string     `gorm:"column:status;size:20;not null;default:'reserved'" json:"status"`
+	RedeemedAt     *time.Time `gorm:"column:redeemed_at" json:"redeemed_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"default:now()" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"default:now()" json:"updated_at"`
+
+	// Relations
+	Coupon *Coupon `gorm:"foreignKey:CouponID" json:"coupon,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (CouponRedemption) TableName() string {
+	return "coupon_redemptions"
+}
+
+// StringList represents a JSONB array of strings
+type StringList []string
+
+// Value implements driver.Valuer interface
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal([]string(l))
+}
+
+// Scan implements sql.Scanner interface
+func (l *StringList) Scan(src interface{}) error {
+	if src == nil {
+		*l = StringList{}
+		return nil
+	}
+
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		*l = StringList{}
+		return nil
+	}
+}
@@ -17,6 +17,8 @@ const (
 	TransactionTypeRefund                   TransactionType = "refund"
 	TransactionTypeAdjustment               TransactionType = "adjustment"
 	TransactionTypeSubscriptionCancellation TransactionType = "subscription_cancellation"
+	TransactionTypeTransferOut              TransactionType = "transfer_out"
+	TransactionTypeTransferIn               TransactionType = "transfer_in"
 )
 
 // Scan implements sql.Scanner interface
@@ -47,9 +49,13 @@ type CreditTransaction struct {
 	BalanceAfter    decimal.Decimal `gorm:"type:decimal(15,2);not null" json:"balance_after"`
 	Description     string          `gorm:"not null" json:"description"`
 	FeatureName     *string         `gorm:"size:100" json:"feature_name,omitempty"`
+	ServiceProvider *string         `gorm:"size:50" json:"service_provider,omitempty"`
+	ActorID         *uuid.UUID      `gorm:"type:uuid" json:"actor_id,omitempty"` // User who spent the credits, when different from UniversalID
 	UsageMetadata   JSONB           `gorm:"type:jsonb;default:'{}'" json:"usage_metadata"`
 	ReferenceID     *string         `gorm:"size:200;index:idx_credit_transactions_reference,where:reference_id IS NOT NULL" json:"reference_id,omitempty"`
 	IdempotencyKey  *uuid.UUID      `gorm:"type:uuid;unique" json:"idempotency_key,omitempty"`
+	TransferID      *uuid.UUID      `gorm:"type:uuid;index:idx_credit_transactions_transfer,where:transfer_id IS NOT NULL" json:"transfer_id,omitempty"` // Shared by both entries of a transfer
+	CounterpartyID  *uuid.UUID      `gorm:"type:uuid" json:"counterparty_id,omitempty"`                                                                  // Other side of a transfer
 	CreatedAt       time.Time       `gorm:"default:now();index:idx_credit_transactions_universal_created" json:"created_at"`
 
 	// Relations
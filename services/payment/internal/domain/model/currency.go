@@ -0,0 +1,81 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// zeroDecimalCurrencies are charged in whole units; amounts of other currencies are in cents
+var zeroDecimalCurrencies = map[string]bool{
+	"KRW": true,
+	"JPY": true,
+	"VND": true,
+	"CLP": true,
+}
+
+var currencySymbols = map[string]string{
+	"KRW": "₩",
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+}
+
+// CurrencyDecimals returns the number of decimal places of a currency's smallest unit
+func CurrencyDecimals(currency string) int {
+	if zeroDecimalCurrencies[strings.ToUpper(currency)] {
+		return 0
+	}
+	return 2
+}
+
+// FormatAmount formats an amount in the smallest currency unit for display, e.g. "₩9,900" or
+// "$9.90". Currencies without a known symbol are suffixed with their code, e.g. "9.90 CHF".
+func FormatAmount(amount int64, currency string) string {
+	currency = strings.ToUpper(currency)
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	decimals := CurrencyDecimals(currency)
+	unit := int64(1)
+	for i := 0; i < decimals; i++ {
+		unit *= 10
+	}
+
+	formatted := groupThousands(strconv.FormatInt(amount/unit, 10))
+	if decimals > 0 {
+		fraction := strconv.FormatInt(amount%unit, 10)
+		formatted += "." + strings.Repeat("0", decimals-len(fraction)) + fraction
+	}
+
+	if symbol, ok := currencySymbols[currency]; ok {
+		return sign + symbol + formatted
+	}
+	if currency == "" {
+		return sign + formatted
+	}
+	return sign + formatted + " " + currency
+}
+
+func groupThousands(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var b strings.Builder
+	lead := len(digits) % 3
+	if lead > 0 {
+		b.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
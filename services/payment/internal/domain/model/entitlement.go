@@ -0,0 +1,152 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// EntitlementsFeatureKey is the plan Features key holding entitlement definitions.
+//
+// Each entry maps a feature key to either a boolean (enabled, unlimited), a number (enabled
+// with that limit) or an object with "enabled" and "limit" fields:
+//
+//	entitlements:
+//	  ux_report: true
+//	  analyses_per_month: 10
+//	  benchmark: { enabled: true, limit: 3 }
+const EntitlementsFeatureKey = "entitlements"
+
+// Entitlement describes whether a feature is available and its usage limit
+type Entitlement struct {
+	Feature string   `json:"feature"`
+	Enabled bool     `json:"enabled"`
+	Limit   *int64   `json:"limit,omitempty"` // nil means unlimited
+	Sources []string `json:"sources,omitempty"`
+}
+
+// Allowed reports whether the feature may be used at all
+func (e *Entitlement) Allowed() bool {
+	return e.Enabled && (e.Limit == nil || *e.Limit > 0)
+}
+
+// Unlimited reports whether the entitlement is enabled without a limit
+func (e *Entitlement) Unlimited() bool {
+	return e.Enabled && e.Limit == nil
+}
+
+// Entitlements maps feature keys to entitlements
+type Entitlements map[string]*Entitlement
+
+// CompileEntitlements builds typed entitlements from plan features.
+// Entries that cannot be interpreted are returned as errors and skipped.
+func CompileEntitlements(features Features, source string) (Entitlements, []error) {
+	result := make(Entitlements)
+	raw, ok := features[EntitlementsFeatureKey].(map[string]interface{})
+	if !ok {
+		return result, nil
+	}
+
+	var errs []error
+	for feature, value := range raw {
+		entitlement, err := compileEntitlement(feature, value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if source != "" {
+			entitlement.Sources = []string{source}
+		}
+		result[feature] = entitlement
+	}
+
+	return result, errs
+}
+
+func compileEntitlement(feature string, value interface{}) (*Entitlement, error) {
+	switch v := value.(type) {
+	case bool:
+		return &Entitlement{Feature: feature, Enabled: v}, nil
+	case map[string]interface{}:
+		entitlement := &Entitlement{Feature: feature, Enabled: true}
+		if enabled, ok := v["enabled"]; ok {
+			b, ok := enabled.(bool)
+			if !ok {
+				return nil, fmt.Errorf("entitlement %s: enabled must be a boolean", feature)
+			}
+			entitlement.Enabled = b
+		}
+		if limit, ok := v["limit"]; ok && limit != nil {
+			n, err := entitlementLimit(limit)
+			if err != nil {
+				return nil, fmt.Errorf("entitlement %s: %w", feature, err)
+			}
+			entitlement.Limit = &n
+		}
+		return entitlement, nil
+	default:
+		n, err := entitlementLimit(value)
+		if err != nil {
+			return nil, fmt.Errorf("entitlement %s: %w", feature, err)
+		}
+		return &Entitlement{Feature: feature, Enabled: n > 0, Limit: &n}, nil
+	}
+}
+
+func entitlementLimit(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("limit must be a whole number")
+		}
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	default:
+		return 0, fmt.Errorf("unsupported value %v", value)
+	}
+}
+
+// Merge combines entitlements granted by several plans. A feature is enabled when any plan
+// enables it, and its limit is the most generous one, unlimited winning over any number.
+func (e Entitlements) Merge(other Entitlements) {
+	for feature, incoming := range other {
+		current, ok := e[feature]
+		if !ok {
+			copied := *incoming
+			copied.Sources = append([]string(nil), incoming.Sources...)
+			e[feature] = &copied
+			continue
+		}
+
+		switch {
+		case !incoming.Enabled:
+		case !current.Enabled:
+			current.Enabled = true
+			current.Limit = incoming.Limit
+		case current.Limit == nil || incoming.Limit == nil:
+			current.Limit = nil
+		case *incoming.Limit > *current.Limit:
+			limit := *incoming.Limit
+			current.Limit = &limit
+		}
+		current.Sources = append(current.Sources, incoming.Sources...)
+	}
+}
+
+// List returns the entitlements sorted by feature key
+func (e Entitlements) List() []*Entitlement {
+	list := make([]*Entitlement, 0, len(e))
+	for _, entitlement := range e {
+		list = append(list, entitlement)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Feature < list[j].Feature
+	})
+	return list
+}
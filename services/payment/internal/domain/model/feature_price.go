@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// FeaturePrice is a versioned credit price for a feature. Blank ServiceProvider or PlanTier
+// apply to every service provider or tier that has no price of its own.
+//
+// A use costs BaseCredits plus UnitCredits for every started block of UnitSize units, where the
+// units are read from the Unit key of the usage metadata (e.g. "tokens" or "pages"), and never
+// less than MinCredits.
+type FeaturePrice struct {
+	ID              int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ServiceProvider string          `gorm:"size:50;not null;default:'';index:idx_feature_prices_lookup,priority:2" json:"service_provider"`
+	FeatureName     string          `gorm:"size:100;not null;index:idx_feature_prices_lookup,priority:1" json:"feature_name"`
+	PlanTier        string          `gorm:"size:50;not null;default:'';index:idx_feature_prices_lookup,priority:3" json:"plan_tier"`
+	Unit            string          `gorm:"size:50;not null;default:''" json:"unit,omitempty"`
+	UnitSize        int64           `gorm:"not null;default:1" json:"unit_size"`
+	BaseCredits     decimal.Decimal `gorm:"type:decimal(15,2);not null;default:0" json:"base_credits"`
+	UnitCredits     decimal.Decimal `gorm:"type:decimal(15,2);not null;default:0" json:"unit_credits"`
+	MinCredits      decimal.Decimal `gorm:"type:decimal(15,2);not null;default:0" json:"min_credits"`
+	Version         int             `gorm:"not null;default:1" json:"version"`
+	EffectiveFrom   time.Time       `gorm:"not null" json:"effective_from"`
+	EffectiveTo     *time.Time      `json:"effective_to,omitempty"`
+	CreatedAt       time.Time       `gorm:"default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (FeaturePrice) TableName() string {
+	return "feature_prices"
+}
+
+// EffectiveAt reports whether the price applies at t
+func (p *FeaturePrice) EffectiveAt(t time.Time) bool {
+	return !t.Before(p.EffectiveFrom) && (p.EffectiveTo == nil || t.Before(*p.EffectiveTo))
+}
+
+// Cost returns the credits charged for a use consuming the given number of units
+func (p *FeaturePrice) Cost(units int64) decimal.Decimal {
+	cost := p.BaseCredits
+	if p.Unit != "" && units > 0 {
+		size := p.UnitSize
+		if size < 1 {
+			size = 1
+		}
+		blocks := (units + size - 1) / size
+		cost = cost.Add(p.UnitCredits.Mul(decimal.NewFromInt(blocks)))
+	}
+	if cost.LessThan(p.MinCredits) {
+		cost = p.MinCredits
+	}
+	return cost
+}
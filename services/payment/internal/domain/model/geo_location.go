@@ -0,0 +1,21 @@
+package model
+
+// GeoLocation is where a request comes from, as resolved by the geo service.
+// Resolved is false when the IP is private or the geo service could not be reached; the other
+// fields are then empty and callers should fall back to their defaults.
+type GeoLocation struct {
+	IP             string `json:"ip"`
+	CountryCode    string `json:"country_code,omitempty"`   // Upper-case ISO 3166-1 alpha-2
+	ContinentCode  string `json:"continent_code,omitempty"` // e.g. AS, EU
+	ASN            uint32 `json:"asn,omitempty"`
+	ASOrganization string `json:"as_organization,omitempty"`
+	IsAnonymous    bool   `json:"is_anonymous"`
+	IsAnonymousVPN bool   `json:"is_anonymous_vpn"`
+	IsTorExitNode  bool   `json:"is_tor_exit_node"`
+	Resolved       bool   `json:"resolved"`
+}
+
+// IsAnonymized reports whether the request comes through an anonymizing network (VPN, proxy or Tor)
+func (l *GeoLocation) IsAnonymized() bool {
+	return l != nil && (l.IsAnonymous || l.IsAnonymousVPN || l.IsTorExitNode)
+}
@@ -17,6 +17,7 @@ type Payment struct {
 	ProviderInvoiceID       *string         `gorm:"column:provider_invoice_id;size:100" json:"provider_invoice_id,omitempty"`
 	AmountCents           int             `gorm:"not null" json:"amount_cents"`
 	Currency              string          `gorm:"size:3;default:'KRW'" json:"currency"`
+	PgProvider            string          `gorm:"column:pg_provider;size:50" json:"pg_provider,omitempty"`
 	Status                string          `gorm:"size:50;not null" json:"status"`
 	CreditsAllocated      decimal.Decimal `gorm:"type:decimal(15,2);default:0" json:"credits_allocated"`
 	CreditsAllocatedAt    *time.Time      `json:"credits_allocated_at,omitempty"`
@@ -25,6 +26,17 @@ type Payment struct {
 	FailureMessage        *string         `json:"failure_message,omitempty"`
 	ProviderPaymentData     JSONB           `gorm:"column:provider_payment_data;type:jsonb" json:"provider_payment_data,omitempty"`
 	PaidAt                *time.Time      `json:"paid_at,omitempty"`
+
+	// Cash receipt (현금영수증) for transfer and virtual account payments
+	CashReceiptType           *string    `gorm:"size:20" json:"cash_receipt_type,omitempty"`
+	CashReceiptIdentifier     *string    `gorm:"size:50" json:"-"`
+	CashReceiptKey            *string    `gorm:"size:200" json:"cash_receipt_key,omitempty"`
+	CashReceiptIssueNumber    *string    `gorm:"size:50" json:"cash_receipt_issue_number,omitempty"`
+	CashReceiptURL            *string    `gorm:"column:cash_receipt_url" json:"cash_receipt_url,omitempty"`
+	CashReceiptStatus         *string    `gorm:"size:20" json:"cash_receipt_status,omitempty"`
+	CashReceiptIssuedAt       *time.Time `json:"cash_receipt_issued_at,omitempty"`
+	CashReceiptCanceledAt     *time.Time `json:"cash_receipt_canceled_at,omitempty"`
+	CashReceiptCanceledAmount int64      `gorm:"default:0" json:"cash_receipt_canceled_amount,omitempty"`
 	CreatedAt             time.Time       `gorm:"default:now()" json:"created_at"`
 	UpdatedAt             time.Time       `gorm:"default:now()" json:"updated_at"`
 
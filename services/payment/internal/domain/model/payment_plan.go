@@ -12,21 +12,53 @@ const (
 	PlanTypeOneTime      = "one_time"
 )
 
+// PlanTierFeatureKey is the plan Features key naming the pricing tier of the plan
+const PlanTierFeatureKey = "tier"
+
 // PaymentPlan represents a payment plan (subscription or one-time)
 type PaymentPlan struct {
-	ID                int64     `gorm:"primaryKey;autoIncrement" json:"id"`
-	ProviderPriceID   string    `gorm:"column:provider_price_id;unique;not null;size:100" json:"provider_price_id"`
-	ProviderProductID string    `gorm:"column:provider_product_id;not null;size:100" json:"provider_product_id"`
-	PgProvider        string    `gorm:"column:pg_provider;size:50" json:"pg_provider"`
-	Currency          string    `gorm:"column:currency;size:10;default:'KRW'" json:"currency"`
-	DisplayName       string    `gorm:"not null;size:200" json:"display_name"`
-	Type              string    `gorm:"not null;size:20;default:'subscription'" json:"type"` // 'subscription' or 'one_time'
-	CreditsPerCycle   int       `gorm:"not null" json:"credits_per_cycle"`
-	Features          Features  `gorm:"type:jsonb;default:'{}'" json:"features"`
-	SortOrder         int       `gorm:"default:0" json:"sort_order"`
-	IsActive          bool      `gorm:"default:true" json:"is_active"`
-	CreatedAt         time.Time `gorm:"default:now()" json:"created_at"`
-	UpdatedAt         time.Time `gorm:"default:now()" json:"updated_at"`
+	ID                int64       `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProviderPriceID   string      `gorm:"column:provider_price_id;unique;not null;size:100" json:"provider_price_id"`
+	ProviderProductID string      `gorm:"column:provider_product_id;not null;size:100" json:"provider_product_id"`
+	PgProvider        string      `gorm:"column:pg_provider;size:50" json:"pg_provider"`
+	ServiceProvider   string      `gorm:"column:service_provider;size:50;not null;default:''" json:"service_provider,omitempty"` // blank when offered by every service provider
+	Currency          string      `gorm:"column:currency;size:10;default:'KRW'" json:"currency"`
+	DisplayName       string      `gorm:"not null;size:200" json:"display_name"`
+	Type              string      `gorm:"not null;size:20;default:'subscription'" json:"type"` // 'subscription' or 'one_time'
+	CreditsPerCycle   int         `gorm:"not null" json:"credits_per_cycle"`
+	TrialPeriodDays   int         `gorm:"column:trial_period_days;default:0" json:"trial_period_days"`
+	TrialCredits      int         `gorm:"column:trial_credits;default:0" json:"trial_credits"`
+	SeatBased         bool        `gorm:"column:seat_based;default:false" json:"seat_based"` // priced and credited per workspace seat
+	Features          Features    `gorm:"type:jsonb;default:'{}'" json:"features"`
+	SortOrder         int         `gorm:"default:0" json:"sort_order"`
+	IsActive          bool        `gorm:"default:true" json:"is_active"`
+	Prices            []PlanPrice `gorm:"-" json:"prices,omitempty"` // Additional price points, loaded by the plan repository
+	CreatedAt         time.Time   `gorm:"default:now()" json:"created_at"`
+	UpdatedAt         time.Time   `gorm:"default:now()" json:"updated_at"`
+}
+
+// HasTrial reports whether the plan offers a free trial
+func (p *PaymentPlan) HasTrial() bool {
+	return p.Type == PlanTypeSubscription && p.TrialPeriodDays > 0
+}
+
+// OfferedBy reports whether the plan is sold by the given service provider
+func (p *PaymentPlan) OfferedBy(serviceProvider string) bool {
+	return p.ServiceProvider == "" || p.ServiceProvider == serviceProvider
+}
+
+// Tier returns the pricing tier of the plan, or "" when it has none
+func (p *PaymentPlan) Tier() string {
+	tier, _ := p.Features[PlanTierFeatureKey].(string)
+	return tier
+}
+
+// SeatCredits returns the credits allocated per cycle for the given number of seats
+func (p *PaymentPlan) SeatCredits(seats int) int {
+	if !p.SeatBased || seats < 1 {
+		return p.CreditsPerCycle
+	}
+	return p.CreditsPerCycle * seats
 }
 
 // Features represents plan features as JSONB
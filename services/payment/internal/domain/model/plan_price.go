@@ -0,0 +1,124 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// PlanPrice is an additional price point of a payment plan in another currency or payment gateway.
+// Checkout is started with its ProviderPriceID like with the plan's own price.
+type PlanPrice struct {
+	ID              int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	PlanID          int64     `gorm:"column:plan_id;not null;index" json:"plan_id"`
+	ProviderPriceID string    `gorm:"column:provider_price_id;unique;not null;size:100" json:"provider_price_id"`
+	PgProvider      string    `gorm:"column:pg_provider;not null;size:50" json:"pg_provider"`
+	Currency        string    `gorm:"column:currency;not null;size:10" json:"currency"`
+	Amount          int64     `gorm:"not null" json:"amount"` // In the smallest currency unit
+	IsActive        bool      `gorm:"default:true" json:"is_active"`
+	CreatedAt       time.Time `gorm:"default:now()" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (PlanPrice) TableName() string {
+	return "plan_prices"
+}
+
+// BasePrice returns the plan's own price point
+func (p *PaymentPlan) BasePrice() PlanPrice {
+	price := PlanPrice{
+		PlanID:          p.ID,
+		ProviderPriceID: p.ProviderPriceID,
+		PgProvider:      p.PgProvider,
+		Currency:        strings.ToUpper(p.Currency),
+		IsActive:        p.IsActive,
+	}
+
+	if priceMap, ok := p.Features["price"].(map[string]interface{}); ok {
+		switch amount := priceMap["amount"].(type) {
+		case float64:
+			price.Amount = int64(amount)
+		case int64:
+			price.Amount = amount
+		case int:
+			price.Amount = int64(amount)
+		}
+		if price.Currency == "" {
+			if currency, ok := priceMap["currency"].(string); ok {
+				price.Currency = strings.ToUpper(currency)
+			}
+		}
+	}
+
+	return price
+}
+
+// PricePoints returns the plan's own price followed by its active additional price points
+func (p *PaymentPlan) PricePoints() []PlanPrice {
+	points := make([]PlanPrice, 0, len(p.Prices)+1)
+	points = append(points, p.BasePrice())
+	for _, price := range p.Prices {
+		if price.IsActive {
+			points = append(points, price)
+		}
+	}
+	return points
+}
+
+// PriceIn returns the plan's price point in currency, preferring pgProvider when several gateways
+// sell it. A blank pgProvider accepts any gateway.
+func (p *PaymentPlan) PriceIn(currency, pgProvider string) (PlanPrice, bool) {
+	var match PlanPrice
+	found := false
+	for _, price := range p.PricePoints() {
+		if !strings.EqualFold(price.Currency, currency) {
+			continue
+		}
+		if pgProvider == "" || price.PgProvider == pgProvider {
+			return price, true
+		}
+		if !found {
+			match, found = price, true
+		}
+	}
+	return match, found
+}
+
+// Currencies returns the currencies the plan is sold in
+func (p *PaymentPlan) Currencies() []string {
+	currencies := make([]string, 0, len(p.Prices)+1)
+	seen := make(map[string]bool, len(p.Prices)+1)
+	for _, price := range p.PricePoints() {
+		if price.Currency == "" || seen[price.Currency] {
+			continue
+		}
+		seen[price.Currency] = true
+		currencies = append(currencies, price.Currency)
+	}
+	return currencies
+}
+
+// WithPrice returns a copy of the plan sold at the given price point. The copy keeps the plan's
+// ID so credits and entitlements resolve to the same plan.
+func (p *PaymentPlan) WithPrice(price PlanPrice) *PaymentPlan {
+	priced := *p
+	priced.ProviderPriceID = price.ProviderPriceID
+	priced.PgProvider = price.PgProvider
+	priced.Currency = price.Currency
+
+	priced.Features = make(Features, len(p.Features))
+	for k, v := range p.Features {
+		priced.Features[k] = v
+	}
+	priceFeature := map[string]interface{}{}
+	if existing, ok := p.Features["price"].(map[string]interface{}); ok {
+		for k, v := range existing {
+			priceFeature[k] = v
+		}
+	}
+	priceFeature["amount"] = price.Amount
+	priceFeature["currency"] = price.Currency
+	priced.Features["price"] = priceFeature
+
+	return &priced
+}
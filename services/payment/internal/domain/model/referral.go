@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Referral status constants
+const (
+	ReferralStatusPending  = "pending"  // Referee signed up with a code and has not paid yet
+	ReferralStatusRewarded = "rewarded" // Both parties received their credits after the referee's first payment
+	ReferralStatusRejected = "rejected" // The referral will never be rewarded, e.g. the referrer reached the reward limit
+)
+
+// ReferralCode is the code a user shares to refer others
+type ReferralCode struct {
+	UniversalID uuid.UUID `gorm:"column:universal_id;type:uuid;primaryKey" json:"universal_id"`
+	Code        string    `gorm:"column:code;uniqueIndex;size:20;not null" json:"code"`
+	CreatedAt   time.Time `gorm:"default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ReferralCode) TableName() string {
+	return "referral_codes"
+}
+
+// Referral links a referee to the user who referred them. Each user can be referred once.
+type Referral struct {
+	ID              int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReferrerID      uuid.UUID  `gorm:"column:referrer_id;type:uuid;not null;index" json:"referrer_id"`
+	RefereeID       uuid.UUID  `gorm:"column:referee_id;type:uuid;not null;uniqueIndex" json:"referee_id"`
+	Code            string     `gorm:"column:code;size:20;not null" json:"code"`
+	Status          string     `gorm:"column:status;size:20;not null;default:pending" json:"status"`
+	ReferrerCredits int        `gorm:"column:referrer_credits;default:0" json:"referrer_credits"`
+	RefereeCredits  int        `gorm:"column:referee_credits;default:0" json:"referee_credits"`
+	PaymentRef      *string    `gorm:"column:payment_ref;size:200" json:"payment_ref,omitempty"` // Payment that triggered the reward
+	RewardedAt      *time.Time `gorm:"column:rewarded_at" json:"rewarded_at,omitempty"`
+	CreatedAt       time.Time  `gorm:"default:now()" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Referral) TableName() string {
+	return "referrals"
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MRRSnapshot is the monthly recurring revenue of one subscription active at the end of a day
+// (KST). Snapshots are written nightly and not rewritten, so reports for past periods stay fixed
+// when subscriptions are edited later.
+type MRRSnapshot struct {
+	ID             int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	SnapshotDate   time.Time `gorm:"type:date;not null;uniqueIndex:idx_mrr_snapshots_key,priority:1" json:"snapshot_date"`
+	SubscriptionID int64     `gorm:"not null;uniqueIndex:idx_mrr_snapshots_key,priority:2" json:"subscription_id"`
+	UniversalID    uuid.UUID `gorm:"column:universal_id;type:uuid;not null" json:"universal_id"`
+	PlanID         string    `gorm:"size:100;not null;default:''" json:"plan_id"`
+	Currency       string    `gorm:"size:3;not null" json:"currency"`
+	MRR            int64     `gorm:"column:mrr;not null" json:"mrr"` // In the smallest currency unit
+	Quantity       int       `gorm:"not null;default:1" json:"quantity"`
+	CreatedAt      time.Time `gorm:"default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (MRRSnapshot) TableName() string {
+	return "mrr_snapshots"
+}
+
+// MonthlyRecurringAmount normalizes the amount billed every intervalCount intervals for quantity
+// units to a monthly amount, rounded to the smallest currency unit. Unknown intervals count as months.
+func MonthlyRecurringAmount(amount int64, quantity int, interval string, intervalCount int64) int64 {
+	if quantity < 1 {
+		quantity = 1
+	}
+	if intervalCount < 1 {
+		intervalCount = 1
+	}
+
+	// Monthly amount = total * numerator / denominator
+	total := amount * int64(quantity)
+	numerator, denominator := int64(1), intervalCount
+	switch interval {
+	case "year":
+		denominator *= 12
+	case "week":
+		numerator, denominator = 52, 12*intervalCount
+	case "day":
+		numerator, denominator = 365, 12*intervalCount
+	}
+
+	return (total*numerator*2 + denominator) / (denominator * 2)
+}
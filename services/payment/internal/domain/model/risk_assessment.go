@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Decisions of a risk assessment, from least to most restrictive
+const (
+	RiskDecisionAllow  = "allow"  // The request proceeds
+	RiskDecisionVerify = "verify" // The request proceeds only with extra verification, e.g. 3-D Secure
+	RiskDecisionBlock  = "block"  // The request is rejected
+)
+
+// Actions a risk assessment is made for
+const (
+	RiskActionCheckout      = "checkout"          // One-time payment
+	RiskActionSubscription  = "subscription"      // Stripe subscription
+	RiskActionBillingKey    = "billing_key_issue" // Card registration for recurring payments
+	RiskActionBillingCharge = "billing_charge"    // Charge of a registered card
+)
+
+// RiskDecisionRank orders decisions from least (0) to most restrictive; unknown decisions rank as allow
+func RiskDecisionRank(decision string) int {
+	switch decision {
+	case RiskDecisionVerify:
+		return 1
+	case RiskDecisionBlock:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// RiskAssessment is the audit record of a risk decision. Assessments also count the attempts per
+// user, card and IP address that velocity rules are evaluated on.
+type RiskAssessment struct {
+	ID              int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UniversalID     uuid.UUID `gorm:"column:universal_id;type:uuid;not null" json:"universal_id"`
+	Action          string    `gorm:"size:50;not null" json:"action"`
+	IPAddress       string    `gorm:"column:ip_address;size:45" json:"ip_address,omitempty"`
+	CardFingerprint string    `gorm:"column:card_fingerprint;size:64" json:"-"`
+	CountryCode     string    `gorm:"column:country_code;size:2" json:"country_code,omitempty"` // Country of the IP address
+	CardCountry     string    `gorm:"column:card_country;size:2" json:"card_country,omitempty"` // Country of the card issuer
+	Amount          int64     `gorm:"default:0" json:"amount,omitempty"`
+	Currency        string    `gorm:"size:3" json:"currency,omitempty"`
+	Score           int       `gorm:"not null" json:"score"`
+	Decision        string    `gorm:"size:20;not null" json:"decision"`
+	MatchedRules    JSONB     `gorm:"column:matched_rules;type:jsonb;default:'{}'" json:"matched_rules"` // Rule name to score
+	Signals         JSONB     `gorm:"type:jsonb;default:'{}'" json:"signals"`                            // Inputs the rules were evaluated on
+	CreatedAt       time.Time `gorm:"default:now()" json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (RiskAssessment) TableName() string {
+	return "risk_assessments"
+}
@@ -1,3 +1,57 @@
 package model
 
+import (
+	"strings"
+	"time"
+)
+
+// ServiceProviderSemo is the default service provider of the deployment
 const ServiceProviderSemo = "semo"
+
+// ServiceProvider is a product served by this payment deployment. Its credentials are stored
+// encrypted and only decrypted into Credentials by the service provider registry.
+type ServiceProvider struct {
+	Code                 string                     `gorm:"primaryKey;size:50" json:"code"`
+	DisplayName          string                     `gorm:"size:200;not null" json:"display_name"`
+	ClientURLs           StringList                 `gorm:"column:client_urls;type:jsonb;not null;default:'[]'" json:"client_urls"`
+	IsActive             bool                       `gorm:"not null;default:true" json:"is_active"`
+	EncryptedCredentials string                     `gorm:"type:text" json:"-"`
+	CredentialsIV        string                     `gorm:"column:credentials_iv;size:100" json:"-"`
+	Credentials          ServiceProviderCredentials `gorm:"-" json:"-"`
+	CreatedAt            time.Time                  `gorm:"default:now()" json:"created_at"`
+	UpdatedAt            time.Time                  `gorm:"default:now()" json:"updated_at"`
+}
+
+// ServiceProviderCredentials are the payment gateway secrets of a service provider.
+// A gateway whose secret is blank is not available to the provider.
+type ServiceProviderCredentials struct {
+	StripeSecretKey      string `json:"stripe_secret_key,omitempty"`
+	StripeWebhookSecret  string `json:"stripe_webhook_secret,omitempty"`
+	TossSecretKey        string `json:"toss_secret_key,omitempty"`
+	TossClientKey        string `json:"toss_client_key,omitempty"`
+	TossBillingSecretKey string `json:"toss_billing_secret_key,omitempty"` // API individual integration key used for billing keys
+}
+
+// TableName specifies the table name for GORM
+func (ServiceProvider) TableName() string {
+	return "service_providers"
+}
+
+// PrimaryClientURL returns the client URL used for redirects, or "" when none is configured
+func (p *ServiceProvider) PrimaryClientURL() string {
+	if len(p.ClientURLs) == 0 {
+		return ""
+	}
+	return p.ClientURLs[0]
+}
+
+// AllowsOrigin reports whether origin is one of the provider's client URLs
+func (p *ServiceProvider) AllowsOrigin(origin string) bool {
+	origin = strings.TrimRight(origin, "/")
+	for _, url := range p.ClientURLs {
+		if strings.TrimRight(url, "/") == origin {
+			return true
+		}
+	}
+	return false
+}
@@ -41,6 +41,7 @@ type Subscription struct {
 	ProviderCustomerID       string             `gorm:"column:provider_customer_id;not null;size:100" json:"provider_customer_id"`
 	ProviderSubscriptionID   *string            `gorm:"column:provider_subscription_id;unique;size:100" json:"provider_subscription_id,omitempty"`
 	PlanID                 *string            `gorm:"not null;size:100" json:"plan_id,omitempty"`
+	PriceID                *string            `gorm:"column:price_id;size:100" json:"price_id,omitempty"`
 	Status                 SubscriptionStatus `gorm:"type:subscription_status;not null;default:'active'" json:"status"`
 	CurrentPeriodStart     time.Time          `gorm:"not null" json:"current_period_start"`
 	CurrentPeriodEnd       time.Time          `gorm:"not null" json:"current_period_end"`
@@ -50,6 +51,8 @@ type Subscription struct {
 	Currency               string             `gorm:"size:3" json:"currency"`
 	Interval               string             `gorm:"size:20" json:"interval"`
 	IntervalCount          int64              `json:"interval_count"`
+	Quantity               int                `gorm:"column:quantity;not null;default:1" json:"quantity"`
+	WorkspaceID            *uuid.UUID         `gorm:"column:workspace_id;type:uuid;index" json:"workspace_id,omitempty"`
 	ProviderSubscriptionData JSONB              `gorm:"column:provider_subscription_data;type:jsonb" json:"provider_subscription_data,omitempty"`
 	CreatedAt              time.Time          `gorm:"default:now()" json:"created_at"`
 	UpdatedAt              time.Time          `gorm:"default:now()" json:"updated_at"`
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Trial status constants
+const (
+	TrialStatusActive     = "active"
+	TrialStatusConverting = "converting" // Claimed by a conversion run that is charging the card
+	TrialStatusConverted  = "converted"
+	TrialStatusCanceled   = "canceled"
+	TrialStatusFailed     = "failed"
+)
+
+// SubscriptionTrial tracks a free trial of a subscription plan and its conversion to a paid subscription
+type SubscriptionTrial struct {
+	ID                     int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UniversalID            uuid.UUID  `gorm:"column:universal_id;type:uuid;not null;index" json:"universal_id"`
+	Email                  string     `gorm:"column:email;size:255" json:"email,omitempty"`
+	PlanID                 string     `gorm:"column:plan_id;size:100;not null" json:"plan_id"`
+	Provider               string     `gorm:"column:provider;size:50;not null" json:"provider"`
+	ServiceProvider        string     `gorm:"column:service_provider;size:50" json:"service_provider,omitempty"`
+	Status                 string     `gorm:"column:status;size:20;not null;default:'active'" json:"status"`
+	BillingKeyID           *int64     `gorm:"column:billing_key_id" json:"billing_key_id,omitempty"`
+	CardFingerprint        string     `gorm:"column:card_fingerprint;size:64;index" json:"-"`
+	ProviderCustomerID     string     `gorm:"column:provider_customer_id;size:100" json:"-"`
+	ProviderSubscriptionID *string    `gorm:"column:provider_subscription_id;size:100" json:"provider_subscription_id,omitempty"`
+	TrialCredits           int        `gorm:"column:trial_credits;default:0" json:"trial_credits"`
+	CreditsGrantedAt       *time.Time `gorm:"column:credits_granted_at" json:"credits_granted_at,omitempty"`
+	StartedAt              time.Time  `gorm:"column:started_at;not null" json:"started_at"`
+	EndsAt                 time.Time  `gorm:"column:ends_at;not null" json:"ends_at"`
+	WillEndNotifiedAt      *time.Time `gorm:"column:will_end_notified_at" json:"-"`
+	ConvertedAt            *time.Time `gorm:"column:converted_at" json:"converted_at,omitempty"`
+	ConversionOrderID      string     `gorm:"column:conversion_order_id;size:100" json:"conversion_order_id,omitempty"`
+	FailureReason          string     `gorm:"column:failure_reason;type:text" json:"failure_reason,omitempty"`
+	CreatedAt              time.Time  `gorm:"default:now()" json:"created_at"`
+	UpdatedAt              time.Time  `gorm:"default:now()" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (SubscriptionTrial) TableName() string {
+	return "subscription_trials"
+}
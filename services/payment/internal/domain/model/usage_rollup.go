@@ -0,0 +1,80 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// UsageGranularity is the length of the period a usage rollup covers
+type UsageGranularity string
+
+const (
+	UsageGranularityDay   UsageGranularity = "day"
+	UsageGranularityWeek  UsageGranularity = "week"
+	UsageGranularityMonth UsageGranularity = "month"
+)
+
+// UsageGranularities lists every granularity rollups are kept for
+var UsageGranularities = []UsageGranularity{UsageGranularityDay, UsageGranularityWeek, UsageGranularityMonth}
+
+// UsageLocation is the timezone usage periods are cut in. Korea has no daylight saving time.
+var UsageLocation = time.FixedZone("KST", 9*60*60)
+
+// Valid reports whether g is a supported granularity
+func (g UsageGranularity) Valid() bool {
+	switch g {
+	case UsageGranularityDay, UsageGranularityWeek, UsageGranularityMonth:
+		return true
+	}
+	return false
+}
+
+// PeriodStart returns the start of the period containing t, in UsageLocation.
+// Weeks start on Monday, matching PostgreSQL date_trunc.
+func (g UsageGranularity) PeriodStart(t time.Time) time.Time {
+	t = t.In(UsageLocation)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, UsageLocation)
+
+	switch g {
+	case UsageGranularityWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case UsageGranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, UsageLocation)
+	default:
+		return day
+	}
+}
+
+// NextPeriod returns the start of the period following the one starting at start
+func (g UsageGranularity) NextPeriod(start time.Time) time.Time {
+	switch g {
+	case UsageGranularityWeek:
+		return start.AddDate(0, 0, 7)
+	case UsageGranularityMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// UsageRollup aggregates credit usage of one feature per period, universal ID and acting user
+type UsageRollup struct {
+	ID              int64            `gorm:"primaryKey;autoIncrement" json:"-"`
+	Granularity     UsageGranularity `gorm:"size:10;not null;uniqueIndex:idx_credit_usage_rollups_key,priority:1" json:"granularity"`
+	PeriodStart     time.Time        `gorm:"type:date;not null;uniqueIndex:idx_credit_usage_rollups_key,priority:2" json:"period_start"`
+	UniversalID     uuid.UUID        `gorm:"column:universal_id;type:uuid;not null;uniqueIndex:idx_credit_usage_rollups_key,priority:3" json:"universal_id"`
+	ActorID         uuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_credit_usage_rollups_key,priority:4" json:"actor_id"` // Equals UniversalID for personal usage
+	ServiceProvider string           `gorm:"size:50;not null;default:'';uniqueIndex:idx_credit_usage_rollups_key,priority:5" json:"service_provider"`
+	FeatureName     string           `gorm:"size:100;not null;default:'';uniqueIndex:idx_credit_usage_rollups_key,priority:6" json:"feature_name"`
+	Credits         decimal.Decimal  `gorm:"type:decimal(15,2);not null;default:0" json:"credits"`
+	UsageCount      int64            `gorm:"not null;default:0" json:"usage_count"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (UsageRollup) TableName() string {
+	return "credit_usage_rollups"
+}
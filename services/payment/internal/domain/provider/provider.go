@@ -122,17 +122,25 @@ type IssueBillingKeyRequest struct {
 }
 
 type IssueBillingKeyResponse struct {
-	BillingKey  string `json:"billingKey"`
-	CustomerKey string `json:"customerKey"`
-	CardCompany string `json:"cardCompany"`
-	CardNumber  string `json:"cardNumber"`
-	CardType    string `json:"cardType"`
+	BillingKey  string         `json:"billingKey"`
+	CustomerKey string         `json:"customerKey"`
+	CardCompany string         `json:"cardCompany"`
+	CardNumber  string         `json:"cardNumber"`
+	CardType    string         `json:"cardType"`
+	Card        BillingKeyCard `json:"card"`
+	CardCountry string         `json:"-"` // ISO country of the card issuer, blank when the provider cannot tell
+}
+
+// BillingKeyCard is the card a billing key was issued for
+type BillingKeyCard struct {
+	IssuerCode string `json:"issuerCode"`
 }
 
 type ChargeBillingKeyRequest struct {
 	BillingKey  string `json:"billingKey"`
 	CustomerKey string `json:"customerKey"`
 	Amount      int64  `json:"amount"`
+	Currency    string `json:"currency,omitempty"` // Blank charges in KRW
 	OrderID     string `json:"orderId"`
 	OrderName   string `json:"orderName"`
 }
@@ -144,4 +152,62 @@ type ChargeBillingKeyResponse struct {
 	Amount         int64      `json:"totalAmount"`
 	ApprovedAt     *time.Time `json:"approvedAt"`
 	TransactionKey string     `json:"transactionKey"`
-}
\ No newline at end of file
+}
+
+// CashReceiptProvider defines the interface for cash receipt (현금영수증) operations
+type CashReceiptProvider interface {
+	IssueCashReceipt(ctx context.Context, req *IssueCashReceiptRequest) (*CashReceiptResponse, error)
+	CancelCashReceipt(ctx context.Context, req *CancelCashReceiptRequest) (*CashReceiptResponse, error)
+}
+
+// CashReceiptType represents the purpose of a cash receipt
+type CashReceiptType string
+
+const (
+	CashReceiptTypeIncomeDeduction CashReceiptType = "소득공제" // Personal income deduction
+	CashReceiptTypeExpenseProof    CashReceiptType = "지출증빙" // Business expense proof
+)
+
+type IssueCashReceiptRequest struct {
+	Amount                 int64           `json:"amount"`
+	OrderID                string          `json:"orderId"`
+	OrderName              string          `json:"orderName"`
+	CustomerIdentityNumber string          `json:"customerIdentityNumber"` // Phone, business or card number
+	Type                   CashReceiptType `json:"type"`
+	TaxFreeAmount          int64           `json:"taxFreeAmount,omitempty"`
+}
+
+type CancelCashReceiptRequest struct {
+	ReceiptKey string `json:"receiptKey"`
+	Amount     int64  `json:"amount,omitempty"` // Zero cancels the full amount
+}
+
+type CashReceiptResponse struct {
+	ReceiptKey      string     `json:"receiptKey"`
+	IssueNumber     string     `json:"issueNumber"`
+	IssueStatus     string     `json:"issueStatus"`
+	TransactionType string     `json:"transactionType"`
+	OrderID         string     `json:"orderId"`
+	Amount          int64      `json:"amount"`
+	ReceiptURL      string     `json:"receiptUrl"`
+	RequestedAt     *time.Time `json:"requestedAt,omitempty"`
+}
+
+// PaymentLookupProvider defines the interface for looking up payments as the provider recorded them
+type PaymentLookupProvider interface {
+	// LookupPayment returns the provider's payment for an order, or nil if it has none
+	LookupPayment(ctx context.Context, orderID string) (*PaymentRecord, error)
+}
+
+// PaymentRecord is a payment as recorded by the provider
+type PaymentRecord struct {
+	OrderID        string                 `json:"order_id"`
+	PaymentKey     string                 `json:"payment_key"`
+	TransactionKey string                 `json:"transaction_key,omitempty"`
+	Status         PaymentStatus          `json:"status"`
+	ProviderStatus string                 `json:"provider_status"` // Status as reported by the provider
+	Amount         int64                  `json:"amount"`          // Amount in smallest currency unit
+	Currency       string                 `json:"currency"`
+	PaidAt         *time.Time             `json:"paid_at,omitempty"`
+	ProviderData   map[string]interface{} `json:"provider_data,omitempty"`
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+)
+
+// AnalyticsRepository defines the interface for revenue snapshots and analytics queries
+type AnalyticsRepository interface {
+	// ListSubscriptionsActiveAt retrieves the subscriptions that were active at the given time
+	ListSubscriptionsActiveAt(ctx context.Context, at time.Time) ([]*model.Subscription, error)
+
+	// HasSnapshot reports whether a snapshot was taken for the date
+	HasSnapshot(ctx context.Context, date time.Time) (bool, error)
+
+	// ReplaceSnapshot stores the rows as the snapshot of the date, replacing any previous one
+	ReplaceSnapshot(ctx context.Context, date time.Time, rows []*model.MRRSnapshot) error
+
+	// LatestSnapshotDate returns the date of the latest snapshot on or before the date, or nil if there is none
+	LatestSnapshotDate(ctx context.Context, onOrBefore time.Time) (*time.Time, error)
+
+	// ListSnapshot retrieves the rows of the snapshot of the date
+	ListSnapshot(ctx context.Context, date time.Time) ([]*model.MRRSnapshot, error)
+
+	// ListCohortActivity counts paying customers per cohort and month for cohorts starting in [from, to)
+	ListCohortActivity(ctx context.Context, from, to time.Time) ([]dto.CohortActivity, error)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+)
+
+// CouponRepository defines the interface for coupon and redemption persistence
+type CouponRepository interface {
+	Create(ctx context.Context, coupon *model.Coupon) error
+	GetByCode(ctx context.Context, code string) (*model.Coupon, error)
+	GetByID(ctx context.Context, id int64) (*model.Coupon, error)
+	UpdateStripeMapping(ctx context.Context, couponID int64, stripeCouponID string, stripePromotionCodeID string) error
+
+	// CountUserRedemptions counts non-released redemptions of a coupon by a user
+	CountUserRedemptions(ctx context.Context, couponID int64, universalID uuid.UUID) (int64, error)
+
+	// Reserve atomically checks usage limits and records a reserved redemption for an order
+	Reserve(ctx context.Context, redemption *model.CouponRedemption) error
+
+	// GetRedemptionByOrderID retrieves the redemption attached to an order
+	GetRedemptionByOrderID(ctx context.Context, orderID string) (*model.CouponRedemption, error)
+
+	// MarkRedeemed finalizes a reserved redemption once the order is paid
+	MarkRedeemed(ctx context.Context, orderID string) (*model.CouponRedemption, error)
+
+	// Release frees a reserved redemption so its usage counts again
+	Release(ctx context.Context, orderID string) error
+}
@@ -2,12 +2,58 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 )
 
+// CreditUsage is one deduction of a credit usage batch
+type CreditUsage struct {
+	Amount         decimal.Decimal
+	Description    string
+	FeatureName    string
+	ActorID        *uuid.UUID
+	UsageMetadata  model.JSONB
+	IdempotencyKey *uuid.UUID
+}
+
+// CreditUsageOutcome reports how one usage of a batch was handled
+type CreditUsageOutcome struct {
+	Transaction *model.CreditTransaction // Created transaction, or the earlier one when Duplicate
+	Duplicate   bool                     // The idempotency key was already recorded; nothing was charged
+	Err         error
+}
+
+// CreditTransfer moves credits between two balances of the same service provider
+type CreditTransfer struct {
+	FromID          uuid.UUID
+	ToID            uuid.UUID
+	ServiceProvider string
+	Amount          decimal.Decimal
+	Note            string
+	ActorID         *uuid.UUID // Member who sent the credits, when sending from a workspace
+	IdempotencyKey  *uuid.UUID
+	Limits          TransferLimits
+}
+
+// TransferLimits bound the transfers sent and received since a point in time. Zero values are unlimited.
+type TransferLimits struct {
+	Since       time.Time
+	MaxSent     decimal.Decimal
+	MaxSentN    int
+	MaxReceived decimal.Decimal
+}
+
+// CreditTransferResult holds the linked ledger entries of a transfer
+type CreditTransferResult struct {
+	TransferID uuid.UUID
+	Debit      *model.CreditTransaction
+	Credit     *model.CreditTransaction
+	Duplicate  bool // The idempotency key was already recorded; nothing was moved
+}
+
 // CreditRepository defines the interface for credit-related operations
 type CreditRepository interface {
 	// GetBalance retrieves the current credit balance for a universal ID
@@ -19,7 +65,17 @@ type CreditRepository interface {
 
 	// UseCredits deducts credits from a universal ID's balance atomically
 	// Returns the new balance and the created transaction
-	UseCredits(ctx context.Context, universalID uuid.UUID, serviceProvider string, amount decimal.Decimal, description string, featureName string) (*model.UserCreditBalance, *model.CreditTransaction, error)
+	UseCredits(ctx context.Context, universalID uuid.UUID, actorID *uuid.UUID, serviceProvider string, amount decimal.Decimal, description string, featureName string, usageMetadata model.JSONB) (*model.UserCreditBalance, *model.CreditTransaction, error)
+
+	// UseCreditsBatch deducts many usages from a balance in a single database transaction.
+	// Usages whose idempotency key was already recorded are not charged again. When atomic is set a
+	// failing usage rolls the whole batch back and ErrCreditBatchRejected is returned with the outcomes;
+	// otherwise failing usages are skipped and the rest applied.
+	UseCreditsBatch(ctx context.Context, universalID uuid.UUID, serviceProvider string, usages []CreditUsage, atomic bool) (*model.UserCreditBalance, []CreditUsageOutcome, error)
+
+	// TransferCredits debits the sender and credits the recipient in a single database transaction,
+	// enforcing the transfer limits while both balances are locked
+	TransferCredits(ctx context.Context, transfer *CreditTransfer) (*CreditTransferResult, error)
 
 	// GetTransactionByReference retrieves a transaction by its reference ID (for idempotency)
 	GetTransactionByReference(ctx context.Context, referenceID string) (*model.CreditTransaction, error)
@@ -10,12 +10,9 @@ import (
 
 // CreditTransactionRepository defines the interface for credit transaction data operations
 type CreditTransactionRepository interface {
-	// GetTransactions retrieves credit transactions with filters
+	// GetTransactions retrieves a page of credit transactions with filters; a malformed cursor fails with ErrInvalidCursor
 	GetTransactions(ctx context.Context, filters dto.TransactionFilters) ([]model.CreditTransaction, error)
 
-	// CountTransactions counts the total number of transactions matching the filters
-	CountTransactions(ctx context.Context, filters dto.TransactionFilters) (int64, error)
-
 	// GetCreditBalance retrieves the current credit balance for a universal ID
 	GetCreditBalance(ctx context.Context, universalID uuid.UUID, serviceProvider string) (*model.UserCreditBalance, error)
 }
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+)
+
+// ExportRepository streams records for finance exports. Records are passed to fn one at a time in
+// (timestamp, id) order without loading the whole range into memory; an error from fn stops the stream.
+type ExportRepository interface {
+	// StreamPayments streams the payments created within the filter range
+	StreamPayments(ctx context.Context, filters dto.ExportFilters, fn func(*model.Payment) error) error
+
+	// StreamRefunds streams the refunded payments whose refund was recorded within the filter range
+	StreamRefunds(ctx context.Context, filters dto.ExportFilters, fn func(*model.Payment) error) error
+
+	// StreamCreditTransactions streams the credit transactions created within the filter range
+	StreamCreditTransactions(ctx context.Context, filters dto.ExportFilters, fn func(*model.CreditTransaction) error) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+)
+
+// FeaturePriceRepository defines the interface for the feature price catalog
+type FeaturePriceRepository interface {
+	// ListEffective lists the prices of a feature effective at the given time for the service provider
+	// and tiers, including the catch-all rows with a blank service provider or tier
+	ListEffective(ctx context.Context, serviceProvider string, featureName string, tiers []string, at time.Time) ([]*model.FeaturePrice, error)
+
+	// List lists catalog entries, optionally narrowed to a service provider and feature.
+	// When at is set only the versions effective at that time are returned.
+	List(ctx context.Context, serviceProvider string, featureName string, at *time.Time) ([]*model.FeaturePrice, error)
+
+	// CreateVersion adds a new version of a price, ending the version it replaces when it takes effect
+	CreateVersion(ctx context.Context, price *model.FeaturePrice) error
+}
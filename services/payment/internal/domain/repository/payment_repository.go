@@ -2,14 +2,22 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
 )
 
+// PlanPurchase is a completed payment made for a plan
+type PlanPurchase struct {
+	PlanID string
+	PaidAt time.Time
+}
+
 type PaymentRepository interface {
 	Create(ctx context.Context, payment *entity.Payment) error
 	GetByID(ctx context.Context, id string) (*entity.Payment, error)
-	GetByUniversalID(ctx context.Context, universalID string, page, limit int) ([]*entity.Payment, int64, error)
+	// ListByUniversalID retrieves a page of a user's payments; a malformed cursor fails with ErrInvalidCursor
+	ListByUniversalID(ctx context.Context, params *entity.PaymentListParams) ([]*entity.Payment, error)
 	GetByTransactionID(ctx context.Context, transactionID string) (*entity.Payment, error)
 	Update(ctx context.Context, payment *entity.Payment) error
 	Delete(ctx context.Context, id string) error
@@ -19,4 +27,12 @@ type PaymentRepository interface {
 	CreateOneTimePayment(ctx context.Context, payment *entity.Payment) error
 	GetByOrderID(ctx context.Context, orderID string) (*entity.Payment, error)
 	UpdatePaymentAfterConfirm(ctx context.Context, orderID string, updates map[string]interface{}) error
+	// UpdateCashReceipt records a cash receipt's status; empty fields of receipt are left unchanged
+	UpdateCashReceipt(ctx context.Context, orderID string, receipt *entity.CashReceipt) error
+
+	// ListPlanPurchases lists the plans a user paid for through completed payments
+	ListPlanPurchases(ctx context.Context, universalID string) ([]*PlanPurchase, error)
+
+	// CountCompleted counts a user's completed payments with a non-zero amount
+	CountCompleted(ctx context.Context, universalID string) (int64, error)
 }
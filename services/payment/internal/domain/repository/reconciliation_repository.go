@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+)
+
+// ReconciliationRepository defines the interface for comparing payments with provider records
+type ReconciliationRepository interface {
+	// ListPayments retrieves the payments of a PG provider and the given service providers created in
+	// [from, to). A blank service provider selects the payments that recorded none.
+	ListPayments(ctx context.Context, pgProvider string, serviceProviders []string, from, to time.Time) ([]*model.Payment, error)
+
+	// GetStripePayment retrieves the payment recorded for a Stripe invoice, or nil if there is none
+	GetStripePayment(ctx context.Context, invoiceID, paymentIntentID string) (*model.Payment, error)
+
+	// UpdatePayment applies updates to the payment with the given ID
+	UpdatePayment(ctx context.Context, id int64, updates map[string]interface{}) error
+}
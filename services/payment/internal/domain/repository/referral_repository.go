@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+)
+
+// ReferralRepository defines the interface for referral codes and referrals persistence
+type ReferralRepository interface {
+	// GetCode retrieves the referral code of a user
+	GetCode(ctx context.Context, universalID uuid.UUID) (*model.ReferralCode, error)
+
+	// CreateCode stores a referral code; returns false when the user or the code already has one
+	CreateCode(ctx context.Context, code *model.ReferralCode) (bool, error)
+
+	// GetCodeByCode looks up the owner of a referral code
+	GetCodeByCode(ctx context.Context, code string) (*model.ReferralCode, error)
+
+	// Create records a pending referral; fails with ErrAlreadyReferred when the referee was referred before
+	Create(ctx context.Context, referral *model.Referral) error
+
+	// GetByReferee retrieves the referral of a referee
+	GetByReferee(ctx context.Context, refereeID uuid.UUID) (*model.Referral, error)
+
+	// CountByReferrer counts a referrer's referrals by status
+	CountByReferrer(ctx context.Context, referrerID uuid.UUID) (map[string]int64, error)
+
+	// UpdateStatus moves a pending referral to its final status; returns false when it was no longer pending
+	UpdateStatus(ctx context.Context, referral *model.Referral) (bool, error)
+}
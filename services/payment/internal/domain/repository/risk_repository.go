@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+)
+
+// RiskAttemptKey names what risk assessments are counted by for velocity rules
+type RiskAttemptKey string
+
+const (
+	RiskAttemptsByUser RiskAttemptKey = "universal_id"
+	RiskAttemptsByCard RiskAttemptKey = "card_fingerprint"
+	RiskAttemptsByIP   RiskAttemptKey = "ip_address"
+)
+
+// RiskRepository defines the interface for recording risk decisions and reading the history they are made on
+type RiskRepository interface {
+	// CreateAssessment records a risk decision
+	CreateAssessment(ctx context.Context, assessment *model.RiskAssessment) error
+
+	// CountAttempts counts the assessments made since a time for a user, card fingerprint or IP address
+	CountAttempts(ctx context.Context, key RiskAttemptKey, value string, since time.Time) (int64, error)
+
+	// CountFailedPayments counts the failed payments of a user created since a time
+	CountFailedPayments(ctx context.Context, universalID uuid.UUID, since time.Time) (int64, error)
+
+	// ListAssessments retrieves assessments matching the filters, newest first
+	ListAssessments(ctx context.Context, filters dto.RiskAssessmentFilters) ([]*model.RiskAssessment, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+)
+
+// ServiceProviderRepository defines the interface for the service provider registry persistence
+type ServiceProviderRepository interface {
+	// GetByCode retrieves a service provider, active or not
+	GetByCode(ctx context.Context, code string) (*model.ServiceProvider, error)
+
+	// List retrieves every service provider ordered by code
+	List(ctx context.Context) ([]*model.ServiceProvider, error)
+
+	// Create stores a new service provider; fails with ErrServiceProviderExists when the code is taken
+	Create(ctx context.Context, provider *model.ServiceProvider) error
+
+	// Update saves the settings and credentials of an existing service provider
+	Update(ctx context.Context, provider *model.ServiceProvider) error
+}
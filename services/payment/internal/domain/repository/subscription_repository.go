@@ -13,4 +13,13 @@ type SubscriptionRepository interface {
 	Update(ctx context.Context, subscription *entity.Subscription) error
 	Cancel(ctx context.Context, subscriptionID string) error
 	ListByStatus(ctx context.Context, status string) ([]*entity.Subscription, error)
+
+	// GetActiveByWorkspaceID retrieves the active subscription whose seats belong to a workspace
+	GetActiveByWorkspaceID(ctx context.Context, workspaceID string) (*entity.Subscription, error)
+
+	// GetActiveByUniversalID retrieves the active subscription of a user or workspace
+	GetActiveByUniversalID(ctx context.Context, universalID string) (*entity.Subscription, error)
+
+	// UpdateQuantity sets the paid seat quantity of a subscription
+	UpdateQuantity(ctx context.Context, subscriptionID string, quantity int) error
 }
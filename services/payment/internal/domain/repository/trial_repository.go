@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+)
+
+// TrialRepository defines the interface for subscription trial persistence
+type TrialRepository interface {
+	Create(ctx context.Context, trial *model.SubscriptionTrial) error
+	GetByID(ctx context.Context, id int64) (*model.SubscriptionTrial, error)
+	GetLatestByUniversalID(ctx context.Context, universalID uuid.UUID) (*model.SubscriptionTrial, error)
+	GetByProviderSubscriptionID(ctx context.Context, subscriptionID string) (*model.SubscriptionTrial, error)
+
+	// GetActiveByProviderCustomerID retrieves the active trial of a provider customer
+	GetActiveByProviderCustomerID(ctx context.Context, provider string, customerID string) (*model.SubscriptionTrial, error)
+
+	// ExistsForUniversalID reports whether the user has ever started a trial
+	ExistsForUniversalID(ctx context.Context, universalID uuid.UUID) (bool, error)
+
+	// ExistsForCardFingerprint reports whether a card was used by any trial other than excludeID.
+	// Fingerprints derived for Toss cards can collide between cards, so a Toss trial only matches
+	// when the billing key it was started with is of the same card company and card number.
+	ExistsForCardFingerprint(ctx context.Context, fingerprint string, cardCompany string, cardLastFour string, excludeID int64) (bool, error)
+
+	// ListEndingBefore lists active trials of a provider ending before the given time that were not yet notified
+	ListEndingBefore(ctx context.Context, provider string, before time.Time) ([]*model.SubscriptionTrial, error)
+
+	// ListDue lists trials of a provider whose trial period is over and that are active, or were
+	// claimed for conversion before staleBefore and never finished
+	ListDue(ctx context.Context, provider string, now time.Time, staleBefore time.Time, limit int) ([]*model.SubscriptionTrial, error)
+
+	// ClaimForConversion moves a due trial to converting, returning false when another run holds it
+	ClaimForConversion(ctx context.Context, id int64, staleBefore time.Time) (bool, error)
+
+	Update(ctx context.Context, id int64, updates map[string]interface{}) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+)
+
+// UsageRepository defines the interface for credit usage rollups
+type UsageRepository interface {
+	// RefreshRollups recomputes the rollups of every period starting at or after from from the credit
+	// transaction ledger. It returns the number of rollup rows written.
+	RefreshRollups(ctx context.Context, granularity model.UsageGranularity, from time.Time) (int64, error)
+
+	// ListRollups retrieves rollups matching the filters, ordered by period and feature
+	ListRollups(ctx context.Context, filters dto.UsageFilters) ([]*model.UsageRollup, error)
+}
@@ -17,4 +17,7 @@ type WorkspaceMember struct {
 type WorkspaceVerificationRepository interface {
 	// VerifyWorkspaceMembership checks if a user belongs to a workspace
 	VerifyWorkspaceMembership(ctx context.Context, userID, workspaceID string) (*WorkspaceMember, error)
+
+	// CountWorkspaceMembers returns the number of members in a workspace
+	CountWorkspaceMembers(ctx context.Context, workspaceID string) (int, error)
 }
\ No newline at end of file
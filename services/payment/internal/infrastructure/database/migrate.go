@@ -243,7 +243,7 @@ func createCustomTypes(db *gorm.DB) error {
 	// Check if transaction_type exists
 	db.Raw(`SELECT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'transaction_type')`).Scan(&exists)
 	if !exists {
-		if err := db.Exec(`CREATE TYPE transaction_type AS ENUM ('credit_allocation', 'credit_usage', 'refund', 'adjustment', 'subscription_cancellation')`).Error; err != nil {
+		if err := db.Exec(`CREATE TYPE transaction_type AS ENUM ('credit_allocation', 'credit_usage', 'refund', 'adjustment', 'subscription_cancellation', 'transfer_out', 'transfer_in')`).Error; err != nil {
 			return err
 		}
 	} else {
@@ -19,6 +19,16 @@ type Repositories struct {
 	Plan                  repository.PlanRepository
 	WorkspaceVerification domainRepo.WorkspaceVerificationRepository
 	BillingKey            domainRepo.BillingKeyRepository
+	Coupon                domainRepo.CouponRepository
+	Trial                 domainRepo.TrialRepository
+	Usage                 domainRepo.UsageRepository
+	FeaturePrice          domainRepo.FeaturePriceRepository
+	Referral              domainRepo.ReferralRepository
+	ServiceProvider       domainRepo.ServiceProviderRepository
+	Export                domainRepo.ExportRepository
+	Analytics             domainRepo.AnalyticsRepository
+	Reconciliation        domainRepo.ReconciliationRepository
+	Risk                  domainRepo.RiskRepository
 }
 
 // NewRepositories creates new repository instances with database connection
@@ -47,5 +57,15 @@ func NewRepositories(db *gorm.DB, supabaseConfig *config.SupabaseConfig, logger
 		Plan:                  repository.NewPlanRepository(db, logger),
 		WorkspaceVerification: workspaceVerificationRepo,
 		BillingKey:            repository.NewBillingKeyRepository(db, logger),
+		Coupon:                repository.NewCouponRepository(db, logger),
+		Trial:                 repository.NewTrialRepository(db, logger),
+		Usage:                 repository.NewUsageRepository(db, logger),
+		FeaturePrice:          repository.NewFeaturePriceRepository(db, logger),
+		Referral:              repository.NewReferralRepository(db, logger),
+		ServiceProvider:       repository.NewServiceProviderRepository(db, logger),
+		Export:                repository.NewExportRepository(db, logger),
+		Analytics:             repository.NewAnalyticsRepository(db, logger),
+		Reconciliation:        repository.NewReconciliationRepository(db, logger),
+		Risk:                  repository.NewRiskRepository(db, logger),
 	}
 }
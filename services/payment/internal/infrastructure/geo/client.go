@@ -0,0 +1,83 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	geov1 "github.com/wekeepgrowing/semo-backend-monorepo/proto/geo/v1"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const defaultLookupTimeout = 300 * time.Millisecond
+
+// Client resolves the location of IP addresses with the geo service over gRPC
+type Client struct {
+	conn    *grpc.ClientConn
+	client  geov1.GeoServiceClient
+	timeout time.Duration
+}
+
+// NewClient creates a new Client for the geo service at cfg.Addr. The connection is established lazily.
+func NewClient(cfg config.GeoServiceConfig) (*Client, error) {
+	conn, err := grpc.NewClient(cfg.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create geo service client: %w", err)
+	}
+
+	timeout := time.Duration(cfg.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = defaultLookupTimeout
+	}
+
+	return &Client{
+		conn:    conn,
+		client:  geov1.NewGeoServiceClient(conn),
+		timeout: timeout,
+	}, nil
+}
+
+// CountryCode returns the upper-case ISO country code of ip, or "" when the geo service does not know it
+func (c *Client) CountryCode(ctx context.Context, ip string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.GetCountryInfo(ctx, &geov1.IpRequest{Ip: ip})
+	if err != nil {
+		return "", fmt.Errorf("failed to look up country of %s: %w", ip, err)
+	}
+
+	return strings.ToUpper(resp.GetCountry().GetIsoCode()), nil
+}
+
+// Locate returns the country, ASN and anonymity of ip in a single lookup
+func (c *Client) Locate(ctx context.Context, ip string) (*model.GeoLocation, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.GetGeoData(ctx, &geov1.IpRequest{Ip: ip})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up location of %s: %w", ip, err)
+	}
+
+	return &model.GeoLocation{
+		IP:             ip,
+		CountryCode:    strings.ToUpper(resp.GetCountryCode()),
+		ContinentCode:  resp.GetContinentCode(),
+		ASN:            resp.GetAsn(),
+		ASOrganization: resp.GetIsp(),
+		IsAnonymous:    resp.GetIsAnonymous(),
+		IsAnonymousVPN: resp.GetIsAnonymousVpn(),
+		IsTorExitNode:  resp.GetIsTorExitNode(),
+		Resolved:       true,
+	}, nil
+}
+
+// Close closes the connection to the geo service
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"net"
 
+	paymentv1 "github.com/wekeepgrowing/semo-backend-monorepo/proto/payment/v1"
+	grpcHandler "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/adapter/handler/grpc"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/database"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
@@ -13,14 +17,19 @@ import (
 type Server struct {
 	config   *config.Config
 	logger   *zap.Logger
+	repos    *database.Repositories
 	server   *grpc.Server
 	listener net.Listener
+
+	entitlements *usecase.EntitlementService
 }
 
-func NewServer(cfg *config.Config, logger *zap.Logger) *Server {
+func NewServer(cfg *config.Config, logger *zap.Logger, repos *database.Repositories, entitlements *usecase.EntitlementService) *Server {
 	return &Server{
-		config: cfg,
-		logger: logger,
+		config:       cfg,
+		logger:       logger,
+		repos:        repos,
+		entitlements: entitlements,
 	}
 }
 
@@ -35,8 +44,9 @@ func (s *Server) Start() error {
 
 	s.server = grpc.NewServer()
 
-	// Register payment service here
-	// pb.RegisterPaymentServiceServer(s.server, handler)
+	pricingService := usecase.NewFeaturePricingService(s.repos.FeaturePrice, s.entitlements, s.logger)
+	batchService := usecase.NewCreditBatchService(s.repos.Credit, pricingService, s.logger, usecase.DefaultServiceProvider(&s.config.Service).Code)
+	paymentv1.RegisterPaymentServiceServer(s.server, grpcHandler.NewPaymentHandler(s.entitlements, batchService, s.logger))
 
 	s.logger.Info("Starting gRPC server", zap.String("address", addr))
 
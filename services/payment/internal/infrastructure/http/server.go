@@ -4,19 +4,22 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	"github.com/stripe/stripe-go/v79"
+	"github.com/wekeepgrowing/semo-backend-monorepo/pkg/middleware/clientip"
 	handlers "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/adapter/handler/http"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
-	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/crypto"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/database"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/geo"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/notification"
 	providerFactory "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/provider"
-	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/provider/toss"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/auth"
+	geoMiddleware "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/geo"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/middleware/tenant"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
 	"go.uber.org/zap"
 )
@@ -35,34 +38,93 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 }
 
 type Server struct {
-	config *config.Config
-	logger *zap.Logger
-	echo   *echo.Echo
-	repos  *database.Repositories
+	config           *config.Config
+	logger           *zap.Logger
+	echo             *echo.Echo
+	repos            *database.Repositories
+	serviceProviders *usecase.ServiceProviderRegistry
+	pricing          *usecase.PricingService
+	geoClient        *geo.Client
+	geoLocations     *usecase.GeoLocationService
+	entitlements     *usecase.EntitlementService
 }
 
-func NewServer(cfg *config.Config, logger *zap.Logger, repos *database.Repositories) *Server {
+func NewServer(cfg *config.Config, logger *zap.Logger, repos *database.Repositories, entitlements *usecase.EntitlementService) *Server {
 	e := echo.New()
 
 	// Register custom validator
 	e.Validator = &CustomValidator{validator: validator.New()}
 
-	// Initialize Stripe
-	stripe.Key = cfg.Service.StripeSecretKey
+	// Service providers (products) served by this deployment; their credentials are encrypted at rest
+	var credentialEncryption crypto.EncryptionService
+	if cfg.Service.Toss.EncryptionKey != "" {
+		encryptService, err := crypto.NewAESEncryptionService(cfg.Service.Toss.EncryptionKey)
+		if err != nil {
+			logger.Warn("Failed to initialize encryption service, service provider credentials cannot be stored",
+				zap.Error(err))
+		} else {
+			credentialEncryption = encryptService
+		}
+	}
+	serviceProviders := usecase.NewServiceProviderRegistry(
+		repos.ServiceProvider,
+		credentialEncryption,
+		usecase.DefaultServiceProvider(&cfg.Service),
+		usecase.DefaultServiceProviderCacheTTL,
+		logger,
+	)
+
+	// Client IPs are read from forwarding headers set by the configured proxies only; without trusted
+	// proxies the headers are ignored, so callers cannot choose the IP they are priced and assessed by
+	resolver, err := clientip.NewResolver(cfg.Server.HTTP.TrustedProxies)
+	if err != nil {
+		logger.Fatal("Invalid trusted proxies", zap.Error(err))
+	}
+	e.IPExtractor = resolver.IPExtractor()
+
+	// Callers are located, and priced in the currency of their country, when the geo service is configured
+	var countryLocator usecase.CountryLocator
+	var geoClient *geo.Client
+	var geoLocations *usecase.GeoLocationService
+	if cfg.Pricing.GeoService.Addr != "" {
+		client, err := geo.NewClient(cfg.Pricing.GeoService)
+		if err != nil {
+			logger.Warn("Failed to initialize geo service client, plans are priced in the default currency",
+				zap.Error(err))
+		} else {
+			geoClient = client
+			geoLocations = usecase.NewGeoLocationService(
+				client,
+				time.Duration(cfg.Pricing.GeoService.CacheTTLSeconds)*time.Second,
+				time.Duration(cfg.Pricing.GeoService.CooldownSeconds)*time.Second,
+				logger,
+			)
+			countryLocator = geoLocations
+		}
+	}
+	pricing := usecase.NewPricingService(cfg.Pricing, countryLocator, logger)
 
 	// Middleware
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: cfg.Service.AllowedClientOrigins(),
+		// Client URLs of every active service provider are allowed
+		AllowOriginFunc: func(origin string) (bool, error) {
+			return serviceProviders.AllowsOrigin(context.Background(), origin), nil
+		},
 		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.DELETE},
 	}))
 
 	return &Server{
-		config: cfg,
-		logger: logger,
-		echo:   e,
-		repos:  repos,
+		config:           cfg,
+		logger:           logger,
+		echo:             e,
+		repos:            repos,
+		serviceProviders: serviceProviders,
+		pricing:          pricing,
+		geoClient:        geoClient,
+		geoLocations:     geoLocations,
+		entitlements:     entitlements,
 	}
 }
 
@@ -77,6 +139,11 @@ func (s *Server) Start() error {
 }
 
 func (s *Server) Shutdown(ctx context.Context) error {
+	if s.geoClient != nil {
+		if err := s.geoClient.Close(); err != nil {
+			s.logger.Warn("Failed to close geo service client", zap.Error(err))
+		}
+	}
 	return s.echo.Shutdown(ctx)
 }
 
@@ -94,57 +161,114 @@ func (s *Server) setupRoutes() {
 
 	// Initialize services
 	subscriptionService := usecase.NewSubscriptionService(s.repos.CustomerMapping, s.repos.Subscription, s.logger)
-	creditService := usecase.NewCreditService(s.repos.Credit, s.repos.Subscription, s.repos.Plan, s.logger, model.ServiceProviderSemo)
-	creditTransactionService := usecase.NewCreditTransactionService(s.repos.CreditTransaction, s.logger, model.ServiceProviderSemo)
+	defaultServiceProvider := s.serviceProviders.DefaultCode()
+	creditService := usecase.NewCreditService(s.repos.Credit, s.repos.Subscription, s.repos.Plan, s.logger, defaultServiceProvider, s.entitlements)
+	creditTransactionService := usecase.NewCreditTransactionService(s.repos.CreditTransaction, s.logger, defaultServiceProvider)
 	workspaceVerificationService := usecase.NewWorkspaceVerificationService(s.repos.WorkspaceVerification, s.logger)
-	productUseCase := usecase.NewProductUseCase(s.repos.Payment, s.logger)
-
-	// Initialize handlers
-	plansHandler := handlers.NewPlansHandler(s.logger, s.repos.Plan)
-	checkoutHandler := handlers.NewCheckoutHandler(s.logger, s.config.Service.PrimaryClientURL(), s.config.Service.AllowedClientOrigins(), s.repos.CustomerMapping)
-	subscriptionHandler := handlers.NewSubscriptionHandler(s.logger, subscriptionService, s.repos.CustomerMapping, s.config.Service.PrimaryClientURL())
-	webhookHandler := handlers.NewWebhookHandler(s.logger, s.config.Service.StripeWebhookSecret, s.repos.Webhook, s.repos.Subscription, s.repos.Payment, s.repos.CustomerMapping, s.repos.Credit, s.repos.Plan, model.ServiceProviderSemo)
-	paymentUsecase := usecase.NewPaymentUsecase(s.repos.Payment, nil, s.logger)
-	paymentHandler := handlers.NewPaymentHandler(paymentUsecase, s.logger)
-	creditHandler := handlers.NewCreditHandler(s.logger, creditService, creditTransactionService)
-	productHandler := handlers.NewProductHandler(productUseCase, factory, s.repos.CustomerMapping, s.repos.Plan, s.logger)
-	tossWebhookHandler := handlers.NewTossWebhookHandler(
-		s.logger,
+	cashReceiptService := usecase.NewCashReceiptService(
 		s.repos.Payment,
-		creditService,
-		s.config.Service.Toss.SecretKey,
-		s.config.Service.Toss.ClientKey,
-		s.config.Webhook.Secret,
+		usecase.TossCashReceiptProviders(s.serviceProviders, s.logger),
+		s.logger,
 	)
+	couponService := usecase.NewCouponService(s.repos.Coupon, s.repos.Plan, creditService, s.logger)
+	referralService := usecase.NewReferralService(s.repos.Referral, s.repos.Payment, creditService, s.config.Credits.Referral, s.logger)
+	productUseCase := usecase.NewProductUseCase(s.repos.Payment, cashReceiptService, couponService, referralService, s.logger)
 
-	// Initialize billing service and handler
-	// 빌링은 API 개별 연동용 시크릿 키(billing_secret_key)를 사용해야 함
-	var billingHandler *handlers.BillingHandler
-	if s.config.Service.Toss.EncryptionKey != "" && s.config.Service.Toss.BillingSecretKey != "" {
-		billingTossProvider := toss.NewTossProvider(
-			s.config.Service.Toss.BillingSecretKey, // API 개별 연동용 시크릿 키
-			s.config.Service.Toss.ClientKey,
-			s.logger,
-		)
+	// Initialize billing service
+	// 빌링은 서비스 제공자별 API 개별 연동용 시크릿 키(toss_billing_secret_key)를 사용해야 함
+	var billingService *usecase.BillingService
+	if s.config.Service.Toss.EncryptionKey != "" {
 		encryptService, err := crypto.NewAESEncryptionService(s.config.Service.Toss.EncryptionKey)
 		if err != nil {
 			s.logger.Warn("Failed to initialize encryption service, billing endpoints disabled",
 				zap.Error(err))
 		} else {
-			billingService := usecase.NewBillingService(
+			billingService = usecase.NewBillingService(
 				s.repos.BillingKey,
 				s.repos.Payment,
-				billingTossProvider,
+				s.repos.Plan,
+				s.serviceProviders,
 				encryptService,
 				creditService,
+				couponService,
+				referralService,
 				s.logger,
 			)
-			billingHandler = handlers.NewBillingHandler(billingService, s.logger)
-			s.logger.Info("Billing service initialized with API individual integration key")
+			if s.config.Service.Toss.BillingSecretKey == "" {
+				s.logger.Warn("Billing secret key not configured, billing is unavailable to the default service provider")
+			}
+			s.logger.Info("Billing service initialized with per service provider integration keys")
+		}
+	} else {
+		s.logger.Warn("Billing encryption key not configured, billing endpoints disabled")
+	}
+
+	trialService := usecase.NewTrialService(
+		s.repos.Trial,
+		s.repos.Plan,
+		s.repos.BillingKey,
+		billingService,
+		creditService,
+		notification.NewTrialNotifier(s.config.Email, s.logger),
+		s.entitlements,
+		s.logger,
+	)
+	seatService := usecase.NewSeatService(s.repos.Subscription, s.repos.Plan, s.repos.WorkspaceVerification, creditService, s.logger)
+	usageService := usecase.NewUsageService(s.repos.Usage, s.logger)
+	exportService := usecase.NewExportService(s.repos.Export, s.logger)
+	analyticsService := usecase.NewAnalyticsService(s.repos.Analytics, s.logger)
+
+	// Checkouts, subscriptions and card registrations are scored for fraud when risk checks are enabled
+	var riskService *usecase.RiskService
+	if s.config.Risk.Enabled {
+		service, err := usecase.NewRiskService(s.repos.Risk, s.repos.BillingKey, s.config.Risk, s.logger)
+		if err != nil {
+			s.logger.Warn("Invalid risk rules, risk checks are disabled", zap.Error(err))
+		} else {
+			riskService = service
 		}
-	} else if s.config.Service.Toss.BillingSecretKey == "" {
-		s.logger.Warn("Billing secret key not configured, billing endpoints disabled")
 	}
+	reconciliationService := s.newReconciliationService(creditService)
+	pricingService := usecase.NewFeaturePricingService(s.repos.FeaturePrice, s.entitlements, s.logger)
+
+	// Initialize handlers
+	plansHandler := handlers.NewPlansHandler(s.logger, s.repos.Plan, s.pricing)
+	checkoutHandler := handlers.NewCheckoutHandler(s.logger, s.config.Service.PrimaryClientURL(), s.config.Service.AllowedClientOrigins(), s.repos.CustomerMapping)
+	subscriptionHandler := handlers.NewSubscriptionHandler(s.logger, subscriptionService, s.repos.CustomerMapping, s.config.Service.PrimaryClientURL(), couponService, trialService, seatService, riskService)
+	webhookHandler := handlers.NewWebhookHandler(s.logger, s.serviceProviders, s.repos.Webhook, s.repos.Subscription, s.repos.Payment, s.repos.CustomerMapping, s.repos.Credit, s.repos.Plan, trialService, referralService, couponService, s.entitlements)
+	paymentUsecase := usecase.NewPaymentUsecase(s.repos.Payment, nil, s.logger)
+	paymentHandler := handlers.NewPaymentHandler(paymentUsecase, s.logger)
+	creditBatchService := usecase.NewCreditBatchService(s.repos.Credit, pricingService, s.logger, defaultServiceProvider)
+	creditHandler := handlers.NewCreditHandler(s.logger, creditService, creditTransactionService, pricingService, creditBatchService)
+	creditTransferService := usecase.NewCreditTransferService(s.repos.Credit, s.repos.Payment, s.repos.CustomerMapping, s.repos.WorkspaceVerification, s.config.Credits.Transfer, s.logger, defaultServiceProvider)
+	creditTransferHandler := handlers.NewCreditTransferHandler(creditTransferService, workspaceVerificationService, s.logger)
+	referralHandler := handlers.NewReferralHandler(referralService, s.logger)
+	productHandler := handlers.NewProductHandler(productUseCase, factory, s.repos.CustomerMapping, s.repos.Plan, riskService, s.logger)
+	couponHandler := handlers.NewCouponHandler(couponService, s.logger)
+	trialHandler := handlers.NewTrialHandler(trialService, s.logger)
+	seatHandler := handlers.NewSeatHandler(seatService, workspaceVerificationService, s.logger)
+	entitlementHandler := handlers.NewEntitlementHandler(s.entitlements, s.logger)
+	usageHandler := handlers.NewUsageHandler(usageService, s.logger)
+	featurePriceHandler := handlers.NewFeaturePriceHandler(pricingService, s.logger)
+	serviceProviderHandler := handlers.NewServiceProviderHandler(s.serviceProviders, s.logger)
+	exportHandler := handlers.NewExportHandler(exportService, s.logger)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService, s.logger)
+	reconciliationHandler := handlers.NewReconciliationHandler(reconciliationService, s.logger)
+	riskHandler := handlers.NewRiskHandler(riskService, s.logger)
+	var billingHandler *handlers.BillingHandler
+	if billingService != nil {
+		billingHandler = handlers.NewBillingHandler(billingService, riskService, s.logger)
+	}
+	tossWebhookHandler := handlers.NewTossWebhookHandler(
+		s.logger,
+		s.repos.Payment,
+		creditService,
+		cashReceiptService,
+		couponService,
+		referralService,
+		s.serviceProviders,
+		s.config.Webhook.Secret,
+	)
 
 	// JWT middleware configuration
 	jwtConfig := auth.JWTConfig{
@@ -156,11 +280,19 @@ func (s *Server) setupRoutes() {
 			"/webhook",
 			"/api/v1/plans",
 			"/api/v1/internal/webhook-data",
+			"/api/v1/admin", // Authenticated with the admin API key
 		},
 	}
 
-	// API v1 routes
-	v1 := s.echo.Group("/api/v1")
+	// API v1 routes, served for the service provider named by the X-Service-Provider header
+	v1 := s.echo.Group("/api/v1", tenant.ServiceProviderMiddleware(s.serviceProviders, s.logger))
+
+	// Routes that assess risk read the caller's country, ASN and anonymity through geoMiddleware.GetLocation;
+	// other routes do not locate the caller
+	var locate []echo.MiddlewareFunc
+	if s.geoLocations != nil {
+		locate = append(locate, geoMiddleware.LocationMiddleware(s.geoLocations, s.logger))
+	}
 
 	// Public routes (no authentication required)
 	// Plans & Pricing - public for browsing
@@ -173,15 +305,35 @@ func (s *Server) setupRoutes() {
 
 	// Subscriptions - RESTful style (all require authentication)
 	subscriptions := protected.Group("/subscriptions")
-	subscriptions.POST("", subscriptionHandler.CreateSubscription)
+	subscriptions.POST("", subscriptionHandler.CreateSubscription, locate...)
 	subscriptions.GET("/current", subscriptionHandler.GetCurrentSubscription)
 	subscriptions.DELETE("/current", subscriptionHandler.CancelCurrentSubscription) // New secure endpoint
 	subscriptions.POST("/portal", checkoutHandler.CreatePortalSession)
 
 	// One-time payment - RESTful style (all require authentication)
 	products := protected.Group("/products")
-	products.POST("", productHandler.CreateProduct)          // Provider-based payment creation
-	products.POST("/confirm", productHandler.ConfirmProduct) // Provider payment confirmation
+	products.POST("", productHandler.CreateProduct, locate...) // Provider-based payment creation
+	products.POST("/confirm", productHandler.ConfirmProduct)   // Provider payment confirmation
+
+	// Free trials (require authentication)
+	trials := protected.Group("/trials")
+	trials.POST("", trialHandler.StartTrial)
+	trials.GET("/current", trialHandler.GetCurrentTrial)
+	trials.DELETE("/current", trialHandler.CancelCurrentTrial)
+
+	// Workspace seats (require authentication and an X-Workspace-Id header)
+	seats := protected.Group("/seats")
+	seats.GET("", seatHandler.GetSeats)
+	seats.POST("/reserve", seatHandler.ReserveSeat) // call before adding a member; ?purchase=true needs owner or admin
+	// Call after members are added or removed
+	seats.POST("/sync", seatHandler.SyncSeats,
+		auth.RequireWorkspaceRole(workspaceVerificationService, s.logger, auth.WorkspaceRoleOwner, auth.WorkspaceRoleAdmin))
+
+	// Entitlements derived from the user's plans (require authentication)
+	protected.GET("/entitlements", entitlementHandler.GetEntitlements)
+
+	// Coupon preview (requires authentication)
+	protected.POST("/coupons/validate", couponHandler.ValidateCoupon)
 
 	// Checkout session status endpoint (requires authentication)
 	protected.GET("/checkout/session/:sessionId", checkoutHandler.CheckSessionStatus)
@@ -194,21 +346,56 @@ func (s *Server) setupRoutes() {
 	protected.GET("/credits", creditHandler.GetUserCredits)
 	protected.POST("/credits", creditHandler.UseCredits)
 	protected.GET("/credits/transactions", creditHandler.GetTransactionHistory)
+	protected.POST("/credits/quote", creditHandler.QuoteCredits)
+	protected.POST("/credits/batch", creditHandler.UseCreditsBatch)
+	protected.POST("/credits/transfers", creditTransferHandler.TransferCredits)
+
+	// Referral program (require authentication)
+	protected.GET("/referrals", referralHandler.GetReferrals)
+	protected.POST("/referrals/redeem", referralHandler.RedeemReferral)
+
+	// Credit usage per feature and period (require authentication)
+	protected.GET("/usage", usageHandler.GetUsage)
 
 	// Billing routes (require authentication)
 	if billingHandler != nil {
 		billing := protected.Group("/billing")
-		billing.POST("/issue", billingHandler.IssueBillingKey)
-		billing.POST("/charge", billingHandler.ChargeBillingKey)
+		billing.POST("/issue", billingHandler.IssueBillingKey, locate...)
+		billing.POST("/charge", billingHandler.ChargeBillingKey, locate...)
 		billing.GET("/cards", billingHandler.GetCards)
 		billing.DELETE("/cards/:id", billingHandler.DeactivateCard)
 	}
 
+	// Admin routes (require the admin API key)
+	admin := v1.Group("/admin", auth.AdminKeyMiddleware(s.config.Service.AdminAPIKey, s.logger))
+	admin.GET("/usage", usageHandler.GetUsageReport)
+	admin.GET("/feature-prices", featurePriceHandler.ListFeaturePrices)
+	admin.POST("/feature-prices", featurePriceHandler.CreateFeaturePrice)
+	admin.GET("/service-providers", serviceProviderHandler.ListServiceProviders)
+	admin.POST("/service-providers", serviceProviderHandler.CreateServiceProvider)
+	admin.PUT("/service-providers/:code", serviceProviderHandler.UpdateServiceProvider)
+	admin.GET("/exports/:dataset", exportHandler.Export)
+	admin.GET("/analytics/revenue", analyticsHandler.GetRevenue)
+	admin.GET("/analytics/cohorts", analyticsHandler.GetCohorts)
+	admin.POST("/analytics/snapshots", analyticsHandler.TakeSnapshot)
+	admin.POST("/reconciliations", reconciliationHandler.Reconcile)
+	admin.GET("/risk-assessments", riskHandler.ListAssessments)
+	admin.POST("/coupons", couponHandler.CreateCoupon)
+
 	// Internal/Debug routes
 	internal := v1.Group("/internal")
 	internal.GET("/webhook-data", webhookHandler.GetWebhookData)
 
 	// Webhook routes (outside API versioning)
-	s.echo.POST("/webhook", webhookHandler.HandleWebhook)   // Stripe webhook
-	s.echo.POST("/webhook/toss", tossWebhookHandler.Handle) // Toss webhook
+	s.echo.POST("/webhook", webhookHandler.HandleWebhook)                  // Stripe webhook of the default service provider
+	s.echo.POST("/webhook/toss", tossWebhookHandler.Handle)                // Toss webhook of the default service provider
+	s.echo.POST("/webhook/toss/:provider", tossWebhookHandler.Handle)      // Toss webhook of a registered service provider
+	s.echo.POST("/webhook/stripe/:provider", webhookHandler.HandleWebhook) // Stripe webhook of a registered service provider
+}
+
+// newReconciliationService creates the reconciliation service for the Toss and Stripe accounts of
+// every service provider
+func (s *Server) newReconciliationService(creditService *usecase.CreditService) *usecase.ReconciliationService {
+	clients := usecase.ServiceProviderReconciliationClients("", "", s.logger)
+	return usecase.NewReconciliationService(s.repos.Reconciliation, s.repos.Credit, creditService, s.serviceProviders, clients, s.logger)
 }
@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// TrialNotifier sends trial lifecycle emails over SMTP.
+// When SMTP is not configured it only logs the notification.
+type TrialNotifier struct {
+	cfg    config.EmailConfig
+	logger *zap.Logger
+}
+
+// NewTrialNotifier creates a new TrialNotifier instance
+func NewTrialNotifier(cfg config.EmailConfig, logger *zap.Logger) *TrialNotifier {
+	return &TrialNotifier{
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// NotifyTrialWillEnd tells the user that their trial ends soon and will be charged
+func (n *TrialNotifier) NotifyTrialWillEnd(ctx context.Context, trial *model.SubscriptionTrial, plan *model.PaymentPlan) error {
+	planName := trial.PlanID
+	if plan != nil && plan.DisplayName != "" {
+		planName = plan.DisplayName
+	}
+
+	n.logger.Info("Trial will end notification",
+		zap.Int64("trial_id", trial.ID),
+		zap.String("universal_id", trial.UniversalID.String()),
+		zap.String("plan", planName),
+		zap.Time("ends_at", trial.EndsAt))
+
+	if n.cfg.Host == "" || trial.Email == "" {
+		return nil
+	}
+
+	subject := fmt.Sprintf("Your %s trial ends on %s", planName, trial.EndsAt.Format("2006-01-02"))
+	body := fmt.Sprintf(
+		"Your free trial of %s ends on %s.\r\nYour registered card will be charged automatically unless you cancel before then.\r\n",
+		planName,
+		trial.EndsAt.Format("2006-01-02 15:04 MST"),
+	)
+
+	return n.send(trial.Email, subject, body)
+}
+
+func (n *TrialNotifier) send(to string, subject string, body string) error {
+	msg := strings.Join([]string{
+		"From: " + n.cfg.From,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	addr := fmt.Sprintf("%s:%d", n.cfg.Host, n.cfg.Port)
+	var auth smtp.Auth
+	if n.cfg.Username != "" {
+		auth = smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
+	}
+
+	if err := smtp.SendMail(addr, auth, n.cfg.From, []string{to}, []byte(msg)); err != nil {
+		n.logger.Error("Failed to send notification email",
+			zap.String("to", to),
+			zap.Error(err))
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
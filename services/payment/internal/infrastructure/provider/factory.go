@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
 	stripeProvider "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/provider/stripe"
 	tossProvider "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/provider/toss"
@@ -47,27 +48,45 @@ func (f *Factory) GetProviderFromString(providerStr string) (provider.PaymentPro
 	return f.GetProvider(providerType)
 }
 
+// GetProviderForServiceProvider returns a payment provider using the credentials of a service provider
+func (f *Factory) GetProviderForServiceProvider(serviceProvider *model.ServiceProvider, providerStr string) (provider.PaymentProvider, error) {
+	if providerStr == "" {
+		providerStr = string(provider.ProviderTypeToss)
+	}
+
+	credentials := serviceProvider.Credentials
+	switch provider.ProviderType(providerStr) {
+	case provider.ProviderTypeToss:
+		return f.newTossProvider(credentials.TossSecretKey, credentials.TossClientKey)
+	case provider.ProviderTypeStripe:
+		return f.newStripeProvider(credentials.StripeSecretKey)
+	default:
+		return nil, fmt.Errorf("unsupported provider type: %s", providerStr)
+	}
+}
+
 // createTossProvider creates a new Toss provider instance
 func (f *Factory) createTossProvider() (provider.PaymentProvider, error) {
-	if f.config.Service.Toss.SecretKey == "" {
+	return f.newTossProvider(f.config.Service.Toss.SecretKey, f.config.Service.Toss.ClientKey)
+}
+
+// createStripeProvider creates a new Stripe provider instance
+func (f *Factory) createStripeProvider() (provider.PaymentProvider, error) {
+	return f.newStripeProvider(f.config.Service.StripeSecretKey)
+}
+
+func (f *Factory) newTossProvider(secretKey string, clientKey string) (provider.PaymentProvider, error) {
+	if secretKey == "" {
 		return nil, fmt.Errorf("Toss secret key not configured")
 	}
 
-	return tossProvider.NewTossProvider(
-		f.config.Service.Toss.SecretKey,
-		f.config.Service.Toss.ClientKey,
-		f.logger,
-	), nil
+	return tossProvider.NewTossProvider(secretKey, clientKey, f.logger), nil
 }
 
-// createStripeProvider creates a new Stripe provider instance
-func (f *Factory) createStripeProvider() (provider.PaymentProvider, error) {
-	if f.config.Service.StripeSecretKey == "" {
+func (f *Factory) newStripeProvider(secretKey string) (provider.PaymentProvider, error) {
+	if secretKey == "" {
 		return nil, fmt.Errorf("Stripe secret key not configured")
 	}
 
-	return stripeProvider.NewStripeProvider(
-		f.config.Service.StripeSecretKey,
-		f.logger,
-	), nil
+	return stripeProvider.NewStripeProvider(secretKey, f.logger), nil
 }
\ No newline at end of file
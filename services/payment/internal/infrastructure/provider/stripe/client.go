@@ -0,0 +1,21 @@
+package stripe
+
+import (
+	"strings"
+
+	stripeapi "github.com/stripe/stripe-go/v79"
+	"github.com/stripe/stripe-go/v79/client"
+)
+
+// NewAPIClient creates a Stripe API client. A non-empty baseURL points it at another API host,
+// such as a local fake of Stripe.
+func NewAPIClient(secretKey, baseURL string) *client.API {
+	if baseURL == "" {
+		return client.New(secretKey, nil)
+	}
+
+	return client.New(secretKey, stripeapi.NewBackendsWithConfig(&stripeapi.BackendConfig{
+		URL:               stripeapi.String(strings.TrimSuffix(baseURL, "/")),
+		MaxNetworkRetries: stripeapi.Int64(0),
+	}))
+}
@@ -39,7 +39,7 @@ func (t *TossProvider) IssueBillingKey(ctx context.Context, req *provider.IssueB
 	t.logger.Info("TossProvider: Request body prepared",
 		zap.String("request_body", string(jsonBody)))
 
-	url := fmt.Sprintf("%s/%s/billing/authorizations/issue", tossAPIBaseURL, tossAPIVersion)
+	url := fmt.Sprintf("%s/%s/billing/authorizations/issue", t.baseURL, tossAPIVersion)
 	t.logger.Info("TossProvider: Calling Toss API",
 		zap.String("url", url))
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
@@ -108,13 +108,36 @@ func (t *TossProvider) IssueBillingKey(ctx context.Context, req *provider.IssueB
 		}
 	}
 
+	result.CardCountry = issuerCountry(result.Card.IssuerCode)
+
 	t.logger.Info("TossProvider: Billing key issued successfully",
 		zap.String("customer_key", result.CustomerKey),
-		zap.String("card_company", result.CardCompany))
+		zap.String("card_company", result.CardCompany),
+		zap.String("card_country", result.CardCountry))
 
 	return &result, nil
 }
 
+// overseasIssuerCodes are the Toss issuer codes of cards issued abroad, whose country Toss does not report
+var overseasIssuerCodes = map[string]bool{
+	"4V": true, // Visa
+	"4M": true, // Mastercard
+	"4J": true, // JCB
+	"3C": true, // UnionPay
+	"6D": true, // Diners Club
+	"6I": true, // Discover
+	"7A": true, // American Express
+}
+
+// issuerCountry returns the country of a card issuer from its Toss issuer code. Every other issuer
+// is a Korean card company; the country of overseas cards is unknown and returned blank.
+func issuerCountry(issuerCode string) string {
+	if issuerCode == "" || overseasIssuerCodes[issuerCode] {
+		return ""
+	}
+	return "KR"
+}
+
 // ChargeBillingKey charges a billing key
 // POST /v1/billing/{billingKey}
 func (t *TossProvider) ChargeBillingKey(ctx context.Context, req *provider.ChargeBillingKeyRequest) (*provider.ChargeBillingKeyResponse, error) {
@@ -128,6 +151,9 @@ func (t *TossProvider) ChargeBillingKey(ctx context.Context, req *provider.Charg
 		"orderId":     req.OrderID,
 		"orderName":   req.OrderName,
 	}
+	if req.Currency != "" {
+		body["currency"] = req.Currency
+	}
 
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
@@ -138,7 +164,7 @@ func (t *TossProvider) ChargeBillingKey(ctx context.Context, req *provider.Charg
 		}
 	}
 
-	url := fmt.Sprintf("%s/%s/billing/%s", tossAPIBaseURL, tossAPIVersion, req.BillingKey)
+	url := fmt.Sprintf("%s/%s/billing/%s", t.baseURL, tossAPIVersion, req.BillingKey)
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, &provider.ProviderError{
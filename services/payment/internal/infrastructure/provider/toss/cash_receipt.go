@@ -0,0 +1,152 @@
+package toss
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
+	"go.uber.org/zap"
+)
+
+// IssueCashReceipt issues a cash receipt for a transfer or virtual account payment
+// POST /v1/cash-receipts
+func (t *TossProvider) IssueCashReceipt(ctx context.Context, req *provider.IssueCashReceiptRequest) (*provider.CashReceiptResponse, error) {
+	t.logger.Info("TossProvider: Issuing cash receipt",
+		zap.String("order_id", req.OrderID),
+		zap.Int64("amount", req.Amount),
+		zap.String("type", string(req.Type)))
+
+	body := map[string]interface{}{
+		"amount":                 req.Amount,
+		"orderId":                req.OrderID,
+		"orderName":              req.OrderName,
+		"customerIdentityNumber": req.CustomerIdentityNumber,
+		"type":                   string(req.Type),
+	}
+	if req.TaxFreeAmount > 0 {
+		body["taxFreeAmount"] = req.TaxFreeAmount
+	}
+
+	url := fmt.Sprintf("%s/%s/cash-receipts", t.baseURL, tossAPIVersion)
+	return t.doCashReceiptRequest(ctx, url, body)
+}
+
+// CancelCashReceipt cancels a previously issued cash receipt
+// POST /v1/cash-receipts/{receiptKey}/cancel
+func (t *TossProvider) CancelCashReceipt(ctx context.Context, req *provider.CancelCashReceiptRequest) (*provider.CashReceiptResponse, error) {
+	t.logger.Info("TossProvider: Cancelling cash receipt",
+		zap.String("receipt_key", req.ReceiptKey),
+		zap.Int64("amount", req.Amount))
+
+	body := map[string]interface{}{}
+	if req.Amount > 0 {
+		body["amount"] = req.Amount
+	}
+
+	url := fmt.Sprintf("%s/%s/cash-receipts/%s/cancel", t.baseURL, tossAPIVersion, req.ReceiptKey)
+	return t.doCashReceiptRequest(ctx, url, body)
+}
+
+// doCashReceiptRequest sends a cash receipt API request and parses the receipt response
+func (t *TossProvider) doCashReceiptRequest(ctx context.Context, url string, body map[string]interface{}) (*provider.CashReceiptResponse, error) {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, &provider.ProviderError{
+			Code:    "MARSHAL_ERROR",
+			Message: "Failed to prepare request",
+			Details: err.Error(),
+		}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, &provider.ProviderError{
+			Code:    "REQUEST_ERROR",
+			Message: "Failed to create request",
+			Details: err.Error(),
+		}
+	}
+
+	auth := base64.StdEncoding.EncodeToString([]byte(t.secretKey + ":"))
+	httpReq.Header.Set("Authorization", "Basic "+auth)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		t.logger.Error("TossProvider: Cash receipt request failed", zap.Error(err))
+		return nil, &provider.ProviderError{
+			Code:    "API_ERROR",
+			Message: "TossPayments API request failed",
+			Details: err.Error(),
+		}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &provider.ProviderError{
+			Code:    "RESPONSE_ERROR",
+			Message: "Failed to read response",
+			Details: err.Error(),
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp map[string]interface{}
+		json.Unmarshal(respBody, &errResp)
+
+		t.logger.Error("TossProvider: Cash receipt request rejected",
+			zap.Int("status_code", resp.StatusCode),
+			zap.String("response", string(respBody)))
+
+		code, _ := errResp["code"].(string)
+		message, _ := errResp["message"].(string)
+
+		return nil, &provider.ProviderError{
+			Code:    code,
+			Message: message,
+			Details: string(respBody),
+		}
+	}
+
+	var tossResp map[string]interface{}
+	if err := json.Unmarshal(respBody, &tossResp); err != nil {
+		return nil, &provider.ProviderError{
+			Code:    "PARSE_ERROR",
+			Message: "Failed to parse response",
+			Details: err.Error(),
+		}
+	}
+
+	result := &provider.CashReceiptResponse{
+		ReceiptKey:      getStringFromMap(tossResp, "receiptKey"),
+		IssueNumber:     getStringFromMap(tossResp, "issueNumber"),
+		IssueStatus:     getStringFromMap(tossResp, "issueStatus"),
+		TransactionType: getStringFromMap(tossResp, "transactionType"),
+		OrderID:         getStringFromMap(tossResp, "orderId"),
+		ReceiptURL:      getStringFromMap(tossResp, "receiptUrl"),
+	}
+
+	if amount, ok := tossResp["amount"].(float64); ok {
+		result.Amount = int64(amount)
+	}
+
+	if requestedAt := getStringFromMap(tossResp, "requestedAt"); requestedAt != "" {
+		if parsed, err := time.Parse(time.RFC3339, requestedAt); err == nil {
+			result.RequestedAt = &parsed
+		}
+	}
+
+	t.logger.Info("TossProvider: Cash receipt request successful",
+		zap.String("receipt_key", result.ReceiptKey),
+		zap.String("transaction_type", result.TransactionType),
+		zap.String("issue_status", result.IssueStatus))
+
+	return result, nil
+}
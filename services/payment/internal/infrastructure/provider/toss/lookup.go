@@ -0,0 +1,108 @@
+package toss
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
+	"go.uber.org/zap"
+)
+
+// LookupPayment retrieves the payment of an order as TossPayments recorded it
+// GET /v1/payments/orders/{orderId}
+func (t *TossProvider) LookupPayment(ctx context.Context, orderID string) (*provider.PaymentRecord, error) {
+	endpoint := fmt.Sprintf("%s/%s/payments/orders/%s", t.baseURL, tossAPIVersion, url.PathEscape(orderID))
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, &provider.ProviderError{
+			Code:    "REQUEST_ERROR",
+			Message: "Failed to create lookup request",
+			Details: err.Error(),
+		}
+	}
+
+	auth := base64.StdEncoding.EncodeToString([]byte(t.secretKey + ":"))
+	httpReq.Header.Set("Authorization", "Basic "+auth)
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		t.logger.Error("TossProvider: Payment lookup request failed",
+			zap.String("order_id", orderID),
+			zap.Error(err))
+		return nil, &provider.ProviderError{
+			Code:    "API_ERROR",
+			Message: "TossPayments API request failed",
+			Details: err.Error(),
+		}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &provider.ProviderError{
+			Code:    "RESPONSE_ERROR",
+			Message: "Failed to read API response",
+			Details: err.Error(),
+		}
+	}
+
+	// Orders the customer never paid for are unknown to TossPayments
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errorResp map[string]interface{}
+		json.Unmarshal(respBody, &errorResp)
+
+		t.logger.Error("TossProvider: Payment lookup failed",
+			zap.String("order_id", orderID),
+			zap.Int("status_code", resp.StatusCode),
+			zap.String("response", string(respBody)))
+
+		errorCode, _ := errorResp["code"].(string)
+		errorMessage, _ := errorResp["message"].(string)
+
+		return nil, &provider.ProviderError{
+			Code:    errorCode,
+			Message: errorMessage,
+			Details: string(respBody),
+		}
+	}
+
+	var tossResp map[string]interface{}
+	if err := json.Unmarshal(respBody, &tossResp); err != nil {
+		return nil, &provider.ProviderError{
+			Code:    "PARSE_ERROR",
+			Message: "Failed to parse lookup response",
+			Details: err.Error(),
+		}
+	}
+
+	tossStatus := getStringFromMap(tossResp, "status")
+	record := &provider.PaymentRecord{
+		OrderID:        orderID,
+		PaymentKey:     getStringFromMap(tossResp, "paymentKey"),
+		TransactionKey: getStringFromMap(tossResp, "transactionKey"),
+		Status:         mapTossStatus(tossStatus),
+		ProviderStatus: tossStatus,
+		Currency:       getStringFromMap(tossResp, "currency"),
+		ProviderData:   tossResp,
+	}
+	if amount, ok := tossResp["totalAmount"].(float64); ok {
+		record.Amount = int64(amount)
+	}
+	if approvedAt := getStringFromMap(tossResp, "approvedAt"); approvedAt != "" {
+		if paidAt, err := time.Parse(time.RFC3339, approvedAt); err == nil {
+			record.PaidAt = &paidAt
+		}
+	}
+
+	return record, nil
+}
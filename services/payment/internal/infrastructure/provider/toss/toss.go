@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
@@ -23,6 +24,7 @@ const (
 type TossProvider struct {
 	secretKey string
 	clientKey string
+	baseURL   string
 	logger    *zap.Logger
 	client    *http.Client
 }
@@ -32,6 +34,7 @@ func NewTossProvider(secretKey, clientKey string, logger *zap.Logger) *TossProvi
 	return &TossProvider{
 		secretKey: secretKey,
 		clientKey: clientKey,
+		baseURL:   tossAPIBaseURL,
 		logger:    logger,
 		client: &http.Client{
 			Timeout: 30 * time.Second,
@@ -39,6 +42,12 @@ func NewTossProvider(secretKey, clientKey string, logger *zap.Logger) *TossProvi
 	}
 }
 
+// WithBaseURL points the provider at another API host, such as a local fake of TossPayments
+func (t *TossProvider) WithBaseURL(baseURL string) *TossProvider {
+	t.baseURL = strings.TrimSuffix(baseURL, "/")
+	return t
+}
+
 // GetProviderName returns the provider name
 func (t *TossProvider) GetProviderName() string {
 	return string(provider.ProviderTypeToss)
@@ -124,7 +133,7 @@ func (t *TossProvider) ConfirmPayment(ctx context.Context, req *provider.Confirm
 	}
 
 	// Create HTTP request
-	url := fmt.Sprintf("%s/%s/payments/confirm", tossAPIBaseURL, tossAPIVersion)
+	url := fmt.Sprintf("%s/%s/payments/confirm", t.baseURL, tossAPIVersion)
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
 	if err != nil {
 		t.logger.Error("TossProvider: Failed to create HTTP request",
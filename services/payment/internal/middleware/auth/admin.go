@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// AdminKeyHeader carries the admin API key on internal admin requests
+const AdminKeyHeader = "X-Admin-Key"
+
+// AdminKeyMiddleware restricts routes to callers presenting the configured admin API key.
+// When no key is configured every request is rejected.
+func AdminKeyMiddleware(apiKey string, logger *zap.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			provided := c.Request().Header.Get(AdminKeyHeader)
+			if apiKey == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(apiKey)) != 1 {
+				logger.Warn("Rejected admin request",
+					zap.String("path", c.Request().URL.Path),
+					zap.String("remote_ip", c.RealIP()),
+					zap.Bool("key_configured", apiKey != ""))
+				return c.JSON(http.StatusUnauthorized, echo.Map{
+					"error": "Admin authentication required",
+					"code":  "ADMIN_AUTH_REQUIRED",
+				})
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Workspace member roles allowed to manage a workspace's billing
+const (
+	WorkspaceRoleOwner = "owner"
+	WorkspaceRoleAdmin = "admin"
+)
+
+// WorkspaceRoleService resolves a user's role in a workspace
+type WorkspaceRoleService interface {
+	GetUserWorkspaceRole(ctx context.Context, userID, workspaceID string) (string, error)
+}
+
+// HasWorkspaceRole reports whether the authenticated user holds one of roles in the workspace
+// named by the X-Workspace-Id header. Requests without a workspace never match.
+func HasWorkspaceRole(c echo.Context, service WorkspaceRoleService, roles ...string) (bool, error) {
+	workspaceID, _ := GetWorkspaceID(c)
+	userID, err := GetUserID(c)
+	if workspaceID == "" || err != nil || service == nil {
+		return false, nil
+	}
+
+	role, err := service.GetUserWorkspaceRole(c.Request().Context(), userID, workspaceID)
+	if err != nil {
+		return false, err
+	}
+	for _, allowed := range roles {
+		if strings.EqualFold(role, allowed) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RequireWorkspaceRole creates a middleware that admits only users holding one of roles in the
+// X-Workspace-Id workspace
+func RequireWorkspaceRole(service WorkspaceRoleService, logger *zap.Logger, roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			allowed, err := HasWorkspaceRole(c, service, roles...)
+			if err != nil {
+				logger.Warn("Failed to resolve workspace role",
+					zap.String("path", c.Path()),
+					zap.Error(err))
+			}
+			if !allowed {
+				return WorkspaceRoleRequired(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+// WorkspaceRoleRequired responds that the request needs a workspace owner or admin
+func WorkspaceRoleRequired(c echo.Context) error {
+	return c.JSON(http.StatusForbidden, echo.Map{
+		"error": "Workspace owner or admin role required",
+		"code":  "WORKSPACE_ROLE_REQUIRED",
+	})
+}
@@ -0,0 +1,59 @@
+package geo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/labstack/echo/v4"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// Locator resolves where an IP address is
+type Locator interface {
+	Locate(ctx context.Context, ip string) (*model.GeoLocation, error)
+}
+
+type contextKey string
+
+const locationContextKey contextKey = "geo_location"
+
+// LocationMiddleware resolves the country, ASN and anonymity of the caller's IP (c.RealIP()) and
+// stores it on the request. Requests are never rejected: when the geo service cannot be reached an
+// unresolved location is stored and handlers fall back to their defaults.
+func LocationMiddleware(locator Locator, logger *zap.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ip := c.RealIP()
+			location, err := locator.Locate(c.Request().Context(), ip)
+			if err != nil {
+				if !errors.Is(err, domainErrors.ErrGeoServiceUnavailable) {
+					logger.Debug("Failed to resolve caller location",
+						zap.String("ip", ip),
+						zap.Error(err))
+				}
+				location = &model.GeoLocation{IP: ip}
+			}
+
+			c.SetRequest(c.Request().WithContext(NewContext(c.Request().Context(), location)))
+			return next(c)
+		}
+	}
+}
+
+// NewContext returns a context carrying the caller's location
+func NewContext(ctx context.Context, location *model.GeoLocation) context.Context {
+	return context.WithValue(ctx, locationContextKey, location)
+}
+
+// FromContext returns the caller's location, or nil outside the middleware
+func FromContext(ctx context.Context) *model.GeoLocation {
+	location, _ := ctx.Value(locationContextKey).(*model.GeoLocation)
+	return location
+}
+
+// GetLocation returns the location of the request's caller, or nil outside the middleware
+func GetLocation(c echo.Context) *model.GeoLocation {
+	return FromContext(c.Request().Context())
+}
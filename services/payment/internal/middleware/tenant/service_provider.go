@@ -0,0 +1,107 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// ServiceProviderHeader names the service provider (product) a request is made for
+const ServiceProviderHeader = "X-Service-Provider"
+
+// ServiceProviderQueryParam names the service provider on requests that cannot set headers, e.g. redirects
+const ServiceProviderQueryParam = "service_provider"
+
+// Resolver looks up active service providers; a blank code resolves the default provider
+type Resolver interface {
+	Resolve(ctx context.Context, code string) (*model.ServiceProvider, error)
+}
+
+type contextKey string
+
+const (
+	providerContextKey contextKey = "service_provider"
+	resolverKey                   = "service_provider_resolver"
+	explicitKey                   = "service_provider_explicit"
+)
+
+// ServiceProviderMiddleware resolves the service provider named by the X-Service-Provider header or
+// the service_provider query parameter, falling back to the default provider, and stores it on the request
+func ServiceProviderMiddleware(resolver Resolver, logger *zap.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			code := strings.TrimSpace(c.Request().Header.Get(ServiceProviderHeader))
+			if code == "" {
+				code = strings.TrimSpace(c.QueryParam(ServiceProviderQueryParam))
+			}
+
+			provider, err := resolver.Resolve(c.Request().Context(), code)
+			if err != nil {
+				if errors.Is(err, domainErrors.ErrServiceProviderNotFound) {
+					logger.Warn("Rejected request for unknown service provider",
+						zap.String("service_provider", code),
+						zap.String("path", c.Request().URL.Path))
+					return c.JSON(http.StatusBadRequest, echo.Map{
+						"error": "Unknown service provider",
+						"code":  "UNKNOWN_SERVICE_PROVIDER",
+					})
+				}
+				logger.Error("Failed to resolve service provider",
+					zap.String("service_provider", code),
+					zap.Error(err))
+				return c.JSON(http.StatusInternalServerError, echo.Map{
+					"error": "Failed to resolve service provider",
+					"code":  "SERVICE_PROVIDER_RESOLUTION_FAILED",
+				})
+			}
+
+			ctx := context.WithValue(c.Request().Context(), providerContextKey, provider)
+			c.SetRequest(c.Request().WithContext(ctx))
+			c.Set(resolverKey, resolver)
+			c.Set(explicitKey, code != "")
+
+			return next(c)
+		}
+	}
+}
+
+// FromContext returns the service provider resolved for a request, or nil outside the middleware
+func FromContext(ctx context.Context) *model.ServiceProvider {
+	provider, _ := ctx.Value(providerContextKey).(*model.ServiceProvider)
+	return provider
+}
+
+// GetServiceProvider returns the service provider resolved for the request, or nil outside the middleware
+func GetServiceProvider(c echo.Context) *model.ServiceProvider {
+	return FromContext(c.Request().Context())
+}
+
+// ResolveRequested returns the service provider named in a request body, or the request's provider
+// when code is blank. A body naming another provider than an explicit header or query parameter
+// fails with ErrServiceProviderMismatch.
+func ResolveRequested(c echo.Context, code string) (*model.ServiceProvider, error) {
+	current := GetServiceProvider(c)
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" || (current != nil && code == current.Code) {
+		if current == nil {
+			return nil, domainErrors.ErrServiceProviderNotFound
+		}
+		return current, nil
+	}
+
+	if explicit, _ := c.Get(explicitKey).(bool); explicit {
+		return nil, domainErrors.ErrServiceProviderMismatch
+	}
+
+	resolver, ok := c.Get(resolverKey).(Resolver)
+	if !ok {
+		return nil, domainErrors.ErrServiceProviderNotFound
+	}
+	return resolver.Resolve(c.Request().Context(), code)
+}
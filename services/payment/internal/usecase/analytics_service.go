@@ -0,0 +1,379 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+)
+
+// MaxCohortMonths bounds the number of cohorts in a cohort report
+const MaxCohortMonths = 24
+
+// RevenueReport is the recurring revenue at the end of a month (KST) and how it changed during the
+// month. Amounts are monthly, in the smallest unit of their currency.
+type RevenueReport struct {
+	Month                time.Time         `json:"month"`
+	SnapshotDate         time.Time         `json:"snapshot_date"`                    // Snapshot the figures come from
+	PreviousSnapshotDate *time.Time        `json:"previous_snapshot_date,omitempty"` // Baseline of the movements
+	Currencies           []CurrencyRevenue `json:"currencies"`
+	Plans                []PlanRevenue     `json:"plans"`
+}
+
+// CurrencyRevenue is the recurring revenue in one currency. Revenue in different currencies is not converted.
+type CurrencyRevenue struct {
+	Currency    string        `json:"currency"`
+	MRR         int64         `json:"mrr"`
+	ARR         int64         `json:"arr"`
+	Subscribers int           `json:"subscribers"` // Paying customers
+	Movements   *MRRMovements `json:"movements,omitempty"`
+}
+
+// MRRMovements breaks the change of MRR during a period down per customer: customers who started
+// paying (new), pay more (expansion), pay less (contraction) or stopped paying (churned).
+type MRRMovements struct {
+	New                int64 `json:"new"`
+	Expansion          int64 `json:"expansion"`
+	Contraction        int64 `json:"contraction"` // Reported as a positive amount
+	Churned            int64 `json:"churned"`     // Reported as a positive amount
+	Net                int64 `json:"net"`
+	NewSubscribers     int   `json:"new_subscribers"`
+	ChurnedSubscribers int   `json:"churned_subscribers"`
+}
+
+// PlanRevenue counts the active subscriptions of a plan
+type PlanRevenue struct {
+	PlanID        string `json:"plan_id"`
+	Currency      string `json:"currency"`
+	Subscriptions int    `json:"subscriptions"`
+	Seats         int    `json:"seats"`
+	MRR           int64  `json:"mrr"`
+}
+
+// CohortReport is the monthly retention of customers grouped by the month of their first payment
+type CohortReport struct {
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"` // exclusive
+	Cohorts []Cohort  `json:"cohorts"`
+}
+
+// Cohort is the customers who first paid in a month (KST)
+type Cohort struct {
+	Month     time.Time         `json:"month"`
+	Customers int64             `json:"customers"`
+	Retention []CohortRetention `json:"retention"` // One entry per month since the cohort month, starting with 0
+}
+
+// CohortRetention is the share of a cohort who paid in the month MonthsSince months after the cohort month
+type CohortRetention struct {
+	MonthsSince int     `json:"months_since"`
+	Customers   int64   `json:"customers"`
+	Rate        float64 `json:"rate"`
+}
+
+// AnalyticsService computes revenue metrics from nightly snapshots of subscriptions and payments
+type AnalyticsService struct {
+	analyticsRepo repository.AnalyticsRepository
+	logger        *zap.Logger
+	now           func() time.Time
+}
+
+// NewAnalyticsService creates a new AnalyticsService instance
+func NewAnalyticsService(analyticsRepo repository.AnalyticsRepository, logger *zap.Logger) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+// TakeSnapshot records the MRR of every subscription active at the end of date (KST). An existing
+// snapshot of the date is kept unless replace is set, so that reports for past periods stay fixed.
+// It returns the number of rows written and whether a snapshot was taken.
+func (s *AnalyticsService) TakeSnapshot(ctx context.Context, date time.Time, replace bool) (int, bool, error) {
+	day := model.UsageGranularityDay.PeriodStart(date)
+	end := day.AddDate(0, 0, 1)
+	if end.After(s.now()) {
+		// The day is not over yet; its snapshot would miss the rest of the day
+		return 0, false, domainErrors.ErrInvalidAnalyticsRange
+	}
+
+	if !replace {
+		exists, err := s.analyticsRepo.HasSnapshot(ctx, day)
+		if err != nil {
+			return 0, false, err
+		}
+		if exists {
+			return 0, false, nil
+		}
+	}
+
+	subscriptions, err := s.analyticsRepo.ListSubscriptionsActiveAt(ctx, end)
+	if err != nil {
+		return 0, false, err
+	}
+
+	rows := make([]*model.MRRSnapshot, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		planID := ""
+		if sub.PlanID != nil {
+			planID = *sub.PlanID
+		}
+		rows = append(rows, &model.MRRSnapshot{
+			SnapshotDate:   day,
+			SubscriptionID: sub.ID,
+			UniversalID:    sub.UniversalID,
+			PlanID:         planID,
+			Currency:       sub.Currency,
+			MRR:            model.MonthlyRecurringAmount(sub.Amount, sub.Quantity, sub.Interval, sub.IntervalCount),
+			Quantity:       sub.Quantity,
+		})
+	}
+
+	if err := s.analyticsRepo.ReplaceSnapshot(ctx, day, rows); err != nil {
+		return 0, false, err
+	}
+
+	s.logger.Info("Revenue snapshot taken",
+		zap.String("date", day.Format("2006-01-02")),
+		zap.Int("subscriptions", len(rows)))
+
+	return len(rows), true, nil
+}
+
+// GetRevenueReport reports the recurring revenue at the end of the month containing month, or at
+// the latest snapshot for the current month, with movements against the end of the previous month
+func (s *AnalyticsService) GetRevenueReport(ctx context.Context, month time.Time) (*RevenueReport, error) {
+	start := model.UsageGranularityMonth.PeriodStart(month)
+	if start.After(s.now()) {
+		return nil, domainErrors.ErrInvalidAnalyticsRange
+	}
+	lastDay := start.AddDate(0, 1, -1)
+
+	snapshotDate, err := s.analyticsRepo.LatestSnapshotDate(ctx, lastDay)
+	if err != nil {
+		return nil, err
+	}
+	if snapshotDate == nil || snapshotDate.Before(start) {
+		return nil, domainErrors.ErrRevenueSnapshotNotFound
+	}
+
+	current, err := s.analyticsRepo.ListSnapshot(ctx, *snapshotDate)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RevenueReport{
+		Month:        start,
+		SnapshotDate: *snapshotDate,
+		Currencies:   currencyRevenue(current),
+		Plans:        planRevenue(current),
+	}
+
+	previousDate, err := s.analyticsRepo.LatestSnapshotDate(ctx, start.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+	if previousDate != nil {
+		previous, err := s.analyticsRepo.ListSnapshot(ctx, *previousDate)
+		if err != nil {
+			return nil, err
+		}
+		report.PreviousSnapshotDate = previousDate
+		movements := mrrMovements(previous, current)
+		for i := range report.Currencies {
+			currency := report.Currencies[i].Currency
+			report.Currencies[i].Movements = &MRRMovements{}
+			if m, ok := movements[currency]; ok {
+				report.Currencies[i].Movements = m
+				delete(movements, currency)
+			}
+		}
+		// Currencies without revenue left this month still report their churn
+		for currency, m := range movements {
+			report.Currencies = append(report.Currencies, CurrencyRevenue{Currency: currency, Movements: m})
+		}
+		sort.Slice(report.Currencies, func(i, j int) bool { return report.Currencies[i].Currency < report.Currencies[j].Currency })
+	}
+
+	return report, nil
+}
+
+// GetCohortReport reports the retention of the cohorts from the month containing from through the
+// month containing to
+func (s *AnalyticsService) GetCohortReport(ctx context.Context, from, to time.Time) (*CohortReport, error) {
+	start := model.UsageGranularityMonth.PeriodStart(from)
+	end := model.UsageGranularityMonth.PeriodStart(to).AddDate(0, 1, 0)
+	if !end.After(start) || end.After(start.AddDate(0, MaxCohortMonths, 0)) {
+		return nil, domainErrors.ErrInvalidAnalyticsRange
+	}
+
+	activity, err := s.analyticsRepo.ListCohortActivity(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CohortReport{From: start, To: end, Cohorts: []Cohort{}}
+	current := model.UsageGranularityMonth.PeriodStart(s.now())
+	for month := start; month.Before(end) && !month.After(current); month = month.AddDate(0, 1, 0) {
+		cohort := Cohort{Month: month}
+		for since := 0; !month.AddDate(0, since, 0).After(current); since++ {
+			cohort.Retention = append(cohort.Retention, CohortRetention{MonthsSince: since})
+		}
+		report.Cohorts = append(report.Cohorts, cohort)
+	}
+
+	for _, row := range activity {
+		index := monthsBetween(start, row.Cohort)
+		if index < 0 || index >= len(report.Cohorts) {
+			continue
+		}
+		cohort := &report.Cohorts[index]
+		since := monthsBetween(row.Cohort, row.Month)
+		if since < 0 || since >= len(cohort.Retention) {
+			continue
+		}
+		cohort.Retention[since].Customers = row.Customers
+		if since == 0 {
+			cohort.Customers = row.Customers
+		}
+	}
+
+	for i := range report.Cohorts {
+		cohort := &report.Cohorts[i]
+		if cohort.Customers == 0 {
+			continue
+		}
+		for j := range cohort.Retention {
+			rate := float64(cohort.Retention[j].Customers) / float64(cohort.Customers)
+			cohort.Retention[j].Rate = math.Round(rate*10000) / 10000
+		}
+	}
+
+	return report, nil
+}
+
+// customerCurrency identifies a customer's revenue in one currency
+type customerCurrency struct {
+	universalID uuid.UUID
+	currency    string
+}
+
+// customerMRR sums the MRR of each customer's subscriptions per currency
+func customerMRR(rows []*model.MRRSnapshot) map[customerCurrency]int64 {
+	totals := make(map[customerCurrency]int64)
+	for _, row := range rows {
+		totals[customerCurrency{row.UniversalID, row.Currency}] += row.MRR
+	}
+	return totals
+}
+
+func currencyRevenue(rows []*model.MRRSnapshot) []CurrencyRevenue {
+	byCurrency := make(map[string]*CurrencyRevenue)
+	for key, mrr := range customerMRR(rows) {
+		revenue, ok := byCurrency[key.currency]
+		if !ok {
+			revenue = &CurrencyRevenue{Currency: key.currency}
+			byCurrency[key.currency] = revenue
+		}
+		revenue.MRR += mrr
+		if mrr > 0 {
+			revenue.Subscribers++
+		}
+	}
+
+	currencies := make([]CurrencyRevenue, 0, len(byCurrency))
+	for _, revenue := range byCurrency {
+		revenue.ARR = revenue.MRR * 12
+		currencies = append(currencies, *revenue)
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i].Currency < currencies[j].Currency })
+	return currencies
+}
+
+func planRevenue(rows []*model.MRRSnapshot) []PlanRevenue {
+	type planKey struct{ planID, currency string }
+	byPlan := make(map[planKey]*PlanRevenue)
+	for _, row := range rows {
+		key := planKey{row.PlanID, row.Currency}
+		plan, ok := byPlan[key]
+		if !ok {
+			plan = &PlanRevenue{PlanID: row.PlanID, Currency: row.Currency}
+			byPlan[key] = plan
+		}
+		plan.Subscriptions++
+		plan.Seats += row.Quantity
+		plan.MRR += row.MRR
+	}
+
+	plans := make([]PlanRevenue, 0, len(byPlan))
+	for _, plan := range byPlan {
+		plans = append(plans, *plan)
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		if plans[i].PlanID != plans[j].PlanID {
+			return plans[i].PlanID < plans[j].PlanID
+		}
+		return plans[i].Currency < plans[j].Currency
+	})
+	return plans
+}
+
+// mrrMovements compares the MRR of each customer between two snapshots, per currency
+func mrrMovements(previous, current []*model.MRRSnapshot) map[string]*MRRMovements {
+	before := customerMRR(previous)
+	after := customerMRR(current)
+
+	movements := make(map[string]*MRRMovements)
+	get := func(currency string) *MRRMovements {
+		m, ok := movements[currency]
+		if !ok {
+			m = &MRRMovements{}
+			movements[currency] = m
+		}
+		return m
+	}
+
+	for key, now := range after {
+		was := before[key]
+		m := get(key.currency)
+		switch {
+		case was == 0 && now > 0:
+			m.New += now
+			m.NewSubscribers++
+		case was > 0 && now == 0:
+			m.Churned += was
+			m.ChurnedSubscribers++
+		case now > was:
+			m.Expansion += now - was
+		case now < was:
+			m.Contraction += was - now
+		}
+	}
+	for key, was := range before {
+		if _, ok := after[key]; ok || was == 0 {
+			continue
+		}
+		m := get(key.currency)
+		m.Churned += was
+		m.ChurnedSubscribers++
+	}
+
+	for _, m := range movements {
+		m.Net = m.New + m.Expansion - m.Contraction - m.Churned
+	}
+	return movements
+}
+
+// monthsBetween returns the number of whole months from the month of a to the month of b
+func monthsBetween(a, b time.Time) int {
+	a, b = a.In(model.UsageLocation), b.In(model.UsageLocation)
+	return (b.Year()-a.Year())*12 + int(b.Month()) - int(a.Month())
+}
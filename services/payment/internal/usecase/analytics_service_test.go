@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// MockAnalyticsRepository is a mock implementation of AnalyticsRepository
+type MockAnalyticsRepository struct {
+	mock.Mock
+}
+
+func (m *MockAnalyticsRepository) ListSubscriptionsActiveAt(ctx context.Context, at time.Time) ([]*model.Subscription, error) {
+	args := m.Called(ctx, at)
+	return args.Get(0).([]*model.Subscription), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) HasSnapshot(ctx context.Context, date time.Time) (bool, error) {
+	args := m.Called(ctx, date)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) ReplaceSnapshot(ctx context.Context, date time.Time, rows []*model.MRRSnapshot) error {
+	args := m.Called(ctx, date, rows)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsRepository) LatestSnapshotDate(ctx context.Context, onOrBefore time.Time) (*time.Time, error) {
+	args := m.Called(ctx, onOrBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) ListSnapshot(ctx context.Context, date time.Time) ([]*model.MRRSnapshot, error) {
+	args := m.Called(ctx, date)
+	return args.Get(0).([]*model.MRRSnapshot), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) ListCohortActivity(ctx context.Context, from, to time.Time) ([]dto.CohortActivity, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]dto.CohortActivity), args.Error(1)
+}
+
+func kstDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, model.UsageLocation)
+}
+
+func TestMonthlyRecurringAmount(t *testing.T) {
+	assert.Equal(t, int64(9900), model.MonthlyRecurringAmount(9900, 1, "month", 1))
+	assert.Equal(t, int64(29700), model.MonthlyRecurringAmount(9900, 3, "month", 1))
+	assert.Equal(t, int64(8250), model.MonthlyRecurringAmount(99000, 1, "year", 1))
+	assert.Equal(t, int64(4950), model.MonthlyRecurringAmount(9900, 0, "month", 2))
+	assert.Equal(t, int64(4333), model.MonthlyRecurringAmount(1000, 1, "week", 1))
+}
+
+func TestAnalyticsService_TakeSnapshot(t *testing.T) {
+	ctx := context.Background()
+	planID := "prod_pro"
+	subscriptions := []*model.Subscription{
+		{ID: 1, UniversalID: uuid.New(), PlanID: &planID, Currency: "KRW", Amount: 99000, Interval: "year", IntervalCount: 1, Quantity: 1},
+	}
+
+	t.Run("existing snapshot is kept", func(t *testing.T) {
+		repo := new(MockAnalyticsRepository)
+		service := NewAnalyticsService(repo, zap.NewNop())
+		service.now = func() time.Time { return kstDate(2025, 4, 1).Add(time.Hour) }
+		repo.On("HasSnapshot", ctx, kstDate(2025, 3, 31)).Return(true, nil)
+
+		rows, taken, err := service.TakeSnapshot(ctx, kstDate(2025, 3, 31), false)
+
+		assert.NoError(t, err)
+		assert.False(t, taken)
+		assert.Zero(t, rows)
+		repo.AssertNotCalled(t, "ReplaceSnapshot", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("subscriptions active at the end of the day are recorded", func(t *testing.T) {
+		repo := new(MockAnalyticsRepository)
+		service := NewAnalyticsService(repo, zap.NewNop())
+		service.now = func() time.Time { return kstDate(2025, 4, 1).Add(time.Hour) }
+		repo.On("HasSnapshot", ctx, kstDate(2025, 3, 31)).Return(false, nil)
+		repo.On("ListSubscriptionsActiveAt", ctx, kstDate(2025, 4, 1)).Return(subscriptions, nil)
+		repo.On("ReplaceSnapshot", ctx, kstDate(2025, 3, 31), mock.MatchedBy(func(rows []*model.MRRSnapshot) bool {
+			return len(rows) == 1 && rows[0].MRR == 8250 && rows[0].PlanID == planID
+		})).Return(nil)
+
+		rows, taken, err := service.TakeSnapshot(ctx, kstDate(2025, 3, 31), false)
+
+		assert.NoError(t, err)
+		assert.True(t, taken)
+		assert.Equal(t, 1, rows)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unfinished day is rejected", func(t *testing.T) {
+		service := NewAnalyticsService(new(MockAnalyticsRepository), zap.NewNop())
+		service.now = func() time.Time { return kstDate(2025, 3, 31).Add(20 * time.Hour) }
+
+		_, _, err := service.TakeSnapshot(ctx, kstDate(2025, 3, 31), true)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidAnalyticsRange)
+	})
+}
+
+func TestAnalyticsService_GetRevenueReport(t *testing.T) {
+	ctx := context.Background()
+	churned, kept, expanded, contracted, added := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	february := []*model.MRRSnapshot{
+		{SubscriptionID: 1, UniversalID: churned, PlanID: "basic", Currency: "KRW", MRR: 9900, Quantity: 1},
+		{SubscriptionID: 2, UniversalID: kept, PlanID: "basic", Currency: "KRW", MRR: 9900, Quantity: 1},
+		{SubscriptionID: 3, UniversalID: expanded, PlanID: "basic", Currency: "KRW", MRR: 9900, Quantity: 1},
+		{SubscriptionID: 4, UniversalID: contracted, PlanID: "team", Currency: "USD", MRR: 3000, Quantity: 3},
+	}
+	march := []*model.MRRSnapshot{
+		{SubscriptionID: 2, UniversalID: kept, PlanID: "basic", Currency: "KRW", MRR: 9900, Quantity: 1},
+		{SubscriptionID: 5, UniversalID: expanded, PlanID: "pro", Currency: "KRW", MRR: 19900, Quantity: 1},
+		{SubscriptionID: 4, UniversalID: contracted, PlanID: "team", Currency: "USD", MRR: 2000, Quantity: 2},
+		{SubscriptionID: 6, UniversalID: added, PlanID: "basic", Currency: "KRW", MRR: 9900, Quantity: 1},
+	}
+	marchEnd, februaryEnd := kstDate(2025, 3, 31), kstDate(2025, 2, 28)
+
+	repo := new(MockAnalyticsRepository)
+	repo.On("LatestSnapshotDate", ctx, marchEnd).Return(&marchEnd, nil)
+	repo.On("LatestSnapshotDate", ctx, februaryEnd).Return(&februaryEnd, nil)
+	repo.On("ListSnapshot", ctx, marchEnd).Return(march, nil)
+	repo.On("ListSnapshot", ctx, februaryEnd).Return(february, nil)
+	service := NewAnalyticsService(repo, zap.NewNop())
+	service.now = func() time.Time { return kstDate(2025, 4, 2) }
+
+	report, err := service.GetRevenueReport(ctx, kstDate(2025, 3, 15))
+
+	require.NoError(t, err)
+	assert.Equal(t, marchEnd, report.SnapshotDate)
+	require.Len(t, report.Currencies, 2)
+
+	krw := report.Currencies[0]
+	assert.Equal(t, "KRW", krw.Currency)
+	assert.Equal(t, int64(39700), krw.MRR)
+	assert.Equal(t, int64(476400), krw.ARR)
+	assert.Equal(t, 3, krw.Subscribers)
+	assert.Equal(t, &MRRMovements{New: 9900, Expansion: 10000, Churned: 9900, Net: 10000, NewSubscribers: 1, ChurnedSubscribers: 1}, krw.Movements)
+
+	usd := report.Currencies[1]
+	assert.Equal(t, &MRRMovements{Contraction: 1000, Net: -1000}, usd.Movements)
+
+	assert.Equal(t, []PlanRevenue{
+		{PlanID: "basic", Currency: "KRW", Subscriptions: 2, Seats: 2, MRR: 19800},
+		{PlanID: "pro", Currency: "KRW", Subscriptions: 1, Seats: 1, MRR: 19900},
+		{PlanID: "team", Currency: "USD", Subscriptions: 1, Seats: 2, MRR: 2000},
+	}, report.Plans)
+}
+
+func TestAnalyticsService_GetCohortReport(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAnalyticsRepository)
+	repo.On("ListCohortActivity", ctx, kstDate(2025, 1, 1), kstDate(2025, 3, 1)).Return([]dto.CohortActivity{
+		{Cohort: kstDate(2025, 1, 1), Month: kstDate(2025, 1, 1), Customers: 8},
+		{Cohort: kstDate(2025, 1, 1), Month: kstDate(2025, 2, 1), Customers: 6},
+		{Cohort: kstDate(2025, 1, 1), Month: kstDate(2025, 3, 1), Customers: 5},
+		{Cohort: kstDate(2025, 2, 1), Month: kstDate(2025, 2, 1), Customers: 4},
+	}, nil)
+	service := NewAnalyticsService(repo, zap.NewNop())
+	service.now = func() time.Time { return kstDate(2025, 3, 10) }
+
+	report, err := service.GetCohortReport(ctx, kstDate(2025, 1, 20), kstDate(2025, 2, 5))
+
+	require.NoError(t, err)
+	require.Len(t, report.Cohorts, 2)
+	assert.Equal(t, int64(8), report.Cohorts[0].Customers)
+	assert.Equal(t, []CohortRetention{
+		{MonthsSince: 0, Customers: 8, Rate: 1},
+		{MonthsSince: 1, Customers: 6, Rate: 0.75},
+		{MonthsSince: 2, Customers: 5, Rate: 0.625},
+	}, report.Cohorts[0].Retention)
+	assert.Equal(t, []CohortRetention{
+		{MonthsSince: 0, Customers: 4, Rate: 1},
+		{MonthsSince: 1, Customers: 0, Rate: 0},
+	}, report.Cohorts[1].Retention)
+}
@@ -2,11 +2,16 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	dbRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/adapter/repository"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
@@ -16,34 +21,46 @@ import (
 )
 
 type BillingService struct {
-	billingKeyRepo repository.BillingKeyRepository
-	paymentRepo    repository.PaymentRepository
-	tossProvider   *toss.TossProvider
-	encryptService crypto.EncryptionService
-	creditService  *CreditService
-	logger         *zap.Logger
+	billingKeyRepo   repository.BillingKeyRepository
+	paymentRepo      repository.PaymentRepository
+	planRepo         dbRepo.PlanRepository
+	serviceProviders ServiceProviderResolver
+	encryptService   crypto.EncryptionService
+	creditService    *CreditService
+	couponService    *CouponService
+	referralService  *ReferralService
+	logger           *zap.Logger
 }
 
 func NewBillingService(
 	billingKeyRepo repository.BillingKeyRepository,
 	paymentRepo repository.PaymentRepository,
-	tossProvider *toss.TossProvider,
+	planRepo dbRepo.PlanRepository,
+	serviceProviders ServiceProviderResolver,
 	encryptService crypto.EncryptionService,
 	creditService *CreditService,
+	couponService *CouponService,
+	referralService *ReferralService,
 	logger *zap.Logger,
 ) *BillingService {
 	return &BillingService{
-		billingKeyRepo: billingKeyRepo,
-		paymentRepo:    paymentRepo,
-		tossProvider:   tossProvider,
-		encryptService: encryptService,
-		creditService:  creditService,
-		logger:         logger,
+		billingKeyRepo:   billingKeyRepo,
+		paymentRepo:      paymentRepo,
+		planRepo:         planRepo,
+		serviceProviders: serviceProviders,
+		encryptService:   encryptService,
+		creditService:    creditService,
+		couponService:    couponService,
+		referralService:  referralService,
+		logger:           logger,
 	}
 }
 
+// IssueBillingKey registers a card with the service provider's Toss account. The billing key can
+// only be charged through that account.
 func (s *BillingService) IssueBillingKey(
 	ctx context.Context,
+	serviceProvider *model.ServiceProvider,
 	universalID uuid.UUID,
 	authKey string,
 	customerKey string,
@@ -55,7 +72,12 @@ func (s *BillingService) IssueBillingKey(
 		zap.String("customer_key", customerKey),
 		zap.String("auth_key_prefix", authKey[:min(len(authKey), 20)]+"..."))
 
-	resp, err := s.tossProvider.IssueBillingKey(ctx, &provider.IssueBillingKeyRequest{
+	tossClient, err := TossBillingClient(serviceProvider, s.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tossClient.IssueBillingKey(ctx, &provider.IssueBillingKeyRequest{
 		AuthKey:     authKey,
 		CustomerKey: customerKey,
 	})
@@ -82,11 +104,14 @@ func (s *BillingService) IssueBillingKey(
 	billingKey := &model.BillingKey{
 		UniversalID:         universalID,
 		CustomerKey:         customerKey,
+		ServiceProvider:     serviceProvider.Code,
 		EncryptedBillingKey: encryptedKey,
 		EncryptionIV:        iv,
 		CardLastFour:        cardLastFour,
 		CardCompany:         resp.CardCompany,
 		CardType:            resp.CardType,
+		CardFingerprint:     CardFingerprint(resp.CardCompany, resp.CardNumber),
+		CardCountry:         resp.CardCountry,
 		IsActive:            true,
 	}
 
@@ -171,18 +196,24 @@ type ChargeBillingKeyResult struct {
 	TransactionKey   string
 	Status           string
 	Amount           int64
+	DiscountAmount   int64
 	ApprovedAt       *time.Time
 	CreditsAllocated int
 }
 
+// ChargeBillingKey charges a registered card. A blank orderID charges under a new order; a fixed
+// orderID makes retries idempotent: an order already completed is returned without charging again,
+// and an unfinished one is checked at Toss before it is charged under the same order ID.
 func (s *BillingService) ChargeBillingKey(
 	ctx context.Context,
 	universalID uuid.UUID,
 	billingKeyID int64,
+	orderID string,
 	amount int64,
 	orderName string,
 	planID string,
 	serviceProvider string,
+	couponCode string,
 	ipAddress string,
 	userAgent string,
 ) (*ChargeBillingKeyResult, error) {
@@ -199,6 +230,14 @@ func (s *BillingService) ChargeBillingKey(
 	if !billingKey.IsActive {
 		return nil, fmt.Errorf("billing key is not active")
 	}
+	if serviceProvider != "" && billingKey.ServiceProvider != "" && serviceProvider != billingKey.ServiceProvider {
+		return nil, domainErrors.ErrBillingKeyServiceProviderMismatch
+	}
+
+	tossClient, err := s.billingClient(ctx, billingKey)
+	if err != nil {
+		return nil, err
+	}
 
 	decryptedBillingKey, err := s.encryptService.Decrypt(billingKey.EncryptedBillingKey, billingKey.EncryptionIV)
 	if err != nil {
@@ -208,15 +247,53 @@ func (s *BillingService) ChargeBillingKey(
 		return nil, fmt.Errorf("failed to decrypt billing key: %w", err)
 	}
 
-	orderID := s.generateOrderID()
+	var existing *entity.Payment
+	if orderID == "" {
+		orderID = s.generateOrderID()
+	} else {
+		existing, err = s.paymentRepo.GetByOrderID(ctx, orderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get payment: %w", err)
+		}
+		if existing != nil && existing.Status == entity.PaymentStatusCompleted {
+			s.logger.Info("billing charge already completed for order",
+				zap.String("order_id", orderID))
+			return &ChargeBillingKeyResult{
+				OrderID: orderID,
+				Status:  "DONE",
+				Amount:  int64(existing.Amount),
+			}, nil
+		}
+	}
+
+	currency, err := s.planCurrency(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	var quote *CouponQuote
+	if couponCode != "" && existing == nil {
+		if s.couponService == nil {
+			return nil, fmt.Errorf("coupon service not configured")
+		}
+		quote, err = s.couponService.Quote(ctx, couponCode, universalID, planID, amount, currency)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.couponService.Reserve(ctx, quote, universalID, orderID); err != nil {
+			return nil, err
+		}
+		amount = quote.FinalAmount
+	}
 
 	payment := &entity.Payment{
 		UniversalID:   universalID.String(),
 		TransactionID: orderID,
 		Amount:        float64(amount),
-		Currency:      "KRW",
+		Currency:      currency,
 		Status:        entity.PaymentStatusPending,
 		Method:        entity.PaymentMethodCard,
+		Provider:      string(provider.ProviderTypeToss),
 		Description:   orderName,
 		Metadata: map[string]interface{}{
 			"plan_id":          planID,
@@ -227,21 +304,39 @@ func (s *BillingService) ChargeBillingKey(
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	if quote != nil {
+		payment.Metadata["coupon_code"] = quote.Code
+		payment.Metadata["original_amount"] = quote.OriginalAmount
+		payment.Metadata["discount_amount"] = quote.DiscountAmount
+	}
 
-	if err := s.paymentRepo.CreateOneTimePayment(ctx, payment); err != nil {
-		s.logger.Error("failed to create payment record",
-			zap.String("order_id", orderID),
-			zap.Error(err))
-		return nil, fmt.Errorf("failed to create payment record: %w", err)
+	var chargeResp *provider.ChargeBillingKeyResponse
+	if existing == nil {
+		if err := s.paymentRepo.CreateOneTimePayment(ctx, payment); err != nil {
+			s.logger.Error("failed to create payment record",
+				zap.String("order_id", orderID),
+				zap.Error(err))
+			s.releaseCoupon(ctx, quote, orderID)
+			return nil, fmt.Errorf("failed to create payment record: %w", err)
+		}
+	} else {
+		// A previous attempt may have been charged before its result was recorded
+		chargeResp, err = s.chargedAtToss(ctx, tossClient, orderID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	chargeResp, err := s.tossProvider.ChargeBillingKey(ctx, &provider.ChargeBillingKeyRequest{
-		BillingKey:  decryptedBillingKey,
-		CustomerKey: billingKey.CustomerKey,
-		Amount:      amount,
-		OrderID:     orderID,
-		OrderName:   orderName,
-	})
+	if chargeResp == nil {
+		chargeResp, err = tossClient.ChargeBillingKey(ctx, &provider.ChargeBillingKeyRequest{
+			BillingKey:  decryptedBillingKey,
+			CustomerKey: billingKey.CustomerKey,
+			Amount:      amount,
+			Currency:    currency,
+			OrderID:     orderID,
+			OrderName:   orderName,
+		})
+	}
 	if err != nil {
 		s.paymentRepo.UpdatePaymentAfterConfirm(ctx, orderID, map[string]interface{}{
 			"status": string(entity.PaymentStatusFailed),
@@ -249,6 +344,7 @@ func (s *BillingService) ChargeBillingKey(
 		s.logger.Error("failed to charge billing key",
 			zap.Int64("billing_key_id", billingKeyID),
 			zap.Error(err))
+		s.releaseCoupon(ctx, quote, orderID)
 		return nil, fmt.Errorf("failed to charge billing key: %w", err)
 	}
 
@@ -290,6 +386,7 @@ func (s *BillingService) ChargeBillingKey(
 			orderID,
 			"",
 			planID,
+			1,
 			serviceProvider,
 		)
 		if err != nil {
@@ -305,7 +402,23 @@ func (s *BillingService) ChargeBillingKey(
 		}
 	}
 
-	return &ChargeBillingKeyResult{
+	if quote != nil && chargeResp.Status == "DONE" {
+		if err := s.couponService.Complete(ctx, orderID, serviceProvider); err != nil {
+			s.logger.Error("failed to complete coupon redemption after billing charge",
+				zap.String("order_id", orderID),
+				zap.Error(err))
+		}
+	}
+
+	if s.referralService != nil && chargeResp.Status == "DONE" {
+		if err := s.referralService.RewardFirstPayment(ctx, universalID.String(), serviceProvider, orderID); err != nil {
+			s.logger.Error("failed to reward referral after billing charge",
+				zap.String("order_id", orderID),
+				zap.Error(err))
+		}
+	}
+
+	result := &ChargeBillingKeyResult{
 		OrderID:          orderID,
 		PaymentKey:       chargeResp.PaymentKey,
 		TransactionKey:   chargeResp.TransactionKey,
@@ -313,9 +426,90 @@ func (s *BillingService) ChargeBillingKey(
 		Amount:           chargeResp.Amount,
 		ApprovedAt:       chargeResp.ApprovedAt,
 		CreditsAllocated: creditsAllocated,
+	}
+	if quote != nil {
+		result.DiscountAmount = quote.DiscountAmount
+	}
+
+	return result, nil
+}
+
+// billingClient returns the Toss client of the service provider a billing key was issued for.
+// Keys registered before billing keys recorded their provider belong to the default provider.
+func (s *BillingService) billingClient(ctx context.Context, billingKey *model.BillingKey) (*toss.TossProvider, error) {
+	serviceProvider, err := s.serviceProviders.Resolve(ctx, billingKey.ServiceProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve service provider of billing key: %w", err)
+	}
+	return TossBillingClient(serviceProvider, s.logger)
+}
+
+// chargedAtToss returns the charge of an order Toss has already completed, or nil if it has none
+func (s *BillingService) chargedAtToss(ctx context.Context, tossClient *toss.TossProvider, orderID string) (*provider.ChargeBillingKeyResponse, error) {
+	record, err := tossClient.LookupPayment(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up order at toss: %w", err)
+	}
+	if record == nil || record.Status != provider.PaymentStatusCompleted {
+		return nil, nil
+	}
+
+	s.logger.Info("billing charge found at toss for unfinished order",
+		zap.String("order_id", orderID),
+		zap.String("payment_key", record.PaymentKey))
+
+	return &provider.ChargeBillingKeyResponse{
+		PaymentKey:     record.PaymentKey,
+		OrderID:        orderID,
+		Status:         "DONE",
+		Amount:         record.Amount,
+		ApprovedAt:     record.PaidAt,
+		TransactionKey: record.TransactionKey,
 	}, nil
 }
 
+// planCurrency returns the currency of the plan price the charge is made for, KRW when it is not set
+func (s *BillingService) planCurrency(ctx context.Context, planID string) (string, error) {
+	if planID == "" || s.planRepo == nil {
+		return "KRW", nil
+	}
+
+	plan, err := s.planRepo.GetByPriceID(ctx, planID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get plan: %w", err)
+	}
+	if plan != nil {
+		for _, price := range plan.PricePoints() {
+			if price.ProviderPriceID == planID && price.Currency != "" {
+				return price.Currency, nil
+			}
+		}
+	}
+	return "KRW", nil
+}
+
+// releaseCoupon frees a coupon reserved for a charge that did not go through
+func (s *BillingService) releaseCoupon(ctx context.Context, quote *CouponQuote, orderID string) {
+	if quote == nil {
+		return
+	}
+	if err := s.couponService.Release(ctx, orderID); err != nil {
+		s.logger.Warn("failed to release coupon after billing charge failure",
+			zap.String("order_id", orderID),
+			zap.Error(err))
+	}
+}
+
+// CardFingerprint derives a stable identifier for a card from its issuer and masked number.
+// Toss does not expose a card fingerprint, so this is used to recognise the same card across accounts.
+func CardFingerprint(cardCompany string, maskedNumber string) string {
+	if maskedNumber == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.ToUpper(strings.TrimSpace(cardCompany)) + "|" + strings.TrimSpace(maskedNumber)))
+	return hex.EncodeToString(sum[:])
+}
+
 func (s *BillingService) generateOrderID() string {
 	return fmt.Sprintf("ORDER_%d_%s", time.Now().Unix(), uuid.New().String()[:8])
 }
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// CashReceiptProviders returns the cash receipt provider of a service provider; a blank code is
+// the default provider
+type CashReceiptProviders func(ctx context.Context, serviceProvider string) (provider.CashReceiptProvider, error)
+
+// TossCashReceiptProviders issues cash receipts through the Toss account of each service provider
+func TossCashReceiptProviders(resolver ServiceProviderResolver, logger *zap.Logger) CashReceiptProviders {
+	return func(ctx context.Context, code string) (provider.CashReceiptProvider, error) {
+		serviceProvider, err := resolver.Resolve(ctx, code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve service provider: %w", err)
+		}
+		tossClient, err := TossClient(serviceProvider, logger)
+		if err != nil {
+			return nil, err
+		}
+		return tossClient, nil
+	}
+}
+
+// CashReceiptService issues and cancels cash receipts (현금영수증) for transfer payments
+type CashReceiptService struct {
+	paymentRepo repository.PaymentRepository
+	providers   CashReceiptProviders
+	logger      *zap.Logger
+}
+
+// NewCashReceiptService creates a new CashReceiptService instance
+func NewCashReceiptService(
+	paymentRepo repository.PaymentRepository,
+	providers CashReceiptProviders,
+	logger *zap.Logger,
+) *CashReceiptService {
+	return &CashReceiptService{
+		paymentRepo: paymentRepo,
+		providers:   providers,
+		logger:      logger,
+	}
+}
+
+// IsCashReceiptEligibleMethod reports whether a Toss payment method requires cash receipt issuance
+func IsCashReceiptEligibleMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case "계좌이체", "가상계좌", "TRANSFER", "VIRTUAL_ACCOUNT":
+		return true
+	default:
+		return false
+	}
+}
+
+// IssueForOrder issues the cash receipt requested at checkout once the payment is completed.
+// It is a no-op when no receipt was requested, the method is not eligible, or it was already issued.
+func (s *CashReceiptService) IssueForOrder(ctx context.Context, orderID string, method string, providerData map[string]interface{}) error {
+	payment, err := s.paymentRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment == nil || payment.CashReceipt == nil {
+		return nil
+	}
+
+	receipt := payment.CashReceipt
+	if receipt.Status == string(entity.CashReceiptStatusIssued) || receipt.Status == string(entity.CashReceiptStatusCanceled) {
+		return nil
+	}
+
+	if method == "" {
+		method = string(payment.Method)
+	}
+	if !IsCashReceiptEligibleMethod(method) {
+		s.logger.Info("Skipping cash receipt for non-transfer payment method",
+			zap.String("order_id", orderID),
+			zap.String("method", method))
+		return nil
+	}
+
+	// Toss may already have issued the receipt through the payment window
+	if existing, ok := providerData["cashReceipt"].(map[string]interface{}); ok {
+		if receiptKey, _ := existing["receiptKey"].(string); receiptKey != "" {
+			issueNumber, _ := existing["issueNumber"].(string)
+			receiptURL, _ := existing["receiptUrl"].(string)
+			return s.markIssued(ctx, orderID, &provider.CashReceiptResponse{
+				ReceiptKey:  receiptKey,
+				IssueNumber: issueNumber,
+				ReceiptURL:  receiptURL,
+			})
+		}
+	}
+
+	receiptProvider, err := s.providerFor(ctx, payment)
+	if err != nil {
+		return err
+	}
+
+	resp, err := receiptProvider.IssueCashReceipt(ctx, &provider.IssueCashReceiptRequest{
+		Amount:                 int64(payment.Amount),
+		OrderID:                orderID,
+		OrderName:              orderNameFromMetadata(payment.Metadata),
+		CustomerIdentityNumber: receipt.Identifier,
+		Type:                   provider.CashReceiptType(receipt.Type),
+	})
+	if err != nil {
+		s.logger.Error("Failed to issue cash receipt",
+			zap.String("order_id", orderID),
+			zap.Error(err))
+
+		failed := &entity.CashReceipt{Status: string(entity.CashReceiptStatusFailed)}
+		if updateErr := s.paymentRepo.UpdateCashReceipt(ctx, orderID, failed); updateErr != nil {
+			s.logger.Error("Failed to record cash receipt failure",
+				zap.String("order_id", orderID),
+				zap.Error(updateErr))
+		}
+		return fmt.Errorf("failed to issue cash receipt: %w", err)
+	}
+
+	return s.markIssued(ctx, orderID, resp)
+}
+
+// CancelForOrder cancels an issued cash receipt when its payment is cancelled or refunded.
+// A zero amount cancels the receipt in full.
+func (s *CashReceiptService) CancelForOrder(ctx context.Context, orderID string, amount int64) error {
+	payment, err := s.paymentRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get payment: %w", err)
+	}
+	if payment == nil || payment.CashReceipt == nil {
+		return nil
+	}
+
+	receipt := payment.CashReceipt
+	issued := receipt.Status == string(entity.CashReceiptStatusIssued) ||
+		receipt.Status == string(entity.CashReceiptStatusPartiallyCanceled)
+	if !issued || receipt.ReceiptKey == "" {
+		// Receipts that were never issued only need to be closed locally
+		if receipt.Status == string(entity.CashReceiptStatusRequested) || receipt.Status == string(entity.CashReceiptStatusFailed) {
+			return s.paymentRepo.UpdateCashReceipt(ctx, orderID, &entity.CashReceipt{
+				Status: string(entity.CashReceiptStatusCanceled),
+			})
+		}
+		return nil
+	}
+
+	// Earlier partial cancellations already reduced the receipt, so only the rest can be cancelled
+	remaining := int64(payment.Amount) - receipt.CanceledAmount
+	fullCancel := amount <= 0 || amount >= remaining
+	cancelReq := &provider.CancelCashReceiptRequest{ReceiptKey: receipt.ReceiptKey}
+	if !fullCancel {
+		cancelReq.Amount = amount
+	}
+
+	receiptProvider, err := s.providerFor(ctx, payment)
+	if err != nil {
+		return err
+	}
+
+	if _, err := receiptProvider.CancelCashReceipt(ctx, cancelReq); err != nil {
+		s.logger.Error("Failed to cancel cash receipt",
+			zap.String("order_id", orderID),
+			zap.String("receipt_key", receipt.ReceiptKey),
+			zap.Error(err))
+		return fmt.Errorf("failed to cancel cash receipt: %w", err)
+	}
+
+	if !fullCancel {
+		partial := &entity.CashReceipt{
+			Status:         string(entity.CashReceiptStatusPartiallyCanceled),
+			CanceledAmount: receipt.CanceledAmount + amount,
+		}
+		if err := s.paymentRepo.UpdateCashReceipt(ctx, orderID, partial); err != nil {
+			return err
+		}
+
+		s.logger.Info("Cash receipt partially cancelled",
+			zap.String("order_id", orderID),
+			zap.Int64("amount", amount),
+			zap.Int64("canceled_amount", partial.CanceledAmount))
+		return nil
+	}
+
+	canceledAt := time.Now()
+	canceled := &entity.CashReceipt{
+		Status:         string(entity.CashReceiptStatusCanceled),
+		CanceledAt:     &canceledAt,
+		CanceledAmount: int64(payment.Amount),
+	}
+	if err := s.paymentRepo.UpdateCashReceipt(ctx, orderID, canceled); err != nil {
+		return err
+	}
+
+	s.logger.Info("Cash receipt cancelled",
+		zap.String("order_id", orderID),
+		zap.String("receipt_key", receipt.ReceiptKey))
+
+	return nil
+}
+
+// providerFor returns the cash receipt provider of the service provider the payment was made for,
+// as receipts are issued by the Toss account that took the payment
+func (s *CashReceiptService) providerFor(ctx context.Context, payment *entity.Payment) (provider.CashReceiptProvider, error) {
+	serviceProvider, _ := payment.Metadata["service_provider"].(string)
+	return s.providers(ctx, serviceProvider)
+}
+
+func (s *CashReceiptService) markIssued(ctx context.Context, orderID string, resp *provider.CashReceiptResponse) error {
+	issuedAt := time.Now()
+	issued := &entity.CashReceipt{
+		Status:      string(entity.CashReceiptStatusIssued),
+		ReceiptKey:  resp.ReceiptKey,
+		IssueNumber: resp.IssueNumber,
+		ReceiptURL:  resp.ReceiptURL,
+		IssuedAt:    &issuedAt,
+	}
+
+	if err := s.paymentRepo.UpdateCashReceipt(ctx, orderID, issued); err != nil {
+		return err
+	}
+
+	s.logger.Info("Cash receipt issued",
+		zap.String("order_id", orderID),
+		zap.String("receipt_key", resp.ReceiptKey))
+
+	return nil
+}
+
+func orderNameFromMetadata(metadata map[string]interface{}) string {
+	for _, key := range []string{"order_name", "orderName"} {
+		if value, ok := metadata[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return "결제"
+}
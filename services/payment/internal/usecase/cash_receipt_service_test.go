@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
+	"go.uber.org/zap"
+)
+
+// MockCashReceiptProvider is a mock implementation of CashReceiptProvider
+type MockCashReceiptProvider struct {
+	mock.Mock
+}
+
+func (m *MockCashReceiptProvider) IssueCashReceipt(ctx context.Context, req *provider.IssueCashReceiptRequest) (*provider.CashReceiptResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*provider.CashReceiptResponse), args.Error(1)
+}
+
+func (m *MockCashReceiptProvider) CancelCashReceipt(ctx context.Context, req *provider.CancelCashReceiptRequest) (*provider.CashReceiptResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*provider.CashReceiptResponse), args.Error(1)
+}
+
+func cashReceiptProviders(receiptProvider *MockCashReceiptProvider) CashReceiptProviders {
+	return func(ctx context.Context, serviceProvider string) (provider.CashReceiptProvider, error) {
+		return receiptProvider, nil
+	}
+}
+
+func cashReceiptPayment(status entity.CashReceiptStatus, receiptKey string) *entity.Payment {
+	return &entity.Payment{
+		Amount:   15000,
+		Method:   entity.PaymentMethodBank,
+		Metadata: map[string]interface{}{"order_name": "Pro 플랜"},
+		CashReceipt: &entity.CashReceipt{
+			Type:       string(provider.CashReceiptTypeIncomeDeduction),
+			Identifier: "01012345678",
+			Status:     string(status),
+			ReceiptKey: receiptKey,
+		},
+	}
+}
+
+func receiptWithStatus(status entity.CashReceiptStatus) interface{} {
+	return mock.MatchedBy(func(receipt *entity.CashReceipt) bool {
+		return receipt.Status == string(status)
+	})
+}
+
+func TestCashReceiptService_IssueForOrder(t *testing.T) {
+	ctx := context.Background()
+	orderID := "order-1"
+
+	t.Run("issues the requested receipt", func(t *testing.T) {
+		paymentRepo := new(MockPaymentRepository)
+		receiptProvider := new(MockCashReceiptProvider)
+		service := NewCashReceiptService(paymentRepo, cashReceiptProviders(receiptProvider), zap.NewNop())
+
+		paymentRepo.On("GetByOrderID", ctx, orderID).Return(cashReceiptPayment(entity.CashReceiptStatusRequested, ""), nil)
+		receiptProvider.On("IssueCashReceipt", ctx, &provider.IssueCashReceiptRequest{
+			Amount:                 15000,
+			OrderID:                orderID,
+			OrderName:              "Pro 플랜",
+			CustomerIdentityNumber: "01012345678",
+			Type:                   provider.CashReceiptTypeIncomeDeduction,
+		}).Return(&provider.CashReceiptResponse{ReceiptKey: "rk_1", IssueNumber: "123", ReceiptURL: "https://receipt"}, nil)
+		paymentRepo.On("UpdateCashReceipt", ctx, orderID, mock.MatchedBy(func(receipt *entity.CashReceipt) bool {
+			return receipt.Status == string(entity.CashReceiptStatusIssued) &&
+				receipt.ReceiptKey == "rk_1" &&
+				receipt.IssueNumber == "123" &&
+				receipt.ReceiptURL == "https://receipt" &&
+				receipt.IssuedAt != nil
+		})).Return(nil)
+
+		err := service.IssueForOrder(ctx, orderID, "계좌이체", nil)
+
+		assert.NoError(t, err)
+		paymentRepo.AssertExpectations(t)
+		receiptProvider.AssertExpectations(t)
+	})
+
+	t.Run("records a receipt Toss already issued", func(t *testing.T) {
+		paymentRepo := new(MockPaymentRepository)
+		receiptProvider := new(MockCashReceiptProvider)
+		service := NewCashReceiptService(paymentRepo, cashReceiptProviders(receiptProvider), zap.NewNop())
+
+		paymentRepo.On("GetByOrderID", ctx, orderID).Return(cashReceiptPayment(entity.CashReceiptStatusRequested, ""), nil)
+		paymentRepo.On("UpdateCashReceipt", ctx, orderID, mock.MatchedBy(func(receipt *entity.CashReceipt) bool {
+			return receipt.Status == string(entity.CashReceiptStatusIssued) && receipt.ReceiptKey == "rk_toss"
+		})).Return(nil)
+
+		err := service.IssueForOrder(ctx, orderID, "TRANSFER", map[string]interface{}{
+			"cashReceipt": map[string]interface{}{"receiptKey": "rk_toss", "issueNumber": "456"},
+		})
+
+		assert.NoError(t, err)
+		paymentRepo.AssertExpectations(t)
+		receiptProvider.AssertNotCalled(t, "IssueCashReceipt", mock.Anything, mock.Anything)
+	})
+
+	t.Run("skips ineligible methods and issued receipts", func(t *testing.T) {
+		paymentRepo := new(MockPaymentRepository)
+		receiptProvider := new(MockCashReceiptProvider)
+		service := NewCashReceiptService(paymentRepo, cashReceiptProviders(receiptProvider), zap.NewNop())
+
+		paymentRepo.On("GetByOrderID", ctx, "card-order").Return(cashReceiptPayment(entity.CashReceiptStatusRequested, ""), nil)
+		paymentRepo.On("GetByOrderID", ctx, "issued-order").Return(cashReceiptPayment(entity.CashReceiptStatusIssued, "rk_1"), nil)
+
+		assert.NoError(t, service.IssueForOrder(ctx, "card-order", "카드", nil))
+		assert.NoError(t, service.IssueForOrder(ctx, "issued-order", "계좌이체", nil))
+
+		receiptProvider.AssertNotCalled(t, "IssueCashReceipt", mock.Anything, mock.Anything)
+		paymentRepo.AssertNotCalled(t, "UpdateCashReceipt", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("records a failed issuance", func(t *testing.T) {
+		paymentRepo := new(MockPaymentRepository)
+		receiptProvider := new(MockCashReceiptProvider)
+		service := NewCashReceiptService(paymentRepo, cashReceiptProviders(receiptProvider), zap.NewNop())
+
+		paymentRepo.On("GetByOrderID", ctx, orderID).Return(cashReceiptPayment(entity.CashReceiptStatusRequested, ""), nil)
+		receiptProvider.On("IssueCashReceipt", ctx, mock.Anything).Return(nil, errors.New("toss unavailable"))
+		paymentRepo.On("UpdateCashReceipt", ctx, orderID, receiptWithStatus(entity.CashReceiptStatusFailed)).Return(nil)
+
+		err := service.IssueForOrder(ctx, orderID, "가상계좌", nil)
+
+		assert.Error(t, err)
+		paymentRepo.AssertExpectations(t)
+		paymentRepo.AssertNotCalled(t, "UpdatePaymentAfterConfirm", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCashReceiptService_CancelForOrder(t *testing.T) {
+	ctx := context.Background()
+	orderID := "order-1"
+
+	t.Run("cancels an issued receipt in full", func(t *testing.T) {
+		paymentRepo := new(MockPaymentRepository)
+		receiptProvider := new(MockCashReceiptProvider)
+		service := NewCashReceiptService(paymentRepo, cashReceiptProviders(receiptProvider), zap.NewNop())
+
+		paymentRepo.On("GetByOrderID", ctx, orderID).Return(cashReceiptPayment(entity.CashReceiptStatusIssued, "rk_1"), nil)
+		receiptProvider.On("CancelCashReceipt", ctx, &provider.CancelCashReceiptRequest{ReceiptKey: "rk_1"}).
+			Return(&provider.CashReceiptResponse{ReceiptKey: "rk_1"}, nil)
+		paymentRepo.On("UpdateCashReceipt", ctx, orderID, mock.MatchedBy(func(receipt *entity.CashReceipt) bool {
+			return receipt.Status == string(entity.CashReceiptStatusCanceled) && receipt.CanceledAt != nil
+		})).Return(nil)
+
+		err := service.CancelForOrder(ctx, orderID, 0)
+
+		assert.NoError(t, err)
+		paymentRepo.AssertExpectations(t)
+		receiptProvider.AssertExpectations(t)
+	})
+
+	t.Run("partially cancels without closing the receipt", func(t *testing.T) {
+		paymentRepo := new(MockPaymentRepository)
+		receiptProvider := new(MockCashReceiptProvider)
+		service := NewCashReceiptService(paymentRepo, cashReceiptProviders(receiptProvider), zap.NewNop())
+
+		paymentRepo.On("GetByOrderID", ctx, orderID).Return(cashReceiptPayment(entity.CashReceiptStatusIssued, "rk_1"), nil)
+		receiptProvider.On("CancelCashReceipt", ctx, &provider.CancelCashReceiptRequest{ReceiptKey: "rk_1", Amount: 5000}).
+			Return(&provider.CashReceiptResponse{ReceiptKey: "rk_1"}, nil)
+		paymentRepo.On("UpdateCashReceipt", ctx, orderID, mock.MatchedBy(func(receipt *entity.CashReceipt) bool {
+			return receipt.Status == string(entity.CashReceiptStatusPartiallyCanceled) &&
+				receipt.CanceledAmount == 5000 && receipt.CanceledAt == nil
+		})).Return(nil)
+
+		err := service.CancelForOrder(ctx, orderID, 5000)
+
+		assert.NoError(t, err)
+		paymentRepo.AssertExpectations(t)
+		receiptProvider.AssertExpectations(t)
+	})
+
+	t.Run("adds to earlier partial cancellations", func(t *testing.T) {
+		paymentRepo := new(MockPaymentRepository)
+		receiptProvider := new(MockCashReceiptProvider)
+		service := NewCashReceiptService(paymentRepo, cashReceiptProviders(receiptProvider), zap.NewNop())
+
+		payment := cashReceiptPayment(entity.CashReceiptStatusPartiallyCanceled, "rk_1")
+		payment.CashReceipt.CanceledAmount = 5000
+		paymentRepo.On("GetByOrderID", ctx, orderID).Return(payment, nil)
+		receiptProvider.On("CancelCashReceipt", ctx, &provider.CancelCashReceiptRequest{ReceiptKey: "rk_1", Amount: 3000}).
+			Return(&provider.CashReceiptResponse{ReceiptKey: "rk_1"}, nil)
+		paymentRepo.On("UpdateCashReceipt", ctx, orderID, mock.MatchedBy(func(receipt *entity.CashReceipt) bool {
+			return receipt.Status == string(entity.CashReceiptStatusPartiallyCanceled) && receipt.CanceledAmount == 8000
+		})).Return(nil)
+
+		err := service.CancelForOrder(ctx, orderID, 3000)
+
+		assert.NoError(t, err)
+		paymentRepo.AssertExpectations(t)
+		receiptProvider.AssertExpectations(t)
+	})
+
+	t.Run("closes the receipt when the rest of a partially cancelled amount is cancelled", func(t *testing.T) {
+		paymentRepo := new(MockPaymentRepository)
+		receiptProvider := new(MockCashReceiptProvider)
+		service := NewCashReceiptService(paymentRepo, cashReceiptProviders(receiptProvider), zap.NewNop())
+
+		payment := cashReceiptPayment(entity.CashReceiptStatusPartiallyCanceled, "rk_1")
+		payment.CashReceipt.CanceledAmount = 5000
+		paymentRepo.On("GetByOrderID", ctx, orderID).Return(payment, nil)
+		receiptProvider.On("CancelCashReceipt", ctx, &provider.CancelCashReceiptRequest{ReceiptKey: "rk_1"}).
+			Return(&provider.CashReceiptResponse{ReceiptKey: "rk_1"}, nil)
+		paymentRepo.On("UpdateCashReceipt", ctx, orderID, mock.MatchedBy(func(receipt *entity.CashReceipt) bool {
+			return receipt.Status == string(entity.CashReceiptStatusCanceled) && receipt.CanceledAmount == 15000
+		})).Return(nil)
+
+		err := service.CancelForOrder(ctx, orderID, 10000)
+
+		assert.NoError(t, err)
+		paymentRepo.AssertExpectations(t)
+		receiptProvider.AssertExpectations(t)
+	})
+
+	t.Run("closes a receipt that was never issued locally", func(t *testing.T) {
+		paymentRepo := new(MockPaymentRepository)
+		receiptProvider := new(MockCashReceiptProvider)
+		service := NewCashReceiptService(paymentRepo, cashReceiptProviders(receiptProvider), zap.NewNop())
+
+		paymentRepo.On("GetByOrderID", ctx, orderID).Return(cashReceiptPayment(entity.CashReceiptStatusFailed, ""), nil)
+		paymentRepo.On("UpdateCashReceipt", ctx, orderID, receiptWithStatus(entity.CashReceiptStatusCanceled)).Return(nil)
+
+		err := service.CancelForOrder(ctx, orderID, 0)
+
+		assert.NoError(t, err)
+		paymentRepo.AssertExpectations(t)
+		receiptProvider.AssertNotCalled(t, "CancelCashReceipt", mock.Anything, mock.Anything)
+	})
+
+	t.Run("keeps the receipt issued when the provider fails", func(t *testing.T) {
+		paymentRepo := new(MockPaymentRepository)
+		receiptProvider := new(MockCashReceiptProvider)
+		service := NewCashReceiptService(paymentRepo, cashReceiptProviders(receiptProvider), zap.NewNop())
+
+		paymentRepo.On("GetByOrderID", ctx, orderID).Return(cashReceiptPayment(entity.CashReceiptStatusIssued, "rk_1"), nil)
+		receiptProvider.On("CancelCashReceipt", ctx, mock.Anything).Return(nil, errors.New("toss unavailable"))
+
+		err := service.CancelForOrder(ctx, orderID, 0)
+
+		assert.Error(t, err)
+		paymentRepo.AssertNotCalled(t, "UpdateCashReceipt", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
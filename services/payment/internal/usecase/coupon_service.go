@@ -0,0 +1,417 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stripe/stripe-go/v79"
+	dbRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/adapter/repository"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// minimumChargeAmount is the smallest amount Toss accepts after a discount is applied
+const minimumChargeAmount int64 = 100
+
+// CouponService validates coupons and tracks their redemption across checkout flows
+type CouponService struct {
+	couponRepo    repository.CouponRepository
+	planRepo      dbRepo.PlanRepository
+	creditService *CreditService
+	logger        *zap.Logger
+}
+
+// NewCouponService creates a new CouponService instance
+func NewCouponService(
+	couponRepo repository.CouponRepository,
+	planRepo dbRepo.PlanRepository,
+	creditService *CreditService,
+	logger *zap.Logger,
+) *CouponService {
+	return &CouponService{
+		couponRepo:    couponRepo,
+		planRepo:      planRepo,
+		creditService: creditService,
+		logger:        logger,
+	}
+}
+
+// CouponQuote is the result of applying a coupon to a prospective order
+type CouponQuote struct {
+	Coupon         *model.Coupon `json:"-"`
+	Code           string        `json:"code"`
+	DiscountType   string        `json:"discount_type"`
+	PlanID         string        `json:"plan_id,omitempty"`
+	OriginalAmount int64         `json:"original_amount"`
+	DiscountAmount int64         `json:"discount_amount"`
+	FinalAmount    int64         `json:"final_amount"`
+	Currency       string        `json:"currency,omitempty"`
+	BonusCredits   int           `json:"bonus_credits"`
+}
+
+// Create validates and stores a new coupon. Plan restrictions are normalized to plan IDs.
+func (s *CouponService) Create(ctx context.Context, coupon *model.Coupon) error {
+	coupon.Code = strings.ToUpper(strings.TrimSpace(coupon.Code))
+	coupon.Currency = strings.ToUpper(coupon.Currency)
+	if err := validateCouponDefinition(coupon); err != nil {
+		return err
+	}
+
+	existing, err := s.couponRepo.GetByCode(ctx, coupon.Code)
+	if err != nil {
+		return fmt.Errorf("failed to get coupon: %w", err)
+	}
+	if existing != nil {
+		return domainErrors.ErrCouponCodeTaken
+	}
+
+	for i, planID := range coupon.PlanIDs {
+		if coupon.PlanIDs[i], err = s.normalizePlanID(ctx, planID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.couponRepo.Create(ctx, coupon); err != nil {
+		return err
+	}
+
+	s.logger.Info("Coupon created",
+		zap.Int64("coupon_id", coupon.ID),
+		zap.String("code", coupon.Code),
+		zap.String("discount_type", coupon.DiscountType))
+
+	return nil
+}
+
+// Quote validates a coupon code for a user and plan and calculates the discounted amount.
+// planID may be a plan ID or the provider price ID of one of its price points; it is normalized to the plan ID.
+// Providers that discount on their side (Stripe) pass a zero amount to only validate the coupon.
+func (s *CouponService) Quote(ctx context.Context, code string, universalID uuid.UUID, planID string, amount int64, currency string) (*CouponQuote, error) {
+	coupon, err := s.couponRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coupon: %w", err)
+	}
+	if coupon == nil {
+		return nil, domainErrors.ErrCouponNotFound
+	}
+
+	planID, err = s.normalizePlanID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validate(ctx, coupon, universalID, planID); err != nil {
+		return nil, err
+	}
+
+	quote := &CouponQuote{
+		Coupon:         coupon,
+		Code:           coupon.Code,
+		DiscountType:   coupon.DiscountType,
+		PlanID:         planID,
+		OriginalAmount: amount,
+		FinalAmount:    amount,
+		Currency:       strings.ToUpper(currency),
+	}
+
+	switch coupon.DiscountType {
+	case model.CouponTypePercentage:
+		quote.DiscountAmount = coupon.PercentOff.Mul(decimal.NewFromInt(amount)).Div(decimal.NewFromInt(100)).Floor().IntPart()
+	case model.CouponTypeFixedAmount:
+		if coupon.Currency != "" && currency != "" && !strings.EqualFold(coupon.Currency, currency) {
+			return nil, domainErrors.ErrCouponCurrencyMismatch
+		}
+		quote.DiscountAmount = coupon.AmountOff
+	case model.CouponTypeBonusCredits:
+		quote.BonusCredits = coupon.BonusCredits
+	default:
+		return nil, fmt.Errorf("unknown coupon discount type: %s", coupon.DiscountType)
+	}
+
+	if amount > 0 {
+		if quote.DiscountAmount > amount {
+			quote.DiscountAmount = amount
+		}
+		quote.FinalAmount = amount - quote.DiscountAmount
+		if quote.DiscountAmount > 0 && quote.FinalAmount < minimumChargeAmount {
+			return nil, domainErrors.ErrCouponAmountTooLow
+		}
+	}
+
+	return quote, nil
+}
+
+// Reserve holds a coupon redemption for an order until the payment completes or fails
+func (s *CouponService) Reserve(ctx context.Context, quote *CouponQuote, universalID uuid.UUID, orderID string) error {
+	redemption := &model.CouponRedemption{
+		CouponID:       quote.Coupon.ID,
+		UniversalID:    universalID,
+		OrderID:        orderID,
+		PlanID:         quote.PlanID,
+		OriginalAmount: quote.OriginalAmount,
+		DiscountAmount: quote.DiscountAmount,
+		BonusCredits:   quote.BonusCredits,
+	}
+
+	if err := s.couponRepo.Reserve(ctx, redemption); err != nil {
+		return err
+	}
+
+	s.logger.Info("Coupon reserved for order",
+		zap.String("code", quote.Code),
+		zap.String("order_id", orderID),
+		zap.String("universal_id", universalID.String()),
+		zap.Int64("discount_amount", quote.DiscountAmount))
+
+	return nil
+}
+
+// Complete finalizes the coupon redemption of a paid order and grants any bonus credits.
+// It is a no-op for orders without a coupon and safe to call more than once.
+func (s *CouponService) Complete(ctx context.Context, orderID string, serviceProvider string) error {
+	redemption, err := s.couponRepo.MarkRedeemed(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if redemption == nil || redemption.Status != model.CouponRedemptionRedeemed {
+		return nil
+	}
+
+	if redemption.BonusCredits <= 0 || s.creditService == nil {
+		return nil
+	}
+
+	code := ""
+	if redemption.Coupon != nil {
+		code = redemption.Coupon.Code
+	}
+
+	// The reference ID makes the allocation idempotent across webhook retries
+	_, _, err = s.creditService.AllocateCreditsManual(
+		ctx,
+		redemption.UniversalID,
+		serviceProvider,
+		redemption.BonusCredits,
+		fmt.Sprintf("Bonus credits from coupon %s", code),
+		"coupon:"+orderID,
+	)
+	if err != nil {
+		s.logger.Error("Failed to allocate coupon bonus credits",
+			zap.String("order_id", orderID),
+			zap.Int("credits", redemption.BonusCredits),
+			zap.Error(err))
+		return fmt.Errorf("failed to allocate bonus credits: %w", err)
+	}
+
+	s.logger.Info("Coupon bonus credits allocated",
+		zap.String("order_id", orderID),
+		zap.String("code", code),
+		zap.Int("credits", redemption.BonusCredits))
+
+	return nil
+}
+
+// Release frees the coupon reserved for an order whose payment failed or was cancelled
+func (s *CouponService) Release(ctx context.Context, orderID string) error {
+	if err := s.couponRepo.Release(ctx, orderID); err != nil {
+		s.logger.Error("Failed to release coupon reservation",
+			zap.String("order_id", orderID),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// StripeDiscount returns the subscription discount for a coupon in the service provider's Stripe
+// account, creating the matching Stripe coupon and promotion code on first use. Only coupons
+// scoped to the service provider keep their Stripe IDs, as a coupon offered by every provider
+// needs a promotion code in each provider's account.
+func (s *CouponService) StripeDiscount(ctx context.Context, serviceProvider *model.ServiceProvider, coupon *model.Coupon) (*stripe.SubscriptionDiscountParams, error) {
+	if coupon.DiscountType == model.CouponTypeBonusCredits {
+		return nil, domainErrors.ErrCouponProviderNotSupported
+	}
+
+	stripeClient, err := StripeClient(serviceProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	scoped := coupon.ServiceProvider != "" && coupon.ServiceProvider == serviceProvider.Code
+	if scoped && coupon.StripePromotionCodeID != nil && *coupon.StripePromotionCodeID != "" {
+		return &stripe.SubscriptionDiscountParams{
+			PromotionCode: coupon.StripePromotionCodeID,
+		}, nil
+	}
+	if !scoped {
+		listParams := &stripe.PromotionCodeListParams{
+			Code:   stripe.String(coupon.Code),
+			Active: stripe.Bool(true),
+		}
+		listParams.Context = ctx
+		iter := stripeClient.PromotionCodes.List(listParams)
+		if iter.Next() {
+			return &stripe.SubscriptionDiscountParams{
+				PromotionCode: stripe.String(iter.PromotionCode().ID),
+			}, nil
+		}
+		if err := iter.Err(); err != nil {
+			return nil, fmt.Errorf("failed to look up Stripe promotion code: %w", err)
+		}
+	}
+
+	couponParams := &stripe.CouponParams{
+		Name:     stripe.String(coupon.Name),
+		Duration: stripe.String(string(stripe.CouponDurationOnce)),
+		Metadata: map[string]string{
+			"coupon_id": fmt.Sprintf("%d", coupon.ID),
+			"code":      coupon.Code,
+		},
+	}
+	if coupon.DiscountType == model.CouponTypePercentage {
+		percentOff, _ := coupon.PercentOff.Float64()
+		couponParams.PercentOff = stripe.Float64(percentOff)
+	} else {
+		couponParams.AmountOff = stripe.Int64(coupon.AmountOff)
+		couponParams.Currency = stripe.String(strings.ToLower(coupon.Currency))
+	}
+	if coupon.MaxRedemptions != nil {
+		couponParams.MaxRedemptions = stripe.Int64(int64(*coupon.MaxRedemptions))
+	}
+	if coupon.ValidUntil != nil {
+		couponParams.RedeemBy = stripe.Int64(coupon.ValidUntil.Unix())
+	}
+	couponParams.Context = ctx
+
+	createdCoupon, err := stripeClient.Coupons.New(couponParams)
+	if err != nil {
+		s.logger.Error("Failed to create Stripe coupon",
+			zap.String("code", coupon.Code),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to create Stripe coupon: %w", err)
+	}
+
+	promotionParams := &stripe.PromotionCodeParams{
+		Coupon: stripe.String(createdCoupon.ID),
+		Code:   stripe.String(coupon.Code),
+	}
+	if coupon.ValidUntil != nil {
+		promotionParams.ExpiresAt = stripe.Int64(coupon.ValidUntil.Unix())
+	}
+	promotionParams.Context = ctx
+
+	promotionCode, err := stripeClient.PromotionCodes.New(promotionParams)
+	if err != nil {
+		s.logger.Error("Failed to create Stripe promotion code",
+			zap.String("code", coupon.Code),
+			zap.String("stripe_coupon_id", createdCoupon.ID),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to create Stripe promotion code: %w", err)
+	}
+
+	if scoped {
+		if err := s.couponRepo.UpdateStripeMapping(ctx, coupon.ID, createdCoupon.ID, promotionCode.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("Stripe coupon created for coupon code",
+		zap.String("code", coupon.Code),
+		zap.String("service_provider", serviceProvider.Code),
+		zap.String("stripe_coupon_id", createdCoupon.ID),
+		zap.String("stripe_promotion_code_id", promotionCode.ID))
+
+	return &stripe.SubscriptionDiscountParams{
+		PromotionCode: stripe.String(promotionCode.ID),
+	}, nil
+}
+
+// normalizePlanID resolves a price ID to the ID of the plan sold at that price, so coupon plan
+// restrictions match the same identifier whichever gateway and price point the order uses
+func (s *CouponService) normalizePlanID(ctx context.Context, planID string) (string, error) {
+	if planID == "" || s.planRepo == nil {
+		return planID, nil
+	}
+
+	plan, err := s.planRepo.GetByPriceID(ctx, planID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get plan: %w", err)
+	}
+	if plan == nil {
+		return planID, nil
+	}
+	return plan.ProviderPriceID, nil
+}
+
+// validateCouponDefinition checks that a new coupon's discount and validity window are consistent
+func validateCouponDefinition(coupon *model.Coupon) error {
+	if coupon.Code == "" || coupon.Name == "" {
+		return fmt.Errorf("%w: code and name are required", domainErrors.ErrInvalidCoupon)
+	}
+
+	switch coupon.DiscountType {
+	case model.CouponTypePercentage:
+		if !coupon.PercentOff.IsPositive() || coupon.PercentOff.GreaterThan(decimal.NewFromInt(100)) {
+			return fmt.Errorf("%w: percent_off must be between 0 and 100", domainErrors.ErrInvalidCoupon)
+		}
+	case model.CouponTypeFixedAmount:
+		if coupon.AmountOff <= 0 || coupon.Currency == "" {
+			return fmt.Errorf("%w: amount_off and currency are required", domainErrors.ErrInvalidCoupon)
+		}
+	case model.CouponTypeBonusCredits:
+		if coupon.BonusCredits <= 0 {
+			return fmt.Errorf("%w: bonus_credits must be positive", domainErrors.ErrInvalidCoupon)
+		}
+	default:
+		return fmt.Errorf("%w: unknown discount_type %q", domainErrors.ErrInvalidCoupon, coupon.DiscountType)
+	}
+
+	if (coupon.MaxRedemptions != nil && *coupon.MaxRedemptions <= 0) || (coupon.MaxRedemptionsPerUser != nil && *coupon.MaxRedemptionsPerUser <= 0) {
+		return fmt.Errorf("%w: redemption limits must be positive", domainErrors.ErrInvalidCoupon)
+	}
+	if coupon.ValidFrom != nil && coupon.ValidUntil != nil && !coupon.ValidUntil.After(*coupon.ValidFrom) {
+		return fmt.Errorf("%w: valid_until must be after valid_from", domainErrors.ErrInvalidCoupon)
+	}
+
+	return nil
+}
+
+// validate checks the coupon's status, validity window, plan restriction and per-user limit
+func (s *CouponService) validate(ctx context.Context, coupon *model.Coupon, universalID uuid.UUID, planID string) error {
+	if !coupon.IsActive {
+		return domainErrors.ErrCouponInactive
+	}
+
+	now := time.Now()
+	if coupon.ValidFrom != nil && now.Before(*coupon.ValidFrom) {
+		return domainErrors.ErrCouponNotYetValid
+	}
+	if coupon.ValidUntil != nil && now.After(*coupon.ValidUntil) {
+		return domainErrors.ErrCouponExpired
+	}
+
+	if coupon.MaxRedemptions != nil && coupon.TimesRedeemed >= *coupon.MaxRedemptions {
+		return domainErrors.ErrCouponUsageLimitReached
+	}
+
+	if !coupon.AppliesToPlan(planID) {
+		return domainErrors.ErrCouponPlanNotApplicable
+	}
+
+	if coupon.MaxRedemptionsPerUser != nil {
+		used, err := s.couponRepo.CountUserRedemptions(ctx, coupon.ID, universalID)
+		if err != nil {
+			return err
+		}
+		if used >= int64(*coupon.MaxRedemptionsPerUser) {
+			return domainErrors.ErrCouponUserLimitReached
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// MockCouponRepository is a mock implementation of CouponRepository
+type MockCouponRepository struct {
+	mock.Mock
+}
+
+func (m *MockCouponRepository) Create(ctx context.Context, coupon *model.Coupon) error {
+	args := m.Called(ctx, coupon)
+	return args.Error(0)
+}
+
+func (m *MockCouponRepository) GetByCode(ctx context.Context, code string) (*model.Coupon, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Coupon), args.Error(1)
+}
+
+func (m *MockCouponRepository) GetByID(ctx context.Context, id int64) (*model.Coupon, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Coupon), args.Error(1)
+}
+
+func (m *MockCouponRepository) UpdateStripeMapping(ctx context.Context, couponID int64, stripeCouponID string, stripePromotionCodeID string) error {
+	args := m.Called(ctx, couponID, stripeCouponID, stripePromotionCodeID)
+	return args.Error(0)
+}
+
+func (m *MockCouponRepository) CountUserRedemptions(ctx context.Context, couponID int64, universalID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, couponID, universalID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCouponRepository) Reserve(ctx context.Context, redemption *model.CouponRedemption) error {
+	args := m.Called(ctx, redemption)
+	return args.Error(0)
+}
+
+func (m *MockCouponRepository) GetRedemptionByOrderID(ctx context.Context, orderID string) (*model.CouponRedemption, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CouponRedemption), args.Error(1)
+}
+
+func (m *MockCouponRepository) MarkRedeemed(ctx context.Context, orderID string) (*model.CouponRedemption, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CouponRedemption), args.Error(1)
+}
+
+func (m *MockCouponRepository) Release(ctx context.Context, orderID string) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func TestCouponService_Quote(t *testing.T) {
+	userID := uuid.New()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	one := 1
+
+	tests := []struct {
+		name             string
+		coupon           *model.Coupon
+		userRedemptions  int64
+		planID           string
+		amount           int64
+		currency         string
+		expectedError    error
+		expectedDiscount int64
+		expectedFinal    int64
+		expectedCredits  int
+	}{
+		{
+			name:             "percentage discount",
+			coupon:           &model.Coupon{ID: 1, Code: "WELCOME10", DiscountType: model.CouponTypePercentage, PercentOff: decimal.NewFromInt(10), IsActive: true},
+			amount:           9900,
+			currency:         "KRW",
+			expectedDiscount: 990,
+			expectedFinal:    8910,
+		},
+		{
+			name:             "fixed amount discount",
+			coupon:           &model.Coupon{ID: 2, Code: "SAVE1000", DiscountType: model.CouponTypeFixedAmount, AmountOff: 1000, Currency: "KRW", IsActive: true},
+			amount:           9900,
+			currency:         "KRW",
+			expectedDiscount: 1000,
+			expectedFinal:    8900,
+		},
+		{
+			name:            "bonus credits keep the amount",
+			coupon:          &model.Coupon{ID: 3, Code: "BONUS", DiscountType: model.CouponTypeBonusCredits, BonusCredits: 50, IsActive: true},
+			amount:          9900,
+			currency:        "KRW",
+			expectedFinal:   9900,
+			expectedCredits: 50,
+		},
+		{
+			name:          "inactive coupon",
+			coupon:        &model.Coupon{ID: 4, Code: "OFF", DiscountType: model.CouponTypePercentage, IsActive: false},
+			amount:        9900,
+			expectedError: domainErrors.ErrCouponInactive,
+		},
+		{
+			name:          "expired coupon",
+			coupon:        &model.Coupon{ID: 5, Code: "OLD", DiscountType: model.CouponTypePercentage, ValidUntil: &past, IsActive: true},
+			amount:        9900,
+			expectedError: domainErrors.ErrCouponExpired,
+		},
+		{
+			name:          "coupon not yet valid",
+			coupon:        &model.Coupon{ID: 6, Code: "SOON", DiscountType: model.CouponTypePercentage, ValidFrom: &future, IsActive: true},
+			amount:        9900,
+			expectedError: domainErrors.ErrCouponNotYetValid,
+		},
+		{
+			name:          "plan restriction",
+			coupon:        &model.Coupon{ID: 7, Code: "PRO", DiscountType: model.CouponTypePercentage, PlanIDs: model.StringList{"plan_pro"}, IsActive: true},
+			planID:        "plan_basic",
+			amount:        9900,
+			expectedError: domainErrors.ErrCouponPlanNotApplicable,
+		},
+		{
+			name:          "usage limit reached",
+			coupon:        &model.Coupon{ID: 8, Code: "LIMITED", DiscountType: model.CouponTypePercentage, MaxRedemptions: &one, TimesRedeemed: 1, IsActive: true},
+			amount:        9900,
+			expectedError: domainErrors.ErrCouponUsageLimitReached,
+		},
+		{
+			name:            "per-user limit reached",
+			coupon:          &model.Coupon{ID: 9, Code: "ONCE", DiscountType: model.CouponTypePercentage, MaxRedemptionsPerUser: &one, IsActive: true},
+			userRedemptions: 1,
+			amount:          9900,
+			expectedError:   domainErrors.ErrCouponUserLimitReached,
+		},
+		{
+			name:          "currency mismatch",
+			coupon:        &model.Coupon{ID: 10, Code: "USD5", DiscountType: model.CouponTypeFixedAmount, AmountOff: 500, Currency: "USD", IsActive: true},
+			amount:        9900,
+			currency:      "KRW",
+			expectedError: domainErrors.ErrCouponCurrencyMismatch,
+		},
+		{
+			name:          "discount below minimum charge",
+			coupon:        &model.Coupon{ID: 11, Code: "ALMOSTFREE", DiscountType: model.CouponTypeFixedAmount, AmountOff: 9850, IsActive: true},
+			amount:        9900,
+			currency:      "KRW",
+			expectedError: domainErrors.ErrCouponAmountTooLow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockCouponRepository)
+			repo.On("GetByCode", mock.Anything, tt.coupon.Code).Return(tt.coupon, nil)
+			repo.On("CountUserRedemptions", mock.Anything, tt.coupon.ID, userID).Return(tt.userRedemptions, nil)
+
+			service := NewCouponService(repo, nil, nil, zap.NewNop())
+			quote, err := service.Quote(context.Background(), tt.coupon.Code, userID, tt.planID, tt.amount, tt.currency)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, quote)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedDiscount, quote.DiscountAmount)
+			assert.Equal(t, tt.expectedFinal, quote.FinalAmount)
+			assert.Equal(t, tt.expectedCredits, quote.BonusCredits)
+		})
+	}
+}
+
+func TestCouponService_QuoteUnknownCode(t *testing.T) {
+	repo := new(MockCouponRepository)
+	repo.On("GetByCode", mock.Anything, "MISSING").Return(nil, nil)
+
+	service := NewCouponService(repo, nil, nil, zap.NewNop())
+	quote, err := service.Quote(context.Background(), "MISSING", uuid.New(), "", 9900, "KRW")
+
+	assert.ErrorIs(t, err, domainErrors.ErrCouponNotFound)
+	assert.Nil(t, quote)
+}
+
+func TestCouponService_QuoteNormalizesPriceIDToPlan(t *testing.T) {
+	userID := uuid.New()
+	coupon := &model.Coupon{
+		ID:           1,
+		Code:         "PRO10",
+		DiscountType: model.CouponTypePercentage,
+		PercentOff:   decimal.NewFromInt(10),
+		PlanIDs:      model.StringList{"plan_pro_monthly"},
+		IsActive:     true,
+	}
+
+	repo := new(MockCouponRepository)
+	repo.On("GetByCode", mock.Anything, "PRO10").Return(coupon, nil)
+
+	planRepo := new(MockPlanRepository)
+	planRepo.On("GetByPriceID", mock.Anything, "price_pro_usd").Return(&model.PaymentPlan{ProviderPriceID: "plan_pro_monthly"}, nil)
+	planRepo.On("GetByPriceID", mock.Anything, "plan_basic_monthly").Return(&model.PaymentPlan{ProviderPriceID: "plan_basic_monthly"}, nil)
+
+	service := NewCouponService(repo, planRepo, nil, zap.NewNop())
+
+	quote, err := service.Quote(context.Background(), "PRO10", userID, "price_pro_usd", 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "plan_pro_monthly", quote.PlanID)
+
+	_, err = service.Quote(context.Background(), "PRO10", userID, "plan_basic_monthly", 0, "")
+	assert.ErrorIs(t, err, domainErrors.ErrCouponPlanNotApplicable)
+}
+
+func TestCouponService_Create(t *testing.T) {
+	t.Run("normalizes and stores a valid coupon", func(t *testing.T) {
+		repo := new(MockCouponRepository)
+		repo.On("GetByCode", mock.Anything, "SPRING").Return(nil, nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*model.Coupon")).Return(nil)
+
+		planRepo := new(MockPlanRepository)
+		planRepo.On("GetByPriceID", mock.Anything, "price_pro_usd").Return(&model.PaymentPlan{ProviderPriceID: "plan_pro_monthly"}, nil)
+
+		coupon := &model.Coupon{
+			Code:         " spring ",
+			Name:         "Spring sale",
+			DiscountType: model.CouponTypeFixedAmount,
+			AmountOff:    1000,
+			Currency:     "krw",
+			PlanIDs:      model.StringList{"price_pro_usd"},
+		}
+
+		err := NewCouponService(repo, planRepo, nil, zap.NewNop()).Create(context.Background(), coupon)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SPRING", coupon.Code)
+		assert.Equal(t, "KRW", coupon.Currency)
+		assert.Equal(t, model.StringList{"plan_pro_monthly"}, coupon.PlanIDs)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an inconsistent coupon", func(t *testing.T) {
+		repo := new(MockCouponRepository)
+
+		err := NewCouponService(repo, nil, nil, zap.NewNop()).Create(context.Background(), &model.Coupon{
+			Code:         "HALF",
+			Name:         "Half off",
+			DiscountType: model.CouponTypePercentage,
+			PercentOff:   decimal.NewFromInt(150),
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidCoupon)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a code in use", func(t *testing.T) {
+		repo := new(MockCouponRepository)
+		repo.On("GetByCode", mock.Anything, "WELCOME").Return(&model.Coupon{ID: 1, Code: "WELCOME"}, nil)
+
+		err := NewCouponService(repo, nil, nil, zap.NewNop()).Create(context.Background(), &model.Coupon{
+			Code:         "WELCOME",
+			Name:         "Welcome",
+			DiscountType: model.CouponTypeBonusCredits,
+			BonusCredits: 100,
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrCouponCodeTaken)
+	})
+}
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// MaxCreditBatchSize is the maximum number of usages accepted in one batch
+const MaxCreditBatchSize = 500
+
+// Batch usage item statuses
+const (
+	BatchUsageApplied   = "applied"   // Credits were deducted
+	BatchUsageDuplicate = "duplicate" // The idempotency key was already processed; nothing was deducted
+	BatchUsageFailed    = "failed"    // The usage itself could not be applied
+	BatchUsageRejected  = "rejected"  // The usage was valid but its atomic batch was not applied
+)
+
+// BatchUsageItem is one usage of a batch. A zero Amount is priced from the feature catalog.
+type BatchUsageItem struct {
+	Amount         decimal.Decimal
+	FeatureName    string
+	Description    string
+	UsageMetadata  map[string]interface{}
+	IdempotencyKey *uuid.UUID
+}
+
+// BatchUsageRequest is a batch of credit usages against one balance
+type BatchUsageRequest struct {
+	UniversalID     uuid.UUID
+	ActorID         *uuid.UUID
+	ServiceProvider string
+	Atomic          bool
+	Items           []BatchUsageItem
+}
+
+// BatchUsageItemResult reports the outcome of one usage of a batch
+type BatchUsageItemResult struct {
+	Index         int    `json:"index"`
+	Status        string `json:"status"`
+	TransactionID int64  `json:"transaction_id,omitempty"`
+	Amount        string `json:"amount,omitempty"`
+	PriceVersion  *int   `json:"price_version,omitempty"`
+	Code          string `json:"code,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// BatchUsageResult reports the outcome of a batch and the balance after it
+type BatchUsageResult struct {
+	Applied      int                    `json:"applied"`
+	Duplicates   int                    `json:"duplicates"`
+	Failed       int                    `json:"failed"`
+	BalanceAfter decimal.Decimal        `json:"balance_after"`
+	Results      []BatchUsageItemResult `json:"results"`
+}
+
+// CreditBatchService applies many credit usages in a single database transaction
+type CreditBatchService struct {
+	creditRepo      repository.CreditRepository
+	pricingService  *FeaturePricingService
+	logger          *zap.Logger
+	serviceProvider string
+}
+
+// NewCreditBatchService creates a new CreditBatchService instance
+func NewCreditBatchService(creditRepo repository.CreditRepository, pricingService *FeaturePricingService, logger *zap.Logger, serviceProvider string) *CreditBatchService {
+	return &CreditBatchService{
+		creditRepo:      creditRepo,
+		pricingService:  pricingService,
+		logger:          logger,
+		serviceProvider: serviceProvider,
+	}
+}
+
+// UseCreditsBatch prices and applies a batch of usages. In atomic mode nothing is applied when any
+// usage fails and ErrCreditBatchRejected is returned along with the per-item results.
+func (s *CreditBatchService) UseCreditsBatch(ctx context.Context, req *BatchUsageRequest) (*BatchUsageResult, error) {
+	if len(req.Items) == 0 {
+		return nil, domainErrors.ErrCreditBatchEmpty
+	}
+	if len(req.Items) > MaxCreditBatchSize {
+		return nil, domainErrors.ErrCreditBatchTooLarge
+	}
+
+	provider := req.ServiceProvider
+	if provider == "" {
+		provider = s.serviceProvider
+	}
+
+	result := &BatchUsageResult{Results: make([]BatchUsageItemResult, len(req.Items))}
+	usages := make([]repository.CreditUsage, 0, len(req.Items))
+	indexes := make([]int, 0, len(req.Items))
+
+	for i, item := range req.Items {
+		result.Results[i].Index = i
+
+		usage, priceVersion, err := s.prepare(ctx, req, provider, item)
+		if err != nil {
+			s.fail(&result.Results[i], err)
+			continue
+		}
+
+		result.Results[i].Amount = usage.Amount.String()
+		result.Results[i].PriceVersion = priceVersion
+		usages = append(usages, usage)
+		indexes = append(indexes, i)
+	}
+
+	if len(usages) == 0 || req.Atomic && len(usages) < len(req.Items) {
+		return s.reject(ctx, req.UniversalID, provider, req.Atomic, result)
+	}
+
+	balance, outcomes, err := s.creditRepo.UseCreditsBatch(ctx, req.UniversalID, provider, usages, req.Atomic)
+	if err != nil && !errors.Is(err, domainErrors.ErrCreditBatchRejected) {
+		return nil, err
+	}
+
+	for j, outcome := range outcomes {
+		item := &result.Results[indexes[j]]
+		switch {
+		case outcome.Err != nil:
+			s.fail(item, outcome.Err)
+		case outcome.Duplicate:
+			item.Status = BatchUsageDuplicate
+			item.TransactionID = outcome.Transaction.ID
+			item.Amount = outcome.Transaction.Amount.Neg().String()
+			item.PriceVersion = nil
+		case err != nil:
+			item.Status = BatchUsageRejected
+		default:
+			item.Status = BatchUsageApplied
+			item.TransactionID = outcome.Transaction.ID
+		}
+	}
+
+	s.count(result)
+	if balance != nil {
+		result.BalanceAfter = balance.CurrentBalance
+	}
+
+	s.logger.Info("Credit usage batch processed",
+		zap.String("universal_id", req.UniversalID.String()),
+		zap.String("service_provider", provider),
+		zap.Bool("atomic", req.Atomic),
+		zap.Int("applied", result.Applied),
+		zap.Int("duplicates", result.Duplicates),
+		zap.Int("failed", result.Failed))
+
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// prepare validates an item and prices it from the catalog when it has no amount
+func (s *CreditBatchService) prepare(ctx context.Context, req *BatchUsageRequest, provider string, item BatchUsageItem) (repository.CreditUsage, *int, error) {
+	if item.FeatureName == "" {
+		return repository.CreditUsage{}, nil, fmt.Errorf("feature_name is required")
+	}
+	if item.Amount.IsNegative() {
+		return repository.CreditUsage{}, nil, fmt.Errorf("amount must be greater than zero")
+	}
+
+	metadata := model.JSONB(item.UsageMetadata)
+	amount := item.Amount
+	var priceVersion *int
+
+	if amount.IsZero() {
+		quote, err := s.pricingService.Quote(ctx, req.UniversalID.String(), provider, item.FeatureName, item.UsageMetadata)
+		if err != nil {
+			return repository.CreditUsage{}, nil, err
+		}
+		amount = quote.Credits
+		priceVersion = &quote.PriceVersion
+
+		metadata = make(model.JSONB, len(item.UsageMetadata)+2)
+		for k, v := range item.UsageMetadata {
+			metadata[k] = v
+		}
+		metadata["price_id"] = quote.PriceID
+		metadata["price_version"] = quote.PriceVersion
+	}
+	if !amount.IsPositive() {
+		return repository.CreditUsage{}, nil, fmt.Errorf("amount must be greater than zero")
+	}
+
+	actorID := req.ActorID
+	if actorID != nil && *actorID == req.UniversalID {
+		actorID = nil
+	}
+
+	description := item.Description
+	if description == "" {
+		description = item.FeatureName
+	}
+
+	return repository.CreditUsage{
+		Amount:         amount,
+		Description:    description,
+		FeatureName:    item.FeatureName,
+		ActorID:        actorID,
+		UsageMetadata:  metadata,
+		IdempotencyKey: item.IdempotencyKey,
+	}, priceVersion, nil
+}
+
+// reject reports a batch that was not sent to the database because its usages failed validation
+func (s *CreditBatchService) reject(ctx context.Context, universalID uuid.UUID, provider string, atomic bool, result *BatchUsageResult) (*BatchUsageResult, error) {
+	for i := range result.Results {
+		if result.Results[i].Status == "" {
+			result.Results[i].Status = BatchUsageRejected
+		}
+	}
+	s.count(result)
+
+	balance, err := s.creditRepo.GetBalance(ctx, universalID, provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+	result.BalanceAfter = balance.CurrentBalance
+
+	if !atomic {
+		return result, nil
+	}
+	return result, domainErrors.ErrCreditBatchRejected
+}
+
+func (s *CreditBatchService) fail(item *BatchUsageItemResult, err error) {
+	item.Status = BatchUsageFailed
+	item.TransactionID = 0
+	item.PriceVersion = nil
+	item.Error = err.Error()
+
+	var insufficientErr *domainErrors.InsufficientBalanceError
+	switch {
+	case errors.As(err, &insufficientErr):
+		item.Code = "insufficient_credits"
+	case errors.Is(err, domainErrors.ErrIdempotencyKeyConflict):
+		item.Code = "idempotency_conflict"
+	case errors.Is(err, domainErrors.ErrFeaturePriceNotFound):
+		item.Code = "price_not_found"
+	case errors.Is(err, domainErrors.ErrFeaturePriceUnitsMissing):
+		item.Code = "invalid_usage_metadata"
+	default:
+		item.Code = "invalid_item"
+	}
+}
+
+func (s *CreditBatchService) count(result *BatchUsageResult) {
+	result.Applied, result.Duplicates, result.Failed = 0, 0, 0
+	for _, item := range result.Results {
+		switch item.Status {
+		case BatchUsageApplied:
+			result.Applied++
+		case BatchUsageDuplicate:
+			result.Duplicates++
+		case BatchUsageFailed:
+			result.Failed++
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// MockCreditRepository is a mock implementation of CreditRepository
+type MockCreditRepository struct {
+	mock.Mock
+}
+
+func (m *MockCreditRepository) GetBalance(ctx context.Context, universalID uuid.UUID, serviceProvider string) (*model.UserCreditBalance, error) {
+	args := m.Called(ctx, universalID, serviceProvider)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UserCreditBalance), args.Error(1)
+}
+
+func (m *MockCreditRepository) AllocateCredits(ctx context.Context, universalID uuid.UUID, serviceProvider string, amount decimal.Decimal, description string, referenceID string) (*model.UserCreditBalance, *model.CreditTransaction, error) {
+	args := m.Called(ctx, universalID, serviceProvider, amount, description, referenceID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*model.UserCreditBalance), args.Get(1).(*model.CreditTransaction), args.Error(2)
+}
+
+func (m *MockCreditRepository) UseCredits(ctx context.Context, universalID uuid.UUID, actorID *uuid.UUID, serviceProvider string, amount decimal.Decimal, description string, featureName string, usageMetadata model.JSONB) (*model.UserCreditBalance, *model.CreditTransaction, error) {
+	args := m.Called(ctx, universalID, actorID, serviceProvider, amount, description, featureName, usageMetadata)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*model.UserCreditBalance), args.Get(1).(*model.CreditTransaction), args.Error(2)
+}
+
+func (m *MockCreditRepository) UseCreditsBatch(ctx context.Context, universalID uuid.UUID, serviceProvider string, usages []repository.CreditUsage, atomic bool) (*model.UserCreditBalance, []repository.CreditUsageOutcome, error) {
+	args := m.Called(ctx, universalID, serviceProvider, usages, atomic)
+	var balance *model.UserCreditBalance
+	if args.Get(0) != nil {
+		balance = args.Get(0).(*model.UserCreditBalance)
+	}
+	var outcomes []repository.CreditUsageOutcome
+	if args.Get(1) != nil {
+		outcomes = args.Get(1).([]repository.CreditUsageOutcome)
+	}
+	return balance, outcomes, args.Error(2)
+}
+
+func (m *MockCreditRepository) TransferCredits(ctx context.Context, transfer *repository.CreditTransfer) (*repository.CreditTransferResult, error) {
+	args := m.Called(ctx, transfer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.CreditTransferResult), args.Error(1)
+}
+
+func (m *MockCreditRepository) GetTransactionByReference(ctx context.Context, referenceID string) (*model.CreditTransaction, error) {
+	args := m.Called(ctx, referenceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CreditTransaction), args.Error(1)
+}
+
+func (m *MockCreditRepository) GetTransactionHistory(ctx context.Context, universalID uuid.UUID, limit, offset int) ([]*model.CreditTransaction, error) {
+	args := m.Called(ctx, universalID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.CreditTransaction), args.Error(1)
+}
+
+func TestCreditBatchService_UseCreditsBatch(t *testing.T) {
+	universalID := uuid.MustParse("5b8e2d73-0000-4000-8000-000000000001")
+	key := uuid.MustParse("5b8e2d73-0000-4000-8000-0000000000aa")
+
+	items := []BatchUsageItem{
+		{Amount: decimal.NewFromInt(10), FeatureName: "summarization"},
+		{Amount: decimal.NewFromInt(5), FeatureName: "translation", IdempotencyKey: &key},
+		{Amount: decimal.NewFromInt(50), FeatureName: "summarization"},
+	}
+
+	t.Run("partial batch reports each usage", func(t *testing.T) {
+		repo := new(MockCreditRepository)
+		repo.On("UseCreditsBatch", mock.Anything, universalID, "semo", mock.MatchedBy(func(u []repository.CreditUsage) bool {
+			return len(u) == 3 && u[0].Description == "summarization"
+		}), false).Return(&model.UserCreditBalance{CurrentBalance: decimal.NewFromInt(20)}, []repository.CreditUsageOutcome{
+			{Transaction: &model.CreditTransaction{ID: 11, Amount: decimal.NewFromInt(-10)}},
+			{Transaction: &model.CreditTransaction{ID: 7, Amount: decimal.NewFromInt(-5)}, Duplicate: true},
+			{Err: domainErrors.NewInsufficientBalanceError(decimal.NewFromInt(50), decimal.NewFromInt(20))},
+		}, nil)
+
+		service := NewCreditBatchService(repo, nil, zap.NewNop(), "semo")
+		result, err := service.UseCreditsBatch(context.Background(), &BatchUsageRequest{UniversalID: universalID, Items: items})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Applied)
+		assert.Equal(t, 1, result.Duplicates)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, "20", result.BalanceAfter.String())
+		assert.Equal(t, BatchUsageApplied, result.Results[0].Status)
+		assert.Equal(t, int64(11), result.Results[0].TransactionID)
+		assert.Equal(t, BatchUsageDuplicate, result.Results[1].Status)
+		assert.Equal(t, "insufficient_credits", result.Results[2].Code)
+	})
+
+	t.Run("atomic batch is rejected as a whole", func(t *testing.T) {
+		repo := new(MockCreditRepository)
+		repo.On("UseCreditsBatch", mock.Anything, universalID, "semo", mock.Anything, true).Return(&model.UserCreditBalance{CurrentBalance: decimal.NewFromInt(35)}, []repository.CreditUsageOutcome{
+			{Transaction: &model.CreditTransaction{Amount: decimal.NewFromInt(-10)}},
+			{Transaction: &model.CreditTransaction{ID: 7, Amount: decimal.NewFromInt(-5)}, Duplicate: true},
+			{Err: domainErrors.NewInsufficientBalanceError(decimal.NewFromInt(50), decimal.NewFromInt(25))},
+		}, domainErrors.ErrCreditBatchRejected)
+
+		service := NewCreditBatchService(repo, nil, zap.NewNop(), "semo")
+		result, err := service.UseCreditsBatch(context.Background(), &BatchUsageRequest{UniversalID: universalID, Atomic: true, Items: items})
+
+		assert.ErrorIs(t, err, domainErrors.ErrCreditBatchRejected)
+		assert.Equal(t, 0, result.Applied)
+		assert.Equal(t, "35", result.BalanceAfter.String())
+		assert.Equal(t, BatchUsageRejected, result.Results[0].Status)
+		assert.Equal(t, BatchUsageFailed, result.Results[2].Status)
+	})
+
+	t.Run("invalid usage rejects an atomic batch before it reaches the database", func(t *testing.T) {
+		repo := new(MockCreditRepository)
+		repo.On("GetBalance", mock.Anything, universalID, "semo").Return(&model.UserCreditBalance{CurrentBalance: decimal.NewFromInt(35)}, nil)
+
+		service := NewCreditBatchService(repo, nil, zap.NewNop(), "semo")
+		result, err := service.UseCreditsBatch(context.Background(), &BatchUsageRequest{
+			UniversalID: universalID,
+			Atomic:      true,
+			Items:       []BatchUsageItem{items[0], {Amount: decimal.NewFromInt(1)}},
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrCreditBatchRejected)
+		assert.Equal(t, BatchUsageRejected, result.Results[0].Status)
+		assert.Equal(t, "invalid_item", result.Results[1].Code)
+		repo.AssertNotCalled(t, "UseCreditsBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("too many usages", func(t *testing.T) {
+		service := NewCreditBatchService(new(MockCreditRepository), nil, zap.NewNop(), "semo")
+		_, err := service.UseCreditsBatch(context.Background(), &BatchUsageRequest{UniversalID: universalID, Items: make([]BatchUsageItem, MaxCreditBatchSize+1)})
+		assert.ErrorIs(t, err, domainErrors.ErrCreditBatchTooLarge)
+	})
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -21,6 +22,7 @@ type CreditService struct {
 	planRepo         repository.PlanRepository
 	logger           *zap.Logger
 	serviceProvider  string
+	entitlements     *EntitlementService // optional; invalidated when a payment or grant is credited
 }
 
 // NewCreditService creates a new credit service instance
@@ -30,6 +32,7 @@ func NewCreditService(
 	planRepo repository.PlanRepository,
 	logger *zap.Logger,
 	serviceProvider string,
+	entitlements *EntitlementService,
 ) *CreditService {
 	if serviceProvider == "" {
 		logger.Error("CreditService initialized without service provider")
@@ -40,18 +43,21 @@ func NewCreditService(
 		planRepo:         planRepo,
 		logger:           logger,
 		serviceProvider:  serviceProvider,
+		entitlements:     entitlements,
 	}
 }
 
 // AllocateCreditsForPayment allocates credits based on a successful payment
+// Seat-based plans allocate the plan's credits for each paid seat.
 // Returns the number of credits newly allocated. When 0 is returned without error,
 // the allocation was already processed earlier (idempotency).
-func (s *CreditService) AllocateCreditsForPayment(ctx context.Context, universalID uuid.UUID, invoiceID string, subscriptionID string, stripePriceID string, serviceProviderOverride string) (int, error) {
+func (s *CreditService) AllocateCreditsForPayment(ctx context.Context, universalID uuid.UUID, invoiceID string, subscriptionID string, stripePriceID string, seats int, serviceProviderOverride string) (int, error) {
 	s.logger.Info("=== AllocateCreditsForPayment START ===",
 		zap.String("universal_id", universalID.String()),
 		zap.String("invoice_id", invoiceID),
 		zap.String("subscription_id", subscriptionID),
 		zap.String("price_id", stripePriceID),
+		zap.Int("seats", seats),
 		zap.String("service_provider", serviceProviderOverride))
 
 	// First check if credits were already allocated for this invoice (idempotency)
@@ -114,8 +120,12 @@ func (s *CreditService) AllocateCreditsForPayment(ctx context.Context, universal
 	}
 
 	// Allocate credits
-	amount := decimal.NewFromInt(int64(plan.CreditsPerCycle))
+	credits := plan.SeatCredits(seats)
+	amount := decimal.NewFromInt(int64(credits))
 	description := fmt.Sprintf("Credit allocation for %s subscription", plan.DisplayName)
+	if plan.SeatBased && seats > 1 {
+		description = fmt.Sprintf("Credit allocation for %s subscription (%d seats)", plan.DisplayName, seats)
+	}
 
 	s.logger.Info("CALLING creditRepo.AllocateCredits",
 		zap.String("universal_id", universalID.String()),
@@ -123,8 +133,11 @@ func (s *CreditService) AllocateCreditsForPayment(ctx context.Context, universal
 		zap.String("description", description),
 		zap.String("reference_id", invoiceID))
 
+	// Plans sold by a single service provider always credit that provider
 	serviceProvider := s.serviceProvider
-	if serviceProviderOverride != "" {
+	if plan.ServiceProvider != "" {
+		serviceProvider = plan.ServiceProvider
+	} else if serviceProviderOverride != "" {
 		serviceProvider = serviceProviderOverride
 	}
 
@@ -146,18 +159,20 @@ func (s *CreditService) AllocateCreditsForPayment(ctx context.Context, universal
 		zap.String("universal_id", universalID.String()),
 		zap.String("invoice_id", invoiceID),
 		zap.String("subscription_id", subscriptionID),
-		zap.Int("credits", plan.CreditsPerCycle),
+		zap.Int("credits", credits),
 		zap.String("new_balance", balance.CurrentBalance.String()),
 		zap.String("transaction_id", fmt.Sprintf("%d", transaction.ID)))
 
+	s.entitlements.Invalidate(universalID.String())
+
 	s.logger.Info("=== AllocateCreditsForPayment END ===")
-	return plan.CreditsPerCycle, nil
+	return credits, nil
 }
 
 // AllocateCreditsWithMetadata allocates credits based on product metadata
 // Returns the number of credits newly allocated. When 0 is returned without error,
 // the allocation was already processed earlier (idempotency).
-func (s *CreditService) AllocateCreditsWithMetadata(ctx context.Context, universalID uuid.UUID, invoiceID string, creditsPerCycle int, productName string) (int, error) {
+func (s *CreditService) AllocateCreditsWithMetadata(ctx context.Context, universalID uuid.UUID, invoiceID string, creditsPerCycle int, productName string, serviceProviderOverride string) (int, error) {
 	s.logger.Info("=== AllocateCreditsWithMetadata START ===",
 		zap.String("universal_id", universalID.String()),
 		zap.String("invoice_id", invoiceID),
@@ -194,7 +209,12 @@ func (s *CreditService) AllocateCreditsWithMetadata(ctx context.Context, univers
 		zap.String("description", description),
 		zap.String("reference_id", invoiceID))
 
-	balance, transaction, err := s.creditRepo.AllocateCredits(ctx, universalID, s.serviceProvider, amount, description, invoiceID)
+	serviceProvider := s.serviceProvider
+	if serviceProviderOverride != "" {
+		serviceProvider = serviceProviderOverride
+	}
+
+	balance, transaction, err := s.creditRepo.AllocateCredits(ctx, universalID, serviceProvider, amount, description, invoiceID)
 	if err != nil {
 		s.logger.Error("CREDIT ALLOCATION WITH METADATA FAILED IN REPOSITORY",
 			zap.String("universal_id", universalID.String()),
@@ -212,6 +232,8 @@ func (s *CreditService) AllocateCreditsWithMetadata(ctx context.Context, univers
 		zap.String("new_balance", balance.CurrentBalance.String()),
 		zap.String("transaction_id", fmt.Sprintf("%d", transaction.ID)))
 
+	s.entitlements.Invalidate(universalID.String())
+
 	s.logger.Info("=== AllocateCreditsWithMetadata END ===")
 	return creditsPerCycle, nil
 }
@@ -255,6 +277,8 @@ func (s *CreditService) AllocateCreditsManual(ctx context.Context, universalID u
 		zap.String("reference_id", referenceID),
 		zap.Int64("transaction_id", transaction.ID))
 
+	s.entitlements.Invalidate(universalID.String())
+
 	return balance, transaction, nil
 }
 
@@ -279,7 +303,7 @@ func (s *CreditService) GetBalanceForProvider(ctx context.Context, universalID u
 }
 
 // UseCredits deducts credits for a specific feature
-func (s *CreditService) UseCredits(ctx context.Context, universalID uuid.UUID, serviceProvider string, amount decimal.Decimal, featureName string, description string, usageMetadata []byte, idempotencyKey *uuid.UUID) (*model.CreditTransaction, error) {
+func (s *CreditService) UseCredits(ctx context.Context, universalID uuid.UUID, actorID *uuid.UUID, serviceProvider string, amount decimal.Decimal, featureName string, description string, usageMetadata []byte, idempotencyKey *uuid.UUID) (*model.CreditTransaction, error) {
 	// For now, we'll use the existing UseCredits without idempotency key support
 	// TODO: Add idempotency key support to repository layer
 
@@ -296,7 +320,19 @@ func (s *CreditService) UseCredits(ctx context.Context, universalID uuid.UUID, s
 		return nil, fmt.Errorf("failed to get balance: %w", err)
 	}
 
-	balance, transaction, err := s.creditRepo.UseCredits(ctx, universalID, provider, amount, description, featureName)
+	var metadata model.JSONB
+	if len(usageMetadata) > 0 {
+		if err := json.Unmarshal(usageMetadata, &metadata); err != nil {
+			return nil, fmt.Errorf("invalid usage metadata: %w", err)
+		}
+	}
+
+	// Usage on a user's own balance has no separate actor
+	if actorID != nil && *actorID == universalID {
+		actorID = nil
+	}
+
+	balance, transaction, err := s.creditRepo.UseCredits(ctx, universalID, actorID, provider, amount, description, featureName, metadata)
 	if err != nil {
 		// Check if it's an insufficient balance error
 		if strings.Contains(err.Error(), "insufficient credit balance") {
@@ -44,9 +44,12 @@ func (s *CreditTransactionService) GetUserTransactionHistory(
 	// Set user ID and defaults
 	filters.UserID = universalID
 	filters.SetDefaults()
+	limit := filters.Limit
 
-	// Get transactions
-	transactions, err := s.transactionRepo.GetTransactions(ctx, filters)
+	// Get transactions, fetching one extra to learn whether another page follows
+	query := filters
+	query.Limit = limit + 1
+	transactions, err := s.transactionRepo.GetTransactions(ctx, query)
 	if err != nil {
 		s.logger.Error("failed to get transactions",
 			zap.String("universal_id", universalID.String()),
@@ -54,13 +57,12 @@ func (s *CreditTransactionService) GetUserTransactionHistory(
 		return nil, fmt.Errorf("failed to get transaction history: %w", err)
 	}
 
-	// Get total count for pagination
-	totalCount, err := s.transactionRepo.CountTransactions(ctx, filters)
-	if err != nil {
-		s.logger.Error("failed to count transactions",
-			zap.String("universal_id", universalID.String()),
-			zap.Error(err))
-		return nil, fmt.Errorf("failed to count transactions: %w", err)
+	// Calculate pagination info
+	pagination := dto.PaginationInfo{Limit: limit}
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+		pagination.HasMore = true
+		pagination.NextCursor = filters.TransactionCursor(&transactions[limit-1]).Encode()
 	}
 
 	// Transform to DTOs
@@ -83,21 +85,15 @@ func (s *CreditTransactionService) GetUserTransactionHistory(
 			Amount:          amountStr,
 			BalanceAfter:    tx.BalanceAfter.String(),
 			Description:     description,
+			TransferID:      tx.TransferID,
+			CounterpartyID:  tx.CounterpartyID,
 			CreatedAt:       tx.CreatedAt,
 		}
 	}
 
-	// Calculate pagination info
-	hasMore := int64(filters.Offset+filters.Limit) < totalCount
-
 	response := &dto.TransactionListResponse{
 		Transactions: transactionDTOs,
-		Pagination: dto.PaginationInfo{
-			Total:   totalCount,
-			Limit:   filters.Limit,
-			Offset:  filters.Offset,
-			HasMore: hasMore,
-		},
+		Pagination:   pagination,
 	}
 
 	return response, nil
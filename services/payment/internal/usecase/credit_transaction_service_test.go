@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/usecase"
 )
@@ -26,11 +27,6 @@ func (m *MockCreditTransactionRepository) GetTransactions(ctx context.Context, f
 	return args.Get(0).([]model.CreditTransaction), args.Error(1)
 }
 
-func (m *MockCreditTransactionRepository) CountTransactions(ctx context.Context, filters dto.TransactionFilters) (int64, error) {
-	args := m.Called(ctx, filters)
-	return args.Get(0).(int64), args.Error(1)
-}
-
 func (m *MockCreditTransactionRepository) GetCreditBalance(ctx context.Context, universalID uuid.UUID, serviceProvider string) (*model.UserCreditBalance, error) {
 	args := m.Called(ctx, universalID, serviceProvider)
 	if args.Get(0) == nil {
@@ -74,12 +70,11 @@ func TestCreditTransactionService_GetUserTransactionHistory(t *testing.T) {
 
 		filters := dto.TransactionFilters{
 			UserID: universalID,
-			Limit:  20,
-			Offset: 0,
+			Limit:  21,
+			Sort:   dto.TransactionSortCreatedAt,
 		}
 
 		mockRepo.On("GetTransactions", ctx, filters).Return(transactions, nil)
-		mockRepo.On("CountTransactions", ctx, filters).Return(int64(2), nil)
 
 		// Execute
 		result, err := service.GetUserTransactionHistory(ctx, universalID, dto.TransactionFilters{})
@@ -91,8 +86,9 @@ func TestCreditTransactionService_GetUserTransactionHistory(t *testing.T) {
 		assert.Equal(t, "credit_usage", result.Transactions[0].TransactionType)
 		assert.Equal(t, "-10", result.Transactions[0].Amount)
 		assert.Equal(t, "90", result.Transactions[0].BalanceAfter)
-		assert.Equal(t, int64(2), result.Pagination.Total)
+		assert.Equal(t, 20, result.Pagination.Limit)
 		assert.False(t, result.Pagination.HasMore)
+		assert.Empty(t, result.Pagination.NextCursor)
 
 		mockRepo.AssertExpectations(t)
 	})
@@ -101,23 +97,40 @@ func TestCreditTransactionService_GetUserTransactionHistory(t *testing.T) {
 		mockRepo := new(MockCreditTransactionRepository)
 		service := usecase.NewCreditTransactionService(mockRepo, logger, model.ServiceProviderSemo)
 
+		start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+		transactions := make([]model.CreditTransaction, 3)
+		for i := range transactions {
+			transactions[i] = model.CreditTransaction{
+				ID:              int64(30 - i),
+				UniversalID:     universalID,
+				TransactionType: model.TransactionTypeCreditUsage,
+				Amount:          decimal.NewFromInt(1),
+				CreatedAt:       start.Add(-time.Duration(i) * time.Hour),
+			}
+		}
+
 		filters := dto.TransactionFilters{
 			UserID: universalID,
-			Limit:  10,
-			Offset: 0,
+			Limit:  3,
+			Sort:   dto.TransactionSortCreatedAt,
 		}
 
-		mockRepo.On("GetTransactions", ctx, filters).Return([]model.CreditTransaction{}, nil)
-		mockRepo.On("CountTransactions", ctx, filters).Return(int64(25), nil)
+		mockRepo.On("GetTransactions", ctx, filters).Return(transactions, nil)
 
 		result, err := service.GetUserTransactionHistory(ctx, universalID, dto.TransactionFilters{
-			Limit:  10,
-			Offset: 0,
+			Limit: 2,
 		})
 
 		assert.NoError(t, err)
+		assert.Len(t, result.Transactions, 2)
 		assert.True(t, result.Pagination.HasMore)
-		assert.Equal(t, int64(25), result.Pagination.Total)
+
+		// The cursor continues after the last returned transaction
+		cursor, err := entity.DecodeCursor(result.Pagination.NextCursor, dto.TransactionSortCreatedAt, true)
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(29), cursor.ID)
+			assert.Equal(t, "2025-03-01T08:00:00Z", cursor.Value)
+		}
 
 		mockRepo.AssertExpectations(t)
 	})
@@ -141,12 +154,11 @@ func TestCreditTransactionService_GetUserTransactionHistory(t *testing.T) {
 
 		filters := dto.TransactionFilters{
 			UserID: universalID,
-			Limit:  20,
-			Offset: 0,
+			Limit:  21,
+			Sort:   dto.TransactionSortCreatedAt,
 		}
 
 		mockRepo.On("GetTransactions", ctx, filters).Return(transactions, nil)
-		mockRepo.On("CountTransactions", ctx, filters).Return(int64(1), nil)
 
 		result, err := service.GetUserTransactionHistory(ctx, universalID, dto.TransactionFilters{})
 
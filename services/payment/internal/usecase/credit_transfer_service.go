@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	domainProvider "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// transferLimitWindow is the rolling window the daily transfer limits apply to
+const transferLimitWindow = 24 * time.Hour
+
+// maxTransferNoteLength bounds the note a sender can attach to a transfer
+const maxTransferNoteLength = 200
+
+// TransferRequest asks to move credits from one user or workspace to another
+type TransferRequest struct {
+	FromID          uuid.UUID
+	ToID            uuid.UUID
+	ActorID         *uuid.UUID
+	ServiceProvider string
+	Amount          decimal.Decimal
+	Note            string
+	IdempotencyKey  *uuid.UUID
+}
+
+// CreditTransferService moves credits between users within the configured limits
+type CreditTransferService struct {
+	creditRepo          repository.CreditRepository
+	paymentRepo         repository.PaymentRepository
+	customerMappingRepo repository.CustomerMappingRepository
+	workspaceRepo       repository.WorkspaceVerificationRepository
+	config              config.TransferConfig
+	logger              *zap.Logger
+	serviceProvider     string
+	now                 func() time.Time
+}
+
+// NewCreditTransferService creates a new CreditTransferService instance
+func NewCreditTransferService(
+	creditRepo repository.CreditRepository,
+	paymentRepo repository.PaymentRepository,
+	customerMappingRepo repository.CustomerMappingRepository,
+	workspaceRepo repository.WorkspaceVerificationRepository,
+	cfg config.TransferConfig,
+	logger *zap.Logger,
+	serviceProvider string,
+) *CreditTransferService {
+	return &CreditTransferService{
+		creditRepo:          creditRepo,
+		paymentRepo:         paymentRepo,
+		customerMappingRepo: customerMappingRepo,
+		workspaceRepo:       workspaceRepo,
+		config:              cfg,
+		logger:              logger,
+		serviceProvider:     serviceProvider,
+		now:                 time.Now,
+	}
+}
+
+// Transfer validates a transfer against the anti-abuse rules and moves the credits. Retrying with the
+// same idempotency key returns the original transfer.
+func (s *CreditTransferService) Transfer(ctx context.Context, req *TransferRequest) (*repository.CreditTransferResult, error) {
+	if req.FromID == req.ToID {
+		return nil, domainErrors.ErrSelfTransfer
+	}
+	if err := s.validateAmount(req.Amount); err != nil {
+		return nil, err
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > maxTransferNoteLength {
+		return nil, fmt.Errorf("note must be at most %d characters", maxTransferNoteLength)
+	}
+
+	// Free signup credits should not be farmed across accounts and pooled into one
+	if s.config.RequirePaidSender {
+		paid, err := s.paymentRepo.CountCompleted(ctx, req.FromID.String())
+		if err != nil {
+			return nil, err
+		}
+		if paid == 0 {
+			return nil, fmt.Errorf("%w: a completed payment is required before sending credits", domainErrors.ErrTransferNotAllowed)
+		}
+	}
+
+	exists, err := s.recipientExists(ctx, req.ToID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domainErrors.ErrTransferRecipientNotFound
+	}
+
+	provider := req.ServiceProvider
+	if provider == "" {
+		provider = s.serviceProvider
+	}
+
+	actorID := req.ActorID
+	if actorID != nil && *actorID == req.FromID {
+		actorID = nil
+	}
+
+	result, err := s.creditRepo.TransferCredits(ctx, &repository.CreditTransfer{
+		FromID:          req.FromID,
+		ToID:            req.ToID,
+		ServiceProvider: provider,
+		Amount:          req.Amount,
+		Note:            note,
+		ActorID:         actorID,
+		IdempotencyKey:  req.IdempotencyKey,
+		Limits: repository.TransferLimits{
+			Since:       s.now().Add(-transferLimitWindow),
+			MaxSent:     decimal.NewFromInt(s.config.DailySendLimit),
+			MaxSentN:    s.config.DailySendCount,
+			MaxReceived: decimal.NewFromInt(s.config.DailyReceiveLimit),
+		},
+	})
+	if err != nil {
+		s.logger.Warn("Credit transfer refused",
+			zap.String("from", req.FromID.String()),
+			zap.String("to", req.ToID.String()),
+			zap.String("amount", req.Amount.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// recipientExists reports whether credits can be sent to the ID: a user with a customer mapping at
+// any payment provider, or a workspace with members
+func (s *CreditTransferService) recipientExists(ctx context.Context, id uuid.UUID) (bool, error) {
+	for _, provider := range []domainProvider.ProviderType{domainProvider.ProviderTypeStripe, domainProvider.ProviderTypeToss} {
+		mapping, err := s.customerMappingRepo.GetByProviderAndUniversalID(ctx, string(provider), id.String())
+		if err != nil {
+			return false, fmt.Errorf("failed to look up recipient: %w", err)
+		}
+		if mapping != nil {
+			return true, nil
+		}
+	}
+
+	members, err := s.workspaceRepo.CountWorkspaceMembers(ctx, id.String())
+	if err != nil {
+		return false, fmt.Errorf("failed to look up recipient workspace: %w", err)
+	}
+	return members > 0, nil
+}
+
+// validateAmount checks the amount against the per-transfer bounds. Balances keep two decimals.
+func (s *CreditTransferService) validateAmount(amount decimal.Decimal) error {
+	if !amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", domainErrors.ErrInvalidTransferAmount)
+	}
+	if !amount.Equal(amount.Round(2)) {
+		return fmt.Errorf("%w: amount has more than two decimals", domainErrors.ErrInvalidTransferAmount)
+	}
+	if s.config.MinAmount > 0 && amount.LessThan(decimal.NewFromInt(s.config.MinAmount)) {
+		return fmt.Errorf("%w: minimum is %d credits", domainErrors.ErrInvalidTransferAmount, s.config.MinAmount)
+	}
+	if s.config.MaxAmount > 0 && amount.GreaterThan(decimal.NewFromInt(s.config.MaxAmount)) {
+		return fmt.Errorf("%w: maximum is %d credits", domainErrors.ErrInvalidTransferAmount, s.config.MaxAmount)
+	}
+	return nil
+}
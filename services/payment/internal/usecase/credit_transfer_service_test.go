@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// MockCustomerMappingRepository is a mock implementation of CustomerMappingRepository
+type MockCustomerMappingRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomerMappingRepository) Create(ctx context.Context, mapping *entity.CustomerMapping) error {
+	args := m.Called(ctx, mapping)
+	return args.Error(0)
+}
+
+func (m *MockCustomerMappingRepository) GetByProviderCustomerID(ctx context.Context, provider string, providerCustomerID string) (*entity.CustomerMapping, error) {
+	args := m.Called(ctx, provider, providerCustomerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CustomerMapping), args.Error(1)
+}
+
+func (m *MockCustomerMappingRepository) GetByProviderAndUniversalID(ctx context.Context, provider string, universalID string) (*entity.CustomerMapping, error) {
+	args := m.Called(ctx, provider, universalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CustomerMapping), args.Error(1)
+}
+
+func (m *MockCustomerMappingRepository) Update(ctx context.Context, mapping *entity.CustomerMapping) error {
+	args := m.Called(ctx, mapping)
+	return args.Error(0)
+}
+
+func TestCreditTransferService_Transfer(t *testing.T) {
+	sender := uuid.MustParse("7c1d9e24-0000-4000-8000-000000000001")
+	recipient := uuid.MustParse("7c1d9e24-0000-4000-8000-000000000002")
+	workspace := uuid.MustParse("7c1d9e24-0000-4000-8000-000000000003")
+	unknown := uuid.MustParse("7c1d9e24-0000-4000-8000-000000000004")
+	now := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	cfg := config.TransferConfig{
+		MinAmount:         1,
+		MaxAmount:         500,
+		DailySendLimit:    1000,
+		DailySendCount:    10,
+		DailyReceiveLimit: 2000,
+		RequirePaidSender: true,
+	}
+
+	tests := []struct {
+		name      string
+		to        uuid.UUID
+		amount    string
+		paid      int64
+		note      string
+		wantError error
+	}{
+		{name: "transfer within limits", to: recipient, amount: "25.50", paid: 1},
+		{name: "korean note of the maximum length", to: recipient, amount: "10", paid: 1, note: strings.Repeat("감", maxTransferNoteLength)},
+		{name: "self transfer", to: sender, amount: "10", paid: 1, wantError: domainErrors.ErrSelfTransfer},
+		{name: "above the maximum", to: recipient, amount: "501", paid: 1, wantError: domainErrors.ErrInvalidTransferAmount},
+		{name: "too many decimals", to: recipient, amount: "1.005", paid: 1, wantError: domainErrors.ErrInvalidTransferAmount},
+		{name: "sender never paid", to: recipient, amount: "10", paid: 0, wantError: domainErrors.ErrTransferNotAllowed},
+		{name: "transfer to a workspace", to: workspace, amount: "10", paid: 1},
+		{name: "unknown recipient", to: unknown, amount: "10", paid: 1, wantError: domainErrors.ErrTransferRecipientNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creditRepo := new(MockCreditRepository)
+			paymentRepo := new(MockPaymentRepository)
+			mappingRepo := new(MockCustomerMappingRepository)
+			workspaceRepo := new(MockWorkspaceVerificationRepository)
+			paymentRepo.On("CountCompleted", mock.Anything, sender.String()).Return(tt.paid, nil)
+			mappingRepo.On("GetByProviderAndUniversalID", mock.Anything, "stripe", recipient.String()).
+				Return(&entity.CustomerMapping{UniversalID: recipient.String()}, nil)
+			mappingRepo.On("GetByProviderAndUniversalID", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+			workspaceRepo.On("CountWorkspaceMembers", mock.Anything, workspace.String()).Return(3, nil)
+			workspaceRepo.On("CountWorkspaceMembers", mock.Anything, mock.Anything).Return(0, nil)
+			creditRepo.On("TransferCredits", mock.Anything, mock.MatchedBy(func(transfer *repository.CreditTransfer) bool {
+				return transfer.FromID == sender &&
+					transfer.ServiceProvider == "semo" &&
+					transfer.Limits.Since.Equal(now.Add(-24*time.Hour)) &&
+					transfer.Limits.MaxSent.Equal(decimal.NewFromInt(1000)) &&
+					transfer.Limits.MaxSentN == 10
+			})).Return(&repository.CreditTransferResult{TransferID: uuid.New()}, nil)
+
+			service := NewCreditTransferService(creditRepo, paymentRepo, mappingRepo, workspaceRepo, cfg, zap.NewNop(), "semo")
+			service.now = func() time.Time { return now }
+
+			_, err := service.Transfer(context.Background(), &TransferRequest{
+				FromID: sender,
+				ToID:   tt.to,
+				Amount: decimal.RequireFromString(tt.amount),
+				Note:   tt.note,
+			})
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+				creditRepo.AssertNotCalled(t, "TransferCredits", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			creditRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreditTransferService_Transfer_RejectsLongNote(t *testing.T) {
+	creditRepo := new(MockCreditRepository)
+	service := NewCreditTransferService(creditRepo, nil, nil, nil, config.TransferConfig{MaxAmount: 500}, zap.NewNop(), "semo")
+
+	_, err := service.Transfer(context.Background(), &TransferRequest{
+		FromID: uuid.New(),
+		ToID:   uuid.New(),
+		Amount: decimal.NewFromInt(10),
+		Note:   strings.Repeat("감", maxTransferNoteLength+1),
+	})
+
+	assert.ErrorContains(t, err, "note must be at most")
+	creditRepo.AssertNotCalled(t, "TransferCredits", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,343 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	dbRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/adapter/repository"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// DefaultEntitlementCacheTTL is how long resolved entitlements are reused before being resolved again
+const DefaultEntitlementCacheTTL = time.Minute
+
+// DefaultEntitlementCacheSize caps how many users' resolved entitlements are cached at once
+const DefaultEntitlementCacheSize = 10000
+
+type entitlementCacheEntry struct {
+	entitlements model.Entitlements
+	tiers        []string
+	expiresAt    time.Time
+}
+
+// EntitlementService resolves the features a user is entitled to, and their pricing tiers, from
+// their active or trialing subscription, running trials and completed plan purchases.
+// One instance is shared by the HTTP and gRPC servers so that invalidations reach both.
+type EntitlementService struct {
+	subscriptionRepo repository.SubscriptionRepository
+	paymentRepo      repository.PaymentRepository
+	trialRepo        repository.TrialRepository
+	planRepo         dbRepo.PlanRepository
+	ttl              time.Duration
+	maxEntries       int
+	now              func() time.Time
+	logger           *zap.Logger
+
+	mu    sync.Mutex
+	cache map[string]entitlementCacheEntry
+}
+
+// NewEntitlementService creates a new EntitlementService instance. A zero ttl uses DefaultEntitlementCacheTTL.
+func NewEntitlementService(
+	subscriptionRepo repository.SubscriptionRepository,
+	paymentRepo repository.PaymentRepository,
+	trialRepo repository.TrialRepository,
+	planRepo dbRepo.PlanRepository,
+	ttl time.Duration,
+	logger *zap.Logger,
+) *EntitlementService {
+	if ttl <= 0 {
+		ttl = DefaultEntitlementCacheTTL
+	}
+	return &EntitlementService{
+		subscriptionRepo: subscriptionRepo,
+		paymentRepo:      paymentRepo,
+		trialRepo:        trialRepo,
+		planRepo:         planRepo,
+		ttl:              ttl,
+		maxEntries:       DefaultEntitlementCacheSize,
+		now:              time.Now,
+		logger:           logger,
+		cache:            make(map[string]entitlementCacheEntry),
+	}
+}
+
+// GetEntitlements returns the entitlements of a user or workspace, using the cache when fresh
+func (s *EntitlementService) GetEntitlements(ctx context.Context, universalID string) (model.Entitlements, error) {
+	entry, err := s.load(ctx, universalID)
+	if err != nil {
+		return nil, err
+	}
+	return entry.entitlements, nil
+}
+
+// GetPlanTiers returns the pricing tiers of the plans a user or workspace currently holds
+func (s *EntitlementService) GetPlanTiers(ctx context.Context, universalID string) ([]string, error) {
+	entry, err := s.load(ctx, universalID)
+	if err != nil {
+		return nil, err
+	}
+	return entry.tiers, nil
+}
+
+// load returns the cached resolution for a user, resolving it again once expired
+func (s *EntitlementService) load(ctx context.Context, universalID string) (entitlementCacheEntry, error) {
+	s.mu.Lock()
+	entry, ok := s.cache[universalID]
+	s.mu.Unlock()
+	if ok && s.now().Before(entry.expiresAt) {
+		return entry, nil
+	}
+
+	plans, err := s.resolvePlans(ctx, universalID)
+	if err != nil {
+		return entitlementCacheEntry{}, err
+	}
+
+	entry = entitlementCacheEntry{
+		entitlements: make(model.Entitlements),
+		expiresAt:    s.now().Add(s.ttl),
+	}
+	for _, plan := range plans {
+		s.merge(entry.entitlements, plan)
+		if tier := plan.Tier(); tier != "" {
+			entry.tiers = append(entry.tiers, tier)
+		}
+	}
+
+	s.store(universalID, entry)
+
+	return entry, nil
+}
+
+// store caches a resolution, making room first by dropping expired entries and, if the cache is
+// still full, the entry closest to expiry
+func (s *EntitlementService) store(universalID string, entry entitlementCacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.cache[universalID]; !ok && len(s.cache) >= s.maxEntries {
+		now := s.now()
+		var oldestID string
+		var oldest time.Time
+		for id, cached := range s.cache {
+			if !now.Before(cached.expiresAt) {
+				delete(s.cache, id)
+				continue
+			}
+			if oldestID == "" || cached.expiresAt.Before(oldest) {
+				oldestID, oldest = id, cached.expiresAt
+			}
+		}
+		if len(s.cache) >= s.maxEntries {
+			delete(s.cache, oldestID)
+		}
+	}
+
+	s.cache[universalID] = entry
+}
+
+// CheckEntitlement returns the entitlement for a single feature. Features not granted by any plan
+// are returned disabled.
+func (s *EntitlementService) CheckEntitlement(ctx context.Context, universalID string, feature string) (*model.Entitlement, error) {
+	entitlements, err := s.GetEntitlements(ctx, universalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entitlement, ok := entitlements[feature]; ok {
+		return entitlement, nil
+	}
+	return &model.Entitlement{Feature: feature}, nil
+}
+
+// Invalidate drops the cached entitlements of a user or workspace, e.g. after a plan change.
+// It is a no-op on a nil service so write paths can call it unconditionally.
+func (s *EntitlementService) Invalidate(universalID string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	delete(s.cache, universalID)
+	s.mu.Unlock()
+}
+
+// resolvePlans returns every plan the user currently holds
+func (s *EntitlementService) resolvePlans(ctx context.Context, universalID string) ([]*model.PaymentPlan, error) {
+	var held []*model.PaymentPlan
+
+	sub, err := s.subscriptionRepo.GetActiveByUniversalID(ctx, universalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active subscription: %w", err)
+	}
+	seen := make(map[string]bool)
+	if sub != nil {
+		plan, err := s.subscriptionPlan(ctx, sub)
+		if err != nil {
+			return nil, err
+		}
+		if plan != nil {
+			seen[plan.ProviderPriceID] = true
+			held = append(held, plan)
+		}
+	}
+
+	plan, err := s.trialPlan(ctx, universalID)
+	if err != nil {
+		return nil, err
+	}
+	if plan != nil && !seen[plan.ProviderPriceID] {
+		seen[plan.ProviderPriceID] = true
+		held = append(held, plan)
+	}
+
+	purchases, err := s.paymentRepo.ListPlanPurchases(ctx, universalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plan purchases: %w", err)
+	}
+
+	for _, purchase := range purchases {
+		if seen[purchase.PlanID] {
+			continue
+		}
+
+		plan, err := s.planRepo.GetByPriceID(ctx, purchase.PlanID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get purchased plan: %w", err)
+		}
+		if plan == nil || !s.purchaseActive(plan, purchase.PaidAt) {
+			continue
+		}
+
+		seen[purchase.PlanID] = true
+		held = append(held, plan)
+	}
+
+	return held, nil
+}
+
+// subscriptionPlan returns the plan of the price a subscription is billed at. Subscriptions stored
+// before their price was recorded fall back to the product's price with the same billing interval.
+func (s *EntitlementService) subscriptionPlan(ctx context.Context, sub *entity.Subscription) (*model.PaymentPlan, error) {
+	if sub.PriceID != "" {
+		plan, err := s.planRepo.GetByPriceID(ctx, sub.PriceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subscription plan: %w", err)
+		}
+		if plan != nil {
+			return plan, nil
+		}
+	}
+	if sub.PlanID == nil {
+		return nil, nil
+	}
+
+	plans, err := s.planRepo.GetByProductID(ctx, *sub.PlanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription plan: %w", err)
+	}
+	if len(plans) == 1 {
+		return plans[0], nil
+	}
+	for _, plan := range plans {
+		interval, count := planInterval(plan)
+		if interval == sub.Interval && int64(count) == sub.IntervalCount {
+			return plan, nil
+		}
+	}
+
+	s.logger.Warn("No plan matches subscription price",
+		zap.String("subscription_id", sub.ID),
+		zap.String("price_id", sub.PriceID))
+	return nil, nil
+}
+
+// trialPlan returns the plan of the user's running trial, if any
+func (s *EntitlementService) trialPlan(ctx context.Context, universalID string) (*model.PaymentPlan, error) {
+	if s.trialRepo == nil {
+		return nil, nil
+	}
+	id, err := uuid.Parse(universalID)
+	if err != nil {
+		return nil, nil
+	}
+
+	trial, err := s.trialRepo.GetLatestByUniversalID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trial: %w", err)
+	}
+	if trial == nil || (trial.Status != model.TrialStatusActive && trial.Status != model.TrialStatusConverting) {
+		return nil, nil
+	}
+
+	plan, err := s.planRepo.GetByPriceID(ctx, trial.PlanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trial plan: %w", err)
+	}
+	return plan, nil
+}
+
+// purchaseActive reports whether a purchase still grants its plan. One-time purchases never lapse;
+// subscription plans paid directly (Toss billing) last for one billing interval from payment.
+func (s *EntitlementService) purchaseActive(plan *model.PaymentPlan, paidAt time.Time) bool {
+	if plan.Type == model.PlanTypeOneTime {
+		return true
+	}
+
+	interval, count := planInterval(plan)
+	var expiresAt time.Time
+	switch interval {
+	case "day":
+		expiresAt = paidAt.AddDate(0, 0, count)
+	case "week":
+		expiresAt = paidAt.AddDate(0, 0, 7*count)
+	case "year":
+		expiresAt = paidAt.AddDate(count, 0, 0)
+	default:
+		expiresAt = paidAt.AddDate(0, count, 0)
+	}
+
+	return s.now().Before(expiresAt)
+}
+
+func (s *EntitlementService) merge(entitlements model.Entitlements, plan *model.PaymentPlan) {
+	compiled, errs := model.CompileEntitlements(plan.Features, plan.ProviderPriceID)
+	for _, err := range errs {
+		s.logger.Warn("Skipping invalid plan entitlement",
+			zap.String("plan_id", plan.ProviderPriceID),
+			zap.Error(err))
+	}
+	entitlements.Merge(compiled)
+}
+
+// planInterval reads the billing interval stored under the plan's price features, defaulting to one month
+func planInterval(plan *model.PaymentPlan) (string, int) {
+	interval, count := "month", 1
+
+	price, ok := plan.Features["price"].(map[string]interface{})
+	if !ok {
+		return interval, count
+	}
+	if v, ok := price["interval"].(string); ok && v != "" {
+		interval = v
+	}
+	switch v := price["interval_count"].(type) {
+	case float64:
+		count = int(v)
+	case int:
+		count = v
+	case int64:
+		count = int(v)
+	}
+	if count < 1 {
+		count = 1
+	}
+
+	return interval, count
+}
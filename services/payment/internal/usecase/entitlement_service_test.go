@@ -0,0 +1,295 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// MockPaymentRepository is a mock implementation of PaymentRepository
+type MockPaymentRepository struct {
+	mock.Mock
+}
+
+func (m *MockPaymentRepository) Create(ctx context.Context, payment *entity.Payment) error {
+	args := m.Called(ctx, payment)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) GetByID(ctx context.Context, id string) (*entity.Payment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) ListByUniversalID(ctx context.Context, params *entity.PaymentListParams) ([]*entity.Payment, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetByTransactionID(ctx context.Context, transactionID string) (*entity.Payment, error) {
+	args := m.Called(ctx, transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) Update(ctx context.Context, payment *entity.Payment) error {
+	args := m.Called(ctx, payment)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) List(ctx context.Context, limit, offset int) ([]*entity.Payment, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) CreateOneTimePayment(ctx context.Context, payment *entity.Payment) error {
+	args := m.Called(ctx, payment)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) GetByOrderID(ctx context.Context, orderID string) (*entity.Payment, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) UpdatePaymentAfterConfirm(ctx context.Context, orderID string, updates map[string]interface{}) error {
+	args := m.Called(ctx, orderID, updates)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) UpdateCashReceipt(ctx context.Context, orderID string, receipt *entity.CashReceipt) error {
+	args := m.Called(ctx, orderID, receipt)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) ListPlanPurchases(ctx context.Context, universalID string) ([]*repository.PlanPurchase, error) {
+	args := m.Called(ctx, universalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.PlanPurchase), args.Error(1)
+}
+
+func (m *MockPaymentRepository) CountCompleted(ctx context.Context, universalID string) (int64, error) {
+	args := m.Called(ctx, universalID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestEntitlementService_GetEntitlements(t *testing.T) {
+	universalID := "0b5e8a8e-0000-4000-8000-000000000001"
+	productID := "prod_pro"
+	now := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	proPlan := &model.PaymentPlan{
+		ProviderPriceID:   "price_pro_monthly",
+		ProviderProductID: productID,
+		Type:              model.PlanTypeSubscription,
+		Features: model.Features{
+			"price": map[string]interface{}{"interval": "month", "interval_count": float64(1)},
+			"entitlements": map[string]interface{}{
+				"ux_report":          true,
+				"analyses_per_month": float64(10),
+			},
+		},
+	}
+	proYearly := &model.PaymentPlan{
+		ProviderPriceID:   "price_pro_yearly",
+		ProviderProductID: productID,
+		Type:              model.PlanTypeSubscription,
+		Features: model.Features{
+			"price": map[string]interface{}{"interval": "year", "interval_count": float64(1)},
+			"entitlements": map[string]interface{}{
+				"ux_report":          true,
+				"analyses_per_month": float64(120),
+			},
+		},
+	}
+	reportPack := &model.PaymentPlan{
+		ProviderPriceID: "toss_report_pack",
+		Type:            model.PlanTypeOneTime,
+		Features: model.Features{
+			"entitlements": map[string]interface{}{
+				"analyses_per_month": map[string]interface{}{"limit": float64(50)},
+				"benchmark":          map[string]interface{}{"enabled": true, "limit": float64(3)},
+			},
+		},
+	}
+	tossMonthly := &model.PaymentPlan{
+		ProviderPriceID: "toss_team_monthly",
+		Type:            model.PlanTypeSubscription,
+		Features: model.Features{
+			"price":        map[string]interface{}{"interval": "month", "interval_count": float64(1)},
+			"entitlements": map[string]interface{}{"team_dashboard": true},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		subscription *entity.Subscription
+		trial        *model.SubscriptionTrial
+		purchases    []*repository.PlanPurchase
+		want         map[string]*int64
+	}{
+		{
+			name:         "subscription without a recorded price matched by interval",
+			subscription: &entity.Subscription{ID: "sub_1", PlanID: &productID, Interval: "month", IntervalCount: 1},
+			want: map[string]*int64{
+				"ux_report":          nil,
+				"analyses_per_month": int64Ptr(10),
+			},
+		},
+		{
+			name:         "subscription matched by its price",
+			subscription: &entity.Subscription{ID: "sub_1", PlanID: &productID, PriceID: "price_pro_yearly"},
+			want: map[string]*int64{
+				"ux_report":          nil,
+				"analyses_per_month": int64Ptr(120),
+			},
+		},
+		{
+			name:  "running trial",
+			trial: &model.SubscriptionTrial{PlanID: "toss_team_monthly", Status: model.TrialStatusActive},
+			want: map[string]*int64{
+				"team_dashboard": nil,
+			},
+		},
+		{
+			name:  "canceled trial",
+			trial: &model.SubscriptionTrial{PlanID: "toss_team_monthly", Status: model.TrialStatusCanceled},
+			want:  map[string]*int64{},
+		},
+		{
+			name:         "one-time purchase raises the subscription limit",
+			subscription: &entity.Subscription{ID: "sub_1", PlanID: &productID, PriceID: "price_pro_monthly"},
+			purchases:    []*repository.PlanPurchase{{PlanID: "toss_report_pack", PaidAt: now.AddDate(-1, 0, 0)}},
+			want: map[string]*int64{
+				"ux_report":          nil,
+				"analyses_per_month": int64Ptr(50),
+				"benchmark":          int64Ptr(3),
+			},
+		},
+		{
+			name:      "billing payment within its interval",
+			purchases: []*repository.PlanPurchase{{PlanID: "toss_team_monthly", PaidAt: now.AddDate(0, 0, -10)}},
+			want: map[string]*int64{
+				"team_dashboard": nil,
+			},
+		},
+		{
+			name:      "billing payment past its interval",
+			purchases: []*repository.PlanPurchase{{PlanID: "toss_team_monthly", PaidAt: now.AddDate(0, -2, 0)}},
+			want:      map[string]*int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscriptionRepo := new(MockSubscriptionRepository)
+			paymentRepo := new(MockPaymentRepository)
+			trialRepo := new(MockTrialRepository)
+			planRepo := new(MockPlanRepository)
+
+			subscriptionRepo.On("GetActiveByUniversalID", mock.Anything, universalID).Return(tt.subscription, nil)
+			trialRepo.On("GetLatestByUniversalID", mock.Anything, uuid.MustParse(universalID)).Return(tt.trial, nil)
+			paymentRepo.On("ListPlanPurchases", mock.Anything, universalID).Return(tt.purchases, nil)
+			planRepo.On("GetByProductID", mock.Anything, productID).Return([]*model.PaymentPlan{proPlan, proYearly}, nil)
+			planRepo.On("GetByPriceID", mock.Anything, "price_pro_monthly").Return(proPlan, nil)
+			planRepo.On("GetByPriceID", mock.Anything, "price_pro_yearly").Return(proYearly, nil)
+			planRepo.On("GetByPriceID", mock.Anything, "toss_report_pack").Return(reportPack, nil)
+			planRepo.On("GetByPriceID", mock.Anything, "toss_team_monthly").Return(tossMonthly, nil)
+
+			service := NewEntitlementService(subscriptionRepo, paymentRepo, trialRepo, planRepo, 0, zap.NewNop())
+			service.now = func() time.Time { return now }
+
+			entitlements, err := service.GetEntitlements(context.Background(), universalID)
+			assert.NoError(t, err)
+			assert.Len(t, entitlements, len(tt.want))
+			for feature, limit := range tt.want {
+				entitlement, ok := entitlements[feature]
+				if assert.True(t, ok, feature) {
+					assert.True(t, entitlement.Allowed(), feature)
+					assert.Equal(t, limit, entitlement.Limit, feature)
+				}
+			}
+		})
+	}
+}
+
+func TestEntitlementService_CachesResolvedEntitlements(t *testing.T) {
+	universalID := "0b5e8a8e-0000-4000-8000-000000000002"
+
+	subscriptionRepo := new(MockSubscriptionRepository)
+	paymentRepo := new(MockPaymentRepository)
+	planRepo := new(MockPlanRepository)
+
+	subscriptionRepo.On("GetActiveByUniversalID", mock.Anything, universalID).Return(nil, nil)
+	paymentRepo.On("ListPlanPurchases", mock.Anything, universalID).Return(nil, nil)
+
+	service := NewEntitlementService(subscriptionRepo, paymentRepo, nil, planRepo, time.Minute, zap.NewNop())
+
+	entitlement, err := service.CheckEntitlement(context.Background(), universalID, "ux_report")
+	assert.NoError(t, err)
+	assert.False(t, entitlement.Allowed())
+
+	_, err = service.GetEntitlements(context.Background(), universalID)
+	assert.NoError(t, err)
+	subscriptionRepo.AssertNumberOfCalls(t, "GetActiveByUniversalID", 1)
+
+	service.Invalidate(universalID)
+	_, err = service.GetEntitlements(context.Background(), universalID)
+	assert.NoError(t, err)
+	subscriptionRepo.AssertNumberOfCalls(t, "GetActiveByUniversalID", 2)
+}
+
+func TestEntitlementService_BoundsCache(t *testing.T) {
+	subscriptionRepo := new(MockSubscriptionRepository)
+	paymentRepo := new(MockPaymentRepository)
+	subscriptionRepo.On("GetActiveByUniversalID", mock.Anything, mock.Anything).Return(nil, nil)
+	paymentRepo.On("ListPlanPurchases", mock.Anything, mock.Anything).Return(nil, nil)
+
+	now := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	service := NewEntitlementService(subscriptionRepo, paymentRepo, nil, new(MockPlanRepository), time.Minute, zap.NewNop())
+	service.maxEntries = 2
+	service.now = func() time.Time { return now }
+
+	for _, id := range []string{"user-1", "user-2", "user-3"} {
+		_, err := service.GetEntitlements(context.Background(), id)
+		assert.NoError(t, err)
+		now = now.Add(time.Second)
+	}
+
+	assert.Len(t, service.cache, 2)
+	assert.NotContains(t, service.cache, "user-1")
+	assert.Contains(t, service.cache, "user-3")
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
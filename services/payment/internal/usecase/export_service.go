@@ -0,0 +1,422 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+)
+
+// ExportDataset names the records an export contains
+type ExportDataset string
+
+// Export datasets
+const (
+	ExportDatasetPayments           ExportDataset = "payments"
+	ExportDatasetRefunds            ExportDataset = "refunds"
+	ExportDatasetCreditTransactions ExportDataset = "credit_transactions"
+)
+
+// ExportFormat is the file format of an export
+type ExportFormat string
+
+// Export formats
+const (
+	ExportFormatCSV   ExportFormat = "csv"
+	ExportFormatJSONL ExportFormat = "jsonl"
+)
+
+// MaxExportDays bounds the date range of a single export
+const MaxExportDays = 366
+
+// exportFlushRows is how many rows are buffered before they are flushed to the writer
+const exportFlushRows = 500
+
+// ExportLocation is the timezone export dates are cut in and timestamps are written in
+var ExportLocation = model.UsageLocation
+
+// ExportRequest selects the records of an export
+type ExportRequest struct {
+	Dataset         ExportDataset
+	Format          ExportFormat
+	From            time.Time // First day exported, in ExportLocation
+	To              time.Time // Last day exported, inclusive
+	ServiceProvider string    // Blank exports every service provider
+}
+
+// Validate checks the dataset, format and date range of the request
+func (r ExportRequest) Validate() error {
+	if _, ok := exportColumnNames[r.Dataset]; !ok {
+		return domainErrors.ErrInvalidExportDataset
+	}
+	if r.Format != ExportFormatCSV && r.Format != ExportFormatJSONL {
+		return domainErrors.ErrInvalidExportFormat
+	}
+	if r.From.IsZero() || r.To.IsZero() || r.To.Before(r.From) || r.To.Sub(r.From) >= MaxExportDays*24*time.Hour {
+		return domainErrors.ErrInvalidExportRange
+	}
+	return nil
+}
+
+// Filename returns the file name of the export, e.g. "payments-20250301-20250331.csv"
+func (r ExportRequest) Filename() string {
+	return fmt.Sprintf("%s-%s-%s.%s", r.Dataset,
+		r.From.In(ExportLocation).Format("20060102"), r.To.In(ExportLocation).Format("20060102"), r.Format)
+}
+
+// ContentType returns the MIME type of the export
+func (r ExportRequest) ContentType() string {
+	if r.Format == ExportFormatJSONL {
+		return "application/x-ndjson; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// filters converts the request's calendar days to the exported time range
+func (r ExportRequest) filters() dto.ExportFilters {
+	from := r.From.In(ExportLocation)
+	to := r.To.In(ExportLocation)
+	return dto.ExportFilters{
+		From:            time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, ExportLocation),
+		To:              time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, ExportLocation),
+		ServiceProvider: r.ServiceProvider,
+	}
+}
+
+// exportColumn is one column of an exported dataset. Values are strings, int64s, json.Numbers
+// (amounts) or nil (empty).
+type exportColumn[T any] struct {
+	name  string
+	value func(T) interface{}
+}
+
+// Columns of each dataset. Spreadsheets depend on their order: add new columns at the end.
+var (
+	paymentExportColumns = []exportColumn[*model.Payment]{
+		{"payment_id", func(p *model.Payment) interface{} { return p.ID }},
+		{"order_id", func(p *model.Payment) interface{} { return stringValue(p.ProviderInvoiceID) }},
+		{"payment_intent_id", func(p *model.Payment) interface{} { return stringValue(p.ProviderPaymentIntentID) }},
+		{"universal_id", func(p *model.Payment) interface{} { return p.UniversalID.String() }},
+		{"service_provider", func(p *model.Payment) interface{} { return paymentMetadata(p, "service_provider") }},
+		{"pg_provider", func(p *model.Payment) interface{} { return p.PgProvider }},
+		{"plan_id", func(p *model.Payment) interface{} { return paymentMetadata(p, "plan_id") }},
+		{"status", func(p *model.Payment) interface{} { return p.Status }},
+		{"currency", func(p *model.Payment) interface{} { return p.Currency }},
+		{"amount", func(p *model.Payment) interface{} { return minorUnitsValue(int64(p.AmountCents), p.Currency) }},
+		{"payment_method", func(p *model.Payment) interface{} { return stringValue(p.PaymentMethodType) }},
+		{"created_at", func(p *model.Payment) interface{} { return exportTime(p.CreatedAt) }},
+		{"paid_at", func(p *model.Payment) interface{} { return exportTimePtr(p.PaidAt) }},
+	}
+
+	refundExportColumns = []exportColumn[*model.Payment]{
+		{"payment_id", func(p *model.Payment) interface{} { return p.ID }},
+		{"order_id", func(p *model.Payment) interface{} { return stringValue(p.ProviderInvoiceID) }},
+		{"payment_intent_id", func(p *model.Payment) interface{} { return stringValue(p.ProviderPaymentIntentID) }},
+		{"universal_id", func(p *model.Payment) interface{} { return p.UniversalID.String() }},
+		{"service_provider", func(p *model.Payment) interface{} { return paymentMetadata(p, "service_provider") }},
+		{"pg_provider", func(p *model.Payment) interface{} { return p.PgProvider }},
+		{"currency", func(p *model.Payment) interface{} { return p.Currency }},
+		{"payment_amount", func(p *model.Payment) interface{} { return minorUnitsValue(int64(p.AmountCents), p.Currency) }},
+		{"refunded_amount", func(p *model.Payment) interface{} { return minorUnitsValue(refundedAmount(p), p.Currency) }},
+		{"reason", func(p *model.Payment) interface{} { return stringValue(p.FailureMessage) }},
+		{"paid_at", func(p *model.Payment) interface{} { return exportTimePtr(p.PaidAt) }},
+		{"refunded_at", func(p *model.Payment) interface{} { return exportTime(p.UpdatedAt) }},
+	}
+
+	creditTransactionExportColumns = []exportColumn[*model.CreditTransaction]{
+		{"transaction_id", func(tx *model.CreditTransaction) interface{} { return tx.ID }},
+		{"universal_id", func(tx *model.CreditTransaction) interface{} { return tx.UniversalID.String() }},
+		{"actor_id", func(tx *model.CreditTransaction) interface{} { return uuidValue(tx.ActorID) }},
+		{"service_provider", func(tx *model.CreditTransaction) interface{} { return stringValue(tx.ServiceProvider) }},
+		{"transaction_type", func(tx *model.CreditTransaction) interface{} { return string(tx.TransactionType) }},
+		{"feature_name", func(tx *model.CreditTransaction) interface{} { return stringValue(tx.FeatureName) }},
+		{"amount", func(tx *model.CreditTransaction) interface{} { return json.Number(tx.Amount.StringFixed(2)) }},
+		{"balance_after", func(tx *model.CreditTransaction) interface{} { return json.Number(tx.BalanceAfter.StringFixed(2)) }},
+		{"description", func(tx *model.CreditTransaction) interface{} { return tx.Description }},
+		{"reference_id", func(tx *model.CreditTransaction) interface{} { return stringValue(tx.ReferenceID) }},
+		{"transfer_id", func(tx *model.CreditTransaction) interface{} { return uuidValue(tx.TransferID) }},
+		{"counterparty_id", func(tx *model.CreditTransaction) interface{} { return uuidValue(tx.CounterpartyID) }},
+		{"created_at", func(tx *model.CreditTransaction) interface{} { return exportTime(tx.CreatedAt) }},
+	}
+
+	exportColumnNames = map[ExportDataset][]string{
+		ExportDatasetPayments:           columnNames(paymentExportColumns),
+		ExportDatasetRefunds:            columnNames(refundExportColumns),
+		ExportDatasetCreditTransactions: columnNames(creditTransactionExportColumns),
+	}
+)
+
+// ExportColumns returns the column names of a dataset, in output order
+func ExportColumns(dataset ExportDataset) []string {
+	return exportColumnNames[dataset]
+}
+
+// ExportService streams payments, refunds and credit transactions to finance exports
+type ExportService struct {
+	exportRepo repository.ExportRepository
+	logger     *zap.Logger
+}
+
+// NewExportService creates a new ExportService instance
+func NewExportService(exportRepo repository.ExportRepository, logger *zap.Logger) *ExportService {
+	return &ExportService{
+		exportRepo: exportRepo,
+		logger:     logger,
+	}
+}
+
+// Export writes the records selected by req to w as they are read, flushing w periodically when it
+// is an http.Flusher. It returns the number of records written.
+func (s *ExportService) Export(ctx context.Context, w io.Writer, req ExportRequest) (int64, error) {
+	if err := req.Validate(); err != nil {
+		return 0, err
+	}
+
+	encoder := newExportEncoder(w, req.Format, ExportColumns(req.Dataset))
+	if err := encoder.writeHeader(); err != nil {
+		return 0, fmt.Errorf("failed to write export header: %w", err)
+	}
+
+	var rows int64
+	write := func(values []interface{}) error {
+		if err := encoder.writeRow(values); err != nil {
+			return fmt.Errorf("failed to write export row: %w", err)
+		}
+		rows++
+		if rows%exportFlushRows == 0 {
+			return encoder.flush()
+		}
+		return nil
+	}
+
+	filters := req.filters()
+	var err error
+	switch req.Dataset {
+	case ExportDatasetPayments:
+		err = s.exportRepo.StreamPayments(ctx, filters, func(p *model.Payment) error {
+			return write(rowValues(paymentExportColumns, p))
+		})
+	case ExportDatasetRefunds:
+		err = s.exportRepo.StreamRefunds(ctx, filters, func(p *model.Payment) error {
+			return write(rowValues(refundExportColumns, p))
+		})
+	case ExportDatasetCreditTransactions:
+		err = s.exportRepo.StreamCreditTransactions(ctx, filters, func(tx *model.CreditTransaction) error {
+			return write(rowValues(creditTransactionExportColumns, tx))
+		})
+	}
+	if err != nil {
+		s.logger.Error("Export failed",
+			zap.String("dataset", string(req.Dataset)),
+			zap.Int64("rows_written", rows),
+			zap.Error(err))
+		return rows, err
+	}
+
+	if err := encoder.flush(); err != nil {
+		return rows, err
+	}
+
+	s.logger.Info("Export completed",
+		zap.String("dataset", string(req.Dataset)),
+		zap.String("format", string(req.Format)),
+		zap.String("service_provider", req.ServiceProvider),
+		zap.Time("from", filters.From),
+		zap.Time("to", filters.To),
+		zap.Int64("rows", rows))
+
+	return rows, nil
+}
+
+// exportEncoder writes rows in an export format
+type exportEncoder interface {
+	writeHeader() error
+	writeRow(values []interface{}) error
+	flush() error
+}
+
+func newExportEncoder(w io.Writer, format ExportFormat, columns []string) exportEncoder {
+	if format == ExportFormatJSONL {
+		return &jsonlExportEncoder{w: w, buf: bufio.NewWriter(w), columns: columns}
+	}
+	return &csvExportEncoder{w: w, writer: csv.NewWriter(w), columns: columns}
+}
+
+// csvExportEncoder writes a header row followed by one record per row
+type csvExportEncoder struct {
+	w       io.Writer
+	writer  *csv.Writer
+	columns []string
+}
+
+func (e *csvExportEncoder) writeHeader() error {
+	return e.writer.Write(e.columns)
+}
+
+func (e *csvExportEncoder) writeRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			record[i] = v
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		case json.Number:
+			record[i] = string(v)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return e.writer.Write(record)
+}
+
+func (e *csvExportEncoder) flush() error {
+	e.writer.Flush()
+	if err := e.writer.Error(); err != nil {
+		return err
+	}
+	flushWriter(e.w)
+	return nil
+}
+
+// jsonlExportEncoder writes one JSON object per line with keys in column order
+type jsonlExportEncoder struct {
+	w       io.Writer
+	buf     *bufio.Writer
+	columns []string
+}
+
+func (e *jsonlExportEncoder) writeHeader() error {
+	return nil
+}
+
+func (e *jsonlExportEncoder) writeRow(values []interface{}) error {
+	e.buf.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(e.columns[i])
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		e.buf.Write(key)
+		e.buf.WriteByte(':')
+		e.buf.Write(encoded)
+	}
+	_, err := e.buf.WriteString("}\n")
+	return err
+}
+
+func (e *jsonlExportEncoder) flush() error {
+	if err := e.buf.Flush(); err != nil {
+		return err
+	}
+	flushWriter(e.w)
+	return nil
+}
+
+// flushWriter pushes buffered output of w, e.g. an HTTP response, to its destination
+func flushWriter(w io.Writer) {
+	if flusher, ok := w.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+}
+
+func columnNames[T any](columns []exportColumn[T]) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.name
+	}
+	return names
+}
+
+func rowValues[T any](columns []exportColumn[T], record T) []interface{} {
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = column.value(record)
+	}
+	return values
+}
+
+// exportTime formats t in ExportLocation, e.g. "2025-03-01T09:30:00+09:00"
+func exportTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.In(ExportLocation).Format(time.RFC3339)
+}
+
+func exportTimePtr(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return exportTime(*t)
+}
+
+func stringValue(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func uuidValue(id *uuid.UUID) interface{} {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
+
+// minorUnitsValue converts an amount in the smallest currency unit to a decimal amount, e.g. 990 USD to 9.90
+func minorUnitsValue(amount int64, currency string) interface{} {
+	decimals := int32(model.CurrencyDecimals(currency))
+	return json.Number(decimal.New(amount, -decimals).StringFixed(decimals))
+}
+
+// paymentMetadata reads a string from the payment's metadata, or from the Toss payment object's
+// metadata that replaces it on refund
+func paymentMetadata(p *model.Payment, key string) interface{} {
+	if value, ok := p.ProviderPaymentData[key].(string); ok && value != "" {
+		return value
+	}
+	if nested, ok := p.ProviderPaymentData["metadata"].(map[string]interface{}); ok {
+		if value, ok := nested[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return nil
+}
+
+// refundedAmount sums the cancellations of a refunded Toss payment; other refunds are full refunds
+func refundedAmount(p *model.Payment) int64 {
+	cancels, ok := p.ProviderPaymentData["cancels"].([]interface{})
+	if !ok || len(cancels) == 0 {
+		return int64(p.AmountCents)
+	}
+
+	var total int64
+	for _, cancel := range cancels {
+		if entry, ok := cancel.(map[string]interface{}); ok {
+			if amount, ok := entry["cancelAmount"].(float64); ok {
+				total += int64(amount)
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// fakeExportRepository streams fixed records and records the filters it was called with
+type fakeExportRepository struct {
+	payments     []*model.Payment
+	transactions []*model.CreditTransaction
+	filters      dto.ExportFilters
+}
+
+func (r *fakeExportRepository) StreamPayments(ctx context.Context, filters dto.ExportFilters, fn func(*model.Payment) error) error {
+	r.filters = filters
+	for _, payment := range r.payments {
+		if err := fn(payment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fakeExportRepository) StreamRefunds(ctx context.Context, filters dto.ExportFilters, fn func(*model.Payment) error) error {
+	return r.StreamPayments(ctx, filters, fn)
+}
+
+func (r *fakeExportRepository) StreamCreditTransactions(ctx context.Context, filters dto.ExportFilters, fn func(*model.CreditTransaction) error) error {
+	r.filters = filters
+	for _, tx := range r.transactions {
+		if err := fn(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportService_Export(t *testing.T) {
+	universalID := uuid.MustParse("5f0c3c1e-8d7a-4a53-9a51-0f1b2c3d4e5f")
+	orderID := "order_1"
+	method := "card"
+	paidAt := time.Date(2025, 3, 31, 15, 30, 0, 0, time.UTC) // 2025-04-01 00:30 KST
+	repo := &fakeExportRepository{
+		payments: []*model.Payment{
+			{
+				ID:                  7,
+				UniversalID:         universalID,
+				ProviderInvoiceID:   &orderID,
+				AmountCents:         9900,
+				Currency:            "KRW",
+				PgProvider:          "toss",
+				Status:              "completed",
+				PaymentMethodType:   &method,
+				ProviderPaymentData: model.JSONB{"service_provider": "semo", "plan_id": "toss_pro_monthly"},
+				CreatedAt:           paidAt.Add(-time.Minute),
+				PaidAt:              &paidAt,
+			},
+			{
+				ID:          8,
+				UniversalID: universalID,
+				AmountCents: 790,
+				Currency:    "USD",
+				PgProvider:  "stripe",
+				Status:      "completed",
+				CreatedAt:   paidAt,
+			},
+		},
+		transactions: []*model.CreditTransaction{
+			{
+				ID:              3,
+				UniversalID:     universalID,
+				TransactionType: model.TransactionTypeCreditUsage,
+				Amount:          decimal.NewFromInt(5),
+				BalanceAfter:    decimal.RequireFromString("95.5"),
+				Description:     "Image, \"HD\"",
+				CreatedAt:       paidAt,
+			},
+		},
+	}
+	service := NewExportService(repo, zap.NewNop())
+	march := func(dataset ExportDataset, format ExportFormat) ExportRequest {
+		return ExportRequest{
+			Dataset: dataset,
+			Format:  format,
+			From:    time.Date(2025, 3, 1, 0, 0, 0, 0, ExportLocation),
+			To:      time.Date(2025, 3, 31, 0, 0, 0, 0, ExportLocation),
+		}
+	}
+
+	t.Run("payments as csv in KST", func(t *testing.T) {
+		var buf bytes.Buffer
+		rows, err := service.Export(context.Background(), &buf, march(ExportDatasetPayments, ExportFormatCSV))
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), rows)
+		assert.Equal(t, "payment_id,order_id,payment_intent_id,universal_id,service_provider,pg_provider,plan_id,status,currency,amount,payment_method,created_at,paid_at\n"+
+			"7,order_1,,5f0c3c1e-8d7a-4a53-9a51-0f1b2c3d4e5f,semo,toss,toss_pro_monthly,completed,KRW,9900,card,2025-04-01T00:29:00+09:00,2025-04-01T00:30:00+09:00\n"+
+			"8,,,5f0c3c1e-8d7a-4a53-9a51-0f1b2c3d4e5f,,stripe,,completed,USD,7.90,,2025-04-01T00:30:00+09:00,\n",
+			buf.String())
+
+		// The last day is included up to midnight KST
+		assert.Equal(t, time.Date(2025, 2, 28, 15, 0, 0, 0, time.UTC), repo.filters.From.UTC())
+		assert.Equal(t, time.Date(2025, 3, 31, 15, 0, 0, 0, time.UTC), repo.filters.To.UTC())
+	})
+
+	t.Run("credit transactions as jsonl keep column order", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := service.Export(context.Background(), &buf, march(ExportDatasetCreditTransactions, ExportFormatJSONL))
+
+		require.NoError(t, err)
+		assert.Equal(t, `{"transaction_id":3,"universal_id":"5f0c3c1e-8d7a-4a53-9a51-0f1b2c3d4e5f","actor_id":null,"service_provider":null,`+
+			`"transaction_type":"credit_usage","feature_name":null,"amount":5.00,"balance_after":95.50,"description":"Image, \"HD\"",`+
+			`"reference_id":null,"transfer_id":null,"counterparty_id":null,"created_at":"2025-04-01T00:30:00+09:00"}`+"\n",
+			buf.String())
+	})
+
+	t.Run("invalid requests are rejected", func(t *testing.T) {
+		tooLong := march(ExportDatasetPayments, ExportFormatCSV)
+		tooLong.To = tooLong.From.AddDate(1, 1, 0)
+		unknown := march("invoices", ExportFormatCSV)
+
+		for req, want := range map[*ExportRequest]error{
+			&tooLong: domainErrors.ErrInvalidExportRange,
+			&unknown: domainErrors.ErrInvalidExportDataset,
+		} {
+			_, err := service.Export(context.Background(), &bytes.Buffer{}, *req)
+			assert.True(t, errors.Is(err, want), "got %v, want %v", err, want)
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// PriceQuote is the credit cost of one use of a feature under the catalog
+type PriceQuote struct {
+	FeatureName     string          `json:"feature_name"`
+	ServiceProvider string          `json:"service_provider"`
+	Credits         decimal.Decimal `json:"credits"`
+	Unit            string          `json:"unit,omitempty"`
+	Units           int64           `json:"units,omitempty"`
+	PlanTier        string          `json:"plan_tier,omitempty"`
+	PriceID         int64           `json:"price_id"`
+	PriceVersion    int             `json:"price_version"`
+}
+
+// FeaturePricingService prices credit consumption from the feature price catalog
+type FeaturePricingService struct {
+	priceRepo          repository.FeaturePriceRepository
+	entitlementService *EntitlementService
+	logger             *zap.Logger
+	now                func() time.Time
+}
+
+// NewFeaturePricingService creates a new FeaturePricingService instance
+func NewFeaturePricingService(priceRepo repository.FeaturePriceRepository, entitlementService *EntitlementService, logger *zap.Logger) *FeaturePricingService {
+	return &FeaturePricingService{
+		priceRepo:          priceRepo,
+		entitlementService: entitlementService,
+		logger:             logger,
+		now:                time.Now,
+	}
+}
+
+// Quote returns the credits a use of the feature costs the user, given its usage metadata.
+// The price specific to the service provider wins over the catch-all one, and a price for one of
+// the user's plan tiers over the default; among the user's tiers the cheapest applies.
+func (s *FeaturePricingService) Quote(ctx context.Context, universalID string, serviceProvider string, featureName string, usageMetadata map[string]interface{}) (*PriceQuote, error) {
+	tiers, err := s.entitlementService.GetPlanTiers(ctx, universalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve plan tiers: %w", err)
+	}
+
+	prices, err := s.priceRepo.ListEffective(ctx, serviceProvider, featureName, tiers, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	bestRank := -1
+	for _, price := range prices {
+		if rank := priceRank(price); rank > bestRank {
+			bestRank = rank
+		}
+	}
+
+	var best *PriceQuote
+	for _, price := range prices {
+		if priceRank(price) != bestRank {
+			continue
+		}
+
+		units, err := priceUnits(price, usageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		quote := &PriceQuote{
+			FeatureName:     featureName,
+			ServiceProvider: serviceProvider,
+			Credits:         price.Cost(units),
+			Unit:            price.Unit,
+			Units:           units,
+			PlanTier:        price.PlanTier,
+			PriceID:         price.ID,
+			PriceVersion:    price.Version,
+		}
+		if best == nil || quote.Credits.LessThan(best.Credits) {
+			best = quote
+		}
+	}
+
+	if best == nil {
+		return nil, domainErrors.ErrFeaturePriceNotFound
+	}
+	return best, nil
+}
+
+// ListPrices lists catalog entries; when at is set only the versions effective then
+func (s *FeaturePricingService) ListPrices(ctx context.Context, serviceProvider string, featureName string, at *time.Time) ([]*model.FeaturePrice, error) {
+	return s.priceRepo.List(ctx, serviceProvider, featureName, at)
+}
+
+// CreatePrice validates and adds a new price version. A zero EffectiveFrom takes effect immediately.
+func (s *FeaturePricingService) CreatePrice(ctx context.Context, price *model.FeaturePrice) error {
+	if price.FeatureName == "" {
+		return fmt.Errorf("%w: feature_name is required", domainErrors.ErrInvalidFeaturePrice)
+	}
+	if price.BaseCredits.IsNegative() || price.UnitCredits.IsNegative() || price.MinCredits.IsNegative() {
+		return fmt.Errorf("%w: credits must not be negative", domainErrors.ErrInvalidFeaturePrice)
+	}
+	if price.UnitSize < 1 {
+		price.UnitSize = 1
+	}
+	if price.Unit == "" && !price.UnitCredits.IsZero() {
+		return fmt.Errorf("%w: unit_credits requires a unit", domainErrors.ErrInvalidFeaturePrice)
+	}
+	if price.EffectiveFrom.IsZero() {
+		price.EffectiveFrom = s.now()
+	}
+	if price.EffectiveTo != nil && !price.EffectiveTo.After(price.EffectiveFrom) {
+		return fmt.Errorf("%w: effective_to must be after effective_from", domainErrors.ErrInvalidFeaturePrice)
+	}
+
+	price.ID = 0
+	price.Version = 0
+	return s.priceRepo.CreateVersion(ctx, price)
+}
+
+// priceRank orders prices by specificity: service provider first, then plan tier
+func priceRank(price *model.FeaturePrice) int {
+	rank := 0
+	if price.ServiceProvider != "" {
+		rank += 2
+	}
+	if price.PlanTier != "" {
+		rank++
+	}
+	return rank
+}
+
+// priceUnits reads the units a price is charged by from the usage metadata
+func priceUnits(price *model.FeaturePrice, usageMetadata map[string]interface{}) (int64, error) {
+	if price.Unit == "" {
+		return 0, nil
+	}
+
+	value, ok := usageMetadata[price.Unit]
+	if !ok || value == nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrFeaturePriceUnitsMissing, price.Unit)
+	}
+
+	var units float64
+	switch v := value.(type) {
+	case float64:
+		units = v
+	case int:
+		units = float64(v)
+	case int64:
+		units = float64(v)
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s is not a number", domainErrors.ErrFeaturePriceUnitsMissing, price.Unit)
+		}
+		units = f
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s is not a number", domainErrors.ErrFeaturePriceUnitsMissing, price.Unit)
+		}
+		units = f
+	default:
+		return 0, fmt.Errorf("%w: %s is not a number", domainErrors.ErrFeaturePriceUnitsMissing, price.Unit)
+	}
+
+	if units < 0 || math.IsNaN(units) || math.IsInf(units, 0) {
+		return 0, fmt.Errorf("%w: %s must not be negative", domainErrors.ErrFeaturePriceUnitsMissing, price.Unit)
+	}
+	// Partial units are charged as a whole unit
+	return int64(math.Ceil(units)), nil
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// MockFeaturePriceRepository is a mock implementation of FeaturePriceRepository
+type MockFeaturePriceRepository struct {
+	mock.Mock
+}
+
+func (m *MockFeaturePriceRepository) ListEffective(ctx context.Context, serviceProvider string, featureName string, tiers []string, at time.Time) ([]*model.FeaturePrice, error) {
+	args := m.Called(ctx, serviceProvider, featureName, tiers, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.FeaturePrice), args.Error(1)
+}
+
+func (m *MockFeaturePriceRepository) List(ctx context.Context, serviceProvider string, featureName string, at *time.Time) ([]*model.FeaturePrice, error) {
+	args := m.Called(ctx, serviceProvider, featureName, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.FeaturePrice), args.Error(1)
+}
+
+func (m *MockFeaturePriceRepository) CreateVersion(ctx context.Context, price *model.FeaturePrice) error {
+	args := m.Called(ctx, price)
+	return args.Error(0)
+}
+
+func TestFeaturePrice_Cost(t *testing.T) {
+	tests := []struct {
+		name  string
+		price model.FeaturePrice
+		units int64
+		want  string
+	}{
+		{"flat per call", model.FeaturePrice{BaseCredits: decimal.NewFromInt(5)}, 0, "5"},
+		{"started blocks are charged", model.FeaturePrice{Unit: "tokens", UnitSize: 1000, UnitCredits: decimal.NewFromFloat(0.5)}, 2500, "1.5"},
+		{"base plus units", model.FeaturePrice{Unit: "pages", UnitSize: 1, BaseCredits: decimal.NewFromInt(1), UnitCredits: decimal.NewFromInt(2)}, 3, "7"},
+		{"minimum applies", model.FeaturePrice{Unit: "tokens", UnitSize: 1000, UnitCredits: decimal.NewFromFloat(0.1), MinCredits: decimal.NewFromInt(1)}, 10, "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.price.Cost(tt.units).String())
+		})
+	}
+}
+
+func TestFeaturePricingService_Quote(t *testing.T) {
+	universalID := "9d2f4c61-0000-4000-8000-000000000001"
+	productID := "prod_pro"
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	defaultPrice := &model.FeaturePrice{ID: 1, FeatureName: "summarization", Unit: "tokens", UnitSize: 1000, UnitCredits: decimal.NewFromInt(2), Version: 3}
+	proPrice := &model.FeaturePrice{ID: 2, FeatureName: "summarization", PlanTier: "pro", Unit: "tokens", UnitSize: 1000, UnitCredits: decimal.NewFromInt(1), Version: 1}
+	semoPrice := &model.FeaturePrice{ID: 3, ServiceProvider: "semo", FeatureName: "summarization", BaseCredits: decimal.NewFromInt(4), Version: 2}
+
+	tests := []struct {
+		name        string
+		prices      []*model.FeaturePrice
+		metadata    map[string]interface{}
+		wantCredits string
+		wantPriceID int64
+		wantError   error
+	}{
+		{
+			name:        "tier price wins over the default",
+			prices:      []*model.FeaturePrice{defaultPrice, proPrice},
+			metadata:    map[string]interface{}{"tokens": float64(1500)},
+			wantCredits: "2",
+			wantPriceID: 2,
+		},
+		{
+			name:        "service provider price wins over tier price",
+			prices:      []*model.FeaturePrice{defaultPrice, proPrice, semoPrice},
+			metadata:    map[string]interface{}{"tokens": float64(1500)},
+			wantCredits: "4",
+			wantPriceID: 3,
+		},
+		{
+			name:        "units given as a string",
+			prices:      []*model.FeaturePrice{defaultPrice},
+			metadata:    map[string]interface{}{"tokens": "999"},
+			wantCredits: "2",
+			wantPriceID: 1,
+		},
+		{
+			name:      "missing units",
+			prices:    []*model.FeaturePrice{defaultPrice},
+			metadata:  map[string]interface{}{"pages": float64(2)},
+			wantError: domainErrors.ErrFeaturePriceUnitsMissing,
+		},
+		{
+			name:      "no price",
+			prices:    []*model.FeaturePrice{},
+			wantError: domainErrors.ErrFeaturePriceNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscriptionRepo := new(MockSubscriptionRepository)
+			paymentRepo := new(MockPaymentRepository)
+			planRepo := new(MockPlanRepository)
+			priceRepo := new(MockFeaturePriceRepository)
+
+			subscriptionRepo.On("GetActiveByUniversalID", mock.Anything, universalID).Return(&entity.Subscription{ID: "sub_1", PlanID: &productID}, nil)
+			paymentRepo.On("ListPlanPurchases", mock.Anything, universalID).Return(nil, nil)
+			planRepo.On("GetByProductID", mock.Anything, productID).Return([]*model.PaymentPlan{
+				{ProviderPriceID: "price_pro", Features: model.Features{"tier": "pro"}},
+			}, nil)
+			priceRepo.On("ListEffective", mock.Anything, "semo", "summarization", []string{"pro"}, now).Return(tt.prices, nil)
+
+			entitlementService := NewEntitlementService(subscriptionRepo, paymentRepo, nil, planRepo, 0, zap.NewNop())
+			service := NewFeaturePricingService(priceRepo, entitlementService, zap.NewNop())
+			service.now = func() time.Time { return now }
+
+			quote, err := service.Quote(context.Background(), universalID, "semo", "summarization", tt.metadata)
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCredits, quote.Credits.String())
+			assert.Equal(t, tt.wantPriceID, quote.PriceID)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// DefaultGeoLocationCacheTTL is how long a resolved location is reused before the geo service is asked again
+const DefaultGeoLocationCacheTTL = 10 * time.Minute
+
+// DefaultGeoServiceCooldown is how long lookups are skipped after the geo service fails, so an outage
+// does not add the lookup timeout to every request
+const DefaultGeoServiceCooldown = 30 * time.Second
+
+// maxGeoLocationCacheEntries bounds the cache; expired entries are dropped when it fills up
+const maxGeoLocationCacheEntries = 50000
+
+// GeoLocator resolves the location of an IP address
+type GeoLocator interface {
+	Locate(ctx context.Context, ip string) (*model.GeoLocation, error)
+}
+
+type geoLocationCacheEntry struct {
+	location  *model.GeoLocation
+	expiresAt time.Time
+}
+
+// GeoLocationService resolves and caches where requests come from. After a failed lookup the geo
+// service is not asked again for a cooldown, during which lookups fail with ErrGeoServiceUnavailable.
+type GeoLocationService struct {
+	locator  GeoLocator
+	ttl      time.Duration
+	cooldown time.Duration
+	now      func() time.Time
+	logger   *zap.Logger
+
+	mu               sync.Mutex
+	cache            map[string]geoLocationCacheEntry
+	unavailableUntil time.Time
+}
+
+// NewGeoLocationService creates a new GeoLocationService instance. Zero durations use
+// DefaultGeoLocationCacheTTL and DefaultGeoServiceCooldown.
+func NewGeoLocationService(locator GeoLocator, ttl, cooldown time.Duration, logger *zap.Logger) *GeoLocationService {
+	if ttl <= 0 {
+		ttl = DefaultGeoLocationCacheTTL
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultGeoServiceCooldown
+	}
+	return &GeoLocationService{
+		locator:  locator,
+		ttl:      ttl,
+		cooldown: cooldown,
+		now:      time.Now,
+		logger:   logger,
+		cache:    make(map[string]geoLocationCacheEntry),
+	}
+}
+
+// Locate returns the location of ip, using the cache when fresh. Private and invalid addresses are
+// returned unresolved without a lookup.
+func (s *GeoLocationService) Locate(ctx context.Context, ip string) (*model.GeoLocation, error) {
+	if !isPublicIP(ip) {
+		return &model.GeoLocation{IP: ip}, nil
+	}
+
+	now := s.now()
+	s.mu.Lock()
+	entry, ok := s.cache[ip]
+	unavailable := now.Before(s.unavailableUntil)
+	s.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.location, nil
+	}
+	if unavailable {
+		return nil, domainErrors.ErrGeoServiceUnavailable
+	}
+
+	location, err := s.locator.Locate(ctx, ip)
+	if err != nil {
+		// A request cancelled by its caller says nothing about the geo service
+		if ctx.Err() == nil {
+			s.mu.Lock()
+			s.unavailableUntil = s.now().Add(s.cooldown)
+			s.mu.Unlock()
+			s.logger.Warn("Geo service lookup failed, skipping lookups during cooldown",
+				zap.String("ip", ip),
+				zap.Duration("cooldown", s.cooldown),
+				zap.Error(err))
+		}
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if len(s.cache) >= maxGeoLocationCacheEntries {
+		s.evictExpired(now)
+	}
+	s.cache[ip] = geoLocationCacheEntry{location: location, expiresAt: now.Add(s.ttl)}
+	s.mu.Unlock()
+
+	return location, nil
+}
+
+// CountryCode returns the upper-case ISO country code of ip, so the service can price plans as a CountryLocator
+func (s *GeoLocationService) CountryCode(ctx context.Context, ip string) (string, error) {
+	location, err := s.Locate(ctx, ip)
+	if err != nil {
+		return "", err
+	}
+	return location.CountryCode, nil
+}
+
+// evictExpired drops expired entries, or every entry when none has expired. Must be called with mu held.
+func (s *GeoLocationService) evictExpired(now time.Time) {
+	for ip, entry := range s.cache {
+		if !now.Before(entry.expiresAt) {
+			delete(s.cache, ip)
+		}
+	}
+	if len(s.cache) >= maxGeoLocationCacheEntries {
+		s.cache = make(map[string]geoLocationCacheEntry)
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// MockGeoLocator is a mock implementation of GeoLocator
+type MockGeoLocator struct {
+	mock.Mock
+}
+
+func (m *MockGeoLocator) Locate(ctx context.Context, ip string) (*model.GeoLocation, error) {
+	args := m.Called(ctx, ip)
+	location, _ := args.Get(0).(*model.GeoLocation)
+	return location, args.Error(1)
+}
+
+func newTestGeoLocationService(locator GeoLocator) (*GeoLocationService, *time.Time) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := NewGeoLocationService(locator, time.Minute, 30*time.Second, zap.NewNop())
+	service.now = func() time.Time { return now }
+	return service, &now
+}
+
+func TestGeoLocationService_CachesLocations(t *testing.T) {
+	locator := new(MockGeoLocator)
+	service, now := newTestGeoLocationService(locator)
+	seoul := &model.GeoLocation{IP: "211.234.10.1", CountryCode: "KR", ASN: 4766, Resolved: true}
+	locator.On("Locate", mock.Anything, "211.234.10.1").Return(seoul, nil).Twice()
+
+	for i := 0; i < 3; i++ {
+		location, err := service.Locate(context.Background(), "211.234.10.1")
+		require.NoError(t, err)
+		assert.Equal(t, seoul, location)
+	}
+
+	*now = now.Add(2 * time.Minute)
+	country, err := service.CountryCode(context.Background(), "211.234.10.1")
+	require.NoError(t, err)
+	assert.Equal(t, "KR", country)
+
+	locator.AssertNumberOfCalls(t, "Locate", 2)
+}
+
+func TestGeoLocationService_SkipsPrivateAddresses(t *testing.T) {
+	locator := new(MockGeoLocator)
+	service, _ := newTestGeoLocationService(locator)
+
+	for _, ip := range []string{"10.0.0.1", "127.0.0.1", "::1", "not-an-ip"} {
+		location, err := service.Locate(context.Background(), ip)
+		require.NoError(t, err)
+		assert.False(t, location.Resolved, ip)
+	}
+
+	locator.AssertNotCalled(t, "Locate", mock.Anything, mock.Anything)
+}
+
+func TestGeoLocationService_CoolsDownAfterFailure(t *testing.T) {
+	locator := new(MockGeoLocator)
+	service, now := newTestGeoLocationService(locator)
+	locator.On("Locate", mock.Anything, "1.2.3.4").Return(nil, errors.New("connection refused")).Once()
+
+	_, err := service.Locate(context.Background(), "1.2.3.4")
+	require.Error(t, err)
+
+	// The geo service is not asked again during the cooldown
+	_, err = service.Locate(context.Background(), "8.8.8.8")
+	assert.ErrorIs(t, err, domainErrors.ErrGeoServiceUnavailable)
+
+	*now = now.Add(31 * time.Second)
+	locator.On("Locate", mock.Anything, "8.8.8.8").Return(&model.GeoLocation{IP: "8.8.8.8", CountryCode: "US", Resolved: true}, nil).Once()
+	location, err := service.Locate(context.Background(), "8.8.8.8")
+	require.NoError(t, err)
+	assert.Equal(t, "US", location.CountryCode)
+
+	locator.AssertExpectations(t)
+}
+
+func TestGeoLocationService_CancelledRequestDoesNotCoolDown(t *testing.T) {
+	locator := new(MockGeoLocator)
+	service, _ := newTestGeoLocationService(locator)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	locator.On("Locate", ctx, "1.2.3.4").Return(nil, context.Canceled).Once()
+	locator.On("Locate", mock.Anything, "1.2.3.4").Return(&model.GeoLocation{IP: "1.2.3.4", Resolved: true}, nil).Once()
+
+	_, err := service.Locate(ctx, "1.2.3.4")
+	require.ErrorIs(t, err, context.Canceled)
+
+	location, err := service.Locate(context.Background(), "1.2.3.4")
+	require.NoError(t, err)
+	assert.True(t, location.Resolved)
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
@@ -51,28 +52,35 @@ func (u *PaymentUsecase) GetPayment(ctx context.Context, id string) (*entity.Pay
 	return u.paymentRepo.GetByID(ctx, id)
 }
 
-func (u *PaymentUsecase) GetPayments(ctx context.Context, universalID string, page, limit int) (*entity.PaginatedPaymentsResponse, error) {
-	if universalID == "" {
+// ListPayments returns a page of a user's payments matching params and the cursor of the next page
+func (u *PaymentUsecase) ListPayments(ctx context.Context, params *entity.PaymentListParams) (*entity.PaginatedPaymentsResponse, error) {
+	if params == nil || params.UniversalID == "" {
 		return nil, errors.New("user ID is required")
 	}
 
-	// Create and validate pagination params
-	params := &entity.PaginationParams{
-		Page:  page,
-		Limit: limit,
+	if params.Sort == "" {
+		params.Sort = entity.PaymentSortCreatedAt
 	}
-	params.Validate()
+	if params.Sort != entity.PaymentSortCreatedAt && params.Sort != entity.PaymentSortAmount {
+		return nil, fmt.Errorf("unsupported sort %q", params.Sort)
+	}
+	limit := entity.NormalizePageSize(params.Limit)
 
-	// Get paginated payments from repository
-	payments, total, err := u.paymentRepo.GetByUniversalID(ctx, universalID, params.Page, params.Limit)
+	// Fetch one extra payment to learn whether another page follows
+	query := *params
+	query.Limit = limit + 1
+	payments, err := u.paymentRepo.ListByUniversalID(ctx, &query)
 	if err != nil {
 		return nil, err
 	}
 
-	// Create pagination metadata
-	meta := entity.NewPaginationMeta(params.Page, params.Limit, total)
+	meta := entity.CursorPaginationMeta{Limit: limit}
+	if len(payments) > limit {
+		payments = payments[:limit]
+		meta.HasMore = true
+		meta.NextCursor = entity.PaymentCursor(payments[limit-1], params.Sort, params.Descending).Encode()
+	}
 
-	// Return paginated response
 	return &entity.PaginatedPaymentsResponse{
 		Data:       payments,
 		Pagination: meta,
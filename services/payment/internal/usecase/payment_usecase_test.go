@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+func TestPaymentUsecase_ListPayments(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	payments := []*entity.Payment{
+		{ID: "12", Amount: 9900, CreatedAt: start},
+		{ID: "11", Amount: 4900, CreatedAt: start.Add(-time.Hour)},
+		{ID: "10", Amount: 4900, CreatedAt: start.Add(-2 * time.Hour)},
+	}
+
+	t.Run("full page returns the cursor of its last payment", func(t *testing.T) {
+		repo := new(MockPaymentRepository)
+		repo.On("ListByUniversalID", ctx, mock.MatchedBy(func(params *entity.PaymentListParams) bool {
+			return params.Limit == 3 && params.Sort == entity.PaymentSortAmount
+		})).Return(payments, nil)
+		usecase := NewPaymentUsecase(repo, nil, zap.NewNop())
+
+		result, err := usecase.ListPayments(ctx, &entity.PaymentListParams{
+			UniversalID: "user-1",
+			Sort:        entity.PaymentSortAmount,
+			Descending:  true,
+			Limit:       2,
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Data, 2)
+		assert.True(t, result.Pagination.HasMore)
+		cursor, err := entity.DecodeCursor(result.Pagination.NextCursor, entity.PaymentSortAmount, true)
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(11), cursor.ID)
+			assert.Equal(t, "4900", cursor.Value)
+		}
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("last page has no cursor", func(t *testing.T) {
+		repo := new(MockPaymentRepository)
+		repo.On("ListByUniversalID", ctx, mock.Anything).Return(payments, nil)
+		usecase := NewPaymentUsecase(repo, nil, zap.NewNop())
+
+		result, err := usecase.ListPayments(ctx, &entity.PaymentListParams{UniversalID: "user-1"})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Data, 3)
+		assert.Equal(t, entity.DefaultPageSize, result.Pagination.Limit)
+		assert.False(t, result.Pagination.HasMore)
+		assert.Empty(t, result.Pagination.NextCursor)
+	})
+
+	t.Run("cursor of another sort order is rejected", func(t *testing.T) {
+		cursor := entity.PaymentCursor(payments[0], entity.PaymentSortCreatedAt, true).Encode()
+
+		_, err := entity.DecodeCursor(cursor, entity.PaymentSortCreatedAt, false)
+
+		assert.Error(t, err)
+	})
+}
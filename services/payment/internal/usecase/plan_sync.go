@@ -7,8 +7,7 @@ import (
 	"strings"
 
 	"github.com/stripe/stripe-go/v79"
-	"github.com/stripe/stripe-go/v79/price"
-	"github.com/stripe/stripe-go/v79/product"
+	"github.com/stripe/stripe-go/v79/client"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/adapter/repository"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 	"go.uber.org/zap"
@@ -28,11 +27,15 @@ func NewPlanSyncService(planRepo repository.PlanRepository, logger *zap.Logger)
 	}
 }
 
-// SyncProductEvent handles product-related webhook events
-func (s *PlanSyncService) SyncProductEvent(ctx context.Context, eventType string, eventData json.RawMessage) error {
+// SyncProductEvent handles product-related webhook events from the service provider's Stripe account
+func (s *PlanSyncService) SyncProductEvent(ctx context.Context, serviceProvider *model.ServiceProvider, eventType string, eventData json.RawMessage) error {
 	switch eventType {
 	case "product.created", "product.updated":
-		return s.handleProductUpsert(ctx, eventData)
+		stripeClient, err := StripeClient(serviceProvider)
+		if err != nil {
+			return err
+		}
+		return s.handleProductUpsert(ctx, stripeClient, eventData)
 	case "product.deleted":
 		return s.handleProductDeleted(ctx, eventData)
 	default:
@@ -40,11 +43,15 @@ func (s *PlanSyncService) SyncProductEvent(ctx context.Context, eventType string
 	}
 }
 
-// SyncPriceEvent handles price-related webhook events
-func (s *PlanSyncService) SyncPriceEvent(ctx context.Context, eventType string, eventData json.RawMessage) error {
+// SyncPriceEvent handles price-related webhook events from the service provider's Stripe account
+func (s *PlanSyncService) SyncPriceEvent(ctx context.Context, serviceProvider *model.ServiceProvider, eventType string, eventData json.RawMessage) error {
 	switch eventType {
 	case "price.created", "price.updated":
-		return s.handlePriceUpsert(ctx, eventData)
+		stripeClient, err := StripeClient(serviceProvider)
+		if err != nil {
+			return err
+		}
+		return s.handlePriceUpsert(ctx, stripeClient, eventData)
 	case "price.deleted":
 		return s.handlePriceDeleted(ctx, eventData)
 	default:
@@ -53,7 +60,7 @@ func (s *PlanSyncService) SyncPriceEvent(ctx context.Context, eventType string,
 }
 
 // handleProductUpsert handles product creation/update
-func (s *PlanSyncService) handleProductUpsert(ctx context.Context, eventData json.RawMessage) error {
+func (s *PlanSyncService) handleProductUpsert(ctx context.Context, stripeClient *client.API, eventData json.RawMessage) error {
 	var prod stripe.Product
 	if err := json.Unmarshal(eventData, &prod); err != nil {
 		return fmt.Errorf("failed to unmarshal product data: %w", err)
@@ -69,7 +76,7 @@ func (s *PlanSyncService) handleProductUpsert(ctx context.Context, eventData jso
 		Active:  stripe.Bool(true),
 	}
 
-	iter := price.List(params)
+	iter := stripeClient.Prices.List(params)
 	for iter.Next() {
 		p := iter.Price()
 		if err := s.SyncPriceWithProduct(ctx, p, &prod); err != nil {
@@ -117,7 +124,7 @@ func (s *PlanSyncService) handleProductDeleted(ctx context.Context, eventData js
 }
 
 // handlePriceUpsert handles price creation/update
-func (s *PlanSyncService) handlePriceUpsert(ctx context.Context, eventData json.RawMessage) error {
+func (s *PlanSyncService) handlePriceUpsert(ctx context.Context, stripeClient *client.API, eventData json.RawMessage) error {
 	var p stripe.Price
 	if err := json.Unmarshal(eventData, &p); err != nil {
 		return fmt.Errorf("failed to unmarshal price data: %w", err)
@@ -128,7 +135,7 @@ func (s *PlanSyncService) handlePriceUpsert(ctx context.Context, eventData json.
 		zap.String("product_id", p.Product.ID))
 
 	// Get full product details
-	prod, err := product.Get(p.Product.ID, nil)
+	prod, err := stripeClient.Products.Get(p.Product.ID, nil)
 	if err != nil {
 		return fmt.Errorf("failed to get product details: %w", err)
 	}
@@ -151,6 +158,11 @@ func (s *PlanSyncService) handlePriceDeleted(ctx context.Context, eventData json
 
 // SyncPriceWithProduct syncs a price with its product information
 func (s *PlanSyncService) SyncPriceWithProduct(ctx context.Context, p *stripe.Price, prod *stripe.Product) error {
+	// Prices of an existing plan in another currency are stored as its price points
+	if basePriceID := p.Metadata["price_point_of"]; basePriceID != "" {
+		return s.syncPricePoint(ctx, p, basePriceID)
+	}
+
 	// Determine plan type based on price type
 	var planType string
 	if p.Type == stripe.PriceTypeRecurring && p.Recurring != nil {
@@ -207,6 +219,21 @@ func (s *PlanSyncService) SyncPriceWithProduct(ctx context.Context, p *stripe.Pr
 		fmt.Sscanf(order, "%d", &sortOrder)
 	}
 
+	// Extract trial settings, preferring product metadata over the price's recurring default
+	trialPeriodDays := 0
+	if planType == model.PlanTypeSubscription && p.Recurring != nil {
+		trialPeriodDays = int(p.Recurring.TrialPeriodDays)
+	}
+	if days, ok := prod.Metadata["trial_period_days"]; ok {
+		fmt.Sscanf(days, "%d", &trialPeriodDays)
+	}
+	trialCredits := 0
+	if credits, ok := prod.Metadata["trial_credits"]; ok {
+		fmt.Sscanf(credits, "%d", &trialCredits)
+	}
+
+	seatBased := prod.Metadata["seat_based"] == "true"
+
 	plan := &model.PaymentPlan{
 		ProviderPriceID:   p.ID,
 		ProviderProductID: prod.ID,
@@ -217,8 +244,37 @@ func (s *PlanSyncService) SyncPriceWithProduct(ctx context.Context, p *stripe.Pr
 		CreditsPerCycle:   creditsPerCycle,
 		Features:          features,
 		SortOrder:         sortOrder,
+		TrialPeriodDays:   trialPeriodDays,
+		TrialCredits:      trialCredits,
+		SeatBased:         seatBased,
+		ServiceProvider:   prod.Metadata["service_provider"],
 		IsActive:          p.Active && prod.Active,
 	}
 
 	return s.planRepo.Upsert(ctx, plan)
 }
+
+// syncPricePoint stores a Stripe price as a price point of the plan sold at basePriceID
+func (s *PlanSyncService) syncPricePoint(ctx context.Context, p *stripe.Price, basePriceID string) error {
+	plan, err := s.planRepo.GetByPriceID(ctx, basePriceID)
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		return fmt.Errorf("plan %s of price point %s not found", basePriceID, p.ID)
+	}
+
+	s.logger.Info("Syncing plan price point",
+		zap.String("price_id", p.ID),
+		zap.String("plan_price_id", basePriceID),
+		zap.String("currency", string(p.Currency)))
+
+	return s.planRepo.UpsertPrice(ctx, &model.PlanPrice{
+		PlanID:          plan.ID,
+		ProviderPriceID: p.ID,
+		PgProvider:      "stripe",
+		Currency:        strings.ToUpper(string(p.Currency)),
+		Amount:          p.UnitAmount,
+		IsActive:        p.Active,
+	})
+}
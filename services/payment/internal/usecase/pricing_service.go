@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// CountryLocator resolves the ISO country code of an IP address
+type CountryLocator interface {
+	CountryCode(ctx context.Context, ip string) (string, error)
+}
+
+// Sources of a currency selection
+const (
+	CurrencySourceOverride = "override" // Requested by the caller
+	CurrencySourceCountry  = "country"  // Currency of the caller's country
+	CurrencySourceDefault  = "default"  // Configured default currency
+)
+
+// DefaultPricingCurrency is used when neither the config nor the caller's country names a currency
+const DefaultPricingCurrency = "USD"
+
+// defaultCountryCurrencies maps ISO country codes to the currency plans are priced in there
+var defaultCountryCurrencies = map[string]string{
+	"KR": "KRW",
+	"US": "USD",
+	"JP": "JPY",
+	"GB": "GBP",
+	"AT": "EUR", "BE": "EUR", "CY": "EUR", "DE": "EUR", "EE": "EUR", "ES": "EUR", "FI": "EUR",
+	"FR": "EUR", "GR": "EUR", "HR": "EUR", "IE": "EUR", "IT": "EUR", "LT": "EUR", "LU": "EUR",
+	"LV": "EUR", "MT": "EUR", "NL": "EUR", "PT": "EUR", "SI": "EUR", "SK": "EUR",
+}
+
+// CurrencySelection is the currency plans are priced in for a caller
+type CurrencySelection struct {
+	Country  string // ISO country code of the caller, when known
+	Currency string
+	Source   string
+}
+
+// PricingService prices plans in the currency of the caller
+type PricingService struct {
+	locator           CountryLocator
+	defaultCurrency   string
+	countryCurrencies map[string]string
+	logger            *zap.Logger
+}
+
+// NewPricingService creates a new PricingService instance. locator may be nil, in which case
+// callers without a currency override are priced in the default currency.
+func NewPricingService(cfg config.PricingConfig, locator CountryLocator, logger *zap.Logger) *PricingService {
+	defaultCurrency := strings.ToUpper(strings.TrimSpace(cfg.DefaultCurrency))
+	if defaultCurrency == "" {
+		defaultCurrency = DefaultPricingCurrency
+	}
+
+	countryCurrencies := make(map[string]string, len(defaultCountryCurrencies)+len(cfg.CountryCurrencies))
+	for country, currency := range defaultCountryCurrencies {
+		countryCurrencies[country] = currency
+	}
+	for country, currency := range cfg.CountryCurrencies {
+		countryCurrencies[strings.ToUpper(country)] = strings.ToUpper(currency)
+	}
+
+	return &PricingService{
+		locator:           locator,
+		defaultCurrency:   defaultCurrency,
+		countryCurrencies: countryCurrencies,
+		logger:            logger,
+	}
+}
+
+// PGProviderForCurrency returns the payment gateway that charges a currency: Toss for KRW, Stripe otherwise
+func PGProviderForCurrency(currency string) string {
+	if strings.EqualFold(currency, "KRW") {
+		return "toss"
+	}
+	return "stripe"
+}
+
+// SelectCurrency selects the currency for a caller: the override when given, otherwise the currency
+// of the country of ip, otherwise the default currency. Failed country lookups are not errors.
+func (s *PricingService) SelectCurrency(ctx context.Context, ip string, override string) CurrencySelection {
+	if override = strings.ToUpper(strings.TrimSpace(override)); override != "" {
+		return CurrencySelection{Currency: override, Source: CurrencySourceOverride}
+	}
+
+	selection := CurrencySelection{Currency: s.defaultCurrency, Source: CurrencySourceDefault}
+	if s.locator == nil || !isPublicIP(ip) {
+		return selection
+	}
+
+	country, err := s.locator.CountryCode(ctx, ip)
+	if errors.Is(err, domainErrors.ErrGeoServiceUnavailable) {
+		return selection
+	}
+	if err != nil {
+		s.logger.Warn("Failed to resolve caller country, using default currency",
+			zap.String("ip", ip),
+			zap.Error(err))
+		return selection
+	}
+
+	selection.Country = country
+	if currency, ok := s.countryCurrencies[country]; ok {
+		selection.Currency = currency
+		selection.Source = CurrencySourceCountry
+	}
+	return selection
+}
+
+// PricePlans returns the plans priced for the selected currency. pgProvider restricts the price
+// points to one payment gateway; when blank the gateway of the currency is preferred.
+// A requested currency, or the currency of the caller's country when some plan is sold in it, keeps
+// only the plans sold in that currency. Otherwise no currency was fixed and no plan is dropped: each
+// is priced in the default currency when it has a price point in it and at its own price when not,
+// and the returned selection names the currency only when all plans share one.
+func (s *PricingService) PricePlans(plans []*model.PaymentPlan, selection CurrencySelection, pgProvider string) ([]*model.PaymentPlan, CurrencySelection) {
+	if selection.Source == CurrencySourceOverride || selection.Source == CurrencySourceCountry {
+		priced := pricePlansIn(plans, selection.Currency, pgProvider, false)
+		if len(priced) > 0 || len(plans) == 0 || selection.Source == CurrencySourceOverride {
+			return priced, selection
+		}
+	}
+
+	priced := pricePlansIn(plans, s.defaultCurrency, pgProvider, true)
+	selection.Source = CurrencySourceDefault
+	selection.Currency = sharedCurrency(priced, s.defaultCurrency)
+	return priced, selection
+}
+
+// pricePlansIn prices plans at their price point in currency. Plans without one are dropped, or
+// kept at their own price when keepUnpriced is set.
+func pricePlansIn(plans []*model.PaymentPlan, currency string, pgProvider string, keepUnpriced bool) []*model.PaymentPlan {
+	preferred := pgProvider
+	if preferred == "" {
+		preferred = PGProviderForCurrency(currency)
+	}
+
+	priced := make([]*model.PaymentPlan, 0, len(plans))
+	for _, plan := range plans {
+		price, ok := plan.PriceIn(currency, preferred)
+		if !ok || (pgProvider != "" && price.PgProvider != pgProvider) {
+			if keepUnpriced {
+				priced = append(priced, plan)
+			}
+			continue
+		}
+		if price.ProviderPriceID == plan.ProviderPriceID {
+			priced = append(priced, plan)
+			continue
+		}
+		priced = append(priced, plan.WithPrice(price))
+	}
+	return priced
+}
+
+// sharedCurrency returns the currency all plans are priced in, "" when they differ, or fallback
+// when there are no plans
+func sharedCurrency(plans []*model.PaymentPlan, fallback string) string {
+	if len(plans) == 0 {
+		return fallback
+	}
+	currency := strings.ToUpper(plans[0].Currency)
+	for _, plan := range plans[1:] {
+		if !strings.EqualFold(plan.Currency, currency) {
+			return ""
+		}
+	}
+	return currency
+}
+
+// isPublicIP reports whether ip can be located; private and loopback addresses cannot
+func isPublicIP(ip string) bool {
+	parsed := net.ParseIP(ip)
+	return parsed != nil && !parsed.IsPrivate() && !parsed.IsLoopback() && !parsed.IsUnspecified() && !parsed.IsLinkLocalUnicast()
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// MockCountryLocator is a mock implementation of CountryLocator
+type MockCountryLocator struct {
+	mock.Mock
+}
+
+func (m *MockCountryLocator) CountryCode(ctx context.Context, ip string) (string, error) {
+	args := m.Called(ctx, ip)
+	return args.String(0), args.Error(1)
+}
+
+func TestPricingService_SelectCurrency(t *testing.T) {
+	tests := []struct {
+		name         string
+		ip           string
+		override     string
+		country      string
+		lookupErr    error
+		wantCurrency string
+		wantSource   string
+		wantCountry  string
+	}{
+		{
+			name:         "override wins over the caller's country",
+			ip:           "211.234.10.1",
+			override:     "eur",
+			wantCurrency: "EUR",
+			wantSource:   CurrencySourceOverride,
+		},
+		{
+			name:         "korean caller is priced in won",
+			ip:           "211.234.10.1",
+			country:      "KR",
+			wantCurrency: "KRW",
+			wantSource:   CurrencySourceCountry,
+			wantCountry:  "KR",
+		},
+		{
+			name:         "configured country currency",
+			ip:           "1.2.3.4",
+			country:      "CA",
+			wantCurrency: "CAD",
+			wantSource:   CurrencySourceCountry,
+			wantCountry:  "CA",
+		},
+		{
+			name:         "country without a currency uses the default",
+			ip:           "1.2.3.4",
+			country:      "BR",
+			wantCurrency: "USD",
+			wantSource:   CurrencySourceDefault,
+			wantCountry:  "BR",
+		},
+		{
+			name:         "failed lookup uses the default",
+			ip:           "1.2.3.4",
+			lookupErr:    errors.New("geo service unavailable"),
+			wantCurrency: "USD",
+			wantSource:   CurrencySourceDefault,
+		},
+		{
+			name:         "private address is not looked up",
+			ip:           "10.0.0.7",
+			wantCurrency: "USD",
+			wantSource:   CurrencySourceDefault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locator := new(MockCountryLocator)
+			if tt.override == "" && tt.ip != "10.0.0.7" {
+				locator.On("CountryCode", mock.Anything, tt.ip).Return(tt.country, tt.lookupErr)
+			}
+			service := NewPricingService(config.PricingConfig{
+				CountryCurrencies: map[string]string{"ca": "cad"},
+			}, locator, zap.NewNop())
+
+			selection := service.SelectCurrency(context.Background(), tt.ip, tt.override)
+
+			assert.Equal(t, tt.wantCurrency, selection.Currency)
+			assert.Equal(t, tt.wantSource, selection.Source)
+			assert.Equal(t, tt.wantCountry, selection.Country)
+			locator.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPricingService_PricePlans(t *testing.T) {
+	pro := &model.PaymentPlan{
+		ID:              1,
+		ProviderPriceID: "toss_pro_monthly",
+		PgProvider:      "toss",
+		Currency:        "KRW",
+		IsActive:        true,
+		Features: model.Features{
+			"price": map[string]interface{}{"amount": float64(9900), "currency": "KRW", "interval": "month"},
+		},
+		Prices: []model.PlanPrice{
+			{PlanID: 1, ProviderPriceID: "price_pro_usd", PgProvider: "stripe", Currency: "USD", Amount: 790, IsActive: true},
+			{PlanID: 1, ProviderPriceID: "price_pro_krw", PgProvider: "stripe", Currency: "KRW", Amount: 9900, IsActive: true},
+		},
+	}
+	krwOnly := &model.PaymentPlan{
+		ID:              2,
+		ProviderPriceID: "toss_credits",
+		PgProvider:      "toss",
+		Currency:        "KRW",
+		IsActive:        true,
+		Features: model.Features{
+			"price": map[string]interface{}{"amount": float64(5000), "currency": "KRW"},
+		},
+	}
+	plans := []*model.PaymentPlan{pro, krwOnly}
+	service := NewPricingService(config.PricingConfig{}, nil, zap.NewNop())
+
+	t.Run("won is charged through toss", func(t *testing.T) {
+		priced, selection := service.PricePlans(plans, CurrencySelection{Currency: "KRW", Source: CurrencySourceCountry}, "")
+
+		assert.Equal(t, "KRW", selection.Currency)
+		if assert.Len(t, priced, 2) {
+			assert.Equal(t, "toss_pro_monthly", priced[0].ProviderPriceID)
+			assert.Equal(t, "toss_credits", priced[1].ProviderPriceID)
+		}
+	})
+
+	t.Run("explicit gateway selects its price point", func(t *testing.T) {
+		priced, _ := service.PricePlans(plans, CurrencySelection{Currency: "KRW", Source: CurrencySourceOverride}, "stripe")
+
+		if assert.Len(t, priced, 1) {
+			assert.Equal(t, "price_pro_krw", priced[0].ProviderPriceID)
+			assert.Equal(t, int64(1), priced[0].ID)
+		}
+	})
+
+	t.Run("dollar price point carries its amount and keeps the interval", func(t *testing.T) {
+		priced, _ := service.PricePlans(plans, CurrencySelection{Currency: "USD", Source: CurrencySourceCountry}, "")
+
+		if assert.Len(t, priced, 1) {
+			price := priced[0].Features["price"].(map[string]interface{})
+			assert.Equal(t, "price_pro_usd", priced[0].ProviderPriceID)
+			assert.Equal(t, "stripe", priced[0].PgProvider)
+			assert.Equal(t, int64(790), price["amount"])
+			assert.Equal(t, "month", price["interval"])
+			assert.Equal(t, "$7.90", model.FormatAmount(790, "USD"))
+		}
+		// The stored plan is not modified
+		assert.Equal(t, "toss_pro_monthly", pro.ProviderPriceID)
+	})
+
+	t.Run("unpriced country currency keeps every plan", func(t *testing.T) {
+		priced, selection := service.PricePlans(plans, CurrencySelection{Country: "FR", Currency: "EUR", Source: CurrencySourceCountry}, "")
+
+		assert.Equal(t, "", selection.Currency)
+		assert.Equal(t, CurrencySourceDefault, selection.Source)
+		assert.Equal(t, "FR", selection.Country)
+		if assert.Len(t, priced, 2) {
+			assert.Equal(t, "price_pro_usd", priced[0].ProviderPriceID)
+			assert.Equal(t, "toss_credits", priced[1].ProviderPriceID)
+		}
+	})
+
+	t.Run("unlocated caller keeps the gateway's own currency", func(t *testing.T) {
+		priced, selection := service.PricePlans(plans, CurrencySelection{Currency: "USD", Source: CurrencySourceDefault}, "toss")
+
+		assert.Equal(t, "KRW", selection.Currency)
+		if assert.Len(t, priced, 2) {
+			assert.Equal(t, "toss_pro_monthly", priced[0].ProviderPriceID)
+			assert.Equal(t, "toss_credits", priced[1].ProviderPriceID)
+		}
+	})
+
+	t.Run("unlocated caller is priced in the default currency where possible", func(t *testing.T) {
+		priced, selection := service.PricePlans([]*model.PaymentPlan{pro}, CurrencySelection{Currency: "USD", Source: CurrencySourceDefault}, "")
+
+		assert.Equal(t, "USD", selection.Currency)
+		if assert.Len(t, priced, 1) {
+			assert.Equal(t, "price_pro_usd", priced[0].ProviderPriceID)
+		}
+	})
+
+	t.Run("unpriced override is not replaced", func(t *testing.T) {
+		priced, selection := service.PricePlans(plans, CurrencySelection{Currency: "EUR", Source: CurrencySourceOverride}, "")
+
+		assert.Equal(t, "EUR", selection.Currency)
+		assert.Empty(t, priced)
+	})
+}
@@ -14,18 +14,27 @@ import (
 
 // ProductUseCase handles one-time payment operations
 type ProductUseCase struct {
-	paymentRepo repository.PaymentRepository
-	logger      *zap.Logger
+	paymentRepo        repository.PaymentRepository
+	cashReceiptService *CashReceiptService
+	couponService      *CouponService
+	referralService    *ReferralService
+	logger             *zap.Logger
 }
 
 // NewProductUseCase creates a new ProductUseCase instance
 func NewProductUseCase(
 	paymentRepo repository.PaymentRepository,
+	cashReceiptService *CashReceiptService,
+	couponService *CouponService,
+	referralService *ReferralService,
 	logger *zap.Logger,
 ) *ProductUseCase {
 	return &ProductUseCase{
-		paymentRepo: paymentRepo,
-		logger:      logger,
+		paymentRepo:        paymentRepo,
+		cashReceiptService: cashReceiptService,
+		couponService:      couponService,
+		referralService:    referralService,
+		logger:             logger,
 	}
 }
 
@@ -38,6 +47,13 @@ type CreateProductRequest struct {
 	CustomerKey string                 `json:"customer_key"`
 	PlanID      string                 `json:"plan_id,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+
+	// Cash receipt requested at checkout (transfer and virtual account payments only)
+	CashReceiptType       string `json:"cash_receipt_type,omitempty"`
+	CashReceiptIdentifier string `json:"cash_receipt_identifier,omitempty"`
+
+	// Coupon code applied to the order amount
+	CouponCode string `json:"coupon_code,omitempty"`
 }
 
 // CreateProductResponse represents the response from payment creation
@@ -50,6 +66,7 @@ type CreateProductResponse struct {
 	Currency     string                 `json:"currency"`
 	CreatedAt    time.Time              `json:"created_at"`
 	ProviderData map[string]interface{} `json:"provider_data,omitempty"`
+	Coupon       *CouponQuote           `json:"coupon,omitempty"`
 }
 
 // CreateProductWithProvider creates a new one-time payment with a specific provider
@@ -62,16 +79,50 @@ func (u *ProductUseCase) CreateProductWithProvider(ctx context.Context, req *Cre
 	// Generate order ID
 	orderID := u.generateOrderID()
 
+	amount := req.Amount
+	metadata := req.Metadata
+
+	// Apply the coupon and hold its redemption until the payment settles
+	var quote *CouponQuote
+	if req.CouponCode != "" {
+		if u.couponService == nil {
+			return nil, fmt.Errorf("coupon service not configured")
+		}
+
+		universalID, err := uuid.Parse(req.UniversalID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid universal ID: %w", err)
+		}
+
+		quote, err = u.couponService.Quote(ctx, req.CouponCode, universalID, req.PlanID, req.Amount, req.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := u.couponService.Reserve(ctx, quote, universalID, orderID); err != nil {
+			return nil, err
+		}
+
+		amount = quote.FinalAmount
+		metadata = make(map[string]interface{}, len(req.Metadata)+3)
+		for k, v := range req.Metadata {
+			metadata[k] = v
+		}
+		metadata["coupon_code"] = quote.Code
+		metadata["original_amount"] = quote.OriginalAmount
+		metadata["discount_amount"] = quote.DiscountAmount
+	}
+
 	// Initialize payment with provider
 	providerReq := &provider.InitializePaymentRequest{
 		UniversalID: req.UniversalID,
-		Amount:      req.Amount,
+		Amount:      amount,
 		Currency:    req.Currency,
 		OrderID:     orderID,
 		OrderName:   req.OrderName,
 		CustomerKey: req.CustomerKey,
 		PlanID:      req.PlanID,
-		Metadata:    req.Metadata,
+		Metadata:    metadata,
 	}
 
 	providerResp, err := paymentProvider.InitializePayment(ctx, providerReq)
@@ -79,6 +130,7 @@ func (u *ProductUseCase) CreateProductWithProvider(ctx context.Context, req *Cre
 		u.logger.Error("Failed to initialize payment with provider",
 			zap.String("order_id", orderID),
 			zap.Error(err))
+		u.releaseCoupon(ctx, quote, orderID)
 		return nil, fmt.Errorf("failed to initialize payment: %w", err)
 	}
 
@@ -86,27 +138,36 @@ func (u *ProductUseCase) CreateProductWithProvider(ctx context.Context, req *Cre
 	payment := &entity.Payment{
 		UniversalID:   req.UniversalID,
 		TransactionID: orderID, // Store order ID in TransactionID field
-		Amount:        float64(req.Amount),
+		Amount:        float64(amount),
 		Currency:      req.Currency,
 		Status:        entity.PaymentStatusPending,
 		Method:        entity.PaymentMethodCard, // Default, will be updated on confirmation
+		Provider:      paymentProvider.GetProviderName(),
 		Description:   req.OrderName,
 		Metadata:      providerResp.ProviderData,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
 
-	if req.Metadata != nil {
-		for k, v := range req.Metadata {
+	if metadata != nil {
+		for k, v := range metadata {
 			payment.Metadata[k] = v
 		}
 	}
 
+	if req.CashReceiptType != "" {
+		payment.CashReceipt = &entity.CashReceipt{
+			Type:       req.CashReceiptType,
+			Identifier: req.CashReceiptIdentifier,
+		}
+	}
+
 	err = u.paymentRepo.CreateOneTimePayment(ctx, payment)
 	if err != nil {
 		u.logger.Error("Failed to create payment record",
 			zap.String("order_id", orderID),
 			zap.Error(err))
+		u.releaseCoupon(ctx, quote, orderID)
 		return nil, fmt.Errorf("failed to create payment record: %w", err)
 	}
 
@@ -119,6 +180,7 @@ func (u *ProductUseCase) CreateProductWithProvider(ctx context.Context, req *Cre
 		Currency:     providerResp.Currency,
 		CreatedAt:    payment.CreatedAt,
 		ProviderData: providerResp.ProviderData,
+		Coupon:       quote,
 	}, nil
 }
 
@@ -210,6 +272,33 @@ func (u *ProductUseCase) ConfirmPaymentWithProvider(ctx context.Context, req *Co
 		return nil, fmt.Errorf("failed to update payment: %w", err)
 	}
 
+	// Transfers complete immediately; virtual accounts are issued from the deposit webhook
+	if u.cashReceiptService != nil && providerResp.Status == provider.PaymentStatusCompleted {
+		if err := u.cashReceiptService.IssueForOrder(ctx, req.OrderID, providerResp.PaymentMethod, providerResp.ProviderData); err != nil {
+			u.logger.Warn("Cash receipt issuance failed after confirmation",
+				zap.String("order_id", req.OrderID),
+				zap.Error(err))
+		}
+	}
+
+	if u.couponService != nil && providerResp.Status == provider.PaymentStatusCompleted {
+		serviceProvider, _ := payment.Metadata["service_provider"].(string)
+		if err := u.couponService.Complete(ctx, req.OrderID, serviceProvider); err != nil {
+			u.logger.Warn("Coupon redemption failed after confirmation",
+				zap.String("order_id", req.OrderID),
+				zap.Error(err))
+		}
+	}
+
+	if u.referralService != nil && providerResp.Status == provider.PaymentStatusCompleted {
+		serviceProvider, _ := payment.Metadata["service_provider"].(string)
+		if err := u.referralService.RewardFirstPayment(ctx, payment.UniversalID, serviceProvider, req.OrderID); err != nil {
+			u.logger.Warn("Referral reward failed after confirmation",
+				zap.String("order_id", req.OrderID),
+				zap.Error(err))
+		}
+	}
+
 	return &ConfirmProductResponse{
 		OrderID:        providerResp.OrderID,
 		PaymentKey:     providerResp.PaymentKey,
@@ -223,6 +312,18 @@ func (u *ProductUseCase) ConfirmPaymentWithProvider(ctx context.Context, req *Co
 	}, nil
 }
 
+// releaseCoupon frees a coupon reserved for an order that could not be created
+func (u *ProductUseCase) releaseCoupon(ctx context.Context, quote *CouponQuote, orderID string) {
+	if quote == nil {
+		return
+	}
+	if err := u.couponService.Release(ctx, orderID); err != nil {
+		u.logger.Warn("Failed to release coupon after payment creation failure",
+			zap.String("order_id", orderID),
+			zap.Error(err))
+	}
+}
+
 // generateOrderID generates a unique order ID
 func (u *ProductUseCase) generateOrderID() string {
 	return fmt.Sprintf("ORDER_%d_%s",
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stripe/stripe-go/v79/client"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	stripeProvider "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/provider/stripe"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/provider/toss"
+	"go.uber.org/zap"
+)
+
+// ServiceProviderResolver looks up active service providers; a blank code resolves the default provider
+type ServiceProviderResolver interface {
+	Resolve(ctx context.Context, code string) (*model.ServiceProvider, error)
+}
+
+// StripeClient returns a Stripe API client for the service provider's Stripe account. It fails
+// with ErrServiceProviderCredentialsMissing when the provider has no Stripe secret key.
+func StripeClient(serviceProvider *model.ServiceProvider) (*client.API, error) {
+	if serviceProvider == nil || serviceProvider.Credentials.StripeSecretKey == "" {
+		return nil, missingCredentials(serviceProvider, "Stripe")
+	}
+	return stripeProvider.NewAPIClient(serviceProvider.Credentials.StripeSecretKey, ""), nil
+}
+
+// TossClient returns a Toss provider for the service provider's payment widget keys
+func TossClient(serviceProvider *model.ServiceProvider, logger *zap.Logger) (*toss.TossProvider, error) {
+	if serviceProvider == nil || serviceProvider.Credentials.TossSecretKey == "" {
+		return nil, missingCredentials(serviceProvider, "Toss")
+	}
+	credentials := serviceProvider.Credentials
+	return toss.NewTossProvider(credentials.TossSecretKey, credentials.TossClientKey, logger), nil
+}
+
+// TossBillingClient returns a Toss provider for the service provider's API individual integration
+// key, which billing keys must be issued and charged with
+func TossBillingClient(serviceProvider *model.ServiceProvider, logger *zap.Logger) (*toss.TossProvider, error) {
+	if serviceProvider == nil || serviceProvider.Credentials.TossBillingSecretKey == "" {
+		return nil, missingCredentials(serviceProvider, "Toss billing")
+	}
+	credentials := serviceProvider.Credentials
+	return toss.NewTossProvider(credentials.TossBillingSecretKey, credentials.TossClientKey, logger), nil
+}
+
+func missingCredentials(serviceProvider *model.ServiceProvider, gateway string) error {
+	code := ""
+	if serviceProvider != nil {
+		code = serviceProvider.Code
+	}
+	return fmt.Errorf("%s secret key of service provider %q: %w", gateway, code, domainErrors.ErrServiceProviderCredentialsMissing)
+}
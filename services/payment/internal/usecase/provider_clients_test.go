@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+func TestProviderClients_RequireServiceProviderCredentials(t *testing.T) {
+	configured := &model.ServiceProvider{
+		Code: "acme",
+		Credentials: model.ServiceProviderCredentials{
+			StripeSecretKey:      "sk_acme",
+			TossSecretKey:        "toss_acme",
+			TossBillingSecretKey: "toss_billing_acme",
+		},
+	}
+	blank := &model.ServiceProvider{Code: "acme"}
+
+	for _, sp := range []*model.ServiceProvider{nil, blank} {
+		_, err := StripeClient(sp)
+		assert.ErrorIs(t, err, domainErrors.ErrServiceProviderCredentialsMissing)
+		_, err = TossClient(sp, zap.NewNop())
+		assert.ErrorIs(t, err, domainErrors.ErrServiceProviderCredentialsMissing)
+		_, err = TossBillingClient(sp, zap.NewNop())
+		assert.ErrorIs(t, err, domainErrors.ErrServiceProviderCredentialsMissing)
+	}
+
+	stripeClient, err := StripeClient(configured)
+	assert.NoError(t, err)
+	assert.NotNil(t, stripeClient)
+	tossClient, err := TossClient(configured, zap.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, tossClient)
+	billingClient, err := TossBillingClient(configured, zap.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, billingClient)
+}
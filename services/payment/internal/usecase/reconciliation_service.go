@@ -0,0 +1,612 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stripe/stripe-go/v79"
+	"github.com/stripe/stripe-go/v79/client"
+	"go.uber.org/zap"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	stripeProvider "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/provider/stripe"
+)
+
+// MaxReconciliationDays bounds the period of a reconciliation run
+const MaxReconciliationDays = 31
+
+// ReconciliationIssue is a kind of mismatch between our records and a PG's
+type ReconciliationIssue string
+
+const (
+	// ReconciliationUnpaid is a payment the PG completed that we have not marked paid
+	ReconciliationUnpaid ReconciliationIssue = "unpaid"
+	// ReconciliationCreditsMissing is a completed payment without its credit allocation
+	ReconciliationCreditsMissing ReconciliationIssue = "credits_missing"
+	// ReconciliationMissingPayment is a PG payment we have no record of
+	ReconciliationMissingPayment ReconciliationIssue = "missing_payment"
+	// ReconciliationAmountMismatch is a payment whose amount or currency differs from the PG's
+	ReconciliationAmountMismatch ReconciliationIssue = "amount_mismatch"
+	// ReconciliationStatusMismatch is a payment whose status contradicts the PG's in a way that is not fixed automatically
+	ReconciliationStatusMismatch ReconciliationIssue = "status_mismatch"
+	// ReconciliationLookupFailed is a payment that could not be looked up at the PG
+	ReconciliationLookupFailed ReconciliationIssue = "lookup_failed"
+)
+
+// CreditAllocator allocates the credits of a paid order, doing nothing if they were already
+// allocated under the same reference
+type CreditAllocator interface {
+	AllocateCreditsForPayment(ctx context.Context, universalID uuid.UUID, invoiceID string, subscriptionID string, stripePriceID string, seats int, serviceProviderOverride string) (int, error)
+}
+
+// ReconciliationRequest selects the payments to reconcile
+type ReconciliationRequest struct {
+	From   time.Time
+	To     time.Time // exclusive
+	DryRun bool      // Report fixable mismatches without fixing them
+}
+
+// ReconciliationReport lists the mismatches found between our payments and the PGs' records
+type ReconciliationReport struct {
+	From     time.Time               `json:"from"`
+	To       time.Time               `json:"to"`
+	DryRun   bool                    `json:"dry_run"`
+	Checked  int                     `json:"checked"` // Payments and PG records compared
+	Fixed    int                     `json:"fixed"`
+	Findings []ReconciliationFinding `json:"findings"`
+}
+
+// ReconciliationFinding is one mismatch. Fixed findings were corrected; the others need a person.
+type ReconciliationFinding struct {
+	Provider        string              `json:"provider"`
+	ServiceProvider string              `json:"service_provider,omitempty"`
+	Issue           ReconciliationIssue `json:"issue"`
+	Fixed           bool                `json:"fixed"`
+	PaymentID       int64               `json:"payment_id,omitempty"`
+	OrderID         string              `json:"order_id,omitempty"` // Toss order or Stripe invoice
+	UniversalID     string              `json:"universal_id,omitempty"`
+	Status          string              `json:"status,omitempty"`          // Our payment status
+	ProviderStatus  string              `json:"provider_status,omitempty"` // The PG's payment status
+	Amount          int64               `json:"amount,omitempty"`          // Our amount, in the smallest currency unit
+	ProviderAmount  int64               `json:"provider_amount,omitempty"`
+	Currency        string              `json:"currency,omitempty"`
+	Credits         int                 `json:"credits,omitempty"` // Credits allocated by the fix
+	Detail          string              `json:"detail,omitempty"`
+}
+
+// ReconciliationClients are the PG clients of one service provider. A nil client skips the payments
+// it would look up.
+type ReconciliationClients struct {
+	Toss        provider.PaymentLookupProvider // Payment widget orders
+	TossBilling provider.PaymentLookupProvider // Billing key charges
+	Stripe      *client.API
+}
+
+// ReconciliationClientFactory returns the PG clients of a service provider
+type ReconciliationClientFactory func(serviceProvider *model.ServiceProvider) ReconciliationClients
+
+// ServiceProviderReconciliationClients builds the clients of each service provider from its own
+// Toss and Stripe credentials. tossURL and stripeURL override the PG hosts when not blank.
+func ServiceProviderReconciliationClients(tossURL, stripeURL string, logger *zap.Logger) ReconciliationClientFactory {
+	return func(serviceProvider *model.ServiceProvider) ReconciliationClients {
+		var clients ReconciliationClients
+		if tossClient, err := TossClient(serviceProvider, logger); err == nil {
+			if tossURL != "" {
+				tossClient.WithBaseURL(tossURL)
+			}
+			clients.Toss = tossClient
+		}
+		if tossClient, err := TossBillingClient(serviceProvider, logger); err == nil {
+			if tossURL != "" {
+				tossClient.WithBaseURL(tossURL)
+			}
+			clients.TossBilling = tossClient
+		}
+		if stripeClient, err := StripeClient(serviceProvider); err == nil {
+			if stripeURL != "" {
+				stripeClient = stripeProvider.NewAPIClient(serviceProvider.Credentials.StripeSecretKey, stripeURL)
+			}
+			clients.Stripe = stripeClient
+		}
+		return clients
+	}
+}
+
+// ServiceProviderLister lists the service providers of the deployment
+type ServiceProviderLister interface {
+	List(ctx context.Context) ([]*model.ServiceProvider, error)
+	DefaultCode() string
+}
+
+// ReconciliationService compares recent payments with the records of TossPayments and Stripe to
+// catch missed webhooks. Every active service provider is reconciled with its own PG accounts.
+// Payments a PG completed are marked paid and their credits allocated under the same reference the
+// webhooks use, so a late webhook does not allocate them twice. Every other mismatch is only reported.
+type ReconciliationService struct {
+	reconciliationRepo repository.ReconciliationRepository
+	creditRepo         repository.CreditRepository
+	credits            CreditAllocator
+	serviceProviders   ServiceProviderLister
+	clients            ReconciliationClientFactory
+	logger             *zap.Logger
+	now                func() time.Time
+}
+
+// NewReconciliationService creates a new ReconciliationService instance
+func NewReconciliationService(
+	reconciliationRepo repository.ReconciliationRepository,
+	creditRepo repository.CreditRepository,
+	credits CreditAllocator,
+	serviceProviders ServiceProviderLister,
+	clients ReconciliationClientFactory,
+	logger *zap.Logger,
+) *ReconciliationService {
+	return &ReconciliationService{
+		reconciliationRepo: reconciliationRepo,
+		creditRepo:         creditRepo,
+		credits:            credits,
+		serviceProviders:   serviceProviders,
+		clients:            clients,
+		logger:             logger,
+		now:                time.Now,
+	}
+}
+
+// Reconcile compares the payments created in the period with the PGs' records
+func (s *ReconciliationService) Reconcile(ctx context.Context, req ReconciliationRequest) (*ReconciliationReport, error) {
+	if !req.From.Before(req.To) || req.To.Sub(req.From) > MaxReconciliationDays*24*time.Hour {
+		return nil, domainErrors.ErrInvalidReconciliationRange
+	}
+	if req.To.After(s.now()) {
+		req.To = s.now()
+	}
+
+	report := &ReconciliationReport{
+		From:     req.From,
+		To:       req.To,
+		DryRun:   req.DryRun,
+		Findings: []ReconciliationFinding{},
+	}
+
+	serviceProviders, err := s.serviceProviders.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service providers: %w", err)
+	}
+	for _, serviceProvider := range serviceProviders {
+		if !serviceProvider.IsActive {
+			continue
+		}
+		// Payments recorded before they named their service provider belong to the default one
+		codes := []string{serviceProvider.Code}
+		if serviceProvider.Code == s.serviceProviders.DefaultCode() {
+			codes = append(codes, "")
+		}
+
+		clients := s.clients(serviceProvider)
+		if clients.Toss != nil || clients.TossBilling != nil {
+			if err := s.reconcileToss(ctx, req, codes, clients, report); err != nil {
+				return nil, err
+			}
+		}
+		if clients.Stripe != nil {
+			if err := s.reconcileStripe(ctx, req, codes, clients.Stripe, report); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, finding := range report.Findings {
+		if finding.Fixed {
+			report.Fixed++
+		}
+	}
+
+	s.logger.Info("Payment reconciliation finished",
+		zap.Time("from", report.From),
+		zap.Time("to", report.To),
+		zap.Bool("dry_run", report.DryRun),
+		zap.Int("checked", report.Checked),
+		zap.Int("findings", len(report.Findings)),
+		zap.Int("fixed", report.Fixed))
+
+	return report, nil
+}
+
+// reconcileToss looks up every Toss order of the service provider created in the period. Billing
+// key charges are looked up with the billing key client and payment widget orders with the widget
+// client, as each is only visible to the key it was made with.
+func (s *ReconciliationService) reconcileToss(ctx context.Context, req ReconciliationRequest, serviceProviders []string, clients ReconciliationClients, report *ReconciliationReport) error {
+	payments, err := s.reconciliationRepo.ListPayments(ctx, string(provider.ProviderTypeToss), serviceProviders, req.From, req.To)
+	if err != nil {
+		return err
+	}
+
+	for _, payment := range payments {
+		if payment.ProviderInvoiceID == nil || *payment.ProviderInvoiceID == "" {
+			continue
+		}
+		orderID := *payment.ProviderInvoiceID
+		report.Checked++
+
+		finding := ReconciliationFinding{
+			Provider:        string(provider.ProviderTypeToss),
+			ServiceProvider: serviceProviders[0],
+			PaymentID:       payment.ID,
+			OrderID:         orderID,
+			UniversalID:     payment.UniversalID.String(),
+			Status:          payment.Status,
+			Amount:          int64(payment.AmountCents),
+			Currency:        payment.Currency,
+		}
+
+		lookup, keyName := clients.Toss, "payment widget"
+		if payment.ProviderPaymentData["billing_key_id"] != nil {
+			lookup, keyName = clients.TossBilling, "billing"
+		}
+		if lookup == nil {
+			finding.Issue = ReconciliationLookupFailed
+			finding.Detail = "service provider has no Toss " + keyName + " secret key"
+			report.Findings = append(report.Findings, finding)
+			continue
+		}
+
+		record, err := lookup.LookupPayment(ctx, orderID)
+		if err != nil {
+			finding.Issue = ReconciliationLookupFailed
+			finding.Detail = err.Error()
+			report.Findings = append(report.Findings, finding)
+			continue
+		}
+
+		completed := payment.Status == string(entity.PaymentStatusCompleted)
+		if record == nil {
+			// Unpaid orders are abandoned checkouts, but a completed one should exist at Toss
+			if completed {
+				finding.Issue = ReconciliationStatusMismatch
+				finding.Detail = "payment not found at TossPayments"
+				report.Findings = append(report.Findings, finding)
+			}
+			continue
+		}
+		finding.ProviderStatus = record.ProviderStatus
+		finding.ProviderAmount = record.Amount
+
+		if record.Status == provider.PaymentStatusPending {
+			continue
+		}
+
+		if record.Status == provider.PaymentStatusCompleted {
+			if record.Amount != int64(payment.AmountCents) || (record.Currency != "" && !strings.EqualFold(record.Currency, payment.Currency)) {
+				finding.Issue = ReconciliationAmountMismatch
+				report.Findings = append(report.Findings, finding)
+				continue
+			}
+
+			if !completed {
+				if !isPendingPaymentStatus(payment.Status) {
+					finding.Issue = ReconciliationStatusMismatch
+					finding.Detail = "paid at TossPayments but " + payment.Status + " here"
+					report.Findings = append(report.Findings, finding)
+					continue
+				}
+
+				updates := map[string]interface{}{
+					"status":                     string(entity.PaymentStatusCompleted),
+					"provider_payment_intent_id": record.PaymentKey,
+					"provider_charge_id":         record.TransactionKey,
+					"paid_at":                    s.paidAt(record.PaidAt),
+				}
+				if !s.markPaid(ctx, payment, updates, req.DryRun, &finding, report) {
+					continue
+				}
+			}
+
+			s.ensureCredits(ctx, payment, orderID, "", paymentMetadataString(payment.ProviderPaymentData, "plan_id"), 1, req.DryRun, finding, report)
+			continue
+		}
+
+		// Canceled, refunded or failed at Toss: only completed payments contradict that
+		if completed {
+			finding.Issue = ReconciliationStatusMismatch
+			finding.Detail = "completed here but " + string(record.Status) + " at TossPayments"
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+
+	return nil
+}
+
+// reconcileStripe compares the paid invoices of the service provider's Stripe account in the period
+// with our payments and checks the payment intents of its Stripe payments we still have pending
+func (s *ReconciliationService) reconcileStripe(ctx context.Context, req ReconciliationRequest, serviceProviders []string, stripeClient *client.API, report *ReconciliationReport) error {
+	seen := make(map[int64]bool)
+
+	params := &stripe.InvoiceListParams{
+		Status: stripe.String(string(stripe.InvoiceStatusPaid)),
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: req.From.Unix(),
+			LesserThan:         req.To.Unix(),
+		},
+	}
+	params.Context = ctx
+
+	invoices := stripeClient.Invoices.List(params)
+	for invoices.Next() {
+		invoice := invoices.Invoice()
+		report.Checked++
+
+		paymentIntentID := ""
+		if invoice.PaymentIntent != nil {
+			paymentIntentID = invoice.PaymentIntent.ID
+		}
+
+		finding := ReconciliationFinding{
+			Provider:        string(provider.ProviderTypeStripe),
+			ServiceProvider: serviceProviders[0],
+			OrderID:         invoice.ID,
+			ProviderStatus:  string(invoice.Status),
+			ProviderAmount:  invoice.AmountPaid,
+			Currency:        string(invoice.Currency),
+		}
+
+		payment, err := s.reconciliationRepo.GetStripePayment(ctx, invoice.ID, paymentIntentID)
+		if err != nil {
+			return err
+		}
+		if payment == nil {
+			// Recording it needs the customer and plan resolution of the invoice.paid webhook
+			finding.Issue = ReconciliationMissingPayment
+			finding.Detail = "resend the invoice.paid webhook from the Stripe dashboard"
+			report.Findings = append(report.Findings, finding)
+			continue
+		}
+		seen[payment.ID] = true
+
+		finding.PaymentID = payment.ID
+		finding.UniversalID = payment.UniversalID.String()
+		finding.Status = payment.Status
+		finding.Amount = int64(payment.AmountCents)
+
+		if invoice.AmountPaid != int64(payment.AmountCents) || !strings.EqualFold(string(invoice.Currency), payment.Currency) {
+			finding.Issue = ReconciliationAmountMismatch
+			report.Findings = append(report.Findings, finding)
+			continue
+		}
+
+		if payment.Status != string(entity.PaymentStatusCompleted) {
+			if !isPendingPaymentStatus(payment.Status) {
+				finding.Issue = ReconciliationStatusMismatch
+				finding.Detail = "paid at Stripe but " + payment.Status + " here"
+				report.Findings = append(report.Findings, finding)
+				continue
+			}
+
+			updates := map[string]interface{}{
+				"status":  string(entity.PaymentStatusCompleted),
+				"paid_at": s.paidAt(stripeInvoicePaidAt(invoice)),
+			}
+			if !s.markPaid(ctx, payment, updates, req.DryRun, &finding, report) {
+				continue
+			}
+		}
+
+		lineIndex := 0
+		if invoice.BillingReason == stripe.InvoiceBillingReasonSubscriptionUpdate {
+			var lines []StripeInvoiceLine
+			if invoice.Lines != nil {
+				for _, line := range invoice.Lines.Data {
+					entry := StripeInvoiceLine{Quantity: line.Quantity, Amount: line.Amount}
+					if line.Price != nil {
+						entry.PriceID = line.Price.ID
+					}
+					lines = append(lines, entry)
+				}
+			}
+			index, ok := SubscriptionUpdateCreditLine(lines)
+			if !ok {
+				continue
+			}
+			lineIndex = index
+		}
+		var subscriptionID, priceID string
+		seats := 1
+		if invoice.Subscription != nil {
+			subscriptionID = invoice.Subscription.ID
+		}
+		if invoice.Lines != nil && len(invoice.Lines.Data) > lineIndex {
+			line := invoice.Lines.Data[lineIndex]
+			if line.Price != nil {
+				priceID = line.Price.ID
+			}
+			if line.Quantity > 0 {
+				seats = int(line.Quantity)
+			}
+		}
+		if subscriptionID != "" && priceID != "" {
+			s.ensureCredits(ctx, payment, invoice.ID, subscriptionID, priceID, seats, req.DryRun, finding, report)
+		}
+	}
+	if err := invoices.Err(); err != nil {
+		s.logger.Error("Failed to list Stripe invoices for reconciliation", zap.Error(err))
+		return fmt.Errorf("failed to list Stripe invoices: %w", err)
+	}
+
+	payments, err := s.reconciliationRepo.ListPayments(ctx, string(provider.ProviderTypeStripe), serviceProviders, req.From, req.To)
+	if err != nil {
+		return err
+	}
+	for _, payment := range payments {
+		if seen[payment.ID] || !isPendingPaymentStatus(payment.Status) {
+			continue
+		}
+		if payment.ProviderPaymentIntentID == nil || !strings.HasPrefix(*payment.ProviderPaymentIntentID, "pi_") {
+			continue
+		}
+		report.Checked++
+
+		finding := ReconciliationFinding{
+			Provider:        string(provider.ProviderTypeStripe),
+			ServiceProvider: serviceProviders[0],
+			PaymentID:       payment.ID,
+			OrderID:         paymentMetadataString(payment.ProviderPaymentData, "provider_invoice_id"),
+			UniversalID:     payment.UniversalID.String(),
+			Status:          payment.Status,
+			Amount:          int64(payment.AmountCents),
+			Currency:        payment.Currency,
+		}
+
+		params := &stripe.PaymentIntentParams{}
+		params.Context = ctx
+		intent, err := stripeClient.PaymentIntents.Get(*payment.ProviderPaymentIntentID, params)
+		if err != nil {
+			finding.Issue = ReconciliationLookupFailed
+			finding.Detail = err.Error()
+			report.Findings = append(report.Findings, finding)
+			continue
+		}
+		finding.ProviderStatus = string(intent.Status)
+		finding.ProviderAmount = intent.AmountReceived
+
+		switch intent.Status {
+		case stripe.PaymentIntentStatusSucceeded:
+			if intent.AmountReceived != int64(payment.AmountCents) || !strings.EqualFold(string(intent.Currency), payment.Currency) {
+				finding.Issue = ReconciliationAmountMismatch
+				report.Findings = append(report.Findings, finding)
+				continue
+			}
+			// Credits of invoice payments are allocated when their invoice is reconciled
+			updates := map[string]interface{}{
+				"status":  string(entity.PaymentStatusCompleted),
+				"paid_at": s.now(),
+			}
+			s.markPaid(ctx, payment, updates, req.DryRun, &finding, report)
+		case stripe.PaymentIntentStatusCanceled:
+			finding.Issue = ReconciliationStatusMismatch
+			finding.Detail = "canceled at Stripe but " + payment.Status + " here"
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+
+	return nil
+}
+
+// markPaid applies the updates that complete a payment and records the fix. It reports whether the
+// payment counts as paid from here on.
+func (s *ReconciliationService) markPaid(ctx context.Context, payment *model.Payment, updates map[string]interface{}, dryRun bool, finding *ReconciliationFinding, report *ReconciliationReport) bool {
+	finding.Issue = ReconciliationUnpaid
+
+	if !dryRun {
+		if err := s.reconciliationRepo.UpdatePayment(ctx, payment.ID, updates); err != nil {
+			finding.Detail = err.Error()
+			report.Findings = append(report.Findings, *finding)
+			return false
+		}
+		finding.Fixed = true
+		payment.Status = string(entity.PaymentStatusCompleted)
+
+		s.logger.Info("Payment marked as completed by reconciliation",
+			zap.String("provider", finding.Provider),
+			zap.Int64("payment_id", payment.ID),
+			zap.String("order_id", finding.OrderID))
+	}
+
+	report.Findings = append(report.Findings, *finding)
+	return true
+}
+
+// ensureCredits allocates the credits of a completed payment unless a credit transaction already
+// references it
+func (s *ReconciliationService) ensureCredits(ctx context.Context, payment *model.Payment, reference, subscriptionID, planID string, seats int, dryRun bool, finding ReconciliationFinding, report *ReconciliationReport) {
+	if s.credits == nil || planID == "" {
+		return
+	}
+
+	existing, err := s.creditRepo.GetTransactionByReference(ctx, reference)
+	if err != nil {
+		finding.Issue = ReconciliationLookupFailed
+		finding.Fixed = false
+		finding.Detail = err.Error()
+		report.Findings = append(report.Findings, finding)
+		return
+	}
+	if existing != nil {
+		return
+	}
+
+	finding.Issue = ReconciliationCreditsMissing
+	finding.Fixed = false
+	finding.Status = payment.Status
+	if dryRun {
+		report.Findings = append(report.Findings, finding)
+		return
+	}
+
+	serviceProvider := paymentMetadataString(payment.ProviderPaymentData, "service_provider")
+	allocated, err := s.credits.AllocateCreditsForPayment(ctx, payment.UniversalID, reference, subscriptionID, planID, seats, serviceProvider)
+	if err != nil {
+		finding.Detail = err.Error()
+		report.Findings = append(report.Findings, finding)
+		return
+	}
+	finding.Fixed = true
+	finding.Credits = allocated
+	report.Findings = append(report.Findings, finding)
+
+	if allocated == 0 {
+		return
+	}
+	updates := map[string]interface{}{
+		"credits_allocated":    decimal.NewFromInt(int64(allocated)),
+		"credits_allocated_at": s.now(),
+	}
+	if err := s.reconciliationRepo.UpdatePayment(ctx, payment.ID, updates); err != nil {
+		s.logger.Error("Failed to update payment after credit allocation",
+			zap.Int64("payment_id", payment.ID),
+			zap.Int("credits", allocated),
+			zap.Error(err))
+	}
+}
+
+// isPendingPaymentStatus reports whether a payment is still waiting for the PG
+func isPendingPaymentStatus(status string) bool {
+	return status == string(entity.PaymentStatusPending) || status == string(entity.PaymentStatusProcessing)
+}
+
+// paymentMetadataString reads a string from payment data, falling back to the metadata nested in
+// the Toss payment object that refund webhooks store
+func paymentMetadataString(data model.JSONB, key string) string {
+	if value, ok := data[key].(string); ok && value != "" {
+		return value
+	}
+	if nested, ok := data["metadata"].(map[string]interface{}); ok {
+		if value, ok := nested[key].(string); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// stripeInvoicePaidAt returns when Stripe recorded an invoice as paid
+func stripeInvoicePaidAt(invoice *stripe.Invoice) *time.Time {
+	if invoice.StatusTransitions == nil || invoice.StatusTransitions.PaidAt == 0 {
+		return nil
+	}
+	paidAt := time.Unix(invoice.StatusTransitions.PaidAt, 0)
+	return &paidAt
+}
+
+// paidAt is the paid_at of a payment the PG completed at paidAt, if known
+func (s *ReconciliationService) paidAt(paidAt *time.Time) time.Time {
+	if paidAt == nil {
+		return s.now()
+	}
+	return *paidAt
+}
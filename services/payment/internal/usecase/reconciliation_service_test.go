@@ -0,0 +1,390 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	stripeProvider "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/provider/stripe"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/provider/toss"
+	"go.uber.org/zap"
+)
+
+// fakeReconciliationRepository serves fixed payments and records updates
+type fakeReconciliationRepository struct {
+	payments map[string][]*model.Payment
+	updates  map[int64]map[string]interface{}
+}
+
+func (r *fakeReconciliationRepository) ListPayments(ctx context.Context, pgProvider string, serviceProviders []string, from, to time.Time) ([]*model.Payment, error) {
+	var payments []*model.Payment
+	for _, payment := range r.payments[pgProvider] {
+		for _, serviceProvider := range serviceProviders {
+			if paymentMetadataString(payment.ProviderPaymentData, "service_provider") == serviceProvider {
+				payments = append(payments, payment)
+				break
+			}
+		}
+	}
+	return payments, nil
+}
+
+func (r *fakeReconciliationRepository) GetStripePayment(ctx context.Context, invoiceID, paymentIntentID string) (*model.Payment, error) {
+	for _, payment := range r.payments["stripe"] {
+		if payment.ProviderPaymentIntentID != nil && *payment.ProviderPaymentIntentID == paymentIntentID {
+			return payment, nil
+		}
+		if payment.ProviderPaymentData["provider_invoice_id"] == invoiceID {
+			return payment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeReconciliationRepository) UpdatePayment(ctx context.Context, id int64, updates map[string]interface{}) error {
+	if r.updates[id] == nil {
+		r.updates[id] = map[string]interface{}{}
+	}
+	for key, value := range updates {
+		r.updates[id][key] = value
+	}
+	return nil
+}
+
+// MockCreditAllocator is a mock implementation of CreditAllocator
+type MockCreditAllocator struct {
+	mock.Mock
+}
+
+func (m *MockCreditAllocator) AllocateCreditsForPayment(ctx context.Context, universalID uuid.UUID, invoiceID string, subscriptionID string, stripePriceID string, seats int, serviceProviderOverride string) (int, error) {
+	args := m.Called(ctx, universalID, invoiceID, subscriptionID, stripePriceID, seats, serviceProviderOverride)
+	return args.Int(0), args.Error(1)
+}
+
+// fakeServiceProviders lists fixed service providers, the first being the default
+type fakeServiceProviders []*model.ServiceProvider
+
+func (p fakeServiceProviders) List(ctx context.Context) ([]*model.ServiceProvider, error) {
+	return p, nil
+}
+
+func (p fakeServiceProviders) DefaultCode() string {
+	return p[0].Code
+}
+
+// newTossFake serves GET /v1/payments/orders/{orderId} from payments keyed by order ID to the
+// Toss account of secretKey
+func newTossFake(t *testing.T, secretKey string, payments map[string]map[string]interface{}) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key, _, _ := r.BasicAuth(); key != secretKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"code": "UNAUTHORIZED_KEY", "message": "unauthorized"})
+			return
+		}
+		orderID := strings.TrimPrefix(r.URL.Path, "/v1/payments/orders/")
+		payment, ok := payments[orderID]
+		if r.Method != http.MethodGet || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"code": "NOT_FOUND_PAYMENT", "message": "not found"})
+			return
+		}
+		json.NewEncoder(w).Encode(payment)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newStripeFake serves the invoice list and payment intents of the Stripe account of secretKey
+func newStripeFake(t *testing.T, secretKey string, invoices []map[string]interface{}, intents map[string]map[string]interface{}) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Header.Get("Authorization") != "Bearer "+secretKey:
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]string{"type": "invalid_request_error", "message": "Invalid API Key"}})
+		case r.URL.Path == "/v1/invoices":
+			assert.Equal(t, "paid", r.URL.Query().Get("status"))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"object":   "list",
+				"url":      "/v1/invoices",
+				"has_more": false,
+				"data":     invoices,
+			})
+		case strings.HasPrefix(r.URL.Path, "/v1/payment_intents/"):
+			intent, ok := intents[strings.TrimPrefix(r.URL.Path, "/v1/payment_intents/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]string{"type": "invalid_request_error", "message": "No such payment_intent"}})
+				return
+			}
+			json.NewEncoder(w).Encode(intent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestReconciliationService_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	tossFake := newTossFake(t, "test_sk", map[string]map[string]interface{}{
+		"order-paid":     {"orderId": "order-paid", "paymentKey": "pk_1", "transactionKey": "tk_1", "status": "DONE", "totalAmount": 9900, "currency": "KRW", "approvedAt": "2025-06-10T09:00:00+09:00"},
+		"order-short":    {"orderId": "order-short", "paymentKey": "pk_2", "status": "DONE", "totalAmount": 100, "currency": "KRW"},
+		"order-canceled": {"orderId": "order-canceled", "paymentKey": "pk_3", "status": "CANCELED", "totalAmount": 9900, "currency": "KRW"},
+	})
+	stripeFake := newStripeFake(t, "sk_test",
+		[]map[string]interface{}{
+			{
+				"id": "in_missing", "object": "invoice", "status": "paid", "amount_paid": 1500, "currency": "usd",
+				"billing_reason": "subscription_cycle", "payment_intent": "pi_missing",
+			},
+			{
+				"id": "in_recorded", "object": "invoice", "status": "paid", "amount_paid": 1999, "currency": "usd",
+				"billing_reason": "subscription_cycle", "payment_intent": "pi_recorded", "subscription": "sub_1",
+				"lines": map[string]interface{}{
+					"object": "list",
+					"data": []map[string]interface{}{
+						{"id": "il_1", "object": "line_item", "quantity": 3, "price": map[string]interface{}{"id": "price_team", "object": "price"}},
+					},
+				},
+			},
+		},
+		map[string]map[string]interface{}{
+			"pi_pending": {"id": "pi_pending", "object": "payment_intent", "status": "succeeded", "amount_received": 500, "currency": "usd"},
+		},
+	)
+
+	newService := func(repo *fakeReconciliationRepository, creditRepo *MockCreditRepository, allocator *MockCreditAllocator) *ReconciliationService {
+		tossProvider := toss.NewTossProvider("test_sk", "test_ck", zap.NewNop()).WithBaseURL(tossFake.URL)
+		stripeClient := stripeProvider.NewAPIClient("sk_test", stripeFake.URL)
+		clients := func(serviceProvider *model.ServiceProvider) ReconciliationClients {
+			return ReconciliationClients{Toss: tossProvider, Stripe: stripeClient}
+		}
+		serviceProviders := fakeServiceProviders{{Code: "semo", IsActive: true}}
+		service := NewReconciliationService(repo, creditRepo, allocator, serviceProviders, clients, zap.NewNop())
+		service.now = func() time.Time { return now }
+		return service
+	}
+	newRepo := func() *fakeReconciliationRepository {
+		return &fakeReconciliationRepository{
+			payments: map[string][]*model.Payment{
+				"toss": {
+					{ID: 1, UniversalID: userID, ProviderInvoiceID: stringPtr("order-paid"), AmountCents: 9900, Currency: "KRW", Status: "pending",
+						ProviderPaymentData: model.JSONB{"plan_id": "plan_basic", "service_provider": "semo"}},
+					{ID: 2, UniversalID: userID, ProviderInvoiceID: stringPtr("order-abandoned"), AmountCents: 9900, Currency: "KRW", Status: "pending"},
+					{ID: 3, UniversalID: userID, ProviderInvoiceID: stringPtr("order-short"), AmountCents: 9900, Currency: "KRW", Status: "pending"},
+					{ID: 4, UniversalID: userID, ProviderInvoiceID: stringPtr("order-canceled"), AmountCents: 9900, Currency: "KRW", Status: "completed"},
+				},
+				"stripe": {
+					{ID: 5, UniversalID: userID, ProviderPaymentIntentID: stringPtr("pi_recorded"), AmountCents: 1999, Currency: "usd", Status: "completed",
+						ProviderPaymentData: model.JSONB{"provider_invoice_id": "in_recorded", "service_provider": "semo"}},
+					{ID: 6, UniversalID: userID, ProviderPaymentIntentID: stringPtr("pi_pending"), AmountCents: 500, Currency: "usd", Status: "pending"},
+				},
+			},
+			updates: map[int64]map[string]interface{}{},
+		}
+	}
+	req := ReconciliationRequest{From: now.Add(-72 * time.Hour), To: now}
+
+	t.Run("fixes unpaid payments and missing credits and reports the rest", func(t *testing.T) {
+		repo := newRepo()
+		creditRepo := new(MockCreditRepository)
+		allocator := new(MockCreditAllocator)
+		creditRepo.On("GetTransactionByReference", ctx, "order-paid").Return(nil, nil)
+		creditRepo.On("GetTransactionByReference", ctx, "in_recorded").Return(nil, nil)
+		allocator.On("AllocateCreditsForPayment", ctx, userID, "order-paid", "", "plan_basic", 1, "semo").Return(100, nil)
+		allocator.On("AllocateCreditsForPayment", ctx, userID, "in_recorded", "sub_1", "price_team", 3, "semo").Return(300, nil)
+
+		report, err := newService(repo, creditRepo, allocator).Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		issues := map[string]ReconciliationIssue{}
+		for _, finding := range report.Findings {
+			key := finding.OrderID
+			if finding.Issue == ReconciliationCreditsMissing {
+				key += "/credits"
+			}
+			if key == "" {
+				key = "payment-6"
+			}
+			issues[key] = finding.Issue
+		}
+		assert.Equal(t, map[string]ReconciliationIssue{
+			"order-paid":          ReconciliationUnpaid,
+			"order-paid/credits":  ReconciliationCreditsMissing,
+			"order-short":         ReconciliationAmountMismatch,
+			"order-canceled":      ReconciliationStatusMismatch,
+			"in_missing":          ReconciliationMissingPayment,
+			"in_recorded/credits": ReconciliationCreditsMissing,
+			"payment-6":           ReconciliationUnpaid,
+		}, issues)
+		assert.Equal(t, 4, report.Fixed)
+		assert.Equal(t, 7, report.Checked)
+
+		assert.Equal(t, "completed", repo.updates[1]["status"])
+		assert.Equal(t, "pk_1", repo.updates[1]["provider_payment_intent_id"])
+		assert.True(t, time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC).Equal(repo.updates[1]["paid_at"].(time.Time)))
+		assert.Equal(t, "100", repo.updates[1]["credits_allocated"].(interface{ String() string }).String())
+		assert.Equal(t, "300", repo.updates[5]["credits_allocated"].(interface{ String() string }).String())
+		assert.Equal(t, "completed", repo.updates[6]["status"])
+		assert.NotContains(t, repo.updates, int64(3))
+		assert.NotContains(t, repo.updates, int64(4))
+		allocator.AssertExpectations(t)
+	})
+
+	t.Run("already allocated credits are left alone", func(t *testing.T) {
+		repo := newRepo()
+		creditRepo := new(MockCreditRepository)
+		allocator := new(MockCreditAllocator)
+		creditRepo.On("GetTransactionByReference", ctx, mock.Anything).Return(&model.CreditTransaction{}, nil)
+
+		report, err := newService(repo, creditRepo, allocator).Reconcile(ctx, req)
+		require.NoError(t, err)
+
+		for _, finding := range report.Findings {
+			assert.NotEqual(t, ReconciliationCreditsMissing, finding.Issue)
+		}
+		allocator.AssertNotCalled(t, "AllocateCreditsForPayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		repo := newRepo()
+		creditRepo := new(MockCreditRepository)
+		allocator := new(MockCreditAllocator)
+		creditRepo.On("GetTransactionByReference", ctx, mock.Anything).Return(nil, nil)
+
+		dryRun := req
+		dryRun.DryRun = true
+		report, err := newService(repo, creditRepo, allocator).Reconcile(ctx, dryRun)
+		require.NoError(t, err)
+
+		assert.Zero(t, report.Fixed)
+		assert.Len(t, report.Findings, 7)
+		assert.Empty(t, repo.updates)
+		allocator.AssertNotCalled(t, "AllocateCreditsForPayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("period is bounded", func(t *testing.T) {
+		service := newService(newRepo(), new(MockCreditRepository), new(MockCreditAllocator))
+
+		_, err := service.Reconcile(ctx, ReconciliationRequest{From: now, To: now})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidReconciliationRange)
+
+		_, err = service.Reconcile(ctx, ReconciliationRequest{From: now.AddDate(0, 0, -(MaxReconciliationDays + 1)), To: now})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidReconciliationRange)
+	})
+}
+
+func TestReconciliationService_ReconcilesEachServiceProvider(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	done := func(orderID string) map[string]map[string]interface{} {
+		return map[string]map[string]interface{}{
+			orderID: {"orderId": orderID, "paymentKey": "pk_" + orderID, "status": "DONE", "totalAmount": 9900, "currency": "KRW"},
+		}
+	}
+	paidInvoice := func(invoiceID string) []map[string]interface{} {
+		return []map[string]interface{}{
+			{"id": invoiceID, "object": "invoice", "status": "paid", "amount_paid": 1500, "currency": "usd", "billing_reason": "manual"},
+		}
+	}
+	succeeded := func(intentID string) map[string]map[string]interface{} {
+		return map[string]map[string]interface{}{
+			intentID: {"id": intentID, "object": "payment_intent", "status": "succeeded", "amount_received": 500, "currency": "usd"},
+		}
+	}
+
+	// Every order, invoice and intent only exists at the account of its own service provider
+	semoWidget := newTossFake(t, "semo_sk", done("semo-widget"))
+	semoBilling := newTossFake(t, "semo_billing_sk", done("semo-billing"))
+	acmeWidget := newTossFake(t, "acme_sk", done("acme-widget"))
+	acmeBilling := newTossFake(t, "acme_billing_sk", done("acme-billing"))
+	semoStripe := newStripeFake(t, "sk_semo", paidInvoice("in_semo"), succeeded("pi_semo_pending"))
+	acmeStripe := newStripeFake(t, "sk_acme", paidInvoice("in_acme"), succeeded("pi_acme_pending"))
+	hosts := map[string]map[string]string{
+		"semo": {"toss": semoWidget.URL, "billing": semoBilling.URL, "stripe": semoStripe.URL},
+		"acme": {"toss": acmeWidget.URL, "billing": acmeBilling.URL, "stripe": acmeStripe.URL},
+	}
+
+	serviceProviders := fakeServiceProviders{
+		{Code: "semo", IsActive: true, Credentials: model.ServiceProviderCredentials{
+			TossSecretKey: "semo_sk", TossBillingSecretKey: "semo_billing_sk", StripeSecretKey: "sk_semo"}},
+		{Code: "acme", IsActive: true, Credentials: model.ServiceProviderCredentials{
+			TossSecretKey: "acme_sk", TossBillingSecretKey: "acme_billing_sk", StripeSecretKey: "sk_acme"}},
+		{Code: "retired", IsActive: false, Credentials: model.ServiceProviderCredentials{TossSecretKey: "retired_sk"}},
+	}
+	clients := func(serviceProvider *model.ServiceProvider) ReconciliationClients {
+		require.True(t, serviceProvider.IsActive, "inactive service provider %s reconciled", serviceProvider.Code)
+		host := hosts[serviceProvider.Code]
+		credentials := serviceProvider.Credentials
+		return ReconciliationClients{
+			Toss:        toss.NewTossProvider(credentials.TossSecretKey, "", zap.NewNop()).WithBaseURL(host["toss"]),
+			TossBilling: toss.NewTossProvider(credentials.TossBillingSecretKey, "", zap.NewNop()).WithBaseURL(host["billing"]),
+			Stripe:      stripeProvider.NewAPIClient(credentials.StripeSecretKey, host["stripe"]),
+		}
+	}
+
+	pending := func(id int64, data model.JSONB) *model.Payment {
+		return &model.Payment{ID: id, UniversalID: userID, AmountCents: 9900, Currency: "KRW", Status: "pending", ProviderPaymentData: data}
+	}
+	pendingIntent := func(id int64, intentID string, amount int, data model.JSONB) *model.Payment {
+		return &model.Payment{ID: id, UniversalID: userID, ProviderPaymentIntentID: stringPtr(intentID), AmountCents: amount, Currency: "usd", Status: "pending", ProviderPaymentData: data}
+	}
+	tossPayments := []*model.Payment{
+		pending(1, model.JSONB{}),
+		pending(2, model.JSONB{"service_provider": "semo", "billing_key_id": float64(7)}),
+		pending(3, model.JSONB{"service_provider": "acme"}),
+		pending(4, model.JSONB{"service_provider": "acme", "billing_key_id": float64(8)}),
+	}
+	for i, orderID := range []string{"semo-widget", "semo-billing", "acme-widget", "acme-billing"} {
+		tossPayments[i].ProviderInvoiceID = stringPtr(orderID)
+	}
+	repo := &fakeReconciliationRepository{
+		payments: map[string][]*model.Payment{
+			"toss": tossPayments,
+			"stripe": {
+				pendingIntent(5, "pi_in_semo", 1500, model.JSONB{"provider_invoice_id": "in_semo", "service_provider": "semo"}),
+				pendingIntent(6, "pi_in_acme", 1500, model.JSONB{"provider_invoice_id": "in_acme", "service_provider": "acme"}),
+				pendingIntent(7, "pi_semo_pending", 500, model.JSONB{}),
+				pendingIntent(8, "pi_acme_pending", 500, model.JSONB{"service_provider": "acme"}),
+			},
+		},
+		updates: map[int64]map[string]interface{}{},
+	}
+
+	service := NewReconciliationService(repo, new(MockCreditRepository), new(MockCreditAllocator), serviceProviders, clients, zap.NewNop())
+	service.now = func() time.Time { return now }
+
+	report, err := service.Reconcile(ctx, ReconciliationRequest{From: now.Add(-72 * time.Hour), To: now})
+	require.NoError(t, err)
+
+	servedBy := map[int64]string{}
+	for _, finding := range report.Findings {
+		assert.Equal(t, ReconciliationUnpaid, finding.Issue, "payment %d: %s", finding.PaymentID, finding.Detail)
+		servedBy[finding.PaymentID] = finding.ServiceProvider
+	}
+	assert.Equal(t, map[int64]string{1: "semo", 2: "semo", 3: "acme", 4: "acme", 5: "semo", 6: "acme", 7: "semo", 8: "acme"}, servedBy)
+	for id := int64(1); id <= 8; id++ {
+		assert.Equal(t, "completed", repo.updates[id]["status"], "payment %d", id)
+	}
+}
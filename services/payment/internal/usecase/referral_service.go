@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// referralCodeAlphabet leaves out characters that are easily confused when read aloud or typed
+const referralCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+const (
+	referralCodeLength   = 8
+	referralCodeAttempts = 5
+)
+
+// ReferralSummary describes a user's referral code and how their referrals are doing
+type ReferralSummary struct {
+	Code            string `json:"code"`
+	Pending         int64  `json:"pending"`
+	Rewarded        int64  `json:"rewarded"`
+	ReferrerCredits int    `json:"referrer_credits"` // Credits the user receives per rewarded referral
+	RefereeCredits  int    `json:"referee_credits"`  // Credits the referred user receives
+	MaxRewards      int    `json:"max_rewards,omitempty"`
+}
+
+// ReferralService runs the referral program: users share a code, and both sides receive credits
+// once the referred user's first payment succeeds
+type ReferralService struct {
+	referralRepo  repository.ReferralRepository
+	paymentRepo   repository.PaymentRepository
+	creditService *CreditService
+	config        config.ReferralConfig
+	logger        *zap.Logger
+	now           func() time.Time
+}
+
+// NewReferralService creates a new ReferralService instance
+func NewReferralService(
+	referralRepo repository.ReferralRepository,
+	paymentRepo repository.PaymentRepository,
+	creditService *CreditService,
+	cfg config.ReferralConfig,
+	logger *zap.Logger,
+) *ReferralService {
+	return &ReferralService{
+		referralRepo:  referralRepo,
+		paymentRepo:   paymentRepo,
+		creditService: creditService,
+		config:        cfg,
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+// GetSummary returns the user's referral code, creating it on first use, and their referral counts
+func (s *ReferralService) GetSummary(ctx context.Context, universalID uuid.UUID) (*ReferralSummary, error) {
+	if !s.config.Enabled {
+		return nil, domainErrors.ErrReferralProgramDisabled
+	}
+
+	code, err := s.getOrCreateCode(ctx, universalID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.referralRepo.CountByReferrer(ctx, universalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReferralSummary{
+		Code:            code.Code,
+		Pending:         counts[model.ReferralStatusPending],
+		Rewarded:        counts[model.ReferralStatusRewarded],
+		ReferrerCredits: s.config.ReferrerCredits,
+		RefereeCredits:  s.config.RefereeCredits,
+		MaxRewards:      s.config.MaxRewards,
+	}, nil
+}
+
+// Redeem links a user to the owner of a referral code. Only users who have not paid yet can be
+// referred, and each user only once.
+func (s *ReferralService) Redeem(ctx context.Context, refereeID uuid.UUID, code string) (*model.Referral, error) {
+	if !s.config.Enabled {
+		return nil, domainErrors.ErrReferralProgramDisabled
+	}
+
+	owner, err := s.referralRepo.GetCodeByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil {
+		return nil, domainErrors.ErrReferralCodeNotFound
+	}
+	if owner.UniversalID == refereeID {
+		return nil, domainErrors.ErrSelfReferral
+	}
+
+	existing, err := s.referralRepo.GetByReferee(ctx, refereeID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, domainErrors.ErrAlreadyReferred
+	}
+
+	// Two accounts referring each other would earn both rewards twice
+	reverse, err := s.referralRepo.GetByReferee(ctx, owner.UniversalID)
+	if err != nil {
+		return nil, err
+	}
+	if reverse != nil && reverse.ReferrerID == refereeID {
+		return nil, fmt.Errorf("%w: accounts cannot refer each other", domainErrors.ErrReferralNotEligible)
+	}
+
+	paid, err := s.paymentRepo.CountCompleted(ctx, refereeID.String())
+	if err != nil {
+		return nil, err
+	}
+	if paid > 0 {
+		return nil, fmt.Errorf("%w: referral codes must be redeemed before the first payment", domainErrors.ErrReferralNotEligible)
+	}
+
+	referral := &model.Referral{
+		ReferrerID: owner.UniversalID,
+		RefereeID:  refereeID,
+		Code:       owner.Code,
+		Status:     model.ReferralStatusPending,
+	}
+	if err := s.referralRepo.Create(ctx, referral); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Referral code redeemed",
+		zap.Int64("referral_id", referral.ID),
+		zap.String("referrer_id", owner.UniversalID.String()),
+		zap.String("referee_id", refereeID.String()))
+
+	return referral, nil
+}
+
+// RewardFirstPayment grants the referral credits to both parties after the referee's payment
+// succeeds. Referrals are redeemed before any payment, so a pending referral means this is the first
+// one. It is a no-op for users without a pending referral and safe to call more than once.
+func (s *ReferralService) RewardFirstPayment(ctx context.Context, refereeID string, serviceProvider string, paymentRef string) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	id, err := uuid.Parse(refereeID)
+	if err != nil {
+		return nil
+	}
+
+	referral, err := s.referralRepo.GetByReferee(ctx, id)
+	if err != nil {
+		return err
+	}
+	if referral == nil || referral.Status != model.ReferralStatusPending {
+		return nil
+	}
+
+	if s.config.MaxRewards > 0 {
+		counts, err := s.referralRepo.CountByReferrer(ctx, referral.ReferrerID)
+		if err != nil {
+			return err
+		}
+		if counts[model.ReferralStatusRewarded] >= int64(s.config.MaxRewards) {
+			referral.Status = model.ReferralStatusRejected
+			if _, err := s.referralRepo.UpdateStatus(ctx, referral); err != nil {
+				return err
+			}
+			s.logger.Info("Referral not rewarded; referrer reached the reward limit",
+				zap.Int64("referral_id", referral.ID),
+				zap.String("referrer_id", referral.ReferrerID.String()))
+			return nil
+		}
+	}
+
+	// Credits go out before the referral is marked rewarded; the reference IDs make a retry after
+	// a partial failure grant each side only once
+	grants := []struct {
+		universalID uuid.UUID
+		credits     int
+		description string
+		side        string
+	}{
+		{referral.RefereeID, s.config.RefereeCredits, "Referral bonus for joining", "referee"},
+		{referral.ReferrerID, s.config.ReferrerCredits, "Referral bonus for inviting a user", "referrer"},
+	}
+	for _, grant := range grants {
+		if grant.credits <= 0 {
+			continue
+		}
+		referenceID := fmt.Sprintf("referral:%d:%s", referral.ID, grant.side)
+		if _, _, err := s.creditService.AllocateCreditsManual(ctx, grant.universalID, serviceProvider, grant.credits, grant.description, referenceID); err != nil {
+			s.logger.Error("Failed to allocate referral credits",
+				zap.Int64("referral_id", referral.ID),
+				zap.String("side", grant.side),
+				zap.Error(err))
+			return fmt.Errorf("failed to allocate referral credits: %w", err)
+		}
+	}
+
+	rewardedAt := s.now()
+	referral.Status = model.ReferralStatusRewarded
+	referral.ReferrerCredits = s.config.ReferrerCredits
+	referral.RefereeCredits = s.config.RefereeCredits
+	referral.PaymentRef = &paymentRef
+	referral.RewardedAt = &rewardedAt
+	if _, err := s.referralRepo.UpdateStatus(ctx, referral); err != nil {
+		return err
+	}
+
+	s.logger.Info("Referral rewarded",
+		zap.Int64("referral_id", referral.ID),
+		zap.String("referrer_id", referral.ReferrerID.String()),
+		zap.String("referee_id", referral.RefereeID.String()),
+		zap.String("payment_ref", paymentRef))
+
+	return nil
+}
+
+// getOrCreateCode returns the user's referral code, generating one on first use
+func (s *ReferralService) getOrCreateCode(ctx context.Context, universalID uuid.UUID) (*model.ReferralCode, error) {
+	code, err := s.referralRepo.GetCode(ctx, universalID)
+	if err != nil || code != nil {
+		return code, err
+	}
+
+	for attempt := 0; attempt < referralCodeAttempts; attempt++ {
+		generated, err := generateReferralCode()
+		if err != nil {
+			return nil, err
+		}
+
+		created, err := s.referralRepo.CreateCode(ctx, &model.ReferralCode{UniversalID: universalID, Code: generated})
+		if err != nil {
+			return nil, err
+		}
+
+		// Not created: either a concurrent request created the user's code or the code is taken
+		code, err := s.referralRepo.GetCode(ctx, universalID)
+		if err != nil || code != nil {
+			return code, err
+		}
+		if created {
+			return nil, fmt.Errorf("referral code was created but cannot be read back")
+		}
+	}
+
+	return nil, fmt.Errorf("failed to generate a unique referral code")
+}
+
+func generateReferralCode() (string, error) {
+	var b strings.Builder
+	max := big.NewInt(int64(len(referralCodeAlphabet)))
+	for i := 0; i < referralCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate referral code: %w", err)
+		}
+		b.WriteByte(referralCodeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// MockReferralRepository is a mock implementation of ReferralRepository
+type MockReferralRepository struct {
+	mock.Mock
+}
+
+func (m *MockReferralRepository) GetCode(ctx context.Context, universalID uuid.UUID) (*model.ReferralCode, error) {
+	args := m.Called(ctx, universalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReferralCode), args.Error(1)
+}
+
+func (m *MockReferralRepository) CreateCode(ctx context.Context, code *model.ReferralCode) (bool, error) {
+	args := m.Called(ctx, code)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReferralRepository) GetCodeByCode(ctx context.Context, code string) (*model.ReferralCode, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReferralCode), args.Error(1)
+}
+
+func (m *MockReferralRepository) Create(ctx context.Context, referral *model.Referral) error {
+	args := m.Called(ctx, referral)
+	return args.Error(0)
+}
+
+func (m *MockReferralRepository) GetByReferee(ctx context.Context, refereeID uuid.UUID) (*model.Referral, error) {
+	args := m.Called(ctx, refereeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Referral), args.Error(1)
+}
+
+func (m *MockReferralRepository) CountByReferrer(ctx context.Context, referrerID uuid.UUID) (map[string]int64, error) {
+	args := m.Called(ctx, referrerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
+func (m *MockReferralRepository) UpdateStatus(ctx context.Context, referral *model.Referral) (bool, error) {
+	args := m.Called(ctx, referral)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestReferralService_Redeem(t *testing.T) {
+	referrer := uuid.MustParse("8e4a2b15-0000-4000-8000-000000000001")
+	referee := uuid.MustParse("8e4a2b15-0000-4000-8000-000000000002")
+	cfg := config.ReferralConfig{Enabled: true, ReferrerCredits: 100, RefereeCredits: 50}
+
+	tests := []struct {
+		name      string
+		redeemer  uuid.UUID
+		existing  *model.Referral
+		reverse   *model.Referral
+		paid      int64
+		wantError error
+	}{
+		{name: "first redemption", redeemer: referee},
+		{name: "own code", redeemer: referrer, wantError: domainErrors.ErrSelfReferral},
+		{name: "already referred", redeemer: referee, existing: &model.Referral{ID: 1}, wantError: domainErrors.ErrAlreadyReferred},
+		{name: "mutual referral", redeemer: referee, reverse: &model.Referral{ReferrerID: referee}, wantError: domainErrors.ErrReferralNotEligible},
+		{name: "already paid", redeemer: referee, paid: 1, wantError: domainErrors.ErrReferralNotEligible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			referralRepo := new(MockReferralRepository)
+			paymentRepo := new(MockPaymentRepository)
+			referralRepo.On("GetCodeByCode", mock.Anything, "ABCD2345").Return(&model.ReferralCode{UniversalID: referrer, Code: "ABCD2345"}, nil)
+			referralRepo.On("GetByReferee", mock.Anything, referee).Return(tt.existing, nil)
+			referralRepo.On("GetByReferee", mock.Anything, referrer).Return(tt.reverse, nil)
+			referralRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			paymentRepo.On("CountCompleted", mock.Anything, referee.String()).Return(tt.paid, nil)
+
+			service := NewReferralService(referralRepo, paymentRepo, nil, cfg, zap.NewNop())
+			referral, err := service.Redeem(context.Background(), tt.redeemer, "ABCD2345")
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+				referralRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, referrer, referral.ReferrerID)
+			assert.Equal(t, model.ReferralStatusPending, referral.Status)
+		})
+	}
+}
+
+func TestReferralService_RewardFirstPayment(t *testing.T) {
+	referrer := uuid.MustParse("8e4a2b15-0000-4000-8000-000000000001")
+	referee := uuid.MustParse("8e4a2b15-0000-4000-8000-000000000002")
+
+	t.Run("grants both sides once", func(t *testing.T) {
+		referralRepo := new(MockReferralRepository)
+		creditRepo := new(MockCreditRepository)
+		referralRepo.On("GetByReferee", mock.Anything, referee).Return(&model.Referral{ID: 9, ReferrerID: referrer, RefereeID: referee, Status: model.ReferralStatusPending}, nil)
+		referralRepo.On("CountByReferrer", mock.Anything, referrer).Return(map[string]int64{model.ReferralStatusRewarded: 2}, nil)
+		referralRepo.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(r *model.Referral) bool {
+			return r.Status == model.ReferralStatusRewarded && *r.PaymentRef == "order_1"
+		})).Return(true, nil)
+		creditRepo.On("AllocateCredits", mock.Anything, referee, "semo", decimal.NewFromInt(50), mock.Anything, "referral:9:referee").
+			Return(&model.UserCreditBalance{}, &model.CreditTransaction{}, nil)
+		creditRepo.On("AllocateCredits", mock.Anything, referrer, "semo", decimal.NewFromInt(100), mock.Anything, "referral:9:referrer").
+			Return(&model.UserCreditBalance{}, &model.CreditTransaction{}, nil)
+
+		creditService := NewCreditService(creditRepo, nil, nil, zap.NewNop(), "semo", nil)
+		cfg := config.ReferralConfig{Enabled: true, ReferrerCredits: 100, RefereeCredits: 50, MaxRewards: 3}
+		service := NewReferralService(referralRepo, nil, creditService, cfg, zap.NewNop())
+
+		assert.NoError(t, service.RewardFirstPayment(context.Background(), referee.String(), "", "order_1"))
+		creditRepo.AssertExpectations(t)
+		referralRepo.AssertExpectations(t)
+	})
+
+	t.Run("referrer at the reward limit", func(t *testing.T) {
+		referralRepo := new(MockReferralRepository)
+		creditRepo := new(MockCreditRepository)
+		referralRepo.On("GetByReferee", mock.Anything, referee).Return(&model.Referral{ID: 9, ReferrerID: referrer, RefereeID: referee, Status: model.ReferralStatusPending}, nil)
+		referralRepo.On("CountByReferrer", mock.Anything, referrer).Return(map[string]int64{model.ReferralStatusRewarded: 3}, nil)
+		referralRepo.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(r *model.Referral) bool {
+			return r.Status == model.ReferralStatusRejected
+		})).Return(true, nil)
+
+		creditService := NewCreditService(creditRepo, nil, nil, zap.NewNop(), "semo", nil)
+		cfg := config.ReferralConfig{Enabled: true, ReferrerCredits: 100, RefereeCredits: 50, MaxRewards: 3}
+		service := NewReferralService(referralRepo, nil, creditService, cfg, zap.NewNop())
+
+		assert.NoError(t, service.RewardFirstPayment(context.Background(), referee.String(), "semo", "order_1"))
+		creditRepo.AssertNotCalled(t, "AllocateCredits", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,332 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// Signals risk rules fire on
+const (
+	RiskSignalAnonymousIP     = "anonymous_ip"     // The IP is an anonymous proxy
+	RiskSignalVPN             = "vpn"              // The IP belongs to an anonymous VPN
+	RiskSignalTor             = "tor"              // The IP is a Tor exit node
+	RiskSignalCountry         = "country"          // The IP is in one of the rule's countries
+	RiskSignalCountryMismatch = "country_mismatch" // The IP and the card issuer are in different countries
+	RiskSignalUserVelocity    = "user_velocity"    // Attempts of the user within the rule's window
+	RiskSignalCardVelocity    = "card_velocity"    // Attempts with the card within the rule's window
+	RiskSignalIPVelocity      = "ip_velocity"      // Attempts from the IP within the rule's window
+	RiskSignalFailedPayments  = "failed_payments"  // Failed payments of the user within the rule's window
+)
+
+// Default decision thresholds
+const (
+	DefaultRiskVerifyScore = 50
+	DefaultRiskBlockScore  = 80
+)
+
+// Listing bounds of risk assessments
+const (
+	defaultRiskAssessmentLimit = 100
+	maxRiskAssessmentLimit     = 1000
+)
+
+// DefaultRiskRules are used when no rules are configured. A Tor exit node is blocked on its own,
+// anonymizing networks and velocity need a second signal before extra verification is required.
+var DefaultRiskRules = []config.RiskRuleConfig{
+	{Signal: RiskSignalTor, Score: 60},
+	{Signal: RiskSignalVPN, Score: 25},
+	{Signal: RiskSignalAnonymousIP, Score: 20},
+	{Signal: RiskSignalCountryMismatch, Score: 30},
+	{Signal: RiskSignalUserVelocity, Score: 40, Limit: 5, WindowMinutes: 10},
+	{Signal: RiskSignalIPVelocity, Score: 40, Limit: 10, WindowMinutes: 10},
+	{Signal: RiskSignalCardVelocity, Score: 50, Limit: 3, WindowMinutes: 60},
+	{Signal: RiskSignalFailedPayments, Score: 40, Limit: 3, WindowMinutes: 24 * 60},
+}
+
+// RiskRequest describes a checkout, subscription or card registration to assess
+type RiskRequest struct {
+	Action          string // One of the model.RiskAction constants
+	UniversalID     uuid.UUID
+	IP              string
+	Location        *model.GeoLocation // Geo rules are skipped when nil or unresolved
+	CardFingerprint string             // Blank when the card is not known yet
+	BillingKeyID    int64              // Resolves the card fingerprint and issuer country of a registered card of the user
+	CardCountry     string             // ISO country of the card issuer as reported by the payment gateway, when known
+	Amount          int64
+	Currency        string
+}
+
+type riskRule struct {
+	name      string
+	signal    string
+	score     int
+	decision  string
+	limit     int64
+	window    time.Duration
+	countries map[string]bool
+	actions   map[string]bool
+}
+
+// RiskService scores requests on geo signals, attempt velocity and failed payments, decides whether
+// they are allowed, need extra verification or are blocked, and records every decision
+type RiskService struct {
+	repo           repository.RiskRepository
+	billingKeyRepo repository.BillingKeyRepository
+	rules          []riskRule
+	verifyScore    int
+	blockScore     int
+	now            func() time.Time
+	logger         *zap.Logger
+}
+
+// NewRiskService creates a new RiskService instance. billingKeyRepo may be nil, in which case
+// registered cards are not resolved. Rules with unknown signals or decisions are rejected.
+func NewRiskService(repo repository.RiskRepository, billingKeyRepo repository.BillingKeyRepository, cfg config.RiskConfig, logger *zap.Logger) (*RiskService, error) {
+	s := &RiskService{
+		repo:           repo,
+		billingKeyRepo: billingKeyRepo,
+		verifyScore:    cfg.VerifyScore,
+		blockScore:     cfg.BlockScore,
+		now:            time.Now,
+		logger:         logger,
+	}
+	if s.verifyScore <= 0 {
+		s.verifyScore = DefaultRiskVerifyScore
+	}
+	if s.blockScore <= 0 {
+		s.blockScore = DefaultRiskBlockScore
+	}
+
+	rules := cfg.Rules
+	if len(rules) == 0 {
+		rules = DefaultRiskRules
+	}
+	for _, rc := range rules {
+		rule, err := newRiskRule(rc)
+		if err != nil {
+			return nil, err
+		}
+		s.rules = append(s.rules, rule)
+	}
+
+	return s, nil
+}
+
+func newRiskRule(rc config.RiskRuleConfig) (riskRule, error) {
+	rule := riskRule{
+		name:     rc.Name,
+		signal:   strings.TrimSpace(rc.Signal),
+		score:    rc.Score,
+		decision: strings.ToLower(strings.TrimSpace(rc.Decision)),
+		limit:    int64(rc.Limit),
+		window:   time.Duration(rc.WindowMinutes) * time.Minute,
+	}
+	if rule.name == "" {
+		rule.name = rule.signal
+	}
+
+	switch rule.signal {
+	case RiskSignalAnonymousIP, RiskSignalVPN, RiskSignalTor, RiskSignalCountryMismatch:
+	case RiskSignalCountry:
+		if len(rc.Countries) == 0 {
+			return riskRule{}, fmt.Errorf("risk rule %s: country rules need countries", rule.name)
+		}
+		rule.countries = make(map[string]bool, len(rc.Countries))
+		for _, country := range rc.Countries {
+			rule.countries[strings.ToUpper(strings.TrimSpace(country))] = true
+		}
+	case RiskSignalUserVelocity, RiskSignalCardVelocity, RiskSignalIPVelocity, RiskSignalFailedPayments:
+		if rule.limit <= 0 || rule.window <= 0 {
+			return riskRule{}, fmt.Errorf("risk rule %s: %s rules need a limit and a window", rule.name, rule.signal)
+		}
+	default:
+		return riskRule{}, fmt.Errorf("risk rule %s: unknown signal %q", rule.name, rule.signal)
+	}
+
+	switch rule.decision {
+	case "", model.RiskDecisionAllow, model.RiskDecisionVerify, model.RiskDecisionBlock:
+	default:
+		return riskRule{}, fmt.Errorf("risk rule %s: unknown decision %q", rule.name, rule.decision)
+	}
+
+	if len(rc.Actions) > 0 {
+		rule.actions = make(map[string]bool, len(rc.Actions))
+		for _, action := range rc.Actions {
+			rule.actions[strings.TrimSpace(action)] = true
+		}
+	}
+
+	return rule, nil
+}
+
+// Assess scores a request and records the decision. Rules whose history cannot be read do not fire,
+// and a decision that cannot be recorded is still returned, so risk checks never fail a request themselves.
+func (s *RiskService) Assess(ctx context.Context, req RiskRequest) *model.RiskAssessment {
+	assessment := &model.RiskAssessment{
+		UniversalID:     req.UniversalID,
+		Action:          req.Action,
+		IPAddress:       req.IP,
+		CardFingerprint: req.CardFingerprint,
+		CardCountry:     strings.ToUpper(strings.TrimSpace(req.CardCountry)),
+		Amount:          req.Amount,
+		Currency:        strings.ToUpper(req.Currency),
+		MatchedRules:    model.JSONB{},
+		Signals:         model.JSONB{},
+	}
+	if req.BillingKeyID != 0 {
+		if card := s.registeredCard(ctx, req.UniversalID, req.BillingKeyID); card != nil {
+			if assessment.CardFingerprint == "" {
+				assessment.CardFingerprint = card.CardFingerprint
+			}
+			if assessment.CardCountry == "" {
+				assessment.CardCountry = card.CardCountry
+			}
+		}
+	}
+	location := req.Location
+	if location != nil && location.Resolved {
+		assessment.CountryCode = location.CountryCode
+		assessment.Signals["anonymous_ip"] = location.IsAnonymous
+		assessment.Signals["vpn"] = location.IsAnonymousVPN
+		assessment.Signals["tor"] = location.IsTorExitNode
+		assessment.Signals["asn"] = location.ASN
+	} else {
+		location = nil
+	}
+
+	now := s.now()
+	forced := model.RiskDecisionAllow
+	for _, rule := range s.rules {
+		if rule.actions != nil && !rule.actions[req.Action] {
+			continue
+		}
+
+		fired, err := s.fires(ctx, rule, assessment, location, now)
+		if err != nil {
+			s.logger.Warn("Failed to evaluate risk rule, skipping it",
+				zap.String("rule", rule.name),
+				zap.String("universal_id", req.UniversalID.String()),
+				zap.Error(err))
+			continue
+		}
+		if !fired {
+			continue
+		}
+
+		assessment.Score += rule.score
+		assessment.MatchedRules[rule.name] = rule.score
+		if model.RiskDecisionRank(rule.decision) > model.RiskDecisionRank(forced) {
+			forced = rule.decision
+		}
+	}
+
+	switch {
+	case assessment.Score >= s.blockScore:
+		assessment.Decision = model.RiskDecisionBlock
+	case assessment.Score >= s.verifyScore:
+		assessment.Decision = model.RiskDecisionVerify
+	default:
+		assessment.Decision = model.RiskDecisionAllow
+	}
+	if model.RiskDecisionRank(forced) > model.RiskDecisionRank(assessment.Decision) {
+		assessment.Decision = forced
+	}
+
+	if err := s.repo.CreateAssessment(ctx, assessment); err != nil {
+		s.logger.Error("Failed to record risk decision",
+			zap.String("universal_id", req.UniversalID.String()),
+			zap.String("action", req.Action),
+			zap.String("decision", assessment.Decision),
+			zap.Error(err))
+	}
+
+	if assessment.Decision != model.RiskDecisionAllow {
+		s.logger.Warn("Risk checks did not allow request",
+			zap.String("universal_id", req.UniversalID.String()),
+			zap.String("action", req.Action),
+			zap.String("ip", req.IP),
+			zap.Int("score", assessment.Score),
+			zap.String("decision", assessment.Decision),
+			zap.Any("matched_rules", assessment.MatchedRules))
+	}
+
+	return assessment
+}
+
+// fires reports whether a rule fires for a request, recording the history it read in the assessment signals
+func (s *RiskService) fires(ctx context.Context, rule riskRule, assessment *model.RiskAssessment, location *model.GeoLocation, now time.Time) (bool, error) {
+	switch rule.signal {
+	case RiskSignalAnonymousIP:
+		return location != nil && location.IsAnonymous, nil
+	case RiskSignalVPN:
+		return location != nil && location.IsAnonymousVPN, nil
+	case RiskSignalTor:
+		return location != nil && location.IsTorExitNode, nil
+	case RiskSignalCountry:
+		return location != nil && rule.countries[location.CountryCode], nil
+	case RiskSignalCountryMismatch:
+		return location != nil && location.CountryCode != "" && assessment.CardCountry != "" &&
+			location.CountryCode != assessment.CardCountry, nil
+	case RiskSignalFailedPayments:
+		count, err := s.repo.CountFailedPayments(ctx, assessment.UniversalID, now.Add(-rule.window))
+		if err != nil {
+			return false, err
+		}
+		assessment.Signals[rule.name] = count
+		return count >= rule.limit, nil
+	}
+
+	var key repository.RiskAttemptKey
+	var value string
+	switch rule.signal {
+	case RiskSignalUserVelocity:
+		key, value = repository.RiskAttemptsByUser, assessment.UniversalID.String()
+	case RiskSignalCardVelocity:
+		key, value = repository.RiskAttemptsByCard, assessment.CardFingerprint
+	case RiskSignalIPVelocity:
+		key, value = repository.RiskAttemptsByIP, assessment.IPAddress
+	}
+	if value == "" {
+		return false, nil
+	}
+
+	count, err := s.repo.CountAttempts(ctx, key, value, now.Add(-rule.window))
+	if err != nil {
+		return false, err
+	}
+	assessment.Signals[rule.name] = count
+	return count >= rule.limit, nil
+}
+
+// registeredCard returns a registered card of the user, or nil when it is not theirs
+func (s *RiskService) registeredCard(ctx context.Context, universalID uuid.UUID, billingKeyID int64) *model.BillingKey {
+	if s.billingKeyRepo == nil {
+		return nil
+	}
+
+	billingKey, err := s.billingKeyRepo.GetByID(ctx, billingKeyID)
+	if err != nil || billingKey == nil || billingKey.UniversalID != universalID {
+		return nil
+	}
+	return billingKey
+}
+
+// ListAssessments returns recorded risk decisions, newest first
+func (s *RiskService) ListAssessments(ctx context.Context, filters dto.RiskAssessmentFilters) ([]*model.RiskAssessment, error) {
+	if filters.Limit <= 0 {
+		filters.Limit = defaultRiskAssessmentLimit
+	}
+	if filters.Limit > maxRiskAssessmentLimit {
+		filters.Limit = maxRiskAssessmentLimit
+	}
+	return s.repo.ListAssessments(ctx, filters)
+}
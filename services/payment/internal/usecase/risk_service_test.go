@@ -0,0 +1,319 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// MockRiskRepository is a mock implementation of RiskRepository
+type MockRiskRepository struct {
+	mock.Mock
+}
+
+func (m *MockRiskRepository) CreateAssessment(ctx context.Context, assessment *model.RiskAssessment) error {
+	args := m.Called(ctx, assessment)
+	return args.Error(0)
+}
+
+func (m *MockRiskRepository) CountAttempts(ctx context.Context, key repository.RiskAttemptKey, value string, since time.Time) (int64, error) {
+	args := m.Called(ctx, key, value, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRiskRepository) CountFailedPayments(ctx context.Context, universalID uuid.UUID, since time.Time) (int64, error) {
+	args := m.Called(ctx, universalID, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRiskRepository) ListAssessments(ctx context.Context, filters dto.RiskAssessmentFilters) ([]*model.RiskAssessment, error) {
+	args := m.Called(ctx, filters)
+	assessments, _ := args.Get(0).([]*model.RiskAssessment)
+	return assessments, args.Error(1)
+}
+
+// MockBillingKeyRepository is a mock implementation of BillingKeyRepository
+type MockBillingKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockBillingKeyRepository) Create(ctx context.Context, billingKey *model.BillingKey) error {
+	args := m.Called(ctx, billingKey)
+	return args.Error(0)
+}
+
+func (m *MockBillingKeyRepository) GetByID(ctx context.Context, id int64) (*model.BillingKey, error) {
+	args := m.Called(ctx, id)
+	billingKey, _ := args.Get(0).(*model.BillingKey)
+	return billingKey, args.Error(1)
+}
+
+func (m *MockBillingKeyRepository) GetByCustomerKey(ctx context.Context, customerKey string) (*model.BillingKey, error) {
+	args := m.Called(ctx, customerKey)
+	billingKey, _ := args.Get(0).(*model.BillingKey)
+	return billingKey, args.Error(1)
+}
+
+func (m *MockBillingKeyRepository) GetActiveByUniversalID(ctx context.Context, universalID uuid.UUID) ([]*model.BillingKey, error) {
+	args := m.Called(ctx, universalID)
+	billingKeys, _ := args.Get(0).([]*model.BillingKey)
+	return billingKeys, args.Error(1)
+}
+
+func (m *MockBillingKeyRepository) Deactivate(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockBillingKeyRepository) CreateAccessLog(ctx context.Context, log *model.BillingKeyAccessLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+var riskTestNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestRiskService(t *testing.T, repo repository.RiskRepository, cfg config.RiskConfig) *RiskService {
+	service, err := NewRiskService(repo, nil, cfg, zap.NewNop())
+	require.NoError(t, err)
+	service.now = func() time.Time { return riskTestNow }
+	return service
+}
+
+// expectQuietHistory answers every history lookup with no earlier attempts
+func expectQuietHistory(repo *MockRiskRepository) {
+	repo.On("CountAttempts", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil)
+	repo.On("CountFailedPayments", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil)
+}
+
+func TestRiskService_AllowsCleanRequest(t *testing.T) {
+	repo := new(MockRiskRepository)
+	service := newTestRiskService(t, repo, config.RiskConfig{})
+	expectQuietHistory(repo)
+	repo.On("CreateAssessment", mock.Anything, mock.Anything).Return(nil).Once()
+
+	assessment := service.Assess(context.Background(), RiskRequest{
+		Action:      model.RiskActionCheckout,
+		UniversalID: uuid.New(),
+		IP:          "211.234.10.1",
+		Location:    &model.GeoLocation{IP: "211.234.10.1", CountryCode: "KR", Resolved: true},
+		CardCountry: "kr",
+		Amount:      9900,
+		Currency:    "krw",
+	})
+
+	assert.Equal(t, model.RiskDecisionAllow, assessment.Decision)
+	assert.Equal(t, 0, assessment.Score)
+	assert.Empty(t, assessment.MatchedRules)
+	assert.Equal(t, "KR", assessment.CountryCode)
+	assert.Equal(t, "KRW", assessment.Currency)
+	repo.AssertExpectations(t)
+}
+
+func TestRiskService_ScoresGeoSignals(t *testing.T) {
+	tests := []struct {
+		name     string
+		location *model.GeoLocation
+		card     string
+		score    int
+		decision string
+	}{
+		{
+			name:     "vpn",
+			location: &model.GeoLocation{CountryCode: "KR", IsAnonymous: true, IsAnonymousVPN: true, Resolved: true},
+			score:    45,
+			decision: model.RiskDecisionAllow,
+		},
+		{
+			name:     "vpn from another country than the card",
+			location: &model.GeoLocation{CountryCode: "NL", IsAnonymous: true, IsAnonymousVPN: true, Resolved: true},
+			card:     "KR",
+			score:    75,
+			decision: model.RiskDecisionVerify,
+		},
+		{
+			name:     "tor",
+			location: &model.GeoLocation{CountryCode: "DE", IsAnonymous: true, IsTorExitNode: true, Resolved: true},
+			score:    80,
+			decision: model.RiskDecisionBlock,
+		},
+		{
+			name:     "unresolved location",
+			location: &model.GeoLocation{IP: "10.0.0.1"},
+			card:     "KR",
+			decision: model.RiskDecisionAllow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockRiskRepository)
+			service := newTestRiskService(t, repo, config.RiskConfig{})
+			expectQuietHistory(repo)
+			repo.On("CreateAssessment", mock.Anything, mock.Anything).Return(nil)
+
+			assessment := service.Assess(context.Background(), RiskRequest{
+				Action:      model.RiskActionSubscription,
+				UniversalID: uuid.New(),
+				Location:    tt.location,
+				CardCountry: tt.card,
+			})
+
+			assert.Equal(t, tt.score, assessment.Score)
+			assert.Equal(t, tt.decision, assessment.Decision)
+		})
+	}
+}
+
+func TestRiskService_CountsVelocity(t *testing.T) {
+	repo := new(MockRiskRepository)
+	service := newTestRiskService(t, repo, config.RiskConfig{})
+	universalID := uuid.New()
+
+	repo.On("CountAttempts", mock.Anything, repository.RiskAttemptsByUser, universalID.String(), riskTestNow.Add(-10*time.Minute)).Return(int64(5), nil).Once()
+	repo.On("CountAttempts", mock.Anything, repository.RiskAttemptsByIP, "1.2.3.4", riskTestNow.Add(-10*time.Minute)).Return(int64(2), nil).Once()
+	repo.On("CountAttempts", mock.Anything, repository.RiskAttemptsByCard, "fp_1", riskTestNow.Add(-time.Hour)).Return(int64(3), nil).Once()
+	repo.On("CountFailedPayments", mock.Anything, universalID, riskTestNow.Add(-24*time.Hour)).Return(int64(0), nil).Once()
+	repo.On("CreateAssessment", mock.Anything, mock.Anything).Return(nil).Once()
+
+	assessment := service.Assess(context.Background(), RiskRequest{
+		Action:          model.RiskActionBillingCharge,
+		UniversalID:     universalID,
+		IP:              "1.2.3.4",
+		CardFingerprint: "fp_1",
+	})
+
+	assert.Equal(t, 90, assessment.Score)
+	assert.Equal(t, model.RiskDecisionBlock, assessment.Decision)
+	assert.Equal(t, model.JSONB{RiskSignalUserVelocity: 40, RiskSignalCardVelocity: 50}, assessment.MatchedRules)
+	assert.Equal(t, int64(2), assessment.Signals[RiskSignalIPVelocity])
+	repo.AssertExpectations(t)
+}
+
+func TestRiskService_RuleDecisionOverridesScore(t *testing.T) {
+	repo := new(MockRiskRepository)
+	service := newTestRiskService(t, repo, config.RiskConfig{
+		Rules: []config.RiskRuleConfig{
+			{Name: "sanctioned", Signal: RiskSignalCountry, Countries: []string{"kp"}, Decision: model.RiskDecisionBlock},
+			{Signal: RiskSignalVPN, Score: 10, Decision: model.RiskDecisionVerify, Actions: []string{model.RiskActionBillingKey}},
+		},
+	})
+	repo.On("CreateAssessment", mock.Anything, mock.Anything).Return(nil)
+
+	blocked := service.Assess(context.Background(), RiskRequest{
+		Action:   model.RiskActionCheckout,
+		Location: &model.GeoLocation{CountryCode: "KP", Resolved: true},
+	})
+	assert.Equal(t, model.RiskDecisionBlock, blocked.Decision)
+	assert.Equal(t, model.JSONB{"sanctioned": 0}, blocked.MatchedRules)
+
+	// The VPN rule only applies to card registration
+	vpn := &model.GeoLocation{CountryCode: "KR", IsAnonymousVPN: true, Resolved: true}
+	checkout := service.Assess(context.Background(), RiskRequest{Action: model.RiskActionCheckout, Location: vpn})
+	assert.Equal(t, model.RiskDecisionAllow, checkout.Decision)
+
+	registration := service.Assess(context.Background(), RiskRequest{Action: model.RiskActionBillingKey, Location: vpn})
+	assert.Equal(t, model.RiskDecisionVerify, registration.Decision)
+	assert.Equal(t, 10, registration.Score)
+}
+
+func TestRiskService_FailsOpenOnRepositoryErrors(t *testing.T) {
+	repo := new(MockRiskRepository)
+	service := newTestRiskService(t, repo, config.RiskConfig{})
+	repo.On("CountAttempts", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(int64(0), errors.New("connection refused"))
+	repo.On("CountFailedPayments", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), errors.New("connection refused"))
+	repo.On("CreateAssessment", mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+
+	assessment := service.Assess(context.Background(), RiskRequest{
+		Action:      model.RiskActionCheckout,
+		UniversalID: uuid.New(),
+		IP:          "1.2.3.4",
+		Location:    &model.GeoLocation{CountryCode: "DE", IsAnonymous: true, IsTorExitNode: true, Resolved: true},
+	})
+
+	// Geo rules still fire without the history
+	assert.Equal(t, model.RiskDecisionBlock, assessment.Decision)
+	repo.AssertExpectations(t)
+}
+
+func TestRiskService_ResolvesRegisteredCard(t *testing.T) {
+	universalID := uuid.New()
+	tests := []struct {
+		name        string
+		billingKey  *model.BillingKey
+		cardCountry string
+		fingerprint string
+		score       int
+	}{
+		{
+			name:        "card of the user",
+			billingKey:  &model.BillingKey{ID: 7, UniversalID: universalID, CardFingerprint: "fp_1", CardCountry: "KR"},
+			cardCountry: "KR",
+			fingerprint: "fp_1",
+			score:       30,
+		},
+		{
+			name:        "overseas card of unknown country",
+			billingKey:  &model.BillingKey{ID: 7, UniversalID: universalID, CardFingerprint: "fp_1"},
+			fingerprint: "fp_1",
+		},
+		{
+			name:       "card of another user",
+			billingKey: &model.BillingKey{ID: 7, UniversalID: uuid.New(), CardFingerprint: "fp_1", CardCountry: "KR"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockRiskRepository)
+			billingKeyRepo := new(MockBillingKeyRepository)
+			service, err := NewRiskService(repo, billingKeyRepo, config.RiskConfig{}, zap.NewNop())
+			require.NoError(t, err)
+			expectQuietHistory(repo)
+			repo.On("CreateAssessment", mock.Anything, mock.Anything).Return(nil)
+			billingKeyRepo.On("GetByID", mock.Anything, int64(7)).Return(tt.billingKey, nil).Once()
+
+			assessment := service.Assess(context.Background(), RiskRequest{
+				Action:       model.RiskActionBillingCharge,
+				UniversalID:  universalID,
+				Location:     &model.GeoLocation{CountryCode: "NL", Resolved: true},
+				BillingKeyID: 7,
+			})
+
+			// The issuer country is the one Toss reported for the card, never one sent by the client
+			assert.Equal(t, tt.cardCountry, assessment.CardCountry)
+			assert.Equal(t, tt.fingerprint, assessment.CardFingerprint)
+			assert.Equal(t, tt.score, assessment.Score)
+			billingKeyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewRiskService_RejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule config.RiskRuleConfig
+	}{
+		{name: "unknown signal", rule: config.RiskRuleConfig{Signal: "weather"}},
+		{name: "unknown decision", rule: config.RiskRuleConfig{Signal: RiskSignalTor, Decision: "deny"}},
+		{name: "velocity without window", rule: config.RiskRuleConfig{Signal: RiskSignalIPVelocity, Limit: 5}},
+		{name: "country without countries", rule: config.RiskRuleConfig{Signal: RiskSignalCountry}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRiskService(new(MockRiskRepository), nil, config.RiskConfig{Rules: []config.RiskRuleConfig{tt.rule}}, zap.NewNop())
+			assert.Error(t, err)
+		})
+	}
+}
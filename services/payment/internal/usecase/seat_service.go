@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stripe/stripe-go/v79"
+	dbRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/adapter/repository"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// SeatUsage describes the paid seats of a workspace subscription and how many are in use
+type SeatUsage struct {
+	WorkspaceID    string `json:"workspace_id"`
+	SubscriptionID string `json:"subscription_id"`
+	PlanID         string `json:"plan_id"`
+	Seats          int    `json:"seats"`
+	Members        int    `json:"members"`
+	Available      int    `json:"available"`
+}
+
+// SeatService keeps the seat quantity of workspace subscriptions in line with workspace membership
+type SeatService struct {
+	subscriptionRepo repository.SubscriptionRepository
+	planRepo         dbRepo.PlanRepository
+	workspaceRepo    repository.WorkspaceVerificationRepository
+	creditService    *CreditService
+	logger           *zap.Logger
+}
+
+// NewSeatService creates a new SeatService instance
+func NewSeatService(
+	subscriptionRepo repository.SubscriptionRepository,
+	planRepo dbRepo.PlanRepository,
+	workspaceRepo repository.WorkspaceVerificationRepository,
+	creditService *CreditService,
+	logger *zap.Logger,
+) *SeatService {
+	return &SeatService{
+		subscriptionRepo: subscriptionRepo,
+		planRepo:         planRepo,
+		workspaceRepo:    workspaceRepo,
+		creditService:    creditService,
+		logger:           logger,
+	}
+}
+
+// GetSeatUsage returns the paid and used seats of a workspace
+func (s *SeatService) GetSeatUsage(ctx context.Context, workspaceID string) (*SeatUsage, error) {
+	_, _, usage, err := s.load(ctx, workspaceID)
+	return usage, err
+}
+
+// CheckoutQuantity returns the seat quantity for a new subscription to priceID, covering at least
+// every current member of the workspace. It returns 0 when the plan is not seat-based.
+func (s *SeatService) CheckoutQuantity(ctx context.Context, workspaceID string, priceID string, requested int) (int, error) {
+	plan, err := s.planRepo.GetByPriceID(ctx, priceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get plan: %w", err)
+	}
+	if plan == nil || !plan.SeatBased {
+		return 0, nil
+	}
+	if workspaceID == "" {
+		return 0, domainErrors.ErrSeatWorkspaceRequired
+	}
+
+	members, err := s.workspaceRepo.CountWorkspaceMembers(ctx, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count workspace members: %w", err)
+	}
+
+	quantity := requested
+	if quantity < members {
+		quantity = members
+	}
+	if quantity < 1 {
+		quantity = 1
+	}
+	return quantity, nil
+}
+
+// ReserveSeat makes room for one more workspace member. When all paid seats are in use it
+// returns ErrSeatLimitReached, unless purchase is set, in which case one seat is bought and its
+// prorated price charged immediately in the service provider's Stripe account. The member must not
+// be added when this returns an error.
+func (s *SeatService) ReserveSeat(ctx context.Context, serviceProvider *model.ServiceProvider, workspaceID string, purchase bool) (*SeatUsage, error) {
+	sub, plan, usage, err := s.load(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if usage.Available > 0 {
+		return usage, nil
+	}
+	if !purchase {
+		return usage, domainErrors.ErrSeatLimitReached
+	}
+
+	if err := s.changeQuantity(ctx, serviceProvider, sub, plan, usage.Members+1); err != nil {
+		return nil, err
+	}
+
+	return newSeatUsage(workspaceID, sub, plan, usage.Members), nil
+}
+
+// SyncSeats matches the paid seats to the current membership after members were added or removed.
+// Added seats are charged immediately; removed seats are credited on the next invoice.
+func (s *SeatService) SyncSeats(ctx context.Context, serviceProvider *model.ServiceProvider, workspaceID string) (*SeatUsage, error) {
+	sub, plan, usage, err := s.load(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := usage.Members
+	if target < 1 {
+		target = 1
+	}
+	if target == usage.Seats {
+		return usage, nil
+	}
+
+	if err := s.changeQuantity(ctx, serviceProvider, sub, plan, target); err != nil {
+		return nil, err
+	}
+
+	return newSeatUsage(workspaceID, sub, plan, usage.Members), nil
+}
+
+// load resolves the workspace's seat-based subscription, its plan and current usage
+func (s *SeatService) load(ctx context.Context, workspaceID string) (*entity.Subscription, *model.PaymentPlan, *SeatUsage, error) {
+	sub, err := s.subscriptionRepo.GetActiveByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if sub == nil || sub.PlanID == nil {
+		return nil, nil, nil, domainErrors.ErrSeatSubscriptionNotFound
+	}
+
+	plans, err := s.planRepo.GetByProductID(ctx, *sub.PlanID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	var plan *model.PaymentPlan
+	for _, candidate := range plans {
+		if candidate.SeatBased {
+			plan = candidate
+			break
+		}
+	}
+	if plan == nil {
+		return nil, nil, nil, domainErrors.ErrSeatSubscriptionNotFound
+	}
+
+	members, err := s.workspaceRepo.CountWorkspaceMembers(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to count workspace members: %w", err)
+	}
+
+	return sub, plan, newSeatUsage(workspaceID, sub, plan, members), nil
+}
+
+// changeQuantity updates the seat quantity at Stripe and in the database
+func (s *SeatService) changeQuantity(ctx context.Context, serviceProvider *model.ServiceProvider, sub *entity.Subscription, plan *model.PaymentPlan, quantity int) error {
+	stripeClient, err := StripeClient(serviceProvider)
+	if err != nil {
+		return err
+	}
+
+	params := &stripe.SubscriptionParams{}
+	params.AddExpand("items")
+	stripeSub, err := stripeClient.Subscriptions.Get(sub.ID, params)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+	if stripeSub.Items == nil || len(stripeSub.Items.Data) == 0 {
+		return fmt.Errorf("subscription %s has no items", sub.ID)
+	}
+	item := stripeSub.Items.Data[0]
+	previous := int(item.Quantity)
+
+	itemParams := &stripe.SubscriptionItemParams{
+		Quantity: stripe.Int64(int64(quantity)),
+	}
+	if quantity > previous {
+		// Charge the prorated price of new seats right away and reject the change if payment fails
+		itemParams.ProrationBehavior = stripe.String("always_invoice")
+		itemParams.PaymentBehavior = stripe.String("error_if_incomplete")
+	} else {
+		itemParams.ProrationBehavior = stripe.String("create_prorations")
+	}
+
+	if _, err := stripeClient.SubscriptionItems.Update(item.ID, itemParams); err != nil {
+		var stripeErr *stripe.Error
+		if errors.As(err, &stripeErr) && stripeErr.Type == stripe.ErrorTypeCard {
+			s.logger.Warn("Seat payment declined",
+				zap.String("subscription_id", sub.ID),
+				zap.Int("quantity", quantity),
+				zap.Error(err))
+			return domainErrors.ErrSeatPaymentFailed
+		}
+		return fmt.Errorf("failed to update seat quantity: %w", err)
+	}
+
+	if err := s.subscriptionRepo.UpdateQuantity(ctx, sub.ID, quantity); err != nil {
+		return err
+	}
+
+	s.logger.Info("Seat quantity updated",
+		zap.String("subscription_id", sub.ID),
+		zap.String("workspace_id", sub.WorkspaceID),
+		zap.Int("previous", previous),
+		zap.Int("quantity", quantity))
+
+	if quantity > previous {
+		s.grantSeatCredits(ctx, sub, plan, quantity-previous, quantity, stripeSub)
+	}
+	sub.Quantity = quantity
+
+	return nil
+}
+
+// grantSeatCredits allocates credits for seats added mid-cycle, prorated over the rest of the period.
+// Proration invoices are skipped by the invoice.paid webhook, so this is the only grant for them.
+func (s *SeatService) grantSeatCredits(ctx context.Context, sub *entity.Subscription, plan *model.PaymentPlan, added int, quantity int, stripeSub *stripe.Subscription) {
+	if s.creditService == nil || plan.CreditsPerCycle <= 0 {
+		return
+	}
+
+	universalID, err := uuid.Parse(sub.UniversalID)
+	if err != nil {
+		s.logger.Error("Invalid universal ID on seat subscription",
+			zap.String("subscription_id", sub.ID),
+			zap.Error(err))
+		return
+	}
+
+	credits := proratedSeatCredits(plan.CreditsPerCycle, added, stripeSub.CurrentPeriodStart, stripeSub.CurrentPeriodEnd, time.Now())
+	if credits <= 0 {
+		return
+	}
+
+	referenceID := fmt.Sprintf("seats:%s:%d:%d", sub.ID, stripeSub.CurrentPeriodStart, quantity)
+	description := fmt.Sprintf("Credit allocation for %d added seat(s) on %s", added, plan.DisplayName)
+	if _, _, err := s.creditService.AllocateCreditsManual(ctx, universalID, "", credits, description, referenceID); err != nil {
+		s.logger.Error("Failed to allocate credits for added seats",
+			zap.String("subscription_id", sub.ID),
+			zap.Int("added_seats", added),
+			zap.Error(err))
+	}
+}
+
+// proratedSeatCredits returns the per-seat credits for the part of the billing period that is left
+func proratedSeatCredits(creditsPerCycle int, seats int, periodStart int64, periodEnd int64, now time.Time) int {
+	total := int64(creditsPerCycle) * int64(seats)
+	period := periodEnd - periodStart
+	if period <= 0 {
+		return int(total)
+	}
+
+	remaining := periodEnd - now.Unix()
+	if remaining <= 0 {
+		return 0
+	}
+	if remaining > period {
+		remaining = period
+	}
+
+	return int(total * remaining / period)
+}
+
+// StripeInvoiceLine is the price, seat quantity and amount billed by one Stripe invoice line
+type StripeInvoiceLine struct {
+	PriceID  string
+	Quantity int64
+	Amount   int64
+}
+
+// SubscriptionUpdateCreditLine picks the line of a subscription_update invoice to grant credits for.
+// Seat-quantity prorations bill a single price and return false, as SeatService grants credits for
+// added seats itself; plan changes return the line charging the remaining period of the new price.
+func SubscriptionUpdateCreditLine(lines []StripeInvoiceLine) (int, bool) {
+	prices := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		if line.PriceID != "" {
+			prices[line.PriceID] = true
+		}
+	}
+	if len(prices) < 2 {
+		return 0, false
+	}
+
+	for i, line := range lines {
+		if line.PriceID != "" && line.Amount > 0 {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func newSeatUsage(workspaceID string, sub *entity.Subscription, plan *model.PaymentPlan, members int) *SeatUsage {
+	seats := sub.Quantity
+	if seats < 1 {
+		seats = 1
+	}
+	available := seats - members
+	if available < 0 {
+		available = 0
+	}
+
+	return &SeatUsage{
+		WorkspaceID:    workspaceID,
+		SubscriptionID: sub.ID,
+		PlanID:         plan.ProviderPriceID,
+		Seats:          seats,
+		Members:        members,
+		Available:      available,
+	}
+}
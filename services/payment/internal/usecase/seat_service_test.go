@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/entity"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// MockSubscriptionRepository is a mock implementation of SubscriptionRepository
+type MockSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSubscriptionRepository) GetByCustomerID(ctx context.Context, customerID string) (*entity.Subscription, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) GetByID(ctx context.Context, subscriptionID string) (*entity.Subscription, error) {
+	args := m.Called(ctx, subscriptionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) Save(ctx context.Context, subscription *entity.Subscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) Update(ctx context.Context, subscription *entity.Subscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) Cancel(ctx context.Context, subscriptionID string) error {
+	args := m.Called(ctx, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *MockSubscriptionRepository) ListByStatus(ctx context.Context, status string) ([]*entity.Subscription, error) {
+	args := m.Called(ctx, status)
+	return args.Get(0).([]*entity.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) GetActiveByWorkspaceID(ctx context.Context, workspaceID string) (*entity.Subscription, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) GetActiveByUniversalID(ctx context.Context, universalID string) (*entity.Subscription, error) {
+	args := m.Called(ctx, universalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Subscription), args.Error(1)
+}
+
+func (m *MockSubscriptionRepository) UpdateQuantity(ctx context.Context, subscriptionID string, quantity int) error {
+	args := m.Called(ctx, subscriptionID, quantity)
+	return args.Error(0)
+}
+
+func TestSeatService_ReserveSeat(t *testing.T) {
+	workspaceID := "6f1c2d3e-0000-4000-8000-000000000001"
+	productID := "prod_team"
+	seatPlan := &model.PaymentPlan{
+		ProviderPriceID:   "price_team_monthly",
+		ProviderProductID: productID,
+		Type:              model.PlanTypeSubscription,
+		CreditsPerCycle:   100,
+		SeatBased:         true,
+	}
+
+	tests := []struct {
+		name              string
+		subscription      *entity.Subscription
+		plans             []*model.PaymentPlan
+		members           int
+		expectedAvailable int
+		expectedError     error
+	}{
+		{
+			name:              "free seat available",
+			subscription:      &entity.Subscription{ID: "sub_1", PlanID: &productID, Quantity: 5},
+			plans:             []*model.PaymentPlan{seatPlan},
+			members:           3,
+			expectedAvailable: 2,
+		},
+		{
+			name:          "all seats in use",
+			subscription:  &entity.Subscription{ID: "sub_1", PlanID: &productID, Quantity: 3},
+			plans:         []*model.PaymentPlan{seatPlan},
+			members:       3,
+			expectedError: domainErrors.ErrSeatLimitReached,
+		},
+		{
+			name:          "no subscription for workspace",
+			expectedError: domainErrors.ErrSeatSubscriptionNotFound,
+		},
+		{
+			name:          "plan is not seat-based",
+			subscription:  &entity.Subscription{ID: "sub_1", PlanID: &productID, Quantity: 1},
+			plans:         []*model.PaymentPlan{{ProviderPriceID: "price_pro", ProviderProductID: productID}},
+			expectedError: domainErrors.ErrSeatSubscriptionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscriptionRepo := new(MockSubscriptionRepository)
+			planRepo := new(MockPlanRepository)
+			workspaceRepo := new(MockWorkspaceVerificationRepository)
+			ctx := context.Background()
+
+			if tt.subscription != nil {
+				subscriptionRepo.On("GetActiveByWorkspaceID", ctx, workspaceID).Return(tt.subscription, nil)
+			} else {
+				subscriptionRepo.On("GetActiveByWorkspaceID", ctx, workspaceID).Return(nil, nil)
+			}
+			planRepo.On("GetByProductID", ctx, productID).Return(tt.plans, nil).Maybe()
+			workspaceRepo.On("CountWorkspaceMembers", ctx, workspaceID).Return(tt.members, nil).Maybe()
+
+			service := NewSeatService(subscriptionRepo, planRepo, workspaceRepo, nil, zap.NewNop())
+			usage, err := service.ReserveSeat(ctx, nil, workspaceID, false)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedAvailable, usage.Available)
+			subscriptionRepo.AssertNotCalled(t, "UpdateQuantity", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSeatService_CheckoutQuantity(t *testing.T) {
+	workspaceID := "6f1c2d3e-0000-4000-8000-000000000001"
+	seatPlan := &model.PaymentPlan{ProviderPriceID: "price_team", SeatBased: true}
+	flatPlan := &model.PaymentPlan{ProviderPriceID: "price_pro"}
+
+	tests := []struct {
+		name          string
+		priceID       string
+		plan          *model.PaymentPlan
+		workspaceID   string
+		requested     int
+		members       int
+		expected      int
+		expectedError error
+	}{
+		{name: "defaults to member count", priceID: "price_team", plan: seatPlan, workspaceID: workspaceID, members: 4, expected: 4},
+		{name: "extra seats requested", priceID: "price_team", plan: seatPlan, workspaceID: workspaceID, requested: 10, members: 4, expected: 10},
+		{name: "request below member count", priceID: "price_team", plan: seatPlan, workspaceID: workspaceID, requested: 2, members: 4, expected: 4},
+		{name: "flat plan", priceID: "price_pro", plan: flatPlan, workspaceID: workspaceID, expected: 0},
+		{name: "seat plan without workspace", priceID: "price_team", plan: seatPlan, expectedError: domainErrors.ErrSeatWorkspaceRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planRepo := new(MockPlanRepository)
+			workspaceRepo := new(MockWorkspaceVerificationRepository)
+			ctx := context.Background()
+
+			planRepo.On("GetByPriceID", ctx, tt.priceID).Return(tt.plan, nil)
+			workspaceRepo.On("CountWorkspaceMembers", ctx, tt.workspaceID).Return(tt.members, nil).Maybe()
+
+			service := NewSeatService(new(MockSubscriptionRepository), planRepo, workspaceRepo, nil, zap.NewNop())
+			quantity, err := service.CheckoutQuantity(ctx, tt.workspaceID, tt.priceID, tt.requested)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, quantity)
+		})
+	}
+}
+
+func TestProratedSeatCredits(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(30 * 24 * time.Hour)
+
+	assert.Equal(t, 200, proratedSeatCredits(100, 2, start.Unix(), end.Unix(), start))
+	assert.Equal(t, 100, proratedSeatCredits(100, 2, start.Unix(), end.Unix(), start.Add(15*24*time.Hour)))
+	assert.Equal(t, 0, proratedSeatCredits(100, 2, start.Unix(), end.Unix(), end.Add(time.Hour)))
+}
+
+func TestSubscriptionUpdateCreditLine(t *testing.T) {
+	// Seat change: unused time and remaining time on the same price
+	_, ok := SubscriptionUpdateCreditLine([]StripeInvoiceLine{
+		{PriceID: "price_team", Quantity: 2, Amount: -1000},
+		{PriceID: "price_team", Quantity: 3, Amount: 1500},
+	})
+	assert.False(t, ok)
+
+	// Plan change: credit for the old price, charge for the new one
+	index, ok := SubscriptionUpdateCreditLine([]StripeInvoiceLine{
+		{PriceID: "price_basic", Quantity: 1, Amount: -500},
+		{PriceID: "price_pro", Quantity: 1, Amount: 1500},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, 1, index)
+
+	_, ok = SubscriptionUpdateCreditLine(nil)
+	assert.False(t, ok)
+}
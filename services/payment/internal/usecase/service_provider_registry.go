@@ -0,0 +1,404 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/config"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/crypto"
+	"go.uber.org/zap"
+)
+
+// DefaultServiceProviderCacheTTL is how long resolved service providers are reused. Changes made
+// on another instance become visible once their cache entry expires.
+const DefaultServiceProviderCacheTTL = time.Minute
+
+var serviceProviderCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+type serviceProviderCacheEntry struct {
+	provider  *model.ServiceProvider // nil when the code is unknown or inactive
+	expiresAt time.Time
+}
+
+// CreateServiceProviderRequest registers a new service provider
+type CreateServiceProviderRequest struct {
+	Code        string
+	DisplayName string
+	ClientURLs  []string
+	Credentials model.ServiceProviderCredentials
+}
+
+// UpdateServiceProviderRequest changes a service provider. Nil fields are left unchanged and
+// blank credentials keep their stored value.
+type UpdateServiceProviderRequest struct {
+	DisplayName *string
+	ClientURLs  []string
+	IsActive    *bool
+	Credentials *model.ServiceProviderCredentials
+}
+
+// ServiceProviderRegistry resolves the service providers (products) served by this deployment.
+// The default provider is built from the deployment configuration and is used whenever a request
+// names no provider; a stored row with the same code overrides it.
+type ServiceProviderRegistry struct {
+	repo       repository.ServiceProviderRepository
+	encryption crypto.EncryptionService
+	fallback   *model.ServiceProvider
+	ttl        time.Duration
+	now        func() time.Time
+	logger     *zap.Logger
+
+	mu            sync.Mutex
+	cache         map[string]serviceProviderCacheEntry
+	origins       map[string]struct{}
+	originsExpiry time.Time
+}
+
+// DefaultServiceProvider builds the default service provider from the deployment configuration
+func DefaultServiceProvider(cfg *config.ServiceConfig) *model.ServiceProvider {
+	code := strings.ToLower(strings.TrimSpace(cfg.ServiceProvider))
+	if code == "" {
+		code = model.ServiceProviderSemo
+	}
+	return &model.ServiceProvider{
+		Code:        code,
+		DisplayName: code,
+		ClientURLs:  normalizeClientURLs(cfg.AllowedClientOrigins()),
+		IsActive:    true,
+		Credentials: model.ServiceProviderCredentials{
+			StripeSecretKey:      cfg.StripeSecretKey,
+			StripeWebhookSecret:  cfg.StripeWebhookSecret,
+			TossSecretKey:        cfg.Toss.SecretKey,
+			TossClientKey:        cfg.Toss.ClientKey,
+			TossBillingSecretKey: cfg.Toss.BillingSecretKey,
+		},
+	}
+}
+
+// NewServiceProviderRegistry creates a new ServiceProviderRegistry instance. encryption may be nil,
+// in which case providers with stored credentials cannot be registered. A zero ttl uses
+// DefaultServiceProviderCacheTTL.
+func NewServiceProviderRegistry(
+	repo repository.ServiceProviderRepository,
+	encryption crypto.EncryptionService,
+	fallback *model.ServiceProvider,
+	ttl time.Duration,
+	logger *zap.Logger,
+) *ServiceProviderRegistry {
+	if ttl <= 0 {
+		ttl = DefaultServiceProviderCacheTTL
+	}
+	if fallback.Code == "" {
+		fallback.Code = model.ServiceProviderSemo
+	}
+	fallback.IsActive = true
+	return &ServiceProviderRegistry{
+		repo:       repo,
+		encryption: encryption,
+		fallback:   fallback,
+		ttl:        ttl,
+		now:        time.Now,
+		logger:     logger,
+		cache:      make(map[string]serviceProviderCacheEntry),
+	}
+}
+
+// DefaultCode returns the code of the default service provider
+func (r *ServiceProviderRegistry) DefaultCode() string {
+	return r.fallback.Code
+}
+
+// Resolve returns the active service provider with the given code, or the default provider when
+// code is blank. The returned provider must not be modified.
+func (r *ServiceProviderRegistry) Resolve(ctx context.Context, code string) (*model.ServiceProvider, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" {
+		code = r.fallback.Code
+	}
+
+	r.mu.Lock()
+	entry, ok := r.cache[code]
+	r.mu.Unlock()
+	if ok && r.now().Before(entry.expiresAt) {
+		if entry.provider == nil {
+			return nil, domainErrors.ErrServiceProviderNotFound
+		}
+		return entry.provider, nil
+	}
+
+	provider, err := r.load(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cache[code] = serviceProviderCacheEntry{provider: provider, expiresAt: r.now().Add(r.ttl)}
+	r.mu.Unlock()
+
+	if provider == nil {
+		return nil, domainErrors.ErrServiceProviderNotFound
+	}
+	return provider, nil
+}
+
+// AllowsOrigin reports whether origin is a client URL of the default or any active service provider
+func (r *ServiceProviderRegistry) AllowsOrigin(ctx context.Context, origin string) bool {
+	origin = strings.TrimRight(origin, "/")
+
+	r.mu.Lock()
+	origins, expiry := r.origins, r.originsExpiry
+	r.mu.Unlock()
+
+	if origins == nil || !r.now().Before(expiry) {
+		loaded, err := r.loadOrigins(ctx)
+		if err != nil {
+			r.logger.Warn("Failed to load service provider origins, using the default provider only",
+				zap.Error(err))
+			return r.fallback.AllowsOrigin(origin)
+		}
+
+		r.mu.Lock()
+		r.origins, r.originsExpiry = loaded, r.now().Add(r.ttl)
+		r.mu.Unlock()
+		origins = loaded
+	}
+
+	_, ok := origins[origin]
+	return ok
+}
+
+// List returns every stored service provider with its credentials decrypted, followed by the
+// default provider when it is not stored
+func (r *ServiceProviderRegistry) List(ctx context.Context) ([]*model.ServiceProvider, error) {
+	stored, err := r.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make([]*model.ServiceProvider, 0, len(stored)+1)
+	hasDefault := false
+	for _, provider := range stored {
+		if err := r.decrypt(provider); err != nil {
+			return nil, err
+		}
+		hasDefault = hasDefault || provider.Code == r.fallback.Code
+		providers = append(providers, provider)
+	}
+	if !hasDefault {
+		fallback := *r.fallback
+		providers = append(providers, &fallback)
+	}
+	return providers, nil
+}
+
+// Create registers a new service provider
+func (r *ServiceProviderRegistry) Create(ctx context.Context, req *CreateServiceProviderRequest) (*model.ServiceProvider, error) {
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	if !serviceProviderCodePattern.MatchString(code) {
+		return nil, domainErrors.ErrInvalidServiceProviderCode
+	}
+
+	provider := &model.ServiceProvider{
+		Code:        code,
+		DisplayName: strings.TrimSpace(req.DisplayName),
+		ClientURLs:  normalizeClientURLs(req.ClientURLs),
+		IsActive:    true,
+		Credentials: req.Credentials,
+	}
+	if provider.DisplayName == "" {
+		provider.DisplayName = code
+	}
+	if err := r.encrypt(provider); err != nil {
+		return nil, err
+	}
+
+	if err := r.repo.Create(ctx, provider); err != nil {
+		return nil, err
+	}
+	r.invalidate(code)
+
+	r.logger.Info("Service provider registered",
+		zap.String("code", code),
+		zap.Int("client_urls", len(provider.ClientURLs)))
+
+	return provider, nil
+}
+
+// Update changes a stored service provider
+func (r *ServiceProviderRegistry) Update(ctx context.Context, code string, req *UpdateServiceProviderRequest) (*model.ServiceProvider, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	provider, err := r.repo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return nil, domainErrors.ErrServiceProviderNotFound
+	}
+	if err := r.decrypt(provider); err != nil {
+		return nil, err
+	}
+
+	if req.DisplayName != nil && strings.TrimSpace(*req.DisplayName) != "" {
+		provider.DisplayName = strings.TrimSpace(*req.DisplayName)
+	}
+	if req.ClientURLs != nil {
+		provider.ClientURLs = normalizeClientURLs(req.ClientURLs)
+	}
+	if req.IsActive != nil {
+		provider.IsActive = *req.IsActive
+	}
+	if req.Credentials != nil {
+		provider.Credentials = mergeCredentials(*req.Credentials, provider.Credentials)
+	}
+	if err := r.encrypt(provider); err != nil {
+		return nil, err
+	}
+
+	if err := r.repo.Update(ctx, provider); err != nil {
+		return nil, err
+	}
+	r.invalidate(code)
+
+	r.logger.Info("Service provider updated",
+		zap.String("code", code),
+		zap.Bool("is_active", provider.IsActive))
+
+	return provider, nil
+}
+
+// load reads a provider from the repository. Only a stored row overriding the default provider
+// takes its blank credentials from the deployment configuration; other providers use their own
+// credentials alone. It returns nil when the code is unknown or the provider is inactive.
+func (r *ServiceProviderRegistry) load(ctx context.Context, code string) (*model.ServiceProvider, error) {
+	provider, err := r.repo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		if code == r.fallback.Code {
+			return r.fallback, nil
+		}
+		return nil, nil
+	}
+	if !provider.IsActive {
+		return nil, nil
+	}
+
+	if err := r.decrypt(provider); err != nil {
+		return nil, err
+	}
+	if code == r.fallback.Code {
+		provider.Credentials = mergeCredentials(provider.Credentials, r.fallback.Credentials)
+	}
+	if len(provider.ClientURLs) == 0 {
+		provider.ClientURLs = r.fallback.ClientURLs
+	}
+	return provider, nil
+}
+
+func (r *ServiceProviderRegistry) loadOrigins(ctx context.Context) (map[string]struct{}, error) {
+	providers, err := r.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	origins := make(map[string]struct{})
+	for _, url := range r.fallback.ClientURLs {
+		origins[strings.TrimRight(url, "/")] = struct{}{}
+	}
+	for _, provider := range providers {
+		if !provider.IsActive {
+			continue
+		}
+		for _, url := range provider.ClientURLs {
+			origins[strings.TrimRight(url, "/")] = struct{}{}
+		}
+	}
+	return origins, nil
+}
+
+func (r *ServiceProviderRegistry) invalidate(code string) {
+	r.mu.Lock()
+	delete(r.cache, code)
+	r.origins = nil
+	r.mu.Unlock()
+}
+
+// encrypt stores the provider's credentials in its encrypted columns
+func (r *ServiceProviderRegistry) encrypt(provider *model.ServiceProvider) error {
+	if provider.Credentials == (model.ServiceProviderCredentials{}) {
+		provider.EncryptedCredentials, provider.CredentialsIV = "", ""
+		return nil
+	}
+	if r.encryption == nil {
+		return domainErrors.ErrCredentialEncryptionUnavailable
+	}
+
+	plaintext, err := json.Marshal(provider.Credentials)
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+	ciphertext, iv, err := r.encryption.Encrypt(string(plaintext))
+	if err != nil {
+		return fmt.Errorf("failed to encrypt credentials: %w", err)
+	}
+	provider.EncryptedCredentials, provider.CredentialsIV = ciphertext, iv
+	return nil
+}
+
+// decrypt fills the provider's Credentials from its encrypted columns
+func (r *ServiceProviderRegistry) decrypt(provider *model.ServiceProvider) error {
+	if provider.EncryptedCredentials == "" {
+		return nil
+	}
+	if r.encryption == nil {
+		return fmt.Errorf("service provider %s: %w", provider.Code, domainErrors.ErrCredentialEncryptionUnavailable)
+	}
+
+	plaintext, err := r.encryption.Decrypt(provider.EncryptedCredentials, provider.CredentialsIV)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt credentials of service provider %s: %w", provider.Code, err)
+	}
+	if err := json.Unmarshal([]byte(plaintext), &provider.Credentials); err != nil {
+		return fmt.Errorf("failed to decode credentials of service provider %s: %w", provider.Code, err)
+	}
+	return nil
+}
+
+// mergeCredentials returns creds with its blank values taken from fallback
+func mergeCredentials(creds, fallback model.ServiceProviderCredentials) model.ServiceProviderCredentials {
+	if creds.StripeSecretKey == "" {
+		creds.StripeSecretKey = fallback.StripeSecretKey
+	}
+	if creds.StripeWebhookSecret == "" {
+		creds.StripeWebhookSecret = fallback.StripeWebhookSecret
+	}
+	if creds.TossSecretKey == "" {
+		creds.TossSecretKey = fallback.TossSecretKey
+	}
+	if creds.TossClientKey == "" {
+		creds.TossClientKey = fallback.TossClientKey
+	}
+	if creds.TossBillingSecretKey == "" {
+		creds.TossBillingSecretKey = fallback.TossBillingSecretKey
+	}
+	return creds
+}
+
+func normalizeClientURLs(urls []string) model.StringList {
+	normalized := make(model.StringList, 0, len(urls))
+	for _, url := range urls {
+		if url = strings.TrimRight(strings.TrimSpace(url), "/"); url != "" {
+			normalized = append(normalized, url)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/infrastructure/crypto"
+	"go.uber.org/zap"
+)
+
+// MockServiceProviderRepository is a mock implementation of ServiceProviderRepository
+type MockServiceProviderRepository struct {
+	mock.Mock
+}
+
+func (m *MockServiceProviderRepository) GetByCode(ctx context.Context, code string) (*model.ServiceProvider, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	// Return a copy so the registry can decrypt into it like a fresh database row
+	provider := *args.Get(0).(*model.ServiceProvider)
+	return &provider, args.Error(1)
+}
+
+func (m *MockServiceProviderRepository) List(ctx context.Context) ([]*model.ServiceProvider, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ServiceProvider), args.Error(1)
+}
+
+func (m *MockServiceProviderRepository) Create(ctx context.Context, provider *model.ServiceProvider) error {
+	args := m.Called(ctx, provider)
+	return args.Error(0)
+}
+
+func (m *MockServiceProviderRepository) Update(ctx context.Context, provider *model.ServiceProvider) error {
+	args := m.Called(ctx, provider)
+	return args.Error(0)
+}
+
+const testEncryptionKey = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func newTestServiceProviderRegistry(t *testing.T, repo *MockServiceProviderRepository) *ServiceProviderRegistry {
+	encryption, err := crypto.NewAESEncryptionService(testEncryptionKey)
+	require.NoError(t, err)
+
+	fallback := &model.ServiceProvider{
+		Code:       "semo",
+		ClientURLs: model.StringList{"https://semo.example.com"},
+		Credentials: model.ServiceProviderCredentials{
+			StripeSecretKey:     "sk_default",
+			StripeWebhookSecret: "whsec_default",
+			TossSecretKey:       "toss_default",
+		},
+	}
+	return NewServiceProviderRegistry(repo, encryption, fallback, time.Minute, zap.NewNop())
+}
+
+func TestServiceProviderRegistry_Resolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		code       string
+		stored     *model.ServiceProvider
+		wantCode   string
+		wantURL    string
+		wantStripe string
+		wantError  error
+	}{
+		{
+			name:       "blank code resolves the configured default",
+			code:       "",
+			wantCode:   "semo",
+			wantURL:    "https://semo.example.com",
+			wantStripe: "sk_default",
+		},
+		{
+			name:      "unknown provider is rejected",
+			code:      "acme",
+			wantError: domainErrors.ErrServiceProviderNotFound,
+		},
+		{
+			name:      "inactive provider is rejected",
+			code:      "acme",
+			stored:    &model.ServiceProvider{Code: "acme", IsActive: false},
+			wantError: domainErrors.ErrServiceProviderNotFound,
+		},
+		{
+			name:     "stored provider inherits default urls but not credentials",
+			code:     " ACME ",
+			stored:   &model.ServiceProvider{Code: "acme", IsActive: true},
+			wantCode: "acme",
+			wantURL:  "https://semo.example.com",
+		},
+		{
+			name:       "stored default provider inherits the deployment credentials",
+			code:       "semo",
+			stored:     &model.ServiceProvider{Code: "semo", IsActive: true},
+			wantCode:   "semo",
+			wantURL:    "https://semo.example.com",
+			wantStripe: "sk_default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockServiceProviderRepository)
+			registry := newTestServiceProviderRegistry(t, repo)
+
+			lookup := tt.wantCode
+			if lookup == "" {
+				lookup = "acme"
+			}
+			if tt.stored != nil {
+				repo.On("GetByCode", mock.Anything, lookup).Return(tt.stored, nil)
+			} else {
+				repo.On("GetByCode", mock.Anything, lookup).Return(nil, nil)
+			}
+
+			provider, err := registry.Resolve(context.Background(), tt.code)
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCode, provider.Code)
+			assert.Equal(t, tt.wantURL, provider.PrimaryClientURL())
+			assert.Equal(t, tt.wantStripe, provider.Credentials.StripeSecretKey)
+		})
+	}
+}
+
+func TestServiceProviderRegistry_ResolveUsesCache(t *testing.T) {
+	repo := new(MockServiceProviderRepository)
+	registry := newTestServiceProviderRegistry(t, repo)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	registry.now = func() time.Time { return now }
+
+	repo.On("GetByCode", mock.Anything, "acme").Return(nil, nil).Twice()
+
+	_, err := registry.Resolve(context.Background(), "acme")
+	assert.ErrorIs(t, err, domainErrors.ErrServiceProviderNotFound)
+	_, err = registry.Resolve(context.Background(), "acme")
+	assert.ErrorIs(t, err, domainErrors.ErrServiceProviderNotFound)
+	repo.AssertNumberOfCalls(t, "GetByCode", 1)
+
+	now = now.Add(2 * time.Minute)
+	_, err = registry.Resolve(context.Background(), "acme")
+	assert.ErrorIs(t, err, domainErrors.ErrServiceProviderNotFound)
+	repo.AssertNumberOfCalls(t, "GetByCode", 2)
+}
+
+func TestServiceProviderRegistry_CreateEncryptsCredentials(t *testing.T) {
+	repo := new(MockServiceProviderRepository)
+	registry := newTestServiceProviderRegistry(t, repo)
+
+	var stored model.ServiceProvider
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*model.ServiceProvider")).
+		Run(func(args mock.Arguments) {
+			stored = *args.Get(1).(*model.ServiceProvider)
+		}).
+		Return(nil)
+
+	_, err := registry.Create(context.Background(), &CreateServiceProviderRequest{
+		Code:        "Acme",
+		ClientURLs:  []string{"https://acme.example.com/"},
+		Credentials: model.ServiceProviderCredentials{StripeSecretKey: "sk_acme"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme", stored.Code)
+	assert.Equal(t, model.StringList{"https://acme.example.com"}, stored.ClientURLs)
+	assert.NotEmpty(t, stored.EncryptedCredentials)
+	assert.NotContains(t, stored.EncryptedCredentials, "sk_acme")
+
+	// The stored row resolves with its own credentials only
+	stored.Credentials = model.ServiceProviderCredentials{}
+	repo.On("GetByCode", mock.Anything, "acme").Return(&stored, nil)
+	repo.On("List", mock.Anything).Return([]*model.ServiceProvider{&stored}, nil)
+
+	provider, err := registry.Resolve(context.Background(), "acme")
+	require.NoError(t, err)
+	assert.Equal(t, "sk_acme", provider.Credentials.StripeSecretKey)
+	assert.Empty(t, provider.Credentials.StripeWebhookSecret)
+	assert.True(t, registry.AllowsOrigin(context.Background(), "https://acme.example.com"))
+}
+
+func TestServiceProviderRegistry_CreateRejectsInvalidCode(t *testing.T) {
+	repo := new(MockServiceProviderRepository)
+	registry := newTestServiceProviderRegistry(t, repo)
+
+	_, err := registry.Create(context.Background(), &CreateServiceProviderRequest{Code: "acme corp"})
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidServiceProviderCode)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -5,9 +5,9 @@ import (
 	"fmt"
 
 	"github.com/stripe/stripe-go/v79"
-	"github.com/stripe/stripe-go/v79/product"
-	"github.com/stripe/stripe-go/v79/subscription"
+	"github.com/stripe/stripe-go/v79/client"
 	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
 	domainProvider "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
 	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
 	"go.uber.org/zap"
@@ -33,8 +33,14 @@ func NewSubscriptionService(
 	}
 }
 
-// GetActiveSubscriptionForUser finds the active subscription for a given user ID
-func (s *SubscriptionService) GetActiveSubscriptionForUniversalID(ctx context.Context, universalID string) (*stripe.Subscription, error) {
+// GetActiveSubscriptionForUser finds the active subscription for a given user ID in the service
+// provider's Stripe account
+func (s *SubscriptionService) GetActiveSubscriptionForUniversalID(ctx context.Context, serviceProvider *model.ServiceProvider, universalID string) (*stripe.Subscription, error) {
+	stripeClient, err := StripeClient(serviceProvider)
+	if err != nil {
+		return nil, err
+	}
+
 	// Look up customer mapping
 	customerMapping, err := s.customerMappingRepo.GetByProviderAndUniversalID(ctx, string(domainProvider.ProviderTypeStripe), universalID)
 	if err != nil {
@@ -46,11 +52,11 @@ func (s *SubscriptionService) GetActiveSubscriptionForUniversalID(ctx context.Co
 	}
 
 	// Find active subscription
-	return s.GetActiveSubscriptionForCustomer(ctx, customerMapping.ProviderCustomerID)
+	return s.activeSubscriptionForCustomer(stripeClient, customerMapping.ProviderCustomerID)
 }
 
-// GetActiveSubscriptionForCustomer finds the active subscription for a given customer ID
-func (s *SubscriptionService) GetActiveSubscriptionForCustomer(ctx context.Context, customerID string) (*stripe.Subscription, error) {
+// activeSubscriptionForCustomer finds the active subscription for a given customer ID
+func (s *SubscriptionService) activeSubscriptionForCustomer(stripeClient *client.API, customerID string) (*stripe.Subscription, error) {
 	params := &stripe.SubscriptionListParams{
 		Customer: stripe.String(customerID),
 		Status:   stripe.String("all"),
@@ -58,7 +64,7 @@ func (s *SubscriptionService) GetActiveSubscriptionForCustomer(ctx context.Conte
 	// Expand only up to price level (4 levels max)
 	params.AddExpand("data.items.data.price")
 
-	iter := subscription.List(params)
+	iter := stripeClient.Subscriptions.List(params)
 
 	var activeSub *stripe.Subscription
 	for iter.Next() {
@@ -81,7 +87,7 @@ func (s *SubscriptionService) GetActiveSubscriptionForCustomer(ctx context.Conte
 	for _, item := range activeSub.Items.Data {
 		if item.Price != nil && item.Price.Product != nil && item.Price.Product.ID != "" {
 			// Product is already expanded as an ID, fetch full product details
-			prod, err := product.Get(item.Price.Product.ID, nil)
+			prod, err := stripeClient.Products.Get(item.Price.Product.ID, nil)
 			if err != nil {
 				s.logger.Warn("Failed to fetch product details",
 					zap.String("product_id", item.Price.Product.ID),
@@ -97,10 +103,16 @@ func (s *SubscriptionService) GetActiveSubscriptionForCustomer(ctx context.Conte
 	return activeSub, nil
 }
 
-// CancelSubscriptionForUser cancels the active subscription for a given user ID
-func (s *SubscriptionService) CancelSubscriptionForUniversalID(ctx context.Context, universalID string) (*stripe.Subscription, error) {
+// CancelSubscriptionForUser cancels the active subscription for a given user ID in the service
+// provider's Stripe account
+func (s *SubscriptionService) CancelSubscriptionForUniversalID(ctx context.Context, serviceProvider *model.ServiceProvider, universalID string) (*stripe.Subscription, error) {
+	stripeClient, err := StripeClient(serviceProvider)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get the active subscription
-	activeSub, err := s.GetActiveSubscriptionForUniversalID(ctx, universalID)
+	activeSub, err := s.GetActiveSubscriptionForUniversalID(ctx, serviceProvider, universalID)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +124,7 @@ func (s *SubscriptionService) CancelSubscriptionForUniversalID(ctx context.Conte
 	// Expand the response to include price details (but not beyond 4 levels)
 	params.AddExpand("items.data.price")
 
-	updatedSub, err := subscription.Update(activeSub.ID, params)
+	updatedSub, err := stripeClient.Subscriptions.Update(activeSub.ID, params)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to cancel subscription: %w", err)
@@ -128,7 +140,7 @@ func (s *SubscriptionService) CancelSubscriptionForUniversalID(ctx context.Conte
 	for _, item := range updatedSub.Items.Data {
 		if item.Price != nil && item.Price.Product != nil && item.Price.Product.ID != "" {
 			// Product is already expanded as an ID, fetch full product details
-			prod, err := product.Get(item.Price.Product.ID, nil)
+			prod, err := stripeClient.Products.Get(item.Price.Product.ID, nil)
 			if err != nil {
 				s.logger.Warn("Failed to fetch product details",
 					zap.String("product_id", item.Price.Product.ID),
@@ -0,0 +1,514 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	dbRepo "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/adapter/repository"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/provider"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// TrialNotifier delivers trial lifecycle notifications to users
+type TrialNotifier interface {
+	NotifyTrialWillEnd(ctx context.Context, trial *model.SubscriptionTrial, plan *model.PaymentPlan) error
+}
+
+// TrialService manages free trials of subscription plans
+type TrialService struct {
+	trialRepo      repository.TrialRepository
+	planRepo       dbRepo.PlanRepository
+	billingKeyRepo repository.BillingKeyRepository
+	billingService *BillingService
+	creditService  *CreditService
+	notifier       TrialNotifier
+	entitlements   *EntitlementService // optional; invalidated when a trial starts or ends
+	logger         *zap.Logger
+}
+
+// NewTrialService creates a new TrialService instance.
+// billingService may be nil when billing keys are not configured; Toss trials are then unavailable.
+func NewTrialService(
+	trialRepo repository.TrialRepository,
+	planRepo dbRepo.PlanRepository,
+	billingKeyRepo repository.BillingKeyRepository,
+	billingService *BillingService,
+	creditService *CreditService,
+	notifier TrialNotifier,
+	entitlements *EntitlementService,
+	logger *zap.Logger,
+) *TrialService {
+	return &TrialService{
+		trialRepo:      trialRepo,
+		planRepo:       planRepo,
+		billingKeyRepo: billingKeyRepo,
+		billingService: billingService,
+		creditService:  creditService,
+		notifier:       notifier,
+		entitlements:   entitlements,
+		logger:         logger,
+	}
+}
+
+// StartTrialRequest represents a request to start a Toss trial with a registered card
+type StartTrialRequest struct {
+	UniversalID     uuid.UUID
+	Email           string
+	PlanID          string
+	BillingKeyID    int64
+	ServiceProvider string
+}
+
+// CheckEligibility returns the plan when the user may start its free trial
+func (s *TrialService) CheckEligibility(ctx context.Context, universalID uuid.UUID, planID string) (*model.PaymentPlan, error) {
+	plan, err := s.planRepo.GetByPriceID(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil || !plan.IsActive || !plan.HasTrial() {
+		return nil, domainErrors.ErrTrialNotAvailable
+	}
+
+	used, err := s.trialRepo.ExistsForUniversalID(ctx, universalID)
+	if err != nil {
+		return nil, err
+	}
+	if used {
+		return nil, domainErrors.ErrTrialAlreadyUsed
+	}
+
+	return plan, nil
+}
+
+// StartTossTrial starts a trial billed through a Toss billing key once the trial period ends
+func (s *TrialService) StartTossTrial(ctx context.Context, req *StartTrialRequest) (*model.SubscriptionTrial, error) {
+	if s.billingService == nil {
+		return nil, domainErrors.ErrTrialNotAvailable
+	}
+
+	plan, err := s.CheckEligibility(ctx, req.UniversalID, req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+	if req.ServiceProvider != "" && !plan.OfferedBy(req.ServiceProvider) {
+		return nil, domainErrors.ErrTrialNotAvailable
+	}
+
+	billingKey, err := s.billingKeyRepo.GetByID(ctx, req.BillingKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get billing key: %w", err)
+	}
+	if billingKey == nil || billingKey.UniversalID != req.UniversalID || !billingKey.IsActive {
+		return nil, fmt.Errorf("billing key not found")
+	}
+
+	cardUsed, err := s.trialRepo.ExistsForCardFingerprint(ctx, billingKey.CardFingerprint, billingKey.CardCompany, billingKey.CardLastFour, 0)
+	if err != nil {
+		return nil, err
+	}
+	if cardUsed {
+		s.logger.Warn("Rejected trial for previously used card",
+			zap.String("universal_id", req.UniversalID.String()),
+			zap.Int64("billing_key_id", billingKey.ID))
+		return nil, domainErrors.ErrTrialCardAlreadyUsed
+	}
+
+	now := time.Now()
+	billingKeyID := billingKey.ID
+	trial := &model.SubscriptionTrial{
+		UniversalID:        req.UniversalID,
+		Email:              req.Email,
+		PlanID:             plan.ProviderPriceID,
+		Provider:           string(provider.ProviderTypeToss),
+		ServiceProvider:    req.ServiceProvider,
+		Status:             model.TrialStatusActive,
+		BillingKeyID:       &billingKeyID,
+		CardFingerprint:    billingKey.CardFingerprint,
+		ProviderCustomerID: billingKey.CustomerKey,
+		TrialCredits:       plan.TrialCredits,
+		StartedAt:          now,
+		EndsAt:             now.AddDate(0, 0, plan.TrialPeriodDays),
+	}
+
+	if err := s.trialRepo.Create(ctx, trial); err != nil {
+		return nil, err
+	}
+	s.entitlements.Invalidate(trial.UniversalID.String())
+
+	s.logger.Info("Toss trial started",
+		zap.Int64("trial_id", trial.ID),
+		zap.String("universal_id", req.UniversalID.String()),
+		zap.String("plan_id", plan.ProviderPriceID),
+		zap.Time("ends_at", trial.EndsAt))
+
+	s.grantTrialCredits(ctx, trial)
+
+	return trial, nil
+}
+
+// RecordStripeTrial records a trial created on a Stripe subscription.
+// Trial credits are granted once the card is collected through the subscription's SetupIntent.
+func (s *TrialService) RecordStripeTrial(ctx context.Context, universalID uuid.UUID, email string, plan *model.PaymentPlan, customerID string, subscriptionID string, endsAt time.Time) (*model.SubscriptionTrial, error) {
+	trial := &model.SubscriptionTrial{
+		UniversalID:            universalID,
+		Email:                  email,
+		PlanID:                 plan.ProviderPriceID,
+		Provider:               string(provider.ProviderTypeStripe),
+		Status:                 model.TrialStatusActive,
+		ProviderCustomerID:     customerID,
+		ProviderSubscriptionID: &subscriptionID,
+		TrialCredits:           plan.TrialCredits,
+		StartedAt:              time.Now(),
+		EndsAt:                 endsAt,
+	}
+
+	if err := s.trialRepo.Create(ctx, trial); err != nil {
+		return nil, err
+	}
+	s.entitlements.Invalidate(trial.UniversalID.String())
+
+	s.logger.Info("Stripe trial started",
+		zap.Int64("trial_id", trial.ID),
+		zap.String("universal_id", universalID.String()),
+		zap.String("subscription_id", subscriptionID),
+		zap.Time("ends_at", endsAt))
+
+	return trial, nil
+}
+
+// AttachStripeCard records the fingerprint of the card collected for a Stripe trial and grants
+// its trial credits. It returns ErrTrialCardAlreadyUsed when the card already backed another trial,
+// in which case the trial is marked failed and the caller should end it at Stripe.
+func (s *TrialService) AttachStripeCard(ctx context.Context, customerID string, fingerprint string) (*model.SubscriptionTrial, error) {
+	trial, err := s.trialRepo.GetActiveByProviderCustomerID(ctx, string(provider.ProviderTypeStripe), customerID)
+	if err != nil || trial == nil {
+		return nil, err
+	}
+	if trial.CardFingerprint != "" {
+		return trial, nil
+	}
+
+	cardUsed, err := s.trialRepo.ExistsForCardFingerprint(ctx, fingerprint, "", "", trial.ID)
+	if err != nil {
+		return nil, err
+	}
+	if cardUsed {
+		s.logger.Warn("Ending Stripe trial for previously used card",
+			zap.Int64("trial_id", trial.ID),
+			zap.String("customer_id", customerID))
+		if err := s.trialRepo.Update(ctx, trial.ID, map[string]interface{}{
+			"status":           model.TrialStatusFailed,
+			"card_fingerprint": fingerprint,
+			"failure_reason":   domainErrors.ErrTrialCardAlreadyUsed.Error(),
+		}); err != nil {
+			return nil, err
+		}
+		return trial, domainErrors.ErrTrialCardAlreadyUsed
+	}
+
+	if err := s.trialRepo.Update(ctx, trial.ID, map[string]interface{}{
+		"card_fingerprint": fingerprint,
+	}); err != nil {
+		return nil, err
+	}
+	trial.CardFingerprint = fingerprint
+
+	s.grantTrialCredits(ctx, trial)
+
+	return trial, nil
+}
+
+// HandleStripeTrialWillEnd notifies the user that a Stripe trial is about to convert
+func (s *TrialService) HandleStripeTrialWillEnd(ctx context.Context, subscriptionID string) error {
+	trial, err := s.trialRepo.GetByProviderSubscriptionID(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+	if trial == nil || trial.Status != model.TrialStatusActive || trial.WillEndNotifiedAt != nil {
+		return nil
+	}
+
+	return s.notifyWillEnd(ctx, trial)
+}
+
+// SyncStripeSubscriptionStatus closes a Stripe trial when its subscription leaves the trialing state
+func (s *TrialService) SyncStripeSubscriptionStatus(ctx context.Context, subscriptionID string, status string) error {
+	trial, err := s.trialRepo.GetByProviderSubscriptionID(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+	if trial == nil || trial.Status != model.TrialStatusActive {
+		return nil
+	}
+
+	updates := map[string]interface{}{}
+	switch status {
+	case "active":
+		updates["status"] = model.TrialStatusConverted
+		updates["converted_at"] = time.Now()
+	case "canceled", "incomplete_expired":
+		updates["status"] = model.TrialStatusCanceled
+	case "past_due", "unpaid":
+		updates["status"] = model.TrialStatusFailed
+		updates["failure_reason"] = "conversion payment failed"
+	default:
+		return nil
+	}
+
+	s.logger.Info("Stripe trial closed",
+		zap.Int64("trial_id", trial.ID),
+		zap.String("subscription_id", subscriptionID),
+		zap.String("subscription_status", status))
+
+	if err := s.trialRepo.Update(ctx, trial.ID, updates); err != nil {
+		return err
+	}
+	s.entitlements.Invalidate(trial.UniversalID.String())
+	return nil
+}
+
+// GetCurrentTrial returns the user's most recent trial
+func (s *TrialService) GetCurrentTrial(ctx context.Context, universalID uuid.UUID) (*model.SubscriptionTrial, error) {
+	trial, err := s.trialRepo.GetLatestByUniversalID(ctx, universalID)
+	if err != nil {
+		return nil, err
+	}
+	if trial == nil {
+		return nil, domainErrors.ErrTrialNotFound
+	}
+	return trial, nil
+}
+
+// CancelTossTrial cancels the user's active Toss trial so it is not charged at the end of the trial
+func (s *TrialService) CancelTossTrial(ctx context.Context, universalID uuid.UUID) (*model.SubscriptionTrial, error) {
+	trial, err := s.trialRepo.GetLatestByUniversalID(ctx, universalID)
+	if err != nil {
+		return nil, err
+	}
+	if trial == nil || trial.Status != model.TrialStatusActive || trial.Provider != string(provider.ProviderTypeToss) {
+		return nil, domainErrors.ErrTrialNotFound
+	}
+
+	if err := s.trialRepo.Update(ctx, trial.ID, map[string]interface{}{
+		"status": model.TrialStatusCanceled,
+	}); err != nil {
+		return nil, err
+	}
+	trial.Status = model.TrialStatusCanceled
+	s.entitlements.Invalidate(universalID.String())
+
+	s.logger.Info("Toss trial cancelled",
+		zap.Int64("trial_id", trial.ID),
+		zap.String("universal_id", universalID.String()))
+
+	return trial, nil
+}
+
+// NotifyEndingTrials sends trial_will_end notifications for Toss trials ending within the lead time.
+// Stripe sends its own customer.subscription.trial_will_end event.
+func (s *TrialService) NotifyEndingTrials(ctx context.Context, now time.Time, lead time.Duration) (int, error) {
+	trials, err := s.trialRepo.ListEndingBefore(ctx, string(provider.ProviderTypeToss), now.Add(lead))
+	if err != nil {
+		return 0, err
+	}
+
+	notified := 0
+	for _, trial := range trials {
+		if err := s.notifyWillEnd(ctx, trial); err != nil {
+			s.logger.Error("Failed to send trial_will_end notification",
+				zap.Int64("trial_id", trial.ID),
+				zap.Error(err))
+			continue
+		}
+		notified++
+	}
+
+	return notified, nil
+}
+
+// trialConversionClaimTimeout is how long a trial stays claimed by a conversion run before another
+// run may retry it. Retries charge under the same order ID, so Toss never charges a trial twice.
+const trialConversionClaimTimeout = 10 * time.Minute
+
+// trialConversionOrderID returns the order ID a trial's conversion is charged under
+func trialConversionOrderID(trialID int64) string {
+	return fmt.Sprintf("TRIAL_%d", trialID)
+}
+
+// ConvertDueTrials charges the registered card of every Toss trial whose trial period is over
+func (s *TrialService) ConvertDueTrials(ctx context.Context, now time.Time, limit int) (converted int, failed int, err error) {
+	if s.billingService == nil {
+		return 0, 0, fmt.Errorf("billing service not configured")
+	}
+
+	staleBefore := now.Add(-trialConversionClaimTimeout)
+	trials, err := s.trialRepo.ListDue(ctx, string(provider.ProviderTypeToss), now, staleBefore, limit)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, trial := range trials {
+		// Overlapping runs list the same trials; only the run that claims a trial charges it
+		claimed, err := s.trialRepo.ClaimForConversion(ctx, trial.ID, staleBefore)
+		if err != nil {
+			failed++
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := s.convertTossTrial(ctx, trial); err != nil {
+			failed++
+			continue
+		}
+		converted++
+	}
+
+	return converted, failed, nil
+}
+
+func (s *TrialService) convertTossTrial(ctx context.Context, trial *model.SubscriptionTrial) error {
+	fail := func(reason error) error {
+		s.logger.Error("Trial conversion failed",
+			zap.Int64("trial_id", trial.ID),
+			zap.String("universal_id", trial.UniversalID.String()),
+			zap.Error(reason))
+		if err := s.trialRepo.Update(ctx, trial.ID, map[string]interface{}{
+			"status":         model.TrialStatusFailed,
+			"failure_reason": reason.Error(),
+		}); err != nil {
+			s.logger.Error("Failed to record trial conversion failure",
+				zap.Int64("trial_id", trial.ID),
+				zap.Error(err))
+		}
+		s.entitlements.Invalidate(trial.UniversalID.String())
+		return reason
+	}
+
+	if trial.BillingKeyID == nil {
+		return fail(fmt.Errorf("trial has no billing key"))
+	}
+
+	plan, err := s.planRepo.GetByPriceID(ctx, trial.PlanID)
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		return fail(fmt.Errorf("plan %s not found", trial.PlanID))
+	}
+
+	amount := planPriceAmount(plan)
+	if amount <= 0 {
+		return fail(fmt.Errorf("plan %s has no price", trial.PlanID))
+	}
+
+	result, err := s.billingService.ChargeBillingKey(
+		ctx,
+		trial.UniversalID,
+		*trial.BillingKeyID,
+		trialConversionOrderID(trial.ID),
+		amount,
+		plan.DisplayName,
+		plan.ProviderPriceID,
+		trial.ServiceProvider,
+		"",
+		"",
+		"trial-conversion",
+	)
+	if err != nil {
+		return fail(err)
+	}
+
+	// A trial left converting when this update fails is retried later; the charge is then
+	// found under its order ID instead of being made again
+	if err := s.trialRepo.Update(ctx, trial.ID, map[string]interface{}{
+		"status":              model.TrialStatusConverted,
+		"converted_at":        time.Now(),
+		"conversion_order_id": result.OrderID,
+	}); err != nil {
+		return err
+	}
+	s.entitlements.Invalidate(trial.UniversalID.String())
+
+	s.logger.Info("Trial converted to paid subscription",
+		zap.Int64("trial_id", trial.ID),
+		zap.String("order_id", result.OrderID),
+		zap.Int64("amount", result.Amount))
+
+	return nil
+}
+
+func (s *TrialService) notifyWillEnd(ctx context.Context, trial *model.SubscriptionTrial) error {
+	plan, err := s.planRepo.GetByPriceID(ctx, trial.PlanID)
+	if err != nil {
+		return err
+	}
+
+	if s.notifier != nil {
+		if err := s.notifier.NotifyTrialWillEnd(ctx, trial, plan); err != nil {
+			return err
+		}
+	}
+
+	return s.trialRepo.Update(ctx, trial.ID, map[string]interface{}{
+		"will_end_notified_at": time.Now(),
+	})
+}
+
+func (s *TrialService) grantTrialCredits(ctx context.Context, trial *model.SubscriptionTrial) {
+	if trial.TrialCredits <= 0 || trial.CreditsGrantedAt != nil || s.creditService == nil {
+		return
+	}
+
+	// The reference ID keeps the grant idempotent if the trial is processed twice
+	_, _, err := s.creditService.AllocateCreditsManual(
+		ctx,
+		trial.UniversalID,
+		trial.ServiceProvider,
+		trial.TrialCredits,
+		"Free trial credits",
+		fmt.Sprintf("trial:%d", trial.ID),
+	)
+	if err != nil {
+		s.logger.Error("Failed to grant trial credits",
+			zap.Int64("trial_id", trial.ID),
+			zap.Int("credits", trial.TrialCredits),
+			zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	if err := s.trialRepo.Update(ctx, trial.ID, map[string]interface{}{
+		"credits_granted_at": now,
+	}); err != nil {
+		s.logger.Error("Failed to record trial credit grant",
+			zap.Int64("trial_id", trial.ID),
+			zap.Error(err))
+	}
+	trial.CreditsGrantedAt = &now
+}
+
+// planPriceAmount extracts the price amount stored in a plan's features
+func planPriceAmount(plan *model.PaymentPlan) int64 {
+	priceMap, ok := plan.Features["price"].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+
+	switch amount := priceMap["amount"].(type) {
+	case float64:
+		return int64(amount)
+	case int:
+		return int64(amount)
+	case int64:
+		return amount
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// MockTrialRepository is a mock implementation of TrialRepository
+type MockTrialRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrialRepository) Create(ctx context.Context, trial *model.SubscriptionTrial) error {
+	args := m.Called(ctx, trial)
+	return args.Error(0)
+}
+
+func (m *MockTrialRepository) GetByID(ctx context.Context, id int64) (*model.SubscriptionTrial, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SubscriptionTrial), args.Error(1)
+}
+
+func (m *MockTrialRepository) GetLatestByUniversalID(ctx context.Context, universalID uuid.UUID) (*model.SubscriptionTrial, error) {
+	args := m.Called(ctx, universalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SubscriptionTrial), args.Error(1)
+}
+
+func (m *MockTrialRepository) GetByProviderSubscriptionID(ctx context.Context, subscriptionID string) (*model.SubscriptionTrial, error) {
+	args := m.Called(ctx, subscriptionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SubscriptionTrial), args.Error(1)
+}
+
+func (m *MockTrialRepository) GetActiveByProviderCustomerID(ctx context.Context, provider string, customerID string) (*model.SubscriptionTrial, error) {
+	args := m.Called(ctx, provider, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SubscriptionTrial), args.Error(1)
+}
+
+func (m *MockTrialRepository) ExistsForUniversalID(ctx context.Context, universalID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, universalID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTrialRepository) ExistsForCardFingerprint(ctx context.Context, fingerprint string, cardCompany string, cardLastFour string, excludeID int64) (bool, error) {
+	args := m.Called(ctx, fingerprint, cardCompany, cardLastFour, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTrialRepository) ListEndingBefore(ctx context.Context, provider string, before time.Time) ([]*model.SubscriptionTrial, error) {
+	args := m.Called(ctx, provider, before)
+	return args.Get(0).([]*model.SubscriptionTrial), args.Error(1)
+}
+
+func (m *MockTrialRepository) ListDue(ctx context.Context, provider string, now time.Time, staleBefore time.Time, limit int) ([]*model.SubscriptionTrial, error) {
+	args := m.Called(ctx, provider, now, staleBefore, limit)
+	return args.Get(0).([]*model.SubscriptionTrial), args.Error(1)
+}
+
+func (m *MockTrialRepository) ClaimForConversion(ctx context.Context, id int64, staleBefore time.Time) (bool, error) {
+	args := m.Called(ctx, id, staleBefore)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTrialRepository) Update(ctx context.Context, id int64, updates map[string]interface{}) error {
+	args := m.Called(ctx, id, updates)
+	return args.Error(0)
+}
+
+// MockPlanRepository is a mock implementation of PlanRepository
+type MockPlanRepository struct {
+	mock.Mock
+}
+
+func (m *MockPlanRepository) GetAll(ctx context.Context) ([]*model.PaymentPlan, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*model.PaymentPlan), args.Error(1)
+}
+
+func (m *MockPlanRepository) GetByType(ctx context.Context, planType string) ([]*model.PaymentPlan, error) {
+	args := m.Called(ctx, planType)
+	return args.Get(0).([]*model.PaymentPlan), args.Error(1)
+}
+
+func (m *MockPlanRepository) GetByTypeAndProvider(ctx context.Context, planType string, provider string, currency string) ([]*model.PaymentPlan, error) {
+	args := m.Called(ctx, planType, provider, currency)
+	return args.Get(0).([]*model.PaymentPlan), args.Error(1)
+}
+
+func (m *MockPlanRepository) GetByPriceID(ctx context.Context, priceID string) (*model.PaymentPlan, error) {
+	args := m.Called(ctx, priceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PaymentPlan), args.Error(1)
+}
+
+func (m *MockPlanRepository) GetByProductID(ctx context.Context, productID string) ([]*model.PaymentPlan, error) {
+	args := m.Called(ctx, productID)
+	return args.Get(0).([]*model.PaymentPlan), args.Error(1)
+}
+
+func (m *MockPlanRepository) Create(ctx context.Context, plan *model.PaymentPlan) error {
+	args := m.Called(ctx, plan)
+	return args.Error(0)
+}
+
+func (m *MockPlanRepository) Update(ctx context.Context, plan *model.PaymentPlan) error {
+	args := m.Called(ctx, plan)
+	return args.Error(0)
+}
+
+func (m *MockPlanRepository) Delete(ctx context.Context, priceID string) error {
+	args := m.Called(ctx, priceID)
+	return args.Error(0)
+}
+
+func (m *MockPlanRepository) Upsert(ctx context.Context, plan *model.PaymentPlan) error {
+	args := m.Called(ctx, plan)
+	return args.Error(0)
+}
+
+func (m *MockPlanRepository) UpsertPrice(ctx context.Context, price *model.PlanPrice) error {
+	args := m.Called(ctx, price)
+	return args.Error(0)
+}
+
+func TestTrialService_CheckEligibility(t *testing.T) {
+	userID := uuid.New()
+	trialPlan := &model.PaymentPlan{
+		ProviderPriceID: "price_pro_monthly",
+		Type:            model.PlanTypeSubscription,
+		IsActive:        true,
+		TrialPeriodDays: 14,
+		TrialCredits:    50,
+	}
+	noTrialPlan := &model.PaymentPlan{
+		ProviderPriceID: "price_basic_monthly",
+		Type:            model.PlanTypeSubscription,
+		IsActive:        true,
+	}
+
+	tests := []struct {
+		name          string
+		planID        string
+		plan          *model.PaymentPlan
+		usedBefore    bool
+		expectedError error
+	}{
+		{
+			name:   "eligible for trial",
+			planID: "price_pro_monthly",
+			plan:   trialPlan,
+		},
+		{
+			name:          "plan without trial",
+			planID:        "price_basic_monthly",
+			plan:          noTrialPlan,
+			expectedError: domainErrors.ErrTrialNotAvailable,
+		},
+		{
+			name:          "unknown plan",
+			planID:        "price_unknown",
+			expectedError: domainErrors.ErrTrialNotAvailable,
+		},
+		{
+			name:          "trial already used",
+			planID:        "price_pro_monthly",
+			plan:          trialPlan,
+			usedBefore:    true,
+			expectedError: domainErrors.ErrTrialAlreadyUsed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trialRepo := new(MockTrialRepository)
+			planRepo := new(MockPlanRepository)
+			ctx := context.Background()
+
+			if tt.plan != nil {
+				planRepo.On("GetByPriceID", ctx, tt.planID).Return(tt.plan, nil)
+			} else {
+				planRepo.On("GetByPriceID", ctx, tt.planID).Return(nil, nil)
+			}
+			trialRepo.On("ExistsForUniversalID", ctx, userID).Return(tt.usedBefore, nil).Maybe()
+
+			service := NewTrialService(trialRepo, planRepo, nil, nil, nil, nil, nil, zap.NewNop())
+			plan, err := service.CheckEligibility(ctx, userID, tt.planID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, plan)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.plan, plan)
+			}
+		})
+	}
+}
+
+func TestTrialService_AttachStripeCard_RejectsReusedCard(t *testing.T) {
+	trialRepo := new(MockTrialRepository)
+	ctx := context.Background()
+	trial := &model.SubscriptionTrial{
+		ID:                 7,
+		UniversalID:        uuid.New(),
+		Provider:           "stripe",
+		Status:             model.TrialStatusActive,
+		Email:              "user@example.com",
+		ProviderCustomerID: "cus_123",
+	}
+
+	trialRepo.On("GetActiveByProviderCustomerID", ctx, "stripe", "cus_123").Return(trial, nil)
+	trialRepo.On("ExistsForCardFingerprint", ctx, "fp_abc", "", "", int64(7)).Return(true, nil)
+	trialRepo.On("Update", ctx, int64(7), mock.Anything).Return(nil)
+
+	service := NewTrialService(trialRepo, new(MockPlanRepository), nil, nil, nil, nil, nil, zap.NewNop())
+	_, err := service.AttachStripeCard(ctx, "cus_123", "fp_abc")
+
+	assert.ErrorIs(t, err, domainErrors.ErrTrialCardAlreadyUsed)
+	trialRepo.AssertExpectations(t)
+}
+
+func TestTrialService_StartTossTrial(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	plan := &model.PaymentPlan{
+		ProviderPriceID: "price_pro_monthly",
+		Type:            model.PlanTypeSubscription,
+		IsActive:        true,
+		TrialPeriodDays: 14,
+	}
+	billingKey := &model.BillingKey{
+		ID:              3,
+		UniversalID:     userID,
+		CardCompany:     "신한",
+		CardLastFour:    "123*",
+		CardFingerprint: "fp_toss",
+		IsActive:        true,
+	}
+	req := &StartTrialRequest{UniversalID: userID, Email: "new@example.com", PlanID: "price_pro_monthly", BillingKeyID: 3}
+
+	setup := func() (*MockTrialRepository, *TrialService) {
+		trialRepo := new(MockTrialRepository)
+		planRepo := new(MockPlanRepository)
+		billingKeyRepo := new(MockBillingKeyRepository)
+		planRepo.On("GetByPriceID", ctx, "price_pro_monthly").Return(plan, nil)
+		trialRepo.On("ExistsForUniversalID", ctx, userID).Return(false, nil)
+		billingKeyRepo.On("GetByID", ctx, int64(3)).Return(billingKey, nil)
+		return trialRepo, NewTrialService(trialRepo, planRepo, billingKeyRepo, &BillingService{}, nil, nil, nil, zap.NewNop())
+	}
+
+	t.Run("rejects a card used by another account whatever its email", func(t *testing.T) {
+		trialRepo, service := setup()
+		trialRepo.On("ExistsForCardFingerprint", ctx, "fp_toss", "신한", "123*", int64(0)).Return(true, nil)
+
+		_, err := service.StartTossTrial(ctx, req)
+
+		assert.ErrorIs(t, err, domainErrors.ErrTrialCardAlreadyUsed)
+		trialRepo.AssertExpectations(t)
+		trialRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("loses a concurrent start to the unique index", func(t *testing.T) {
+		trialRepo, service := setup()
+		trialRepo.On("ExistsForCardFingerprint", ctx, "fp_toss", "신한", "123*", int64(0)).Return(false, nil)
+		trialRepo.On("Create", ctx, mock.Anything).Return(domainErrors.ErrTrialAlreadyUsed)
+
+		_, err := service.StartTossTrial(ctx, req)
+
+		assert.ErrorIs(t, err, domainErrors.ErrTrialAlreadyUsed)
+		trialRepo.AssertExpectations(t)
+	})
+}
+
+func TestTrialService_ConvertDueTrials_SkipsClaimedTrials(t *testing.T) {
+	trialRepo := new(MockTrialRepository)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	staleBefore := now.Add(-trialConversionClaimTimeout)
+
+	due := []*model.SubscriptionTrial{
+		{ID: 1, Provider: "toss", Status: model.TrialStatusActive},
+		{ID: 2, Provider: "toss", Status: model.TrialStatusConverting},
+	}
+	trialRepo.On("ListDue", ctx, "toss", now, staleBefore, 10).Return(due, nil)
+	trialRepo.On("ClaimForConversion", ctx, int64(1), staleBefore).Return(false, nil)
+	trialRepo.On("ClaimForConversion", ctx, int64(2), staleBefore).Return(false, nil)
+
+	service := NewTrialService(trialRepo, new(MockPlanRepository), nil, &BillingService{}, nil, nil, nil, zap.NewNop())
+	converted, failed, err := service.ConvertDueTrials(ctx, now, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, converted)
+	assert.Equal(t, 0, failed)
+	trialRepo.AssertExpectations(t)
+	trialRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// maxUsagePeriods bounds the number of periods a single usage report may span
+const maxUsagePeriods = 366
+
+// UsageQuery selects the credit usage to report. Zero From and To select the current period;
+// To is the last period included.
+type UsageQuery struct {
+	Granularity     model.UsageGranularity
+	From            time.Time
+	To              time.Time
+	UniversalID     *uuid.UUID
+	ActorID         *uuid.UUID
+	ServiceProvider string
+	FeatureName     string
+}
+
+// UsageFeatureTotal is the usage of one feature over a whole report
+type UsageFeatureTotal struct {
+	FeatureName string          `json:"feature_name"`
+	Credits     decimal.Decimal `json:"credits"`
+	UsageCount  int64           `json:"usage_count"`
+}
+
+// UsageReport is the credit usage over a range of periods
+type UsageReport struct {
+	Granularity  model.UsageGranularity `json:"granularity"`
+	From         time.Time              `json:"from"`
+	To           time.Time              `json:"to"` // exclusive
+	TotalCredits decimal.Decimal        `json:"total_credits"`
+	TotalCount   int64                  `json:"total_count"`
+	Features     []UsageFeatureTotal    `json:"features"`
+	Rollups      []*model.UsageRollup   `json:"rollups"`
+}
+
+// UsageService reports credit usage from the usage rollups
+type UsageService struct {
+	usageRepo repository.UsageRepository
+	logger    *zap.Logger
+	now       func() time.Time
+}
+
+// NewUsageService creates a new UsageService instance
+func NewUsageService(usageRepo repository.UsageRepository, logger *zap.Logger) *UsageService {
+	return &UsageService{
+		usageRepo: usageRepo,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// GetUsageReport returns usage per period and totals per feature for the query
+func (s *UsageService) GetUsageReport(ctx context.Context, query UsageQuery) (*UsageReport, error) {
+	filters, err := s.filters(query)
+	if err != nil {
+		return nil, err
+	}
+
+	rollups, err := s.usageRepo.ListRollups(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UsageReport{
+		Granularity:  filters.Granularity,
+		From:         filters.From,
+		To:           filters.To,
+		TotalCredits: decimal.Zero,
+		Features:     []UsageFeatureTotal{},
+		Rollups:      rollups,
+	}
+
+	totals := make(map[string]*UsageFeatureTotal)
+	for _, rollup := range rollups {
+		report.TotalCredits = report.TotalCredits.Add(rollup.Credits)
+		report.TotalCount += rollup.UsageCount
+
+		total, ok := totals[rollup.FeatureName]
+		if !ok {
+			total = &UsageFeatureTotal{FeatureName: rollup.FeatureName, Credits: decimal.Zero}
+			totals[rollup.FeatureName] = total
+		}
+		total.Credits = total.Credits.Add(rollup.Credits)
+		total.UsageCount += rollup.UsageCount
+	}
+
+	for _, total := range totals {
+		report.Features = append(report.Features, *total)
+	}
+	sort.Slice(report.Features, func(i, j int) bool {
+		return report.Features[i].Credits.GreaterThan(report.Features[j].Credits)
+	})
+
+	return report, nil
+}
+
+// RefreshRollups recomputes every granularity for the periods touched since the given time
+func (s *UsageService) RefreshRollups(ctx context.Context, since time.Time) (int64, error) {
+	var total int64
+	for _, granularity := range model.UsageGranularities {
+		rows, err := s.usageRepo.RefreshRollups(ctx, granularity, since)
+		if err != nil {
+			return total, err
+		}
+		s.logger.Info("Usage rollups refreshed",
+			zap.String("granularity", string(granularity)),
+			zap.Time("from", granularity.PeriodStart(since)),
+			zap.Int64("rows", rows))
+		total += rows
+	}
+	return total, nil
+}
+
+// UsageCSVHeader lists the columns written by WriteUsageCSV
+var UsageCSVHeader = []string{"period_start", "granularity", "universal_id", "actor_id", "service_provider", "feature_name", "credits", "usage_count"}
+
+// WriteUsageCSV writes the report's rollups as CSV
+func WriteUsageCSV(w io.Writer, report *UsageReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(UsageCSVHeader); err != nil {
+		return err
+	}
+
+	for _, rollup := range report.Rollups {
+		record := []string{
+			rollup.PeriodStart.Format("2006-01-02"),
+			string(rollup.Granularity),
+			rollup.UniversalID.String(),
+			rollup.ActorID.String(),
+			rollup.ServiceProvider,
+			rollup.FeatureName,
+			rollup.Credits.StringFixed(2),
+			strconv.FormatInt(rollup.UsageCount, 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// filters validates the query and expands it to whole periods
+func (s *UsageService) filters(query UsageQuery) (dto.UsageFilters, error) {
+	granularity := query.Granularity
+	if granularity == "" {
+		granularity = model.UsageGranularityMonth
+	}
+	if !granularity.Valid() {
+		return dto.UsageFilters{}, domainErrors.ErrInvalidUsageGranularity
+	}
+
+	from := query.From
+	if from.IsZero() {
+		from = s.now()
+	}
+	to := query.To
+	if to.IsZero() {
+		to = s.now()
+	}
+
+	start := granularity.PeriodStart(from)
+	end := granularity.NextPeriod(granularity.PeriodStart(to))
+	if !end.After(start) {
+		return dto.UsageFilters{}, fmt.Errorf("%w: from must not be after to", domainErrors.ErrInvalidUsageRange)
+	}
+
+	periods := 0
+	for p := start; p.Before(end); p = granularity.NextPeriod(p) {
+		periods++
+		if periods > maxUsagePeriods {
+			return dto.UsageFilters{}, fmt.Errorf("%w: at most %d %ss", domainErrors.ErrInvalidUsageRange, maxUsagePeriods, granularity)
+		}
+	}
+
+	return dto.UsageFilters{
+		Granularity:     granularity,
+		From:            start,
+		To:              end,
+		UniversalID:     query.UniversalID,
+		ActorID:         query.ActorID,
+		ServiceProvider: query.ServiceProvider,
+		FeatureName:     query.FeatureName,
+	}, nil
+}
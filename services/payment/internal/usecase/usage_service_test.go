@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/dto"
+	domainErrors "github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/errors"
+	"github.com/wekeepgrowing/semo-backend-monorepo/services/payment/internal/domain/model"
+	"go.uber.org/zap"
+)
+
+// MockUsageRepository is a mock implementation of UsageRepository
+type MockUsageRepository struct {
+	mock.Mock
+}
+
+func (m *MockUsageRepository) RefreshRollups(ctx context.Context, granularity model.UsageGranularity, from time.Time) (int64, error) {
+	args := m.Called(ctx, granularity, from)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUsageRepository) ListRollups(ctx context.Context, filters dto.UsageFilters) ([]*model.UsageRollup, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.UsageRollup), args.Error(1)
+}
+
+func TestUsageService_Filters(t *testing.T) {
+	kst := model.UsageLocation
+	// Wednesday 2026-03-18 01:00 KST is still Tuesday in UTC
+	now := time.Date(2026, 3, 17, 16, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		query     UsageQuery
+		wantFrom  time.Time
+		wantTo    time.Time
+		wantError error
+	}{
+		{
+			name:     "defaults to the current month",
+			query:    UsageQuery{},
+			wantFrom: time.Date(2026, 3, 1, 0, 0, 0, 0, kst),
+			wantTo:   time.Date(2026, 4, 1, 0, 0, 0, 0, kst),
+		},
+		{
+			name:     "current day in KST",
+			query:    UsageQuery{Granularity: model.UsageGranularityDay},
+			wantFrom: time.Date(2026, 3, 18, 0, 0, 0, 0, kst),
+			wantTo:   time.Date(2026, 3, 19, 0, 0, 0, 0, kst),
+		},
+		{
+			name: "weeks start on Monday and include the last week",
+			query: UsageQuery{
+				Granularity: model.UsageGranularityWeek,
+				From:        time.Date(2026, 3, 4, 0, 0, 0, 0, kst),
+				To:          time.Date(2026, 3, 15, 0, 0, 0, 0, kst),
+			},
+			wantFrom: time.Date(2026, 3, 2, 0, 0, 0, 0, kst),
+			wantTo:   time.Date(2026, 3, 16, 0, 0, 0, 0, kst),
+		},
+		{
+			name:      "unknown granularity",
+			query:     UsageQuery{Granularity: "hour"},
+			wantError: domainErrors.ErrInvalidUsageGranularity,
+		},
+		{
+			name: "from after to",
+			query: UsageQuery{
+				From: time.Date(2026, 5, 1, 0, 0, 0, 0, kst),
+				To:   time.Date(2026, 3, 1, 0, 0, 0, 0, kst),
+			},
+			wantError: domainErrors.ErrInvalidUsageRange,
+		},
+		{
+			name: "too many periods",
+			query: UsageQuery{
+				Granularity: model.UsageGranularityDay,
+				From:        time.Date(2024, 1, 1, 0, 0, 0, 0, kst),
+				To:          time.Date(2026, 1, 1, 0, 0, 0, 0, kst),
+			},
+			wantError: domainErrors.ErrInvalidUsageRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewUsageService(new(MockUsageRepository), zap.NewNop())
+			service.now = func() time.Time { return now }
+
+			filters, err := service.filters(tt.query)
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.wantFrom.Equal(filters.From), "from = %s", filters.From)
+			assert.True(t, tt.wantTo.Equal(filters.To), "to = %s", filters.To)
+		})
+	}
+}
+
+func TestUsageService_GetUsageReport(t *testing.T) {
+	universalID := uuid.MustParse("3a7c1e52-0000-4000-8000-000000000001")
+	memberID := uuid.MustParse("3a7c1e52-0000-4000-8000-000000000002")
+	march := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	repo := new(MockUsageRepository)
+	repo.On("ListRollups", mock.Anything, mock.MatchedBy(func(f dto.UsageFilters) bool {
+		return f.UniversalID != nil && *f.UniversalID == universalID && f.Granularity == model.UsageGranularityMonth
+	})).Return([]*model.UsageRollup{
+		{Granularity: model.UsageGranularityMonth, PeriodStart: march, UniversalID: universalID, ActorID: universalID, FeatureName: "summarization", Credits: decimal.NewFromInt(80), UsageCount: 8},
+		{Granularity: model.UsageGranularityMonth, PeriodStart: march, UniversalID: universalID, ActorID: memberID, FeatureName: "summarization", Credits: decimal.NewFromInt(40), UsageCount: 4},
+		{Granularity: model.UsageGranularityMonth, PeriodStart: march, UniversalID: universalID, ActorID: memberID, FeatureName: "translation", Credits: decimal.NewFromFloat(12.5), UsageCount: 1},
+	}, nil)
+
+	service := NewUsageService(repo, zap.NewNop())
+	service.now = func() time.Time { return time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC) }
+
+	report, err := service.GetUsageReport(context.Background(), UsageQuery{UniversalID: &universalID})
+	assert.NoError(t, err)
+	assert.Equal(t, "132.5", report.TotalCredits.String())
+	assert.Equal(t, int64(13), report.TotalCount)
+	if assert.Len(t, report.Features, 2) {
+		assert.Equal(t, "summarization", report.Features[0].FeatureName)
+		assert.Equal(t, "120", report.Features[0].Credits.String())
+		assert.Equal(t, int64(12), report.Features[0].UsageCount)
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteUsageCSV(&buf, report))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, strings.Join(UsageCSVHeader, ","), lines[0])
+	assert.Equal(t, "2026-03-01,month,"+universalID.String()+","+memberID.String()+",,translation,12.50,1", lines[3])
+}
@@ -25,6 +25,11 @@ func (m *MockWorkspaceVerificationRepository) VerifyWorkspaceMembership(ctx cont
 	return args.Get(0).(*domainRepo.WorkspaceMember), args.Error(1)
 }
 
+func (m *MockWorkspaceVerificationRepository) CountWorkspaceMembers(ctx context.Context, workspaceID string) (int, error) {
+	args := m.Called(ctx, workspaceID)
+	return args.Int(0), args.Error(1)
+}
+
 func TestWorkspaceVerificationService_VerifyUserWorkspaceAccess(t *testing.T) {
 	logger := zap.NewNop()
 	
@@ -0,0 +1,15 @@
+-- Migration: Add cash receipt (현금영수증) columns to payments
+
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS cash_receipt_type VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS cash_receipt_identifier VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS cash_receipt_key VARCHAR(200),
+    ADD COLUMN IF NOT EXISTS cash_receipt_issue_number VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS cash_receipt_url TEXT,
+    ADD COLUMN IF NOT EXISTS cash_receipt_status VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS cash_receipt_issued_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS cash_receipt_canceled_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_payments_cash_receipt_status
+    ON payments(cash_receipt_status)
+    WHERE cash_receipt_status IS NOT NULL;
@@ -0,0 +1,44 @@
+-- Coupons and promotion codes
+CREATE TABLE IF NOT EXISTS coupons (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    code VARCHAR(100) NOT NULL UNIQUE,
+    name VARCHAR(200) NOT NULL,
+    discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('percentage', 'fixed_amount', 'bonus_credits')),
+    percent_off DECIMAL(5,2) DEFAULT 0 CHECK (percent_off >= 0 AND percent_off <= 100),
+    amount_off BIGINT DEFAULT 0 CHECK (amount_off >= 0),
+    currency VARCHAR(10),
+    bonus_credits INTEGER DEFAULT 0 CHECK (bonus_credits >= 0),
+    max_redemptions INTEGER,
+    max_redemptions_per_user INTEGER,
+    times_redeemed INTEGER DEFAULT 0,
+    valid_from TIMESTAMP,
+    valid_until TIMESTAMP,
+    plan_ids JSONB DEFAULT '[]',
+    service_provider VARCHAR(50),
+    stripe_coupon_id VARCHAR(100),
+    stripe_promotion_code_id VARCHAR(100),
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_coupons_active ON coupons(is_active) WHERE is_active = TRUE;
+
+-- One redemption per order; reserved at checkout, redeemed on payment, released on failure
+CREATE TABLE IF NOT EXISTS coupon_redemptions (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    coupon_id BIGINT NOT NULL REFERENCES coupons(id),
+    universal_id UUID NOT NULL,
+    order_id VARCHAR(100) NOT NULL UNIQUE,
+    plan_id VARCHAR(100),
+    original_amount BIGINT NOT NULL,
+    discount_amount BIGINT NOT NULL DEFAULT 0,
+    bonus_credits INTEGER DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'reserved' CHECK (status IN ('reserved', 'redeemed', 'released')),
+    redeemed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_coupon_redemptions_coupon_id ON coupon_redemptions(coupon_id);
+CREATE INDEX idx_coupon_redemptions_user ON coupon_redemptions(coupon_id, universal_id) WHERE status <> 'released';
@@ -0,0 +1,39 @@
+-- Migration: Free trials for subscription plans
+
+ALTER TABLE payment_plans
+    ADD COLUMN IF NOT EXISTS trial_period_days INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS trial_credits INTEGER NOT NULL DEFAULT 0;
+
+-- Card fingerprint used to detect trial abuse across accounts
+ALTER TABLE billing_keys
+    ADD COLUMN IF NOT EXISTS card_fingerprint VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_billing_keys_card_fingerprint ON billing_keys(card_fingerprint);
+
+CREATE TABLE IF NOT EXISTS subscription_trials (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    universal_id UUID NOT NULL,
+    email VARCHAR(255),
+    plan_id VARCHAR(100) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    service_provider VARCHAR(50),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'converted', 'canceled', 'failed')),
+    billing_key_id BIGINT REFERENCES billing_keys(id),
+    card_fingerprint VARCHAR(64),
+    provider_customer_id VARCHAR(100),
+    provider_subscription_id VARCHAR(100) UNIQUE,
+    trial_credits INTEGER DEFAULT 0,
+    credits_granted_at TIMESTAMP,
+    started_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    will_end_notified_at TIMESTAMP,
+    converted_at TIMESTAMP,
+    conversion_order_id VARCHAR(100),
+    failure_reason TEXT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX idx_subscription_trials_universal_id ON subscription_trials(universal_id);
+CREATE INDEX idx_subscription_trials_card_fingerprint ON subscription_trials(card_fingerprint) WHERE card_fingerprint IS NOT NULL;
+CREATE INDEX idx_subscription_trials_due ON subscription_trials(provider, ends_at) WHERE status = 'active';
@@ -0,0 +1,11 @@
+-- Migration: Seat-based workspace subscriptions
+
+ALTER TABLE payment_plans
+    ADD COLUMN IF NOT EXISTS seat_based BOOLEAN NOT NULL DEFAULT false;
+
+-- Paid seat quantity and the workspace the seats belong to
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    ADD COLUMN IF NOT EXISTS workspace_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_workspace_id ON subscriptions(workspace_id) WHERE workspace_id IS NOT NULL;
@@ -0,0 +1,29 @@
+-- Migration: Credit usage metering
+
+-- Service provider and acting workspace member of each credit usage
+ALTER TABLE credit_transactions
+    ADD COLUMN IF NOT EXISTS service_provider VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS actor_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_credit_transactions_usage_created
+    ON credit_transactions(created_at) WHERE transaction_type = 'credit_usage';
+
+-- Usage per feature, period (day/week/month in KST), universal ID and acting user.
+-- Rows are recomputed from credit_transactions by cmd/rollup-usage.
+CREATE TABLE IF NOT EXISTS credit_usage_rollups (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    granularity VARCHAR(10) NOT NULL CHECK (granularity IN ('day', 'week', 'month')),
+    period_start DATE NOT NULL,
+    universal_id UUID NOT NULL,
+    actor_id UUID NOT NULL,
+    service_provider VARCHAR(50) NOT NULL DEFAULT '',
+    feature_name VARCHAR(100) NOT NULL DEFAULT '',
+    credits DECIMAL(15,2) NOT NULL DEFAULT 0,
+    usage_count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_credit_usage_rollups_key
+    ON credit_usage_rollups(granularity, period_start, universal_id, actor_id, service_provider, feature_name);
+CREATE INDEX IF NOT EXISTS idx_credit_usage_rollups_universal
+    ON credit_usage_rollups(universal_id, granularity, period_start);
@@ -0,0 +1,21 @@
+-- Migration: Feature price catalog for credit consumption
+
+CREATE TABLE IF NOT EXISTS feature_prices (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    service_provider VARCHAR(50) NOT NULL DEFAULT '',
+    feature_name VARCHAR(100) NOT NULL,
+    plan_tier VARCHAR(50) NOT NULL DEFAULT '',
+    unit VARCHAR(50) NOT NULL DEFAULT '',
+    unit_size BIGINT NOT NULL DEFAULT 1 CHECK (unit_size > 0),
+    base_credits DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (base_credits >= 0),
+    unit_credits DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (unit_credits >= 0),
+    min_credits DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (min_credits >= 0),
+    version INTEGER NOT NULL DEFAULT 1,
+    effective_from TIMESTAMP NOT NULL,
+    effective_to TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (service_provider, feature_name, plan_tier, version),
+    CHECK (effective_to IS NULL OR effective_to > effective_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_feature_prices_lookup ON feature_prices(feature_name, service_provider, plan_tier);
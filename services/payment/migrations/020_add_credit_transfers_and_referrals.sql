@@ -0,0 +1,41 @@
+-- Migration: Credit transfers between users and the referral program
+
+-- ALTER TYPE ... ADD VALUE cannot run inside a transaction block on older PostgreSQL versions
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'transfer_out';
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'transfer_in';
+
+-- Both ledger entries of a transfer share its transfer_id
+ALTER TABLE credit_transactions
+    ADD COLUMN IF NOT EXISTS transfer_id UUID,
+    ADD COLUMN IF NOT EXISTS counterparty_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_credit_transactions_transfer ON credit_transactions(transfer_id) WHERE transfer_id IS NOT NULL;
+
+-- Rolling daily transfer limits sum a user's recent transfers
+CREATE INDEX IF NOT EXISTS idx_credit_transactions_transfers_recent
+    ON credit_transactions(universal_id, transaction_type, created_at)
+    WHERE transfer_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS referral_codes (
+    universal_id UUID PRIMARY KEY,
+    code VARCHAR(20) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- A user can be referred once; rewards are granted after the referee's first payment
+CREATE TABLE IF NOT EXISTS referrals (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    referrer_id UUID NOT NULL,
+    referee_id UUID NOT NULL UNIQUE,
+    code VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'rewarded', 'rejected')),
+    referrer_credits INTEGER DEFAULT 0,
+    referee_credits INTEGER DEFAULT 0,
+    payment_ref VARCHAR(200),
+    rewarded_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    CHECK (referrer_id <> referee_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_referrals_referrer ON referrals(referrer_id, status);
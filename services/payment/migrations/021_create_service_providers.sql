@@ -0,0 +1,19 @@
+-- Migration: Service provider registry for multi-tenant deployments
+
+-- Credentials are an AES-GCM encrypted JSON document; blank values fall back to the deployment config
+CREATE TABLE IF NOT EXISTS service_providers (
+    code VARCHAR(50) PRIMARY KEY,
+    display_name VARCHAR(200) NOT NULL,
+    client_urls JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    encrypted_credentials TEXT,
+    credentials_iv VARCHAR(100),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- A blank service_provider means every service provider offers the plan
+ALTER TABLE payment_plans
+    ADD COLUMN IF NOT EXISTS service_provider VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_payment_plans_service_provider ON payment_plans(service_provider) WHERE service_provider <> '';
@@ -0,0 +1,17 @@
+-- Migration: Multi-currency price points of payment plans
+
+-- Each price point is checked out with its own provider price ID; the plan row keeps its original price
+CREATE TABLE IF NOT EXISTS plan_prices (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    plan_id BIGINT NOT NULL REFERENCES payment_plans(id),
+    provider_price_id VARCHAR(100) NOT NULL UNIQUE,
+    pg_provider VARCHAR(50) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    amount BIGINT NOT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_plan_prices_plan ON plan_prices(plan_id) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_plan_prices_currency ON plan_prices(currency, pg_provider) WHERE is_active;
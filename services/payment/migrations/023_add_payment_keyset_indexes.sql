@@ -0,0 +1,15 @@
+-- Migration: Keyset pagination and provider filtering of payment and credit transaction lists
+
+-- Payment gateway that processed each payment
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS pg_provider VARCHAR(50);
+
+-- Stripe payments carry a payment intent; the remaining payments were made through Toss
+UPDATE payments
+SET pg_provider = CASE WHEN provider_payment_intent_id IS NOT NULL THEN 'stripe' ELSE 'toss' END
+WHERE pg_provider IS NULL;
+
+-- Lists are paged by (sort key, id) per user
+CREATE INDEX IF NOT EXISTS idx_payments_universal_created_id ON payments(universal_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_payments_universal_amount_id ON payments(universal_id, amount_cents, id);
+CREATE INDEX IF NOT EXISTS idx_credit_transactions_universal_created_id ON credit_transactions(universal_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_credit_transactions_universal_amount_id ON credit_transactions(universal_id, amount, id);
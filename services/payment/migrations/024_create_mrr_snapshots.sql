@@ -0,0 +1,21 @@
+-- Migration: Nightly revenue snapshots for MRR, churn and cohort analytics
+
+-- MRR of each subscription active at the end of a day (KST), written by cmd/snapshot-revenue.
+-- Reports read these rows instead of subscriptions, so past figures do not change when
+-- subscriptions are edited.
+CREATE TABLE IF NOT EXISTS mrr_snapshots (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    snapshot_date DATE NOT NULL,
+    subscription_id BIGINT NOT NULL,
+    universal_id UUID NOT NULL,
+    plan_id VARCHAR(100) NOT NULL DEFAULT '',
+    currency VARCHAR(3) NOT NULL,
+    mrr BIGINT NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mrr_snapshots_key ON mrr_snapshots(snapshot_date, subscription_id);
+
+-- Cohort reports group completed payments by user and month
+CREATE INDEX IF NOT EXISTS idx_payments_status_universal_created ON payments(status, universal_id, created_at);
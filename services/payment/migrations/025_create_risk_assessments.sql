@@ -0,0 +1,25 @@
+-- Migration: Risk assessments of checkout requests
+
+-- One row per risk decision on a checkout, subscription or card registration. Rows are the audit
+-- trail of decisions and also the attempts that velocity rules count per user, card and IP address.
+CREATE TABLE IF NOT EXISTS risk_assessments (
+    id BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    universal_id UUID NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    card_fingerprint VARCHAR(64) NOT NULL DEFAULT '',
+    country_code VARCHAR(2) NOT NULL DEFAULT '',
+    card_country VARCHAR(2) NOT NULL DEFAULT '',
+    amount BIGINT NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT '',
+    score INTEGER NOT NULL,
+    decision VARCHAR(20) NOT NULL,
+    matched_rules JSONB NOT NULL DEFAULT '{}',
+    signals JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_assessments_user_created ON risk_assessments(universal_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_risk_assessments_ip_created ON risk_assessments(ip_address, created_at) WHERE ip_address <> '';
+CREATE INDEX IF NOT EXISTS idx_risk_assessments_card_created ON risk_assessments(card_fingerprint, created_at) WHERE card_fingerprint <> '';
+CREATE INDEX IF NOT EXISTS idx_risk_assessments_decision_created ON risk_assessments(decision, created_at);
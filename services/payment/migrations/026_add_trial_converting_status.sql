@@ -0,0 +1,11 @@
+-- Migration: Claim Toss trials before charging them
+
+-- A trial is moved to 'converting' before its card is charged so overlapping conversion runs
+-- cannot charge it twice. Trials left 'converting' by an interrupted run are retried.
+ALTER TABLE subscription_trials DROP CONSTRAINT IF EXISTS subscription_trials_status_check;
+ALTER TABLE subscription_trials
+    ADD CONSTRAINT subscription_trials_status_check
+    CHECK (status IN ('active', 'converting', 'converted', 'canceled', 'failed'));
+
+DROP INDEX IF EXISTS idx_subscription_trials_due;
+CREATE INDEX idx_subscription_trials_due ON subscription_trials(provider, ends_at) WHERE status IN ('active', 'converting');
@@ -0,0 +1,5 @@
+-- Migration: Record the price a subscription is billed at
+
+-- plan_id holds the product; a product can have several prices (e.g. monthly and yearly)
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS price_id VARCHAR(100);
@@ -0,0 +1,6 @@
+-- Migration: Record the service provider whose Toss account issued a billing key
+
+-- A billing key can only be charged through the Toss account that issued it; existing keys
+-- were issued with the deployment's key and keep a blank value for the default provider
+ALTER TABLE billing_keys
+    ADD COLUMN IF NOT EXISTS service_provider VARCHAR(50) NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+-- Migration: Record the issuer country of registered cards
+
+-- Risk checks compare it with the country of the request; Toss only reveals whether the issuer is
+-- a Korean card company, so overseas cards and keys issued before this migration stay blank
+ALTER TABLE billing_keys
+    ADD COLUMN IF NOT EXISTS card_country VARCHAR(2) NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+-- Migration: Record how much of a cash receipt was cancelled
+
+-- Partial refunds reduce the receipt at Toss without closing it; the running total keeps later
+-- cancellations and reconciliation from assuming the full original amount
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS cash_receipt_canceled_amount BIGINT NOT NULL DEFAULT 0;
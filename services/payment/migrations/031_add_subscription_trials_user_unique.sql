@@ -0,0 +1,7 @@
+-- Migration: One trial per user even when trials are started concurrently
+
+-- The eligibility check and the insert are separate statements, so two simultaneous starts could
+-- both pass the check. A trial that is running, being converted or converted holds the user's slot.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subscription_trials_user_once
+    ON subscription_trials(universal_id)
+    WHERE status IN ('active', 'converting', 'converted');